@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"text/tabwriter"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/config"
+	"github.com/madalinpopa/gocost-web/internal/infrastructure/storage/sqlite"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var (
+	inviteUses    int
+	inviteExpires time.Duration
+)
+
+var inviteCmd = &cobra.Command{
+	Use:   "invite",
+	Short: "Manage the invite codes to register with",
+	Long: `Manage the invite codes to register with while REGISTRATION is set to invite.
+Only a hash of each code is stored, so a code is shown once, when it is created.`,
+}
+
+var inviteCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an invite code",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withInvites(func(invites usecase.InviteUseCase) error {
+			invite, err := invites.Create(cmd.Context(), &usecase.CreateInviteRequest{
+				MaxUses:   inviteUses,
+				ExpiresIn: inviteExpires,
+			})
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "Invite code: %s\n", invite.Code)
+			fmt.Fprintf(out, "Link path:   /register?%s\n", url.Values{"invite": {invite.Code}}.Encode())
+			fmt.Fprintf(out, "Uses:        %d\n", invite.MaxUses)
+			fmt.Fprintf(out, "Expires:     %s\n", invite.ExpiresAt.Local().Format(time.DateTime))
+			if conf.Registration != config.RegistrationInvite {
+				fmt.Fprintf(out, "\nRegistration is %s: set REGISTRATION=invite for invite codes to be asked for.\n", conf.Registration)
+			}
+			return nil
+		})
+	},
+}
+
+var inviteListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the invite codes",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withInvites(func(invites usecase.InviteUseCase) error {
+			list, err := invites.List(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return printInvites(cmd.OutOrStdout(), list, time.Now())
+		})
+	},
+}
+
+var inviteRevokeCmd = &cobra.Command{
+	Use:   "revoke ID",
+	Short: "Revoke an invite code",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withInvites(func(invites usecase.InviteUseCase) error {
+			if err := invites.Revoke(cmd.Context(), args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Invite %s revoked.\n", args[0])
+			return nil
+		})
+	},
+}
+
+func init() {
+	inviteCreateCmd.Flags().IntVar(&inviteUses, "uses", 1, "number of users the code registers")
+	inviteCreateCmd.Flags().DurationVar(&inviteExpires, "expires", 7*24*time.Hour, "how long the code is valid for")
+
+	inviteCmd.AddCommand(inviteCreateCmd)
+	inviteCmd.AddCommand(inviteListCmd)
+	inviteCmd.AddCommand(inviteRevokeCmd)
+}
+
+// withInvites runs fn with the invite use case on the database of --dsn.
+func withInvites(fn func(usecase.InviteUseCase) error) error {
+	db, err := sqlite.NewDatabaseConnection(context.Background(), conf.Dsn)
+	if err != nil {
+		logger.Error("failed to get database connection", "err", err)
+		return err
+	}
+
+	defer func(db *sql.DB) {
+		err := db.Close()
+		if err != nil {
+			logger.Error("Failed to close database", "err", err)
+		}
+	}(db)
+
+	return fn(usecase.NewInviteUseCase(sqlite.NewUnitOfWork(db), logger.With(slog.String("command", "invite"))))
+}
+
+func printInvites(out io.Writer, invites []usecase.InviteResponse, now time.Time) error {
+	if len(invites) == 0 {
+		_, err := fmt.Fprintln(out, "No invite codes.")
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tUSES\tCREATED\tEXPIRES\tSTATUS")
+	for _, invite := range invites {
+		status := "active"
+		switch {
+		case invite.Uses >= invite.MaxUses:
+			status = "used up"
+		case !now.Before(invite.ExpiresAt):
+			status = "expired"
+		}
+		fmt.Fprintf(w, "%s\t%d/%d\t%s\t%s\t%s\n",
+			invite.ID,
+			invite.Uses, invite.MaxUses,
+			invite.CreatedAt.Local().Format(time.DateTime),
+			invite.ExpiresAt.Local().Format(time.DateTime),
+			status,
+		)
+	}
+	return w.Flush()
+}
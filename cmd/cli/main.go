@@ -44,6 +44,7 @@ func init() {
 
 	rootCmd.AddCommand(migrateCmd)
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(inviteCmd)
 
 	logger = slog.New(slog.NewTextHandler(os.Stdout, nil))
 }
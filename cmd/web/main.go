@@ -15,11 +15,15 @@ import (
 
 	"github.com/go-playground/form/v4"
 	"github.com/madalinpopa/gocost-web/internal/config"
+	"github.com/madalinpopa/gocost-web/internal/infrastructure/mail"
 	"github.com/madalinpopa/gocost-web/internal/infrastructure/storage/sqlite"
 	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
 	"github.com/madalinpopa/gocost-web/internal/interfaces/web/handler"
 	"github.com/madalinpopa/gocost-web/internal/interfaces/web/respond"
 	"github.com/madalinpopa/gocost-web/internal/interfaces/web/router"
+	"github.com/madalinpopa/gocost-web/internal/platform/captcha"
+	"github.com/madalinpopa/gocost-web/internal/platform/ratelimit"
+	"github.com/madalinpopa/gocost-web/internal/platform/security"
 	"github.com/madalinpopa/gocost-web/internal/usecase"
 )
 
@@ -31,13 +35,12 @@ var (
 	version string = "dev"
 )
 
-func buildHTTPHandler(db *sql.DB, logger *slog.Logger, conf *config.Config) http.Handler {
+func buildHTTPHandler(db *sql.DB, logger *slog.Logger, conf *config.Config, signer security.Signer) http.Handler {
 	templateRenderer := web.NewTemplate(logger, conf)
 	sessionManager := web.NewSession(db, conf)
 	errHandler := respond.NewErrorHandler(logger)
 	notify := respond.NewNotify(logger)
 	htmx := respond.NewHtmx(errHandler)
-	middleware := web.NewMiddleware(logger, conf, sessionManager, errHandler)
 	formDecoder := form.NewDecoder()
 
 	formDecoder.RegisterCustomTypeFunc(func(vals []string) (any, error) {
@@ -45,6 +48,17 @@ func buildHTTPHandler(db *sql.DB, logger *slog.Logger, conf *config.Config) http
 	}, time.Time{})
 
 	unitOfWork := sqlite.NewUnitOfWork(db)
+	mailer := mail.NewBackgroundMailer(mail.New(conf.Mail, logger), logger)
+
+	var limiter *ratelimit.Limiter
+	if conf.RateLimit.Enabled {
+		limiter = ratelimit.New(ratelimit.NewMemoryStore(), conf.RateLimit.LoginLockout)
+	}
+
+	var challenges *captcha.Captcha
+	if conf.CaptchaDifficulty > 0 {
+		challenges = captcha.New(signer, conf.CaptchaDifficulty)
+	}
 
 	handlerContext := handler.HandlerContext{
 		Config:   conf,
@@ -55,11 +69,17 @@ func buildHTTPHandler(db *sql.DB, logger *slog.Logger, conf *config.Config) http
 		Htmx:     htmx,
 		Notify:   notify,
 		Session:  sessionManager,
+		Limiter:  limiter,
+		Captcha:  challenges,
 	}
 
-	useCases := usecase.New(unitOfWork, logger)
+	useCases := usecase.New(unitOfWork, logger, mailer, signer, usecase.RegistrationMode(conf.Registration))
 	webHandlers := handler.New(handlerContext, useCases)
 
+	middleware := web.NewMiddleware(logger, conf, sessionManager, errHandler).
+		WithSessionTracker(handler.NewSessionTracker(handlerContext, useCases.SessionUseCase)).
+		WithRateLimiter(limiter)
+
 	httpRouter := router.New(middleware)
 	httpRouter.RegisterRoutes(webHandlers)
 	return httpRouter.Handlers()
@@ -105,7 +125,12 @@ func run() error {
 		}
 	}()
 
-	httpHandler := buildHTTPHandler(db, logger, conf)
+	signer, err := newSigner(conf, logger)
+	if err != nil {
+		return err
+	}
+
+	httpHandler := buildHTTPHandler(db, logger, conf, signer)
 	server := newHTTPServer(conf, logger, httpHandler)
 
 	logger.Info("Environment", "mode", conf.GetEnvironment())
@@ -137,6 +162,21 @@ func loadConfig(logger *slog.Logger) (*config.Config, error) {
 	return conf, nil
 }
 
+// newSigner signs with the configured secret key, or with a random one that
+// lasts until the server stops.
+func newSigner(conf *config.Config, logger *slog.Logger) (security.Signer, error) {
+	if conf.SecretKey != "" {
+		return security.NewSigner([]byte(conf.SecretKey)), nil
+	}
+
+	logger.Warn("SECRET_KEY is not set; emailed verification links stop working when the server restarts")
+	key, err := security.NewRandomKey()
+	if err != nil {
+		return security.Signer{}, err
+	}
+	return security.NewSigner(key), nil
+}
+
 func newHTTPServer(conf *config.Config, logger *slog.Logger, handler http.Handler) *http.Server {
 	return &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", conf.Addr, conf.Port),
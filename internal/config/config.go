@@ -3,14 +3,67 @@ package config
 import (
 	"fmt"
 	"log/slog"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/madalinpopa/gocost-web/internal/platform/ratelimit"
 	"github.com/spf13/viper"
 )
 
 const defaultCurrency = "USD"
 
+// Mail transports, chosen with MAIL_TRANSPORT.
+const (
+	MailTransportSMTP = "smtp"
+	MailTransportFile = "file"
+	MailTransportLog  = "log"
+)
+
+// What happens to users who have not verified their email, chosen with
+// EMAIL_VERIFICATION.
+const (
+	// EmailVerificationBanner lets them in and reminds them with a banner.
+	EmailVerificationBanner = "banner"
+	// EmailVerificationBlock keeps them from logging in.
+	EmailVerificationBlock = "block"
+)
+
+// Who can register, chosen with REGISTRATION. DISABLE_REGISTRATION closes
+// registration whatever the mode.
+const (
+	// RegistrationOpen lets anyone register.
+	RegistrationOpen = "open"
+	// RegistrationInvite asks for an invite code to register.
+	RegistrationInvite = "invite"
+	// RegistrationClosed hides the register page.
+	RegistrationClosed = "closed"
+)
+
+const (
+	defaultCaptchaDifficulty = 16
+	maxCaptchaDifficulty     = 32
+)
+
+const (
+	defaultMailDir  = "mail"
+	defaultSMTPPort = 587
+)
+
+const (
+	defaultSessionLifetime    = time.Hour
+	defaultSessionIdleTimeout = 20 * time.Minute
+	defaultRememberMeLifetime = 30 * 24 * time.Hour
+)
+
+var (
+	defaultLoginRateLimit         = ratelimit.Policy{Requests: 10, Window: time.Minute}
+	defaultRegisterRateLimit      = ratelimit.Policy{Requests: 5, Window: time.Hour}
+	defaultPasswordResetRateLimit = ratelimit.Policy{Requests: 5, Window: time.Hour}
+	defaultLoginLockout           = ratelimit.Lockout{Threshold: 5, Duration: time.Minute, MaxDuration: time.Hour}
+)
+
 type Config struct {
 	// Version specifies the application version
 	Version string
@@ -36,6 +89,35 @@ type Config struct {
 	// Currency specifies the currency symbol
 	Currency string
 
+	// BaseURL specifies the address the application is reached at, used in
+	// links sent by email
+	BaseURL string
+
+	// Mail specifies how emails are delivered
+	Mail MailConfig
+
+	// SecretKey specifies the key links sent by email are signed with
+	SecretKey string
+
+	// EmailVerification specifies whether users who have not verified their
+	// email can log in
+	EmailVerification string
+
+	// Registration specifies who can register: anyone, people with an invite
+	// code or no one
+	Registration string
+
+	// Session specifies how long users stay logged in
+	Session SessionConfig
+
+	// RateLimit specifies how often clients can try to log in, register and
+	// reset passwords
+	RateLimit RateLimitConfig
+
+	// CaptchaDifficulty specifies how many leading zero bits the proof-of-work
+	// challenges of public forms ask for; zero turns them off
+	CaptchaDifficulty int
+
 	// logger is used for config-level logging.
 	logger *slog.Logger
 
@@ -43,6 +125,46 @@ type Config struct {
 	environment string
 }
 
+// MailConfig specifies the transport emails are delivered through. SMTP
+// sends them to a mail server, file writes them to Dir and log prints them to
+// the application log.
+type MailConfig struct {
+	Transport    string
+	From         string
+	Dir          string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+}
+
+// SessionConfig specifies how long sessions last. A session ends after
+// Lifetime, or once unused for IdleTimeout, unless the user asked to be
+// remembered: then it lasts RememberMeLifetime. Sensitive actions ask for the
+// password again when it was last given more than ReauthAfter ago; zero never
+// asks. With NewDeviceAlerts users are emailed when they log in from a
+// browser they never logged in from before.
+type SessionConfig struct {
+	Lifetime           time.Duration
+	IdleTimeout        time.Duration
+	RememberMeLifetime time.Duration
+	ReauthAfter        time.Duration
+	NewDeviceAlerts    bool
+}
+
+// RateLimitConfig specifies how often the authentication pages can be used.
+// Each policy limits the requests from every IP address and for every
+// account. After LoginLockout.Threshold failed logins in a row, logging in to
+// the account from the same IP address is locked out for a while, longer at
+// each further failure.
+type RateLimitConfig struct {
+	Enabled       bool
+	Login         ratelimit.Policy
+	Register      ratelimit.Policy
+	PasswordReset ratelimit.Policy
+	LoginLockout  ratelimit.Lockout
+}
+
 func New() *Config {
 	return NewWithLogger(nil)
 }
@@ -51,7 +173,20 @@ func NewWithLogger(logger *slog.Logger) *Config {
 	return &Config{
 		AllowedHosts:   make([]string, 0),
 		TrustedProxies: make([]string, 0),
-		logger:         logger,
+		Session: SessionConfig{
+			Lifetime:           defaultSessionLifetime,
+			IdleTimeout:        defaultSessionIdleTimeout,
+			RememberMeLifetime: defaultRememberMeLifetime,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:       true,
+			Login:         defaultLoginRateLimit,
+			Register:      defaultRegisterRateLimit,
+			PasswordReset: defaultPasswordResetRateLimit,
+			LoginLockout:  defaultLoginLockout,
+		},
+		CaptchaDifficulty: defaultCaptchaDifficulty,
+		logger:            logger,
 	}
 }
 
@@ -95,9 +230,192 @@ func (c *Config) LoadEnvironments() error {
 
 	c.Currency = c.currencyCodeOrDefault(viper.GetString("CURRENCY"))
 
+	c.BaseURL = strings.TrimRight(viper.GetString("BASE_URL"), "/")
+	if c.BaseURL == "" {
+		c.BaseURL = c.defaultBaseURL()
+	}
+
+	c.SecretKey = viper.GetString("SECRET_KEY")
+
+	c.EmailVerification = strings.ToLower(viper.GetString("EMAIL_VERIFICATION"))
+	switch c.EmailVerification {
+	case "":
+		c.EmailVerification = EmailVerificationBanner
+	case EmailVerificationBanner, EmailVerificationBlock:
+	default:
+		return fmt.Errorf("env EMAIL_VERIFICATION must be %s or %s", EmailVerificationBanner, EmailVerificationBlock)
+	}
+
+	c.Registration = strings.ToLower(viper.GetString("REGISTRATION"))
+	switch c.Registration {
+	case "":
+		c.Registration = RegistrationOpen
+	case RegistrationOpen, RegistrationInvite, RegistrationClosed:
+	default:
+		return fmt.Errorf("env REGISTRATION must be %s, %s or %s", RegistrationOpen, RegistrationInvite, RegistrationClosed)
+	}
+	if viper.GetBool("DISABLE_REGISTRATION") {
+		c.Registration = RegistrationClosed
+	}
+
+	if err := c.loadSession(); err != nil {
+		return err
+	}
+
+	if err := c.loadRateLimit(); err != nil {
+		return err
+	}
+
+	if value := viper.GetString("CAPTCHA_DIFFICULTY"); value != "" {
+		difficulty, err := strconv.Atoi(value)
+		if err != nil || difficulty < 0 || difficulty > maxCaptchaDifficulty {
+			return fmt.Errorf("env CAPTCHA_DIFFICULTY must be a number of bits from 0 to %d", maxCaptchaDifficulty)
+		}
+		c.CaptchaDifficulty = difficulty
+	}
+
+	return c.loadMail()
+}
+
+func (c *Config) loadSession() error {
+	durations := []struct {
+		env   string
+		value *time.Duration
+	}{
+		{"SESSION_LIFETIME", &c.Session.Lifetime},
+		{"SESSION_IDLE_TIMEOUT", &c.Session.IdleTimeout},
+		{"REMEMBER_ME_LIFETIME", &c.Session.RememberMeLifetime},
+		{"REAUTH_AFTER", &c.Session.ReauthAfter},
+	}
+
+	for _, d := range durations {
+		value := viper.GetString(d.env)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed < 0 {
+			return fmt.Errorf("env %s must be a duration such as 30m or 720h", d.env)
+		}
+		*d.value = parsed
+	}
+
+	if c.Session.Lifetime == 0 || c.Session.RememberMeLifetime == 0 {
+		return fmt.Errorf("env SESSION_LIFETIME and REMEMBER_ME_LIFETIME cannot be zero")
+	}
+
+	c.Session.NewDeviceAlerts = viper.GetBool("NEW_DEVICE_ALERTS")
+
+	return nil
+}
+
+func (c *Config) loadRateLimit() error {
+	if viper.IsSet("RATE_LIMIT_ENABLED") {
+		c.RateLimit.Enabled = viper.GetBool("RATE_LIMIT_ENABLED")
+	}
+
+	policies := []struct {
+		env   string
+		value *ratelimit.Policy
+	}{
+		{"RATE_LIMIT_LOGIN", &c.RateLimit.Login},
+		{"RATE_LIMIT_REGISTER", &c.RateLimit.Register},
+		{"RATE_LIMIT_PASSWORD_RESET", &c.RateLimit.PasswordReset},
+	}
+
+	for _, p := range policies {
+		value := viper.GetString(p.env)
+		if value == "" {
+			continue
+		}
+		parsed, err := ratelimit.ParsePolicy(value)
+		if err != nil {
+			return fmt.Errorf("env %s must be requests per duration such as 10/1m", p.env)
+		}
+		*p.value = parsed
+	}
+
+	if value := viper.GetString("LOGIN_LOCKOUT_THRESHOLD"); value != "" {
+		threshold, err := strconv.Atoi(value)
+		if err != nil || threshold < 0 {
+			return fmt.Errorf("env LOGIN_LOCKOUT_THRESHOLD must be a number of failed logins")
+		}
+		c.RateLimit.LoginLockout.Threshold = threshold
+	}
+
+	durations := []struct {
+		env   string
+		value *time.Duration
+	}{
+		{"LOGIN_LOCKOUT_DURATION", &c.RateLimit.LoginLockout.Duration},
+		{"LOGIN_LOCKOUT_MAX_DURATION", &c.RateLimit.LoginLockout.MaxDuration},
+	}
+
+	for _, d := range durations {
+		value := viper.GetString(d.env)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			return fmt.Errorf("env %s must be a duration such as 1m or 1h", d.env)
+		}
+		*d.value = parsed
+	}
+
+	if c.RateLimit.LoginLockout.MaxDuration < c.RateLimit.LoginLockout.Duration {
+		c.RateLimit.LoginLockout.MaxDuration = c.RateLimit.LoginLockout.Duration
+	}
+
 	return nil
 }
 
+func (c *Config) loadMail() error {
+	c.Mail = MailConfig{
+		Transport:    strings.ToLower(viper.GetString("MAIL_TRANSPORT")),
+		From:         viper.GetString("MAIL_FROM"),
+		Dir:          viper.GetString("MAIL_DIR"),
+		SMTPHost:     viper.GetString("SMTP_HOST"),
+		SMTPPort:     viper.GetInt("SMTP_PORT"),
+		SMTPUsername: viper.GetString("SMTP_USERNAME"),
+		SMTPPassword: viper.GetString("SMTP_PASSWORD"),
+	}
+
+	if c.Mail.Transport == "" {
+		c.Mail.Transport = MailTransportLog
+	}
+	if c.Mail.From == "" {
+		c.Mail.From = "gocost@" + c.Domain
+	}
+	if c.Mail.Dir == "" {
+		c.Mail.Dir = defaultMailDir
+	}
+	if c.Mail.SMTPPort == 0 {
+		c.Mail.SMTPPort = defaultSMTPPort
+	}
+
+	switch c.Mail.Transport {
+	case MailTransportSMTP:
+		if c.Mail.SMTPHost == "" {
+			return fmt.Errorf("env SMTP_HOST is not set")
+		}
+	case MailTransportFile, MailTransportLog:
+	default:
+		return fmt.Errorf("env MAIL_TRANSPORT must be one of %s, %s or %s", MailTransportSMTP, MailTransportFile, MailTransportLog)
+	}
+
+	return nil
+}
+
+// defaultBaseURL is the domain over HTTPS in production, and the domain on
+// the port the server listens on otherwise.
+func (c *Config) defaultBaseURL() string {
+	if c.environment == "production" {
+		return "https://" + c.Domain
+	}
+	return fmt.Sprintf("http://%s:%d", c.Domain, c.Port)
+}
+
 func (c *Config) getStringSliceFromEnv(key string) []string {
 	slice := viper.GetStringSlice(key)
 	// If the environment variable is passed as a comma-separated string (e.g. via Docker),
@@ -6,8 +6,10 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/madalinpopa/gocost-web/internal/config"
+	"github.com/madalinpopa/gocost-web/internal/platform/ratelimit"
 )
 
 func TestNew(t *testing.T) {
@@ -46,6 +48,28 @@ func TestConfig_LoadEnvironments(t *testing.T) {
 				AllowedHosts: []string{"localhost"},
 				Domain:       "gocost.ro",
 				Currency:     "USD",
+				BaseURL:      "http://gocost.ro:4000",
+				Mail: config.MailConfig{
+					Transport: config.MailTransportLog,
+					From:      "gocost@gocost.ro",
+					Dir:       "mail",
+					SMTPPort:  587,
+				},
+				EmailVerification: config.EmailVerificationBanner,
+				Registration:      config.RegistrationOpen,
+				Session: config.SessionConfig{
+					Lifetime:           time.Hour,
+					IdleTimeout:        20 * time.Minute,
+					RememberMeLifetime: 30 * 24 * time.Hour,
+				},
+				RateLimit: config.RateLimitConfig{
+					Enabled:       true,
+					Login:         ratelimit.Policy{Requests: 10, Window: time.Minute},
+					Register:      ratelimit.Policy{Requests: 5, Window: time.Hour},
+					PasswordReset: ratelimit.Policy{Requests: 5, Window: time.Hour},
+					LoginLockout:  ratelimit.Lockout{Threshold: 5, Duration: time.Minute, MaxDuration: time.Hour},
+				},
+				CaptchaDifficulty: 16,
 			},
 			wantErr: false,
 		},
@@ -62,6 +86,28 @@ func TestConfig_LoadEnvironments(t *testing.T) {
 				AllowedHosts: []string{"localhost", "example.com"},
 				Domain:       "gocost.ro",
 				Currency:     "USD",
+				BaseURL:      "http://gocost.ro:4000",
+				Mail: config.MailConfig{
+					Transport: config.MailTransportLog,
+					From:      "gocost@gocost.ro",
+					Dir:       "mail",
+					SMTPPort:  587,
+				},
+				EmailVerification: config.EmailVerificationBanner,
+				Registration:      config.RegistrationOpen,
+				Session: config.SessionConfig{
+					Lifetime:           time.Hour,
+					IdleTimeout:        20 * time.Minute,
+					RememberMeLifetime: 30 * 24 * time.Hour,
+				},
+				RateLimit: config.RateLimitConfig{
+					Enabled:       true,
+					Login:         ratelimit.Policy{Requests: 10, Window: time.Minute},
+					Register:      ratelimit.Policy{Requests: 5, Window: time.Hour},
+					PasswordReset: ratelimit.Policy{Requests: 5, Window: time.Hour},
+					LoginLockout:  ratelimit.Lockout{Threshold: 5, Duration: time.Minute, MaxDuration: time.Hour},
+				},
+				CaptchaDifficulty: 16,
 			},
 			wantErr: false,
 		},
@@ -78,6 +124,28 @@ func TestConfig_LoadEnvironments(t *testing.T) {
 				AllowedHosts: []string{"localhost", "example.com"},
 				Domain:       "gocost.ro",
 				Currency:     "USD",
+				BaseURL:      "http://gocost.ro:4000",
+				Mail: config.MailConfig{
+					Transport: config.MailTransportLog,
+					From:      "gocost@gocost.ro",
+					Dir:       "mail",
+					SMTPPort:  587,
+				},
+				EmailVerification: config.EmailVerificationBanner,
+				Registration:      config.RegistrationOpen,
+				Session: config.SessionConfig{
+					Lifetime:           time.Hour,
+					IdleTimeout:        20 * time.Minute,
+					RememberMeLifetime: 30 * 24 * time.Hour,
+				},
+				RateLimit: config.RateLimitConfig{
+					Enabled:       true,
+					Login:         ratelimit.Policy{Requests: 10, Window: time.Minute},
+					Register:      ratelimit.Policy{Requests: 5, Window: time.Hour},
+					PasswordReset: ratelimit.Policy{Requests: 5, Window: time.Hour},
+					LoginLockout:  ratelimit.Lockout{Threshold: 5, Duration: time.Minute, MaxDuration: time.Hour},
+				},
+				CaptchaDifficulty: 16,
 			},
 			wantErr: false,
 		},
@@ -125,9 +193,329 @@ func TestConfig_LoadEnvironments(t *testing.T) {
 				AllowedHosts: []string{"localhost"},
 				Domain:       "gocost.ro",
 				Currency:     "EUR",
+				BaseURL:      "http://gocost.ro:4000",
+				Mail: config.MailConfig{
+					Transport: config.MailTransportLog,
+					From:      "gocost@gocost.ro",
+					Dir:       "mail",
+					SMTPPort:  587,
+				},
+				EmailVerification: config.EmailVerificationBanner,
+				Registration:      config.RegistrationOpen,
+				Session: config.SessionConfig{
+					Lifetime:           time.Hour,
+					IdleTimeout:        20 * time.Minute,
+					RememberMeLifetime: 30 * 24 * time.Hour,
+				},
+				RateLimit: config.RateLimitConfig{
+					Enabled:       true,
+					Login:         ratelimit.Policy{Requests: 10, Window: time.Minute},
+					Register:      ratelimit.Policy{Requests: 5, Window: time.Hour},
+					PasswordReset: ratelimit.Policy{Requests: 5, Window: time.Hour},
+					LoginLockout:  ratelimit.Lockout{Threshold: 5, Duration: time.Minute, MaxDuration: time.Hour},
+				},
+				CaptchaDifficulty: 16,
 			},
 			wantErr: false,
 		},
+		{
+			name: "Mail, signing and verification settings",
+			envVars: map[string]string{
+				"ALLOWED_HOSTS":      "localhost",
+				"DOMAIN":             "gocost.ro",
+				"BASE_URL":           "https://app.gocost.ro/",
+				"MAIL_TRANSPORT":     "SMTP",
+				"MAIL_FROM":          "no-reply@gocost.ro",
+				"SMTP_HOST":          "smtp.gocost.ro",
+				"SMTP_PORT":          "465",
+				"SMTP_USERNAME":      "mailer",
+				"SMTP_PASSWORD":      "secret",
+				"SECRET_KEY":         "signing-key",
+				"EMAIL_VERIFICATION": "Block",
+			},
+			want: &config.Config{
+				Addr:         "0.0.0.0",
+				Port:         4000,
+				Dsn:          "data.sqlite",
+				AllowedHosts: []string{"localhost"},
+				Domain:       "gocost.ro",
+				Currency:     "USD",
+				BaseURL:      "https://app.gocost.ro",
+				Mail: config.MailConfig{
+					Transport:    config.MailTransportSMTP,
+					From:         "no-reply@gocost.ro",
+					Dir:          "mail",
+					SMTPHost:     "smtp.gocost.ro",
+					SMTPPort:     465,
+					SMTPUsername: "mailer",
+					SMTPPassword: "secret",
+				},
+				SecretKey:         "signing-key",
+				EmailVerification: config.EmailVerificationBlock,
+				Registration:      config.RegistrationOpen,
+				Session: config.SessionConfig{
+					Lifetime:           time.Hour,
+					IdleTimeout:        20 * time.Minute,
+					RememberMeLifetime: 30 * 24 * time.Hour,
+				},
+				RateLimit: config.RateLimitConfig{
+					Enabled:       true,
+					Login:         ratelimit.Policy{Requests: 10, Window: time.Minute},
+					Register:      ratelimit.Policy{Requests: 5, Window: time.Hour},
+					PasswordReset: ratelimit.Policy{Requests: 5, Window: time.Hour},
+					LoginLockout:  ratelimit.Lockout{Threshold: 5, Duration: time.Minute, MaxDuration: time.Hour},
+				},
+				CaptchaDifficulty: 16,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Session settings",
+			envVars: map[string]string{
+				"ALLOWED_HOSTS":        "localhost",
+				"DOMAIN":               "gocost.ro",
+				"SESSION_LIFETIME":     "2h",
+				"SESSION_IDLE_TIMEOUT": "0",
+				"REMEMBER_ME_LIFETIME": "168h",
+				"REAUTH_AFTER":         "15m",
+				"NEW_DEVICE_ALERTS":    "true",
+			},
+			want: &config.Config{
+				Addr:         "0.0.0.0",
+				Port:         4000,
+				Dsn:          "data.sqlite",
+				AllowedHosts: []string{"localhost"},
+				Domain:       "gocost.ro",
+				Currency:     "USD",
+				BaseURL:      "http://gocost.ro:4000",
+				Mail: config.MailConfig{
+					Transport: config.MailTransportLog,
+					From:      "gocost@gocost.ro",
+					Dir:       "mail",
+					SMTPPort:  587,
+				},
+				EmailVerification: config.EmailVerificationBanner,
+				Registration:      config.RegistrationOpen,
+				Session: config.SessionConfig{
+					Lifetime:           2 * time.Hour,
+					RememberMeLifetime: 7 * 24 * time.Hour,
+					ReauthAfter:        15 * time.Minute,
+					NewDeviceAlerts:    true,
+				},
+				RateLimit: config.RateLimitConfig{
+					Enabled:       true,
+					Login:         ratelimit.Policy{Requests: 10, Window: time.Minute},
+					Register:      ratelimit.Policy{Requests: 5, Window: time.Hour},
+					PasswordReset: ratelimit.Policy{Requests: 5, Window: time.Hour},
+					LoginLockout:  ratelimit.Lockout{Threshold: 5, Duration: time.Minute, MaxDuration: time.Hour},
+				},
+				CaptchaDifficulty: 16,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid session duration",
+			envVars: map[string]string{
+				"ALLOWED_HOSTS":    "localhost",
+				"DOMAIN":           "gocost.ro",
+				"SESSION_LIFETIME": "a while",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Zero session lifetime",
+			envVars: map[string]string{
+				"ALLOWED_HOSTS":    "localhost",
+				"DOMAIN":           "gocost.ro",
+				"SESSION_LIFETIME": "0s",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Rate limit settings",
+			envVars: map[string]string{
+				"ALLOWED_HOSTS":              "localhost",
+				"DOMAIN":                     "gocost.ro",
+				"RATE_LIMIT_ENABLED":         "false",
+				"RATE_LIMIT_LOGIN":           "3/30s",
+				"RATE_LIMIT_REGISTER":        "2/1h",
+				"RATE_LIMIT_PASSWORD_RESET":  "1/10m",
+				"LOGIN_LOCKOUT_THRESHOLD":    "0",
+				"LOGIN_LOCKOUT_DURATION":     "2m",
+				"LOGIN_LOCKOUT_MAX_DURATION": "1m",
+				"CAPTCHA_DIFFICULTY":         "0",
+			},
+			want: &config.Config{
+				Addr:         "0.0.0.0",
+				Port:         4000,
+				Dsn:          "data.sqlite",
+				AllowedHosts: []string{"localhost"},
+				Domain:       "gocost.ro",
+				Currency:     "USD",
+				BaseURL:      "http://gocost.ro:4000",
+				Mail: config.MailConfig{
+					Transport: config.MailTransportLog,
+					From:      "gocost@gocost.ro",
+					Dir:       "mail",
+					SMTPPort:  587,
+				},
+				EmailVerification: config.EmailVerificationBanner,
+				Registration:      config.RegistrationOpen,
+				Session: config.SessionConfig{
+					Lifetime:           time.Hour,
+					IdleTimeout:        20 * time.Minute,
+					RememberMeLifetime: 30 * 24 * time.Hour,
+				},
+				RateLimit: config.RateLimitConfig{
+					Login:         ratelimit.Policy{Requests: 3, Window: 30 * time.Second},
+					Register:      ratelimit.Policy{Requests: 2, Window: time.Hour},
+					PasswordReset: ratelimit.Policy{Requests: 1, Window: 10 * time.Minute},
+					LoginLockout:  ratelimit.Lockout{Duration: 2 * time.Minute, MaxDuration: 2 * time.Minute},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Captcha difficulty out of range",
+			envVars: map[string]string{
+				"ALLOWED_HOSTS":      "localhost",
+				"DOMAIN":             "gocost.ro",
+				"CAPTCHA_DIFFICULTY": "64",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Invalid rate limit",
+			envVars: map[string]string{
+				"ALLOWED_HOSTS":    "localhost",
+				"DOMAIN":           "gocost.ro",
+				"RATE_LIMIT_LOGIN": "10 per minute",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "SMTP transport without SMTP_HOST",
+			envVars: map[string]string{
+				"ALLOWED_HOSTS":  "localhost",
+				"DOMAIN":         "gocost.ro",
+				"MAIL_TRANSPORT": "smtp",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Unknown email verification",
+			envVars: map[string]string{
+				"ALLOWED_HOSTS":      "localhost",
+				"DOMAIN":             "gocost.ro",
+				"EMAIL_VERIFICATION": "sometimes",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Invite-only registration",
+			envVars: map[string]string{
+				"ALLOWED_HOSTS": "localhost",
+				"DOMAIN":        "gocost.ro",
+				"REGISTRATION":  "Invite",
+			},
+			want: &config.Config{
+				Addr:         "0.0.0.0",
+				Port:         4000,
+				Dsn:          "data.sqlite",
+				AllowedHosts: []string{"localhost"},
+				Domain:       "gocost.ro",
+				Currency:     "USD",
+				BaseURL:      "http://gocost.ro:4000",
+				Mail: config.MailConfig{
+					Transport: config.MailTransportLog,
+					From:      "gocost@gocost.ro",
+					Dir:       "mail",
+					SMTPPort:  587,
+				},
+				EmailVerification: config.EmailVerificationBanner,
+				Registration:      config.RegistrationInvite,
+				Session: config.SessionConfig{
+					Lifetime:           time.Hour,
+					IdleTimeout:        20 * time.Minute,
+					RememberMeLifetime: 30 * 24 * time.Hour,
+				},
+				RateLimit: config.RateLimitConfig{
+					Enabled:       true,
+					Login:         ratelimit.Policy{Requests: 10, Window: time.Minute},
+					Register:      ratelimit.Policy{Requests: 5, Window: time.Hour},
+					PasswordReset: ratelimit.Policy{Requests: 5, Window: time.Hour},
+					LoginLockout:  ratelimit.Lockout{Threshold: 5, Duration: time.Minute, MaxDuration: time.Hour},
+				},
+				CaptchaDifficulty: 16,
+			},
+			wantErr: false,
+		},
+		{
+			name: "DISABLE_REGISTRATION closes registration",
+			envVars: map[string]string{
+				"ALLOWED_HOSTS":        "localhost",
+				"DOMAIN":               "gocost.ro",
+				"REGISTRATION":         "invite",
+				"DISABLE_REGISTRATION": "true",
+			},
+			want: &config.Config{
+				Addr:         "0.0.0.0",
+				Port:         4000,
+				Dsn:          "data.sqlite",
+				AllowedHosts: []string{"localhost"},
+				Domain:       "gocost.ro",
+				Currency:     "USD",
+				BaseURL:      "http://gocost.ro:4000",
+				Mail: config.MailConfig{
+					Transport: config.MailTransportLog,
+					From:      "gocost@gocost.ro",
+					Dir:       "mail",
+					SMTPPort:  587,
+				},
+				EmailVerification: config.EmailVerificationBanner,
+				Registration:      config.RegistrationClosed,
+				Session: config.SessionConfig{
+					Lifetime:           time.Hour,
+					IdleTimeout:        20 * time.Minute,
+					RememberMeLifetime: 30 * 24 * time.Hour,
+				},
+				RateLimit: config.RateLimitConfig{
+					Enabled:       true,
+					Login:         ratelimit.Policy{Requests: 10, Window: time.Minute},
+					Register:      ratelimit.Policy{Requests: 5, Window: time.Hour},
+					PasswordReset: ratelimit.Policy{Requests: 5, Window: time.Hour},
+					LoginLockout:  ratelimit.Lockout{Threshold: 5, Duration: time.Minute, MaxDuration: time.Hour},
+				},
+				CaptchaDifficulty: 16,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Unknown registration mode",
+			envVars: map[string]string{
+				"ALLOWED_HOSTS": "localhost",
+				"DOMAIN":        "gocost.ro",
+				"REGISTRATION":  "friends",
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Unknown mail transport",
+			envVars: map[string]string{
+				"ALLOWED_HOSTS":  "localhost",
+				"DOMAIN":         "gocost.ro",
+				"MAIL_TRANSPORT": "pigeon",
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
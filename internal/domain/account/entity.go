@@ -0,0 +1,216 @@
+package account
+
+import (
+	"sort"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+)
+
+type ID = identifier.ID
+
+// Account is a place where money lives, such as a checking account or a
+// credit card. Its balance starts at OpeningBalance, which is negative for
+// a card that starts with debt.
+type Account struct {
+	ID             ID
+	UserID         ID
+	Name           NameVO
+	Type           Type
+	OpeningBalance money.Money
+}
+
+func NewAccount(id ID, userID ID, name NameVO, accountType Type, openingBalance money.Money) (*Account, error) {
+	a := &Account{
+		ID:     id,
+		UserID: userID,
+	}
+	if err := a.Update(name, accountType, openingBalance); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *Account) Update(name NameVO, accountType Type, openingBalance money.Money) error {
+	if _, err := NewType(accountType.Value()); err != nil {
+		return err
+	}
+
+	a.Name = name
+	a.Type = accountType
+	a.OpeningBalance = openingBalance
+	return nil
+}
+
+// Transfer moves money between two accounts of the same user. It changes
+// both balances but is neither income nor spending.
+type Transfer struct {
+	ID            ID
+	UserID        ID
+	FromAccountID ID
+	ToAccountID   ID
+	Amount        money.Money
+	Date          time.Time
+}
+
+func NewTransfer(id ID, userID ID, fromAccountID ID, toAccountID ID, amount money.Money, date time.Time) (*Transfer, error) {
+	if fromAccountID == toAccountID {
+		return nil, ErrSameAccountTransfer
+	}
+	isPositive, err := amount.IsPositive()
+	if err != nil || !isPositive {
+		return nil, ErrInvalidTransferAmount
+	}
+
+	return &Transfer{
+		ID:            id,
+		UserID:        userID,
+		FromAccountID: fromAccountID,
+		ToAccountID:   toAccountID,
+		Amount:        amount,
+		Date:          date,
+	}, nil
+}
+
+// Entry is an income, paid expense or transfer recorded against an account.
+// Amount is always positive; the kind tells the direction.
+type Entry struct {
+	Kind        EntryKind
+	ID          ID
+	Date        time.Time
+	Description string
+	Amount      money.Money
+	Reconciled  bool
+}
+
+func (e Entry) IsInflow() bool {
+	return e.Kind == EntryIncome || e.Kind == EntryTransferIn
+}
+
+func (e Entry) signedCents() int64 {
+	if e.IsInflow() {
+		return e.Amount.Cents()
+	}
+	return -e.Amount.Cents()
+}
+
+// EntryRef identifies an entry of an account.
+type EntryRef struct {
+	Kind EntryKind
+	ID   ID
+}
+
+// Line is an entry with the account balance right after it.
+type Line struct {
+	Entry
+	Balance money.Money
+}
+
+// Ledger is the history of an account: its entries in date order, each with
+// the running balance.
+type Ledger struct {
+	Account Account
+	Lines   []Line
+}
+
+func NewLedger(account Account, entries []Entry) Ledger {
+	sorted := make([]Entry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	currency := account.OpeningBalance.Currency()
+	balance := account.OpeningBalance.Cents()
+	lines := make([]Line, 0, len(sorted))
+	for _, e := range sorted {
+		balance += e.signedCents()
+		lineBalance, _ := money.New(balance, currency)
+		lines = append(lines, Line{Entry: e, Balance: lineBalance})
+	}
+
+	return Ledger{Account: account, Lines: lines}
+}
+
+// Balance is the balance after every entry.
+func (l Ledger) Balance() money.Money {
+	if len(l.Lines) == 0 {
+		return l.Account.OpeningBalance
+	}
+	return l.Lines[len(l.Lines)-1].Balance
+}
+
+// BalanceAt is the balance at the end of the given day.
+func (l Ledger) BalanceAt(day time.Time) money.Money {
+	balance := l.Account.OpeningBalance
+	for _, line := range l.Lines {
+		if !onOrBefore(line.Date, day) {
+			break
+		}
+		balance = line.Balance
+	}
+	return balance
+}
+
+// Unreconciled returns the entries up to the end of the day that have not
+// been matched against a statement yet.
+func (l Ledger) Unreconciled(day time.Time) []Line {
+	var lines []Line
+	for _, line := range l.Lines {
+		if !onOrBefore(line.Date, day) {
+			break
+		}
+		if !line.Reconciled {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// Reconciliation records that an account matched a statement balance at a
+// date. The entries it covers are the ones it marked as reconciled.
+type Reconciliation struct {
+	ID               ID
+	AccountID        ID
+	StatementDate    time.Time
+	StatementBalance money.Money
+	Entries          []EntryRef
+}
+
+// Reconcile checks the balance at the statement date against the statement
+// and, when they agree, marks every entry up to that date as reconciled.
+func (l *Ledger) Reconcile(id ID, statementDate time.Time, statementBalance money.Money) (*Reconciliation, error) {
+	if statementBalance.Currency() != l.Account.OpeningBalance.Currency() {
+		return nil, ErrCurrencyMismatch
+	}
+	if l.BalanceAt(statementDate).Cents() != statementBalance.Cents() {
+		return nil, ErrReconciliationMismatch
+	}
+
+	reconciliation := &Reconciliation{
+		ID:               id,
+		AccountID:        l.Account.ID,
+		StatementDate:    statementDate,
+		StatementBalance: statementBalance,
+	}
+	for i := range l.Lines {
+		line := &l.Lines[i]
+		if !onOrBefore(line.Date, statementDate) {
+			break
+		}
+		if !line.Reconciled {
+			line.Reconciled = true
+			reconciliation.Entries = append(reconciliation.Entries, EntryRef{Kind: line.Kind, ID: line.ID})
+		}
+	}
+
+	return reconciliation, nil
+}
+
+// onOrBefore reports whether t falls on or before the calendar day of day.
+func onOrBefore(t time.Time, day time.Time) bool {
+	y, m, d := day.Date()
+	endOfDay := time.Date(y, m, d, 0, 0, 0, 0, day.Location()).AddDate(0, 0, 1)
+	return t.Before(endOfDay)
+}
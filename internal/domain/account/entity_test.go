@@ -0,0 +1,179 @@
+package account
+
+import (
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAccount(t *testing.T, openingCents int64) *Account {
+	t.Helper()
+
+	id, _ := identifier.NewID()
+	userID, _ := identifier.NewID()
+	name, err := NewNameVO("Checking")
+	require.NoError(t, err)
+	opening, _ := money.New(openingCents, "USD")
+
+	a, err := NewAccount(id, userID, name, TypeChecking, opening)
+	require.NoError(t, err)
+	return a
+}
+
+func newTestEntry(t *testing.T, kind EntryKind, cents int64, day int, reconciled bool) Entry {
+	t.Helper()
+
+	id, _ := identifier.NewID()
+	amount, _ := money.New(cents, "USD")
+	return Entry{
+		Kind:       kind,
+		ID:         id,
+		Date:       time.Date(2024, time.March, day, 12, 0, 0, 0, time.UTC),
+		Amount:     amount,
+		Reconciled: reconciled,
+	}
+}
+
+func TestNewAccount(t *testing.T) {
+	id, _ := identifier.NewID()
+	userID, _ := identifier.NewID()
+	name, _ := NewNameVO("Visa")
+	opening, _ := money.New(-25000, "USD")
+
+	t.Run("creates account with negative opening balance", func(t *testing.T) {
+		a, err := NewAccount(id, userID, name, TypeCreditCard, opening)
+
+		assert.NoError(t, err)
+		assert.Equal(t, TypeCreditCard, a.Type)
+		assert.Equal(t, int64(-25000), a.OpeningBalance.Cents())
+	})
+
+	t.Run("rejects unknown type", func(t *testing.T) {
+		_, err := NewAccount(id, userID, name, Type("crypto"), opening)
+		assert.ErrorIs(t, err, ErrInvalidType)
+	})
+}
+
+func TestNewNameVO(t *testing.T) {
+	_, err := NewNameVO("  ")
+	assert.ErrorIs(t, err, ErrEmptyName)
+
+	_, err = NewNameVO(string(make([]byte, 101)))
+	assert.ErrorIs(t, err, ErrNameTooLong)
+
+	name, err := NewNameVO(" Wallet ")
+	assert.NoError(t, err)
+	assert.Equal(t, "Wallet", name.Value())
+}
+
+func TestNewTransfer(t *testing.T) {
+	id, _ := identifier.NewID()
+	userID, _ := identifier.NewID()
+	from, _ := identifier.NewID()
+	to, _ := identifier.NewID()
+	amount, _ := money.New(5000, "USD")
+	zero, _ := money.New(0, "USD")
+	date := time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC)
+
+	transfer, err := NewTransfer(id, userID, from, to, amount, date)
+	assert.NoError(t, err)
+	assert.Equal(t, from, transfer.FromAccountID)
+	assert.Equal(t, to, transfer.ToAccountID)
+
+	_, err = NewTransfer(id, userID, from, from, amount, date)
+	assert.ErrorIs(t, err, ErrSameAccountTransfer)
+
+	_, err = NewTransfer(id, userID, from, to, zero, date)
+	assert.ErrorIs(t, err, ErrInvalidTransferAmount)
+}
+
+func TestLedger(t *testing.T) {
+	a := newTestAccount(t, 10000)
+	entries := []Entry{
+		newTestEntry(t, EntryExpense, 3000, 10, false),
+		newTestEntry(t, EntryIncome, 50000, 1, true),
+		newTestEntry(t, EntryTransferOut, 20000, 15, false),
+		newTestEntry(t, EntryTransferIn, 1000, 20, false),
+	}
+
+	ledger := NewLedger(*a, entries)
+
+	t.Run("orders entries by date with running balances", func(t *testing.T) {
+		require.Len(t, ledger.Lines, 4)
+		balances := make([]int64, 0, len(ledger.Lines))
+		for _, line := range ledger.Lines {
+			balances = append(balances, line.Balance.Cents())
+		}
+		assert.Equal(t, []int64{60000, 57000, 37000, 38000}, balances)
+		assert.Equal(t, EntryIncome, ledger.Lines[0].Kind)
+		assert.Equal(t, int64(38000), ledger.Balance().Cents())
+	})
+
+	t.Run("balance at a day includes the whole day", func(t *testing.T) {
+		assert.Equal(t, int64(10000), ledger.BalanceAt(time.Date(2024, time.February, 28, 0, 0, 0, 0, time.UTC)).Cents())
+		assert.Equal(t, int64(57000), ledger.BalanceAt(time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)).Cents())
+	})
+
+	t.Run("lists unreconciled entries up to a day", func(t *testing.T) {
+		lines := ledger.Unreconciled(time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC))
+		require.Len(t, lines, 2)
+		assert.Equal(t, EntryExpense, lines[0].Kind)
+		assert.Equal(t, EntryTransferOut, lines[1].Kind)
+	})
+
+	t.Run("opening balance when there are no entries", func(t *testing.T) {
+		assert.Equal(t, int64(10000), NewLedger(*a, nil).Balance().Cents())
+	})
+}
+
+func TestLedger_Reconcile(t *testing.T) {
+	a := newTestAccount(t, 10000)
+	statementDate := time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)
+	newLedger := func() Ledger {
+		return NewLedger(*a, []Entry{
+			newTestEntry(t, EntryIncome, 50000, 1, true),
+			newTestEntry(t, EntryExpense, 3000, 10, false),
+			newTestEntry(t, EntryTransferOut, 20000, 20, false),
+		})
+	}
+	id, _ := identifier.NewID()
+
+	t.Run("marks entries up to the statement date", func(t *testing.T) {
+		ledger := newLedger()
+		balance, _ := money.New(57000, "USD")
+
+		reconciliation, err := ledger.Reconcile(id, statementDate, balance)
+
+		require.NoError(t, err)
+		assert.Equal(t, a.ID, reconciliation.AccountID)
+		require.Len(t, reconciliation.Entries, 1)
+		assert.Equal(t, ledger.Lines[1].ID, reconciliation.Entries[0].ID)
+		assert.True(t, ledger.Lines[1].Reconciled)
+		assert.False(t, ledger.Lines[2].Reconciled)
+		assert.Empty(t, ledger.Unreconciled(statementDate))
+	})
+
+	t.Run("rejects a statement that does not match", func(t *testing.T) {
+		ledger := newLedger()
+		balance, _ := money.New(56000, "USD")
+
+		reconciliation, err := ledger.Reconcile(id, statementDate, balance)
+
+		assert.Nil(t, reconciliation)
+		assert.ErrorIs(t, err, ErrReconciliationMismatch)
+		assert.False(t, ledger.Lines[1].Reconciled)
+	})
+
+	t.Run("rejects a statement in another currency", func(t *testing.T) {
+		ledger := newLedger()
+		balance, _ := money.New(57000, "EUR")
+
+		_, err := ledger.Reconcile(id, statementDate, balance)
+
+		assert.ErrorIs(t, err, ErrCurrencyMismatch)
+	})
+}
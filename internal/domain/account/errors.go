@@ -0,0 +1,15 @@
+package account
+
+import "errors"
+
+var (
+	ErrEmptyName              = errors.New("account name cannot be empty")
+	ErrNameTooLong            = errors.New("account name exceeds maximum length of 100 characters")
+	ErrInvalidType            = errors.New("invalid account type")
+	ErrCurrencyMismatch       = errors.New("amount currency does not match the account")
+	ErrInvalidTransferAmount  = errors.New("transfer amount must be positive")
+	ErrSameAccountTransfer    = errors.New("cannot transfer to the same account")
+	ErrReconciliationMismatch = errors.New("computed balance does not match the statement balance")
+	ErrAccountNotFound        = errors.New("account not found")
+	ErrTransferNotFound       = errors.New("transfer not found")
+)
@@ -0,0 +1,16 @@
+package account
+
+import "context"
+
+type AccountRepository interface {
+	Save(ctx context.Context, account Account) error
+	FindByID(ctx context.Context, userID ID, id ID) (Account, error)
+	FindByUserID(ctx context.Context, userID ID) ([]Account, error)
+	Delete(ctx context.Context, userID ID, id ID) error
+	SaveTransfer(ctx context.Context, transfer Transfer) error
+	DeleteTransfer(ctx context.Context, userID ID, id ID) error
+	// Entries returns the incomes, paid expenses and transfers recorded
+	// against the account.
+	Entries(ctx context.Context, userID ID, accountID ID) ([]Entry, error)
+	SaveReconciliation(ctx context.Context, reconciliation Reconciliation) error
+}
@@ -0,0 +1,65 @@
+package account
+
+import "strings"
+
+const maxNameLength = 100
+
+type NameVO struct {
+	value string
+}
+
+func NewNameVO(value string) (NameVO, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return NameVO{}, ErrEmptyName
+	}
+	if len(value) > maxNameLength {
+		return NameVO{}, ErrNameTooLong
+	}
+	return NameVO{value: value}, nil
+}
+
+func (n NameVO) Value() string {
+	return n.value
+}
+
+func (n NameVO) String() string {
+	return n.value
+}
+
+func (n NameVO) Equals(other NameVO) bool {
+	return n.value == other.value
+}
+
+// Type is where the money of an account lives.
+type Type string
+
+const (
+	TypeChecking   Type = "checking"
+	TypeSavings    Type = "savings"
+	TypeCreditCard Type = "credit_card"
+	TypeCash       Type = "cash"
+)
+
+func NewType(value string) (Type, error) {
+	switch t := Type(value); t {
+	case TypeChecking, TypeSavings, TypeCreditCard, TypeCash:
+		return t, nil
+	default:
+		return "", ErrInvalidType
+	}
+}
+
+func (t Type) Value() string {
+	return string(t)
+}
+
+// EntryKind tells what moved money in or out of an account.
+type EntryKind string
+
+const (
+	EntryIncome      EntryKind = "income"
+	EntryExpense     EntryKind = "expense"
+	EntryTransferIn  EntryKind = "transfer_in"
+	EntryTransferOut EntryKind = "transfer_out"
+)
@@ -0,0 +1,56 @@
+package alert
+
+import (
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+)
+
+type ID = identifier.ID
+
+// Notification records that spending in a category reached one of its
+// thresholds in a month. A threshold notifies at most once per category and
+// month.
+type Notification struct {
+	ID           ID
+	UserID       ID
+	CategoryID   ID
+	CategoryName string
+	Month        string
+	Threshold    Threshold
+	Spent        money.Money
+	Budget       money.Money
+	CreatedAt    time.Time
+	ReadAt       *time.Time
+}
+
+func NewNotification(id ID, userID ID, categoryID ID, month string, threshold Threshold, spent money.Money, budget money.Money, createdAt time.Time) (*Notification, error) {
+	if !validMonth(month) {
+		return nil, ErrInvalidMonth
+	}
+	if _, err := NewThreshold(threshold.Value()); err != nil {
+		return nil, err
+	}
+
+	return &Notification{
+		ID:         id,
+		UserID:     userID,
+		CategoryID: categoryID,
+		Month:      month,
+		Threshold:  threshold,
+		Spent:      spent,
+		Budget:     budget,
+		CreatedAt:  createdAt,
+	}, nil
+}
+
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+func (n *Notification) MarkRead(at time.Time) {
+	if n.ReadAt == nil {
+		n.ReadAt = &at
+	}
+}
@@ -0,0 +1,78 @@
+package alert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func usd(cents int64) money.Money {
+	m, _ := money.New(cents, "USD")
+	return m
+}
+
+func TestNewThresholds(t *testing.T) {
+	thresholds, err := NewThresholds([]int{100, 80, 100})
+	require.NoError(t, err)
+	assert.Equal(t, []Threshold{80, 100}, thresholds)
+
+	_, err = NewThresholds([]int{0})
+	assert.ErrorIs(t, err, ErrInvalidThreshold)
+
+	_, err = NewThresholds([]int{501})
+	assert.ErrorIs(t, err, ErrInvalidThreshold)
+
+	_, err = NewThresholds([]int{10, 20, 30, 40, 50, 60})
+	assert.ErrorIs(t, err, ErrTooManyThresholds)
+}
+
+func TestCrossed(t *testing.T) {
+	thresholds := []Threshold{80, 100}
+
+	tests := []struct {
+		name   string
+		budget int64
+		before int64
+		after  int64
+		want   []Threshold
+	}{
+		{name: "below every threshold", budget: 10000, before: 0, after: 7999, want: nil},
+		{name: "reaches the first", budget: 10000, before: 7000, after: 8000, want: []Threshold{80}},
+		{name: "jumps past both", budget: 10000, before: 5000, after: 12000, want: []Threshold{80, 100}},
+		{name: "already past", budget: 10000, before: 8500, after: 9000, want: nil},
+		{name: "spending went down", budget: 10000, before: 12000, after: 9000, want: nil},
+		{name: "no budget", budget: 0, before: 0, after: 5000, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Crossed(thresholds, tt.budget, tt.before, tt.after))
+		})
+	}
+}
+
+func TestNewNotification(t *testing.T) {
+	id, _ := identifier.NewID()
+	userID, _ := identifier.NewID()
+	categoryID, _ := identifier.NewID()
+	now := time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	n, err := NewNotification(id, userID, categoryID, "2024-03", 80, usd(8000), usd(10000), now)
+	require.NoError(t, err)
+	assert.False(t, n.IsRead())
+
+	n.MarkRead(now)
+	n.MarkRead(now.Add(time.Hour))
+	assert.True(t, n.IsRead())
+	assert.Equal(t, now, *n.ReadAt)
+
+	_, err = NewNotification(id, userID, categoryID, "March", 80, usd(8000), usd(10000), now)
+	assert.ErrorIs(t, err, ErrInvalidMonth)
+
+	_, err = NewNotification(id, userID, categoryID, "2024-03", 0, usd(8000), usd(10000), now)
+	assert.ErrorIs(t, err, ErrInvalidThreshold)
+}
@@ -0,0 +1,10 @@
+package alert
+
+import "errors"
+
+var (
+	ErrInvalidThreshold     = errors.New("threshold must be a whole percentage between 1 and 500")
+	ErrTooManyThresholds    = errors.New("a category can have at most 5 thresholds")
+	ErrInvalidMonth         = errors.New("invalid month")
+	ErrNotificationNotFound = errors.New("notification not found")
+)
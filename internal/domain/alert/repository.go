@@ -0,0 +1,23 @@
+package alert
+
+import (
+	"context"
+	"time"
+)
+
+type AlertRepository interface {
+	// Thresholds returns the thresholds of every category of the user,
+	// keyed by category.
+	Thresholds(ctx context.Context, userID ID) (map[ID][]Threshold, error)
+	SaveThresholds(ctx context.Context, categoryID ID, thresholds []Threshold) error
+	// ForkCategory gives a category forked from another its thresholds and
+	// moves over the notifications from the month on.
+	ForkCategory(ctx context.Context, fromCategoryID ID, toCategoryID ID, month string) error
+	// SaveNotification records the notification unless the threshold already
+	// notified for the category and month, and reports whether it did.
+	SaveNotification(ctx context.Context, notification Notification) (bool, error)
+	Notifications(ctx context.Context, userID ID, limit int) ([]Notification, error)
+	CountUnread(ctx context.Context, userID ID) (int, error)
+	MarkRead(ctx context.Context, userID ID, id ID, at time.Time) error
+	MarkAllRead(ctx context.Context, userID ID, at time.Time) error
+}
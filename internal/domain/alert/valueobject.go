@@ -0,0 +1,76 @@
+package alert
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	minThreshold  = 1
+	maxThreshold  = 500
+	maxThresholds = 5
+	monthLayout   = "2006-01"
+)
+
+// Threshold is a share of a category's monthly budget, in percent, that
+// raises a notification once spending reaches it.
+type Threshold int
+
+func NewThreshold(percent int) (Threshold, error) {
+	if percent < minThreshold || percent > maxThreshold {
+		return 0, ErrInvalidThreshold
+	}
+	return Threshold(percent), nil
+}
+
+func (t Threshold) Value() int {
+	return int(t)
+}
+
+// NewThresholds validates the thresholds of a category and returns them
+// sorted, without duplicates.
+func NewThresholds(percents []int) ([]Threshold, error) {
+	seen := make(map[Threshold]struct{}, len(percents))
+	thresholds := make([]Threshold, 0, len(percents))
+	for _, p := range percents {
+		t, err := NewThreshold(p)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		thresholds = append(thresholds, t)
+	}
+	if len(thresholds) > maxThresholds {
+		return nil, ErrTooManyThresholds
+	}
+
+	sort.Slice(thresholds, func(i, j int) bool {
+		return thresholds[i] < thresholds[j]
+	})
+	return thresholds, nil
+}
+
+// Crossed returns the thresholds that spending reached when it went from
+// before to after, all in minor units. Nothing is crossed without a budget.
+func Crossed(thresholds []Threshold, budgetCents, beforeCents, afterCents int64) []Threshold {
+	if budgetCents <= 0 || afterCents <= beforeCents {
+		return nil
+	}
+
+	var crossed []Threshold
+	for _, t := range thresholds {
+		limit := budgetCents * int64(t)
+		if beforeCents*100 < limit && afterCents*100 >= limit {
+			crossed = append(crossed, t)
+		}
+	}
+	return crossed
+}
+
+func validMonth(month string) bool {
+	_, err := time.Parse(monthLayout, month)
+	return err == nil
+}
@@ -0,0 +1,52 @@
+package closing
+
+import (
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+)
+
+type ID = identifier.ID
+
+// Totals are the headline figures of a month at the time it was closed.
+type Totals struct {
+	Income       money.Money
+	Expenses     money.Money
+	Budgeted     money.Money
+	PaidExpenses money.Money
+}
+
+// MonthClose records that a user closed a month. Snapshot holds the month's
+// report as it looked when closed, encoded by the caller.
+type MonthClose struct {
+	ID          ID
+	UserID      ID
+	Month       string
+	Totals      Totals
+	UnpaidCount int
+	Snapshot    []byte
+	ClosedAt    time.Time
+}
+
+func NewMonthClose(id, userID ID, month string, totals Totals, unpaidCount int, snapshot []byte, closedAt time.Time) (*MonthClose, error) {
+	if _, err := time.Parse(monthLayout, month); err != nil {
+		return nil, ErrInvalidMonth
+	}
+	if unpaidCount < 0 {
+		return nil, ErrInvalidUnpaidCount
+	}
+	if len(snapshot) == 0 {
+		return nil, ErrEmptySnapshot
+	}
+
+	return &MonthClose{
+		ID:          id,
+		UserID:      userID,
+		Month:       month,
+		Totals:      totals,
+		UnpaidCount: unpaidCount,
+		Snapshot:    snapshot,
+		ClosedAt:    closedAt,
+	}, nil
+}
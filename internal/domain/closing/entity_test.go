@@ -0,0 +1,51 @@
+package closing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMonthClose(t *testing.T) {
+	id, _ := identifier.NewID()
+	userID, _ := identifier.NewID()
+	income, _ := money.New(100000, "USD")
+	totals := Totals{Income: income}
+	snapshot := []byte(`{}`)
+	closedAt := time.Now()
+
+	t.Run("creates valid month close", func(t *testing.T) {
+		monthClose, err := NewMonthClose(id, userID, "2024-03", totals, 1, snapshot, closedAt)
+
+		assert.NoError(t, err)
+		assert.Equal(t, id, monthClose.ID)
+		assert.Equal(t, userID, monthClose.UserID)
+		assert.Equal(t, "2024-03", monthClose.Month)
+		assert.Equal(t, totals, monthClose.Totals)
+		assert.Equal(t, 1, monthClose.UnpaidCount)
+		assert.Equal(t, snapshot, monthClose.Snapshot)
+		assert.Equal(t, closedAt, monthClose.ClosedAt)
+	})
+
+	t.Run("rejects invalid month", func(t *testing.T) {
+		_, err := NewMonthClose(id, userID, "March 2024", totals, 0, snapshot, closedAt)
+		assert.ErrorIs(t, err, ErrInvalidMonth)
+	})
+
+	t.Run("rejects negative unpaid count", func(t *testing.T) {
+		_, err := NewMonthClose(id, userID, "2024-03", totals, -1, snapshot, closedAt)
+		assert.ErrorIs(t, err, ErrInvalidUnpaidCount)
+	})
+
+	t.Run("rejects empty snapshot", func(t *testing.T) {
+		_, err := NewMonthClose(id, userID, "2024-03", totals, 0, nil, closedAt)
+		assert.ErrorIs(t, err, ErrEmptySnapshot)
+	})
+}
+
+func TestMonthOf(t *testing.T) {
+	assert.Equal(t, "2024-03", MonthOf(time.Date(2024, time.March, 31, 23, 59, 0, 0, time.UTC)))
+}
@@ -0,0 +1,12 @@
+package closing
+
+import "errors"
+
+var (
+	ErrInvalidMonth       = errors.New("month must be in YYYY-MM format")
+	ErrEmptySnapshot      = errors.New("snapshot cannot be empty")
+	ErrInvalidUnpaidCount = errors.New("unpaid count cannot be negative")
+	ErrMonthClosed        = errors.New("month is closed")
+	ErrMonthNotClosed     = errors.New("month is not closed")
+	ErrUnpaidExpenses     = errors.New("month has unpaid expenses")
+)
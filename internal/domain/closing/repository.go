@@ -0,0 +1,10 @@
+package closing
+
+import "context"
+
+type MonthCloseRepository interface {
+	Save(ctx context.Context, monthClose MonthClose) error
+	FindByUserIDAndMonth(ctx context.Context, userID ID, month string) (MonthClose, error)
+	IsClosed(ctx context.Context, userID ID, month string) (bool, error)
+	Delete(ctx context.Context, userID ID, month string) error
+}
@@ -0,0 +1,12 @@
+package closing
+
+import "time"
+
+const (
+	monthLayout = "2006-01"
+)
+
+// MonthOf returns the YYYY-MM month that t falls in.
+func MonthOf(t time.Time) string {
+	return t.Format(monthLayout)
+}
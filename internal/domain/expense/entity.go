@@ -16,6 +16,9 @@ type Expense struct {
 	Description ExpenseDescriptionVO
 	SpentAt     time.Time
 	Payment     PaymentStatus
+	// AccountID is the account the expense is paid from. It is zero when
+	// the expense is not tied to an account.
+	AccountID ID
 }
 
 func NewExpense(id ID, categoryID ID, amount money.Money, description ExpenseDescriptionVO, spentAt time.Time, payment PaymentStatus) (*Expense, error) {
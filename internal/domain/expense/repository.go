@@ -13,6 +13,9 @@ type ExpenseRepository interface {
 	FindByUserIDAndMonth(ctx context.Context, userID ID, month string) ([]Expense, error)
 	TotalsByCategoryAndMonth(ctx context.Context, userID ID, month string) ([]CategoryTotals, error)
 	ReassignCategoryFromMonth(ctx context.Context, userID ID, fromCategoryID ID, toCategoryID ID, month string) error
+	ReassignCategory(ctx context.Context, userID ID, fromCategoryID ID, toCategoryID ID) error
+	CountByCategoriesPerMonth(ctx context.Context, userID ID, categoryIDs []ID) ([]MonthlyCount, error)
+	DeleteByCategoryFromMonth(ctx context.Context, userID ID, categoryID ID, month string) error
 	Delete(ctx context.Context, id ID) error
 	Total(ctx context.Context, userID ID, month string) (money.Money, error)
 }
@@ -22,3 +25,9 @@ type CategoryTotals struct {
 	Total      money.Money
 	PaidTotal  money.Money
 }
+
+// MonthlyCount is the number of expenses recorded in a month (YYYY-MM).
+type MonthlyCount struct {
+	Month string
+	Count int
+}
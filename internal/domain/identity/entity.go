@@ -1,6 +1,10 @@
 package identity
 
 import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
 	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
 )
 
@@ -12,6 +16,17 @@ type User struct {
 		Username UsernameVO
 		Password PasswordVO
 		Currency CurrencyVO
+
+		// ZeroBasedBudgeting asks for every unit of income to be assigned
+		// to a category.
+		ZeroBasedBudgeting bool
+
+		// EmailVerified reports that the user followed the link emailed to
+		// their address.
+		EmailVerified bool
+
+		// VerificationSentAt is when the last verification link was sent.
+		VerificationSentAt *time.Time
 	}
 	
 	func NewUser(id ID, username UsernameVO, email EmailVO, password PasswordVO, currency CurrencyVO) *User {
@@ -23,4 +38,325 @@ type User struct {
 			Currency: currency,
 		}
 	}
-	
\ No newline at end of file
+
+// ChangeEmail sets a new address, which has to be verified again.
+func (u *User) ChangeEmail(email EmailVO) {
+	if u.Email.Equals(email) {
+		return
+	}
+	u.Email = email
+	u.EmailVerified = false
+	u.VerificationSentAt = nil
+}
+
+// RecordVerificationSent notes that a verification link is sent at the given
+// time, unless one was sent less than VerificationResendInterval before.
+func (u *User) RecordVerificationSent(at time.Time) error {
+	if u.VerificationSentAt != nil && at.Sub(*u.VerificationSentAt) < VerificationResendInterval {
+		return ErrVerificationRateLimited
+	}
+	u.VerificationSentAt = &at
+	return nil
+}
+
+// VerifyEmail marks the address as verified.
+func (u *User) VerifyEmail() {
+	u.EmailVerified = true
+}
+
+// VerificationTTL is how long an email verification link can be followed.
+const VerificationTTL = 24 * time.Hour
+
+// VerificationResendInterval is how long a user waits between two
+// verification links.
+const VerificationResendInterval = 2 * time.Minute
+
+// PasswordResetTTL is how long a password reset link can be followed.
+const PasswordResetTTL = time.Hour
+
+// PasswordReset lets a user who forgot their password set a new one with the
+// token emailed to them. Only a hash of the token is kept, and the token works
+// once, until it expires.
+type PasswordReset struct {
+	ID        ID
+	UserID    ID
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+func NewPasswordReset(id ID, userID ID, tokenHash string, createdAt time.Time) *PasswordReset {
+	return &PasswordReset{
+		ID:        id,
+		UserID:    userID,
+		TokenHash: tokenHash,
+		ExpiresAt: createdAt.Add(PasswordResetTTL),
+	}
+}
+
+func (r *PasswordReset) IsUsable(at time.Time) bool {
+	return r.UsedAt == nil && at.Before(r.ExpiresAt)
+}
+
+// Use spends the reset, once.
+func (r *PasswordReset) Use(at time.Time) error {
+	if !r.IsUsable(at) {
+		return ErrInvalidResetToken
+	}
+	r.UsedAt = &at
+	return nil
+}
+
+// RecoveryCodeCount is how many recovery codes a user gets when enabling
+// two-factor authentication.
+const RecoveryCodeCount = 10
+
+// TwoFactor is the TOTP second factor of a user. It is pending until the user
+// proves their authenticator app works by entering a first code. Only hashes
+// of the recovery codes are kept, and each works once.
+type TwoFactor struct {
+	UserID        ID
+	Secret        string
+	ConfirmedAt   *time.Time
+	LastUsedStep  int64
+	RecoveryCodes []RecoveryCode
+}
+
+type RecoveryCode struct {
+	CodeHash string
+	UsedAt   *time.Time
+}
+
+func NewTwoFactor(userID ID, secret string) *TwoFactor {
+	return &TwoFactor{
+		UserID: userID,
+		Secret: secret,
+	}
+}
+
+func (t *TwoFactor) IsEnabled() bool {
+	return t.ConfirmedAt != nil
+}
+
+// Confirm enables the second factor with the time step of the first code
+// entered and the hashes of the recovery codes handed out.
+func (t *TwoFactor) Confirm(step int64, codeHashes []string, at time.Time) error {
+	if t.IsEnabled() {
+		return ErrTwoFactorAlreadyEnabled
+	}
+	if err := t.UseStep(step); err != nil {
+		return err
+	}
+
+	t.ConfirmedAt = &at
+	t.RecoveryCodes = make([]RecoveryCode, 0, len(codeHashes))
+	for _, hash := range codeHashes {
+		t.RecoveryCodes = append(t.RecoveryCodes, RecoveryCode{CodeHash: hash})
+	}
+	return nil
+}
+
+// UseStep spends the time step of a valid code, so that neither it nor an
+// earlier one is accepted again.
+func (t *TwoFactor) UseStep(step int64) error {
+	if step <= t.LastUsedStep {
+		return ErrInvalidTwoFactorCode
+	}
+	t.LastUsedStep = step
+	return nil
+}
+
+// UseRecoveryCode spends the recovery code with the hash, once.
+func (t *TwoFactor) UseRecoveryCode(codeHash string, at time.Time) error {
+	for i := range t.RecoveryCodes {
+		code := &t.RecoveryCodes[i]
+		if code.UsedAt == nil && code.CodeHash == codeHash {
+			code.UsedAt = &at
+			return nil
+		}
+	}
+	return ErrInvalidTwoFactorCode
+}
+
+// RecoveryCodesLeft counts the recovery codes not used yet.
+func (t *TwoFactor) RecoveryCodesLeft() int {
+	left := 0
+	for _, code := range t.RecoveryCodes {
+		if code.UsedAt == nil {
+			left++
+		}
+	}
+	return left
+}
+
+// PasskeyNameMaxLength is the longest name a passkey can be given.
+const PasskeyNameMaxLength = 64
+
+// Passkey is a WebAuthn credential a user logs in with instead of a password.
+// The server keeps its public key and the signature count the authenticator
+// reported last.
+type Passkey struct {
+	ID           ID
+	UserID       ID
+	Name         string
+	CredentialID []byte
+	PublicKey    []byte
+	SignCount    uint32
+	CreatedAt    time.Time
+	LastUsedAt   *time.Time
+}
+
+func NewPasskey(id ID, userID ID, name string, credentialID, publicKey []byte, signCount uint32, createdAt time.Time) (*Passkey, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > PasskeyNameMaxLength {
+		return nil, ErrInvalidPasskeyName
+	}
+	return &Passkey{
+		ID:           id,
+		UserID:       userID,
+		Name:         name,
+		CredentialID: credentialID,
+		PublicKey:    publicKey,
+		SignCount:    signCount,
+		CreatedAt:    createdAt,
+	}, nil
+}
+
+// Use records a login with the passkey and the signature count it reported.
+func (p *Passkey) Use(signCount uint32, at time.Time) {
+	p.SignCount = signCount
+	p.LastUsedAt = &at
+}
+
+// UserAgentMaxLength is the most of a user agent a session keeps.
+const UserAgentMaxLength = 512
+
+// UserSessionRetention is how long the record of a session is kept after it
+// was last used. A browser not seen for longer counts as a new device again.
+const UserSessionRetention = 365 * 24 * time.Hour
+
+// UserSession records where a user logged in from and when the session was
+// last used. The session itself lives in the session store; the record is
+// what the user sees of it in the settings.
+type UserSession struct {
+	ID         ID
+	UserID     ID
+	IPAddress  string
+	UserAgent  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+}
+
+func NewUserSession(id ID, userID ID, ipAddress, userAgent string, createdAt time.Time) *UserSession {
+	return &UserSession{
+		ID:         id,
+		UserID:     userID,
+		IPAddress:  ipAddress,
+		UserAgent:  truncate(strings.TrimSpace(userAgent), UserAgentMaxLength),
+		CreatedAt:  createdAt,
+		LastSeenAt: createdAt,
+	}
+}
+
+// Seen records a request of the session, from the address it came from.
+func (s *UserSession) Seen(ipAddress string, at time.Time) {
+	s.IPAddress = ipAddress
+	s.LastSeenAt = at
+}
+
+// userAgentMarker is a part of a user agent that tells a browser or an
+// operating system apart, and the name it is shown with.
+type userAgentMarker struct {
+	marker string
+	name   string
+}
+
+// browsers and systems are checked in order, since user agents also carry
+// the markers of the browsers they descend from.
+var (
+	browsers = []userAgentMarker{
+		{"Edg/", "Edge"}, {"OPR/", "Opera"}, {"Firefox/", "Firefox"}, {"FxiOS/", "Firefox"},
+		{"Chrome/", "Chrome"}, {"CriOS/", "Chrome"}, {"Safari/", "Safari"},
+	}
+	systems = []userAgentMarker{
+		{"Windows", "Windows"}, {"iPhone", "iOS"}, {"iPad", "iPadOS"}, {"Android", "Android"},
+		{"CrOS", "ChromeOS"}, {"Mac OS X", "macOS"}, {"Linux", "Linux"},
+	}
+)
+
+// Device names the browser and operating system of the user agent, such as
+// "Firefox on Windows".
+func (s UserSession) Device() string {
+	browser := firstMatch(s.UserAgent, browsers)
+	system := firstMatch(s.UserAgent, systems)
+
+	switch {
+	case browser != "" && system != "":
+		return browser + " on " + system
+	case browser != "":
+		return browser
+	case system != "":
+		return system
+	default:
+		return "Unknown device"
+	}
+}
+
+// firstMatch returns the name of the first marker found in the user agent.
+func firstMatch(userAgent string, markers []userAgentMarker) string {
+	for _, m := range markers {
+		if strings.Contains(userAgent, m.marker) {
+			return m.name
+		}
+	}
+	return ""
+}
+
+// truncate cuts s to at most n runes.
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+// Invite lets people register while registration is by invitation. Only a
+// hash of its code is kept, and it works MaxUses times until it expires.
+type Invite struct {
+	ID        ID
+	CodeHash  string
+	MaxUses   int
+	Uses      int
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+func NewInvite(id ID, codeHash string, maxUses int, createdAt, expiresAt time.Time) (*Invite, error) {
+	if maxUses < 1 {
+		return nil, ErrInvalidInviteUses
+	}
+	if !expiresAt.After(createdAt) {
+		return nil, ErrInvalidInviteExpiry
+	}
+
+	return &Invite{
+		ID:        id,
+		CodeHash:  codeHash,
+		MaxUses:   maxUses,
+		CreatedAt: createdAt,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (i *Invite) IsUsable(at time.Time) bool {
+	return i.Uses < i.MaxUses && at.Before(i.ExpiresAt)
+}
+
+// Use spends one use of the invite.
+func (i *Invite) Use(at time.Time) error {
+	if !i.IsUsable(at) {
+		return ErrInvalidInvite
+	}
+	i.Uses++
+	return nil
+}
@@ -1,7 +1,9 @@
 package identity
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
 	"github.com/stretchr/testify/assert"
@@ -28,3 +30,299 @@ func TestNewUser(t *testing.T) {
 		assert.Equal(t, currency, user.Currency)
 	})
 }
+
+func TestPasswordReset_Use(t *testing.T) {
+	id, _ := identifier.NewID()
+	userID, _ := identifier.NewID()
+	createdAt := time.Date(2024, time.March, 12, 9, 0, 0, 0, time.UTC)
+
+	t.Run("can be used once before it expires", func(t *testing.T) {
+		// Arrange
+		reset := NewPasswordReset(id, userID, "hash", createdAt)
+		at := createdAt.Add(30 * time.Minute)
+
+		// Act
+		err := reset.Use(at)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, &at, reset.UsedAt)
+		assert.ErrorIs(t, reset.Use(at), ErrInvalidResetToken)
+	})
+
+	t.Run("cannot be used once expired", func(t *testing.T) {
+		// Arrange
+		reset := NewPasswordReset(id, userID, "hash", createdAt)
+
+		// Act
+		err := reset.Use(createdAt.Add(PasswordResetTTL))
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidResetToken)
+		assert.Nil(t, reset.UsedAt)
+	})
+}
+
+func TestUser_ChangeEmail(t *testing.T) {
+	// Arrange
+	email, _ := NewEmailVO("test@example.com")
+	user := User{Email: email, EmailVerified: true}
+	newEmail, _ := NewEmailVO("new@example.com")
+
+	// Act
+	user.ChangeEmail(email)
+	keptVerified := user.EmailVerified
+	user.ChangeEmail(newEmail)
+
+	// Assert
+	assert.True(t, keptVerified)
+	assert.Equal(t, newEmail, user.Email)
+	assert.False(t, user.EmailVerified)
+}
+
+func TestUser_RecordVerificationSent(t *testing.T) {
+	// Arrange
+	user := User{}
+	sentAt := time.Date(2024, time.March, 12, 9, 0, 0, 0, time.UTC)
+
+	// Act
+	first := user.RecordVerificationSent(sentAt)
+	tooSoon := user.RecordVerificationSent(sentAt.Add(time.Minute))
+	later := user.RecordVerificationSent(sentAt.Add(VerificationResendInterval))
+
+	// Assert
+	assert.NoError(t, first)
+	assert.ErrorIs(t, tooSoon, ErrVerificationRateLimited)
+	assert.NoError(t, later)
+	assert.Equal(t, sentAt.Add(VerificationResendInterval), *user.VerificationSentAt)
+}
+
+func TestTwoFactor_Confirm(t *testing.T) {
+	userID, _ := identifier.NewID()
+	at := time.Date(2024, time.March, 12, 9, 0, 0, 0, time.UTC)
+
+	t.Run("enables it with the recovery codes", func(t *testing.T) {
+		// Arrange
+		twoFactor := NewTwoFactor(userID, "SECRET")
+
+		// Act
+		err := twoFactor.Confirm(100, []string{"a", "b"}, at)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, twoFactor.IsEnabled())
+		assert.Equal(t, int64(100), twoFactor.LastUsedStep)
+		assert.Equal(t, 2, twoFactor.RecoveryCodesLeft())
+	})
+
+	t.Run("cannot be confirmed twice", func(t *testing.T) {
+		// Arrange
+		twoFactor := NewTwoFactor(userID, "SECRET")
+		assert.NoError(t, twoFactor.Confirm(100, nil, at))
+
+		// Act
+		err := twoFactor.Confirm(101, nil, at)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrTwoFactorAlreadyEnabled)
+	})
+}
+
+func TestTwoFactor_UseStep(t *testing.T) {
+	// Arrange
+	userID, _ := identifier.NewID()
+	twoFactor := NewTwoFactor(userID, "SECRET")
+
+	// Act & Assert
+	assert.NoError(t, twoFactor.UseStep(100))
+	assert.ErrorIs(t, twoFactor.UseStep(100), ErrInvalidTwoFactorCode)
+	assert.ErrorIs(t, twoFactor.UseStep(99), ErrInvalidTwoFactorCode)
+	assert.NoError(t, twoFactor.UseStep(101))
+}
+
+func TestTwoFactor_UseRecoveryCode(t *testing.T) {
+	// Arrange
+	userID, _ := identifier.NewID()
+	at := time.Date(2024, time.March, 12, 9, 0, 0, 0, time.UTC)
+	twoFactor := NewTwoFactor(userID, "SECRET")
+	assert.NoError(t, twoFactor.Confirm(100, []string{"a", "b"}, at))
+
+	// Act
+	err := twoFactor.UseRecoveryCode("a", at)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, twoFactor.RecoveryCodesLeft())
+	assert.ErrorIs(t, twoFactor.UseRecoveryCode("a", at), ErrInvalidTwoFactorCode)
+	assert.ErrorIs(t, twoFactor.UseRecoveryCode("c", at), ErrInvalidTwoFactorCode)
+}
+
+func TestNewPasskey(t *testing.T) {
+	id, _ := identifier.NewID()
+	userID, _ := identifier.NewID()
+	at := time.Date(2024, time.March, 12, 9, 0, 0, 0, time.UTC)
+
+	t.Run("trims the name", func(t *testing.T) {
+		// Act
+		passkey, err := NewPasskey(id, userID, "  Laptop  ", []byte{1}, []byte{2}, 7, at)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "Laptop", passkey.Name)
+		assert.Equal(t, uint32(7), passkey.SignCount)
+		assert.Nil(t, passkey.LastUsedAt)
+	})
+
+	t.Run("requires a name of at most 64 characters", func(t *testing.T) {
+		// Act
+		_, blank := NewPasskey(id, userID, " ", []byte{1}, []byte{2}, 0, at)
+		_, tooLong := NewPasskey(id, userID, strings.Repeat("ą", PasskeyNameMaxLength+1), []byte{1}, []byte{2}, 0, at)
+
+		// Assert
+		assert.ErrorIs(t, blank, ErrInvalidPasskeyName)
+		assert.ErrorIs(t, tooLong, ErrInvalidPasskeyName)
+	})
+}
+
+func TestPasskey_Use(t *testing.T) {
+	// Arrange
+	id, _ := identifier.NewID()
+	userID, _ := identifier.NewID()
+	at := time.Date(2024, time.March, 12, 9, 0, 0, 0, time.UTC)
+	passkey, _ := NewPasskey(id, userID, "Phone", []byte{1}, []byte{2}, 3, at)
+
+	// Act
+	passkey.Use(4, at.Add(time.Hour))
+
+	// Assert
+	assert.Equal(t, uint32(4), passkey.SignCount)
+	assert.Equal(t, at.Add(time.Hour), *passkey.LastUsedAt)
+}
+
+func TestNewUserSession(t *testing.T) {
+	// Arrange
+	id, _ := identifier.NewID()
+	userID, _ := identifier.NewID()
+	at := time.Date(2024, time.March, 12, 9, 0, 0, 0, time.UTC)
+
+	// Act
+	session := NewUserSession(id, userID, "203.0.113.7", strings.Repeat("a", UserAgentMaxLength+10), at)
+
+	// Assert
+	assert.Equal(t, "203.0.113.7", session.IPAddress)
+	assert.Len(t, session.UserAgent, UserAgentMaxLength)
+	assert.Equal(t, at, session.CreatedAt)
+	assert.Equal(t, at, session.LastSeenAt)
+}
+
+func TestUserSession_Seen(t *testing.T) {
+	// Arrange
+	id, _ := identifier.NewID()
+	userID, _ := identifier.NewID()
+	at := time.Date(2024, time.March, 12, 9, 0, 0, 0, time.UTC)
+	session := NewUserSession(id, userID, "203.0.113.7", "Mozilla/5.0", at)
+
+	// Act
+	session.Seen("198.51.100.2", at.Add(time.Hour))
+
+	// Assert
+	assert.Equal(t, "198.51.100.2", session.IPAddress)
+	assert.Equal(t, at, session.CreatedAt)
+	assert.Equal(t, at.Add(time.Hour), session.LastSeenAt)
+}
+
+func TestUserSession_Device(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		want      string
+	}{
+		{
+			name:      "Firefox on Windows",
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:128.0) Gecko/20100101 Firefox/128.0",
+			want:      "Firefox on Windows",
+		},
+		{
+			name:      "Edge carries the Chrome marker too",
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36 Edg/126.0.0.0",
+			want:      "Edge on Windows",
+		},
+		{
+			name:      "Safari on iPhone mentions Mac OS X",
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1",
+			want:      "Safari on iOS",
+		},
+		{
+			name:      "Chrome on Android mentions Linux",
+			userAgent: "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Mobile Safari/537.36",
+			want:      "Chrome on Android",
+		},
+		{
+			name:      "unknown user agent",
+			userAgent: "curl/8.5.0",
+			want:      "Unknown device",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := UserSession{UserAgent: tt.userAgent}
+			assert.Equal(t, tt.want, session.Device())
+		})
+	}
+}
+
+func TestNewInvite(t *testing.T) {
+	id, _ := identifier.NewID()
+	createdAt := time.Date(2024, time.March, 12, 9, 0, 0, 0, time.UTC)
+
+	t.Run("creates valid invite", func(t *testing.T) {
+		// Act
+		invite, err := NewInvite(id, "hash", 3, createdAt, createdAt.Add(24*time.Hour))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 3, invite.MaxUses)
+		assert.Zero(t, invite.Uses)
+	})
+
+	t.Run("rejects an invite without uses", func(t *testing.T) {
+		_, err := NewInvite(id, "hash", 0, createdAt, createdAt.Add(24*time.Hour))
+		assert.ErrorIs(t, err, ErrInvalidInviteUses)
+	})
+
+	t.Run("rejects an invite already expired", func(t *testing.T) {
+		_, err := NewInvite(id, "hash", 1, createdAt, createdAt)
+		assert.ErrorIs(t, err, ErrInvalidInviteExpiry)
+	})
+}
+
+func TestInvite_Use(t *testing.T) {
+	id, _ := identifier.NewID()
+	createdAt := time.Date(2024, time.March, 12, 9, 0, 0, 0, time.UTC)
+	expiresAt := createdAt.Add(24 * time.Hour)
+
+	t.Run("can be used up to its uses", func(t *testing.T) {
+		// Arrange
+		invite, _ := NewInvite(id, "hash", 2, createdAt, expiresAt)
+		at := createdAt.Add(time.Hour)
+
+		// Act & Assert
+		assert.NoError(t, invite.Use(at))
+		assert.NoError(t, invite.Use(at))
+		assert.ErrorIs(t, invite.Use(at), ErrInvalidInvite)
+		assert.Equal(t, 2, invite.Uses)
+	})
+
+	t.Run("cannot be used once expired", func(t *testing.T) {
+		// Arrange
+		invite, _ := NewInvite(id, "hash", 2, createdAt, expiresAt)
+
+		// Act
+		err := invite.Use(expiresAt)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidInvite)
+		assert.Zero(t, invite.Uses)
+	})
+}
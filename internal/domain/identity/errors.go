@@ -15,6 +15,27 @@ var (
 	ErrInvalidHash        = errors.New("invalid password hash")
 	ErrUserNotFound       = errors.New("user not found")
 	ErrUserAlreadyExists  = errors.New("user already exists")
+	ErrEmailTaken         = errors.New("email is already in use")
+	ErrUsernameTaken      = errors.New("username is already in use")
 	ErrInvalidCurrency    = errors.New("invalid currency code")
 	ErrEmptyCurrency      = errors.New("currency cannot be empty")
+	ErrInvalidResetToken  = errors.New("password reset link is invalid or has expired")
+
+	ErrInvalidVerificationToken = errors.New("verification link is invalid or has expired")
+	ErrVerificationRateLimited  = errors.New("a verification link was sent recently")
+
+	ErrTwoFactorNotFound       = errors.New("two-factor authentication is not set up")
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrInvalidTwoFactorCode    = errors.New("invalid authentication code")
+
+	ErrPasskeyNotFound          = errors.New("passkey not found")
+	ErrPasskeyAlreadyRegistered = errors.New("passkey is already registered")
+	ErrInvalidPasskeyName       = errors.New("passkey name must be between 1 and 64 characters")
+
+	ErrUserSessionNotFound = errors.New("session not found")
+
+	ErrInvalidInvite       = errors.New("invite code is invalid, used up or expired")
+	ErrInviteNotFound      = errors.New("invite not found")
+	ErrInvalidInviteUses   = errors.New("an invite must allow at least one use")
+	ErrInvalidInviteExpiry = errors.New("an invite must expire in the future")
 )
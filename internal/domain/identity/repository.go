@@ -1,6 +1,9 @@
 package identity
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // UserRepository defines the contract for user data persistence operations.
 type UserRepository interface {
@@ -11,3 +14,48 @@ type UserRepository interface {
 	ExistsByEmail(ctx context.Context, email EmailVO) (bool, error)
 	ExistsByUsername(ctx context.Context, username UsernameVO) (bool, error)
 }
+
+// PasswordResetRepository defines the contract for password reset persistence.
+type PasswordResetRepository interface {
+	Save(ctx context.Context, reset PasswordReset) error
+	FindByTokenHash(ctx context.Context, tokenHash string) (PasswordReset, error)
+	// DeleteByUserID removes every reset of the user, so that only the one
+	// requested last can be used.
+	DeleteByUserID(ctx context.Context, userID ID) error
+}
+
+// TwoFactorRepository defines the contract for two-factor authentication
+// persistence. A user has at most one, pending or enabled.
+type TwoFactorRepository interface {
+	Save(ctx context.Context, twoFactor TwoFactor) error
+	FindByUserID(ctx context.Context, userID ID) (TwoFactor, error)
+	DeleteByUserID(ctx context.Context, userID ID) error
+}
+
+// PasskeyRepository defines the contract for passkey persistence.
+type PasskeyRepository interface {
+	Save(ctx context.Context, passkey Passkey) error
+	FindByCredentialID(ctx context.Context, credentialID []byte) (Passkey, error)
+	FindByUserID(ctx context.Context, userID ID) ([]Passkey, error)
+	// Delete removes the passkey if it belongs to the user.
+	Delete(ctx context.Context, userID ID, id ID) error
+}
+
+// UserSessionRepository defines the contract for the persistence of session
+// records.
+type UserSessionRepository interface {
+	Save(ctx context.Context, session UserSession) error
+	FindByID(ctx context.Context, userID ID, id ID) (UserSession, error)
+	FindByUserID(ctx context.Context, userID ID) ([]UserSession, error)
+	// DeleteSeenBefore removes the records of the user last used before the
+	// given time.
+	DeleteSeenBefore(ctx context.Context, userID ID, before time.Time) error
+}
+
+// InviteRepository defines the contract for invite persistence.
+type InviteRepository interface {
+	Save(ctx context.Context, invite Invite) error
+	FindByCodeHash(ctx context.Context, codeHash string) (Invite, error)
+	FindAll(ctx context.Context) ([]Invite, error)
+	Delete(ctx context.Context, id ID) error
+}
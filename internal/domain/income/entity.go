@@ -15,6 +15,9 @@ type Income struct {
 	Amount     money.Money
 	Source     SourceVO
 	ReceivedAt time.Time
+	// AccountID is the account the income was paid into. It is zero when
+	// the income is not tied to an account.
+	AccountID ID
 }
 
 func NewIncome(id ID, userID ID, amount money.Money, source SourceVO, receivedAt time.Time) (*Income, error) {
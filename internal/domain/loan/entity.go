@@ -0,0 +1,210 @@
+package loan
+
+import (
+	"math"
+
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+)
+
+type ID = identifier.ID
+
+// Loan is money borrowed at a fixed yearly rate and repaid in equal monthly
+// payments over the term, the first one due in StartMonth. Its payments are
+// recorded as expenses in the category CategoryID when one is linked.
+type Loan struct {
+	ID         ID
+	UserID     ID
+	Name       NameVO
+	Principal  money.Money
+	Rate       RateVO
+	TermMonths int
+	StartMonth string
+	CategoryID ID
+}
+
+// Payment is the total of the paid expenses recorded in the category of a
+// loan in a month.
+type Payment struct {
+	Month  string
+	Amount money.Money
+}
+
+// Installment is a row of an amortization schedule. Payment is the part of
+// the monthly payment due, split into Principal and Interest; Extra is paid
+// on top of it and goes to principal only. Balance is what is owed after it.
+type Installment struct {
+	Number    int
+	Month     string
+	Payment   money.Money
+	Principal money.Money
+	Interest  money.Money
+	Extra     money.Money
+	Balance   money.Money
+}
+
+// Schedule is the amortization of a loan from its first payment to payoff.
+type Schedule struct {
+	Payment       money.Money
+	Installments  []Installment
+	TotalInterest money.Money
+	PayoffMonth   string
+}
+
+// Extra is paid towards principal on top of the scheduled payment: Monthly
+// every month from From on, or from the first payment when From is blank,
+// plus the amounts in OneOff in their month.
+type Extra struct {
+	Monthly money.Money
+	From    string
+	OneOff  map[string]money.Money
+}
+
+func (e Extra) in(month string) int64 {
+	var cents int64
+	if month >= e.From {
+		cents = e.Monthly.Cents()
+	}
+	if oneOff, ok := e.OneOff[month]; ok {
+		cents += oneOff.Cents()
+	}
+	return max(cents, 0)
+}
+
+func NewLoan(id ID, userID ID, name NameVO, principal money.Money, rate RateVO, termMonths int, startMonth string, categoryID ID) (*Loan, error) {
+	l := &Loan{
+		ID:     id,
+		UserID: userID,
+	}
+	if err := l.Update(name, principal, rate, termMonths, startMonth, categoryID); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *Loan) Update(name NameVO, principal money.Money, rate RateVO, termMonths int, startMonth string, categoryID ID) error {
+	isPositive, err := principal.IsPositive()
+	if err != nil || !isPositive {
+		return ErrInvalidPrincipal
+	}
+	if termMonths < 1 || termMonths > maxTermMonths {
+		return ErrInvalidTerm
+	}
+	if !validMonth(startMonth) {
+		return ErrInvalidMonth
+	}
+
+	l.Name = name
+	l.Principal = principal
+	l.Rate = rate
+	l.TermMonths = termMonths
+	l.StartMonth = startMonth
+	l.CategoryID = categoryID
+	return nil
+}
+
+// Payment is the fixed monthly payment that repays the principal with
+// interest over the term, rounded to the nearest minor unit. The last
+// installment absorbs the rounding.
+func (l *Loan) Payment() money.Money {
+	principal := l.Principal.Cents()
+	n := l.TermMonths
+
+	var cents int64
+	if l.Rate.BasisPoints() == 0 {
+		cents = (principal + int64(n) - 1) / int64(n)
+	} else {
+		r := float64(l.Rate.BasisPoints()) / 120000
+		cents = int64(math.Round(float64(principal) * r / (1 - math.Pow(1+r, float64(-n)))))
+	}
+
+	payment, _ := money.New(cents, l.Principal.Currency())
+	return payment
+}
+
+// EndMonth is the month of the last payment when no extra is paid.
+func (l *Loan) EndMonth() string {
+	return addMonths(l.StartMonth, l.TermMonths-1)
+}
+
+// Schedule amortizes the loan month by month. Interest accrues on the
+// balance at a twelfth of the yearly rate, rounded half up to the minor
+// unit, and extra payments shorten the term rather than the payment.
+func (l *Loan) Schedule(extra Extra) Schedule {
+	currency := l.Principal.Currency()
+	newMoney := func(cents int64) money.Money {
+		m, _ := money.New(cents, currency)
+		return m
+	}
+
+	payment := l.Payment().Cents()
+	balance := l.Principal.Cents()
+	rate := l.Rate.BasisPoints()
+
+	schedule := Schedule{Payment: newMoney(payment)}
+	var totalInterest int64
+	for i := 0; i < l.TermMonths && balance > 0; i++ {
+		month := addMonths(l.StartMonth, i)
+		interest := (balance*rate + 60000) / 120000
+
+		principal := payment - interest
+		if principal > balance || i == l.TermMonths-1 {
+			principal = balance
+		}
+		extraCents := min(extra.in(month), balance-principal)
+		balance -= principal + extraCents
+		totalInterest += interest
+
+		schedule.Installments = append(schedule.Installments, Installment{
+			Number:    i + 1,
+			Month:     month,
+			Payment:   newMoney(principal + interest),
+			Principal: newMoney(principal),
+			Interest:  newMoney(interest),
+			Extra:     newMoney(extraCents),
+			Balance:   newMoney(balance),
+		})
+		schedule.PayoffMonth = month
+	}
+	schedule.TotalInterest = newMoney(totalInterest)
+
+	return schedule
+}
+
+// BalanceAfter is what is still owed once the installments due up to and
+// including the month are paid. It is the principal before the first one.
+func (s Schedule) BalanceAfter(principal money.Money, month string) money.Money {
+	balance := principal
+	for _, installment := range s.Installments {
+		if installment.Month > month {
+			break
+		}
+		balance = installment.Balance
+	}
+	return balance
+}
+
+// Find returns the installment due in the month.
+func (s Schedule) Find(month string) (Installment, bool) {
+	for _, installment := range s.Installments {
+		if installment.Month == month {
+			return installment, true
+		}
+	}
+	return Installment{}, false
+}
+
+// Overpayments returns what was paid above the scheduled payment in each
+// month, so recorded payments can be fed back into the schedule as extra.
+func (l *Loan) Overpayments(payments []Payment) map[string]money.Money {
+	scheduled := l.Payment().Cents()
+	overpayments := make(map[string]money.Money)
+	for _, p := range payments {
+		if p.Month < l.StartMonth || p.Month > l.EndMonth() || p.Amount.Cents() <= scheduled {
+			continue
+		}
+		over, _ := money.New(p.Amount.Cents()-scheduled, l.Principal.Currency())
+		overpayments[p.Month] = over
+	}
+	return overpayments
+}
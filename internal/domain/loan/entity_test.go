@@ -0,0 +1,189 @@
+package loan
+
+import (
+	"testing"
+
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLoan(t *testing.T, principalCents int64, basisPoints int64, termMonths int) *Loan {
+	t.Helper()
+
+	id, _ := identifier.NewID()
+	userID, _ := identifier.NewID()
+	name, err := NewNameVO("Mortgage")
+	require.NoError(t, err)
+	principal, err := money.New(principalCents, "USD")
+	require.NoError(t, err)
+	rate, err := NewRateVO(basisPoints)
+	require.NoError(t, err)
+
+	l, err := NewLoan(id, userID, name, principal, rate, termMonths, "2024-01", identifier.ID{})
+	require.NoError(t, err)
+	return l
+}
+
+func usd(cents int64) money.Money {
+	m, _ := money.New(cents, "USD")
+	return m
+}
+
+func TestNewLoan(t *testing.T) {
+	id, _ := identifier.NewID()
+	userID, _ := identifier.NewID()
+	name, _ := NewNameVO("Car")
+	rate, _ := NewRateVO(500)
+
+	tests := []struct {
+		name      string
+		principal money.Money
+		term      int
+		month     string
+		wantErr   error
+	}{
+		{name: "valid", principal: usd(2000000), term: 60, month: "2024-01"},
+		{name: "zero principal", principal: usd(0), term: 60, month: "2024-01", wantErr: ErrInvalidPrincipal},
+		{name: "no term", principal: usd(2000000), term: 0, month: "2024-01", wantErr: ErrInvalidTerm},
+		{name: "term too long", principal: usd(2000000), term: 601, month: "2024-01", wantErr: ErrInvalidTerm},
+		{name: "invalid month", principal: usd(2000000), term: 60, month: "2024-13", wantErr: ErrInvalidMonth},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLoan(id, userID, name, tt.principal, rate, tt.term, tt.month, identifier.ID{})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewRateFromPercent(t *testing.T) {
+	rate, err := NewRateFromPercent(6.5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(650), rate.BasisPoints())
+
+	_, err = NewRateFromPercent(-1)
+	assert.ErrorIs(t, err, ErrInvalidRate)
+	_, err = NewRateFromPercent(51)
+	assert.ErrorIs(t, err, ErrInvalidRate)
+}
+
+func TestLoan_Schedule(t *testing.T) {
+	t.Run("30 year mortgage of 200,000 at 6.5%", func(t *testing.T) {
+		l := newTestLoan(t, 20000000, 650, 360)
+
+		schedule := l.Schedule(Extra{})
+
+		assert.Equal(t, int64(126414), schedule.Payment.Cents())
+		require.Len(t, schedule.Installments, 360)
+
+		first := schedule.Installments[0]
+		assert.Equal(t, "2024-01", first.Month)
+		assert.Equal(t, int64(108333), first.Interest.Cents())
+		assert.Equal(t, int64(18081), first.Principal.Cents())
+		assert.Equal(t, int64(19981919), first.Balance.Cents())
+
+		last := schedule.Installments[359]
+		assert.Equal(t, "2053-12", last.Month)
+		assert.Equal(t, int64(125956), last.Payment.Cents())
+		assert.Equal(t, int64(0), last.Balance.Cents())
+		assert.Equal(t, "2053-12", schedule.PayoffMonth)
+		assert.Equal(t, int64(25508582), schedule.TotalInterest.Cents())
+	})
+
+	t.Run("5 year car loan of 20,000 at 5%", func(t *testing.T) {
+		l := newTestLoan(t, 2000000, 500, 60)
+
+		schedule := l.Schedule(Extra{})
+
+		assert.Equal(t, int64(37742), schedule.Payment.Cents())
+		require.Len(t, schedule.Installments, 60)
+		assert.Equal(t, int64(8333), schedule.Installments[0].Interest.Cents())
+		assert.Equal(t, int64(29409), schedule.Installments[0].Principal.Cents())
+		assert.Equal(t, int64(37774), schedule.Installments[59].Payment.Cents())
+		assert.Equal(t, int64(264552), schedule.TotalInterest.Cents())
+	})
+
+	t.Run("interest free loan splits the principal evenly", func(t *testing.T) {
+		l := newTestLoan(t, 1200000, 0, 12)
+
+		schedule := l.Schedule(Extra{})
+
+		assert.Equal(t, int64(100000), schedule.Payment.Cents())
+		assert.Len(t, schedule.Installments, 12)
+		assert.Equal(t, int64(0), schedule.TotalInterest.Cents())
+	})
+
+	t.Run("rounding is absorbed by the last installment", func(t *testing.T) {
+		l := newTestLoan(t, 100000, 0, 3)
+
+		schedule := l.Schedule(Extra{})
+
+		require.Len(t, schedule.Installments, 3)
+		assert.Equal(t, int64(33334), schedule.Installments[0].Payment.Cents())
+		assert.Equal(t, int64(33332), schedule.Installments[2].Payment.Cents())
+		assert.Equal(t, int64(0), schedule.Installments[2].Balance.Cents())
+	})
+
+	t.Run("a monthly extra payment shortens the term", func(t *testing.T) {
+		l := newTestLoan(t, 2000000, 500, 60)
+
+		schedule := l.Schedule(Extra{Monthly: usd(10000)})
+
+		require.Len(t, schedule.Installments, 47)
+		assert.Equal(t, int64(1960591), schedule.Installments[0].Balance.Cents())
+		assert.Equal(t, "2027-11", schedule.PayoffMonth)
+		assert.Equal(t, int64(202541), schedule.TotalInterest.Cents())
+		assert.Equal(t, int64(0), schedule.Installments[46].Balance.Cents())
+	})
+
+	t.Run("a monthly extra payment can start later", func(t *testing.T) {
+		l := newTestLoan(t, 1200000, 0, 12)
+
+		schedule := l.Schedule(Extra{Monthly: usd(100000), From: "2024-07"})
+
+		require.Len(t, schedule.Installments, 9)
+		assert.Equal(t, int64(0), schedule.Installments[5].Extra.Cents())
+		assert.Equal(t, int64(100000), schedule.Installments[6].Extra.Cents())
+		assert.Equal(t, "2024-09", schedule.PayoffMonth)
+	})
+
+	t.Run("a one-off payment never takes the balance below zero", func(t *testing.T) {
+		l := newTestLoan(t, 1200000, 0, 12)
+
+		schedule := l.Schedule(Extra{OneOff: map[string]money.Money{"2024-02": usd(5000000)}})
+
+		require.Len(t, schedule.Installments, 2)
+		assert.Equal(t, int64(1000000), schedule.Installments[1].Extra.Cents())
+		assert.Equal(t, int64(0), schedule.Installments[1].Balance.Cents())
+		assert.Equal(t, "2024-02", schedule.PayoffMonth)
+	})
+}
+
+func TestSchedule_BalanceAfter(t *testing.T) {
+	l := newTestLoan(t, 1200000, 0, 12)
+	schedule := l.Schedule(Extra{})
+
+	assert.Equal(t, int64(1200000), schedule.BalanceAfter(l.Principal, "2023-12").Cents())
+	assert.Equal(t, int64(900000), schedule.BalanceAfter(l.Principal, "2024-03").Cents())
+	assert.Equal(t, int64(0), schedule.BalanceAfter(l.Principal, "2030-01").Cents())
+}
+
+func TestLoan_Overpayments(t *testing.T) {
+	l := newTestLoan(t, 1200000, 0, 12)
+
+	overpayments := l.Overpayments([]Payment{
+		{Month: "2023-12", Amount: usd(500000)},
+		{Month: "2024-01", Amount: usd(100000)},
+		{Month: "2024-02", Amount: usd(150000)},
+	})
+
+	assert.Len(t, overpayments, 1)
+	assert.Equal(t, int64(50000), overpayments["2024-02"].Cents())
+}
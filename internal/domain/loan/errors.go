@@ -0,0 +1,14 @@
+package loan
+
+import "errors"
+
+var (
+	ErrEmptyName        = errors.New("loan name cannot be empty")
+	ErrNameTooLong      = errors.New("loan name exceeds maximum length of 100 characters")
+	ErrInvalidPrincipal = errors.New("principal must be positive")
+	ErrInvalidRate      = errors.New("interest rate must be between 0 and 50 percent")
+	ErrInvalidTerm      = errors.New("term must be between 1 and 600 months")
+	ErrInvalidMonth     = errors.New("invalid month")
+	ErrInvalidExtra     = errors.New("extra payment cannot be negative")
+	ErrLoanNotFound     = errors.New("loan not found")
+)
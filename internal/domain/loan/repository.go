@@ -0,0 +1,12 @@
+package loan
+
+import "context"
+
+type LoanRepository interface {
+	Save(ctx context.Context, loan Loan) error
+	FindByID(ctx context.Context, userID ID, id ID) (Loan, error)
+	FindByUserID(ctx context.Context, userID ID) ([]Loan, error)
+	Delete(ctx context.Context, userID ID, id ID) error
+	// Payments sums the paid expenses recorded in the category by month.
+	Payments(ctx context.Context, userID ID, categoryID ID) ([]Payment, error)
+}
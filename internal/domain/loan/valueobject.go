@@ -0,0 +1,80 @@
+package loan
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	maxNameLength = 100
+	maxRate       = 5000
+	maxTermMonths = 600
+	monthLayout   = "2006-01"
+)
+
+type NameVO struct {
+	value string
+}
+
+func NewNameVO(value string) (NameVO, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return NameVO{}, ErrEmptyName
+	}
+	if len(value) > maxNameLength {
+		return NameVO{}, ErrNameTooLong
+	}
+	return NameVO{value: value}, nil
+}
+
+func (n NameVO) Value() string {
+	return n.value
+}
+
+func (n NameVO) String() string {
+	return n.value
+}
+
+func (n NameVO) Equals(other NameVO) bool {
+	return n.value == other.value
+}
+
+// RateVO is a yearly interest rate in basis points, so 6.5% is 650. Keeping
+// it whole lets the schedule be computed in minor units without drift.
+type RateVO struct {
+	basisPoints int64
+}
+
+func NewRateVO(basisPoints int64) (RateVO, error) {
+	if basisPoints < 0 || basisPoints > maxRate {
+		return RateVO{}, ErrInvalidRate
+	}
+	return RateVO{basisPoints: basisPoints}, nil
+}
+
+// NewRateFromPercent rounds a percentage such as 6.5 to basis points.
+func NewRateFromPercent(percent float64) (RateVO, error) {
+	return NewRateVO(int64(math.Round(percent * 100)))
+}
+
+func (r RateVO) BasisPoints() int64 {
+	return r.basisPoints
+}
+
+func (r RateVO) Percent() float64 {
+	return float64(r.basisPoints) / 100
+}
+
+func validMonth(month string) bool {
+	_, err := time.Parse(monthLayout, month)
+	return err == nil
+}
+
+func addMonths(month string, n int) string {
+	t, err := time.Parse(monthLayout, month)
+	if err != nil {
+		return month
+	}
+	return t.AddDate(0, n, 0).Format(monthLayout)
+}
@@ -0,0 +1,121 @@
+package networth
+
+import (
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+)
+
+type ID = identifier.ID
+
+// Asset is something owned, or owed when its kind is a liability, whose
+// value is entered by hand from time to time, such as an investment
+// portfolio or a house.
+type Asset struct {
+	ID     ID
+	UserID ID
+	Name   NameVO
+	Kind   Kind
+}
+
+func NewAsset(id ID, userID ID, name NameVO, kind Kind) (*Asset, error) {
+	a := &Asset{
+		ID:     id,
+		UserID: userID,
+	}
+	if err := a.Update(name, kind); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *Asset) Update(name NameVO, kind Kind) error {
+	if _, err := NewKind(kind.Value()); err != nil {
+		return err
+	}
+
+	a.Name = name
+	a.Kind = kind
+	return nil
+}
+
+// Valuation is the value of an asset at the end of a month. An asset has at
+// most one valuation per month, and it holds until the next one.
+type Valuation struct {
+	AssetID ID
+	Month   string
+	Value   money.Money
+}
+
+func NewValuation(assetID ID, month string, value money.Money) (*Valuation, error) {
+	if !validMonth(month) {
+		return nil, ErrInvalidMonth
+	}
+	isNegative, err := value.IsNegative()
+	if err != nil || isNegative {
+		return nil, ErrInvalidValue
+	}
+
+	return &Valuation{
+		AssetID: assetID,
+		Month:   month,
+		Value:   value,
+	}, nil
+}
+
+// ValueAt returns the latest of the valuations made in or before the month.
+// It reports false when the asset was not valued yet by then.
+func ValueAt(valuations []Valuation, month string) (Valuation, bool) {
+	var latest Valuation
+	found := false
+	for _, v := range valuations {
+		if v.Month > month || (found && v.Month <= latest.Month) {
+			continue
+		}
+		latest = v
+		found = true
+	}
+	return latest, found
+}
+
+// Snapshot is what is owned and what is owed at the end of a month.
+type Snapshot struct {
+	Month       string
+	Assets      money.Money
+	Liabilities money.Money
+}
+
+func NewSnapshot(month string, currency string) Snapshot {
+	zero, _ := money.New(0, currency)
+	return Snapshot{Month: month, Assets: zero, Liabilities: zero}
+}
+
+// Add counts a value owned, or owed when liability is set. A negative value,
+// such as the balance of a card in debt, is counted on the other side.
+func (s *Snapshot) Add(value money.Money, liability bool) error {
+	cents := value.Cents()
+	if cents < 0 {
+		cents = -cents
+		liability = !liability
+	}
+	amount, err := money.New(cents, value.Currency())
+	if err != nil {
+		return err
+	}
+
+	side := &s.Assets
+	if liability {
+		side = &s.Liabilities
+	}
+	total, err := side.Add(amount)
+	if err != nil {
+		return err
+	}
+	*side = total
+	return nil
+}
+
+// NetWorth is what is owned less what is owed.
+func (s Snapshot) NetWorth() money.Money {
+	netWorth, _ := s.Assets.Subtract(s.Liabilities)
+	return netWorth
+}
@@ -0,0 +1,98 @@
+package networth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func usd(cents int64) money.Money {
+	m, _ := money.New(cents, "USD")
+	return m
+}
+
+func TestNewAsset(t *testing.T) {
+	id, _ := identifier.NewID()
+	userID, _ := identifier.NewID()
+	name, _ := NewNameVO("House")
+
+	asset, err := NewAsset(id, userID, name, KindProperty)
+	require.NoError(t, err)
+	assert.Equal(t, KindProperty, asset.Kind)
+
+	_, err = NewAsset(id, userID, name, Kind("boat"))
+	assert.ErrorIs(t, err, ErrInvalidKind)
+}
+
+func TestNewValuation(t *testing.T) {
+	assetID, _ := identifier.NewID()
+
+	_, err := NewValuation(assetID, "2024-03", usd(0))
+	assert.NoError(t, err)
+
+	_, err = NewValuation(assetID, "2024-03", usd(-1))
+	assert.ErrorIs(t, err, ErrInvalidValue)
+
+	_, err = NewValuation(assetID, "March", usd(100))
+	assert.ErrorIs(t, err, ErrInvalidMonth)
+}
+
+func TestValueAt(t *testing.T) {
+	valuations := []Valuation{
+		{Month: "2024-05", Value: usd(300)},
+		{Month: "2024-01", Value: usd(100)},
+		{Month: "2024-03", Value: usd(200)},
+	}
+
+	_, ok := ValueAt(valuations, "2023-12")
+	assert.False(t, ok)
+
+	v, ok := ValueAt(valuations, "2024-04")
+	require.True(t, ok)
+	assert.Equal(t, int64(200), v.Value.Cents())
+
+	v, ok = ValueAt(valuations, "2025-01")
+	require.True(t, ok)
+	assert.Equal(t, "2024-05", v.Month)
+}
+
+func TestSnapshot_Add(t *testing.T) {
+	s := NewSnapshot("2024-03", "USD")
+
+	require.NoError(t, s.Add(usd(50000), false))
+	require.NoError(t, s.Add(usd(20000), true))
+	require.NoError(t, s.Add(usd(-5000), false))
+
+	assert.Equal(t, int64(50000), s.Assets.Cents())
+	assert.Equal(t, int64(25000), s.Liabilities.Cents())
+	assert.Equal(t, int64(25000), s.NetWorth().Cents())
+
+	eur, _ := money.New(100, "EUR")
+	assert.Error(t, s.Add(eur, false))
+	assert.Equal(t, int64(50000), s.Assets.Cents())
+}
+
+func TestMonthRange(t *testing.T) {
+	months, err := MonthRange("2023-11", "2024-02")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2023-11", "2023-12", "2024-01", "2024-02"}, months)
+
+	_, err = MonthRange("2024-02", "2023-11")
+	assert.ErrorIs(t, err, ErrInvalidRange)
+
+	_, err = MonthRange("2014-01", "2024-01")
+	assert.ErrorIs(t, err, ErrInvalidRange)
+
+	_, err = MonthRange("2024", "2024-01")
+	assert.ErrorIs(t, err, ErrInvalidMonth)
+}
+
+func TestLastDay(t *testing.T) {
+	day, err := LastDay("2024-02")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), day)
+}
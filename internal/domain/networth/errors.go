@@ -0,0 +1,14 @@
+package networth
+
+import "errors"
+
+var (
+	ErrEmptyName         = errors.New("asset name cannot be empty")
+	ErrNameTooLong       = errors.New("asset name exceeds maximum length of 100 characters")
+	ErrInvalidKind       = errors.New("invalid asset kind")
+	ErrInvalidMonth      = errors.New("invalid month")
+	ErrInvalidRange      = errors.New("the range must end after it starts and span at most 10 years")
+	ErrInvalidValue      = errors.New("value cannot be negative")
+	ErrAssetNotFound     = errors.New("asset not found")
+	ErrValuationNotFound = errors.New("valuation not found")
+)
@@ -0,0 +1,13 @@
+package networth
+
+import "context"
+
+type AssetRepository interface {
+	Save(ctx context.Context, asset Asset) error
+	FindByID(ctx context.Context, userID ID, id ID) (Asset, error)
+	FindByUserID(ctx context.Context, userID ID) ([]Asset, error)
+	Delete(ctx context.Context, userID ID, id ID) error
+	SaveValuation(ctx context.Context, valuation Valuation) error
+	DeleteValuation(ctx context.Context, userID ID, assetID ID, month string) error
+	Valuations(ctx context.Context, userID ID) ([]Valuation, error)
+}
@@ -0,0 +1,105 @@
+package networth
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	maxNameLength  = 100
+	maxRangeMonths = 120
+	monthLayout    = "2006-01"
+)
+
+type NameVO struct {
+	value string
+}
+
+func NewNameVO(value string) (NameVO, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return NameVO{}, ErrEmptyName
+	}
+	if len(value) > maxNameLength {
+		return NameVO{}, ErrNameTooLong
+	}
+	return NameVO{value: value}, nil
+}
+
+func (n NameVO) Value() string {
+	return n.value
+}
+
+func (n NameVO) String() string {
+	return n.value
+}
+
+func (n NameVO) Equals(other NameVO) bool {
+	return n.value == other.value
+}
+
+// Kind is what an asset entered by hand is. Debt is the only liability;
+// loans tracked in the app are counted on their own.
+type Kind string
+
+const (
+	KindInvestment Kind = "investment"
+	KindProperty   Kind = "property"
+	KindVehicle    Kind = "vehicle"
+	KindOther      Kind = "other"
+	KindDebt       Kind = "debt"
+)
+
+func NewKind(value string) (Kind, error) {
+	switch k := Kind(value); k {
+	case KindInvestment, KindProperty, KindVehicle, KindOther, KindDebt:
+		return k, nil
+	default:
+		return "", ErrInvalidKind
+	}
+}
+
+func (k Kind) Value() string {
+	return string(k)
+}
+
+// IsLiability reports whether the value of the asset is owed rather than
+// owned.
+func (k Kind) IsLiability() bool {
+	return k == KindDebt
+}
+
+// MonthRange lists the months from one month to another, both included.
+func MonthRange(from, to string) ([]string, error) {
+	f, err := time.Parse(monthLayout, from)
+	if err != nil {
+		return nil, ErrInvalidMonth
+	}
+	t, err := time.Parse(monthLayout, to)
+	if err != nil {
+		return nil, ErrInvalidMonth
+	}
+	if t.Before(f) || (t.Year()-f.Year())*12+int(t.Month()-f.Month()) >= maxRangeMonths {
+		return nil, ErrInvalidRange
+	}
+
+	var months []string
+	for m := f; !m.After(t); m = m.AddDate(0, 1, 0) {
+		months = append(months, m.Format(monthLayout))
+	}
+	return months, nil
+}
+
+// LastDay is the last day of the month.
+func LastDay(month string) (time.Time, error) {
+	t, err := time.Parse(monthLayout, month)
+	if err != nil {
+		return time.Time{}, ErrInvalidMonth
+	}
+	return t.AddDate(0, 1, -1), nil
+}
+
+func validMonth(month string) bool {
+	_, err := time.Parse(monthLayout, month)
+	return err == nil
+}
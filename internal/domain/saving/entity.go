@@ -0,0 +1,195 @@
+package saving
+
+import (
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+)
+
+type ID = identifier.ID
+
+// Goal is an amount to save by a target date. Money put aside for it is
+// recorded as monthly contributions.
+type Goal struct {
+	ID            ID
+	UserID        ID
+	Name          NameVO
+	Target        money.Money
+	StartMonth    string
+	TargetDate    time.Time
+	Contributions []*Contribution
+}
+
+type Contribution struct {
+	ID     ID
+	GoalID ID
+	Month  string
+	Amount money.Money
+}
+
+// Progress describes a goal at the end of a month. RequiredMonthly is what
+// must be put aside every month from that month on to reach the target in
+// time.
+type Progress struct {
+	Saved           money.Money
+	Remaining       money.Money
+	RequiredMonthly money.Money
+	Percent         float64
+	Status          Status
+}
+
+func NewGoal(id ID, userID ID, name NameVO, target money.Money, startMonth string, targetDate time.Time) (*Goal, error) {
+	if !validMonth(startMonth) {
+		return nil, ErrInvalidMonth
+	}
+
+	goal := &Goal{
+		ID:         id,
+		UserID:     userID,
+		StartMonth: startMonth,
+	}
+	if err := goal.Update(name, target, targetDate); err != nil {
+		return nil, err
+	}
+
+	return goal, nil
+}
+
+func (g *Goal) Update(name NameVO, target money.Money, targetDate time.Time) error {
+	isPositive, err := target.IsPositive()
+	if err != nil || !isPositive {
+		return ErrInvalidTarget
+	}
+	if MonthOf(targetDate) < g.StartMonth {
+		return ErrTargetDateBeforeStart
+	}
+
+	g.Name = name
+	g.Target = target
+	g.TargetDate = targetDate
+	return nil
+}
+
+// TargetMonth is the month of the target date, the last month in which
+// contributions count towards the goal on time.
+func (g *Goal) TargetMonth() string {
+	return MonthOf(g.TargetDate)
+}
+
+func (g *Goal) Contribute(id ID, month string, amount money.Money) (*Contribution, error) {
+	if !validMonth(month) || month < g.StartMonth {
+		return nil, ErrInvalidMonth
+	}
+	isPositive, err := amount.IsPositive()
+	if err != nil || !isPositive {
+		return nil, ErrInvalidContribution
+	}
+	if amount.Currency() != g.Target.Currency() {
+		return nil, ErrInvalidContribution
+	}
+
+	contribution := &Contribution{
+		ID:     id,
+		GoalID: g.ID,
+		Month:  month,
+		Amount: amount,
+	}
+	g.Contributions = append(g.Contributions, contribution)
+	return contribution, nil
+}
+
+func (g *Goal) FindContribution(id ID) (*Contribution, error) {
+	for _, c := range g.Contributions {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return nil, ErrContributionNotFound
+}
+
+func (g *Goal) RemoveContribution(id ID) error {
+	for i, c := range g.Contributions {
+		if c.ID == id {
+			g.Contributions = append(g.Contributions[:i], g.Contributions[i+1:]...)
+			return nil
+		}
+	}
+	return ErrContributionNotFound
+}
+
+// ContributedIn sums the contributions recorded for the month.
+func (g *Goal) ContributedIn(month string) money.Money {
+	return g.sumContributions(func(c *Contribution) bool { return c.Month == month })
+}
+
+// SavedBy sums the contributions recorded up to and including the month.
+func (g *Goal) SavedBy(month string) money.Money {
+	return g.sumContributions(func(c *Contribution) bool { return c.Month <= month })
+}
+
+func (g *Goal) sumContributions(include func(*Contribution) bool) money.Money {
+	var cents int64
+	for _, c := range g.Contributions {
+		if include(c) {
+			cents += c.Amount.Cents()
+		}
+	}
+	total, _ := money.New(cents, g.Target.Currency())
+	return total
+}
+
+// ProgressAt reports the goal as of the end of the month. The goal is on
+// track when the savings keep up with an even split of the target over the
+// months from the start to the target date.
+func (g *Goal) ProgressAt(month string) Progress {
+	currency := g.Target.Currency()
+	targetCents := g.Target.Cents()
+	savedCents := g.SavedBy(month).Cents()
+
+	progress := Progress{
+		Saved:   g.SavedBy(month),
+		Percent: min(float64(savedCents)/float64(targetCents)*100, 100),
+	}
+	progress.Remaining, _ = money.New(max(targetCents-savedCents, 0), currency)
+	progress.RequiredMonthly, _ = money.New(0, currency)
+
+	if savedCents >= targetCents {
+		progress.Status = StatusAchieved
+		return progress
+	}
+
+	// The monthly requirement does not move within a month as contributions
+	// come in: it spreads what was left at the start of the month.
+	remainingBefore := targetCents - g.SavedBy(previousMonth(month)).Cents()
+	monthsLeft := monthsBetween(month, g.TargetMonth())
+	if monthsLeft <= 0 {
+		progress.RequiredMonthly = progress.Remaining
+		progress.Status = StatusOverdue
+		return progress
+	}
+	progress.RequiredMonthly, _ = money.New(ceilDiv(remainingBefore, int64(monthsLeft)), currency)
+
+	elapsed := int64(max(monthsBetween(g.StartMonth, month), 0))
+	total := int64(monthsBetween(g.StartMonth, g.TargetMonth()))
+	expectedCents := targetCents * elapsed / total
+	if savedCents >= expectedCents {
+		progress.Status = StatusOnTrack
+	} else {
+		progress.Status = StatusBehind
+	}
+
+	return progress
+}
+
+func previousMonth(month string) string {
+	t, err := time.Parse(monthLayout, month)
+	if err != nil {
+		return month
+	}
+	return t.AddDate(0, -1, 0).Format(monthLayout)
+}
+
+func ceilDiv(a, b int64) int64 {
+	return (a + b - 1) / b
+}
@@ -0,0 +1,155 @@
+package saving
+
+import (
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGoal(t *testing.T) *Goal {
+	t.Helper()
+
+	id, _ := identifier.NewID()
+	userID, _ := identifier.NewID()
+	name, err := NewNameVO("Emergency fund")
+	require.NoError(t, err)
+	target, _ := money.New(120000, "USD")
+
+	goal, err := NewGoal(id, userID, name, target, "2024-01", time.Date(2024, time.December, 15, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	return goal
+}
+
+func contribute(t *testing.T, goal *Goal, month string, cents int64) *Contribution {
+	t.Helper()
+
+	id, _ := identifier.NewID()
+	amount, _ := money.New(cents, "USD")
+	contribution, err := goal.Contribute(id, month, amount)
+	require.NoError(t, err)
+	return contribution
+}
+
+func TestNewGoal(t *testing.T) {
+	id, _ := identifier.NewID()
+	userID, _ := identifier.NewID()
+	name, _ := NewNameVO("Car")
+	target, _ := money.New(500000, "USD")
+	targetDate := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("creates valid goal", func(t *testing.T) {
+		goal, err := NewGoal(id, userID, name, target, "2024-03", targetDate)
+
+		assert.NoError(t, err)
+		assert.Equal(t, id, goal.ID)
+		assert.Equal(t, userID, goal.UserID)
+		assert.Equal(t, name, goal.Name)
+		assert.Equal(t, target, goal.Target)
+		assert.Equal(t, "2024-03", goal.StartMonth)
+		assert.Equal(t, "2025-06", goal.TargetMonth())
+	})
+
+	t.Run("rejects invalid start month", func(t *testing.T) {
+		_, err := NewGoal(id, userID, name, target, "March", targetDate)
+		assert.ErrorIs(t, err, ErrInvalidMonth)
+	})
+
+	t.Run("rejects non-positive target", func(t *testing.T) {
+		zero, _ := money.New(0, "USD")
+		_, err := NewGoal(id, userID, name, zero, "2024-03", targetDate)
+		assert.ErrorIs(t, err, ErrInvalidTarget)
+	})
+
+	t.Run("rejects target date before start", func(t *testing.T) {
+		_, err := NewGoal(id, userID, name, target, "2025-07", targetDate)
+		assert.ErrorIs(t, err, ErrTargetDateBeforeStart)
+	})
+}
+
+func TestNewNameVO(t *testing.T) {
+	_, err := NewNameVO("  ")
+	assert.ErrorIs(t, err, ErrEmptyName)
+
+	_, err = NewNameVO(string(make([]byte, 101)))
+	assert.ErrorIs(t, err, ErrNameTooLong)
+}
+
+func TestGoal_Contribute(t *testing.T) {
+	goal := newTestGoal(t)
+	id, _ := identifier.NewID()
+
+	t.Run("rejects month before start", func(t *testing.T) {
+		amount, _ := money.New(1000, "USD")
+		_, err := goal.Contribute(id, "2023-12", amount)
+		assert.ErrorIs(t, err, ErrInvalidMonth)
+	})
+
+	t.Run("rejects non-positive amount", func(t *testing.T) {
+		amount, _ := money.New(0, "USD")
+		_, err := goal.Contribute(id, "2024-02", amount)
+		assert.ErrorIs(t, err, ErrInvalidContribution)
+	})
+
+	t.Run("rejects another currency", func(t *testing.T) {
+		amount, _ := money.New(1000, "EUR")
+		_, err := goal.Contribute(id, "2024-02", amount)
+		assert.ErrorIs(t, err, ErrInvalidContribution)
+	})
+
+	t.Run("records and removes contributions", func(t *testing.T) {
+		first := contribute(t, goal, "2024-02", 10000)
+		contribute(t, goal, "2024-02", 5000)
+		contribute(t, goal, "2024-03", 2000)
+
+		assert.Equal(t, int64(15000), goal.ContributedIn("2024-02").Cents())
+		assert.Equal(t, int64(17000), goal.SavedBy("2024-03").Cents())
+
+		require.NoError(t, goal.RemoveContribution(first.ID))
+		assert.Equal(t, int64(5000), goal.ContributedIn("2024-02").Cents())
+		assert.ErrorIs(t, goal.RemoveContribution(first.ID), ErrContributionNotFound)
+	})
+}
+
+func TestGoal_ProgressAt(t *testing.T) {
+	goal := newTestGoal(t)
+	contribute(t, goal, "2024-01", 10000)
+	contribute(t, goal, "2024-02", 10000)
+
+	t.Run("on track", func(t *testing.T) {
+		progress := goal.ProgressAt("2024-02")
+
+		assert.Equal(t, StatusOnTrack, progress.Status)
+		assert.Equal(t, int64(20000), progress.Saved.Cents())
+		assert.Equal(t, int64(100000), progress.Remaining.Cents())
+		assert.Equal(t, int64(10000), progress.RequiredMonthly.Cents())
+		assert.InDelta(t, 16.67, progress.Percent, 0.01)
+	})
+
+	t.Run("behind", func(t *testing.T) {
+		progress := goal.ProgressAt("2024-05")
+
+		assert.Equal(t, StatusBehind, progress.Status)
+		assert.Equal(t, int64(12500), progress.RequiredMonthly.Cents())
+	})
+
+	t.Run("overdue", func(t *testing.T) {
+		progress := goal.ProgressAt("2025-01")
+
+		assert.Equal(t, StatusOverdue, progress.Status)
+		assert.Equal(t, int64(100000), progress.RequiredMonthly.Cents())
+	})
+
+	t.Run("achieved", func(t *testing.T) {
+		contribute(t, goal, "2024-06", 100000)
+		progress := goal.ProgressAt("2024-06")
+
+		assert.Equal(t, StatusAchieved, progress.Status)
+		assert.Equal(t, int64(0), progress.Remaining.Cents())
+		assert.Equal(t, int64(0), progress.RequiredMonthly.Cents())
+		assert.Equal(t, 100.0, progress.Percent)
+	})
+}
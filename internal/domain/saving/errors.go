@@ -0,0 +1,14 @@
+package saving
+
+import "errors"
+
+var (
+	ErrEmptyName             = errors.New("goal name cannot be empty")
+	ErrNameTooLong           = errors.New("goal name exceeds maximum length of 100 characters")
+	ErrInvalidTarget         = errors.New("target amount must be positive")
+	ErrInvalidMonth          = errors.New("invalid month")
+	ErrTargetDateBeforeStart = errors.New("target date cannot be before the goal starts")
+	ErrInvalidContribution   = errors.New("contribution must be positive")
+	ErrContributionNotFound  = errors.New("contribution not found")
+	ErrGoalNotFound          = errors.New("goal not found")
+)
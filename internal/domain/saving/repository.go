@@ -0,0 +1,10 @@
+package saving
+
+import "context"
+
+type GoalRepository interface {
+	Save(ctx context.Context, goal Goal) error
+	FindByID(ctx context.Context, userID ID, id ID) (Goal, error)
+	FindByUserID(ctx context.Context, userID ID) ([]Goal, error)
+	Delete(ctx context.Context, userID ID, id ID) error
+}
@@ -0,0 +1,66 @@
+package saving
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	maxNameLength = 100
+	monthLayout   = "2006-01"
+)
+
+type NameVO struct {
+	value string
+}
+
+func NewNameVO(value string) (NameVO, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return NameVO{}, ErrEmptyName
+	}
+	if len(value) > maxNameLength {
+		return NameVO{}, ErrNameTooLong
+	}
+	return NameVO{value: value}, nil
+}
+
+func (n NameVO) Value() string {
+	return n.value
+}
+
+func (n NameVO) String() string {
+	return n.value
+}
+
+func (n NameVO) Equals(other NameVO) bool {
+	return n.value == other.value
+}
+
+// Status tells how a goal is doing against its target date.
+type Status string
+
+const (
+	StatusAchieved Status = "achieved"
+	StatusOnTrack  Status = "on_track"
+	StatusBehind   Status = "behind"
+	StatusOverdue  Status = "overdue"
+)
+
+// MonthOf returns the month of t in the "2006-01" layout.
+func MonthOf(t time.Time) string {
+	return t.Format(monthLayout)
+}
+
+func validMonth(month string) bool {
+	_, err := time.Parse(monthLayout, month)
+	return err == nil
+}
+
+// monthsBetween counts the months from one month to another, both included.
+// It is zero or negative when to comes before from.
+func monthsBetween(from, to string) int {
+	f, _ := time.Parse(monthLayout, from)
+	t, _ := time.Parse(monthLayout, to)
+	return (t.Year()-f.Year())*12 + int(t.Month()-f.Month()) + 1
+}
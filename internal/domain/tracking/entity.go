@@ -1,6 +1,8 @@
 package tracking
 
 import (
+	"sort"
+
 	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
 	"github.com/madalinpopa/gocost-web/internal/platform/money"
 )
@@ -13,6 +15,7 @@ type Group struct {
 	Name        NameVO
 	Description DescriptionVO
 	Order       OrderVO
+	Archived    bool
 	Categories  []*Category
 }
 
@@ -31,7 +34,7 @@ func (g *Group) AddCategory(category *Category) error {
 	if category.GroupID != g.ID {
 		return ErrCategoryGroupMismatch
 	}
-	if g.hasConflictingCategory(category.Name, category.ID, category.IsRecurrent, category.StartMonth, category.EndMonth) {
+	if g.hasConflictingCategory(category.Name, category.ID, category.ParentID, category.IsRecurrent, category.StartMonth, category.EndMonth) {
 		return ErrCategoryNameExists
 	}
 	g.Categories = append(g.Categories, category)
@@ -39,16 +42,83 @@ func (g *Group) AddCategory(category *Category) error {
 }
 
 func (g *Group) CreateCategory(id ID, name NameVO, description DescriptionVO, isRecurrent bool, startMonth Month, endMonth Month, budget money.Money) (*Category, error) {
+	if g.Archived {
+		return nil, ErrGroupArchived
+	}
+	category, err := NewCategory(id, g.ID, name, description, isRecurrent, startMonth, endMonth, budget)
+	if err != nil {
+		return nil, err
+	}
+	category.Order = g.nextCategoryOrder()
+	if err := g.AddCategory(category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// CreateSubcategory creates a category nested under an existing category of
+// the group. Names only have to be unique among categories sharing a parent.
+func (g *Group) CreateSubcategory(parentID ID, id ID, name NameVO, description DescriptionVO, isRecurrent bool, startMonth Month, endMonth Month, budget money.Money) (*Category, error) {
+	if g.Archived {
+		return nil, ErrGroupArchived
+	}
+	if _, err := g.FindCategory(parentID); err != nil {
+		return nil, ErrParentCategoryNotFound
+	}
 	category, err := NewCategory(id, g.ID, name, description, isRecurrent, startMonth, endMonth, budget)
 	if err != nil {
 		return nil, err
 	}
+	category.ParentID = parentID
+	category.Order = g.nextCategoryOrder()
 	if err := g.AddCategory(category); err != nil {
 		return nil, err
 	}
 	return category, nil
 }
 
+// ForkCategory ends a recurrent category before the given month and carries
+// it on from that month as a new category under parentID with a new budget.
+// Earlier months keep the original. Subcategories that only start from that
+// month move to the new category.
+func (g *Group) ForkCategory(id ID, newID ID, parentID ID, month Month, budget money.Money) (*Category, error) {
+	category, err := g.FindCategory(id)
+	if err != nil {
+		return nil, err
+	}
+	if !category.IsRecurrent || !category.StartMonth.Before(month) || !category.IsActiveFor(month) {
+		return nil, ErrInvalidMonth
+	}
+	if !parentID.IsZero() {
+		if _, err := g.FindCategory(parentID); err != nil {
+			return nil, ErrParentCategoryNotFound
+		}
+	}
+
+	fork, err := NewCategory(newID, g.ID, category.Name, category.Description, true, month, category.EndMonth, budget)
+	if err != nil {
+		return nil, err
+	}
+	fork.ParentID = parentID
+	fork.Order = category.Order
+
+	previousEnd := category.EndMonth
+	category.EndMonth = month.Previous()
+	if g.hasConflictingCategory(fork.Name, newID, parentID, true, fork.StartMonth, fork.EndMonth) {
+		category.EndMonth = previousEnd
+		return nil, ErrCategoryNameExists
+	}
+
+	for _, c := range g.Categories {
+		if c.ParentID == id && !c.StartMonth.Before(month) {
+			c.ParentID = newID
+		}
+	}
+	g.Categories = append(g.Categories, fork)
+
+	return fork, nil
+}
+
 func (g *Group) UpdateCategory(id ID, name NameVO, description DescriptionVO, isRecurrent bool, startMonth Month, endMonth Month, budget money.Money) (*Category, error) {
 	var category *Category
 	for _, c := range g.Categories {
@@ -61,8 +131,8 @@ func (g *Group) UpdateCategory(id ID, name NameVO, description DescriptionVO, is
 		return nil, ErrCategoryNotFound
 	}
 
-	// Check for conflicts with other categories (excluding self)
-	if g.hasConflictingCategory(name, id, isRecurrent, startMonth, endMonth) {
+	// Check for conflicts with sibling categories (excluding self)
+	if g.hasConflictingCategory(name, id, category.ParentID, isRecurrent, startMonth, endMonth) {
 		return nil, ErrCategoryNameExists
 	}
 
@@ -88,6 +158,9 @@ func (g *Group) UpdateCategory(id ID, name NameVO, description DescriptionVO, is
 }
 
 func (g *Group) RemoveCategory(id ID) error {
+	if g.HasSubcategories(id) {
+		return ErrCategoryHasSubcategories
+	}
 	for i, c := range g.Categories {
 		if c.ID == id {
 			g.Categories = append(g.Categories[:i], g.Categories[i+1:]...)
@@ -97,6 +170,217 @@ func (g *Group) RemoveCategory(id ID) error {
 	return ErrCategoryNotFound
 }
 
+// EndCategory stops a category and its subcategories from showing up from
+// the given month onwards, keeping them for the months before. Categories with
+// no months left before that point are removed from the group and their IDs
+// are returned.
+func (g *Group) EndCategory(id ID, month Month) ([]ID, error) {
+	if month.IsZero() {
+		return nil, ErrInvalidMonth
+	}
+
+	category, err := g.FindCategory(id)
+	if err != nil {
+		return nil, err
+	}
+
+	subtree := append([]*Category{category}, g.Descendants(id)...)
+	var removed []ID
+	for i := len(subtree) - 1; i >= 0; i-- {
+		c := subtree[i]
+		if !c.StartMonth.Before(month) {
+			if err := g.RemoveCategory(c.ID); err != nil {
+				return nil, err
+			}
+			removed = append(removed, c.ID)
+			continue
+		}
+		if c.IsActiveFor(month) {
+			c.EndMonth = month.Previous()
+		}
+	}
+
+	return removed, nil
+}
+
+// TopLevelCategories returns the categories without a parent in the group.
+func (g *Group) TopLevelCategories() []*Category {
+	roots := make([]*Category, 0, len(g.Categories))
+	for _, c := range g.Categories {
+		if c.ParentID.IsZero() {
+			roots = append(roots, c)
+			continue
+		}
+		if _, err := g.FindCategory(c.ParentID); err != nil {
+			roots = append(roots, c)
+		}
+	}
+	return roots
+}
+
+// Archive hides the group from months in which none of its categories
+// recorded expenses. Categories keep their own archived state.
+func (g *Group) Archive() {
+	g.Archived = true
+}
+
+func (g *Group) Restore() {
+	g.Archived = false
+}
+
+// ArchiveCategory archives the category together with its subcategories.
+func (g *Group) ArchiveCategory(id ID) error {
+	return g.setCategoryArchived(id, true)
+}
+
+func (g *Group) RestoreCategory(id ID) error {
+	return g.setCategoryArchived(id, false)
+}
+
+func (g *Group) setCategoryArchived(id ID, archived bool) error {
+	category, err := g.FindCategory(id)
+	if err != nil {
+		return err
+	}
+	category.Archived = archived
+	for _, c := range g.Descendants(id) {
+		c.Archived = archived
+	}
+	return nil
+}
+
+// Subcategories returns the direct children of the category.
+func (g *Group) Subcategories(id ID) []*Category {
+	children := make([]*Category, 0)
+	for _, c := range g.Categories {
+		if c.ParentID == id {
+			children = append(children, c)
+		}
+	}
+	return children
+}
+
+func (g *Group) HasSubcategories(id ID) bool {
+	for _, c := range g.Categories {
+		if c.ParentID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Descendants returns every category nested below the given one, parents
+// before their children.
+func (g *Group) Descendants(id ID) []*Category {
+	var result []*Category
+	for _, child := range g.Subcategories(id) {
+		result = append(result, child)
+		result = append(result, g.Descendants(child.ID)...)
+	}
+	return result
+}
+
+func (g *Group) FindCategory(id ID) (*Category, error) {
+	for _, c := range g.Categories {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return nil, ErrCategoryNotFound
+}
+
+// MoveCategory transfers a category to the target group, placing it last.
+func (g *Group) MoveCategory(id ID, target *Group) (*Category, error) {
+	if target.ID == g.ID {
+		return nil, ErrSameGroup
+	}
+	if target.Archived {
+		return nil, ErrGroupArchived
+	}
+
+	category, err := g.FindCategory(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if target.hasConflictingCategory(category.Name, category.ID, ID{}, category.IsRecurrent, category.StartMonth, category.EndMonth) {
+		return nil, ErrCategoryNameExists
+	}
+
+	// The category lands at the top level of the target and brings its
+	// subcategories along.
+	subtree := append([]*Category{category}, g.Descendants(id)...)
+	moving := make(map[ID]struct{}, len(subtree))
+	for _, c := range subtree {
+		moving[c.ID] = struct{}{}
+	}
+	remaining := make([]*Category, 0, len(g.Categories))
+	for _, c := range g.Categories {
+		if _, ok := moving[c.ID]; !ok {
+			remaining = append(remaining, c)
+		}
+	}
+	g.Categories = remaining
+
+	category.ParentID = ID{}
+	for _, c := range subtree {
+		c.GroupID = target.ID
+		c.Order = target.nextCategoryOrder()
+		target.Categories = append(target.Categories, c)
+	}
+
+	return category, nil
+}
+
+// ReorderCategories assigns display positions following the given IDs.
+// Categories that are not listed keep their relative order after the listed ones.
+func (g *Group) ReorderCategories(ids []ID) error {
+	if len(ids) == 0 {
+		return ErrInvalidCategoryOrder
+	}
+
+	positions := make(map[ID]int, len(ids))
+	for i, id := range ids {
+		if _, exists := positions[id]; exists {
+			return ErrInvalidCategoryOrder
+		}
+		positions[id] = i
+	}
+
+	matched := 0
+	for _, c := range g.Categories {
+		if _, ok := positions[c.ID]; ok {
+			matched++
+		}
+	}
+	if matched != len(ids) {
+		return ErrCategoryNotFound
+	}
+
+	sort.SliceStable(g.Categories, func(i, j int) bool {
+		return g.Categories[i].Order.Value() < g.Categories[j].Order.Value()
+	})
+
+	ordered := make([]*Category, len(ids), len(g.Categories))
+	for _, c := range g.Categories {
+		if pos, ok := positions[c.ID]; ok {
+			ordered[pos] = c
+		}
+	}
+	for _, c := range g.Categories {
+		if _, ok := positions[c.ID]; !ok {
+			ordered = append(ordered, c)
+		}
+	}
+
+	for i, c := range ordered {
+		c.Order = OrderVO{value: i}
+	}
+	g.Categories = ordered
+
+	return nil
+}
+
 func (g *Group) CategoriesForMonth(month Month) ([]*Category, error) {
 	if month.IsZero() {
 		return nil, ErrInvalidMonth
@@ -111,7 +395,17 @@ func (g *Group) CategoriesForMonth(month Month) ([]*Category, error) {
 	return categories, nil
 }
 
-func (g *Group) hasConflictingCategory(name NameVO, excludeID ID, isRecurrent bool, start Month, end Month) bool {
+func (g *Group) nextCategoryOrder() OrderVO {
+	next := 0
+	for _, c := range g.Categories {
+		if c.Order.Value() >= next {
+			next = c.Order.Value() + 1
+		}
+	}
+	return OrderVO{value: next}
+}
+
+func (g *Group) hasConflictingCategory(name NameVO, excludeID ID, parentID ID, isRecurrent bool, start Month, end Month) bool {
 	// Create a temporary category object to check overlap
 	// We don't care about ID/Group/Desc/Budget for overlap check
 	candidate := &Category{
@@ -122,7 +416,7 @@ func (g *Group) hasConflictingCategory(name NameVO, excludeID ID, isRecurrent bo
 	}
 
 	for _, category := range g.Categories {
-		if category.ID == excludeID {
+		if category.ID == excludeID || category.ParentID != parentID {
 			continue
 		}
 		if category.Name.Equals(name) {
@@ -137,12 +431,15 @@ func (g *Group) hasConflictingCategory(name NameVO, excludeID ID, isRecurrent bo
 type Category struct {
 	ID          ID
 	GroupID     ID
+	ParentID    ID
 	Name        NameVO
 	Description DescriptionVO
 	IsRecurrent bool
 	StartMonth  Month
 	EndMonth    Month
 	Budget      money.Money
+	Order       OrderVO
+	Archived    bool
 }
 
 func NewCategory(id ID, groupID ID, name NameVO, description DescriptionVO, isRecurrent bool, startMonth Month, endMonth Month, budget money.Money) (*Category, error) {
@@ -184,6 +481,34 @@ func (c *Category) IsActiveFor(month Month) bool {
 	return !c.EndMonth.Before(month)
 }
 
+// MergedPeriod returns the start and end months the category needs to stay
+// active for every month in which the other category is active.
+func (c *Category) MergedPeriod(other *Category) (Month, Month, error) {
+	if !c.IsRecurrent {
+		if other.IsRecurrent || !other.StartMonth.Equals(c.StartMonth) {
+			return Month{}, Month{}, ErrMergePeriodMismatch
+		}
+		return c.StartMonth, c.EndMonth, nil
+	}
+
+	start := c.StartMonth
+	if other.StartMonth.Before(start) {
+		start = other.StartMonth
+	}
+
+	otherEnd := other.EndMonth
+	if !other.IsRecurrent {
+		otherEnd = other.StartMonth
+	}
+
+	end := c.EndMonth
+	if !end.IsZero() && (otherEnd.IsZero() || end.Before(otherEnd)) {
+		end = otherEnd
+	}
+
+	return start, end, nil
+}
+
 func (c *Category) Overlaps(other *Category) bool {
 	// Define intervals [StartA, EndA] and [StartB, EndB]
 	startA := c.StartMonth
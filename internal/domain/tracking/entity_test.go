@@ -363,6 +363,492 @@ func TestGroup_RemoveCategory(t *testing.T) {
 	})
 }
 
+func TestGroup_ReorderCategories(t *testing.T) {
+	newGroupWithCategories := func(t *testing.T, names ...string) (*Group, []ID) {
+		t.Helper()
+		groupID, _ := identifier.NewID()
+		userID, _ := identifier.NewID()
+		group := NewGroup(groupID, userID, mustName(t, "Group"), mustDesc(t, "Desc"), mustOrder(t, 0))
+		startMonth, _ := NewMonth(2024, time.January)
+
+		ids := make([]ID, 0, len(names))
+		for _, name := range names {
+			id, _ := identifier.NewID()
+			_, err := group.CreateCategory(id, mustName(t, name), mustDesc(t, "Desc"), true, startMonth, Month{}, money.Money{})
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+		return group, ids
+	}
+
+	t.Run("assigns increasing order on create", func(t *testing.T) {
+		group, _ := newGroupWithCategories(t, "Rent", "Food", "Fun")
+
+		for i, c := range group.Categories {
+			assert.Equal(t, i, c.Order.Value())
+		}
+	})
+
+	t.Run("reorders all categories", func(t *testing.T) {
+		group, ids := newGroupWithCategories(t, "Rent", "Food", "Fun")
+
+		err := group.ReorderCategories([]ID{ids[2], ids[0], ids[1]})
+
+		require.NoError(t, err)
+		assert.Equal(t, ids[2], group.Categories[0].ID)
+		assert.Equal(t, ids[0], group.Categories[1].ID)
+		assert.Equal(t, ids[1], group.Categories[2].ID)
+		for i, c := range group.Categories {
+			assert.Equal(t, i, c.Order.Value())
+		}
+	})
+
+	t.Run("keeps unlisted categories after listed ones", func(t *testing.T) {
+		group, ids := newGroupWithCategories(t, "Rent", "Food", "Fun")
+
+		err := group.ReorderCategories([]ID{ids[2], ids[1]})
+
+		require.NoError(t, err)
+		assert.Equal(t, ids[2], group.Categories[0].ID)
+		assert.Equal(t, ids[1], group.Categories[1].ID)
+		assert.Equal(t, ids[0], group.Categories[2].ID)
+		assert.Equal(t, 2, group.Categories[2].Order.Value())
+	})
+
+	t.Run("rejects duplicate ids", func(t *testing.T) {
+		group, ids := newGroupWithCategories(t, "Rent", "Food")
+
+		err := group.ReorderCategories([]ID{ids[0], ids[0]})
+
+		assert.ErrorIs(t, err, ErrInvalidCategoryOrder)
+	})
+
+	t.Run("rejects empty list", func(t *testing.T) {
+		group, _ := newGroupWithCategories(t, "Rent")
+
+		err := group.ReorderCategories(nil)
+
+		assert.ErrorIs(t, err, ErrInvalidCategoryOrder)
+	})
+
+	t.Run("rejects unknown category", func(t *testing.T) {
+		group, ids := newGroupWithCategories(t, "Rent", "Food")
+		unknownID, _ := identifier.NewID()
+
+		err := group.ReorderCategories([]ID{ids[1], unknownID})
+
+		assert.ErrorIs(t, err, ErrCategoryNotFound)
+		assert.Equal(t, ids[0], group.Categories[0].ID)
+	})
+}
+
+func TestGroup_MoveCategory(t *testing.T) {
+	userID, _ := identifier.NewID()
+	startMonth, _ := NewMonth(2024, time.January)
+
+	newTestGroup := func(t *testing.T, name string) *Group {
+		t.Helper()
+		id, _ := identifier.NewID()
+		return NewGroup(id, userID, mustName(t, name), mustDesc(t, "Desc"), mustOrder(t, 0))
+	}
+
+	t.Run("moves category to target group", func(t *testing.T) {
+		source := newTestGroup(t, "Utilities")
+		target := newTestGroup(t, "Subscriptions")
+		catID, _ := identifier.NewID()
+		_, err := source.CreateCategory(catID, mustName(t, "Internet"), mustDesc(t, "Desc"), true, startMonth, Month{}, money.Money{})
+		require.NoError(t, err)
+		existingID, _ := identifier.NewID()
+		_, err = target.CreateCategory(existingID, mustName(t, "Streaming"), mustDesc(t, "Desc"), true, startMonth, Month{}, money.Money{})
+		require.NoError(t, err)
+
+		moved, err := source.MoveCategory(catID, target)
+
+		require.NoError(t, err)
+		assert.Equal(t, target.ID, moved.GroupID)
+		assert.Equal(t, 1, moved.Order.Value())
+		assert.Empty(t, source.Categories)
+		assert.Len(t, target.Categories, 2)
+		assert.Equal(t, moved, target.Categories[1])
+	})
+
+	t.Run("rejects conflicting name in target", func(t *testing.T) {
+		source := newTestGroup(t, "Utilities")
+		target := newTestGroup(t, "Subscriptions")
+		catID, _ := identifier.NewID()
+		_, err := source.CreateCategory(catID, mustName(t, "Internet"), mustDesc(t, "Desc"), true, startMonth, Month{}, money.Money{})
+		require.NoError(t, err)
+		otherID, _ := identifier.NewID()
+		_, err = target.CreateCategory(otherID, mustName(t, "Internet"), mustDesc(t, "Desc"), false, startMonth, Month{}, money.Money{})
+		require.NoError(t, err)
+
+		_, err = source.MoveCategory(catID, target)
+
+		assert.ErrorIs(t, err, ErrCategoryNameExists)
+		assert.Len(t, source.Categories, 1)
+		assert.Len(t, target.Categories, 1)
+	})
+
+	t.Run("rejects same group", func(t *testing.T) {
+		source := newTestGroup(t, "Utilities")
+
+		_, err := source.MoveCategory(source.ID, source)
+
+		assert.ErrorIs(t, err, ErrSameGroup)
+	})
+
+	t.Run("returns error when category not found", func(t *testing.T) {
+		source := newTestGroup(t, "Utilities")
+		target := newTestGroup(t, "Subscriptions")
+		missingID, _ := identifier.NewID()
+
+		_, err := source.MoveCategory(missingID, target)
+
+		assert.ErrorIs(t, err, ErrCategoryNotFound)
+	})
+
+	t.Run("rejects archived target group", func(t *testing.T) {
+		source := newTestGroup(t, "Utilities")
+		target := newTestGroup(t, "Old apartment")
+		target.Archive()
+		catID, _ := identifier.NewID()
+		_, err := source.CreateCategory(catID, mustName(t, "Internet"), mustDesc(t, "Desc"), true, startMonth, Month{}, money.Money{})
+		require.NoError(t, err)
+
+		_, err = source.MoveCategory(catID, target)
+
+		assert.ErrorIs(t, err, ErrGroupArchived)
+		assert.Len(t, source.Categories, 1)
+	})
+}
+
+func TestGroup_Archive(t *testing.T) {
+	userID, _ := identifier.NewID()
+	startMonth, _ := NewMonth(2024, time.January)
+
+	newTestGroup := func(t *testing.T) (*Group, ID) {
+		t.Helper()
+		groupID, _ := identifier.NewID()
+		group := NewGroup(groupID, userID, mustName(t, "Old apartment"), mustDesc(t, "Desc"), mustOrder(t, 0))
+		catID, _ := identifier.NewID()
+		_, err := group.CreateCategory(catID, mustName(t, "Rent"), mustDesc(t, "Desc"), true, startMonth, Month{}, money.Money{})
+		require.NoError(t, err)
+		return group, catID
+	}
+
+	t.Run("archives and restores group", func(t *testing.T) {
+		group, _ := newTestGroup(t)
+
+		group.Archive()
+		assert.True(t, group.Archived)
+		assert.False(t, group.Categories[0].Archived)
+
+		group.Restore()
+		assert.False(t, group.Archived)
+	})
+
+	t.Run("rejects new categories while archived", func(t *testing.T) {
+		group, _ := newTestGroup(t)
+		group.Archive()
+		catID, _ := identifier.NewID()
+
+		_, err := group.CreateCategory(catID, mustName(t, "Utilities"), mustDesc(t, "Desc"), false, startMonth, Month{}, money.Money{})
+
+		assert.ErrorIs(t, err, ErrGroupArchived)
+		assert.Len(t, group.Categories, 1)
+	})
+
+	t.Run("archives and restores category", func(t *testing.T) {
+		group, catID := newTestGroup(t)
+
+		require.NoError(t, group.ArchiveCategory(catID))
+		assert.True(t, group.Categories[0].Archived)
+
+		require.NoError(t, group.RestoreCategory(catID))
+		assert.False(t, group.Categories[0].Archived)
+	})
+
+	t.Run("returns error when category not found", func(t *testing.T) {
+		group, _ := newTestGroup(t)
+		missingID, _ := identifier.NewID()
+
+		assert.ErrorIs(t, group.ArchiveCategory(missingID), ErrCategoryNotFound)
+		assert.ErrorIs(t, group.RestoreCategory(missingID), ErrCategoryNotFound)
+	})
+}
+
+func TestCategory_MergedPeriod(t *testing.T) {
+	jan, _ := NewMonth(2024, time.January)
+	mar, _ := NewMonth(2024, time.March)
+	jun, _ := NewMonth(2024, time.June)
+	dec, _ := NewMonth(2024, time.December)
+
+	tests := []struct {
+		name      string
+		target    Category
+		source    Category
+		wantStart Month
+		wantEnd   Month
+		wantErr   error
+	}{
+		{
+			name:      "recurrent target widens start",
+			target:    Category{IsRecurrent: true, StartMonth: mar},
+			source:    Category{IsRecurrent: true, StartMonth: jan, EndMonth: jun},
+			wantStart: jan,
+			wantEnd:   Month{},
+		},
+		{
+			name:      "recurrent target widens end",
+			target:    Category{IsRecurrent: true, StartMonth: jan, EndMonth: mar},
+			source:    Category{IsRecurrent: true, StartMonth: jan, EndMonth: dec},
+			wantStart: jan,
+			wantEnd:   dec,
+		},
+		{
+			name:      "recurrent target becomes open ended",
+			target:    Category{IsRecurrent: true, StartMonth: jan, EndMonth: mar},
+			source:    Category{IsRecurrent: true, StartMonth: mar},
+			wantStart: jan,
+			wantEnd:   Month{},
+		},
+		{
+			name:      "recurrent target covers monthly source",
+			target:    Category{IsRecurrent: true, StartMonth: jan, EndMonth: mar},
+			source:    Category{IsRecurrent: false, StartMonth: jun},
+			wantStart: jan,
+			wantEnd:   jun,
+		},
+		{
+			name:      "monthly target with same month",
+			target:    Category{IsRecurrent: false, StartMonth: mar},
+			source:    Category{IsRecurrent: false, StartMonth: mar},
+			wantStart: mar,
+			wantEnd:   Month{},
+		},
+		{
+			name:    "monthly target with other month",
+			target:  Category{IsRecurrent: false, StartMonth: mar},
+			source:  Category{IsRecurrent: false, StartMonth: jun},
+			wantErr: ErrMergePeriodMismatch,
+		},
+		{
+			name:    "monthly target with recurrent source",
+			target:  Category{IsRecurrent: false, StartMonth: mar},
+			source:  Category{IsRecurrent: true, StartMonth: mar},
+			wantErr: ErrMergePeriodMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := tt.target.MergedPeriod(&tt.source)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantEnd, end)
+		})
+	}
+}
+
+func TestGroup_EndCategory(t *testing.T) {
+	userID, _ := identifier.NewID()
+	jan, _ := NewMonth(2024, time.January)
+	mar, _ := NewMonth(2024, time.March)
+	jun, _ := NewMonth(2024, time.June)
+
+	newGroupWith := func(t *testing.T, isRecurrent bool, start Month, end Month) (*Group, ID) {
+		t.Helper()
+		groupID, _ := identifier.NewID()
+		group := NewGroup(groupID, userID, mustName(t, "Housing"), mustDesc(t, "Desc"), mustOrder(t, 0))
+		catID, _ := identifier.NewID()
+		_, err := group.CreateCategory(catID, mustName(t, "Rent"), mustDesc(t, "Desc"), isRecurrent, start, end, money.Money{})
+		require.NoError(t, err)
+		return group, catID
+	}
+
+	t.Run("ends recurrent category at previous month", func(t *testing.T) {
+		group, catID := newGroupWith(t, true, jan, Month{})
+
+		removed, err := group.EndCategory(catID, mar)
+
+		require.NoError(t, err)
+		assert.Empty(t, removed)
+		assert.Equal(t, "2024-02", group.Categories[0].EndMonth.Value())
+	})
+
+	t.Run("removes category starting in or after the month", func(t *testing.T) {
+		group, catID := newGroupWith(t, true, mar, Month{})
+
+		removed, err := group.EndCategory(catID, mar)
+
+		require.NoError(t, err)
+		assert.Equal(t, []ID{catID}, removed)
+		assert.Empty(t, group.Categories)
+	})
+
+	t.Run("keeps category that already ended", func(t *testing.T) {
+		group, catID := newGroupWith(t, true, jan, mar)
+
+		removed, err := group.EndCategory(catID, jun)
+
+		require.NoError(t, err)
+		assert.Empty(t, removed)
+		assert.Equal(t, mar, group.Categories[0].EndMonth)
+	})
+
+	t.Run("keeps past monthly category", func(t *testing.T) {
+		group, catID := newGroupWith(t, false, jan, Month{})
+
+		removed, err := group.EndCategory(catID, mar)
+
+		require.NoError(t, err)
+		assert.Empty(t, removed)
+		assert.True(t, group.Categories[0].EndMonth.IsZero())
+	})
+
+	t.Run("rejects zero month", func(t *testing.T) {
+		group, catID := newGroupWith(t, true, jan, Month{})
+
+		_, err := group.EndCategory(catID, Month{})
+
+		assert.ErrorIs(t, err, ErrInvalidMonth)
+	})
+
+	t.Run("returns error when category not found", func(t *testing.T) {
+		group, _ := newGroupWith(t, true, jan, Month{})
+		missingID, _ := identifier.NewID()
+
+		_, err := group.EndCategory(missingID, mar)
+
+		assert.ErrorIs(t, err, ErrCategoryNotFound)
+	})
+
+	t.Run("ends subcategories with their parent", func(t *testing.T) {
+		group, catID := newGroupWith(t, true, jan, Month{})
+		keptID, _ := identifier.NewID()
+		_, err := group.CreateSubcategory(catID, keptID, mustName(t, "Deposit"), mustDesc(t, "Desc"), true, jan, Month{}, money.Money{})
+		require.NoError(t, err)
+		newID, _ := identifier.NewID()
+		_, err = group.CreateSubcategory(catID, newID, mustName(t, "Parking"), mustDesc(t, "Desc"), true, mar, Month{}, money.Money{})
+		require.NoError(t, err)
+
+		removed, err := group.EndCategory(catID, mar)
+
+		require.NoError(t, err)
+		assert.Equal(t, []ID{newID}, removed)
+		require.Len(t, group.Categories, 2)
+		for _, c := range group.Categories {
+			assert.Equal(t, "2024-02", c.EndMonth.Value())
+		}
+	})
+}
+
+func TestGroup_Subcategories(t *testing.T) {
+	userID, _ := identifier.NewID()
+	jan, _ := NewMonth(2024, time.January)
+
+	newCarGroup := func(t *testing.T) (*Group, ID) {
+		t.Helper()
+		groupID, _ := identifier.NewID()
+		group := NewGroup(groupID, userID, mustName(t, "Transport"), mustDesc(t, "Desc"), mustOrder(t, 0))
+		carID, _ := identifier.NewID()
+		_, err := group.CreateCategory(carID, mustName(t, "Car"), mustDesc(t, "Desc"), true, jan, Month{}, money.Money{})
+		require.NoError(t, err)
+		return group, carID
+	}
+
+	t.Run("creates nested subcategories", func(t *testing.T) {
+		group, carID := newCarGroup(t)
+		fuelID, _ := identifier.NewID()
+		dieselID, _ := identifier.NewID()
+
+		fuel, err := group.CreateSubcategory(carID, fuelID, mustName(t, "Fuel"), mustDesc(t, "Desc"), true, jan, Month{}, money.Money{})
+		require.NoError(t, err)
+		_, err = group.CreateSubcategory(fuelID, dieselID, mustName(t, "Diesel"), mustDesc(t, "Desc"), true, jan, Month{}, money.Money{})
+		require.NoError(t, err)
+
+		assert.Equal(t, carID, fuel.ParentID)
+		assert.Equal(t, []*Category{fuel}, group.Subcategories(carID))
+		assert.True(t, group.HasSubcategories(carID))
+		assert.Len(t, group.Descendants(carID), 2)
+		assert.Equal(t, fuelID, group.Descendants(carID)[0].ID)
+		assert.Len(t, group.TopLevelCategories(), 1)
+	})
+
+	t.Run("rejects unknown parent", func(t *testing.T) {
+		group, _ := newCarGroup(t)
+		missingID, _ := identifier.NewID()
+		id, _ := identifier.NewID()
+
+		_, err := group.CreateSubcategory(missingID, id, mustName(t, "Fuel"), mustDesc(t, "Desc"), true, jan, Month{}, money.Money{})
+
+		assert.ErrorIs(t, err, ErrParentCategoryNotFound)
+	})
+
+	t.Run("scopes name uniqueness to siblings", func(t *testing.T) {
+		group, carID := newCarGroup(t)
+		serviceID, _ := identifier.NewID()
+		_, err := group.CreateCategory(serviceID, mustName(t, "Service"), mustDesc(t, "Desc"), true, jan, Month{}, money.Money{})
+		require.NoError(t, err)
+
+		id, _ := identifier.NewID()
+		_, err = group.CreateSubcategory(carID, id, mustName(t, "Service"), mustDesc(t, "Desc"), true, jan, Month{}, money.Money{})
+		require.NoError(t, err)
+
+		otherID, _ := identifier.NewID()
+		_, err = group.CreateSubcategory(carID, otherID, mustName(t, "Service"), mustDesc(t, "Desc"), true, jan, Month{}, money.Money{})
+		assert.ErrorIs(t, err, ErrCategoryNameExists)
+	})
+
+	t.Run("refuses to remove a category with subcategories", func(t *testing.T) {
+		group, carID := newCarGroup(t)
+		fuelID, _ := identifier.NewID()
+		_, err := group.CreateSubcategory(carID, fuelID, mustName(t, "Fuel"), mustDesc(t, "Desc"), true, jan, Month{}, money.Money{})
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, group.RemoveCategory(carID), ErrCategoryHasSubcategories)
+		require.NoError(t, group.RemoveCategory(fuelID))
+		require.NoError(t, group.RemoveCategory(carID))
+	})
+
+	t.Run("moves subtree to the top level of another group", func(t *testing.T) {
+		group, carID := newCarGroup(t)
+		fuelID, _ := identifier.NewID()
+		_, err := group.CreateSubcategory(carID, fuelID, mustName(t, "Fuel"), mustDesc(t, "Desc"), true, jan, Month{}, money.Money{})
+		require.NoError(t, err)
+		targetID, _ := identifier.NewID()
+		target := NewGroup(targetID, userID, mustName(t, "Vehicles"), mustDesc(t, "Desc"), mustOrder(t, 1))
+
+		_, err = group.MoveCategory(fuelID, target)
+		require.NoError(t, err)
+		assert.False(t, group.HasSubcategories(carID))
+		assert.True(t, target.Categories[0].ParentID.IsZero())
+
+		_, err = group.MoveCategory(carID, target)
+		require.NoError(t, err)
+		assert.Empty(t, group.Categories)
+		assert.Len(t, target.Categories, 2)
+	})
+
+	t.Run("archives subtree", func(t *testing.T) {
+		group, carID := newCarGroup(t)
+		fuelID, _ := identifier.NewID()
+		fuel, err := group.CreateSubcategory(carID, fuelID, mustName(t, "Fuel"), mustDesc(t, "Desc"), true, jan, Month{}, money.Money{})
+		require.NoError(t, err)
+
+		require.NoError(t, group.ArchiveCategory(carID))
+		assert.True(t, fuel.Archived)
+
+		require.NoError(t, group.RestoreCategory(carID))
+		assert.False(t, fuel.Archived)
+	})
+}
+
 func mustName(t *testing.T, value string) NameVO {
 	t.Helper()
 	name, err := NewNameVO(value)
@@ -507,3 +993,65 @@ func TestGroup_AddCategory_Overlap(t *testing.T) {
 	err = group.AddCategory(cat4)
 	assert.ErrorIs(t, err, ErrCategoryNameExists)
 }
+
+func TestGroup_ForkCategory(t *testing.T) {
+	userID, _ := identifier.NewID()
+	jan, _ := NewMonth(2024, time.January)
+	mar, _ := NewMonth(2024, time.March)
+	budget, _ := money.New(10000, "USD")
+	raised, _ := money.New(12000, "USD")
+
+	newRentGroup := func(t *testing.T) (*Group, ID) {
+		t.Helper()
+		groupID, _ := identifier.NewID()
+		group := NewGroup(groupID, userID, mustName(t, "Home"), mustDesc(t, "Desc"), mustOrder(t, 0))
+		rentID, _ := identifier.NewID()
+		_, err := group.CreateCategory(rentID, mustName(t, "Rent"), mustDesc(t, "Desc"), true, jan, Month{}, budget)
+		require.NoError(t, err)
+		return group, rentID
+	}
+
+	t.Run("ends the original and continues with the new budget", func(t *testing.T) {
+		group, rentID := newRentGroup(t)
+		newID, _ := identifier.NewID()
+
+		fork, err := group.ForkCategory(rentID, newID, ID{}, mar, raised)
+
+		require.NoError(t, err)
+		original, _ := group.FindCategory(rentID)
+		assert.Equal(t, mar.Previous(), original.EndMonth)
+		assert.Equal(t, mar, fork.StartMonth)
+		assert.True(t, fork.EndMonth.IsZero())
+		assert.Equal(t, int64(12000), fork.Budget.Cents())
+		assert.Equal(t, original.Order, fork.Order)
+	})
+
+	t.Run("moves subcategories starting from the month", func(t *testing.T) {
+		group, rentID := newRentGroup(t)
+		laterID, _ := identifier.NewID()
+		earlyID, _ := identifier.NewID()
+		_, err := group.CreateSubcategory(rentID, earlyID, mustName(t, "Deposit"), mustDesc(t, "Desc"), true, jan, Month{}, money.Money{})
+		require.NoError(t, err)
+		_, err = group.CreateSubcategory(rentID, laterID, mustName(t, "Parking"), mustDesc(t, "Desc"), true, mar, Month{}, money.Money{})
+		require.NoError(t, err)
+		newID, _ := identifier.NewID()
+
+		_, err = group.ForkCategory(rentID, newID, ID{}, mar, raised)
+
+		require.NoError(t, err)
+		later, _ := group.FindCategory(laterID)
+		early, _ := group.FindCategory(earlyID)
+		assert.Equal(t, newID, later.ParentID)
+		assert.Equal(t, rentID, early.ParentID)
+	})
+
+	t.Run("rejects a month outside the category", func(t *testing.T) {
+		group, rentID := newRentGroup(t)
+		newID, _ := identifier.NewID()
+
+		_, err := group.ForkCategory(rentID, newID, ID{}, jan, raised)
+
+		assert.ErrorIs(t, err, ErrInvalidMonth)
+		assert.Len(t, group.Categories, 1)
+	})
+}
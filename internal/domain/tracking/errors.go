@@ -3,15 +3,25 @@ package tracking
 import "errors"
 
 var (
-	ErrEmptyName          = errors.New("name cannot be empty")
-	ErrNameTooLong        = errors.New("name exceeds maximum length of 100 characters")
-	ErrDescriptionTooLong = errors.New("description exceeds maximum length")
-	ErrInvalidMonth       = errors.New("month must be in YYYY-MM format")
+	ErrEmptyName                = errors.New("name cannot be empty")
+	ErrNameTooLong              = errors.New("name exceeds maximum length of 100 characters")
+	ErrDescriptionTooLong       = errors.New("description exceeds maximum length")
+	ErrInvalidMonth             = errors.New("month must be in YYYY-MM format")
 	ErrEndMonthBeforeStartMonth = errors.New("end month must be after or equal to start month")
-	ErrEndMonthNotAllowed = errors.New("end month is only allowed for recurrent categories")
-	ErrCategoryNameExists = errors.New("category name already exists in group")
-	ErrCategoryGroupMismatch = errors.New("category does not belong to this group")
-	ErrGroupNotFound      = errors.New("group not found")
-	ErrCategoryNotFound   = errors.New("category not found")
-	ErrInvalidOrder       = errors.New("order cannot be negative")
+	ErrEndMonthNotAllowed       = errors.New("end month is only allowed for recurrent categories")
+	ErrCategoryNameExists       = errors.New("category name already exists in group")
+	ErrCategoryGroupMismatch    = errors.New("category does not belong to this group")
+	ErrGroupNotFound            = errors.New("group not found")
+	ErrCategoryNotFound         = errors.New("category not found")
+	ErrInvalidOrder             = errors.New("order cannot be negative")
+	ErrInvalidCategoryOrder     = errors.New("category order must list each category once")
+	ErrInvalidGroupOrder        = errors.New("group order must list each group exactly once")
+	ErrSameGroup                = errors.New("category already belongs to this group")
+	ErrSameCategory             = errors.New("cannot merge a category into itself")
+	ErrMergePeriodMismatch      = errors.New("target category is not active in every month of the source category")
+	ErrGroupArchived            = errors.New("group is archived")
+	ErrParentCategoryNotFound   = errors.New("parent category not found in group")
+	ErrCategoryHasSubcategories = errors.New("category has subcategories")
+	ErrTargetInDeletedGroup     = errors.New("expenses cannot be reassigned to a category of the group being deleted")
+	ErrOneOffReassignTarget     = errors.New("a one-off category can only take the expenses of one-off categories of its month")
 )
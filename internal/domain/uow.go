@@ -3,18 +3,35 @@ package domain
 import (
 	"context"
 
+	"github.com/madalinpopa/gocost-web/internal/domain/account"
+	"github.com/madalinpopa/gocost-web/internal/domain/alert"
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
 	"github.com/madalinpopa/gocost-web/internal/domain/expense"
 	"github.com/madalinpopa/gocost-web/internal/domain/identity"
 	"github.com/madalinpopa/gocost-web/internal/domain/income"
+	"github.com/madalinpopa/gocost-web/internal/domain/loan"
+	"github.com/madalinpopa/gocost-web/internal/domain/networth"
+	"github.com/madalinpopa/gocost-web/internal/domain/saving"
 	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
 )
 
 // UnitOfWork defines the contract for a transactional unit of work.
 type UnitOfWork interface {
 	UserRepository() identity.UserRepository
+	PasswordResetRepository() identity.PasswordResetRepository
+	TwoFactorRepository() identity.TwoFactorRepository
+	PasskeyRepository() identity.PasskeyRepository
+	UserSessionRepository() identity.UserSessionRepository
+	InviteRepository() identity.InviteRepository
 	IncomeRepository() income.IncomeRepository
 	ExpenseRepository() expense.ExpenseRepository
 	TrackingRepository() tracking.GroupRepository
+	ClosingRepository() closing.MonthCloseRepository
+	SavingRepository() saving.GoalRepository
+	AccountRepository() account.AccountRepository
+	LoanRepository() loan.LoanRepository
+	AssetRepository() networth.AssetRepository
+	AlertRepository() alert.AlertRepository
 	Begin(ctx context.Context) (UnitOfWork, error)
 	Commit() error
 	Rollback() error
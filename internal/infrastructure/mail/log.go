@@ -0,0 +1,31 @@
+package mail
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogMailer prints each message to the application log instead of sending
+// it. It is the default, so that links sent by email can be followed in local
+// setups without any mail configuration.
+type LogMailer struct {
+	logger *slog.Logger
+	from   string
+}
+
+func NewLogMailer(logger *slog.Logger, from string) LogMailer {
+	return LogMailer{
+		logger: logger,
+		from:   from,
+	}
+}
+
+func (m LogMailer) Send(ctx context.Context, msg Message) error {
+	m.logger.InfoContext(ctx, "email",
+		"from", m.from,
+		"to", msg.To,
+		"subject", msg.Subject,
+		"body", msg.Body,
+	)
+	return nil
+}
@@ -0,0 +1,95 @@
+// Package mail delivers the emails the application sends, such as password
+// reset links, through the transport chosen in the configuration.
+package mail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"strings"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/config"
+)
+
+// sendTimeout bounds how long sending a message in the background may take.
+const sendTimeout = 30 * time.Second
+
+var ErrInvalidHeader = errors.New("email header cannot contain line breaks")
+
+// Message is a plain text email to a single recipient.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers messages.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// New returns the mailer of the configured transport. The configuration is
+// expected to be validated already; an unknown transport logs messages.
+func New(cfg config.MailConfig, logger *slog.Logger) Mailer {
+	switch cfg.Transport {
+	case config.MailTransportSMTP:
+		return NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From)
+	case config.MailTransportFile:
+		return NewFileMailer(cfg.Dir, cfg.From)
+	default:
+		return NewLogMailer(logger, cfg.From)
+	}
+}
+
+// BackgroundMailer sends messages without waiting for the transport, so that
+// neither a slow mail server nor whether a message was sent at all shows in
+// how long a request takes. Failures are logged.
+type BackgroundMailer struct {
+	mailer Mailer
+	logger *slog.Logger
+}
+
+func NewBackgroundMailer(mailer Mailer, logger *slog.Logger) BackgroundMailer {
+	return BackgroundMailer{
+		mailer: mailer,
+		logger: logger,
+	}
+}
+
+func (m BackgroundMailer) Send(ctx context.Context, msg Message) error {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sendTimeout)
+		defer cancel()
+
+		if err := m.mailer.Send(ctx, msg); err != nil {
+			m.logger.Error("failed to send email", "subject", msg.Subject, "error", err)
+		}
+	}()
+	return nil
+}
+
+// format renders the message as an RFC 5322 email.
+func (msg Message) format(from string, date time.Time) ([]byte, error) {
+	if strings.ContainsAny(from+msg.To, "\r\n") {
+		return nil, ErrInvalidHeader
+	}
+
+	body := strings.ReplaceAll(msg.Body, "\r\n", "\n")
+	body = strings.ReplaceAll(body, "\n", "\r\n")
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(body)
+	return b.Bytes(), nil
+}
@@ -0,0 +1,202 @@
+package mail
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMessage() Message {
+	return Message{
+		To:      "user@example.com",
+		Subject: "Reset your password",
+		Body:    "Hello,\nfollow the link.",
+	}
+}
+
+func TestMessage_Format(t *testing.T) {
+	t.Run("renders headers and a CRLF body", func(t *testing.T) {
+		// Act
+		data, err := newTestMessage().format("gocost@example.com", time.Date(2024, time.March, 12, 9, 30, 0, 0, time.UTC))
+
+		// Assert
+		require.NoError(t, err)
+		email := string(data)
+		assert.Contains(t, email, "From: gocost@example.com\r\n")
+		assert.Contains(t, email, "To: user@example.com\r\n")
+		assert.Contains(t, email, "Subject: Reset your password\r\n")
+		assert.Contains(t, email, "Date: Tue, 12 Mar 2024 09:30:00 +0000\r\n")
+		assert.True(t, strings.HasSuffix(email, "\r\n\r\nHello,\r\nfollow the link."))
+	})
+
+	t.Run("rejects a recipient with a line break", func(t *testing.T) {
+		// Arrange
+		msg := newTestMessage()
+		msg.To = "user@example.com\r\nBcc: other@example.com"
+
+		// Act
+		_, err := msg.format("gocost@example.com", time.Now())
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidHeader)
+	})
+}
+
+func TestNew(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	assert.IsType(t, SMTPMailer{}, New(config.MailConfig{Transport: config.MailTransportSMTP}, logger))
+	assert.IsType(t, FileMailer{}, New(config.MailConfig{Transport: config.MailTransportFile}, logger))
+	assert.IsType(t, LogMailer{}, New(config.MailConfig{Transport: config.MailTransportLog}, logger))
+}
+
+func TestFileMailer_Send(t *testing.T) {
+	// Arrange
+	dir := filepath.Join(t.TempDir(), "mail")
+	mailer := NewFileMailer(dir, "gocost@example.com")
+
+	// Act
+	err := mailer.Send(context.Background(), newTestMessage())
+
+	// Assert
+	require.NoError(t, err)
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Subject: Reset your password\r\n")
+}
+
+func TestLogMailer_Send(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+	mailer := NewLogMailer(slog.New(slog.NewTextHandler(&buf, nil)), "gocost@example.com")
+
+	// Act
+	err := mailer.Send(context.Background(), newTestMessage())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "to=user@example.com")
+	assert.Contains(t, buf.String(), `subject="Reset your password"`)
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	// Arrange
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = listener.Close() }()
+
+	received := make(chan string, 1)
+	go serveSMTP(t, listener, received)
+
+	addr := listener.Addr().(*net.TCPAddr)
+	mailer := NewSMTPMailer("127.0.0.1", addr.Port, "", "", "gocost@example.com")
+
+	// Act
+	err = mailer.Send(context.Background(), newTestMessage())
+
+	// Assert
+	require.NoError(t, err)
+	select {
+	case data := <-received:
+		assert.Contains(t, data, "MAIL FROM:<gocost@example.com>")
+		assert.Contains(t, data, "RCPT TO:<user@example.com>")
+		assert.Contains(t, data, "Subject: Reset your password")
+	case <-time.After(5 * time.Second):
+		t.Fatal("mail server received nothing")
+	}
+}
+
+type failingMailer struct{}
+
+func (failingMailer) Send(context.Context, Message) error {
+	return errors.New("mail server down")
+}
+
+func TestBackgroundMailer_Send(t *testing.T) {
+	// Arrange
+	logged := make(chan string, 1)
+	logger := slog.New(slog.NewTextHandler(writerFunc(func(p []byte) (int, error) {
+		logged <- string(p)
+		return len(p), nil
+	}), nil))
+	mailer := NewBackgroundMailer(failingMailer{}, logger)
+
+	// Act
+	err := mailer.Send(context.Background(), newTestMessage())
+
+	// Assert
+	require.NoError(t, err)
+	select {
+	case line := <-logged:
+		assert.Contains(t, line, "mail server down")
+	case <-time.After(5 * time.Second):
+		t.Fatal("failure was not logged")
+	}
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) {
+	return f(p)
+}
+
+// serveSMTP answers a single SMTP session just enough to accept one message,
+// and sends what the client wrote to received.
+func serveSMTP(t *testing.T, listener net.Listener, received chan<- string) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer func() { _ = conn.Close() }()
+
+	var transcript strings.Builder
+	r := bufio.NewReader(conn)
+	reply := func(line string) {
+		_, _ = conn.Write([]byte(line + "\r\n"))
+	}
+
+	reply("220 localhost ESMTP")
+	inData := false
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Errorf("smtp server: %v", err)
+			return
+		}
+		transcript.WriteString(line)
+
+		switch {
+		case inData && line == ".\r\n":
+			inData = false
+			reply("250 OK")
+		case inData:
+		case strings.HasPrefix(line, "EHLO"):
+			reply("250 localhost")
+		case strings.HasPrefix(line, "DATA"):
+			inData = true
+			reply("354 go ahead")
+		case strings.HasPrefix(line, "QUIT"):
+			reply("221 bye")
+			received <- transcript.String()
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
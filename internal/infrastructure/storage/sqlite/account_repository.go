@@ -0,0 +1,334 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/account"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+)
+
+type SQLiteAccountRepository struct {
+	db DBExecutor
+}
+
+func NewSQLiteAccountRepository(db DBExecutor) *SQLiteAccountRepository {
+	return &SQLiteAccountRepository{db: db}
+}
+
+func (r *SQLiteAccountRepository) Save(ctx context.Context, a account.Account) error {
+	query := `
+		INSERT INTO accounts (id, user_id, name, type, opening_balance)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			name = excluded.name,
+			type = excluded.type,
+			opening_balance = excluded.opening_balance
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		a.ID.String(),
+		a.UserID.String(),
+		a.Name.Value(),
+		a.Type.Value(),
+		a.OpeningBalance.Cents(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save account: %w", err)
+	}
+	return nil
+}
+
+func (r *SQLiteAccountRepository) FindByID(ctx context.Context, userID identifier.ID, id identifier.ID) (account.Account, error) {
+	accounts, err := r.findAccounts(ctx, `WHERE a.user_id = ? AND a.id = ?`, userID.String(), id.String())
+	if err != nil {
+		return account.Account{}, err
+	}
+	if len(accounts) == 0 {
+		return account.Account{}, account.ErrAccountNotFound
+	}
+	return accounts[0], nil
+}
+
+func (r *SQLiteAccountRepository) FindByUserID(ctx context.Context, userID identifier.ID) ([]account.Account, error) {
+	return r.findAccounts(ctx, `WHERE a.user_id = ?`, userID.String())
+}
+
+// Delete removes the account with its transfers and reconciliations. Incomes
+// and expenses recorded against it are kept; the database unties them from the
+// account in the same statement.
+func (r *SQLiteAccountRepository) Delete(ctx context.Context, userID identifier.ID, id identifier.ID) error {
+	query := `DELETE FROM accounts WHERE user_id = ? AND id = ?`
+	result, err := r.db.ExecContext(ctx, query, userID.String(), id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete account: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return account.ErrAccountNotFound
+	}
+	return nil
+}
+
+func (r *SQLiteAccountRepository) SaveTransfer(ctx context.Context, transfer account.Transfer) error {
+	query := `
+		INSERT INTO account_transfers (id, user_id, from_account_id, to_account_id, amount, transfer_date)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		transfer.ID.String(),
+		transfer.UserID.String(),
+		transfer.FromAccountID.String(),
+		transfer.ToAccountID.String(),
+		transfer.Amount.Cents(),
+		transfer.Date,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save transfer: %w", err)
+	}
+	return nil
+}
+
+func (r *SQLiteAccountRepository) DeleteTransfer(ctx context.Context, userID identifier.ID, id identifier.ID) error {
+	query := `DELETE FROM account_transfers WHERE user_id = ? AND id = ?`
+	result, err := r.db.ExecContext(ctx, query, userID.String(), id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete transfer: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return account.ErrTransferNotFound
+	}
+
+	query = `DELETE FROM account_reconciled_entries WHERE kind IN (?, ?) AND entry_id = ?`
+	_, err = r.db.ExecContext(ctx, query, string(account.EntryTransferIn), string(account.EntryTransferOut), id.String())
+	if err != nil {
+		return fmt.Errorf("failed to clear transfer reconciliation: %w", err)
+	}
+	return nil
+}
+
+// Entries returns every income and paid expense tied to the account and every
+// transfer in or out of it. A paid expense is dated when it was paid.
+func (r *SQLiteAccountRepository) Entries(ctx context.Context, userID identifier.ID, accountID identifier.ID) ([]account.Entry, error) {
+	a, err := r.FindByID(ctx, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	queries := []struct {
+		kind  account.EntryKind
+		query string
+	}{
+		{account.EntryIncome, `
+			SELECT i.id, i.received_at, NULL, COALESCE(i.source, ''), i.amount
+			FROM incomes i
+			WHERE i.user_id = ? AND i.account_id = ?
+		`},
+		{account.EntryExpense, `
+			SELECT e.id, e.spent_at, e.paid_at, COALESCE(NULLIF(e.description, ''), c.name), e.amount
+			FROM expenses e
+			JOIN categories c ON e.category_id = c.id
+			JOIN groups g ON c.group_id = g.id
+			WHERE g.user_id = ? AND e.account_id = ? AND e.is_paid = 1
+		`},
+		{account.EntryTransferOut, `
+			SELECT t.id, t.transfer_date, NULL, 'Transfer to ' || dest.name, t.amount
+			FROM account_transfers t
+			JOIN accounts dest ON t.to_account_id = dest.id
+			WHERE t.user_id = ? AND t.from_account_id = ?
+		`},
+		{account.EntryTransferIn, `
+			SELECT t.id, t.transfer_date, NULL, 'Transfer from ' || src.name, t.amount
+			FROM account_transfers t
+			JOIN accounts src ON t.from_account_id = src.id
+			WHERE t.user_id = ? AND t.to_account_id = ?
+		`},
+	}
+
+	reconciled, err := r.reconciledEntries(ctx, accountID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]account.Entry, 0)
+	for _, q := range queries {
+		rows, err := r.db.QueryContext(ctx, q.query, userID.String(), accountID.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to query account entries: %w", err)
+		}
+
+		for rows.Next() {
+			var idStr, description string
+			var date time.Time
+			var paidAt sql.NullTime
+			var amountCents int64
+			if err := rows.Scan(&idStr, &date, &paidAt, &description, &amountCents); err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("failed to scan account entry row: %w", err)
+			}
+
+			entry, err := r.mapToEntry(q.kind, idStr, date, paidAt, description, amountCents, a.OpeningBalance.Currency())
+			if err != nil {
+				_ = rows.Close()
+				return nil, fmt.Errorf("failed to map account entry: %w", err)
+			}
+			_, entry.Reconciled = reconciled[account.EntryRef{Kind: entry.Kind, ID: entry.ID}]
+			entries = append(entries, entry)
+		}
+		if err := rows.Err(); err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("error iterating account entry rows: %w", err)
+		}
+		_ = rows.Close()
+	}
+
+	return entries, nil
+}
+
+// SaveReconciliation records the reconciliation and marks its entries as
+// reconciled.
+func (r *SQLiteAccountRepository) SaveReconciliation(ctx context.Context, reconciliation account.Reconciliation) error {
+	query := `
+		INSERT INTO account_reconciliations (id, account_id, statement_date, statement_balance)
+		VALUES (?, ?, ?, ?)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		reconciliation.ID.String(),
+		reconciliation.AccountID.String(),
+		reconciliation.StatementDate,
+		reconciliation.StatementBalance.Cents(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save reconciliation: %w", err)
+	}
+
+	entryQuery := `
+		INSERT INTO account_reconciled_entries (account_id, kind, entry_id)
+		VALUES (?, ?, ?)
+		ON CONFLICT DO NOTHING
+	`
+	for _, ref := range reconciliation.Entries {
+		_, err := r.db.ExecContext(ctx, entryQuery, reconciliation.AccountID.String(), string(ref.Kind), ref.ID.String())
+		if err != nil {
+			return fmt.Errorf("failed to save reconciled entry: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *SQLiteAccountRepository) reconciledEntries(ctx context.Context, accountID string) (map[account.EntryRef]struct{}, error) {
+	query := `SELECT kind, entry_id FROM account_reconciled_entries WHERE account_id = ?`
+	rows, err := r.db.QueryContext(ctx, query, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reconciled entries: %w", err)
+	}
+	defer rows.Close()
+
+	reconciled := make(map[account.EntryRef]struct{})
+	for rows.Next() {
+		var kind, idStr string
+		if err := rows.Scan(&kind, &idStr); err != nil {
+			return nil, fmt.Errorf("failed to scan reconciled entry row: %w", err)
+		}
+		id, err := identifier.ParseID(idStr)
+		if err != nil {
+			return nil, err
+		}
+		reconciled[account.EntryRef{Kind: account.EntryKind(kind), ID: id}] = struct{}{}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reconciled entry rows: %w", err)
+	}
+	return reconciled, nil
+}
+
+func (r *SQLiteAccountRepository) findAccounts(ctx context.Context, where string, args ...any) ([]account.Account, error) {
+	query := `
+		SELECT a.id, a.user_id, a.name, a.type, a.opening_balance, u.currency
+		FROM accounts a
+		JOIN users u ON a.user_id = u.id
+		` + where + `
+		ORDER BY a.name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query accounts: %w", err)
+	}
+	defer rows.Close()
+
+	accounts := make([]account.Account, 0)
+	for rows.Next() {
+		var idStr, userIDStr, nameStr, typeStr, currencyStr string
+		var openingCents int64
+		if err := rows.Scan(&idStr, &userIDStr, &nameStr, &typeStr, &openingCents, &currencyStr); err != nil {
+			return nil, fmt.Errorf("failed to scan account row: %w", err)
+		}
+
+		a, err := r.mapToAccount(idStr, userIDStr, nameStr, typeStr, openingCents, currencyStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map account: %w", err)
+		}
+		accounts = append(accounts, *a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating account rows: %w", err)
+	}
+	return accounts, nil
+}
+
+func (r *SQLiteAccountRepository) mapToEntry(kind account.EntryKind, idStr string, date time.Time, paidAt sql.NullTime, description string, amountCents int64, currency string) (account.Entry, error) {
+	id, err := identifier.ParseID(idStr)
+	if err != nil {
+		return account.Entry{}, err
+	}
+	amount, err := money.New(amountCents, currency)
+	if err != nil {
+		return account.Entry{}, err
+	}
+	if paidAt.Valid {
+		date = paidAt.Time
+	}
+
+	return account.Entry{
+		Kind:        kind,
+		ID:          id,
+		Date:        date,
+		Description: description,
+		Amount:      amount,
+	}, nil
+}
+
+func (r *SQLiteAccountRepository) mapToAccount(idStr, userIDStr, nameStr, typeStr string, openingCents int64, currencyStr string) (*account.Account, error) {
+	id, err := identifier.ParseID(idStr)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := identifier.ParseID(userIDStr)
+	if err != nil {
+		return nil, err
+	}
+	name, err := account.NewNameVO(nameStr)
+	if err != nil {
+		return nil, err
+	}
+	accountType, err := account.NewType(typeStr)
+	if err != nil {
+		return nil, err
+	}
+	opening, err := money.New(openingCents, currencyStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return account.NewAccount(id, userID, name, accountType, opening)
+}
@@ -0,0 +1,165 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/account"
+	"github.com/madalinpopa/gocost-web/internal/domain/expense"
+	"github.com/madalinpopa/gocost-web/internal/infrastructure/storage/sqlite"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createAccount(t *testing.T, userID identifier.ID, name string, openingCents int64) *account.Account {
+	t.Helper()
+	id, err := identifier.NewID()
+	require.NoError(t, err)
+
+	nameVO, err := account.NewNameVO(name)
+	require.NoError(t, err)
+	opening, err := money.New(openingCents, "USD")
+	require.NoError(t, err)
+
+	a, err := account.NewAccount(id, userID, nameVO, account.TypeChecking, opening)
+	require.NoError(t, err)
+	return a
+}
+
+func TestSQLiteAccountRepository(t *testing.T) {
+	repo := sqlite.NewSQLiteAccountRepository(testDB)
+	userRepo := sqlite.NewSQLiteUserRepository(testDB)
+	incomeRepo := sqlite.NewSQLiteIncomeRepository(testDB)
+	expenseRepo := sqlite.NewSQLiteExpenseRepository(testDB)
+	ctx := context.Background()
+
+	t.Run("Save_And_FindByID", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+
+		a := createAccount(t, user.ID, "Checking", 10000)
+		require.NoError(t, repo.Save(ctx, *a))
+
+		found, err := repo.FindByID(ctx, user.ID, a.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Checking", found.Name.Value())
+		assert.Equal(t, account.TypeChecking, found.Type)
+		assert.Equal(t, int64(10000), found.OpeningBalance.Cents())
+		assert.Equal(t, "USD", found.OpeningBalance.Currency())
+
+		other := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *other))
+		_, err = repo.FindByID(ctx, other.ID, a.ID)
+		assert.ErrorIs(t, err, account.ErrAccountNotFound)
+	})
+
+	t.Run("Entries", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		checking := createAccount(t, user.ID, "Checking", 0)
+		savings := createAccount(t, user.ID, "Savings", 0)
+		require.NoError(t, repo.Save(ctx, *checking))
+		require.NoError(t, repo.Save(ctx, *savings))
+
+		inc := createRandomIncome(t, user.ID)
+		inc.AccountID = checking.ID
+		require.NoError(t, incomeRepo.Save(ctx, *inc))
+
+		var err error
+		group := createRandomGroup(t, user.ID)
+		category := createRandomCategory(t, group.ID)
+		paid := createRandomExpense(t, category.ID)
+		paid.Payment, err = expense.NewPaidStatus(time.Now())
+		require.NoError(t, err)
+		paid.AccountID = checking.ID
+		require.NoError(t, expenseRepo.Save(ctx, *paid))
+		unpaid := createRandomExpense(t, category.ID)
+		unpaid.AccountID = checking.ID
+		require.NoError(t, expenseRepo.Save(ctx, *unpaid))
+
+		transferID, err := identifier.NewID()
+		require.NoError(t, err)
+		amount, err := money.New(200, "USD")
+		require.NoError(t, err)
+		transfer, err := account.NewTransfer(transferID, user.ID, checking.ID, savings.ID, amount, time.Now())
+		require.NoError(t, err)
+		require.NoError(t, repo.SaveTransfer(ctx, *transfer))
+
+		entries, err := repo.Entries(ctx, user.ID, checking.ID)
+		require.NoError(t, err)
+		kinds := make(map[account.EntryKind]account.Entry)
+		for _, e := range entries {
+			kinds[e.Kind] = e
+		}
+		require.Len(t, entries, 3)
+		assert.Equal(t, inc.ID, kinds[account.EntryIncome].ID)
+		assert.Equal(t, paid.ID, kinds[account.EntryExpense].ID)
+		assert.Equal(t, "Transfer to Savings", kinds[account.EntryTransferOut].Description)
+
+		savingsEntries, err := repo.Entries(ctx, user.ID, savings.ID)
+		require.NoError(t, err)
+		require.Len(t, savingsEntries, 1)
+		assert.Equal(t, account.EntryTransferIn, savingsEntries[0].Kind)
+		assert.Equal(t, int64(200), savingsEntries[0].Amount.Cents())
+
+		t.Run("marks reconciled entries", func(t *testing.T) {
+			reconciliationID, err := identifier.NewID()
+			require.NoError(t, err)
+			balance, err := money.New(0, "USD")
+			require.NoError(t, err)
+			err = repo.SaveReconciliation(ctx, account.Reconciliation{
+				ID:               reconciliationID,
+				AccountID:        checking.ID,
+				StatementDate:    time.Now(),
+				StatementBalance: balance,
+				Entries:          []account.EntryRef{{Kind: account.EntryIncome, ID: inc.ID}},
+			})
+			require.NoError(t, err)
+
+			entries, err := repo.Entries(ctx, user.ID, checking.ID)
+			require.NoError(t, err)
+			for _, e := range entries {
+				assert.Equal(t, e.Kind == account.EntryIncome, e.Reconciled, e.Kind)
+			}
+		})
+
+		t.Run("DeleteTransfer", func(t *testing.T) {
+			require.NoError(t, repo.DeleteTransfer(ctx, user.ID, transfer.ID))
+			assert.ErrorIs(t, repo.DeleteTransfer(ctx, user.ID, transfer.ID), account.ErrTransferNotFound)
+
+			entries, err := repo.Entries(ctx, user.ID, savings.ID)
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+		})
+
+		t.Run("Delete detaches incomes and expenses", func(t *testing.T) {
+			require.NoError(t, repo.Delete(ctx, user.ID, checking.ID))
+
+			found, err := incomeRepo.FindByID(ctx, inc.ID)
+			require.NoError(t, err)
+			assert.True(t, found.AccountID.IsZero())
+
+			foundExpense, err := expenseRepo.FindByID(ctx, paid.ID)
+			require.NoError(t, err)
+			assert.True(t, foundExpense.AccountID.IsZero())
+
+			assert.ErrorIs(t, repo.Delete(ctx, user.ID, checking.ID), account.ErrAccountNotFound)
+		})
+	})
+
+	t.Run("FindByUserID", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		require.NoError(t, repo.Save(ctx, *createAccount(t, user.ID, "Wallet", 0)))
+		require.NoError(t, repo.Save(ctx, *createAccount(t, user.ID, "Bank", 0)))
+
+		accounts, err := repo.FindByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, accounts, 2)
+		assert.Equal(t, "Bank", accounts[0].Name.Value())
+		assert.Equal(t, "Wallet", accounts[1].Name.Value())
+	})
+}
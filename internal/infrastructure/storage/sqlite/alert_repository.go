@@ -0,0 +1,234 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/alert"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+)
+
+type SQLiteAlertRepository struct {
+	db DBExecutor
+}
+
+func NewSQLiteAlertRepository(db DBExecutor) *SQLiteAlertRepository {
+	return &SQLiteAlertRepository{db: db}
+}
+
+func (r *SQLiteAlertRepository) Thresholds(ctx context.Context, userID identifier.ID) (map[identifier.ID][]alert.Threshold, error) {
+	query := `
+		SELECT t.category_id, t.percent
+		FROM category_alert_thresholds t
+		JOIN categories c ON t.category_id = c.id
+		JOIN groups g ON c.group_id = g.id
+		WHERE g.user_id = ?
+		ORDER BY t.category_id, t.percent
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query alert thresholds: %w", err)
+	}
+	defer rows.Close()
+
+	thresholds := make(map[identifier.ID][]alert.Threshold)
+	for rows.Next() {
+		var categoryIDStr string
+		var percent int
+		if err := rows.Scan(&categoryIDStr, &percent); err != nil {
+			return nil, fmt.Errorf("failed to scan alert threshold row: %w", err)
+		}
+
+		categoryID, err := identifier.ParseID(categoryIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map alert threshold: %w", err)
+		}
+		threshold, err := alert.NewThreshold(percent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map alert threshold: %w", err)
+		}
+		thresholds[categoryID] = append(thresholds[categoryID], threshold)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating alert threshold rows: %w", err)
+	}
+
+	return thresholds, nil
+}
+
+// SaveThresholds replaces the thresholds of the category.
+func (r *SQLiteAlertRepository) SaveThresholds(ctx context.Context, categoryID identifier.ID, thresholds []alert.Threshold) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM category_alert_thresholds WHERE category_id = ?`, categoryID.String()); err != nil {
+		return fmt.Errorf("failed to clear alert thresholds: %w", err)
+	}
+
+	for _, t := range thresholds {
+		query := `INSERT INTO category_alert_thresholds (category_id, percent) VALUES (?, ?)`
+		if _, err := r.db.ExecContext(ctx, query, categoryID.String(), t.Value()); err != nil {
+			return fmt.Errorf("failed to save alert threshold: %w", err)
+		}
+	}
+	return nil
+}
+
+// ForkCategory gives a category forked from another from a month on its
+// thresholds, and moves over the notifications from that month on so they
+// do not repeat.
+func (r *SQLiteAlertRepository) ForkCategory(ctx context.Context, fromCategoryID identifier.ID, toCategoryID identifier.ID, month string) error {
+	query := `
+		INSERT OR IGNORE INTO category_alert_thresholds (category_id, percent)
+		SELECT ?, percent FROM category_alert_thresholds WHERE category_id = ?
+	`
+	if _, err := r.db.ExecContext(ctx, query, toCategoryID.String(), fromCategoryID.String()); err != nil {
+		return fmt.Errorf("failed to copy alert thresholds: %w", err)
+	}
+
+	query = `
+		UPDATE OR IGNORE notifications SET category_id = ?
+		WHERE category_id = ? AND month >= ?
+	`
+	if _, err := r.db.ExecContext(ctx, query, toCategoryID.String(), fromCategoryID.String(), month); err != nil {
+		return fmt.Errorf("failed to move notifications: %w", err)
+	}
+	return nil
+}
+
+func (r *SQLiteAlertRepository) SaveNotification(ctx context.Context, n alert.Notification) (bool, error) {
+	query := `
+		INSERT INTO notifications (id, user_id, category_id, month, threshold, spent, budget, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(category_id, month, threshold) DO NOTHING
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		n.ID.String(),
+		n.UserID.String(),
+		n.CategoryID.String(),
+		n.Month,
+		n.Threshold.Value(),
+		n.Spent.Cents(),
+		n.Budget.Cents(),
+		n.CreatedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to save notification: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// Notifications returns the latest notifications of the user, the newest
+// first.
+func (r *SQLiteAlertRepository) Notifications(ctx context.Context, userID identifier.ID, limit int) ([]alert.Notification, error) {
+	query := `
+		SELECT n.id, n.user_id, n.category_id, c.name, n.month, n.threshold, n.spent, n.budget, n.created_at, n.read_at, u.currency
+		FROM notifications n
+		JOIN categories c ON n.category_id = c.id
+		JOIN users u ON n.user_id = u.id
+		WHERE n.user_id = ?
+		ORDER BY n.created_at DESC, n.threshold DESC
+		LIMIT ?
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID.String(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := make([]alert.Notification, 0)
+	for rows.Next() {
+		var idStr, userIDStr, categoryIDStr, categoryName, month, currencyStr string
+		var threshold int
+		var spentCents, budgetCents int64
+		var createdAt time.Time
+		var readAt sql.NullTime
+		if err := rows.Scan(&idStr, &userIDStr, &categoryIDStr, &categoryName, &month, &threshold, &spentCents, &budgetCents, &createdAt, &readAt, &currencyStr); err != nil {
+			return nil, fmt.Errorf("failed to scan notification row: %w", err)
+		}
+
+		n, err := r.mapToNotification(idStr, userIDStr, categoryIDStr, month, threshold, spentCents, budgetCents, currencyStr, createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map notification: %w", err)
+		}
+		n.CategoryName = categoryName
+		if readAt.Valid {
+			n.MarkRead(readAt.Time)
+		}
+		notifications = append(notifications, *n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating notification rows: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (r *SQLiteAlertRepository) CountUnread(ctx context.Context, userID identifier.ID) (int, error) {
+	query := `SELECT COUNT(*) FROM notifications WHERE user_id = ? AND read_at IS NULL`
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, userID.String()).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+func (r *SQLiteAlertRepository) MarkRead(ctx context.Context, userID identifier.ID, id identifier.ID, at time.Time) error {
+	query := `
+		UPDATE notifications SET read_at = COALESCE(read_at, ?)
+		WHERE user_id = ? AND id = ?
+	`
+	result, err := r.db.ExecContext(ctx, query, at, userID.String(), id.String())
+	if err != nil {
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return alert.ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (r *SQLiteAlertRepository) MarkAllRead(ctx context.Context, userID identifier.ID, at time.Time) error {
+	query := `UPDATE notifications SET read_at = ? WHERE user_id = ? AND read_at IS NULL`
+	if _, err := r.db.ExecContext(ctx, query, at, userID.String()); err != nil {
+		return fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+	return nil
+}
+
+func (r *SQLiteAlertRepository) mapToNotification(idStr, userIDStr, categoryIDStr, month string, threshold int, spentCents, budgetCents int64, currencyStr string, createdAt time.Time) (*alert.Notification, error) {
+	id, err := identifier.ParseID(idStr)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := identifier.ParseID(userIDStr)
+	if err != nil {
+		return nil, err
+	}
+	categoryID, err := identifier.ParseID(categoryIDStr)
+	if err != nil {
+		return nil, err
+	}
+	t, err := alert.NewThreshold(threshold)
+	if err != nil {
+		return nil, err
+	}
+	spent, err := money.New(spentCents, currencyStr)
+	if err != nil {
+		return nil, err
+	}
+	budget, err := money.New(budgetCents, currencyStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return alert.NewNotification(id, userID, categoryID, month, t, spent, budget, createdAt)
+}
@@ -0,0 +1,131 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/alert"
+	"github.com/madalinpopa/gocost-web/internal/infrastructure/storage/sqlite"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createNotification(t *testing.T, userID identifier.ID, categoryID identifier.ID, month string, threshold alert.Threshold, createdAt time.Time) alert.Notification {
+	t.Helper()
+	id, err := identifier.NewID()
+	require.NoError(t, err)
+
+	spent, err := money.New(8000, "USD")
+	require.NoError(t, err)
+	budget, err := money.New(10000, "USD")
+	require.NoError(t, err)
+
+	n, err := alert.NewNotification(id, userID, categoryID, month, threshold, spent, budget, createdAt)
+	require.NoError(t, err)
+	return *n
+}
+
+func TestSQLiteAlertRepository(t *testing.T) {
+	repo := sqlite.NewSQLiteAlertRepository(testDB)
+	userRepo := sqlite.NewSQLiteUserRepository(testDB)
+	ctx := context.Background()
+
+	t.Run("SaveThresholds_And_ForkCategory", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		group := createRandomGroup(t, user.ID)
+		category := createRandomCategory(t, group.ID)
+		forked := createRandomCategory(t, group.ID)
+
+		require.NoError(t, repo.SaveThresholds(ctx, category.ID, []alert.Threshold{50, 100}))
+		require.NoError(t, repo.SaveThresholds(ctx, category.ID, []alert.Threshold{80, 100}))
+		_, err := repo.SaveNotification(ctx, createNotification(t, user.ID, category.ID, "2024-02", 80, time.Now()))
+		require.NoError(t, err)
+		_, err = repo.SaveNotification(ctx, createNotification(t, user.ID, category.ID, "2024-03", 80, time.Now()))
+		require.NoError(t, err)
+		require.NoError(t, repo.ForkCategory(ctx, category.ID, forked.ID, "2024-03"))
+
+		thresholds, err := repo.Thresholds(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []alert.Threshold{80, 100}, thresholds[category.ID])
+		assert.Equal(t, []alert.Threshold{80, 100}, thresholds[forked.ID])
+
+		notifications, err := repo.Notifications(ctx, user.ID, 10)
+		require.NoError(t, err)
+		months := map[string]identifier.ID{}
+		for _, n := range notifications {
+			months[n.Month] = n.CategoryID
+		}
+		assert.Equal(t, category.ID, months["2024-02"])
+		assert.Equal(t, forked.ID, months["2024-03"])
+
+		require.NoError(t, repo.SaveThresholds(ctx, category.ID, nil))
+		thresholds, err = repo.Thresholds(ctx, user.ID)
+		require.NoError(t, err)
+		assert.NotContains(t, thresholds, category.ID)
+	})
+
+	t.Run("SaveNotification_OncePerMonth", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		group := createRandomGroup(t, user.ID)
+		category := createRandomCategory(t, group.ID)
+		now := time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+		saved, err := repo.SaveNotification(ctx, createNotification(t, user.ID, category.ID, "2024-03", 80, now))
+		require.NoError(t, err)
+		assert.True(t, saved)
+
+		saved, err = repo.SaveNotification(ctx, createNotification(t, user.ID, category.ID, "2024-03", 80, now.Add(time.Hour)))
+		require.NoError(t, err)
+		assert.False(t, saved)
+
+		saved, err = repo.SaveNotification(ctx, createNotification(t, user.ID, category.ID, "2024-04", 80, now.AddDate(0, 1, 0)))
+		require.NoError(t, err)
+		assert.True(t, saved)
+
+		notifications, err := repo.Notifications(ctx, user.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, notifications, 2)
+		assert.Equal(t, "2024-04", notifications[0].Month)
+		assert.Equal(t, category.Name.Value(), notifications[0].CategoryName)
+		assert.Equal(t, int64(8000), notifications[0].Spent.Cents())
+		assert.False(t, notifications[0].IsRead())
+	})
+
+	t.Run("MarkRead", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		group := createRandomGroup(t, user.ID)
+		category := createRandomCategory(t, group.ID)
+		now := time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+		first := createNotification(t, user.ID, category.ID, "2024-03", 80, now)
+		second := createNotification(t, user.ID, category.ID, "2024-03", 100, now)
+		for _, n := range []alert.Notification{first, second} {
+			_, err := repo.SaveNotification(ctx, n)
+			require.NoError(t, err)
+		}
+
+		count, err := repo.CountUnread(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		require.NoError(t, repo.MarkRead(ctx, user.ID, first.ID, now))
+		count, err = repo.CountUnread(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		other := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *other))
+		assert.ErrorIs(t, repo.MarkRead(ctx, other.ID, second.ID, now), alert.ErrNotificationNotFound)
+
+		require.NoError(t, repo.MarkAllRead(ctx, user.ID, now))
+		count, err = repo.CountUnread(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+}
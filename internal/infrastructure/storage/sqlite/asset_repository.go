@@ -0,0 +1,208 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/networth"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+)
+
+type SQLiteAssetRepository struct {
+	db DBExecutor
+}
+
+func NewSQLiteAssetRepository(db DBExecutor) *SQLiteAssetRepository {
+	return &SQLiteAssetRepository{db: db}
+}
+
+func (r *SQLiteAssetRepository) Save(ctx context.Context, asset networth.Asset) error {
+	query := `
+		INSERT INTO assets (id, user_id, name, kind)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			name = excluded.name,
+			kind = excluded.kind
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		asset.ID.String(),
+		asset.UserID.String(),
+		asset.Name.Value(),
+		asset.Kind.Value(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save asset: %w", err)
+	}
+	return nil
+}
+
+func (r *SQLiteAssetRepository) FindByID(ctx context.Context, userID identifier.ID, id identifier.ID) (networth.Asset, error) {
+	assets, err := r.findAssets(ctx, `WHERE user_id = ? AND id = ?`, userID.String(), id.String())
+	if err != nil {
+		return networth.Asset{}, err
+	}
+	if len(assets) == 0 {
+		return networth.Asset{}, networth.ErrAssetNotFound
+	}
+	return assets[0], nil
+}
+
+func (r *SQLiteAssetRepository) FindByUserID(ctx context.Context, userID identifier.ID) ([]networth.Asset, error) {
+	return r.findAssets(ctx, `WHERE user_id = ?`, userID.String())
+}
+
+func (r *SQLiteAssetRepository) Delete(ctx context.Context, userID identifier.ID, id identifier.ID) error {
+	query := `DELETE FROM assets WHERE user_id = ? AND id = ?`
+	result, err := r.db.ExecContext(ctx, query, userID.String(), id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete asset: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return networth.ErrAssetNotFound
+	}
+	return nil
+}
+
+// SaveValuation records the value of the asset for the month, replacing the
+// one already recorded for that month.
+func (r *SQLiteAssetRepository) SaveValuation(ctx context.Context, valuation networth.Valuation) error {
+	query := `
+		INSERT INTO asset_valuations (asset_id, month, value)
+		VALUES (?, ?, ?)
+		ON CONFLICT(asset_id, month) DO UPDATE SET
+			value = excluded.value
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		valuation.AssetID.String(),
+		valuation.Month,
+		valuation.Value.Cents(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save asset valuation: %w", err)
+	}
+	return nil
+}
+
+func (r *SQLiteAssetRepository) DeleteValuation(ctx context.Context, userID identifier.ID, assetID identifier.ID, month string) error {
+	query := `
+		DELETE FROM asset_valuations
+		WHERE asset_id = ? AND month = ?
+		AND asset_id IN (SELECT id FROM assets WHERE user_id = ?)
+	`
+	result, err := r.db.ExecContext(ctx, query, assetID.String(), month, userID.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete asset valuation: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return networth.ErrValuationNotFound
+	}
+	return nil
+}
+
+func (r *SQLiteAssetRepository) Valuations(ctx context.Context, userID identifier.ID) ([]networth.Valuation, error) {
+	query := `
+		SELECT v.asset_id, v.month, v.value, u.currency
+		FROM asset_valuations v
+		JOIN assets a ON v.asset_id = a.id
+		JOIN users u ON a.user_id = u.id
+		WHERE a.user_id = ?
+		ORDER BY v.asset_id, v.month
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query asset valuations: %w", err)
+	}
+	defer rows.Close()
+
+	valuations := make([]networth.Valuation, 0)
+	for rows.Next() {
+		var assetIDStr, month, currencyStr string
+		var valueCents int64
+		if err := rows.Scan(&assetIDStr, &month, &valueCents, &currencyStr); err != nil {
+			return nil, fmt.Errorf("failed to scan asset valuation row: %w", err)
+		}
+
+		assetID, err := identifier.ParseID(assetIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map asset valuation: %w", err)
+		}
+		value, err := money.New(valueCents, currencyStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map asset valuation: %w", err)
+		}
+		valuations = append(valuations, networth.Valuation{AssetID: assetID, Month: month, Value: value})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating asset valuation rows: %w", err)
+	}
+
+	return valuations, nil
+}
+
+func (r *SQLiteAssetRepository) findAssets(ctx context.Context, where string, args ...any) ([]networth.Asset, error) {
+	query := `
+		SELECT id, user_id, name, kind
+		FROM assets
+		` + where + `
+		ORDER BY name
+	`
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query assets: %w", err)
+	}
+	defer rows.Close()
+
+	assets := make([]networth.Asset, 0)
+	for rows.Next() {
+		var idStr, userIDStr, nameStr, kindStr string
+		if err := rows.Scan(&idStr, &userIDStr, &nameStr, &kindStr); err != nil {
+			return nil, fmt.Errorf("failed to scan asset row: %w", err)
+		}
+
+		asset, err := r.mapToAsset(idStr, userIDStr, nameStr, kindStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map asset: %w", err)
+		}
+		assets = append(assets, *asset)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating asset rows: %w", err)
+	}
+
+	return assets, nil
+}
+
+func (r *SQLiteAssetRepository) mapToAsset(idStr, userIDStr, nameStr, kindStr string) (*networth.Asset, error) {
+	id, err := identifier.ParseID(idStr)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := identifier.ParseID(userIDStr)
+	if err != nil {
+		return nil, err
+	}
+	name, err := networth.NewNameVO(nameStr)
+	if err != nil {
+		return nil, err
+	}
+	kind, err := networth.NewKind(kindStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &networth.Asset{
+		ID:     id,
+		UserID: userID,
+		Name:   name,
+		Kind:   kind,
+	}, nil
+}
@@ -0,0 +1,102 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/networth"
+	"github.com/madalinpopa/gocost-web/internal/infrastructure/storage/sqlite"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createAsset(t *testing.T, userID identifier.ID, name string, kind networth.Kind) *networth.Asset {
+	t.Helper()
+	id, err := identifier.NewID()
+	require.NoError(t, err)
+
+	nameVO, err := networth.NewNameVO(name)
+	require.NoError(t, err)
+
+	asset, err := networth.NewAsset(id, userID, nameVO, kind)
+	require.NoError(t, err)
+	return asset
+}
+
+func createValuation(t *testing.T, assetID identifier.ID, month string, cents int64) networth.Valuation {
+	t.Helper()
+	value, err := money.New(cents, "USD")
+	require.NoError(t, err)
+
+	valuation, err := networth.NewValuation(assetID, month, value)
+	require.NoError(t, err)
+	return *valuation
+}
+
+func TestSQLiteAssetRepository(t *testing.T) {
+	repo := sqlite.NewSQLiteAssetRepository(testDB)
+	userRepo := sqlite.NewSQLiteUserRepository(testDB)
+	ctx := context.Background()
+
+	t.Run("Save_And_FindByID", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+
+		asset := createAsset(t, user.ID, "House", networth.KindProperty)
+		require.NoError(t, repo.Save(ctx, *asset))
+
+		found, err := repo.FindByID(ctx, user.ID, asset.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "House", found.Name.Value())
+		assert.Equal(t, networth.KindProperty, found.Kind)
+
+		other := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *other))
+		_, err = repo.FindByID(ctx, other.ID, asset.ID)
+		assert.ErrorIs(t, err, networth.ErrAssetNotFound)
+	})
+
+	t.Run("Valuations", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		asset := createAsset(t, user.ID, "Brokerage", networth.KindInvestment)
+		require.NoError(t, repo.Save(ctx, *asset))
+
+		require.NoError(t, repo.SaveValuation(ctx, createValuation(t, asset.ID, "2024-03", 500000)))
+		require.NoError(t, repo.SaveValuation(ctx, createValuation(t, asset.ID, "2024-01", 400000)))
+		require.NoError(t, repo.SaveValuation(ctx, createValuation(t, asset.ID, "2024-03", 550000)))
+
+		valuations, err := repo.Valuations(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, valuations, 2)
+		assert.Equal(t, "2024-01", valuations[0].Month)
+		assert.Equal(t, int64(550000), valuations[1].Value.Cents())
+		assert.Equal(t, asset.ID, valuations[1].AssetID)
+
+		other := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *other))
+		assert.ErrorIs(t, repo.DeleteValuation(ctx, other.ID, asset.ID, "2024-01"), networth.ErrValuationNotFound)
+
+		require.NoError(t, repo.DeleteValuation(ctx, user.ID, asset.ID, "2024-01"))
+		valuations, err = repo.Valuations(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Len(t, valuations, 1)
+	})
+
+	t.Run("Delete_RemovesValuations", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		asset := createAsset(t, user.ID, "Car", networth.KindVehicle)
+		require.NoError(t, repo.Save(ctx, *asset))
+		require.NoError(t, repo.SaveValuation(ctx, createValuation(t, asset.ID, "2024-01", 1500000)))
+
+		require.NoError(t, repo.Delete(ctx, user.ID, asset.ID))
+		assert.ErrorIs(t, repo.Delete(ctx, user.ID, asset.ID), networth.ErrAssetNotFound)
+
+		valuations, err := repo.Valuations(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Empty(t, valuations)
+	})
+}
@@ -0,0 +1,138 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+)
+
+type SQLiteClosingRepository struct {
+	db DBExecutor
+}
+
+func NewSQLiteClosingRepository(db DBExecutor) *SQLiteClosingRepository {
+	return &SQLiteClosingRepository{db: db}
+}
+
+func (r *SQLiteClosingRepository) Save(ctx context.Context, c closing.MonthClose) error {
+	query := `
+		INSERT INTO month_closes (id, user_id, month, total_income, total_expenses, total_budgeted, paid_expenses, unpaid_count, snapshot, closed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		c.ID.String(),
+		c.UserID.String(),
+		c.Month,
+		c.Totals.Income.Cents(),
+		c.Totals.Expenses.Cents(),
+		c.Totals.Budgeted.Cents(),
+		c.Totals.PaidExpenses.Cents(),
+		c.UnpaidCount,
+		string(c.Snapshot),
+		c.ClosedAt,
+	)
+	if err != nil {
+		if isUniqueConstraintViolation(err) {
+			return closing.ErrMonthClosed
+		}
+		return fmt.Errorf("failed to save month close: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLiteClosingRepository) FindByUserIDAndMonth(ctx context.Context, userID identifier.ID, month string) (closing.MonthClose, error) {
+	query := `
+		SELECT m.id, m.user_id, m.month, m.total_income, m.total_expenses, m.total_budgeted, m.paid_expenses, m.unpaid_count, m.snapshot, m.closed_at, u.currency
+		FROM month_closes m
+		JOIN users u ON m.user_id = u.id
+		WHERE m.user_id = ? AND m.month = ?
+	`
+
+	var idStr, userIDStr, monthStr, snapshot, currencyStr string
+	var incomeCents, expensesCents, budgetedCents, paidCents int64
+	var unpaidCount int
+	var closedAt time.Time
+
+	err := r.db.QueryRowContext(ctx, query, userID.String(), month).Scan(
+		&idStr, &userIDStr, &monthStr, &incomeCents, &expensesCents, &budgetedCents, &paidCents, &unpaidCount, &snapshot, &closedAt, &currencyStr,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return closing.MonthClose{}, closing.ErrMonthNotClosed
+		}
+		return closing.MonthClose{}, fmt.Errorf("failed to find month close: %w", err)
+	}
+
+	id, err := identifier.ParseID(idStr)
+	if err != nil {
+		return closing.MonthClose{}, err
+	}
+	uID, err := identifier.ParseID(userIDStr)
+	if err != nil {
+		return closing.MonthClose{}, err
+	}
+
+	income, err := money.New(incomeCents, currencyStr)
+	if err != nil {
+		return closing.MonthClose{}, err
+	}
+	expenses, err := money.New(expensesCents, currencyStr)
+	if err != nil {
+		return closing.MonthClose{}, err
+	}
+	budgeted, err := money.New(budgetedCents, currencyStr)
+	if err != nil {
+		return closing.MonthClose{}, err
+	}
+	paid, err := money.New(paidCents, currencyStr)
+	if err != nil {
+		return closing.MonthClose{}, err
+	}
+
+	totals := closing.Totals{
+		Income:       income,
+		Expenses:     expenses,
+		Budgeted:     budgeted,
+		PaidExpenses: paid,
+	}
+
+	monthClose, err := closing.NewMonthClose(id, uID, monthStr, totals, unpaidCount, []byte(snapshot), closedAt)
+	if err != nil {
+		return closing.MonthClose{}, err
+	}
+	return *monthClose, nil
+}
+
+func (r *SQLiteClosingRepository) IsClosed(ctx context.Context, userID identifier.ID, month string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM month_closes WHERE user_id = ? AND month = ?)`
+
+	var closed bool
+	if err := r.db.QueryRowContext(ctx, query, userID.String(), month).Scan(&closed); err != nil {
+		return false, fmt.Errorf("failed to check month close: %w", err)
+	}
+	return closed, nil
+}
+
+func (r *SQLiteClosingRepository) Delete(ctx context.Context, userID identifier.ID, month string) error {
+	query := `DELETE FROM month_closes WHERE user_id = ? AND month = ?`
+	result, err := r.db.ExecContext(ctx, query, userID.String(), month)
+	if err != nil {
+		return fmt.Errorf("failed to delete month close: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return closing.ErrMonthNotClosed
+	}
+	return nil
+}
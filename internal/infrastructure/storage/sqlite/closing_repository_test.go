@@ -0,0 +1,106 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
+	"github.com/madalinpopa/gocost-web/internal/infrastructure/storage/sqlite"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMonthClose(t *testing.T, userID identifier.ID, month string) *closing.MonthClose {
+	t.Helper()
+	id, err := identifier.NewID()
+	require.NoError(t, err)
+
+	income, err := money.New(250000, "USD")
+	require.NoError(t, err)
+	expenses, err := money.New(120000, "USD")
+	require.NoError(t, err)
+	budgeted, err := money.New(150000, "USD")
+	require.NoError(t, err)
+	paid, err := money.New(100000, "USD")
+	require.NoError(t, err)
+
+	totals := closing.Totals{Income: income, Expenses: expenses, Budgeted: budgeted, PaidExpenses: paid}
+	monthClose, err := closing.NewMonthClose(id, userID, month, totals, 2, []byte(`{"TotalIncomeCents":250000}`), time.Now().UTC())
+	require.NoError(t, err)
+	return monthClose
+}
+
+func TestSQLiteClosingRepository(t *testing.T) {
+	repo := sqlite.NewSQLiteClosingRepository(testDB)
+	userRepo := sqlite.NewSQLiteUserRepository(testDB)
+	ctx := context.Background()
+
+	t.Run("Save_And_Find", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+
+		monthClose := createMonthClose(t, user.ID, "2024-03")
+		require.NoError(t, repo.Save(ctx, *monthClose))
+
+		found, err := repo.FindByUserIDAndMonth(ctx, user.ID, "2024-03")
+		require.NoError(t, err)
+		assert.Equal(t, monthClose.ID, found.ID)
+		assert.Equal(t, "2024-03", found.Month)
+		assert.Equal(t, monthClose.Totals.Income.Cents(), found.Totals.Income.Cents())
+		assert.Equal(t, monthClose.Totals.Expenses.Cents(), found.Totals.Expenses.Cents())
+		assert.Equal(t, monthClose.Totals.Budgeted.Cents(), found.Totals.Budgeted.Cents())
+		assert.Equal(t, monthClose.Totals.PaidExpenses.Cents(), found.Totals.PaidExpenses.Cents())
+		assert.Equal(t, "USD", found.Totals.Income.Currency())
+		assert.Equal(t, 2, found.UnpaidCount)
+		assert.JSONEq(t, string(monthClose.Snapshot), string(found.Snapshot))
+		assert.WithinDuration(t, monthClose.ClosedAt, found.ClosedAt, time.Second)
+	})
+
+	t.Run("Save_RejectsSecondClose", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+
+		require.NoError(t, repo.Save(ctx, *createMonthClose(t, user.ID, "2024-03")))
+		err := repo.Save(ctx, *createMonthClose(t, user.ID, "2024-03"))
+		assert.ErrorIs(t, err, closing.ErrMonthClosed)
+	})
+
+	t.Run("FindByUserIDAndMonth_NotClosed", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+
+		_, err := repo.FindByUserIDAndMonth(ctx, user.ID, "2024-03")
+		assert.ErrorIs(t, err, closing.ErrMonthNotClosed)
+	})
+
+	t.Run("IsClosed", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		require.NoError(t, repo.Save(ctx, *createMonthClose(t, user.ID, "2024-03")))
+
+		closed, err := repo.IsClosed(ctx, user.ID, "2024-03")
+		require.NoError(t, err)
+		assert.True(t, closed)
+
+		closed, err = repo.IsClosed(ctx, user.ID, "2024-04")
+		require.NoError(t, err)
+		assert.False(t, closed)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		require.NoError(t, repo.Save(ctx, *createMonthClose(t, user.ID, "2024-03")))
+
+		require.NoError(t, repo.Delete(ctx, user.ID, "2024-03"))
+		closed, err := repo.IsClosed(ctx, user.ID, "2024-03")
+		require.NoError(t, err)
+		assert.False(t, closed)
+
+		err = repo.Delete(ctx, user.ID, "2024-03")
+		assert.ErrorIs(t, err, closing.ErrMonthNotClosed)
+	})
+}
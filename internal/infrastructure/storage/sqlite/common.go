@@ -6,6 +6,7 @@ import (
 	"errors"
 	"strings"
 
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
 	"github.com/mattn/go-sqlite3"
 )
 
@@ -16,6 +17,11 @@ type DBExecutor interface {
 	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 }
 
+// rowScanner is a single row of a query, from sql.Row or sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
 func isUniqueConstraintViolation(err error) bool {
 	var sqliteErr sqlite3.Error
 	if errors.As(err, &sqliteErr) {
@@ -28,3 +34,19 @@ func isUniqueConstraintViolation(err error) bool {
 	}
 	return false
 }
+
+// nullableID stores a zero ID as NULL.
+func nullableID(id identifier.ID) sql.NullString {
+	if id.IsZero() {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: id.String(), Valid: true}
+}
+
+// parseNullableID reads a column written by nullableID back into an ID.
+func parseNullableID(value sql.NullString) (identifier.ID, error) {
+	if !value.Valid || value.String == "" {
+		return identifier.ID{}, nil
+	}
+	return identifier.ParseID(value.String)
+}
@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/madalinpopa/gocost-web/internal/domain/expense"
@@ -22,8 +24,8 @@ func NewSQLiteExpenseRepository(db DBExecutor) *SQLiteExpenseRepository {
 
 func (r *SQLiteExpenseRepository) Save(ctx context.Context, e expense.Expense) error {
 	query := `
-		INSERT INTO expenses (id, category_id, amount, description, spent_at, is_paid, paid_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO expenses (id, category_id, amount, description, spent_at, is_paid, paid_at, account_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
 			category_id = excluded.category_id,
 			amount = excluded.amount,
@@ -31,6 +33,7 @@ func (r *SQLiteExpenseRepository) Save(ctx context.Context, e expense.Expense) e
 			spent_at = excluded.spent_at,
 			is_paid = excluded.is_paid,
 			paid_at = excluded.paid_at,
+			account_id = excluded.account_id,
 			updated_at = CURRENT_TIMESTAMP
 	`
 
@@ -47,6 +50,7 @@ func (r *SQLiteExpenseRepository) Save(ctx context.Context, e expense.Expense) e
 		e.SpentAt,
 		e.Payment.IsPaid(),
 		paidAt,
+		nullableID(e.AccountID),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to save expense: %w", err)
@@ -58,7 +62,7 @@ func (r *SQLiteExpenseRepository) Save(ctx context.Context, e expense.Expense) e
 func (r *SQLiteExpenseRepository) FindByID(ctx context.Context, id identifier.ID) (expense.Expense, error) {
 	// Updated query to join up to users to get currency
 	query := `
-		SELECT e.id, e.category_id, e.amount, e.description, e.spent_at, e.is_paid, e.paid_at, u.currency
+		SELECT e.id, e.category_id, e.amount, e.description, e.spent_at, e.is_paid, e.paid_at, e.account_id, u.currency
 		FROM expenses e
 		JOIN categories c ON e.category_id = c.id
 		JOIN groups g ON c.group_id = g.id
@@ -71,6 +75,7 @@ func (r *SQLiteExpenseRepository) FindByID(ctx context.Context, id identifier.ID
 	var spentAt time.Time
 	var isPaidInt int
 	var paidAt sql.NullTime
+	var accountID sql.NullString
 
 	err := r.db.QueryRowContext(ctx, query, id.String()).Scan(
 		&idStr,
@@ -80,6 +85,7 @@ func (r *SQLiteExpenseRepository) FindByID(ctx context.Context, id identifier.ID
 		&spentAt,
 		&isPaidInt,
 		&paidAt,
+		&accountID,
 		&currencyStr,
 	)
 	if err != nil {
@@ -98,12 +104,13 @@ func (r *SQLiteExpenseRepository) FindByID(ctx context.Context, id identifier.ID
 		spentAt,
 		isPaidInt == 1,
 		paidAt,
+		accountID,
 	)
 }
 
 func (r *SQLiteExpenseRepository) FindByUserID(ctx context.Context, userID identifier.ID) ([]expense.Expense, error) {
 	query := `
-		SELECT e.id, e.category_id, e.amount, e.description, e.spent_at, e.is_paid, e.paid_at, u.currency
+		SELECT e.id, e.category_id, e.amount, e.description, e.spent_at, e.is_paid, e.paid_at, e.account_id, u.currency
 		FROM expenses e
 		JOIN categories c ON e.category_id = c.id
 		JOIN groups g ON c.group_id = g.id
@@ -122,7 +129,7 @@ func (r *SQLiteExpenseRepository) FindByUserIDAndMonth(ctx context.Context, user
 	}
 
 	query := `
-		SELECT e.id, e.category_id, e.amount, e.description, e.spent_at, e.is_paid, e.paid_at, u.currency
+		SELECT e.id, e.category_id, e.amount, e.description, e.spent_at, e.is_paid, e.paid_at, e.account_id, u.currency
 		FROM expenses e
 		JOIN categories c ON e.category_id = c.id
 		JOIN groups g ON c.group_id = g.id
@@ -231,6 +238,111 @@ func (r *SQLiteExpenseRepository) ReassignCategoryFromMonth(ctx context.Context,
 	return nil
 }
 
+func (r *SQLiteExpenseRepository) ReassignCategory(ctx context.Context, userID identifier.ID, fromCategoryID identifier.ID, toCategoryID identifier.ID) error {
+	query := `
+			UPDATE expenses
+			SET category_id = ?
+			WHERE category_id = ?
+			  AND EXISTS (
+				SELECT 1
+				FROM categories c
+				JOIN groups g ON c.group_id = g.id
+				WHERE c.id = expenses.category_id AND g.user_id = ?
+			  )
+			  AND EXISTS (
+				SELECT 1
+				FROM categories c
+				JOIN groups g ON c.group_id = g.id
+				WHERE c.id = ? AND g.user_id = ?
+			  )
+		`
+
+	_, err := r.db.ExecContext(ctx, query, toCategoryID.String(), fromCategoryID.String(), userID.String(), toCategoryID.String(), userID.String())
+	if err != nil {
+		return fmt.Errorf("failed to reassign expenses: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLiteExpenseRepository) CountByCategoriesPerMonth(ctx context.Context, userID identifier.ID, categoryIDs []identifier.ID) ([]expense.MonthlyCount, error) {
+	if len(categoryIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(categoryIDs)), ",")
+	query := fmt.Sprintf(`
+		SELECT e.spent_at
+		FROM expenses e
+		JOIN categories c ON e.category_id = c.id
+		JOIN groups g ON c.group_id = g.id
+		WHERE g.user_id = ? AND e.category_id IN (%s)
+	`, placeholders)
+
+	args := make([]any, 0, len(categoryIDs)+1)
+	args = append(args, userID.String())
+	for _, id := range categoryIDs {
+		args = append(args, id.String())
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expense months: %w", err)
+	}
+	defer rows.Close()
+
+	// Months are grouped here rather than in SQL so the stored time format
+	// does not matter.
+	countByMonth := make(map[string]int)
+	for rows.Next() {
+		var spentAt time.Time
+		if err := rows.Scan(&spentAt); err != nil {
+			return nil, fmt.Errorf("failed to scan expense month: %w", err)
+		}
+		countByMonth[spentAt.Format("2006-01")]++
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating expense months: %w", err)
+	}
+
+	counts := make([]expense.MonthlyCount, 0, len(countByMonth))
+	for month, count := range countByMonth {
+		counts = append(counts, expense.MonthlyCount{Month: month, Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		return counts[i].Month < counts[j].Month
+	})
+
+	return counts, nil
+}
+
+func (r *SQLiteExpenseRepository) DeleteByCategoryFromMonth(ctx context.Context, userID identifier.ID, categoryID identifier.ID, month string) error {
+	start, _, err := monthToDateRange(month)
+	if err != nil {
+		return fmt.Errorf("failed to parse month: %w", err)
+	}
+
+	query := `
+			DELETE FROM expenses
+			WHERE category_id = ?
+			  AND spent_at >= ?
+			  AND EXISTS (
+				SELECT 1
+				FROM categories c
+				JOIN groups g ON c.group_id = g.id
+				WHERE c.id = expenses.category_id AND g.user_id = ?
+			  )
+		`
+
+	_, err = r.db.ExecContext(ctx, query, categoryID.String(), start, userID.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete expenses: %w", err)
+	}
+
+	return nil
+}
+
 func (r *SQLiteExpenseRepository) fetchExpenses(ctx context.Context, query string, args ...any) ([]expense.Expense, error) {
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -245,12 +357,13 @@ func (r *SQLiteExpenseRepository) fetchExpenses(ctx context.Context, query strin
 		var spentAt time.Time
 		var isPaidInt int
 		var paidAt sql.NullTime
+		var accountID sql.NullString
 
-		if err := rows.Scan(&idStr, &categoryIDStr, &amountCents, &descriptionStr, &spentAt, &isPaidInt, &paidAt, &currencyStr); err != nil {
+		if err := rows.Scan(&idStr, &categoryIDStr, &amountCents, &descriptionStr, &spentAt, &isPaidInt, &paidAt, &accountID, &currencyStr); err != nil {
 			return nil, fmt.Errorf("failed to scan expense row: %w", err)
 		}
 
-		exp, err := r.mapToExpense(idStr, categoryIDStr, amountCents, currencyStr, descriptionStr, spentAt, isPaidInt == 1, paidAt)
+		exp, err := r.mapToExpense(idStr, categoryIDStr, amountCents, currencyStr, descriptionStr, spentAt, isPaidInt == 1, paidAt, accountID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to map expense: %w", err)
 		}
@@ -307,7 +420,7 @@ func (r *SQLiteExpenseRepository) Delete(ctx context.Context, id identifier.ID)
 	return nil
 }
 
-func (r *SQLiteExpenseRepository) mapToExpense(idStr, categoryIDStr string, amountCents int64, currencyStr string, descriptionStr string, spentAt time.Time, isPaid bool, paidAt sql.NullTime) (expense.Expense, error) {
+func (r *SQLiteExpenseRepository) mapToExpense(idStr, categoryIDStr string, amountCents int64, currencyStr string, descriptionStr string, spentAt time.Time, isPaid bool, paidAt sql.NullTime, accountID sql.NullString) (expense.Expense, error) {
 	id, err := identifier.ParseID(idStr)
 	if err != nil {
 		return expense.Expense{}, err
@@ -342,6 +455,9 @@ func (r *SQLiteExpenseRepository) mapToExpense(idStr, categoryIDStr string, amou
 	if err != nil {
 		return expense.Expense{}, err
 	}
+	if exp.AccountID, err = parseNullableID(accountID); err != nil {
+		return expense.Expense{}, err
+	}
 
 	return *exp, nil
 }
@@ -206,6 +206,119 @@ func TestSQLiteExpenseRepository(t *testing.T) {
 		assert.Equal(t, oldCategory.ID, updatedMar.CategoryID)
 	})
 
+	t.Run("ReassignCategory_AllMonths", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		group := createRandomGroup(t, user.ID)
+		oldCategory := createRandomCategory(t, group.ID)
+		newCategory := createRandomCategory(t, group.ID)
+
+		expJan := createRandomExpense(t, oldCategory.ID)
+		expJan.SpentAt = time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC)
+		require.NoError(t, repo.Save(ctx, *expJan))
+
+		expMar := createRandomExpense(t, oldCategory.ID)
+		expMar.SpentAt = time.Date(2023, 3, 5, 0, 0, 0, 0, time.UTC)
+		require.NoError(t, repo.Save(ctx, *expMar))
+
+		err := repo.ReassignCategory(ctx, user.ID, oldCategory.ID, newCategory.ID)
+		require.NoError(t, err)
+
+		for _, id := range []identifier.ID{expJan.ID, expMar.ID} {
+			updated, err := repo.FindByID(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, newCategory.ID, updated.CategoryID)
+		}
+	})
+
+	t.Run("ReassignCategory_DoesNotReassignToAnotherUsersCategory", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		group := createRandomGroup(t, user.ID)
+		oldCategory := createRandomCategory(t, group.ID)
+
+		otherUser := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *otherUser))
+		otherGroup := createRandomGroup(t, otherUser.ID)
+		otherCategory := createRandomCategory(t, otherGroup.ID)
+
+		exp := createRandomExpense(t, oldCategory.ID)
+		require.NoError(t, repo.Save(ctx, *exp))
+
+		err := repo.ReassignCategory(ctx, user.ID, oldCategory.ID, otherCategory.ID)
+		require.NoError(t, err)
+
+		updated, err := repo.FindByID(ctx, exp.ID)
+		require.NoError(t, err)
+		assert.Equal(t, oldCategory.ID, updated.CategoryID)
+	})
+
+	t.Run("CountByCategoriesPerMonth", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		group := createRandomGroup(t, user.ID)
+		first := createRandomCategory(t, group.ID)
+		second := createRandomCategory(t, group.ID)
+		untracked := createRandomCategory(t, group.ID)
+
+		dates := []struct {
+			categoryID identifier.ID
+			spentAt    time.Time
+		}{
+			{first.ID, time.Date(2023, 3, 5, 0, 0, 0, 0, time.UTC)},
+			{first.ID, time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC)},
+			{second.ID, time.Date(2023, 3, 20, 0, 0, 0, 0, time.UTC)},
+			{untracked.ID, time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)},
+		}
+		for _, d := range dates {
+			exp := createRandomExpense(t, d.categoryID)
+			exp.SpentAt = d.spentAt
+			require.NoError(t, repo.Save(ctx, *exp))
+		}
+
+		counts, err := repo.CountByCategoriesPerMonth(ctx, user.ID, []identifier.ID{first.ID, second.ID})
+		require.NoError(t, err)
+		assert.Equal(t, []expense.MonthlyCount{
+			{Month: "2023-01", Count: 1},
+			{Month: "2023-03", Count: 2},
+		}, counts)
+
+		otherUser := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *otherUser))
+		counts, err = repo.CountByCategoriesPerMonth(ctx, otherUser.ID, []identifier.ID{first.ID})
+		require.NoError(t, err)
+		assert.Empty(t, counts)
+	})
+
+	t.Run("DeleteByCategoryFromMonth", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		group := createRandomGroup(t, user.ID)
+		category := createRandomCategory(t, group.ID)
+
+		expFeb := createRandomExpense(t, category.ID)
+		expFeb.SpentAt = time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC)
+		require.NoError(t, repo.Save(ctx, *expFeb))
+
+		expMar := createRandomExpense(t, category.ID)
+		expMar.SpentAt = time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+		require.NoError(t, repo.Save(ctx, *expMar))
+
+		otherUser := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *otherUser))
+		require.NoError(t, repo.DeleteByCategoryFromMonth(ctx, otherUser.ID, category.ID, "2023-03"))
+		_, err := repo.FindByID(ctx, expMar.ID)
+		require.NoError(t, err)
+
+		err = repo.DeleteByCategoryFromMonth(ctx, user.ID, category.ID, "2023-03")
+		require.NoError(t, err)
+
+		_, err = repo.FindByID(ctx, expFeb.ID)
+		require.NoError(t, err)
+		_, err = repo.FindByID(ctx, expMar.ID)
+		assert.ErrorIs(t, err, expense.ErrExpenseNotFound)
+	})
+
 	t.Run("Total_Success", func(t *testing.T) {
 		user := createRandomUser(t)
 		require.NoError(t, userRepo.Save(ctx, *user))
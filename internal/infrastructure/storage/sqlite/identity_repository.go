@@ -20,21 +20,32 @@ func NewSQLiteUserRepository(db DBExecutor) *SQLiteUserRepository {
 
 func (r *SQLiteUserRepository) Save(ctx context.Context, user identity.User) error {
 	query := `
-		INSERT INTO users (id, username, email, password, currency)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO users (id, username, email, password, currency, zero_based_budgeting, email_verified, verification_sent_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
 			username = excluded.username,
 			email = excluded.email,
 			password = excluded.password,
-			currency = excluded.currency
+			currency = excluded.currency,
+			zero_based_budgeting = excluded.zero_based_budgeting,
+			email_verified = excluded.email_verified,
+			verification_sent_at = excluded.verification_sent_at
 	`
 
+	var verificationSentAt sql.NullTime
+	if user.VerificationSentAt != nil {
+		verificationSentAt = sql.NullTime{Time: *user.VerificationSentAt, Valid: true}
+	}
+
 	_, err := r.db.ExecContext(ctx, query,
 		user.ID.String(),
 		user.Username.Value(),
 		user.Email.Value(),
 		user.Password.Value(),
 		user.Currency.Value(),
+		user.ZeroBasedBudgeting,
+		user.EmailVerified,
+		verificationSentAt,
 	)
 	if err != nil {
 		if isUniqueConstraintViolation(err) {
@@ -47,11 +58,13 @@ func (r *SQLiteUserRepository) Save(ctx context.Context, user identity.User) err
 }
 
 func (r *SQLiteUserRepository) FindByID(ctx context.Context, id identity.ID) (identity.User, error) {
-	query := `SELECT id, username, email, password, currency FROM users WHERE id = ?`
+	query := `SELECT id, username, email, password, currency, zero_based_budgeting, email_verified, verification_sent_at FROM users WHERE id = ?`
 
 	var idStr, usernameStr, emailStr, passwordStr, currencyStr string
+	var zeroBased, emailVerified bool
+	var verificationSentAt sql.NullTime
 
-	err := r.db.QueryRowContext(ctx, query, id.String()).Scan(&idStr, &usernameStr, &emailStr, &passwordStr, &currencyStr)
+	err := r.db.QueryRowContext(ctx, query, id.String()).Scan(&idStr, &usernameStr, &emailStr, &passwordStr, &currencyStr, &zeroBased, &emailVerified, &verificationSentAt)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return identity.User{}, identity.ErrUserNotFound
@@ -59,15 +72,17 @@ func (r *SQLiteUserRepository) FindByID(ctx context.Context, id identity.ID) (id
 		return identity.User{}, fmt.Errorf("failed to find user by id: %w", err)
 	}
 
-	return r.mapToUser(idStr, usernameStr, emailStr, passwordStr, currencyStr)
+	return r.mapToUser(idStr, usernameStr, emailStr, passwordStr, currencyStr, zeroBased, emailVerified, verificationSentAt)
 }
 
 func (r *SQLiteUserRepository) FindByEmail(ctx context.Context, email identity.EmailVO) (identity.User, error) {
-	query := `SELECT id, username, email, password, currency FROM users WHERE email = ?`
+	query := `SELECT id, username, email, password, currency, zero_based_budgeting, email_verified, verification_sent_at FROM users WHERE email = ?`
 
 	var idStr, usernameStr, emailStr, passwordStr, currencyStr string
+	var zeroBased, emailVerified bool
+	var verificationSentAt sql.NullTime
 
-	err := r.db.QueryRowContext(ctx, query, email.Value()).Scan(&idStr, &usernameStr, &emailStr, &passwordStr, &currencyStr)
+	err := r.db.QueryRowContext(ctx, query, email.Value()).Scan(&idStr, &usernameStr, &emailStr, &passwordStr, &currencyStr, &zeroBased, &emailVerified, &verificationSentAt)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return identity.User{}, identity.ErrUserNotFound
@@ -75,15 +90,17 @@ func (r *SQLiteUserRepository) FindByEmail(ctx context.Context, email identity.E
 		return identity.User{}, fmt.Errorf("failed to find user by email: %w", err)
 	}
 
-	return r.mapToUser(idStr, usernameStr, emailStr, passwordStr, currencyStr)
+	return r.mapToUser(idStr, usernameStr, emailStr, passwordStr, currencyStr, zeroBased, emailVerified, verificationSentAt)
 }
 
 func (r *SQLiteUserRepository) FindByUsername(ctx context.Context, username identity.UsernameVO) (identity.User, error) {
-	query := `SELECT id, username, email, password, currency FROM users WHERE username = ?`
+	query := `SELECT id, username, email, password, currency, zero_based_budgeting, email_verified, verification_sent_at FROM users WHERE username = ?`
 
 	var idStr, usernameStr, emailStr, passwordStr, currencyStr string
+	var zeroBased, emailVerified bool
+	var verificationSentAt sql.NullTime
 
-	err := r.db.QueryRowContext(ctx, query, username.Value()).Scan(&idStr, &usernameStr, &emailStr, &passwordStr, &currencyStr)
+	err := r.db.QueryRowContext(ctx, query, username.Value()).Scan(&idStr, &usernameStr, &emailStr, &passwordStr, &currencyStr, &zeroBased, &emailVerified, &verificationSentAt)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return identity.User{}, identity.ErrUserNotFound
@@ -91,7 +108,7 @@ func (r *SQLiteUserRepository) FindByUsername(ctx context.Context, username iden
 		return identity.User{}, fmt.Errorf("failed to find user by username: %w", err)
 	}
 
-	return r.mapToUser(idStr, usernameStr, emailStr, passwordStr, currencyStr)
+	return r.mapToUser(idStr, usernameStr, emailStr, passwordStr, currencyStr, zeroBased, emailVerified, verificationSentAt)
 }
 
 func (r *SQLiteUserRepository) ExistsByEmail(ctx context.Context, email identity.EmailVO) (bool, error) {
@@ -118,7 +135,7 @@ func (r *SQLiteUserRepository) ExistsByUsername(ctx context.Context, username id
 	return exists, nil
 }
 
-func (r *SQLiteUserRepository) mapToUser(idStr, usernameStr, emailStr, passwordStr, currencyStr string) (identity.User, error) {
+func (r *SQLiteUserRepository) mapToUser(idStr, usernameStr, emailStr, passwordStr, currencyStr string, zeroBased, emailVerified bool, verificationSentAt sql.NullTime) (identity.User, error) {
 	id, err := identifier.ParseID(idStr)
 	if err != nil {
 		return identity.User{}, err
@@ -144,5 +161,11 @@ func (r *SQLiteUserRepository) mapToUser(idStr, usernameStr, emailStr, passwordS
 		return identity.User{}, err
 	}
 
-	return *identity.NewUser(id, username, email, password, currency), nil
+	user := identity.NewUser(id, username, email, password, currency)
+	user.ZeroBasedBudgeting = zeroBased
+	user.EmailVerified = emailVerified
+	if verificationSentAt.Valid {
+		user.VerificationSentAt = &verificationSentAt.Time
+	}
+	return *user, nil
 }
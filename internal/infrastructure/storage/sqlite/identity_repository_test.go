@@ -3,11 +3,13 @@ package sqlite_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/madalinpopa/gocost-web/internal/domain/identity"
 	"github.com/madalinpopa/gocost-web/internal/infrastructure/storage/sqlite"
 	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSQLiteUserRepository(t *testing.T) {
@@ -36,6 +38,27 @@ func TestSQLiteUserRepository(t *testing.T) {
 		assert.Equal(t, user.Currency.Value(), foundUser.Currency.Value())
 	})
 
+	t.Run("Save_EmailVerification", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, repo.Save(ctx, *user))
+
+		foundUser, err := repo.FindByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.False(t, foundUser.EmailVerified)
+		assert.Nil(t, foundUser.VerificationSentAt)
+
+		sentAt := time.Now().UTC().Truncate(time.Second)
+		require.NoError(t, foundUser.RecordVerificationSent(sentAt))
+		foundUser.VerifyEmail()
+		require.NoError(t, repo.Save(ctx, foundUser))
+
+		verified, err := repo.FindByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.True(t, verified.EmailVerified)
+		require.NotNil(t, verified.VerificationSentAt)
+		assert.True(t, sentAt.Equal(*verified.VerificationSentAt))
+	})
+
 	t.Run("FindByID_NotFound", func(t *testing.T) {
 		randomID, _ := identifier.NewID()
 		_, err := repo.FindByID(ctx, randomID)
@@ -132,4 +155,18 @@ func TestSQLiteUserRepository(t *testing.T) {
 		assert.Equal(t, newUsername.Value(), foundUser.Username.Value())
 		assert.Equal(t, "EUR", foundUser.Currency.Value())
 	})
+
+	t.Run("Update_ZeroBasedBudgeting", func(t *testing.T) {
+		user := createRandomUser(t)
+		err := repo.Save(ctx, *user)
+		assert.NoError(t, err)
+
+		user.ZeroBasedBudgeting = true
+		err = repo.Save(ctx, *user)
+		assert.NoError(t, err)
+
+		foundUser, err := repo.FindByID(ctx, user.ID)
+		assert.NoError(t, err)
+		assert.True(t, foundUser.ZeroBasedBudgeting)
+	})
 }
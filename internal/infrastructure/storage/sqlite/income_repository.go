@@ -22,13 +22,14 @@ func NewSQLiteIncomeRepository(db DBExecutor) *SQLiteIncomeRepository {
 
 func (r *SQLiteIncomeRepository) Save(ctx context.Context, i income.Income) error {
 	query := `
-		INSERT INTO incomes (id, user_id, amount, source, received_at) 
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO incomes (id, user_id, amount, source, received_at, account_id) 
+		VALUES (?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
 			user_id = excluded.user_id,
 			amount = excluded.amount,
 			source = excluded.source,
 			received_at = excluded.received_at,
+			account_id = excluded.account_id,
 			updated_at = CURRENT_TIMESTAMP
 	`
 
@@ -43,6 +44,7 @@ func (r *SQLiteIncomeRepository) Save(ctx context.Context, i income.Income) erro
 		i.Amount.Cents(), // Use Cents()
 		source,
 		i.ReceivedAt,
+		nullableID(i.AccountID),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to save income: %w", err)
@@ -54,7 +56,7 @@ func (r *SQLiteIncomeRepository) Save(ctx context.Context, i income.Income) erro
 func (r *SQLiteIncomeRepository) FindByID(ctx context.Context, id identifier.ID) (income.Income, error) {
 	// Join users to get currency
 	query := `
-		SELECT i.id, i.user_id, i.amount, i.source, i.received_at, u.currency 
+		SELECT i.id, i.user_id, i.amount, i.source, i.received_at, i.account_id, u.currency 
 		FROM incomes i
 		JOIN users u ON i.user_id = u.id
 		WHERE i.id = ?
@@ -62,10 +64,10 @@ func (r *SQLiteIncomeRepository) FindByID(ctx context.Context, id identifier.ID)
 
 	var idStr, userIDStr, currencyStr string
 	var amountCents int64
-	var source sql.NullString
+	var source, accountID sql.NullString
 	var receivedAt time.Time
 
-	err := r.db.QueryRowContext(ctx, query, id.String()).Scan(&idStr, &userIDStr, &amountCents, &source, &receivedAt, &currencyStr)
+	err := r.db.QueryRowContext(ctx, query, id.String()).Scan(&idStr, &userIDStr, &amountCents, &source, &receivedAt, &accountID, &currencyStr)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return income.Income{}, income.ErrIncomeNotFound
@@ -73,12 +75,12 @@ func (r *SQLiteIncomeRepository) FindByID(ctx context.Context, id identifier.ID)
 		return income.Income{}, fmt.Errorf("failed to find income by id: %w", err)
 	}
 
-	return r.mapToIncome(idStr, userIDStr, amountCents, currencyStr, source, receivedAt)
+	return r.mapToIncome(idStr, userIDStr, amountCents, currencyStr, source, receivedAt, accountID)
 }
 
 func (r *SQLiteIncomeRepository) FindByUserID(ctx context.Context, userID identifier.ID) ([]income.Income, error) {
 	query := `
-			SELECT i.id, i.user_id, i.amount, i.source, i.received_at, u.currency 
+			SELECT i.id, i.user_id, i.amount, i.source, i.received_at, i.account_id, u.currency 
 			FROM incomes i
 			JOIN users u ON i.user_id = u.id
 			WHERE i.user_id = ? 
@@ -95,7 +97,7 @@ func (r *SQLiteIncomeRepository) FindByUserIDAndMonth(ctx context.Context, userI
 	}
 
 	query := `
-			SELECT i.id, i.user_id, i.amount, i.source, i.received_at, u.currency 
+			SELECT i.id, i.user_id, i.amount, i.source, i.received_at, i.account_id, u.currency 
 			FROM incomes i
 			JOIN users u ON i.user_id = u.id
 			WHERE i.user_id = ? AND i.received_at >= ? AND i.received_at < ?
@@ -116,14 +118,14 @@ func (r *SQLiteIncomeRepository) fetchIncomes(ctx context.Context, query string,
 	for rows.Next() {
 		var idStr, userIDStr, currencyStr string
 		var amountCents int64
-		var source sql.NullString
+		var source, accountID sql.NullString
 		var receivedAt time.Time
 
-		if err := rows.Scan(&idStr, &userIDStr, &amountCents, &source, &receivedAt, &currencyStr); err != nil {
+		if err := rows.Scan(&idStr, &userIDStr, &amountCents, &source, &receivedAt, &accountID, &currencyStr); err != nil {
 			return nil, fmt.Errorf("failed to scan income row: %w", err)
 		}
 
-		inc, err := r.mapToIncome(idStr, userIDStr, amountCents, currencyStr, source, receivedAt)
+		inc, err := r.mapToIncome(idStr, userIDStr, amountCents, currencyStr, source, receivedAt, accountID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to map income: %w", err)
 		}
@@ -180,7 +182,7 @@ func (r *SQLiteIncomeRepository) Delete(ctx context.Context, id identifier.ID) e
 	return nil
 }
 
-func (r *SQLiteIncomeRepository) mapToIncome(idStr, userIDStr string, amountCents int64, currencyStr string, source sql.NullString, receivedAt time.Time) (income.Income, error) {
+func (r *SQLiteIncomeRepository) mapToIncome(idStr, userIDStr string, amountCents int64, currencyStr string, source sql.NullString, receivedAt time.Time, accountID sql.NullString) (income.Income, error) {
 	id, err := identifier.ParseID(idStr)
 	if err != nil {
 		return income.Income{}, err
@@ -209,6 +211,9 @@ func (r *SQLiteIncomeRepository) mapToIncome(idStr, userIDStr string, amountCent
 	if err != nil {
 		return income.Income{}, err
 	}
+	if inc.AccountID, err = parseNullableID(accountID); err != nil {
+		return income.Income{}, err
+	}
 
 	return *inc, nil
 }
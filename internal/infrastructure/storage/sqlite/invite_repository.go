@@ -0,0 +1,118 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+)
+
+type SQLiteInviteRepository struct {
+	db DBExecutor
+}
+
+func NewSQLiteInviteRepository(db DBExecutor) *SQLiteInviteRepository {
+	return &SQLiteInviteRepository{db: db}
+}
+
+func (r *SQLiteInviteRepository) Save(ctx context.Context, invite identity.Invite) error {
+	query := `
+		INSERT INTO invites (id, code_hash, max_uses, uses, created_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			uses = excluded.uses
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		invite.ID.String(),
+		invite.CodeHash,
+		invite.MaxUses,
+		invite.Uses,
+		invite.CreatedAt,
+		invite.ExpiresAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save invite: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLiteInviteRepository) FindByCodeHash(ctx context.Context, codeHash string) (identity.Invite, error) {
+	query := `
+		SELECT id, code_hash, max_uses, uses, created_at, expires_at
+		FROM invites WHERE code_hash = ?
+	`
+
+	invite, err := scanInvite(r.db.QueryRowContext(ctx, query, codeHash))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return identity.Invite{}, identity.ErrInvalidInvite
+		}
+		return identity.Invite{}, fmt.Errorf("failed to find invite: %w", err)
+	}
+	return invite, nil
+}
+
+func (r *SQLiteInviteRepository) FindAll(ctx context.Context) ([]identity.Invite, error) {
+	query := `
+		SELECT id, code_hash, max_uses, uses, created_at, expires_at
+		FROM invites ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find invites: %w", err)
+	}
+	defer rows.Close()
+
+	var invites []identity.Invite
+	for rows.Next() {
+		invite, err := scanInvite(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invite: %w", err)
+		}
+		invites = append(invites, invite)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate invites: %w", err)
+	}
+
+	return invites, nil
+}
+
+func (r *SQLiteInviteRepository) Delete(ctx context.Context, id identifier.ID) error {
+	query := `DELETE FROM invites WHERE id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete invite: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return identity.ErrInviteNotFound
+	}
+
+	return nil
+}
+
+func scanInvite(row rowScanner) (identity.Invite, error) {
+	var idStr string
+	var invite identity.Invite
+
+	err := row.Scan(&idStr, &invite.CodeHash, &invite.MaxUses, &invite.Uses, &invite.CreatedAt, &invite.ExpiresAt)
+	if err != nil {
+		return identity.Invite{}, err
+	}
+
+	if invite.ID, err = identifier.ParseID(idStr); err != nil {
+		return identity.Invite{}, err
+	}
+	return invite, nil
+}
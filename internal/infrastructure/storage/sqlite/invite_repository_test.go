@@ -0,0 +1,72 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/infrastructure/storage/sqlite"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteInviteRepository(t *testing.T) {
+	repo := sqlite.NewSQLiteInviteRepository(testDB)
+	ctx := context.Background()
+
+	newInvite := func(t *testing.T, at time.Time) *identity.Invite {
+		id, err := identifier.NewID()
+		require.NoError(t, err)
+		invite, err := identity.NewInvite(id, "hash-"+id.String(), 2, at, at.Add(24*time.Hour))
+		require.NoError(t, err)
+		return invite
+	}
+
+	t.Run("Save_And_FindByCodeHash", func(t *testing.T) {
+		at := time.Now().UTC().Truncate(time.Second)
+		invite := newInvite(t, at)
+		require.NoError(t, repo.Save(ctx, *invite))
+
+		require.NoError(t, invite.Use(at))
+		require.NoError(t, repo.Save(ctx, *invite))
+
+		found, err := repo.FindByCodeHash(ctx, invite.CodeHash)
+		require.NoError(t, err)
+		assert.Equal(t, invite.ID, found.ID)
+		assert.Equal(t, 2, found.MaxUses)
+		assert.Equal(t, 1, found.Uses)
+		assert.True(t, at.Equal(found.CreatedAt))
+		assert.True(t, at.Add(24*time.Hour).Equal(found.ExpiresAt))
+	})
+
+	t.Run("FindByCodeHash_Unknown", func(t *testing.T) {
+		_, err := repo.FindByCodeHash(ctx, "unknown")
+		assert.ErrorIs(t, err, identity.ErrInvalidInvite)
+	})
+
+	t.Run("FindAll_And_Delete", func(t *testing.T) {
+		invite := newInvite(t, time.Now().UTC())
+		require.NoError(t, repo.Save(ctx, *invite))
+
+		invites, err := repo.FindAll(ctx)
+		require.NoError(t, err)
+		assert.Contains(t, inviteIDs(invites), invite.ID)
+
+		require.NoError(t, repo.Delete(ctx, invite.ID))
+		assert.ErrorIs(t, repo.Delete(ctx, invite.ID), identity.ErrInviteNotFound)
+
+		invites, err = repo.FindAll(ctx)
+		require.NoError(t, err)
+		assert.NotContains(t, inviteIDs(invites), invite.ID)
+	})
+}
+
+func inviteIDs(invites []identity.Invite) []identifier.ID {
+	ids := make([]identifier.ID, 0, len(invites))
+	for _, invite := range invites {
+		ids = append(ids, invite.ID)
+	}
+	return ids
+}
@@ -0,0 +1,200 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/loan"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+)
+
+type SQLiteLoanRepository struct {
+	db DBExecutor
+}
+
+func NewSQLiteLoanRepository(db DBExecutor) *SQLiteLoanRepository {
+	return &SQLiteLoanRepository{db: db}
+}
+
+func (r *SQLiteLoanRepository) Save(ctx context.Context, l loan.Loan) error {
+	query := `
+		INSERT INTO loans (id, user_id, name, principal, rate, term_months, start_month, category_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			name = excluded.name,
+			principal = excluded.principal,
+			rate = excluded.rate,
+			term_months = excluded.term_months,
+			start_month = excluded.start_month,
+			category_id = excluded.category_id
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		l.ID.String(),
+		l.UserID.String(),
+		l.Name.Value(),
+		l.Principal.Cents(),
+		l.Rate.BasisPoints(),
+		l.TermMonths,
+		l.StartMonth,
+		nullableID(l.CategoryID),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save loan: %w", err)
+	}
+	return nil
+}
+
+func (r *SQLiteLoanRepository) FindByID(ctx context.Context, userID identifier.ID, id identifier.ID) (loan.Loan, error) {
+	loans, err := r.findLoans(ctx, `WHERE l.user_id = ? AND l.id = ?`, userID.String(), id.String())
+	if err != nil {
+		return loan.Loan{}, err
+	}
+	if len(loans) == 0 {
+		return loan.Loan{}, loan.ErrLoanNotFound
+	}
+	return loans[0], nil
+}
+
+func (r *SQLiteLoanRepository) FindByUserID(ctx context.Context, userID identifier.ID) ([]loan.Loan, error) {
+	return r.findLoans(ctx, `WHERE l.user_id = ?`, userID.String())
+}
+
+func (r *SQLiteLoanRepository) Delete(ctx context.Context, userID identifier.ID, id identifier.ID) error {
+	query := `DELETE FROM loans WHERE user_id = ? AND id = ?`
+	result, err := r.db.ExecContext(ctx, query, userID.String(), id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete loan: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return loan.ErrLoanNotFound
+	}
+	return nil
+}
+
+func (r *SQLiteLoanRepository) Payments(ctx context.Context, userID identifier.ID, categoryID identifier.ID) ([]loan.Payment, error) {
+	query := `
+		SELECT e.spent_at, e.amount, u.currency
+		FROM expenses e
+		JOIN categories c ON e.category_id = c.id
+		JOIN groups g ON c.group_id = g.id
+		JOIN users u ON g.user_id = u.id
+		WHERE g.user_id = ? AND e.category_id = ? AND e.is_paid = 1
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID.String(), categoryID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query loan payments: %w", err)
+	}
+	defer rows.Close()
+
+	// Months are grouped here rather than in SQL so the stored time format
+	// does not matter.
+	var currency string
+	centsByMonth := make(map[string]int64)
+	for rows.Next() {
+		var spentAt time.Time
+		var amountCents int64
+		if err := rows.Scan(&spentAt, &amountCents, &currency); err != nil {
+			return nil, fmt.Errorf("failed to scan loan payment: %w", err)
+		}
+		centsByMonth[spentAt.Format("2006-01")] += amountCents
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating loan payments: %w", err)
+	}
+
+	payments := make([]loan.Payment, 0, len(centsByMonth))
+	for month, cents := range centsByMonth {
+		amount, err := money.New(cents, currency)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, loan.Payment{Month: month, Amount: amount})
+	}
+	sort.Slice(payments, func(i, j int) bool {
+		return payments[i].Month < payments[j].Month
+	})
+	return payments, nil
+}
+
+func (r *SQLiteLoanRepository) findLoans(ctx context.Context, where string, args ...any) ([]loan.Loan, error) {
+	query := `
+		SELECT l.id, l.user_id, l.name, l.principal, l.rate, l.term_months, l.start_month, l.category_id, u.currency
+		FROM loans l
+		JOIN users u ON l.user_id = u.id
+		` + where + `
+		ORDER BY l.start_month, l.name
+	`
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query loans: %w", err)
+	}
+	defer rows.Close()
+
+	loans := make([]loan.Loan, 0)
+	for rows.Next() {
+		var idStr, userIDStr, nameStr, startMonth, currencyStr string
+		var principalCents, rate int64
+		var termMonths int
+		var categoryID sql.NullString
+		if err := rows.Scan(&idStr, &userIDStr, &nameStr, &principalCents, &rate, &termMonths, &startMonth, &categoryID, &currencyStr); err != nil {
+			return nil, fmt.Errorf("failed to scan loan row: %w", err)
+		}
+
+		l, err := r.mapToLoan(idStr, userIDStr, nameStr, principalCents, rate, termMonths, startMonth, categoryID, currencyStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map loan: %w", err)
+		}
+		loans = append(loans, *l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating loan rows: %w", err)
+	}
+
+	return loans, nil
+}
+
+func (r *SQLiteLoanRepository) mapToLoan(idStr, userIDStr, nameStr string, principalCents, rate int64, termMonths int, startMonth string, categoryIDStr sql.NullString, currencyStr string) (*loan.Loan, error) {
+	id, err := identifier.ParseID(idStr)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := identifier.ParseID(userIDStr)
+	if err != nil {
+		return nil, err
+	}
+	name, err := loan.NewNameVO(nameStr)
+	if err != nil {
+		return nil, err
+	}
+	principal, err := money.New(principalCents, currencyStr)
+	if err != nil {
+		return nil, err
+	}
+	rateVO, err := loan.NewRateVO(rate)
+	if err != nil {
+		return nil, err
+	}
+	categoryID, err := parseNullableID(categoryIDStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &loan.Loan{
+		ID:         id,
+		UserID:     userID,
+		Name:       name,
+		Principal:  principal,
+		Rate:       rateVO,
+		TermMonths: termMonths,
+		StartMonth: startMonth,
+		CategoryID: categoryID,
+	}, nil
+}
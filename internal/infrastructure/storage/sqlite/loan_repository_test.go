@@ -0,0 +1,115 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/expense"
+	"github.com/madalinpopa/gocost-web/internal/domain/loan"
+	"github.com/madalinpopa/gocost-web/internal/infrastructure/storage/sqlite"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createLoan(t *testing.T, userID identifier.ID, name string, categoryID identifier.ID) *loan.Loan {
+	t.Helper()
+	id, err := identifier.NewID()
+	require.NoError(t, err)
+
+	nameVO, err := loan.NewNameVO(name)
+	require.NoError(t, err)
+	principal, err := money.New(2000000, "USD")
+	require.NoError(t, err)
+	rate, err := loan.NewRateVO(500)
+	require.NoError(t, err)
+
+	l, err := loan.NewLoan(id, userID, nameVO, principal, rate, 60, "2024-01", categoryID)
+	require.NoError(t, err)
+	return l
+}
+
+func TestSQLiteLoanRepository(t *testing.T) {
+	repo := sqlite.NewSQLiteLoanRepository(testDB)
+	userRepo := sqlite.NewSQLiteUserRepository(testDB)
+	expenseRepo := sqlite.NewSQLiteExpenseRepository(testDB)
+	ctx := context.Background()
+
+	t.Run("Save_And_FindByID", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		group := createRandomGroup(t, user.ID)
+		category := createRandomCategory(t, group.ID)
+
+		l := createLoan(t, user.ID, "Car", category.ID)
+		require.NoError(t, repo.Save(ctx, *l))
+
+		found, err := repo.FindByID(ctx, user.ID, l.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Car", found.Name.Value())
+		assert.Equal(t, int64(2000000), found.Principal.Cents())
+		assert.Equal(t, int64(500), found.Rate.BasisPoints())
+		assert.Equal(t, 60, found.TermMonths)
+		assert.Equal(t, "2024-01", found.StartMonth)
+		assert.Equal(t, category.ID, found.CategoryID)
+
+		other := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *other))
+		_, err = repo.FindByID(ctx, other.ID, l.ID)
+		assert.ErrorIs(t, err, loan.ErrLoanNotFound)
+	})
+
+	t.Run("Save_WithoutCategory", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+
+		l := createLoan(t, user.ID, "Mortgage", identifier.ID{})
+		require.NoError(t, repo.Save(ctx, *l))
+
+		loans, err := repo.FindByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, loans, 1)
+		assert.True(t, loans[0].CategoryID.IsZero())
+	})
+
+	t.Run("Payments", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		group := createRandomGroup(t, user.ID)
+		category := createRandomCategory(t, group.ID)
+
+		march := time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC)
+		for _, spentAt := range []time.Time{march, march.AddDate(0, 0, 10), march.AddDate(0, 1, 0)} {
+			paid := createRandomExpense(t, category.ID)
+			paid.SpentAt = spentAt
+			var err error
+			paid.Payment, err = expense.NewPaidStatus(spentAt)
+			require.NoError(t, err)
+			require.NoError(t, expenseRepo.Save(ctx, *paid))
+		}
+		unpaid := createRandomExpense(t, category.ID)
+		unpaid.SpentAt = march
+		require.NoError(t, expenseRepo.Save(ctx, *unpaid))
+
+		payments, err := repo.Payments(ctx, user.ID, category.ID)
+		require.NoError(t, err)
+		require.Len(t, payments, 2)
+		assert.Equal(t, "2024-03", payments[0].Month)
+		assert.Equal(t, int64(1000), payments[0].Amount.Cents())
+		assert.Equal(t, "2024-04", payments[1].Month)
+		assert.Equal(t, int64(500), payments[1].Amount.Cents())
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+
+		l := createLoan(t, user.ID, "Car", identifier.ID{})
+		require.NoError(t, repo.Save(ctx, *l))
+
+		require.NoError(t, repo.Delete(ctx, user.ID, l.ID))
+		assert.ErrorIs(t, repo.Delete(ctx, user.ID, l.ID), loan.ErrLoanNotFound)
+	})
+}
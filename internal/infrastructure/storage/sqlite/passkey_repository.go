@@ -0,0 +1,140 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+)
+
+type SQLitePasskeyRepository struct {
+	db DBExecutor
+}
+
+func NewSQLitePasskeyRepository(db DBExecutor) *SQLitePasskeyRepository {
+	return &SQLitePasskeyRepository{db: db}
+}
+
+func (r *SQLitePasskeyRepository) Save(ctx context.Context, passkey identity.Passkey) error {
+	query := `
+		INSERT INTO passkeys (id, user_id, name, credential_id, public_key, sign_count, created_at, last_used_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			name = excluded.name,
+			sign_count = excluded.sign_count,
+			last_used_at = excluded.last_used_at
+	`
+
+	var lastUsedAt sql.NullTime
+	if passkey.LastUsedAt != nil {
+		lastUsedAt = sql.NullTime{Time: *passkey.LastUsedAt, Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		passkey.ID.String(),
+		passkey.UserID.String(),
+		passkey.Name,
+		passkey.CredentialID,
+		passkey.PublicKey,
+		passkey.SignCount,
+		passkey.CreatedAt,
+		lastUsedAt,
+	)
+	if err != nil {
+		if isUniqueConstraintViolation(err) {
+			return identity.ErrPasskeyAlreadyRegistered
+		}
+		return fmt.Errorf("failed to save passkey: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLitePasskeyRepository) FindByCredentialID(ctx context.Context, credentialID []byte) (identity.Passkey, error) {
+	query := `
+		SELECT id, user_id, name, credential_id, public_key, sign_count, created_at, last_used_at
+		FROM passkeys WHERE credential_id = ?
+	`
+
+	passkey, err := scanPasskey(r.db.QueryRowContext(ctx, query, credentialID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return identity.Passkey{}, identity.ErrPasskeyNotFound
+		}
+		return identity.Passkey{}, fmt.Errorf("failed to find passkey: %w", err)
+	}
+	return passkey, nil
+}
+
+func (r *SQLitePasskeyRepository) FindByUserID(ctx context.Context, userID identifier.ID) ([]identity.Passkey, error) {
+	query := `
+		SELECT id, user_id, name, credential_id, public_key, sign_count, created_at, last_used_at
+		FROM passkeys WHERE user_id = ?
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find passkeys: %w", err)
+	}
+	defer rows.Close()
+
+	var passkeys []identity.Passkey
+	for rows.Next() {
+		passkey, err := scanPasskey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan passkey: %w", err)
+		}
+		passkeys = append(passkeys, passkey)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate passkeys: %w", err)
+	}
+
+	return passkeys, nil
+}
+
+func (r *SQLitePasskeyRepository) Delete(ctx context.Context, userID identifier.ID, id identifier.ID) error {
+	query := `DELETE FROM passkeys WHERE id = ? AND user_id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, id.String(), userID.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete passkey: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete passkey: %w", err)
+	}
+	if affected == 0 {
+		return identity.ErrPasskeyNotFound
+	}
+	return nil
+}
+
+func scanPasskey(row rowScanner) (identity.Passkey, error) {
+	var idStr, userIDStr string
+	var createdAt time.Time
+	var lastUsedAt sql.NullTime
+	var passkey identity.Passkey
+
+	err := row.Scan(&idStr, &userIDStr, &passkey.Name, &passkey.CredentialID, &passkey.PublicKey, &passkey.SignCount, &createdAt, &lastUsedAt)
+	if err != nil {
+		return identity.Passkey{}, err
+	}
+
+	if passkey.ID, err = identifier.ParseID(idStr); err != nil {
+		return identity.Passkey{}, err
+	}
+	if passkey.UserID, err = identifier.ParseID(userIDStr); err != nil {
+		return identity.Passkey{}, err
+	}
+	passkey.CreatedAt = createdAt
+	if lastUsedAt.Valid {
+		passkey.LastUsedAt = &lastUsedAt.Time
+	}
+	return passkey, nil
+}
@@ -0,0 +1,84 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/infrastructure/storage/sqlite"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLitePasskeyRepository(t *testing.T) {
+	repo := sqlite.NewSQLitePasskeyRepository(testDB)
+	userRepo := sqlite.NewSQLiteUserRepository(testDB)
+	ctx := context.Background()
+
+	newPasskey := func(t *testing.T, userID identifier.ID, credentialID string) *identity.Passkey {
+		id, err := identifier.NewID()
+		require.NoError(t, err)
+		passkey, err := identity.NewPasskey(id, userID, "Laptop", []byte(credentialID), []byte{0xa5, 0x01}, 3, time.Now().UTC().Truncate(time.Second))
+		require.NoError(t, err)
+		return passkey
+	}
+
+	t.Run("Save_And_FindByCredentialID", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		passkey := newPasskey(t, user.ID, "credential-find-"+user.ID.String())
+		require.NoError(t, repo.Save(ctx, *passkey))
+
+		passkey.Use(4, time.Now().UTC().Truncate(time.Second))
+		require.NoError(t, repo.Save(ctx, *passkey))
+
+		found, err := repo.FindByCredentialID(ctx, passkey.CredentialID)
+		require.NoError(t, err)
+		assert.Equal(t, passkey.ID, found.ID)
+		assert.Equal(t, user.ID, found.UserID)
+		assert.Equal(t, "Laptop", found.Name)
+		assert.Equal(t, []byte{0xa5, 0x01}, found.PublicKey)
+		assert.Equal(t, uint32(4), found.SignCount)
+		require.NotNil(t, found.LastUsedAt)
+	})
+
+	t.Run("FindByCredentialID_NotFound", func(t *testing.T) {
+		_, err := repo.FindByCredentialID(ctx, []byte("unknown"))
+		assert.ErrorIs(t, err, identity.ErrPasskeyNotFound)
+	})
+
+	t.Run("Save_DuplicateCredential", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		credentialID := "credential-duplicate-" + user.ID.String()
+		require.NoError(t, repo.Save(ctx, *newPasskey(t, user.ID, credentialID)))
+
+		err := repo.Save(ctx, *newPasskey(t, user.ID, credentialID))
+		assert.ErrorIs(t, err, identity.ErrPasskeyAlreadyRegistered)
+	})
+
+	t.Run("FindByUserID_And_Delete", func(t *testing.T) {
+		user := createRandomUser(t)
+		other := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		require.NoError(t, userRepo.Save(ctx, *other))
+		first := newPasskey(t, user.ID, "credential-first-"+user.ID.String())
+		second := newPasskey(t, user.ID, "credential-second-"+user.ID.String())
+		require.NoError(t, repo.Save(ctx, *first))
+		require.NoError(t, repo.Save(ctx, *second))
+
+		passkeys, err := repo.FindByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Len(t, passkeys, 2)
+
+		assert.ErrorIs(t, repo.Delete(ctx, other.ID, first.ID), identity.ErrPasskeyNotFound)
+		require.NoError(t, repo.Delete(ctx, user.ID, first.ID))
+
+		passkeys, err = repo.FindByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, passkeys, 1)
+		assert.Equal(t, second.ID, passkeys[0].ID)
+	})
+}
@@ -0,0 +1,91 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+)
+
+type SQLitePasswordResetRepository struct {
+	db DBExecutor
+}
+
+func NewSQLitePasswordResetRepository(db DBExecutor) *SQLitePasswordResetRepository {
+	return &SQLitePasswordResetRepository{db: db}
+}
+
+func (r *SQLitePasswordResetRepository) Save(ctx context.Context, reset identity.PasswordReset) error {
+	query := `
+		INSERT INTO password_resets (id, user_id, token_hash, expires_at, used_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			used_at = excluded.used_at
+	`
+
+	var usedAt sql.NullTime
+	if reset.UsedAt != nil {
+		usedAt = sql.NullTime{Time: *reset.UsedAt, Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		reset.ID.String(),
+		reset.UserID.String(),
+		reset.TokenHash,
+		reset.ExpiresAt,
+		usedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save password reset: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLitePasswordResetRepository) FindByTokenHash(ctx context.Context, tokenHash string) (identity.PasswordReset, error) {
+	query := `SELECT id, user_id, token_hash, expires_at, used_at FROM password_resets WHERE token_hash = ?`
+
+	var idStr, userIDStr, hash string
+	var expiresAt time.Time
+	var usedAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(&idStr, &userIDStr, &hash, &expiresAt, &usedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return identity.PasswordReset{}, identity.ErrInvalidResetToken
+		}
+		return identity.PasswordReset{}, fmt.Errorf("failed to find password reset: %w", err)
+	}
+
+	id, err := identifier.ParseID(idStr)
+	if err != nil {
+		return identity.PasswordReset{}, err
+	}
+	userID, err := identifier.ParseID(userIDStr)
+	if err != nil {
+		return identity.PasswordReset{}, err
+	}
+
+	reset := identity.PasswordReset{
+		ID:        id,
+		UserID:    userID,
+		TokenHash: hash,
+		ExpiresAt: expiresAt,
+	}
+	if usedAt.Valid {
+		reset.UsedAt = &usedAt.Time
+	}
+	return reset, nil
+}
+
+func (r *SQLitePasswordResetRepository) DeleteByUserID(ctx context.Context, userID identifier.ID) error {
+	query := `DELETE FROM password_resets WHERE user_id = ?`
+	if _, err := r.db.ExecContext(ctx, query, userID.String()); err != nil {
+		return fmt.Errorf("failed to delete password resets: %w", err)
+	}
+	return nil
+}
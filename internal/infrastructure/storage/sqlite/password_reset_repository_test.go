@@ -0,0 +1,71 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/infrastructure/storage/sqlite"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createPasswordReset(t *testing.T, userID identifier.ID, tokenHash string) identity.PasswordReset {
+	t.Helper()
+	id, err := identifier.NewID()
+	require.NoError(t, err)
+
+	return *identity.NewPasswordReset(id, userID, tokenHash, time.Now().UTC().Truncate(time.Second))
+}
+
+func TestSQLitePasswordResetRepository(t *testing.T) {
+	repo := sqlite.NewSQLitePasswordResetRepository(testDB)
+	userRepo := sqlite.NewSQLiteUserRepository(testDB)
+	ctx := context.Background()
+
+	t.Run("Save_And_FindByTokenHash", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		reset := createPasswordReset(t, user.ID, "hash-"+user.ID.String())
+		require.NoError(t, repo.Save(ctx, reset))
+
+		found, err := repo.FindByTokenHash(ctx, reset.TokenHash)
+		require.NoError(t, err)
+		assert.Equal(t, reset.ID, found.ID)
+		assert.Equal(t, user.ID, found.UserID)
+		assert.True(t, reset.ExpiresAt.Equal(found.ExpiresAt))
+		assert.Nil(t, found.UsedAt)
+
+		require.NoError(t, found.Use(time.Now()))
+		require.NoError(t, repo.Save(ctx, found))
+
+		used, err := repo.FindByTokenHash(ctx, reset.TokenHash)
+		require.NoError(t, err)
+		assert.NotNil(t, used.UsedAt)
+	})
+
+	t.Run("FindByTokenHash_Unknown", func(t *testing.T) {
+		_, err := repo.FindByTokenHash(ctx, "unknown")
+		assert.ErrorIs(t, err, identity.ErrInvalidResetToken)
+	})
+
+	t.Run("DeleteByUserID", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		other := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *other))
+		reset := createPasswordReset(t, user.ID, "delete-"+user.ID.String())
+		otherReset := createPasswordReset(t, other.ID, "keep-"+other.ID.String())
+		require.NoError(t, repo.Save(ctx, reset))
+		require.NoError(t, repo.Save(ctx, otherReset))
+
+		require.NoError(t, repo.DeleteByUserID(ctx, user.ID))
+
+		_, err := repo.FindByTokenHash(ctx, reset.TokenHash)
+		assert.ErrorIs(t, err, identity.ErrInvalidResetToken)
+		_, err = repo.FindByTokenHash(ctx, otherReset.TokenHash)
+		assert.NoError(t, err)
+	})
+}
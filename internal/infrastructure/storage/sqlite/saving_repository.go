@@ -0,0 +1,206 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/saving"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+)
+
+type SQLiteSavingRepository struct {
+	db DBExecutor
+}
+
+func NewSQLiteSavingRepository(db DBExecutor) *SQLiteSavingRepository {
+	return &SQLiteSavingRepository{db: db}
+}
+
+// Save upserts the goal and replaces its contributions with the ones it
+// currently holds.
+func (r *SQLiteSavingRepository) Save(ctx context.Context, goal saving.Goal) error {
+	goalQuery := `
+		INSERT INTO savings_goals (id, user_id, name, target, start_month, target_date)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			name = excluded.name,
+			target = excluded.target,
+			start_month = excluded.start_month,
+			target_date = excluded.target_date
+	`
+	_, err := r.db.ExecContext(ctx, goalQuery,
+		goal.ID.String(),
+		goal.UserID.String(),
+		goal.Name.Value(),
+		goal.Target.Cents(),
+		goal.StartMonth,
+		goal.TargetDate,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save goal: %w", err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM goal_contributions WHERE goal_id = ?`, goal.ID.String()); err != nil {
+		return fmt.Errorf("failed to clear goal contributions: %w", err)
+	}
+
+	contributionQuery := `INSERT INTO goal_contributions (id, goal_id, month, amount) VALUES (?, ?, ?, ?)`
+	for _, c := range goal.Contributions {
+		_, err := r.db.ExecContext(ctx, contributionQuery,
+			c.ID.String(),
+			goal.ID.String(),
+			c.Month,
+			c.Amount.Cents(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save goal contribution: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *SQLiteSavingRepository) FindByID(ctx context.Context, userID identifier.ID, id identifier.ID) (saving.Goal, error) {
+	goals, err := r.findGoals(ctx, `WHERE g.user_id = ? AND g.id = ?`, userID.String(), id.String())
+	if err != nil {
+		return saving.Goal{}, err
+	}
+	if len(goals) == 0 {
+		return saving.Goal{}, saving.ErrGoalNotFound
+	}
+	return goals[0], nil
+}
+
+func (r *SQLiteSavingRepository) FindByUserID(ctx context.Context, userID identifier.ID) ([]saving.Goal, error) {
+	return r.findGoals(ctx, `WHERE g.user_id = ?`, userID.String())
+}
+
+func (r *SQLiteSavingRepository) Delete(ctx context.Context, userID identifier.ID, id identifier.ID) error {
+	query := `DELETE FROM savings_goals WHERE user_id = ? AND id = ?`
+	result, err := r.db.ExecContext(ctx, query, userID.String(), id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete goal: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return saving.ErrGoalNotFound
+	}
+	return nil
+}
+
+func (r *SQLiteSavingRepository) findGoals(ctx context.Context, where string, args ...any) ([]saving.Goal, error) {
+	goalQuery := `
+		SELECT g.id, g.user_id, g.name, g.target, g.start_month, g.target_date, u.currency
+		FROM savings_goals g
+		JOIN users u ON g.user_id = u.id
+		` + where + `
+		ORDER BY g.target_date, g.name
+	`
+
+	rows, err := r.db.QueryContext(ctx, goalQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query goals: %w", err)
+	}
+	defer rows.Close()
+
+	goals := make([]*saving.Goal, 0)
+	goalByID := make(map[string]*saving.Goal)
+	for rows.Next() {
+		var idStr, userIDStr, nameStr, startMonth, currencyStr string
+		var targetCents int64
+		var targetDate time.Time
+		if err := rows.Scan(&idStr, &userIDStr, &nameStr, &targetCents, &startMonth, &targetDate, &currencyStr); err != nil {
+			return nil, fmt.Errorf("failed to scan goal row: %w", err)
+		}
+
+		goal, err := r.mapToGoal(idStr, userIDStr, nameStr, targetCents, startMonth, targetDate, currencyStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map goal: %w", err)
+		}
+		goals = append(goals, goal)
+		goalByID[idStr] = goal
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating goal rows: %w", err)
+	}
+
+	if len(goals) == 0 {
+		return []saving.Goal{}, nil
+	}
+
+	contributionQuery := `
+		SELECT c.id, c.goal_id, c.month, c.amount
+		FROM goal_contributions c
+		JOIN savings_goals g ON c.goal_id = g.id
+		` + where + `
+		ORDER BY c.month, c.created_at
+	`
+	contributionRows, err := r.db.QueryContext(ctx, contributionQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query goal contributions: %w", err)
+	}
+	defer contributionRows.Close()
+
+	for contributionRows.Next() {
+		var idStr, goalIDStr, month string
+		var amountCents int64
+		if err := contributionRows.Scan(&idStr, &goalIDStr, &month, &amountCents); err != nil {
+			return nil, fmt.Errorf("failed to scan goal contribution row: %w", err)
+		}
+
+		goal, ok := goalByID[goalIDStr]
+		if !ok {
+			return nil, fmt.Errorf("failed to resolve goal for contribution %s: %w", idStr, saving.ErrGoalNotFound)
+		}
+
+		id, err := identifier.ParseID(idStr)
+		if err != nil {
+			return nil, err
+		}
+		amount, err := money.New(amountCents, goal.Target.Currency())
+		if err != nil {
+			return nil, err
+		}
+		goal.Contributions = append(goal.Contributions, &saving.Contribution{
+			ID:     id,
+			GoalID: goal.ID,
+			Month:  month,
+			Amount: amount,
+		})
+	}
+	if err := contributionRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating goal contribution rows: %w", err)
+	}
+
+	result := make([]saving.Goal, 0, len(goals))
+	for _, goal := range goals {
+		result = append(result, *goal)
+	}
+	return result, nil
+}
+
+func (r *SQLiteSavingRepository) mapToGoal(idStr, userIDStr, nameStr string, targetCents int64, startMonth string, targetDate time.Time, currencyStr string) (*saving.Goal, error) {
+	id, err := identifier.ParseID(idStr)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := identifier.ParseID(userIDStr)
+	if err != nil {
+		return nil, err
+	}
+	name, err := saving.NewNameVO(nameStr)
+	if err != nil {
+		return nil, err
+	}
+	target, err := money.New(targetCents, currencyStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return saving.NewGoal(id, userID, name, target, startMonth, targetDate)
+}
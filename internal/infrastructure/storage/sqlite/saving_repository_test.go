@@ -0,0 +1,119 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/saving"
+	"github.com/madalinpopa/gocost-web/internal/infrastructure/storage/sqlite"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createGoal(t *testing.T, userID identifier.ID, name string) *saving.Goal {
+	t.Helper()
+	id, err := identifier.NewID()
+	require.NoError(t, err)
+
+	nameVO, err := saving.NewNameVO(name)
+	require.NoError(t, err)
+	target, err := money.New(500000, "USD")
+	require.NoError(t, err)
+
+	goal, err := saving.NewGoal(id, userID, nameVO, target, "2024-01", time.Date(2025, time.June, 30, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	return goal
+}
+
+func addContribution(t *testing.T, goal *saving.Goal, month string, cents int64) *saving.Contribution {
+	t.Helper()
+	id, err := identifier.NewID()
+	require.NoError(t, err)
+	amount, err := money.New(cents, "USD")
+	require.NoError(t, err)
+
+	contribution, err := goal.Contribute(id, month, amount)
+	require.NoError(t, err)
+	return contribution
+}
+
+func TestSQLiteSavingRepository(t *testing.T) {
+	repo := sqlite.NewSQLiteSavingRepository(testDB)
+	userRepo := sqlite.NewSQLiteUserRepository(testDB)
+	ctx := context.Background()
+
+	t.Run("Save_And_FindByID", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+
+		goal := createGoal(t, user.ID, "Car")
+		addContribution(t, goal, "2024-01", 10000)
+		addContribution(t, goal, "2024-02", 20000)
+		require.NoError(t, repo.Save(ctx, *goal))
+
+		found, err := repo.FindByID(ctx, user.ID, goal.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Car", found.Name.Value())
+		assert.Equal(t, int64(500000), found.Target.Cents())
+		assert.Equal(t, "2025-06", found.TargetMonth())
+		require.Len(t, found.Contributions, 2)
+		assert.Equal(t, int64(30000), found.SavedBy("2024-02").Cents())
+	})
+
+	t.Run("Save_RemovesContributions", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+
+		goal := createGoal(t, user.ID, "Holiday")
+		first := addContribution(t, goal, "2024-01", 10000)
+		addContribution(t, goal, "2024-02", 20000)
+		require.NoError(t, repo.Save(ctx, *goal))
+
+		require.NoError(t, goal.RemoveContribution(first.ID))
+		require.NoError(t, repo.Save(ctx, *goal))
+
+		found, err := repo.FindByID(ctx, user.ID, goal.ID)
+		require.NoError(t, err)
+		require.Len(t, found.Contributions, 1)
+		assert.Equal(t, "2024-02", found.Contributions[0].Month)
+	})
+
+	t.Run("FindByUserID", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+
+		require.NoError(t, repo.Save(ctx, *createGoal(t, user.ID, "Car")))
+		require.NoError(t, repo.Save(ctx, *createGoal(t, user.ID, "Bike")))
+
+		goals, err := repo.FindByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Len(t, goals, 2)
+	})
+
+	t.Run("FindByID_OtherUser", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		goal := createGoal(t, user.ID, "Car")
+		require.NoError(t, repo.Save(ctx, *goal))
+
+		otherID, _ := identifier.NewID()
+		_, err := repo.FindByID(ctx, otherID, goal.ID)
+		assert.ErrorIs(t, err, saving.ErrGoalNotFound)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		goal := createGoal(t, user.ID, "Car")
+		addContribution(t, goal, "2024-01", 10000)
+		require.NoError(t, repo.Save(ctx, *goal))
+
+		require.NoError(t, repo.Delete(ctx, user.ID, goal.ID))
+		_, err := repo.FindByID(ctx, user.ID, goal.ID)
+		assert.ErrorIs(t, err, saving.ErrGoalNotFound)
+		assert.ErrorIs(t, repo.Delete(ctx, user.ID, goal.ID), saving.ErrGoalNotFound)
+	})
+}
@@ -26,13 +26,14 @@ func (r *SQLiteTrackingRepository) Save(ctx context.Context, group tracking.Grou
 
 func (r *SQLiteTrackingRepository) saveWithExecutor(ctx context.Context, exec DBExecutor, group tracking.Group) error {
 	groupQuery := `
-		INSERT INTO groups (id, user_id, name, description, display_order)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO groups (id, user_id, name, description, display_order, archived)
+		VALUES (?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
 			user_id = excluded.user_id,
 			name = excluded.name,
 			description = excluded.description,
-			display_order = excluded.display_order
+			display_order = excluded.display_order,
+			archived = excluded.archived
 	`
 	_, err := exec.ExecContext(ctx, groupQuery,
 		group.ID.String(),
@@ -40,14 +41,15 @@ func (r *SQLiteTrackingRepository) saveWithExecutor(ctx context.Context, exec DB
 		group.Name.Value(),
 		group.Description.Value(),
 		group.Order.Value(),
+		group.Archived,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to save group: %w", err)
 	}
 
 	categoryQuery := `
-		INSERT INTO categories (id, group_id, name, description, is_recurrent, start_month, end_month, budget)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO categories (id, group_id, name, description, is_recurrent, start_month, end_month, budget, display_order, archived, parent_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
 			group_id = excluded.group_id,
 			name = excluded.name,
@@ -56,6 +58,9 @@ func (r *SQLiteTrackingRepository) saveWithExecutor(ctx context.Context, exec DB
 			start_month = excluded.start_month,
 			end_month = excluded.end_month,
 			budget = excluded.budget,
+			display_order = excluded.display_order,
+			archived = excluded.archived,
+			parent_id = excluded.parent_id,
 			updated_at = CURRENT_TIMESTAMP
 	`
 
@@ -65,6 +70,11 @@ func (r *SQLiteTrackingRepository) saveWithExecutor(ctx context.Context, exec DB
 			endMonth = sql.NullString{String: category.EndMonth.Value(), Valid: true}
 		}
 
+		parentID := sql.NullString{}
+		if !category.ParentID.IsZero() {
+			parentID = sql.NullString{String: category.ParentID.String(), Valid: true}
+		}
+
 		_, err = exec.ExecContext(ctx, categoryQuery,
 			category.ID.String(),
 			category.GroupID.String(),
@@ -74,6 +84,9 @@ func (r *SQLiteTrackingRepository) saveWithExecutor(ctx context.Context, exec DB
 			category.StartMonth.Value(),
 			endMonth,
 			category.Budget.Cents(),
+			category.Order.Value(),
+			category.Archived,
+			parentID,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to save category: %w", err)
@@ -84,11 +97,12 @@ func (r *SQLiteTrackingRepository) saveWithExecutor(ctx context.Context, exec DB
 }
 
 func (r *SQLiteTrackingRepository) FindByID(ctx context.Context, id tracking.ID) (tracking.Group, error) {
-	groupQuery := `SELECT id, user_id, name, description, display_order FROM groups WHERE id = ?`
+	groupQuery := `SELECT id, user_id, name, description, display_order, archived FROM groups WHERE id = ?`
 
 	var idStr, userIDStr, nameStr, descriptionStr string
 	var orderInt int
-	err := r.db.QueryRowContext(ctx, groupQuery, id.String()).Scan(&idStr, &userIDStr, &nameStr, &descriptionStr, &orderInt)
+	var archived bool
+	err := r.db.QueryRowContext(ctx, groupQuery, id.String()).Scan(&idStr, &userIDStr, &nameStr, &descriptionStr, &orderInt, &archived)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return tracking.Group{}, tracking.ErrGroupNotFound
@@ -96,7 +110,7 @@ func (r *SQLiteTrackingRepository) FindByID(ctx context.Context, id tracking.ID)
 		return tracking.Group{}, fmt.Errorf("failed to find group by id: %w", err)
 	}
 
-	group, err := r.mapToGroup(idStr, userIDStr, nameStr, descriptionStr, orderInt)
+	group, err := r.mapToGroup(idStr, userIDStr, nameStr, descriptionStr, orderInt, archived)
 	if err != nil {
 		return tracking.Group{}, fmt.Errorf("failed to map group: %w", err)
 	}
@@ -116,7 +130,7 @@ func (r *SQLiteTrackingRepository) FindByID(ctx context.Context, id tracking.ID)
 }
 
 func (r *SQLiteTrackingRepository) FindByUserID(ctx context.Context, userID tracking.ID) ([]tracking.Group, error) {
-	groupQuery := `SELECT id, user_id, name, description, display_order FROM groups WHERE user_id = ? ORDER BY display_order, name`
+	groupQuery := `SELECT id, user_id, name, description, display_order, archived FROM groups WHERE user_id = ? ORDER BY display_order, name`
 
 	rows, err := r.db.QueryContext(ctx, groupQuery, userID.String())
 	if err != nil {
@@ -130,11 +144,12 @@ func (r *SQLiteTrackingRepository) FindByUserID(ctx context.Context, userID trac
 	for rows.Next() {
 		var idStr, userIDStr, nameStr, descriptionStr string
 		var orderInt int
-		if err := rows.Scan(&idStr, &userIDStr, &nameStr, &descriptionStr, &orderInt); err != nil {
+		var archived bool
+		if err := rows.Scan(&idStr, &userIDStr, &nameStr, &descriptionStr, &orderInt, &archived); err != nil {
 			return nil, fmt.Errorf("failed to scan group row: %w", err)
 		}
 
-		group, err := r.mapToGroup(idStr, userIDStr, nameStr, descriptionStr, orderInt)
+		group, err := r.mapToGroup(idStr, userIDStr, nameStr, descriptionStr, orderInt, archived)
 		if err != nil {
 			return nil, fmt.Errorf("failed to map group: %w", err)
 		}
@@ -166,16 +181,18 @@ func (r *SQLiteTrackingRepository) FindByUserID(ctx context.Context, userID trac
 	for categoryRows.Next() {
 		var (
 			idStr, groupIDStr, nameStr, descriptionStr, startMonthStr, currencyStr string
-			isRecurrentInt                                                         int
+			isRecurrentInt, orderInt                                               int
 			budgetCents                                                            int64
 			endMonth                                                               sql.NullString
+			archived                                                               bool
+			parentID                                                               sql.NullString
 		)
 
-		if err := categoryRows.Scan(&idStr, &groupIDStr, &nameStr, &descriptionStr, &isRecurrentInt, &startMonthStr, &endMonth, &budgetCents, &currencyStr); err != nil {
+		if err := categoryRows.Scan(&idStr, &groupIDStr, &nameStr, &descriptionStr, &isRecurrentInt, &startMonthStr, &endMonth, &budgetCents, &orderInt, &archived, &parentID, &currencyStr); err != nil {
 			return nil, fmt.Errorf("failed to scan category row: %w", err)
 		}
 
-		category, err := r.mapToCategory(idStr, groupIDStr, nameStr, descriptionStr, isRecurrentInt == 1, startMonthStr, endMonth, budgetCents, currencyStr)
+		category, err := r.mapToCategory(idStr, groupIDStr, nameStr, descriptionStr, isRecurrentInt == 1, startMonthStr, endMonth, budgetCents, orderInt, archived, parentID, currencyStr)
 		if err != nil {
 			return nil, fmt.Errorf("failed to map category: %w", err)
 		}
@@ -207,7 +224,13 @@ func (r *SQLiteTrackingRepository) FindByUserIDAndMonth(ctx context.Context, use
 		return nil, fmt.Errorf("failed to parse month: %w", err)
 	}
 
-	groupQuery := `SELECT id, user_id, name, description, display_order FROM groups WHERE user_id = ? ORDER BY display_order, name`
+	// Archived categories only show up in months where they recorded expenses.
+	start, end, err := monthToDateRange(month)
+	if err != nil {
+		return nil, err
+	}
+
+	groupQuery := `SELECT id, user_id, name, description, display_order, archived FROM groups WHERE user_id = ? ORDER BY display_order, name`
 
 	rows, err := r.db.QueryContext(ctx, groupQuery, userID.String())
 	if err != nil {
@@ -221,11 +244,12 @@ func (r *SQLiteTrackingRepository) FindByUserIDAndMonth(ctx context.Context, use
 	for rows.Next() {
 		var idStr, userIDStr, nameStr, descriptionStr string
 		var orderInt int
-		if err := rows.Scan(&idStr, &userIDStr, &nameStr, &descriptionStr, &orderInt); err != nil {
+		var archived bool
+		if err := rows.Scan(&idStr, &userIDStr, &nameStr, &descriptionStr, &orderInt, &archived); err != nil {
 			return nil, fmt.Errorf("failed to scan group row: %w", err)
 		}
 
-		group, err := r.mapToGroup(idStr, userIDStr, nameStr, descriptionStr, orderInt)
+		group, err := r.mapToGroup(idStr, userIDStr, nameStr, descriptionStr, orderInt, archived)
 		if err != nil {
 			return nil, fmt.Errorf("failed to map group: %w", err)
 		}
@@ -248,7 +272,7 @@ func (r *SQLiteTrackingRepository) FindByUserIDAndMonth(ctx context.Context, use
 	}
 
 	categoryQuery := buildCategoriesByGroupIDsAndMonthQuery(len(groupIDs))
-	args := append(groupIDs, month, month, month)
+	args := append(groupIDs, month, month, month, start, end)
 	categoryRows, err := r.db.QueryContext(ctx, categoryQuery, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query categories by group ids and month: %w", err)
@@ -258,16 +282,18 @@ func (r *SQLiteTrackingRepository) FindByUserIDAndMonth(ctx context.Context, use
 	for categoryRows.Next() {
 		var (
 			idStr, groupIDStr, nameStr, descriptionStr, startMonthStr, currencyStr string
-			isRecurrentInt                                                         int
+			isRecurrentInt, orderInt                                               int
 			budgetCents                                                            int64
 			endMonth                                                               sql.NullString
+			archived                                                               bool
+			parentID                                                               sql.NullString
 		)
 
-		if err := categoryRows.Scan(&idStr, &groupIDStr, &nameStr, &descriptionStr, &isRecurrentInt, &startMonthStr, &endMonth, &budgetCents, &currencyStr); err != nil {
+		if err := categoryRows.Scan(&idStr, &groupIDStr, &nameStr, &descriptionStr, &isRecurrentInt, &startMonthStr, &endMonth, &budgetCents, &orderInt, &archived, &parentID, &currencyStr); err != nil {
 			return nil, fmt.Errorf("failed to scan category row: %w", err)
 		}
 
-		category, err := r.mapToCategory(idStr, groupIDStr, nameStr, descriptionStr, isRecurrentInt == 1, startMonthStr, endMonth, budgetCents, currencyStr)
+		category, err := r.mapToCategory(idStr, groupIDStr, nameStr, descriptionStr, isRecurrentInt == 1, startMonthStr, endMonth, budgetCents, orderInt, archived, parentID, currencyStr)
 		if err != nil {
 			return nil, fmt.Errorf("failed to map category: %w", err)
 		}
@@ -288,6 +314,9 @@ func (r *SQLiteTrackingRepository) FindByUserIDAndMonth(ctx context.Context, use
 
 	result := make([]tracking.Group, 0, len(groups))
 	for _, group := range groups {
+		if group.Archived && len(group.Categories) == 0 {
+			continue
+		}
 		result = append(result, *group)
 	}
 
@@ -296,14 +325,15 @@ func (r *SQLiteTrackingRepository) FindByUserIDAndMonth(ctx context.Context, use
 
 func (r *SQLiteTrackingRepository) FindGroupByCategoryID(ctx context.Context, categoryID tracking.ID) (tracking.Group, error) {
 	query := `
-		SELECT g.id, g.user_id, g.name, g.description, g.display_order
+		SELECT g.id, g.user_id, g.name, g.description, g.display_order, g.archived
 		FROM groups g
 		JOIN categories c ON g.id = c.group_id
 		WHERE c.id = ?
 	`
 	var idStr, userIDStr, nameStr, descriptionStr string
 	var orderInt int
-	err := r.db.QueryRowContext(ctx, query, categoryID.String()).Scan(&idStr, &userIDStr, &nameStr, &descriptionStr, &orderInt)
+	var archived bool
+	err := r.db.QueryRowContext(ctx, query, categoryID.String()).Scan(&idStr, &userIDStr, &nameStr, &descriptionStr, &orderInt, &archived)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return tracking.Group{}, tracking.ErrGroupNotFound
@@ -311,7 +341,7 @@ func (r *SQLiteTrackingRepository) FindGroupByCategoryID(ctx context.Context, ca
 		return tracking.Group{}, fmt.Errorf("failed to find group by category id: %w", err)
 	}
 
-	group, err := r.mapToGroup(idStr, userIDStr, nameStr, descriptionStr, orderInt)
+	group, err := r.mapToGroup(idStr, userIDStr, nameStr, descriptionStr, orderInt, archived)
 	if err != nil {
 		return tracking.Group{}, fmt.Errorf("failed to map group: %w", err)
 	}
@@ -364,12 +394,12 @@ func (r *SQLiteTrackingRepository) DeleteCategory(ctx context.Context, id tracki
 
 func (r *SQLiteTrackingRepository) findCategoriesByGroupID(ctx context.Context, groupID string) ([]*tracking.Category, error) {
 	query := `
-		SELECT c.id, c.group_id, c.name, c.description, c.is_recurrent, c.start_month, c.end_month, c.budget, u.currency
+		SELECT c.id, c.group_id, c.name, c.description, c.is_recurrent, c.start_month, c.end_month, c.budget, c.display_order, c.archived, c.parent_id, u.currency
 		FROM categories c
 		JOIN groups g ON c.group_id = g.id
 		JOIN users u ON g.user_id = u.id
 		WHERE c.group_id = ?
-		ORDER BY c.name
+		ORDER BY c.display_order, c.name
 	`
 	rows, err := r.db.QueryContext(ctx, query, groupID)
 	if err != nil {
@@ -381,16 +411,18 @@ func (r *SQLiteTrackingRepository) findCategoriesByGroupID(ctx context.Context,
 	for rows.Next() {
 		var (
 			idStr, groupIDStr, nameStr, descriptionStr, startMonthStr, currencyStr string
-			isRecurrentInt                                                         int
+			isRecurrentInt, orderInt                                               int
 			budgetCents                                                            int64
 			endMonth                                                               sql.NullString
+			archived                                                               bool
+			parentID                                                               sql.NullString
 		)
 
-		if err := rows.Scan(&idStr, &groupIDStr, &nameStr, &descriptionStr, &isRecurrentInt, &startMonthStr, &endMonth, &budgetCents, &currencyStr); err != nil {
+		if err := rows.Scan(&idStr, &groupIDStr, &nameStr, &descriptionStr, &isRecurrentInt, &startMonthStr, &endMonth, &budgetCents, &orderInt, &archived, &parentID, &currencyStr); err != nil {
 			return nil, fmt.Errorf("failed to scan category row: %w", err)
 		}
 
-		category, err := r.mapToCategory(idStr, groupIDStr, nameStr, descriptionStr, isRecurrentInt == 1, startMonthStr, endMonth, budgetCents, currencyStr)
+		category, err := r.mapToCategory(idStr, groupIDStr, nameStr, descriptionStr, isRecurrentInt == 1, startMonthStr, endMonth, budgetCents, orderInt, archived, parentID, currencyStr)
 		if err != nil {
 			return nil, fmt.Errorf("failed to map category: %w", err)
 		}
@@ -404,7 +436,7 @@ func (r *SQLiteTrackingRepository) findCategoriesByGroupID(ctx context.Context,
 	return categories, nil
 }
 
-func (r *SQLiteTrackingRepository) mapToGroup(idStr, userIDStr, nameStr, descriptionStr string, orderInt int) (*tracking.Group, error) {
+func (r *SQLiteTrackingRepository) mapToGroup(idStr, userIDStr, nameStr, descriptionStr string, orderInt int, archived bool) (*tracking.Group, error) {
 	id, err := identifier.ParseID(idStr)
 	if err != nil {
 		return nil, err
@@ -430,10 +462,13 @@ func (r *SQLiteTrackingRepository) mapToGroup(idStr, userIDStr, nameStr, descrip
 		return nil, err
 	}
 
-	return tracking.NewGroup(id, userID, name, description, order), nil
+	group := tracking.NewGroup(id, userID, name, description, order)
+	group.Archived = archived
+
+	return group, nil
 }
 
-func (r *SQLiteTrackingRepository) mapToCategory(idStr, groupIDStr, nameStr, descriptionStr string, isRecurrent bool, startMonthStr string, endMonth sql.NullString, budgetCents int64, currencyStr string) (*tracking.Category, error) {
+func (r *SQLiteTrackingRepository) mapToCategory(idStr, groupIDStr, nameStr, descriptionStr string, isRecurrent bool, startMonthStr string, endMonth sql.NullString, budgetCents int64, orderInt int, archived bool, parentID sql.NullString, currencyStr string) (*tracking.Category, error) {
 	id, err := identifier.ParseID(idStr)
 	if err != nil {
 		return nil, err
@@ -472,19 +507,37 @@ func (r *SQLiteTrackingRepository) mapToCategory(idStr, groupIDStr, nameStr, des
 		return nil, err
 	}
 
-	return tracking.NewCategory(id, groupID, name, description, isRecurrent, startMonth, endMonthValue, budget)
+	order, err := tracking.NewOrderVO(orderInt)
+	if err != nil {
+		return nil, err
+	}
+
+	category, err := tracking.NewCategory(id, groupID, name, description, isRecurrent, startMonth, endMonthValue, budget)
+	if err != nil {
+		return nil, err
+	}
+	category.Order = order
+	category.Archived = archived
+	if parentID.Valid && parentID.String != "" {
+		category.ParentID, err = identifier.ParseID(parentID.String)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return category, nil
 }
 
 func buildCategoriesByGroupIDsQuery(count int) string {
 	placeholders := strings.Repeat("?,", count)
 	placeholders = strings.TrimSuffix(placeholders, ",")
 	return fmt.Sprintf(`
-		SELECT c.id, c.group_id, c.name, c.description, c.is_recurrent, c.start_month, c.end_month, c.budget, u.currency
+		SELECT c.id, c.group_id, c.name, c.description, c.is_recurrent, c.start_month, c.end_month, c.budget, c.display_order, c.archived, c.parent_id, u.currency
 		FROM categories c
 		JOIN groups g ON c.group_id = g.id
 		JOIN users u ON g.user_id = u.id
 		WHERE c.group_id IN (%s)
-		ORDER BY c.group_id, c.name
+		ORDER BY c.group_id, c.display_order, c.name
 	`, placeholders)
 }
 
@@ -492,14 +545,17 @@ func buildCategoriesByGroupIDsAndMonthQuery(count int) string {
 	placeholders := strings.Repeat("?,", count)
 	placeholders = strings.TrimSuffix(placeholders, ",")
 	return fmt.Sprintf(`
-		SELECT c.id, c.group_id, c.name, c.description, c.is_recurrent, c.start_month, c.end_month, c.budget, u.currency
+		SELECT c.id, c.group_id, c.name, c.description, c.is_recurrent, c.start_month, c.end_month, c.budget, c.display_order, c.archived, c.parent_id, u.currency
 		FROM categories c
 		JOIN groups g ON c.group_id = g.id
 		JOIN users u ON g.user_id = u.id
 		WHERE c.group_id IN (%s) AND (
 			(c.is_recurrent = 1 AND c.start_month <= ? AND (c.end_month IS NULL OR c.end_month = '' OR c.end_month >= ?))
 			OR (c.is_recurrent = 0 AND c.start_month = ?)
+		) AND (
+			(g.archived = 0 AND c.archived = 0)
+			OR EXISTS (SELECT 1 FROM expenses e WHERE e.category_id = c.id AND e.spent_at >= ? AND e.spent_at < ?)
 		)
-		ORDER BY c.group_id, c.name
+		ORDER BY c.group_id, c.display_order, c.name
 	`, placeholders)
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
 	"github.com/madalinpopa/gocost-web/internal/infrastructure/storage/sqlite"
 	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -115,6 +116,135 @@ func TestSQLiteTrackingRepository(t *testing.T) {
 		assert.Equal(t, updatedEndMonth, foundGroup.Categories[0].EndMonth)
 	})
 
+	t.Run("Save_PersistsCategoryOrder", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+
+		startMonth := mustMonth(t, 2024, time.January)
+		group := newGroup(t, user.ID, "Personal")
+		catA := addCategory(t, group, "A", true, startMonth, tracking.Month{})
+		catB := addCategory(t, group, "B", true, startMonth, tracking.Month{})
+		catC := addCategory(t, group, "C", true, startMonth, tracking.Month{})
+		require.NoError(t, group.ReorderCategories([]tracking.ID{catC.ID, catA.ID, catB.ID}))
+		require.NoError(t, repo.Save(ctx, *group))
+
+		foundGroup, err := repo.FindByID(ctx, group.ID)
+		require.NoError(t, err)
+		require.Len(t, foundGroup.Categories, 3)
+		assert.Equal(t, catC.ID, foundGroup.Categories[0].ID)
+		assert.Equal(t, catA.ID, foundGroup.Categories[1].ID)
+		assert.Equal(t, catB.ID, foundGroup.Categories[2].ID)
+		assert.Equal(t, 2, foundGroup.Categories[2].Order.Value())
+
+		groups, err := repo.FindByUserIDAndMonth(ctx, user.ID, startMonth.Value())
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		require.Len(t, groups[0].Categories, 3)
+		assert.Equal(t, catC.ID, groups[0].Categories[0].ID)
+		assert.Equal(t, catB.ID, groups[0].Categories[2].ID)
+	})
+
+	t.Run("Save_PersistsArchivedState", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+
+		group := newGroup(t, user.ID, "Old apartment")
+		category := addCategory(t, group, "Rent", true, mustMonth(t, 2024, time.January), tracking.Month{})
+		group.Archive()
+		require.NoError(t, group.ArchiveCategory(category.ID))
+		require.NoError(t, repo.Save(ctx, *group))
+
+		foundGroup, err := repo.FindByID(ctx, group.ID)
+		require.NoError(t, err)
+		assert.True(t, foundGroup.Archived)
+		require.Len(t, foundGroup.Categories, 1)
+		assert.True(t, foundGroup.Categories[0].Archived)
+
+		foundGroup.Restore()
+		require.NoError(t, foundGroup.RestoreCategory(category.ID))
+		require.NoError(t, repo.Save(ctx, foundGroup))
+
+		groups, err := repo.FindByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		assert.False(t, groups[0].Archived)
+		assert.False(t, groups[0].Categories[0].Archived)
+	})
+
+	t.Run("Save_PersistsSubcategories", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+
+		jan := mustMonth(t, 2024, time.January)
+		group := newGroup(t, user.ID, "Household")
+		utilities := addCategory(t, group, "Utilities", true, jan, tracking.Month{})
+
+		subID, err := identifier.NewID()
+		require.NoError(t, err)
+		name, err := tracking.NewNameVO("Electricity")
+		require.NoError(t, err)
+		desc, err := tracking.NewDescriptionVO("")
+		require.NoError(t, err)
+		_, err = group.CreateSubcategory(utilities.ID, subID, name, desc, true, jan, tracking.Month{}, money.Money{})
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, *group))
+
+		foundGroup, err := repo.FindByID(ctx, group.ID)
+		require.NoError(t, err)
+		require.Len(t, foundGroup.Categories, 2)
+		subs := foundGroup.Subcategories(utilities.ID)
+		require.Len(t, subs, 1)
+		assert.Equal(t, subID, subs[0].ID)
+		assert.True(t, foundGroup.TopLevelCategories()[0].ParentID.IsZero())
+
+		groups, err := repo.FindByUserIDAndMonth(ctx, user.ID, jan.Value())
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		assert.Len(t, groups[0].Subcategories(utilities.ID), 1)
+	})
+
+	t.Run("FindByUserIDAndMonth_ShowsArchivedOnlyWithActivity", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		expenseRepo := sqlite.NewSQLiteExpenseRepository(testDB)
+
+		jan := mustMonth(t, 2024, time.January)
+		archivedGroup := newGroup(t, user.ID, "Old apartment")
+		rent := addCategory(t, archivedGroup, "Rent", true, jan, tracking.Month{})
+		archivedGroup.Archive()
+		require.NoError(t, repo.Save(ctx, *archivedGroup))
+
+		activeGroup := newGroup(t, user.ID, "Personal")
+		food := addCategory(t, activeGroup, "Food", true, jan, tracking.Month{})
+		gym := addCategory(t, activeGroup, "Gym", true, jan, tracking.Month{})
+		require.NoError(t, activeGroup.ArchiveCategory(gym.ID))
+		require.NoError(t, repo.Save(ctx, *activeGroup))
+
+		for _, categoryID := range []identifier.ID{rent.ID, gym.ID} {
+			exp := createRandomExpense(t, categoryID)
+			exp.SpentAt = time.Date(2024, time.February, 10, 0, 0, 0, 0, time.UTC)
+			require.NoError(t, expenseRepo.Save(ctx, *exp))
+		}
+
+		groups, err := repo.FindByUserIDAndMonth(ctx, user.ID, "2024-02")
+		require.NoError(t, err)
+		require.Len(t, groups, 2)
+		categoryIDs := make([]identifier.ID, 0)
+		for _, group := range groups {
+			for _, category := range group.Categories {
+				categoryIDs = append(categoryIDs, category.ID)
+			}
+		}
+		assert.ElementsMatch(t, []identifier.ID{rent.ID, food.ID, gym.ID}, categoryIDs)
+
+		groups, err = repo.FindByUserIDAndMonth(ctx, user.ID, "2024-03")
+		require.NoError(t, err)
+		require.Len(t, groups, 1)
+		assert.Equal(t, activeGroup.ID, groups[0].ID)
+		require.Len(t, groups[0].Categories, 1)
+		assert.Equal(t, food.ID, groups[0].Categories[0].ID)
+	})
+
 	t.Run("FindByUserIDAndMonth_InvalidMonth", func(t *testing.T) {
 		user := createRandomUser(t)
 		require.NoError(t, userRepo.Save(ctx, *user))
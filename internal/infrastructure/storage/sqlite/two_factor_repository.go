@@ -0,0 +1,112 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+)
+
+type SQLiteTwoFactorRepository struct {
+	db DBExecutor
+}
+
+func NewSQLiteTwoFactorRepository(db DBExecutor) *SQLiteTwoFactorRepository {
+	return &SQLiteTwoFactorRepository{db: db}
+}
+
+// Save stores the second factor of the user and replaces their recovery
+// codes.
+func (r *SQLiteTwoFactorRepository) Save(ctx context.Context, twoFactor identity.TwoFactor) error {
+	query := `
+		INSERT INTO two_factor (user_id, secret, confirmed_at, last_used_step)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(user_id) DO UPDATE SET
+			secret = excluded.secret,
+			confirmed_at = excluded.confirmed_at,
+			last_used_step = excluded.last_used_step
+	`
+
+	var confirmedAt sql.NullTime
+	if twoFactor.ConfirmedAt != nil {
+		confirmedAt = sql.NullTime{Time: *twoFactor.ConfirmedAt, Valid: true}
+	}
+
+	userID := twoFactor.UserID.String()
+	_, err := r.db.ExecContext(ctx, query, userID, twoFactor.Secret, confirmedAt, twoFactor.LastUsedStep)
+	if err != nil {
+		return fmt.Errorf("failed to save two-factor authentication: %w", err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = ?`, userID); err != nil {
+		return fmt.Errorf("failed to clear recovery codes: %w", err)
+	}
+
+	for _, code := range twoFactor.RecoveryCodes {
+		var usedAt sql.NullTime
+		if code.UsedAt != nil {
+			usedAt = sql.NullTime{Time: *code.UsedAt, Valid: true}
+		}
+
+		query := `INSERT INTO recovery_codes (user_id, code_hash, used_at) VALUES (?, ?, ?)`
+		if _, err := r.db.ExecContext(ctx, query, userID, code.CodeHash, usedAt); err != nil {
+			return fmt.Errorf("failed to save recovery code: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *SQLiteTwoFactorRepository) FindByUserID(ctx context.Context, userID identifier.ID) (identity.TwoFactor, error) {
+	query := `SELECT secret, confirmed_at, last_used_step FROM two_factor WHERE user_id = ?`
+
+	twoFactor := identity.TwoFactor{UserID: userID}
+	var confirmedAt sql.NullTime
+
+	err := r.db.QueryRowContext(ctx, query, userID.String()).Scan(&twoFactor.Secret, &confirmedAt, &twoFactor.LastUsedStep)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return identity.TwoFactor{}, identity.ErrTwoFactorNotFound
+		}
+		return identity.TwoFactor{}, fmt.Errorf("failed to find two-factor authentication: %w", err)
+	}
+	if confirmedAt.Valid {
+		twoFactor.ConfirmedAt = &confirmedAt.Time
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT code_hash, used_at FROM recovery_codes WHERE user_id = ?`, userID.String())
+	if err != nil {
+		return identity.TwoFactor{}, fmt.Errorf("failed to find recovery codes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var code identity.RecoveryCode
+		var usedAt sql.NullTime
+		if err := rows.Scan(&code.CodeHash, &usedAt); err != nil {
+			return identity.TwoFactor{}, fmt.Errorf("failed to scan recovery code: %w", err)
+		}
+		if usedAt.Valid {
+			code.UsedAt = &usedAt.Time
+		}
+		twoFactor.RecoveryCodes = append(twoFactor.RecoveryCodes, code)
+	}
+	if err := rows.Err(); err != nil {
+		return identity.TwoFactor{}, fmt.Errorf("failed to iterate recovery codes: %w", err)
+	}
+
+	return twoFactor, nil
+}
+
+func (r *SQLiteTwoFactorRepository) DeleteByUserID(ctx context.Context, userID identifier.ID) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = ?`, userID.String()); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM two_factor WHERE user_id = ?`, userID.String()); err != nil {
+		return fmt.Errorf("failed to delete two-factor authentication: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/infrastructure/storage/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteTwoFactorRepository(t *testing.T) {
+	repo := sqlite.NewSQLiteTwoFactorRepository(testDB)
+	userRepo := sqlite.NewSQLiteUserRepository(testDB)
+	ctx := context.Background()
+
+	t.Run("Save_And_FindByUserID", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+
+		twoFactor := identity.NewTwoFactor(user.ID, "SECRET")
+		require.NoError(t, repo.Save(ctx, *twoFactor))
+
+		pending, err := repo.FindByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "SECRET", pending.Secret)
+		assert.False(t, pending.IsEnabled())
+		assert.Empty(t, pending.RecoveryCodes)
+
+		now := time.Now().UTC().Truncate(time.Second)
+		require.NoError(t, pending.Confirm(42, []string{"hash-a", "hash-b"}, now))
+		require.NoError(t, pending.UseRecoveryCode("hash-a", now))
+		require.NoError(t, repo.Save(ctx, pending))
+
+		enabled, err := repo.FindByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.True(t, enabled.IsEnabled())
+		assert.Equal(t, int64(42), enabled.LastUsedStep)
+		assert.Len(t, enabled.RecoveryCodes, 2)
+		assert.Equal(t, 1, enabled.RecoveryCodesLeft())
+	})
+
+	t.Run("FindByUserID_NotFound", func(t *testing.T) {
+		user := createRandomUser(t)
+
+		_, err := repo.FindByUserID(ctx, user.ID)
+		assert.ErrorIs(t, err, identity.ErrTwoFactorNotFound)
+	})
+
+	t.Run("DeleteByUserID", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		twoFactor := identity.NewTwoFactor(user.ID, "SECRET")
+		require.NoError(t, twoFactor.Confirm(1, []string{"hash"}, time.Now()))
+		require.NoError(t, repo.Save(ctx, *twoFactor))
+
+		require.NoError(t, repo.DeleteByUserID(ctx, user.ID))
+
+		_, err := repo.FindByUserID(ctx, user.ID)
+		assert.ErrorIs(t, err, identity.ErrTwoFactorNotFound)
+	})
+}
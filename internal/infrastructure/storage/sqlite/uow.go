@@ -6,9 +6,15 @@ import (
 	"fmt"
 
 	"github.com/madalinpopa/gocost-web/internal/domain"
+	"github.com/madalinpopa/gocost-web/internal/domain/account"
+	"github.com/madalinpopa/gocost-web/internal/domain/alert"
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
 	"github.com/madalinpopa/gocost-web/internal/domain/expense"
 	"github.com/madalinpopa/gocost-web/internal/domain/identity"
 	"github.com/madalinpopa/gocost-web/internal/domain/income"
+	"github.com/madalinpopa/gocost-web/internal/domain/loan"
+	"github.com/madalinpopa/gocost-web/internal/domain/networth"
+	"github.com/madalinpopa/gocost-web/internal/domain/saving"
 	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
 )
 
@@ -33,6 +39,41 @@ func (u *SqliteUnitOfWork) UserRepository() identity.UserRepository {
 	return NewSQLiteUserRepository(u.db)
 }
 
+func (u *SqliteUnitOfWork) PasswordResetRepository() identity.PasswordResetRepository {
+	if u.tx != nil {
+		return NewSQLitePasswordResetRepository(u.tx)
+	}
+	return NewSQLitePasswordResetRepository(u.db)
+}
+
+func (u *SqliteUnitOfWork) TwoFactorRepository() identity.TwoFactorRepository {
+	if u.tx != nil {
+		return NewSQLiteTwoFactorRepository(u.tx)
+	}
+	return NewSQLiteTwoFactorRepository(u.db)
+}
+
+func (u *SqliteUnitOfWork) PasskeyRepository() identity.PasskeyRepository {
+	if u.tx != nil {
+		return NewSQLitePasskeyRepository(u.tx)
+	}
+	return NewSQLitePasskeyRepository(u.db)
+}
+
+func (u *SqliteUnitOfWork) UserSessionRepository() identity.UserSessionRepository {
+	if u.tx != nil {
+		return NewSQLiteUserSessionRepository(u.tx)
+	}
+	return NewSQLiteUserSessionRepository(u.db)
+}
+
+func (u *SqliteUnitOfWork) InviteRepository() identity.InviteRepository {
+	if u.tx != nil {
+		return NewSQLiteInviteRepository(u.tx)
+	}
+	return NewSQLiteInviteRepository(u.db)
+}
+
 func (u *SqliteUnitOfWork) IncomeRepository() income.IncomeRepository {
 	if u.tx != nil {
 		return NewSQLiteIncomeRepository(u.tx)
@@ -54,6 +95,48 @@ func (u *SqliteUnitOfWork) TrackingRepository() tracking.GroupRepository {
 	return NewSQLiteTrackingRepository(u.db)
 }
 
+func (u *SqliteUnitOfWork) ClosingRepository() closing.MonthCloseRepository {
+	if u.tx != nil {
+		return NewSQLiteClosingRepository(u.tx)
+	}
+	return NewSQLiteClosingRepository(u.db)
+}
+
+func (u *SqliteUnitOfWork) SavingRepository() saving.GoalRepository {
+	if u.tx != nil {
+		return NewSQLiteSavingRepository(u.tx)
+	}
+	return NewSQLiteSavingRepository(u.db)
+}
+
+func (u *SqliteUnitOfWork) AccountRepository() account.AccountRepository {
+	if u.tx != nil {
+		return NewSQLiteAccountRepository(u.tx)
+	}
+	return NewSQLiteAccountRepository(u.db)
+}
+
+func (u *SqliteUnitOfWork) LoanRepository() loan.LoanRepository {
+	if u.tx != nil {
+		return NewSQLiteLoanRepository(u.tx)
+	}
+	return NewSQLiteLoanRepository(u.db)
+}
+
+func (u *SqliteUnitOfWork) AssetRepository() networth.AssetRepository {
+	if u.tx != nil {
+		return NewSQLiteAssetRepository(u.tx)
+	}
+	return NewSQLiteAssetRepository(u.db)
+}
+
+func (u *SqliteUnitOfWork) AlertRepository() alert.AlertRepository {
+	if u.tx != nil {
+		return NewSQLiteAlertRepository(u.tx)
+	}
+	return NewSQLiteAlertRepository(u.db)
+}
+
 func (u *SqliteUnitOfWork) Begin(ctx context.Context) (domain.UnitOfWork, error) {
 	tx, err := u.db.BeginTx(ctx, nil)
 	if err != nil {
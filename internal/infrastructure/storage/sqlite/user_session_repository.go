@@ -0,0 +1,115 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+)
+
+type SQLiteUserSessionRepository struct {
+	db DBExecutor
+}
+
+func NewSQLiteUserSessionRepository(db DBExecutor) *SQLiteUserSessionRepository {
+	return &SQLiteUserSessionRepository{db: db}
+}
+
+func (r *SQLiteUserSessionRepository) Save(ctx context.Context, session identity.UserSession) error {
+	query := `
+		INSERT INTO user_sessions (id, user_id, ip_address, user_agent, created_at, last_seen_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			ip_address = excluded.ip_address,
+			last_seen_at = excluded.last_seen_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		session.ID.String(),
+		session.UserID.String(),
+		session.IPAddress,
+		session.UserAgent,
+		session.CreatedAt,
+		session.LastSeenAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SQLiteUserSessionRepository) FindByID(ctx context.Context, userID identifier.ID, id identifier.ID) (identity.UserSession, error) {
+	query := `
+		SELECT id, user_id, ip_address, user_agent, created_at, last_seen_at
+		FROM user_sessions WHERE id = ? AND user_id = ?
+	`
+
+	session, err := scanUserSession(r.db.QueryRowContext(ctx, query, id.String(), userID.String()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return identity.UserSession{}, identity.ErrUserSessionNotFound
+		}
+		return identity.UserSession{}, fmt.Errorf("failed to find session: %w", err)
+	}
+	return session, nil
+}
+
+func (r *SQLiteUserSessionRepository) FindByUserID(ctx context.Context, userID identifier.ID) ([]identity.UserSession, error) {
+	query := `
+		SELECT id, user_id, ip_address, user_agent, created_at, last_seen_at
+		FROM user_sessions WHERE user_id = ?
+		ORDER BY last_seen_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []identity.UserSession
+	for rows.Next() {
+		session, err := scanUserSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (r *SQLiteUserSessionRepository) DeleteSeenBefore(ctx context.Context, userID identifier.ID, before time.Time) error {
+	query := `DELETE FROM user_sessions WHERE user_id = ? AND last_seen_at < ?`
+
+	if _, err := r.db.ExecContext(ctx, query, userID.String(), before); err != nil {
+		return fmt.Errorf("failed to delete sessions: %w", err)
+	}
+	return nil
+}
+
+func scanUserSession(row rowScanner) (identity.UserSession, error) {
+	var idStr, userIDStr string
+	var session identity.UserSession
+
+	err := row.Scan(&idStr, &userIDStr, &session.IPAddress, &session.UserAgent, &session.CreatedAt, &session.LastSeenAt)
+	if err != nil {
+		return identity.UserSession{}, err
+	}
+
+	if session.ID, err = identifier.ParseID(idStr); err != nil {
+		return identity.UserSession{}, err
+	}
+	if session.UserID, err = identifier.ParseID(userIDStr); err != nil {
+		return identity.UserSession{}, err
+	}
+	return session, nil
+}
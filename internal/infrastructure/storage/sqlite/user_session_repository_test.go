@@ -0,0 +1,89 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/infrastructure/storage/sqlite"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteUserSessionRepository(t *testing.T) {
+	repo := sqlite.NewSQLiteUserSessionRepository(testDB)
+	userRepo := sqlite.NewSQLiteUserRepository(testDB)
+	ctx := context.Background()
+
+	newSession := func(t *testing.T, userID identifier.ID, at time.Time) *identity.UserSession {
+		id, err := identifier.NewID()
+		require.NoError(t, err)
+		return identity.NewUserSession(id, userID, "203.0.113.7", "Mozilla/5.0 Firefox/128.0", at)
+	}
+
+	t.Run("Save_And_FindByID", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		at := time.Now().UTC().Truncate(time.Second)
+		session := newSession(t, user.ID, at)
+		require.NoError(t, repo.Save(ctx, *session))
+
+		session.Seen("198.51.100.2", at.Add(time.Hour))
+		require.NoError(t, repo.Save(ctx, *session))
+
+		found, err := repo.FindByID(ctx, user.ID, session.ID)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, found.UserID)
+		assert.Equal(t, "198.51.100.2", found.IPAddress)
+		assert.Equal(t, "Mozilla/5.0 Firefox/128.0", found.UserAgent)
+		assert.True(t, at.Equal(found.CreatedAt))
+		assert.True(t, at.Add(time.Hour).Equal(found.LastSeenAt))
+	})
+
+	t.Run("FindByID_OfAnotherUser", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		other := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *other))
+		session := newSession(t, user.ID, time.Now().UTC())
+		require.NoError(t, repo.Save(ctx, *session))
+
+		_, err := repo.FindByID(ctx, other.ID, session.ID)
+		assert.ErrorIs(t, err, identity.ErrUserSessionNotFound)
+	})
+
+	t.Run("FindByUserID_LastSeenFirst", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		at := time.Now().UTC().Truncate(time.Second)
+		older := newSession(t, user.ID, at.Add(-time.Hour))
+		newer := newSession(t, user.ID, at)
+		require.NoError(t, repo.Save(ctx, *older))
+		require.NoError(t, repo.Save(ctx, *newer))
+
+		sessions, err := repo.FindByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, sessions, 2)
+		assert.Equal(t, newer.ID, sessions[0].ID)
+		assert.Equal(t, older.ID, sessions[1].ID)
+	})
+
+	t.Run("DeleteSeenBefore", func(t *testing.T) {
+		user := createRandomUser(t)
+		require.NoError(t, userRepo.Save(ctx, *user))
+		at := time.Now().UTC().Truncate(time.Second)
+		stale := newSession(t, user.ID, at.Add(-48*time.Hour))
+		recent := newSession(t, user.ID, at)
+		require.NoError(t, repo.Save(ctx, *stale))
+		require.NoError(t, repo.Save(ctx, *recent))
+
+		require.NoError(t, repo.DeleteSeenBefore(ctx, user.ID, at.Add(-24*time.Hour)))
+
+		sessions, err := repo.FindByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, sessions, 1)
+		assert.Equal(t, recent.ID, sessions[0].ID)
+	})
+}
@@ -0,0 +1,116 @@
+package form
+
+import (
+	"strconv"
+	"time"
+)
+
+// AccountForm creates an account, or updates the account with ID when it is
+// set. The opening balance may be negative, as for a card that starts with
+// debt.
+type AccountForm struct {
+	ID             string `form:"account-id"`
+	Name           string `form:"account-name"`
+	Type           string `form:"account-type"`
+	OpeningBalance string `form:"account-opening"`
+	Base           `form:"-"`
+}
+
+func (f *AccountForm) ParsedOpeningBalance() float64 {
+	val, _ := strconv.ParseFloat(f.OpeningBalance, 64)
+	return val
+}
+
+func (f *AccountForm) Validate() {
+	f.CheckField(NotBlank(f.Name),
+		"account-name",
+		"this field is required",
+	)
+	f.CheckField(MaxChars(f.Name, 100),
+		"account-name",
+		"name must be at most 100 characters long",
+	)
+	f.CheckField(PermittedValue(f.Type, "checking", "savings", "credit_card", "cash"),
+		"account-type",
+		"invalid account type",
+	)
+	f.CheckField(ValidFloat(f.OpeningBalance),
+		"account-opening",
+		"opening balance must be a number",
+	)
+}
+
+// TransferForm moves money from one account to another.
+type TransferForm struct {
+	FromAccountID string `form:"transfer-from"`
+	ToAccountID   string `form:"transfer-to"`
+	Amount        string `form:"transfer-amount"`
+	Date          string `form:"transfer-date"`
+	Base          `form:"-"`
+}
+
+func (f *TransferForm) ParsedAmount() float64 {
+	val, _ := strconv.ParseFloat(f.Amount, 64)
+	return val
+}
+
+func (f *TransferForm) ParsedDate() time.Time {
+	val, _ := time.Parse("2006-01-02", f.Date)
+	return val
+}
+
+func (f *TransferForm) Validate() {
+	f.CheckField(NotBlank(f.FromAccountID),
+		"transfer-from",
+		"this field is required",
+	)
+	f.CheckField(NotBlank(f.ToAccountID),
+		"transfer-to",
+		"this field is required",
+	)
+	if f.FromAccountID != "" && f.FromAccountID == f.ToAccountID {
+		f.AddFieldError("transfer-to", "choose a different account")
+	}
+	if !ValidFloat(f.Amount) {
+		f.AddFieldError("transfer-amount", "amount must be a number")
+	} else {
+		f.CheckField(PositiveFloat(f.ParsedAmount()),
+			"transfer-amount",
+			"amount must be greater than 0",
+		)
+	}
+	f.CheckField(ValidDateString(f.Date),
+		"transfer-date",
+		"invalid date",
+	)
+}
+
+// ReconcileForm compares an account with a bank statement. Confirm asks for
+// the entries to be marked as reconciled rather than only compared.
+type ReconcileForm struct {
+	StatementDate    string `form:"statement-date"`
+	StatementBalance string `form:"statement-balance"`
+	Confirm          bool   `form:"confirm"`
+	Base             `form:"-"`
+}
+
+func (f *ReconcileForm) ParsedStatementBalance() float64 {
+	val, _ := strconv.ParseFloat(f.StatementBalance, 64)
+	return val
+}
+
+func (f *ReconcileForm) ParsedStatementDate() time.Time {
+	val, _ := time.Parse("2006-01-02", f.StatementDate)
+	return val
+}
+
+func (f *ReconcileForm) Validate() {
+	f.CheckField(ValidDateString(f.StatementDate),
+		"statement-date",
+		"invalid date",
+	)
+	f.CheckField(ValidFloat(f.StatementBalance),
+		"statement-balance",
+		"balance must be a number",
+	)
+}
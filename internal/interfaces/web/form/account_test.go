@@ -0,0 +1,104 @@
+package form
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountForm_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		form       AccountForm
+		wantValid  bool
+		wantErrors map[string]string
+	}{
+		{
+			name:       "valid form",
+			form:       AccountForm{Name: "Checking", Type: "checking", OpeningBalance: "1200.50"},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name:       "negative opening balance",
+			form:       AccountForm{Name: "Visa", Type: "credit_card", OpeningBalance: "-250"},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name:      "missing fields",
+			form:      AccountForm{Type: "crypto"},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"account-name":    "this field is required",
+				"account-type":    "invalid account type",
+				"account-opening": "opening balance must be a number",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.form.Validate()
+			assert.Equal(t, tt.wantValid, tt.form.IsValid())
+			assert.Equal(t, tt.wantErrors, tt.form.FieldErrors)
+		})
+	}
+}
+
+func TestTransferForm_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		form       TransferForm
+		wantValid  bool
+		wantErrors map[string]string
+	}{
+		{
+			name:       "valid form",
+			form:       TransferForm{FromAccountID: "a", ToAccountID: "b", Amount: "50", Date: "2024-03-05"},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name:      "same account",
+			form:      TransferForm{FromAccountID: "a", ToAccountID: "a", Amount: "50", Date: "2024-03-05"},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"transfer-to": "choose a different account",
+			},
+		},
+		{
+			name:      "invalid amount and date",
+			form:      TransferForm{FromAccountID: "a", ToAccountID: "b", Amount: "0", Date: "05/03/2024"},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"transfer-amount": "amount must be greater than 0",
+				"transfer-date":   "invalid date",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.form.Validate()
+			assert.Equal(t, tt.wantValid, tt.form.IsValid())
+			assert.Equal(t, tt.wantErrors, tt.form.FieldErrors)
+		})
+	}
+}
+
+func TestReconcileForm_Validate(t *testing.T) {
+	f := ReconcileForm{StatementDate: "2024-03-31", StatementBalance: "-120.25"}
+	f.Validate()
+	assert.True(t, f.IsValid())
+	assert.Equal(t, -120.25, f.ParsedStatementBalance())
+	assert.Equal(t, time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC), f.ParsedStatementDate())
+
+	f = ReconcileForm{StatementDate: "March", StatementBalance: "abc"}
+	f.Validate()
+	assert.Equal(t, map[string]string{
+		"statement-date":    "invalid date",
+		"statement-balance": "balance must be a number",
+	}, f.FieldErrors)
+}
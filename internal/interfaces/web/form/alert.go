@@ -0,0 +1,50 @@
+package form
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ThresholdsForm sets the alert thresholds of a category as percentages of
+// its budget separated by commas, such as "80, 100". Leaving it empty turns
+// the category's alerts off.
+type ThresholdsForm struct {
+	Thresholds string `form:"thresholds"`
+	Base       `form:"-"`
+}
+
+// ParsedThresholds returns the percentages, or nil when one of them is not a
+// whole number.
+func (f *ThresholdsForm) ParsedThresholds() []int {
+	var percents []int
+	for _, part := range strings.Split(f.Thresholds, ",") {
+		part = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(part), "%"))
+		if part == "" {
+			continue
+		}
+		percent, err := strconv.Atoi(part)
+		if err != nil {
+			return nil
+		}
+		percents = append(percents, percent)
+	}
+	return percents
+}
+
+func (f *ThresholdsForm) Validate() {
+	percents := f.ParsedThresholds()
+	if percents == nil && strings.Trim(f.Thresholds, ", %") != "" {
+		f.AddFieldError("thresholds", "thresholds must be whole percentages separated by commas")
+		return
+	}
+	for _, p := range percents {
+		if p < 1 || p > 500 {
+			f.AddFieldError("thresholds", "thresholds must be between 1 and 500 percent")
+			return
+		}
+	}
+	f.CheckField(len(percents) <= 5,
+		"thresholds",
+		"at most 5 thresholds are allowed",
+	)
+}
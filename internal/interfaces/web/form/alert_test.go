@@ -0,0 +1,67 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThresholdsForm_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		form       ThresholdsForm
+		wantValid  bool
+		wantErrors map[string]string
+	}{
+		{
+			name:       "valid form",
+			form:       ThresholdsForm{Thresholds: "80%, 100"},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name:       "empty turns alerts off",
+			form:       ThresholdsForm{Thresholds: " "},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name:      "not a number",
+			form:      ThresholdsForm{Thresholds: "80, most"},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"thresholds": "thresholds must be whole percentages separated by commas",
+			},
+		},
+		{
+			name:      "out of range",
+			form:      ThresholdsForm{Thresholds: "0, 100"},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"thresholds": "thresholds must be between 1 and 500 percent",
+			},
+		},
+		{
+			name:      "too many",
+			form:      ThresholdsForm{Thresholds: "10, 20, 30, 40, 50, 60"},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"thresholds": "at most 5 thresholds are allowed",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.form.Validate()
+
+			assert.Equal(t, tt.wantValid, tt.form.IsValid())
+			assert.Equal(t, tt.wantErrors, tt.form.FieldErrors)
+		})
+	}
+}
+
+func TestThresholdsForm_ParsedThresholds(t *testing.T) {
+	f := ThresholdsForm{Thresholds: "100, 80%,"}
+	assert.Equal(t, []int{100, 80}, f.ParsedThresholds())
+}
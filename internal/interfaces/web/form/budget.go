@@ -0,0 +1,90 @@
+package form
+
+import (
+	"sort"
+	"strconv"
+)
+
+// ZeroBasedForm turns zero-based budgeting on or off.
+type ZeroBasedForm struct {
+	Enabled bool `form:"enabled"`
+	Base    `form:"-"`
+}
+
+func (f *ZeroBasedForm) Validate() {}
+
+// FillBudgetForm sets a category's budget to what it spent last month.
+type FillBudgetForm struct {
+	Month      string `form:"month"`
+	GroupID    string `form:"group-id"`
+	CategoryID string `form:"category-id"`
+	Base       `form:"-"`
+}
+
+func (f *FillBudgetForm) Validate() {
+	f.CheckField(ValidMonthString(f.Month),
+		"month",
+		"invalid month format",
+	)
+	f.CheckField(NotBlank(f.CategoryID),
+		"category-id",
+		"category is required",
+	)
+}
+
+// DistributeBudgetForm splits the income left to assign by percentage.
+// Percents are keyed by category ID; blank entries are left out.
+type DistributeBudgetForm struct {
+	Month    string            `form:"month"`
+	Percents map[string]string `form:"percent"`
+	Base     `form:"-"`
+}
+
+// CategoryIDs returns the IDs of the categories given a percentage, sorted so
+// that the shares are applied in a stable order.
+func (f *DistributeBudgetForm) CategoryIDs() []string {
+	ids := make([]string, 0, len(f.Percents))
+	for id, value := range f.Percents {
+		if NotBlank(value) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (f *DistributeBudgetForm) ParsedPercent(categoryID string) float64 {
+	val, _ := strconv.ParseFloat(f.Percents[categoryID], 64)
+	return val
+}
+
+func (f *DistributeBudgetForm) Validate() {
+	f.CheckField(ValidMonthString(f.Month),
+		"month",
+		"invalid month format",
+	)
+
+	ids := f.CategoryIDs()
+	if len(ids) == 0 {
+		f.AddNonFieldError("Enter a percentage for at least one category.")
+		return
+	}
+
+	var total float64
+	for _, id := range ids {
+		if !ValidFloat(f.Percents[id]) {
+			f.AddFieldError("percent-"+id, "percentage must be a number")
+			continue
+		}
+		percent := f.ParsedPercent(id)
+		f.CheckField(percent > 0,
+			"percent-"+id,
+			"percentage must be positive",
+		)
+		total += percent
+	}
+
+	if total > 100 {
+		f.AddNonFieldError("Percentages cannot add up to more than 100%.")
+	}
+}
@@ -0,0 +1,115 @@
+package form
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/go-playground/form/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFillBudgetForm_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		form       FillBudgetForm
+		wantValid  bool
+		wantErrors map[string]string
+	}{
+		{
+			name:       "valid form",
+			form:       FillBudgetForm{Month: "2024-03", GroupID: "g", CategoryID: "c"},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name:      "invalid month and missing category",
+			form:      FillBudgetForm{Month: "March"},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"month":       "invalid month format",
+				"category-id": "category is required",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.form.Validate()
+
+			assert.Equal(t, tt.wantValid, tt.form.IsValid())
+			assert.Equal(t, tt.wantErrors, tt.form.FieldErrors)
+		})
+	}
+}
+
+func TestDistributeBudgetForm_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		form          DistributeBudgetForm
+		wantValid     bool
+		wantErrors    map[string]string
+		wantNonFields []string
+	}{
+		{
+			name: "valid form",
+			form: DistributeBudgetForm{
+				Month:    "2024-03",
+				Percents: map[string]string{"a": "60", "b": "40", "c": ""},
+			},
+			wantValid: true,
+		},
+		{
+			name: "invalid percentages",
+			form: DistributeBudgetForm{
+				Month:    "2024-03",
+				Percents: map[string]string{"a": "abc", "b": "-5"},
+			},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"percent-a": "percentage must be a number",
+				"percent-b": "percentage must be positive",
+			},
+		},
+		{
+			name: "over 100 percent",
+			form: DistributeBudgetForm{
+				Month:    "2024-03",
+				Percents: map[string]string{"a": "70", "b": "40"},
+			},
+			wantValid:     false,
+			wantNonFields: []string{"Percentages cannot add up to more than 100%."},
+		},
+		{
+			name:          "no percentages",
+			form:          DistributeBudgetForm{Month: "2024-03", Percents: map[string]string{"a": ""}},
+			wantValid:     false,
+			wantNonFields: []string{"Enter a percentage for at least one category."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.form.Validate()
+
+			assert.Equal(t, tt.wantValid, tt.form.IsValid())
+			assert.Equal(t, tt.wantErrors, tt.form.FieldErrors)
+			assert.Equal(t, tt.wantNonFields, tt.form.NonFieldErrors)
+		})
+	}
+}
+
+func TestDistributeBudgetForm_Decode(t *testing.T) {
+	values := url.Values{
+		"month":      {"2024-03"},
+		"percent[b]": {"25"},
+		"percent[a]": {"50"},
+		"percent[c]": {""},
+	}
+
+	var f DistributeBudgetForm
+	require.NoError(t, form.NewDecoder().Decode(&f, values))
+
+	assert.Equal(t, []string{"a", "b"}, f.CategoryIDs())
+	assert.Equal(t, 50.0, f.ParsedPercent("a"))
+}
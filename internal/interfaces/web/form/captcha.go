@@ -0,0 +1,21 @@
+package form
+
+// Captcha is the proof-of-work challenge of a public form and the nonce the
+// browser solved it with. A form without a challenge asks for none.
+type Captcha struct {
+	Challenge  string `form:"captcha-challenge"`
+	Nonce      string `form:"captcha-nonce"`
+	Difficulty int    `form:"-"`
+}
+
+// CaptchaVerifier checks the solution of a proof-of-work challenge.
+type CaptchaVerifier interface {
+	Verify(challenge, nonce string) error
+}
+
+// CheckCaptcha adds an error to the form unless v accepts the solution of c.
+func (b *Base) CheckCaptcha(c Captcha, v CaptchaVerifier) {
+	if err := v.Verify(c.Challenge, c.Nonce); err != nil {
+		b.AddNonFieldError("The browser check did not pass or has expired. Please try again.")
+	}
+}
@@ -0,0 +1,33 @@
+package form
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubCaptchaVerifier struct {
+	err error
+}
+
+func (s stubCaptchaVerifier) Verify(string, string) error {
+	return s.err
+}
+
+func TestBase_CheckCaptcha(t *testing.T) {
+	t.Run("solved", func(t *testing.T) {
+		f := RegisterForm{Captcha: Captcha{Challenge: "challenge", Nonce: "42"}}
+		f.CheckCaptcha(f.Captcha, stubCaptchaVerifier{})
+
+		assert.True(t, f.IsValid())
+	})
+
+	t.Run("not solved", func(t *testing.T) {
+		f := RegisterForm{Captcha: Captcha{Challenge: "challenge"}}
+		f.CheckCaptcha(f.Captcha, stubCaptchaVerifier{err: errors.New("captcha challenge is not solved")})
+
+		assert.False(t, f.IsValid())
+		assert.Len(t, f.NonFieldErrors, 1)
+	})
+}
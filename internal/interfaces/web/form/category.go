@@ -4,6 +4,7 @@ import "strconv"
 
 type CreateCategoryForm struct {
 	GroupID     string `form:"group-id"`
+	ParentID    string `form:"parent-id"`
 	Name        string `form:"category-name"`
 	Description string `form:"category-desc"`
 	Type        string `form:"type"`
@@ -147,3 +148,60 @@ func (f *UpdateCategoryForm) Validate() {
 		}
 	}
 }
+
+type TransferCategoryForm struct {
+	GroupID          string `form:"group-id"`
+	CategoryID       string `form:"category-id"`
+	Action           string `form:"transfer-action"`
+	TargetGroupID    string `form:"target-group-id"`
+	TargetCategoryID string `form:"target-category-id"`
+	Base             `form:"-"`
+}
+
+func (f *TransferCategoryForm) Validate() {
+	f.CheckField(NotBlank(f.GroupID),
+		"group-id",
+		"group ID is required",
+	)
+	f.CheckField(NotBlank(f.CategoryID),
+		"category-id",
+		"category ID is required",
+	)
+	f.CheckField(PermittedValue(f.Action, "move", "merge"),
+		"transfer-action",
+		"invalid action",
+	)
+	if f.Action == "move" {
+		f.CheckField(NotBlank(f.TargetGroupID),
+			"target-group-id",
+			"please choose a group",
+		)
+	}
+	if f.Action == "merge" {
+		f.CheckField(NotBlank(f.TargetCategoryID),
+			"target-category-id",
+			"please choose a category",
+		)
+	}
+}
+
+type DeleteCategoryForm struct {
+	GroupID          string `form:"group-id"`
+	CategoryID       string `form:"category-id"`
+	Month            string `form:"current-month"`
+	Mode             string `form:"delete-mode"`
+	TargetCategoryID string `form:"target-category-id"`
+	Base             `form:"-"`
+}
+
+func (f *DeleteCategoryForm) Validate() {
+	f.CheckField(NotBlank(f.GroupID),
+		"group-id",
+		"group ID is required",
+	)
+	f.CheckField(NotBlank(f.CategoryID),
+		"category-id",
+		"category ID is required",
+	)
+	validateDeleteMode(&f.Base, f.Mode, f.TargetCategoryID, f.Month)
+}
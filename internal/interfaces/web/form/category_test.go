@@ -90,4 +90,123 @@ func TestCreateCategoryForm_Validate(t *testing.T) {
 			assert.Equal(t, tt.wantErrors, tt.form.FieldErrors)
 		})
 	}
-}
\ No newline at end of file
+}
+func TestTransferCategoryForm_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		form       TransferCategoryForm
+		wantValid  bool
+		wantErrors map[string]string
+	}{
+		{
+			name: "valid move",
+			form: TransferCategoryForm{
+				GroupID:       "g1",
+				CategoryID:    "c1",
+				Action:        "move",
+				TargetGroupID: "g2",
+			},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name: "valid merge",
+			form: TransferCategoryForm{
+				GroupID:          "g1",
+				CategoryID:       "c1",
+				Action:           "merge",
+				TargetCategoryID: "c2",
+			},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name: "move without target group",
+			form: TransferCategoryForm{
+				GroupID:          "g1",
+				CategoryID:       "c1",
+				Action:           "move",
+				TargetCategoryID: "c2",
+			},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"target-group-id": "please choose a group",
+			},
+		},
+		{
+			name: "merge without target category",
+			form: TransferCategoryForm{
+				GroupID:    "g1",
+				CategoryID: "c1",
+				Action:     "merge",
+			},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"target-category-id": "please choose a category",
+			},
+		},
+		{
+			name: "invalid action",
+			form: TransferCategoryForm{
+				GroupID:    "g1",
+				CategoryID: "c1",
+				Action:     "copy",
+			},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"transfer-action": "invalid action",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.form.Validate()
+
+			assert.Equal(t, tt.wantValid, tt.form.IsValid())
+			assert.Equal(t, tt.wantErrors, tt.form.FieldErrors)
+		})
+	}
+}
+
+func TestDeleteCategoryForm_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		form       DeleteCategoryForm
+		wantValid  bool
+		wantErrors map[string]string
+	}{
+		{
+			name:       "valid end",
+			form:       DeleteCategoryForm{GroupID: "g1", CategoryID: "c1", Mode: "end", Month: "2024-05"},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name:      "missing ids",
+			form:      DeleteCategoryForm{Mode: "hard"},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"group-id":    "group ID is required",
+				"category-id": "category ID is required",
+			},
+		},
+		{
+			name:      "invalid mode",
+			form:      DeleteCategoryForm{GroupID: "g1", CategoryID: "c1", Mode: "wipe"},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"delete-mode": "please choose what happens to the expenses",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.form.Validate()
+
+			assert.Equal(t, tt.wantValid, tt.form.IsValid())
+			assert.Equal(t, tt.wantErrors, tt.form.FieldErrors)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package form
+
+// CloseMonthForm closes a month. AcceptUnpaid confirms that the month may be
+// closed while some of its expenses are still unpaid.
+type CloseMonthForm struct {
+	Month        string `form:"month"`
+	AcceptUnpaid bool   `form:"accept-unpaid"`
+	Base         `form:"-"`
+}
+
+func (f *CloseMonthForm) Validate() {
+	f.CheckField(ValidMonthString(f.Month),
+		"month",
+		"invalid month format",
+	)
+}
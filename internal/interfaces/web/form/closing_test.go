@@ -0,0 +1,56 @@
+package form
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/go-playground/form/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloseMonthForm_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		form       CloseMonthForm
+		wantValid  bool
+		wantErrors map[string]string
+	}{
+		{
+			name:       "valid form",
+			form:       CloseMonthForm{Month: "2024-03", AcceptUnpaid: true},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name:      "invalid month",
+			form:      CloseMonthForm{Month: "March"},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"month": "invalid month format",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.form.Validate()
+
+			assert.Equal(t, tt.wantValid, tt.form.IsValid())
+			assert.Equal(t, tt.wantErrors, tt.form.FieldErrors)
+		})
+	}
+}
+
+func TestCloseMonthForm_Decode(t *testing.T) {
+	values := url.Values{
+		"month":         {"2024-03"},
+		"accept-unpaid": {"true"},
+	}
+
+	var f CloseMonthForm
+	require.NoError(t, form.NewDecoder().Decode(&f, values))
+
+	assert.Equal(t, "2024-03", f.Month)
+	assert.True(t, f.AcceptUnpaid)
+}
@@ -1,14 +1,25 @@
 package form
 
-import "strconv"
+import (
+	"strconv"
+	"strings"
+	"time"
+)
 
+// CreateExpenseForm adds an expense to Month. Date is the day it was spent
+// or is due; left blank, the expense is dated the first of the month.
+// ChooseCategory is set when the category is picked in the form rather than
+// fixed by where the form was opened, as from the bill calendar.
 type CreateExpenseForm struct {
-	CategoryID    string `form:"category-id"`
-	Amount        string `form:"expense-amount"`
-	Description   string `form:"expense-desc"`
-	Month         string `form:"month"`
-	PaymentStatus string `form:"payment-status"`
-	Base          `form:"-"`
+	CategoryID     string `form:"category-id"`
+	Amount         string `form:"expense-amount"`
+	Description    string `form:"expense-desc"`
+	Month          string `form:"month"`
+	Date           string `form:"expense-date"`
+	PaymentStatus  string `form:"payment-status"`
+	AccountID      string `form:"account-id"`
+	ChooseCategory bool   `form:"choose-category"`
+	Base           `form:"-"`
 }
 
 func (f *CreateExpenseForm) ParsedAmount() float64 {
@@ -16,6 +27,17 @@ func (f *CreateExpenseForm) ParsedAmount() float64 {
 	return val
 }
 
+// ParsedSpentAt is the date of the expense, or the first of the month when
+// no date was given.
+func (f *CreateExpenseForm) ParsedSpentAt() time.Time {
+	if f.Date != "" {
+		val, _ := time.Parse("2006-01-02", f.Date)
+		return val
+	}
+	val, _ := time.Parse("2006-01", f.Month)
+	return val
+}
+
 func (f *CreateExpenseForm) Validate() {
 	f.CheckField(NotBlank(f.CategoryID),
 		"category-id",
@@ -37,6 +59,12 @@ func (f *CreateExpenseForm) Validate() {
 		"month",
 		"invalid month format",
 	)
+	if f.Date != "" {
+		f.CheckField(ValidDateString(f.Date) && strings.HasPrefix(f.Date, f.Month+"-"),
+			"expense-date",
+			"date must be in the month",
+		)
+	}
 	f.CheckField(PermittedValue(f.PaymentStatus, "paid", "unpaid"),
 		"payment-status",
 		"invalid status",
@@ -49,6 +77,7 @@ type UpdateExpenseForm struct {
 	Amount        string `form:"edit-amount"`
 	Description   string `form:"edit-desc"`
 	PaymentStatus string `form:"payment-status"`
+	AccountID     string `form:"account-id"`
 	Base          `form:"-"`
 }
 
@@ -2,6 +2,7 @@ package form
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -74,6 +75,32 @@ func TestCreateExpenseForm_Validate(t *testing.T) {
 				"month": "invalid month format",
 			},
 		},
+		{
+			name: "valid expense on a day",
+			form: CreateExpenseForm{
+				CategoryID:    "cat-123",
+				Amount:        "10.00",
+				Month:         "2023-10",
+				Date:          "2023-10-27",
+				PaymentStatus: "unpaid",
+			},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name: "date outside the month",
+			form: CreateExpenseForm{
+				CategoryID:    "cat-123",
+				Amount:        "10.00",
+				Month:         "2023-10",
+				Date:          "2023-11-01",
+				PaymentStatus: "unpaid",
+			},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"expense-date": "date must be in the month",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -86,6 +113,14 @@ func TestCreateExpenseForm_Validate(t *testing.T) {
 	}
 }
 
+func TestCreateExpenseForm_ParsedSpentAt(t *testing.T) {
+	f := CreateExpenseForm{Month: "2023-10"}
+	assert.Equal(t, time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC), f.ParsedSpentAt())
+
+	f.Date = "2023-10-27"
+	assert.Equal(t, time.Date(2023, time.October, 27, 0, 0, 0, 0, time.UTC), f.ParsedSpentAt())
+}
+
 func TestUpdateExpenseForm_Validate(t *testing.T) {
 	tests := []struct {
 		name       string
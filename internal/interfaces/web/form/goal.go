@@ -0,0 +1,81 @@
+package form
+
+import (
+	"strconv"
+	"time"
+)
+
+// GoalForm creates a savings goal starting in Month, or updates the goal with
+// ID when it is set.
+type GoalForm struct {
+	ID         string `form:"goal-id"`
+	Month      string `form:"month"`
+	Name       string `form:"goal-name"`
+	Target     string `form:"goal-target"`
+	TargetDate string `form:"goal-date"`
+	Base       `form:"-"`
+}
+
+func (f *GoalForm) ParsedTarget() float64 {
+	val, _ := strconv.ParseFloat(f.Target, 64)
+	return val
+}
+
+func (f *GoalForm) ParsedTargetDate() time.Time {
+	val, _ := time.Parse("2006-01-02", f.TargetDate)
+	return val
+}
+
+func (f *GoalForm) Validate() {
+	f.CheckField(ValidMonthString(f.Month),
+		"month",
+		"invalid month format",
+	)
+	f.CheckField(NotBlank(f.Name),
+		"goal-name",
+		"this field is required",
+	)
+	f.CheckField(MaxChars(f.Name, 100),
+		"goal-name",
+		"name must be at most 100 characters long",
+	)
+	if !ValidFloat(f.Target) {
+		f.AddFieldError("goal-target", "target must be a number")
+	} else {
+		f.CheckField(PositiveFloat(f.ParsedTarget()),
+			"goal-target",
+			"target must be greater than 0",
+		)
+	}
+	f.CheckField(ValidDateString(f.TargetDate),
+		"goal-date",
+		"invalid date",
+	)
+}
+
+// ContributeGoalForm puts money aside for a goal in a month.
+type ContributeGoalForm struct {
+	Month  string `form:"month"`
+	Amount string `form:"contribution-amount"`
+	Base   `form:"-"`
+}
+
+func (f *ContributeGoalForm) ParsedAmount() float64 {
+	val, _ := strconv.ParseFloat(f.Amount, 64)
+	return val
+}
+
+func (f *ContributeGoalForm) Validate() {
+	f.CheckField(ValidMonthString(f.Month),
+		"month",
+		"invalid month format",
+	)
+	if !ValidFloat(f.Amount) {
+		f.AddFieldError("contribution-amount", "amount must be a number")
+	} else {
+		f.CheckField(PositiveFloat(f.ParsedAmount()),
+			"contribution-amount",
+			"amount must be greater than 0",
+		)
+	}
+}
@@ -0,0 +1,114 @@
+package form
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-playground/form/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoalForm_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		form       GoalForm
+		wantValid  bool
+		wantErrors map[string]string
+	}{
+		{
+			name:       "valid form",
+			form:       GoalForm{Month: "2024-03", Name: "Emergency fund", Target: "5000", TargetDate: "2025-03-01"},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name:      "missing fields",
+			form:      GoalForm{Month: "March"},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"month":       "invalid month format",
+				"goal-name":   "this field is required",
+				"goal-target": "target must be a number",
+				"goal-date":   "invalid date",
+			},
+		},
+		{
+			name:      "non-positive target",
+			form:      GoalForm{Month: "2024-03", Name: "Car", Target: "0", TargetDate: "2025-03-01"},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"goal-target": "target must be greater than 0",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.form.Validate()
+
+			assert.Equal(t, tt.wantValid, tt.form.IsValid())
+			assert.Equal(t, tt.wantErrors, tt.form.FieldErrors)
+		})
+	}
+}
+
+func TestGoalForm_Decode(t *testing.T) {
+	values := url.Values{
+		"goal-id":     {"g"},
+		"month":       {"2024-03"},
+		"goal-name":   {"Car"},
+		"goal-target": {"12000.50"},
+		"goal-date":   {"2026-06-30"},
+	}
+
+	var f GoalForm
+	require.NoError(t, form.NewDecoder().Decode(&f, values))
+
+	assert.Equal(t, "g", f.ID)
+	assert.Equal(t, 12000.50, f.ParsedTarget())
+	assert.Equal(t, time.Date(2026, time.June, 30, 0, 0, 0, 0, time.UTC), f.ParsedTargetDate())
+}
+
+func TestContributeGoalForm_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		form       ContributeGoalForm
+		wantValid  bool
+		wantErrors map[string]string
+	}{
+		{
+			name:       "valid form",
+			form:       ContributeGoalForm{Month: "2024-03", Amount: "150"},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name:      "invalid amount",
+			form:      ContributeGoalForm{Month: "2024-03", Amount: "abc"},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"contribution-amount": "amount must be a number",
+			},
+		},
+		{
+			name:      "negative amount and invalid month",
+			form:      ContributeGoalForm{Month: "", Amount: "-5"},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"month":               "invalid month format",
+				"contribution-amount": "amount must be greater than 0",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.form.Validate()
+
+			assert.Equal(t, tt.wantValid, tt.form.IsValid())
+			assert.Equal(t, tt.wantErrors, tt.form.FieldErrors)
+		})
+	}
+}
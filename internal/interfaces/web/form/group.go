@@ -56,3 +56,39 @@ func (f *UpdateGroupForm) Validate() {
 		"order must be non-negative",
 	)
 }
+
+type DeleteGroupForm struct {
+	ID               string `form:"group-id"`
+	Month            string `form:"current-month"`
+	Mode             string `form:"delete-mode"`
+	TargetCategoryID string `form:"target-category-id"`
+	Base             `form:"-"`
+}
+
+func (f *DeleteGroupForm) Validate() {
+	f.CheckField(NotBlank(f.ID),
+		"group-id",
+		"group ID is required",
+	)
+	validateDeleteMode(&f.Base, f.Mode, f.TargetCategoryID, f.Month)
+}
+
+// validateDeleteMode checks the fields each delete option depends on.
+func validateDeleteMode(b *Base, mode string, targetCategoryID string, month string) {
+	b.CheckField(PermittedValue(mode, "hard", "reassign", "end"),
+		"delete-mode",
+		"please choose what happens to the expenses",
+	)
+	if mode == "reassign" {
+		b.CheckField(NotBlank(targetCategoryID),
+			"target-category-id",
+			"please choose a category",
+		)
+	}
+	if mode == "end" {
+		b.CheckField(ValidMonthString(month),
+			"current-month",
+			"invalid month format",
+		)
+	}
+}
@@ -97,3 +97,72 @@ func TestUpdateGroupForm_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteGroupForm_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		form       DeleteGroupForm
+		wantValid  bool
+		wantErrors map[string]string
+	}{
+		{
+			name:       "valid hard delete",
+			form:       DeleteGroupForm{ID: "g1", Mode: "hard"},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name:       "valid reassign",
+			form:       DeleteGroupForm{ID: "g1", Mode: "reassign", TargetCategoryID: "c1"},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name:       "valid end",
+			form:       DeleteGroupForm{ID: "g1", Mode: "end", Month: "2024-05"},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name:      "missing mode",
+			form:      DeleteGroupForm{ID: "g1"},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"delete-mode": "please choose what happens to the expenses",
+			},
+		},
+		{
+			name:      "reassign without target",
+			form:      DeleteGroupForm{ID: "g1", Mode: "reassign"},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"target-category-id": "please choose a category",
+			},
+		},
+		{
+			name:      "end with invalid month",
+			form:      DeleteGroupForm{ID: "g1", Mode: "end", Month: "May"},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"current-month": "invalid month format",
+			},
+		},
+		{
+			name:      "missing group ID",
+			form:      DeleteGroupForm{Mode: "hard"},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"group-id": "group ID is required",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.form.Validate()
+
+			assert.Equal(t, tt.wantValid, tt.form.IsValid())
+			assert.Equal(t, tt.wantErrors, tt.form.FieldErrors)
+		})
+	}
+}
@@ -6,6 +6,7 @@ type CreateIncomeForm struct {
 	Amount       string `form:"income-amount"`
 	Description  string `form:"income-desc"`
 	CurrentMonth string `form:"current-month"`
+	AccountID    string `form:"account-id"`
 	Base         `form:"-"`
 }
 
@@ -0,0 +1,111 @@
+package form
+
+import "strconv"
+
+// LoanForm creates a loan, or updates the loan with ID when it is set.
+// CategoryID is the category its monthly payments are recorded in.
+type LoanForm struct {
+	ID         string `form:"loan-id"`
+	Name       string `form:"loan-name"`
+	Principal  string `form:"loan-principal"`
+	Rate       string `form:"loan-rate"`
+	Term       string `form:"loan-term"`
+	StartMonth string `form:"loan-start"`
+	CategoryID string `form:"loan-category"`
+	Base       `form:"-"`
+}
+
+func (f *LoanForm) ParsedPrincipal() float64 {
+	val, _ := strconv.ParseFloat(f.Principal, 64)
+	return val
+}
+
+func (f *LoanForm) ParsedRate() float64 {
+	val, _ := strconv.ParseFloat(f.Rate, 64)
+	return val
+}
+
+func (f *LoanForm) ParsedTerm() int {
+	val, _ := strconv.Atoi(f.Term)
+	return val
+}
+
+func (f *LoanForm) Validate() {
+	f.CheckField(NotBlank(f.Name),
+		"loan-name",
+		"this field is required",
+	)
+	f.CheckField(MaxChars(f.Name, 100),
+		"loan-name",
+		"name must be at most 100 characters long",
+	)
+	if !ValidFloat(f.Principal) {
+		f.AddFieldError("loan-principal", "principal must be a number")
+	} else {
+		f.CheckField(PositiveFloat(f.ParsedPrincipal()),
+			"loan-principal",
+			"principal must be greater than 0",
+		)
+	}
+	if !ValidFloat(f.Rate) {
+		f.AddFieldError("loan-rate", "rate must be a number")
+	} else {
+		f.CheckField(f.ParsedRate() >= 0 && f.ParsedRate() <= 50,
+			"loan-rate",
+			"rate must be between 0 and 50",
+		)
+	}
+	if !Number(f.Term) {
+		f.AddFieldError("loan-term", "term must be a whole number of months")
+	} else {
+		f.CheckField(PositiveNumber(f.ParsedTerm()) && MaxNumber(f.ParsedTerm(), 600),
+			"loan-term",
+			"term must be between 1 and 600 months",
+		)
+	}
+	f.CheckField(ValidMonthString(f.StartMonth),
+		"loan-start",
+		"invalid month format",
+	)
+}
+
+// LoanSimulationForm asks what paying extra towards a loan would save:
+// ExtraMonthly on top of every payment and LumpSum once in LumpSumMonth.
+// Blank amounts count as zero.
+type LoanSimulationForm struct {
+	ExtraMonthly string `form:"extra-monthly"`
+	LumpSum      string `form:"lump-sum"`
+	LumpSumMonth string `form:"lump-sum-month"`
+	Base         `form:"-"`
+}
+
+func (f *LoanSimulationForm) ParsedExtraMonthly() float64 {
+	val, _ := strconv.ParseFloat(f.ExtraMonthly, 64)
+	return val
+}
+
+func (f *LoanSimulationForm) ParsedLumpSum() float64 {
+	val, _ := strconv.ParseFloat(f.LumpSum, 64)
+	return val
+}
+
+func (f *LoanSimulationForm) Validate() {
+	if f.ExtraMonthly != "" {
+		f.CheckField(ValidFloat(f.ExtraMonthly) && f.ParsedExtraMonthly() >= 0,
+			"extra-monthly",
+			"extra payment must be a number of at least 0",
+		)
+	}
+	if f.LumpSum != "" {
+		f.CheckField(ValidFloat(f.LumpSum) && f.ParsedLumpSum() >= 0,
+			"lump-sum",
+			"lump sum must be a number of at least 0",
+		)
+	}
+	if f.ParsedLumpSum() > 0 {
+		f.CheckField(ValidMonthString(f.LumpSumMonth),
+			"lump-sum-month",
+			"invalid month format",
+		)
+	}
+}
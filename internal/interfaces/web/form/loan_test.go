@@ -0,0 +1,116 @@
+package form
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/go-playground/form/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoanForm_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		form       LoanForm
+		wantValid  bool
+		wantErrors map[string]string
+	}{
+		{
+			name:       "valid form",
+			form:       LoanForm{Name: "Mortgage", Principal: "200000", Rate: "6.5", Term: "360", StartMonth: "2024-01"},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name:      "missing fields",
+			form:      LoanForm{},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"loan-name":      "this field is required",
+				"loan-principal": "principal must be a number",
+				"loan-rate":      "rate must be a number",
+				"loan-term":      "term must be a whole number of months",
+				"loan-start":     "invalid month format",
+			},
+		},
+		{
+			name:      "out of range values",
+			form:      LoanForm{Name: "Car", Principal: "0", Rate: "75", Term: "601", StartMonth: "2024-01"},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"loan-principal": "principal must be greater than 0",
+				"loan-rate":      "rate must be between 0 and 50",
+				"loan-term":      "term must be between 1 and 600 months",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.form.Validate()
+
+			assert.Equal(t, tt.wantValid, tt.form.IsValid())
+			assert.Equal(t, tt.wantErrors, tt.form.FieldErrors)
+		})
+	}
+}
+
+func TestLoanForm_Decode(t *testing.T) {
+	values := url.Values{
+		"loan-name":      {"Car"},
+		"loan-principal": {"20000.50"},
+		"loan-rate":      {"4.9"},
+		"loan-term":      {"60"},
+		"loan-start":     {"2024-03"},
+		"loan-category":  {"c"},
+	}
+
+	var f LoanForm
+	require.NoError(t, form.NewDecoder().Decode(&f, values))
+
+	assert.Equal(t, 20000.50, f.ParsedPrincipal())
+	assert.Equal(t, 4.9, f.ParsedRate())
+	assert.Equal(t, 60, f.ParsedTerm())
+	assert.Equal(t, "c", f.CategoryID)
+}
+
+func TestLoanSimulationForm_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		form       LoanSimulationForm
+		wantValid  bool
+		wantErrors map[string]string
+	}{
+		{
+			name:       "blank amounts",
+			form:       LoanSimulationForm{},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name:       "extra and lump sum",
+			form:       LoanSimulationForm{ExtraMonthly: "100", LumpSum: "5000", LumpSumMonth: "2025-06"},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name:      "negative extra and lump sum without month",
+			form:      LoanSimulationForm{ExtraMonthly: "-1", LumpSum: "5000"},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"extra-monthly":  "extra payment must be a number of at least 0",
+				"lump-sum-month": "invalid month format",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.form.Validate()
+
+			assert.Equal(t, tt.wantValid, tt.form.IsValid())
+			assert.Equal(t, tt.wantErrors, tt.form.FieldErrors)
+		})
+	}
+}
@@ -1,9 +1,10 @@
 package form
 
 type LoginForm struct {
-	Email    string `form:"email"`
-	Password string `form:"password"`
-	Base     `form:"-"`
+	Email      string `form:"email"`
+	Password   string `form:"password"`
+	RememberMe bool   `form:"remember"`
+	Base       `form:"-"`
 }
 
 func (f *LoginForm) Validate() {
@@ -20,3 +21,18 @@ func (f *LoginForm) Validate() {
 		"this field is required",
 	)
 }
+
+// ReauthForm asks a logged-in user for the password again before a sensitive
+// action, then goes back to Next.
+type ReauthForm struct {
+	Password string `form:"password"`
+	Next     string `form:"next"`
+	Base     `form:"-"`
+}
+
+func (f *ReauthForm) Validate() {
+	f.CheckField(NotBlank(f.Password),
+		"password",
+		"this field is required",
+	)
+}
@@ -67,3 +67,14 @@ func TestLoginForm_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestReauthForm_Validate(t *testing.T) {
+	valid := ReauthForm{Password: "secret", Next: "/profile"}
+	valid.Validate()
+	assert.True(t, valid.IsValid())
+
+	missing := ReauthForm{Next: "/profile"}
+	missing.Validate()
+	assert.False(t, missing.IsValid())
+	assert.Equal(t, "this field is required", missing.FieldErrors["password"])
+}
@@ -0,0 +1,54 @@
+package form
+
+import "strconv"
+
+// AssetForm creates an asset entered by hand, or updates the asset with ID
+// when it is set. Debts are entered with the "debt" kind.
+type AssetForm struct {
+	ID   string `form:"asset-id"`
+	Name string `form:"asset-name"`
+	Kind string `form:"asset-kind"`
+	Base `form:"-"`
+}
+
+func (f *AssetForm) Validate() {
+	f.CheckField(NotBlank(f.Name),
+		"asset-name",
+		"this field is required",
+	)
+	f.CheckField(MaxChars(f.Name, 100),
+		"asset-name",
+		"name must be at most 100 characters long",
+	)
+	f.CheckField(PermittedValue(f.Kind, "investment", "property", "vehicle", "other", "debt"),
+		"asset-kind",
+		"invalid asset kind",
+	)
+}
+
+// ValuationForm records what an asset was worth at the end of Month.
+type ValuationForm struct {
+	Month string `form:"valuation-month"`
+	Value string `form:"valuation-value"`
+	Base  `form:"-"`
+}
+
+func (f *ValuationForm) ParsedValue() float64 {
+	val, _ := strconv.ParseFloat(f.Value, 64)
+	return val
+}
+
+func (f *ValuationForm) Validate() {
+	f.CheckField(ValidMonthString(f.Month),
+		"valuation-month",
+		"invalid month format",
+	)
+	if !ValidFloat(f.Value) {
+		f.AddFieldError("valuation-value", "value must be a number")
+	} else {
+		f.CheckField(f.ParsedValue() >= 0,
+			"valuation-value",
+			"value must be at least 0",
+		)
+	}
+}
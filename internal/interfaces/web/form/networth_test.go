@@ -0,0 +1,89 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssetForm_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		form       AssetForm
+		wantValid  bool
+		wantErrors map[string]string
+	}{
+		{
+			name:       "valid form",
+			form:       AssetForm{Name: "House", Kind: "property"},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name:      "missing name and unknown kind",
+			form:      AssetForm{Kind: "boat"},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"asset-name": "this field is required",
+				"asset-kind": "invalid asset kind",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.form.Validate()
+
+			assert.Equal(t, tt.wantValid, tt.form.IsValid())
+			assert.Equal(t, tt.wantErrors, tt.form.FieldErrors)
+		})
+	}
+}
+
+func TestValuationForm_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		form       ValuationForm
+		wantValid  bool
+		wantErrors map[string]string
+	}{
+		{
+			name:       "valid form",
+			form:       ValuationForm{Month: "2024-03", Value: "250000.50"},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name:       "zero value",
+			form:       ValuationForm{Month: "2024-03", Value: "0"},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name:      "negative value and bad month",
+			form:      ValuationForm{Month: "March", Value: "-5"},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"valuation-month": "invalid month format",
+				"valuation-value": "value must be at least 0",
+			},
+		},
+		{
+			name:      "value not a number",
+			form:      ValuationForm{Month: "2024-03", Value: "lots"},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"valuation-value": "value must be a number",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.form.Validate()
+
+			assert.Equal(t, tt.wantValid, tt.form.IsValid())
+			assert.Equal(t, tt.wantErrors, tt.form.FieldErrors)
+		})
+	}
+}
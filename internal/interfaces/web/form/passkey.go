@@ -0,0 +1,48 @@
+package form
+
+import (
+	"encoding/json"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/platform/webauthn"
+)
+
+// PasskeyForm names a new passkey and carries the credential the browser
+// created, as JSON.
+type PasskeyForm struct {
+	Name       string `form:"name"`
+	Credential string `form:"credential"`
+	Base       `form:"-"`
+
+	Registration webauthn.RegistrationResponse `form:"-"`
+}
+
+func (f *PasskeyForm) Validate() {
+	f.CheckField(NotBlank(f.Name),
+		"name",
+		"this field is required",
+	)
+	f.CheckField(MaxChars(f.Name, identity.PasskeyNameMaxLength),
+		"name",
+		"this field cannot be more than 64 characters long",
+	)
+	if err := json.Unmarshal([]byte(f.Credential), &f.Registration); err != nil {
+		f.AddNonFieldError("The passkey could not be read. Please try again.")
+	}
+}
+
+// PasskeyLoginForm carries the assertion the browser signed with a passkey,
+// as JSON.
+type PasskeyLoginForm struct {
+	Credential string `form:"credential"`
+	RememberMe bool   `form:"remember"`
+	Base       `form:"-"`
+
+	Assertion webauthn.AssertionResponse `form:"-"`
+}
+
+func (f *PasskeyLoginForm) Validate() {
+	if err := json.Unmarshal([]byte(f.Credential), &f.Assertion); err != nil {
+		f.AddNonFieldError("The passkey could not be read. Please try again.")
+	}
+}
@@ -0,0 +1,60 @@
+package form
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasskeyForm_Validate(t *testing.T) {
+	t.Run("valid form", func(t *testing.T) {
+		f := PasskeyForm{
+			Name:       "Laptop",
+			Credential: `{"id":"AQI","rawId":"AQI","type":"public-key","response":{"clientDataJSON":"e30","attestationObject":"oA"}}`,
+		}
+
+		f.Validate()
+
+		assert.True(t, f.IsValid())
+		assert.Equal(t, []byte{1, 2}, []byte(f.Registration.RawID))
+		assert.Equal(t, []byte("{}"), []byte(f.Registration.Response.ClientDataJSON))
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		f := PasskeyForm{Name: strings.Repeat("a", 65), Credential: `{}`}
+
+		f.Validate()
+
+		assert.False(t, f.IsValid())
+		assert.Equal(t, "this field cannot be more than 64 characters long", f.FieldErrors["name"])
+	})
+
+	t.Run("unreadable credential", func(t *testing.T) {
+		f := PasskeyForm{Name: "Laptop", Credential: `{"rawId":"not base64url!"}`}
+
+		f.Validate()
+
+		assert.False(t, f.IsValid())
+		assert.NotEmpty(t, f.NonFieldErrors)
+	})
+}
+
+func TestPasskeyLoginForm_Validate(t *testing.T) {
+	t.Run("valid form", func(t *testing.T) {
+		f := PasskeyLoginForm{Credential: `{"rawId":"AQI","response":{"signature":"AQ","userHandle":"dQ"}}`}
+
+		f.Validate()
+
+		assert.True(t, f.IsValid())
+		assert.Equal(t, []byte("u"), []byte(f.Assertion.Response.UserHandle))
+	})
+
+	t.Run("missing credential", func(t *testing.T) {
+		f := PasskeyLoginForm{}
+
+		f.Validate()
+
+		assert.False(t, f.IsValid())
+	})
+}
@@ -0,0 +1,43 @@
+package form
+
+// ForgotPasswordForm asks for a link to reset the password of an account.
+type ForgotPasswordForm struct {
+	Email string `form:"email"`
+	Captcha
+	Base `form:"-"`
+}
+
+func (f *ForgotPasswordForm) Validate() {
+	f.CheckField(NotBlank(f.Email),
+		"email",
+		"this field is required",
+	)
+	f.CheckField(Matches(f.Email, MailRX),
+		"email",
+		"please enter a valid e-mail address",
+	)
+}
+
+// ResetPasswordForm sets a new password with the token of a reset link.
+type ResetPasswordForm struct {
+	Token           string `form:"token"`
+	NewPassword     string `form:"new-password"`
+	ConfirmPassword string `form:"confirm-password"`
+	Captcha
+	Base `form:"-"`
+}
+
+func (f *ResetPasswordForm) Validate() {
+	f.CheckField(NotBlank(f.NewPassword),
+		"new-password",
+		"this field is required",
+	)
+	f.CheckField(MinChars(f.NewPassword, 8),
+		"new-password",
+		"password must be at least 8 characters long",
+	)
+	f.CheckField(Equal(f.NewPassword, f.ConfirmPassword),
+		"confirm-password",
+		"passwords do not match",
+	)
+}
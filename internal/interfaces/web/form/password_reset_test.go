@@ -0,0 +1,78 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForgotPasswordForm_Validate(t *testing.T) {
+	t.Run("valid form", func(t *testing.T) {
+		f := ForgotPasswordForm{Email: "user@example.com"}
+
+		f.Validate()
+
+		assert.True(t, f.IsValid())
+	})
+
+	t.Run("invalid email format", func(t *testing.T) {
+		f := ForgotPasswordForm{Email: "not-an-email"}
+
+		f.Validate()
+
+		assert.False(t, f.IsValid())
+		assert.Equal(t, "please enter a valid e-mail address", f.FieldErrors["email"])
+	})
+}
+
+func TestResetPasswordForm_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		form       ResetPasswordForm
+		wantValid  bool
+		wantErrors map[string]string
+	}{
+		{
+			name: "valid form",
+			form: ResetPasswordForm{
+				Token:           "token",
+				NewPassword:     "new-password",
+				ConfirmPassword: "new-password",
+			},
+			wantValid: true,
+		},
+		{
+			name: "short password",
+			form: ResetPasswordForm{
+				Token:           "token",
+				NewPassword:     "short",
+				ConfirmPassword: "short",
+			},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"new-password": "password must be at least 8 characters long",
+			},
+		},
+		{
+			name: "passwords do not match",
+			form: ResetPasswordForm{
+				Token:           "token",
+				NewPassword:     "new-password",
+				ConfirmPassword: "other-password",
+			},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"confirm-password": "passwords do not match",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.form.Validate()
+
+			assert.Equal(t, tt.wantValid, tt.form.IsValid())
+			assert.Equal(t, tt.wantErrors, tt.form.FieldErrors)
+		})
+	}
+}
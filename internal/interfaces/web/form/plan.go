@@ -0,0 +1,57 @@
+package form
+
+import "strconv"
+
+// PlanMonthForm holds the plan-next-month wizard. Budgets are keyed by
+// category ID and only read for the selected categories.
+type PlanMonthForm struct {
+	FromMonth     string            `form:"from-month"`
+	AdjustPercent string            `form:"adjust-percent"`
+	Selected      []string          `form:"selected"`
+	Budgets       map[string]string `form:"budget"`
+	Base          `form:"-"`
+}
+
+func (f *PlanMonthForm) ParsedAdjustPercent() float64 {
+	val, _ := strconv.ParseFloat(f.AdjustPercent, 64)
+	return val
+}
+
+func (f *PlanMonthForm) ParsedBudget(categoryID string) float64 {
+	val, _ := strconv.ParseFloat(f.Budgets[categoryID], 64)
+	return val
+}
+
+func (f *PlanMonthForm) Validate() {
+	f.CheckField(ValidMonthString(f.FromMonth),
+		"from-month",
+		"invalid month format",
+	)
+
+	if f.AdjustPercent != "" {
+		if !ValidFloat(f.AdjustPercent) {
+			f.AddFieldError("adjust-percent", "adjustment must be a number")
+		} else {
+			f.CheckField(f.ParsedAdjustPercent() >= -100,
+				"adjust-percent",
+				"adjustment cannot be below -100%",
+			)
+		}
+	}
+
+	if len(f.Selected) == 0 {
+		f.AddNonFieldError("Select at least one category to carry over.")
+	}
+
+	for _, id := range f.Selected {
+		budget := f.Budgets[id]
+		if !ValidFloat(budget) {
+			f.AddFieldError("budget-"+id, "budget must be a number")
+			continue
+		}
+		f.CheckField(f.ParsedBudget(id) >= 0,
+			"budget-"+id,
+			"budget must be zero or positive",
+		)
+	}
+}
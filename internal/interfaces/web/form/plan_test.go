@@ -0,0 +1,91 @@
+package form
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/go-playground/form/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanMonthForm_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		form       PlanMonthForm
+		wantValid  bool
+		wantErrors map[string]string
+	}{
+		{
+			name: "valid form",
+			form: PlanMonthForm{
+				FromMonth:     "2024-03",
+				AdjustPercent: "-5",
+				Selected:      []string{"a"},
+				Budgets:       map[string]string{"a": "120.50", "b": "oops"},
+			},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name: "invalid month and adjustment",
+			form: PlanMonthForm{
+				FromMonth:     "March",
+				AdjustPercent: "-150",
+				Selected:      []string{"a"},
+				Budgets:       map[string]string{"a": "10"},
+			},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"from-month":     "invalid month format",
+				"adjust-percent": "adjustment cannot be below -100%",
+			},
+		},
+		{
+			name: "invalid selected budget",
+			form: PlanMonthForm{
+				FromMonth: "2024-03",
+				Selected:  []string{"a"},
+				Budgets:   map[string]string{"a": "-1"},
+			},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"budget-a": "budget must be zero or positive",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.form.Validate()
+
+			assert.Equal(t, tt.wantValid, tt.form.IsValid())
+			assert.Equal(t, tt.wantErrors, tt.form.FieldErrors)
+		})
+	}
+
+	t.Run("requires a selection", func(t *testing.T) {
+		f := PlanMonthForm{FromMonth: "2024-03"}
+		f.Validate()
+
+		assert.False(t, f.IsValid())
+		assert.Equal(t, []string{"Select at least one category to carry over."}, f.NonFieldErrors)
+	})
+}
+
+func TestPlanMonthForm_Decode(t *testing.T) {
+	values := url.Values{
+		"from-month":     {"2024-03"},
+		"adjust-percent": {"10"},
+		"selected":       {"a", "b"},
+		"budget[a]":      {"12.5"},
+		"budget[b]":      {"40"},
+	}
+
+	var f PlanMonthForm
+	require.NoError(t, form.NewDecoder().Decode(&f, values))
+
+	assert.Equal(t, []string{"a", "b"}, f.Selected)
+	assert.Equal(t, 12.5, f.ParsedBudget("a"))
+	assert.Equal(t, 10.0, f.ParsedAdjustPercent())
+}
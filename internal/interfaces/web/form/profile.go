@@ -0,0 +1,82 @@
+package form
+
+import "strings"
+
+// ProfileForm changes the email and username of the signed in user.
+type ProfileForm struct {
+	Email    string `form:"email"`
+	Username string `form:"username"`
+	Base     `form:"-"`
+}
+
+func (f *ProfileForm) Validate() {
+	f.CheckField(NotBlank(f.Email),
+		"email",
+		"this field is required",
+	)
+	f.CheckField(Matches(f.Email, MailRX),
+		"email",
+		"please enter a valid e-mail address",
+	)
+	f.CheckField(NotBlank(f.Username),
+		"username",
+		"this field is required",
+	)
+	f.CheckField(MinChars(f.Username, 3),
+		"username",
+		"username must be at least 3 characters long",
+	)
+	f.CheckField(MaxChars(f.Username, 30),
+		"username",
+		"username must be at most 30 characters long",
+	)
+	f.CheckField(UsernameCharsOnly(f.Username),
+		"username",
+		"username can only contain letters, numbers and underscores",
+	)
+}
+
+// CurrencyForm sets the currency amounts are shown in, as an ISO 4217 code.
+type CurrencyForm struct {
+	Currency string `form:"currency"`
+	Base     `form:"-"`
+}
+
+// ParsedCurrency returns the code in upper case.
+func (f *CurrencyForm) ParsedCurrency() string {
+	return strings.ToUpper(strings.TrimSpace(f.Currency))
+}
+
+func (f *CurrencyForm) Validate() {
+	f.CheckField(NotBlank(f.Currency),
+		"currency",
+		"this field is required",
+	)
+}
+
+// PasswordForm changes the password once the current one is confirmed.
+type PasswordForm struct {
+	CurrentPassword string `form:"current-password"`
+	NewPassword     string `form:"new-password"`
+	ConfirmPassword string `form:"confirm-password"`
+	Base            `form:"-"`
+}
+
+func (f *PasswordForm) Validate() {
+	f.CheckField(NotBlank(f.CurrentPassword),
+		"current-password",
+		"this field is required",
+	)
+	f.CheckField(NotBlank(f.NewPassword),
+		"new-password",
+		"this field is required",
+	)
+	f.CheckField(MinChars(f.NewPassword, 8),
+		"new-password",
+		"password must be at least 8 characters long",
+	)
+	f.CheckField(Equal(f.NewPassword, f.ConfirmPassword),
+		"confirm-password",
+		"passwords do not match",
+	)
+}
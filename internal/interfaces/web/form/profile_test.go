@@ -0,0 +1,64 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfileForm_Validate(t *testing.T) {
+	f := ProfileForm{Email: "user@example.com", Username: "test_user"}
+	f.Validate()
+	assert.True(t, f.IsValid())
+
+	f = ProfileForm{Email: "not-an-email", Username: "a b"}
+	f.Validate()
+	assert.Equal(t, "please enter a valid e-mail address", f.FieldErrors["email"])
+	assert.Equal(t, "username can only contain letters, numbers and underscores", f.FieldErrors["username"])
+}
+
+func TestCurrencyForm_ParsedCurrency(t *testing.T) {
+	f := CurrencyForm{Currency: " eur "}
+	f.Validate()
+	assert.True(t, f.IsValid())
+	assert.Equal(t, "EUR", f.ParsedCurrency())
+
+	f = CurrencyForm{}
+	f.Validate()
+	assert.Equal(t, "this field is required", f.FieldErrors["currency"])
+}
+
+func TestPasswordForm_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		form       PasswordForm
+		wantErrors map[string]string
+	}{
+		{
+			name:       "valid form",
+			form:       PasswordForm{CurrentPassword: "old-password", NewPassword: "new-password", ConfirmPassword: "new-password"},
+			wantErrors: nil,
+		},
+		{
+			name: "new password too short",
+			form: PasswordForm{CurrentPassword: "old-password", NewPassword: "short", ConfirmPassword: "short"},
+			wantErrors: map[string]string{
+				"new-password": "password must be at least 8 characters long",
+			},
+		},
+		{
+			name: "confirmation does not match",
+			form: PasswordForm{CurrentPassword: "old-password", NewPassword: "new-password", ConfirmPassword: "new-passwort"},
+			wantErrors: map[string]string{
+				"confirm-password": "passwords do not match",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.form.Validate()
+			assert.Equal(t, tt.wantErrors, tt.form.FieldErrors)
+		})
+	}
+}
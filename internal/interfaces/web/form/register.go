@@ -4,7 +4,12 @@ type RegisterForm struct {
 	Email    string `form:"email"`
 	Username string `form:"username"`
 	Password string `form:"password"`
-	Base     `form:"-"`
+	// InviteCode is asked for when InviteRequired, while registration is by
+	// invitation.
+	InviteCode     string `form:"invite"`
+	InviteRequired bool   `form:"-"`
+	Captcha
+	Base `form:"-"`
 }
 
 func (f *RegisterForm) Validate() {
@@ -40,4 +45,10 @@ func (f *RegisterForm) Validate() {
 		"password",
 		"password must be at least 8 characters long",
 	)
+	if f.InviteRequired {
+		f.CheckField(NotBlank(f.InviteCode),
+			"invite",
+			"this field is required",
+		)
+	}
 }
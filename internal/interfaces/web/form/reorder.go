@@ -0,0 +1,19 @@
+package form
+
+type ReorderForm struct {
+	IDs  []string `form:"ids"`
+	Base `form:"-"`
+}
+
+func (f *ReorderForm) Validate() {
+	f.CheckField(len(f.IDs) > 0,
+		"ids",
+		"at least one item is required",
+	)
+	for _, id := range f.IDs {
+		if !NotBlank(id) {
+			f.AddFieldError("ids", "item ID cannot be blank")
+			break
+		}
+	}
+}
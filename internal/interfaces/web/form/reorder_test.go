@@ -0,0 +1,60 @@
+package form
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/go-playground/form/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReorderForm_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		form       ReorderForm
+		wantValid  bool
+		wantErrors map[string]string
+	}{
+		{
+			name:       "valid form",
+			form:       ReorderForm{IDs: []string{"a", "b"}},
+			wantValid:  true,
+			wantErrors: nil,
+		},
+		{
+			name:      "missing ids",
+			form:      ReorderForm{},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"ids": "at least one item is required",
+			},
+		},
+		{
+			name:      "blank id",
+			form:      ReorderForm{IDs: []string{"a", " "}},
+			wantValid: false,
+			wantErrors: map[string]string{
+				"ids": "item ID cannot be blank",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.form.Validate()
+
+			assert.Equal(t, tt.wantValid, tt.form.IsValid())
+			assert.Equal(t, tt.wantErrors, tt.form.FieldErrors)
+		})
+	}
+}
+
+func TestReorderForm_Decode(t *testing.T) {
+	values := url.Values{"ids": {"c", "a", "b"}}
+
+	var f ReorderForm
+	require.NoError(t, form.NewDecoder().Decode(&f, values))
+
+	assert.Equal(t, []string{"c", "a", "b"}, f.IDs)
+}
@@ -0,0 +1,29 @@
+package form
+
+// TwoFactorForm takes a code from the authenticator app, or at login a
+// recovery code.
+type TwoFactorForm struct {
+	Code string `form:"code"`
+	Base `form:"-"`
+}
+
+func (f *TwoFactorForm) Validate() {
+	f.CheckField(NotBlank(f.Code),
+		"code",
+		"this field is required",
+	)
+}
+
+// DisableTwoFactorForm turns two-factor authentication off once the password
+// is confirmed.
+type DisableTwoFactorForm struct {
+	Password string `form:"password"`
+	Base     `form:"-"`
+}
+
+func (f *DisableTwoFactorForm) Validate() {
+	f.CheckField(NotBlank(f.Password),
+		"password",
+		"this field is required",
+	)
+}
@@ -0,0 +1,45 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTwoFactorForm_Validate(t *testing.T) {
+	t.Run("valid form", func(t *testing.T) {
+		f := TwoFactorForm{Code: "123 456"}
+
+		f.Validate()
+
+		assert.True(t, f.IsValid())
+	})
+
+	t.Run("missing code", func(t *testing.T) {
+		f := TwoFactorForm{Code: "  "}
+
+		f.Validate()
+
+		assert.False(t, f.IsValid())
+		assert.Equal(t, "this field is required", f.FieldErrors["code"])
+	})
+}
+
+func TestDisableTwoFactorForm_Validate(t *testing.T) {
+	t.Run("valid form", func(t *testing.T) {
+		f := DisableTwoFactorForm{Password: "password1"}
+
+		f.Validate()
+
+		assert.True(t, f.IsValid())
+	})
+
+	t.Run("missing password", func(t *testing.T) {
+		f := DisableTwoFactorForm{}
+
+		f.Validate()
+
+		assert.False(t, f.IsValid())
+		assert.Equal(t, "this field is required", f.FieldErrors["password"])
+	})
+}
@@ -0,0 +1,319 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/account"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/form"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/views"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/madalinpopa/gocost-web/ui/templates/components"
+	"github.com/madalinpopa/gocost-web/ui/templates/pages/private"
+)
+
+type AccountHandler struct {
+	app     HandlerContext
+	account usecase.AccountUseCase
+}
+
+func NewAccountHandler(app HandlerContext, account usecase.AccountUseCase) AccountHandler {
+	return AccountHandler{
+		app:     app,
+		account: account,
+	}
+}
+
+func (h *AccountHandler) ShowAccountsPage(w http.ResponseWriter, r *http.Request) {
+	data := h.app.Template.GetData(r)
+
+	accounts, err := h.accountsView(r)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	page := private.AccountsPage(data, accounts, &form.AccountForm{}, &form.TransferForm{Date: time.Now().Format("2006-01-02")})
+	h.app.Template.Render(w, r, page, http.StatusOK)
+}
+
+func (h *AccountHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
+	var accountForm form.AccountForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &accountForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !accountForm.IsValid() {
+		h.renderAccounts(w, r, &accountForm, nil, nil, http.StatusUnprocessableEntity)
+		return
+	}
+
+	_, err := h.account.Create(r.Context(), &usecase.CreateAccountRequest{
+		UserID:         h.app.Session.GetUserID(r.Context()),
+		Currency:       h.app.Session.GetCurrency(r.Context()),
+		Name:           accountForm.Name,
+		Type:           accountForm.Type,
+		OpeningBalance: accountForm.ParsedOpeningBalance(),
+	})
+	if err != nil {
+		errMessage, isUserFacing := translateAccountError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to create account", "error", err)
+		}
+		accountForm.AddNonFieldError(errMessage)
+		h.renderAccounts(w, r, &accountForm, nil, nil, http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.app.Notify.Toast(w, web.Success, "Account created.")
+	h.renderAccounts(w, r, nil, nil, nil, http.StatusOK)
+}
+
+func (h *AccountHandler) UpdateAccount(w http.ResponseWriter, r *http.Request) {
+	var accountForm form.AccountForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &accountForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+	accountForm.ID = r.PathValue("id")
+
+	if !accountForm.IsValid() {
+		h.renderAccounts(w, r, nil, nil, fieldErrorMessages(accountForm.FieldErrors), http.StatusUnprocessableEntity)
+		return
+	}
+
+	_, err := h.account.Update(r.Context(), &usecase.UpdateAccountRequest{
+		ID:             accountForm.ID,
+		UserID:         h.app.Session.GetUserID(r.Context()),
+		Currency:       h.app.Session.GetCurrency(r.Context()),
+		Name:           accountForm.Name,
+		Type:           accountForm.Type,
+		OpeningBalance: accountForm.ParsedOpeningBalance(),
+	})
+	if err != nil {
+		errMessage, isUserFacing := translateAccountError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to update account", "error", err)
+		}
+		h.renderAccounts(w, r, nil, nil, []string{errMessage}, http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.app.Notify.Toast(w, web.Success, "Account updated.")
+	h.renderAccounts(w, r, nil, nil, nil, http.StatusOK)
+}
+
+func (h *AccountHandler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	userID := h.app.Session.GetUserID(r.Context())
+	if err := h.account.Delete(r.Context(), userID, r.PathValue("id")); err != nil {
+		errMessage, isUserFacing := translateAccountError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to delete account", "error", err)
+		}
+		h.renderAccounts(w, r, nil, nil, []string{errMessage}, http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.app.Notify.Toast(w, web.Success, "Account deleted.")
+	h.renderAccounts(w, r, nil, nil, nil, http.StatusOK)
+}
+
+// CreateTransfer moves money between two accounts. Transfers change the
+// balances only; they never show up as income or spending on the dashboard.
+func (h *AccountHandler) CreateTransfer(w http.ResponseWriter, r *http.Request) {
+	var transferForm form.TransferForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &transferForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !transferForm.IsValid() {
+		h.renderAccounts(w, r, nil, &transferForm, nil, http.StatusUnprocessableEntity)
+		return
+	}
+
+	err := h.account.Transfer(r.Context(), &usecase.TransferRequest{
+		UserID:        h.app.Session.GetUserID(r.Context()),
+		Currency:      h.app.Session.GetCurrency(r.Context()),
+		FromAccountID: transferForm.FromAccountID,
+		ToAccountID:   transferForm.ToAccountID,
+		Amount:        transferForm.ParsedAmount(),
+		Date:          transferForm.ParsedDate(),
+	})
+	if err != nil {
+		errMessage, isUserFacing := translateAccountError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to transfer between accounts", "error", err)
+		}
+		transferForm.AddNonFieldError(errMessage)
+		h.renderAccounts(w, r, nil, &transferForm, nil, http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.app.Notify.Toast(w, web.Success, "Transfer recorded.")
+	h.renderAccounts(w, r, nil, nil, nil, http.StatusOK)
+}
+
+// GetAccountOptions renders the account picker of the income and expense
+// forms, with the account in the selected query parameter chosen.
+func (h *AccountHandler) GetAccountOptions(w http.ResponseWriter, r *http.Request) {
+	accounts, err := h.accountsView(r)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	component := components.AccountSelect(r.URL.Query().Get("id"), accounts, r.URL.Query().Get("selected"))
+	h.app.Template.Render(w, r, component, http.StatusOK)
+}
+
+func (h *AccountHandler) ShowLedgerPage(w http.ResponseWriter, r *http.Request) {
+	data := h.app.Template.GetData(r)
+
+	ledger, err := h.account.Ledger(r.Context(), data.User.ID, r.PathValue("id"))
+	if err != nil {
+		if errors.Is(err, account.ErrAccountNotFound) {
+			h.app.Errors.Error(w, r, http.StatusNotFound, err)
+			return
+		}
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	view, err := views.NewAccountLedgerView(ledger, data.Currency)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	reconcileForm := &form.ReconcileForm{StatementDate: time.Now().Format("2006-01-02")}
+	page := private.AccountLedgerPage(data, view, reconcileForm)
+	h.app.Template.Render(w, r, page, http.StatusOK)
+}
+
+func (h *AccountHandler) DeleteTransfer(w http.ResponseWriter, r *http.Request) {
+	userID := h.app.Session.GetUserID(r.Context())
+	if err := h.account.DeleteTransfer(r.Context(), userID, r.PathValue("transferID")); err != nil {
+		errMessage, isUserFacing := translateAccountError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to delete transfer", "error", err)
+		}
+		h.app.Notify.Toast(w, web.ErrorMsg, errMessage)
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.app.Htmx.Redirect(w, "/accounts/"+r.PathValue("id"))
+}
+
+// Reconcile compares the account with a statement. With confirm set and a
+// matching balance, every entry up to the statement date is marked as
+// reconciled and the page reloads to show it.
+func (h *AccountHandler) Reconcile(w http.ResponseWriter, r *http.Request) {
+	var reconcileForm form.ReconcileForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &reconcileForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !reconcileForm.IsValid() {
+		h.app.Template.Render(w, r, components.ReconcilePanel(r.PathValue("id"), nil, &reconcileForm), http.StatusUnprocessableEntity)
+		return
+	}
+
+	req := &usecase.ReconcileRequest{
+		UserID:           h.app.Session.GetUserID(r.Context()),
+		Currency:         h.app.Session.GetCurrency(r.Context()),
+		AccountID:        r.PathValue("id"),
+		StatementDate:    reconcileForm.ParsedStatementDate(),
+		StatementBalance: reconcileForm.ParsedStatementBalance(),
+	}
+
+	compare := h.account.CompareStatement
+	if reconcileForm.Confirm {
+		compare = h.account.Reconcile
+	}
+
+	resp, err := compare(r.Context(), req)
+	if err != nil {
+		errMessage, isUserFacing := translateAccountError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to reconcile account", "error", err)
+		}
+		reconcileForm.AddNonFieldError(errMessage)
+		h.app.Template.Render(w, r, components.ReconcilePanel(r.PathValue("id"), nil, &reconcileForm), http.StatusUnprocessableEntity)
+		return
+	}
+
+	if reconcileForm.Confirm {
+		h.app.Htmx.Redirect(w, "/accounts/"+r.PathValue("id"))
+		return
+	}
+
+	view, err := views.NewReconciliationView(resp, h.app.Session.GetCurrency(r.Context()))
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	h.app.Template.Render(w, r, components.ReconcilePanel(r.PathValue("id"), &view, &reconcileForm), http.StatusOK)
+}
+
+// renderAccounts renders the account manager. Errors from the per-account
+// actions are shown above the list; the create and transfer forms keep
+// their own.
+func (h *AccountHandler) renderAccounts(w http.ResponseWriter, r *http.Request, accountForm *form.AccountForm, transferForm *form.TransferForm, actionErrors []string, status int) {
+	accounts, err := h.accountsView(r)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	if accountForm == nil {
+		accountForm = &form.AccountForm{}
+	}
+	if transferForm == nil {
+		transferForm = &form.TransferForm{Date: time.Now().Format("2006-01-02")}
+	}
+
+	h.app.Template.Render(w, r, components.AccountsManager(accounts, accountForm, transferForm, actionErrors), status)
+}
+
+func (h *AccountHandler) accountsView(r *http.Request) (views.AccountsView, error) {
+	userID := h.app.Session.GetUserID(r.Context())
+
+	accounts, err := h.account.List(r.Context(), userID)
+	if err != nil {
+		return views.AccountsView{}, err
+	}
+
+	return views.NewAccountsView(accounts, h.app.Session.GetCurrency(r.Context()))
+}
+
+func translateAccountError(err error) (string, bool) {
+	switch {
+	case errors.Is(err, account.ErrEmptyName):
+		return "Account name cannot be empty.", true
+	case errors.Is(err, account.ErrNameTooLong):
+		return "Account name is too long.", true
+	case errors.Is(err, account.ErrInvalidType):
+		return "Choose a valid account type.", true
+	case errors.Is(err, account.ErrSameAccountTransfer):
+		return "Choose two different accounts.", true
+	case errors.Is(err, account.ErrInvalidTransferAmount):
+		return "Transfer amount must be greater than zero.", true
+	case errors.Is(err, account.ErrCurrencyMismatch):
+		return "The statement must be in the account currency.", true
+	case errors.Is(err, account.ErrReconciliationMismatch):
+		return "The balance no longer matches the statement. Compare it again.", true
+	case errors.Is(err, account.ErrAccountNotFound):
+		return "Account not found.", true
+	case errors.Is(err, account.ErrTransferNotFound):
+		return "Transfer not found.", true
+	default:
+		return "An unexpected error occurred. Please try again later.", false
+	}
+}
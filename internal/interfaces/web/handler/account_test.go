@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-playground/form/v4"
+	"github.com/madalinpopa/gocost-web/internal/config"
+	"github.com/madalinpopa/gocost-web/internal/domain/account"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/respond"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestAccountHandler(session *MockSessionManager, accountUC *MockAccountUseCase) AccountHandler {
+	cfg := &config.Config{Currency: "USD"}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	errHandler := newTestErrors(logger, new(MockErrorHandler))
+
+	return NewAccountHandler(HandlerContext{
+		Config:   cfg,
+		Decoder:  form.NewDecoder(),
+		Logger:   logger,
+		Session:  session,
+		Errors:   errHandler,
+		Htmx:     respond.NewHtmx(errHandler),
+		Notify:   respond.NewNotify(logger),
+		Template: web.NewTemplate(logger, cfg),
+	}, accountUC)
+}
+
+func newTestAccounts() []usecase.AccountResponse {
+	return []usecase.AccountResponse{
+		{ID: "checking", Name: "Checking", Type: "checking", Currency: "USD", BalanceCents: 58000, UnreconciledCount: 1},
+		{ID: "visa", Name: "Visa", Type: "credit_card", Currency: "USD", BalanceCents: -12000},
+	}
+}
+
+func TestAccountHandler_CreateAccount(t *testing.T) {
+	t.Run("creates the account and renders the manager", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockAccountUC := new(MockAccountUseCase)
+		handler := newTestAccountHandler(mockSession, mockAccountUC)
+
+		req := newFormRequest("/accounts", url.Values{
+			"account-name":    {"Visa"},
+			"account-type":    {"credit_card"},
+			"account-opening": {"-120"},
+		})
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockAccountUC.On("Create", req.Context(), mock.MatchedBy(func(r *usecase.CreateAccountRequest) bool {
+			return r.UserID == "user-123" && r.Type == "credit_card" && r.OpeningBalance == -120
+		})).Return(&usecase.AccountResponse{ID: "visa"}, nil)
+		mockAccountUC.On("List", req.Context(), "user-123").Return(newTestAccounts(), nil)
+
+		// Act
+		handler.CreateAccount(rec, req)
+
+		// Assert
+		body := rec.Body.String()
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, body, `id="accounts-manager"`)
+		assert.Contains(t, body, `href="/accounts/visa"`)
+		assert.Contains(t, body, "1 unreconciled")
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "Account created.")
+		mockAccountUC.AssertExpectations(t)
+	})
+
+	t.Run("re-renders the form when the name is missing", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockAccountUC := new(MockAccountUseCase)
+		handler := newTestAccountHandler(mockSession, mockAccountUC)
+
+		req := newFormRequest("/accounts", url.Values{
+			"account-type":    {"checking"},
+			"account-opening": {"0"},
+		})
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockAccountUC.On("List", req.Context(), "user-123").Return(newTestAccounts(), nil)
+
+		// Act
+		handler.CreateAccount(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "this field is required")
+		mockAccountUC.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAccountHandler_CreateTransfer(t *testing.T) {
+	t.Run("shows a domain error on the transfer form", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockAccountUC := new(MockAccountUseCase)
+		handler := newTestAccountHandler(mockSession, mockAccountUC)
+
+		req := newFormRequest("/accounts/transfers", url.Values{
+			"transfer-from":   {"checking"},
+			"transfer-to":     {"gone"},
+			"transfer-amount": {"50"},
+			"transfer-date":   {"2024-03-05"},
+		})
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockAccountUC.On("Transfer", req.Context(), mock.MatchedBy(func(r *usecase.TransferRequest) bool {
+			return r.FromAccountID == "checking" && r.Amount == 50 && r.Date.Equal(time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC))
+		})).Return(account.ErrAccountNotFound)
+		mockAccountUC.On("List", req.Context(), "user-123").Return(newTestAccounts(), nil)
+
+		// Act
+		handler.CreateTransfer(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Account not found.")
+	})
+}
+
+func TestAccountHandler_GetAccountOptions(t *testing.T) {
+	// Arrange
+	mockSession := new(MockSessionManager)
+	mockAccountUC := new(MockAccountUseCase)
+	handler := newTestAccountHandler(mockSession, mockAccountUC)
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/options?id=expense-account&selected=visa", nil)
+	rec := httptest.NewRecorder()
+
+	mockSession.On("GetUserID", req.Context()).Return("user-123")
+	mockSession.On("GetCurrency", req.Context()).Return("USD")
+	mockAccountUC.On("List", req.Context(), "user-123").Return(newTestAccounts(), nil)
+
+	// Act
+	handler.GetAccountOptions(rec, req)
+
+	// Assert
+	body := rec.Body.String()
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, body, `name="account-id"`)
+	assert.Contains(t, body, `<option value="">No account</option>`)
+	assert.Contains(t, body, `<option value="visa" selected>Visa</option>`)
+}
+
+func TestAccountHandler_Reconcile(t *testing.T) {
+	statement := url.Values{
+		"statement-date":    {"2024-03-31"},
+		"statement-balance": {"570"},
+	}
+
+	t.Run("compares the statement and highlights unreconciled entries", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockAccountUC := new(MockAccountUseCase)
+		handler := newTestAccountHandler(mockSession, mockAccountUC)
+
+		req := newFormRequest("/accounts/checking/reconcile", statement)
+		req.SetPathValue("id", "checking")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockAccountUC.On("CompareStatement", req.Context(), mock.MatchedBy(func(r *usecase.ReconcileRequest) bool {
+			return r.AccountID == "checking" && r.StatementBalance == 570
+		})).Return(&usecase.ReconciliationResponse{
+			Account:               newTestAccounts()[0],
+			StatementDate:         time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC),
+			StatementBalanceCents: 57000,
+			ComputedBalanceCents:  58000,
+			DifferenceCents:       -1000,
+			Unreconciled: []usecase.AccountEntryResponse{
+				{Kind: "expense", ID: "rent", Description: "Rent", AmountCents: -2000, BalanceCents: 58000},
+			},
+		}, nil)
+
+		// Act
+		handler.Reconcile(rec, req)
+
+		// Assert
+		body := rec.Body.String()
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, body, "Rent")
+		assert.Contains(t, body, "The balances differ.")
+		assert.NotContains(t, body, `name="confirm"`)
+		mockAccountUC.AssertNotCalled(t, "Reconcile", mock.Anything, mock.Anything)
+	})
+
+	t.Run("reconciles a matching statement", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockAccountUC := new(MockAccountUseCase)
+		handler := newTestAccountHandler(mockSession, mockAccountUC)
+
+		values := url.Values{"confirm": {"true"}}
+		for key, value := range statement {
+			values[key] = value
+		}
+		req := newFormRequest("/accounts/checking/reconcile", values)
+		req.SetPathValue("id", "checking")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockAccountUC.On("Reconcile", req.Context(), mock.Anything).Return(&usecase.ReconciliationResponse{Reconciled: true}, nil)
+
+		// Act
+		handler.Reconcile(rec, req)
+
+		// Assert
+		assert.Equal(t, "/accounts/checking", rec.Header().Get("HX-Redirect"))
+		mockAccountUC.AssertNotCalled(t, "CompareStatement", mock.Anything, mock.Anything)
+	})
+
+	t.Run("shows a mismatch when the balance changed since the comparison", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockAccountUC := new(MockAccountUseCase)
+		handler := newTestAccountHandler(mockSession, mockAccountUC)
+
+		values := url.Values{"confirm": {"true"}}
+		for key, value := range statement {
+			values[key] = value
+		}
+		req := newFormRequest("/accounts/checking/reconcile", values)
+		req.SetPathValue("id", "checking")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockAccountUC.On("Reconcile", req.Context(), mock.Anything).Return(nil, account.ErrReconciliationMismatch)
+
+		// Act
+		handler.Reconcile(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "The balance no longer matches the statement.")
+	})
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/alert"
+	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/form"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/views"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/madalinpopa/gocost-web/ui/templates/components"
+	"github.com/madalinpopa/gocost-web/ui/templates/pages/private"
+)
+
+// notificationLimit is how many of the latest notifications the
+// notification center shows.
+const notificationLimit = 20
+
+type AlertHandler struct {
+	app   HandlerContext
+	alert usecase.AlertUseCase
+}
+
+func NewAlertHandler(app HandlerContext, alert usecase.AlertUseCase) AlertHandler {
+	return AlertHandler{
+		app:   app,
+		alert: alert,
+	}
+}
+
+// ShowAlertsPage lists the categories of the current month with their
+// alert thresholds.
+func (h *AlertHandler) ShowAlertsPage(w http.ResponseWriter, r *http.Request) {
+	data := h.app.Template.GetData(r)
+
+	groups, err := h.alert.Settings(r.Context(), data.User.ID, currentMonth())
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	page := private.AlertsPage(data, views.NewAlertGroupViews(groups))
+	h.app.Template.Render(w, r, page, http.StatusOK)
+}
+
+// SaveThresholds replaces the thresholds of a category and renders the
+// settings again, with the error next to the category when they were not
+// saved.
+func (h *AlertHandler) SaveThresholds(w http.ResponseWriter, r *http.Request) {
+	var thresholdsForm form.ThresholdsForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &thresholdsForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	categoryID := r.PathValue("id")
+	if !thresholdsForm.IsValid() {
+		h.renderSettings(w, r, categoryID, &thresholdsForm, thresholdsForm.FieldErrors["thresholds"], http.StatusUnprocessableEntity)
+		return
+	}
+
+	err := h.alert.SaveThresholds(r.Context(), &usecase.SaveThresholdsRequest{
+		UserID:     h.app.Session.GetUserID(r.Context()),
+		CategoryID: categoryID,
+		Thresholds: thresholdsForm.ParsedThresholds(),
+	})
+	if err != nil {
+		errMessage, isUserFacing := translateAlertError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to save alert thresholds", "error", err)
+		}
+		h.renderSettings(w, r, categoryID, &thresholdsForm, errMessage, http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.app.Notify.Toast(w, web.Success, "Alerts saved.")
+	h.renderSettings(w, r, "", nil, "", http.StatusOK)
+}
+
+// GetNotifications renders the notification center in the header.
+func (h *AlertHandler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	h.renderNotifications(w, r, false)
+}
+
+func (h *AlertHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	userID := h.app.Session.GetUserID(r.Context())
+	if err := h.alert.MarkRead(r.Context(), userID, r.PathValue("id")); err != nil {
+		if errors.Is(err, alert.ErrNotificationNotFound) {
+			h.app.Errors.Error(w, r, http.StatusNotFound, err)
+			return
+		}
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	h.renderNotifications(w, r, true)
+}
+
+func (h *AlertHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	userID := h.app.Session.GetUserID(r.Context())
+	if err := h.alert.MarkAllRead(r.Context(), userID); err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	h.renderNotifications(w, r, true)
+}
+
+// renderSettings renders the alert settings. When thresholdsForm is set, the
+// category it was sent for keeps what was entered along with errMessage.
+func (h *AlertHandler) renderSettings(w http.ResponseWriter, r *http.Request, categoryID string, thresholdsForm *form.ThresholdsForm, errMessage string, status int) {
+	userID := h.app.Session.GetUserID(r.Context())
+
+	groups, err := h.alert.Settings(r.Context(), userID, currentMonth())
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	settings := views.NewAlertGroupViews(groups)
+	if thresholdsForm != nil {
+		for i := range settings {
+			for j := range settings[i].Categories {
+				if settings[i].Categories[j].ID == categoryID {
+					settings[i].Categories[j].Thresholds = thresholdsForm.Thresholds
+					settings[i].Categories[j].Error = errMessage
+				}
+			}
+		}
+	}
+
+	h.app.Template.Render(w, r, components.AlertSettings(settings), status)
+}
+
+// renderNotifications renders the notification center, left open after
+// marking notifications as read.
+func (h *AlertHandler) renderNotifications(w http.ResponseWriter, r *http.Request, open bool) {
+	userID := h.app.Session.GetUserID(r.Context())
+
+	notifications, err := h.alert.Notifications(r.Context(), userID, notificationLimit)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	unread, err := h.alert.UnreadCount(r.Context(), userID)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	view, err := views.NewNotificationsView(notifications, unread, h.app.Session.GetCurrency(r.Context()))
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	h.app.Template.Render(w, r, components.NotificationCenter(view, open), http.StatusOK)
+}
+
+func translateAlertError(err error) (string, bool) {
+	switch {
+	case errors.Is(err, alert.ErrInvalidThreshold):
+		return "Thresholds must be between 1 and 500 percent.", true
+	case errors.Is(err, alert.ErrTooManyThresholds):
+		return "A category can have at most 5 thresholds.", true
+	case errors.Is(err, tracking.ErrCategoryNotFound), errors.Is(err, tracking.ErrGroupNotFound):
+		return "Category not found.", true
+	default:
+		return "An unexpected error occurred. Please try again later.", false
+	}
+}
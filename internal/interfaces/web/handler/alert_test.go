@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/form/v4"
+	"github.com/madalinpopa/gocost-web/internal/config"
+	"github.com/madalinpopa/gocost-web/internal/domain/alert"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/respond"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestAlertHandler(session *MockSessionManager, alertUC *MockAlertUseCase, mockErrors *MockErrorHandler) AlertHandler {
+	cfg := &config.Config{Currency: "USD"}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	errHandler := newTestErrors(logger, mockErrors)
+
+	return NewAlertHandler(HandlerContext{
+		Config:   cfg,
+		Decoder:  form.NewDecoder(),
+		Logger:   logger,
+		Session:  session,
+		Errors:   errHandler,
+		Htmx:     respond.NewHtmx(errHandler),
+		Notify:   respond.NewNotify(logger),
+		Template: web.NewTemplate(logger, cfg),
+	}, alertUC)
+}
+
+func newTestAlertSettings() []usecase.AlertGroupResponse {
+	return []usecase.AlertGroupResponse{
+		{ID: "home", Name: "Home", Categories: []usecase.AlertCategoryResponse{
+			{ID: "food", Name: "Food", Thresholds: []int{80, 100}},
+			{ID: "groceries", ParentID: "food", Name: "Groceries"},
+		}},
+	}
+}
+
+func TestAlertHandler_SaveThresholds(t *testing.T) {
+	t.Run("saves the thresholds and renders the settings", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockAlertUC := new(MockAlertUseCase)
+		handler := newTestAlertHandler(mockSession, mockAlertUC, new(MockErrorHandler))
+
+		req := httptest.NewRequest(http.MethodPost, "/alerts/categories/food", strings.NewReader(url.Values{"thresholds": {"100, 80"}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetPathValue("id", "food")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockAlertUC.On("SaveThresholds", req.Context(), mock.MatchedBy(func(r *usecase.SaveThresholdsRequest) bool {
+			return r.UserID == "user-123" && r.CategoryID == "food" && assert.ObjectsAreEqual([]int{100, 80}, r.Thresholds)
+		})).Return(nil)
+		mockAlertUC.On("Settings", req.Context(), "user-123", mock.Anything).Return(newTestAlertSettings(), nil)
+
+		// Act
+		handler.SaveThresholds(rec, req)
+
+		// Assert
+		body := rec.Body.String()
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, body, `id="alert-settings"`)
+		assert.Contains(t, body, `value="80, 100"`)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "Alerts saved.")
+		mockAlertUC.AssertExpectations(t)
+	})
+
+	t.Run("keeps what was entered when it is not valid", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockAlertUC := new(MockAlertUseCase)
+		handler := newTestAlertHandler(mockSession, mockAlertUC, new(MockErrorHandler))
+
+		req := httptest.NewRequest(http.MethodPost, "/alerts/categories/groceries", strings.NewReader(url.Values{"thresholds": {"eighty"}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetPathValue("id", "groceries")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockAlertUC.On("Settings", req.Context(), "user-123", mock.Anything).Return(newTestAlertSettings(), nil)
+
+		// Act
+		handler.SaveThresholds(rec, req)
+
+		// Assert
+		body := rec.Body.String()
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, body, `value="eighty"`)
+		assert.Contains(t, body, "thresholds must be whole percentages separated by commas")
+		mockAlertUC.AssertNotCalled(t, "SaveThresholds", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAlertHandler_GetNotifications(t *testing.T) {
+	// Arrange
+	mockSession := new(MockSessionManager)
+	mockAlertUC := new(MockAlertUseCase)
+	handler := newTestAlertHandler(mockSession, mockAlertUC, new(MockErrorHandler))
+
+	req := httptest.NewRequest(http.MethodGet, "/notifications", nil)
+	rec := httptest.NewRecorder()
+
+	mockSession.On("GetUserID", req.Context()).Return("user-123")
+	mockSession.On("GetCurrency", req.Context()).Return("USD")
+	mockAlertUC.On("Notifications", req.Context(), "user-123", notificationLimit).Return([]usecase.NotificationResponse{
+		{ID: "n1", CategoryID: "food", CategoryName: "Food", Month: "2024-03", Threshold: 100, SpentCents: 10500, BudgetCents: 10000, CreatedAt: time.Now()},
+	}, nil)
+	mockAlertUC.On("UnreadCount", req.Context(), "user-123").Return(1, nil)
+
+	// Act
+	handler.GetNotifications(rec, req)
+
+	// Assert
+	body := rec.Body.String()
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, body, `id="notification-center"`)
+	assert.Contains(t, body, "Food reached 100% of its budget")
+	assert.Contains(t, body, `href="/home?month=2024-03#category-food"`)
+	assert.Contains(t, body, `hx-post="/notifications/n1/read"`)
+}
+
+func TestAlertHandler_MarkRead(t *testing.T) {
+	t.Run("renders the center left open", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockAlertUC := new(MockAlertUseCase)
+		handler := newTestAlertHandler(mockSession, mockAlertUC, new(MockErrorHandler))
+
+		req := httptest.NewRequest(http.MethodPost, "/notifications/n1/read", nil)
+		req.SetPathValue("id", "n1")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockAlertUC.On("MarkRead", req.Context(), "user-123", "n1").Return(nil)
+		mockAlertUC.On("Notifications", req.Context(), "user-123", notificationLimit).Return([]usecase.NotificationResponse{}, nil)
+		mockAlertUC.On("UnreadCount", req.Context(), "user-123").Return(0, nil)
+
+		// Act
+		handler.MarkRead(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "{ open: true }")
+		mockAlertUC.AssertExpectations(t)
+	})
+
+	t.Run("returns not found for another user's notification", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockAlertUC := new(MockAlertUseCase)
+		mockErrorHandler := new(MockErrorHandler)
+		handler := newTestAlertHandler(mockSession, mockAlertUC, mockErrorHandler)
+
+		req := httptest.NewRequest(http.MethodPost, "/notifications/n1/read", nil)
+		req.SetPathValue("id", "n1")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockAlertUC.On("MarkRead", req.Context(), "user-123", "n1").Return(alert.ErrNotificationNotFound)
+		mockErrorHandler.On("Error", rec, req, http.StatusNotFound, alert.ErrNotificationNotFound).Return()
+
+		// Act
+		handler.MarkRead(rec, req)
+
+		// Assert
+		mockErrorHandler.AssertExpectations(t)
+		mockAlertUC.AssertNotCalled(t, "Notifications", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/views"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/madalinpopa/gocost-web/ui/templates/components"
+	"github.com/madalinpopa/gocost-web/ui/templates/pages/private"
+)
+
+type ArchiveHandler struct {
+	app   HandlerContext
+	group usecase.GroupUseCase
+}
+
+func NewArchiveHandler(app HandlerContext, group usecase.GroupUseCase) ArchiveHandler {
+	return ArchiveHandler{
+		app:   app,
+		group: group,
+	}
+}
+
+func (h *ArchiveHandler) ShowArchivePage(w http.ResponseWriter, r *http.Request) {
+	data := h.app.Template.GetData(r)
+
+	groups, err := h.group.List(r.Context(), data.User.ID)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	page := private.ArchivePage(data, views.NewArchiveView(groups))
+	h.app.Template.Render(w, r, page, http.StatusOK)
+}
+
+func (h *ArchiveHandler) GetArchiveList(w http.ResponseWriter, r *http.Request) {
+	userID := h.app.Session.GetUserID(r.Context())
+
+	groups, err := h.group.List(r.Context(), userID)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	if err := components.ArchiveList(views.NewArchiveView(groups)).Render(r.Context(), w); err != nil {
+		h.app.Errors.LogServerError(r, err)
+	}
+}
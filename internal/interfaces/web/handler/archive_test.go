@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchiveHandler_GetArchiveList(t *testing.T) {
+	t.Run("renders archived groups and categories", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockGroupUC := new(MockGroupUseCase)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		appCtx := HandlerContext{
+			Session: mockSession,
+			Logger:  logger,
+			Errors:  newTestErrors(logger, new(MockErrorHandler)),
+		}
+
+		handler := NewArchiveHandler(appCtx, mockGroupUC)
+
+		req := httptest.NewRequest(http.MethodGet, "/archive/list", nil)
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockGroupUC.On("List", req.Context(), "user-123").Return([]*usecase.GroupResponse{
+			{ID: "group-1", Name: "Old apartment", Archived: true},
+			{ID: "group-2", Name: "Personal", Categories: []usecase.CategoryResponse{
+				{ID: "cat-1", Name: "Gym", Archived: true},
+				{ID: "cat-2", Name: "Food"},
+			}},
+		}, nil)
+
+		// Act
+		handler.GetArchiveList(rec, req)
+
+		// Assert
+		body := rec.Body.String()
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, body, "Old apartment")
+		assert.Contains(t, body, `hx-post="/groups/group-1/restore"`)
+		assert.Contains(t, body, `hx-post="/groups/group-2/categories/cat-1/restore"`)
+		assert.NotContains(t, body, "Food")
+	})
+
+	t.Run("usecase error", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockGroupUC := new(MockGroupUseCase)
+		mockErrorHandler := new(MockErrorHandler)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		appCtx := HandlerContext{
+			Session: mockSession,
+			Logger:  logger,
+			Errors:  newTestErrors(logger, mockErrorHandler),
+		}
+
+		handler := NewArchiveHandler(appCtx, mockGroupUC)
+
+		req := httptest.NewRequest(http.MethodGet, "/archive/list", nil)
+		rec := httptest.NewRecorder()
+		expectedErr := errors.New("db error")
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockGroupUC.On("List", req.Context(), "user-123").Return(nil, expectedErr)
+		mockErrorHandler.On("ServerError", rec, req, expectedErr).Return()
+
+		// Act
+		handler.GetArchiveList(rec, req)
+
+		// Assert
+		mockErrorHandler.AssertExpectations(t)
+	})
+}
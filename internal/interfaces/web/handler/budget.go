@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
+	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/form"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/views"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/madalinpopa/gocost-web/ui/templates/components"
+)
+
+type BudgetHandler struct {
+	app    HandlerContext
+	budget usecase.BudgetUseCase
+}
+
+func NewBudgetHandler(app HandlerContext, budget usecase.BudgetUseCase) BudgetHandler {
+	return BudgetHandler{
+		app:    app,
+		budget: budget,
+	}
+}
+
+func (h *BudgetHandler) ToggleZeroBased(w http.ResponseWriter, r *http.Request) {
+	var zeroBasedForm form.ZeroBasedForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &zeroBasedForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := h.app.Session.GetUserID(r.Context())
+	if err := h.budget.SetZeroBased(r.Context(), userID, zeroBasedForm.Enabled); err != nil {
+		h.app.Logger.Error("failed to update zero-based budgeting", "error", err)
+		triggerDashboardRefresh(w, h.app.Notify, web.ErrorMsg, "An unexpected error occurred. Please try again later.", "")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	message := "Zero-based budgeting turned off."
+	if zeroBasedForm.Enabled {
+		message = "Zero-based budgeting turned on."
+	}
+	triggerDashboardRefresh(w, h.app.Notify, web.Success, message, "")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *BudgetHandler) GetAssignForm(w http.ResponseWriter, r *http.Request) {
+	month, err := web.GetRequiredQueryParam(r, "month")
+	if err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	h.renderAssignForm(w, r, month, &form.DistributeBudgetForm{Month: month}, http.StatusOK)
+}
+
+// FillFromLastMonth keeps the assign form open so that several categories can
+// be filled in a row.
+func (h *BudgetHandler) FillFromLastMonth(w http.ResponseWriter, r *http.Request) {
+	var fillForm form.FillBudgetForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &fillForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !fillForm.IsValid() {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, errors.New("invalid budget fill"))
+		return
+	}
+
+	_, err := h.budget.FillFromLastMonth(r.Context(), &usecase.FillBudgetRequest{
+		UserID:     h.app.Session.GetUserID(r.Context()),
+		Currency:   h.app.Session.GetCurrency(r.Context()),
+		GroupID:    fillForm.GroupID,
+		CategoryID: fillForm.CategoryID,
+		Month:      fillForm.Month,
+	})
+	distributeForm := &form.DistributeBudgetForm{Month: fillForm.Month}
+	if err != nil {
+		errMessage, isUserFacing := translateBudgetError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to fill budget", "error", err)
+		}
+		distributeForm.AddNonFieldError(errMessage)
+		h.renderAssignForm(w, r, fillForm.Month, distributeForm, http.StatusUnprocessableEntity)
+		return
+	}
+
+	triggerDashboardRefresh(w, h.app.Notify, web.Success, "Budget filled from last month.", "")
+	h.renderAssignForm(w, r, fillForm.Month, distributeForm, http.StatusOK)
+}
+
+func (h *BudgetHandler) Distribute(w http.ResponseWriter, r *http.Request) {
+	var distributeForm form.DistributeBudgetForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &distributeForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !distributeForm.IsValid() {
+		h.renderAssignForm(w, r, distributeForm.Month, &distributeForm, http.StatusUnprocessableEntity)
+		return
+	}
+
+	ids := distributeForm.CategoryIDs()
+	shares := make([]usecase.BudgetShare, 0, len(ids))
+	for _, id := range ids {
+		shares = append(shares, usecase.BudgetShare{
+			CategoryID: id,
+			Percent:    distributeForm.ParsedPercent(id),
+		})
+	}
+
+	_, err := h.budget.Distribute(r.Context(), &usecase.DistributeBudgetRequest{
+		UserID:   h.app.Session.GetUserID(r.Context()),
+		Currency: h.app.Session.GetCurrency(r.Context()),
+		Month:    distributeForm.Month,
+		Shares:   shares,
+	})
+	if err != nil {
+		errMessage, isUserFacing := translateBudgetError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to distribute budget", "error", err)
+		}
+		distributeForm.AddNonFieldError(errMessage)
+		h.renderAssignForm(w, r, distributeForm.Month, &distributeForm, http.StatusUnprocessableEntity)
+		return
+	}
+
+	triggerDashboardRefresh(w, h.app.Notify, web.Success, "Income distributed.", "assign-budget-modal")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *BudgetHandler) renderAssignForm(w http.ResponseWriter, r *http.Request, month string, distributeForm *form.DistributeBudgetForm, status int) {
+	userID := h.app.Session.GetUserID(r.Context())
+
+	assignment, err := h.budget.Assignment(r.Context(), userID, month)
+	if err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	view, err := views.NewBudgetAssignmentView(assignment, h.app.Session.GetCurrency(r.Context()))
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	h.app.Template.Render(w, r, components.AssignBudgetForm(view, distributeForm), status)
+}
+
+func translateBudgetError(err error) (string, bool) {
+	switch {
+	case errors.Is(err, usecase.ErrOverAssigned):
+		return "That would assign more than the income left this month.", true
+	case errors.Is(err, usecase.ErrNothingToAssign):
+		return "There is no income left to assign this month.", true
+	case errors.Is(err, usecase.ErrInvalidShares):
+		return "Percentages must be positive and add up to at most 100%.", true
+	case errors.Is(err, usecase.ErrCategoryNotAssignable):
+		return "This category cannot be budgeted this month.", true
+	case errors.Is(err, closing.ErrMonthClosed):
+		return monthClosedMessage, true
+	case errors.Is(err, tracking.ErrCategoryNameExists):
+		return "A category with this name already exists in this month.", true
+	case errors.Is(err, tracking.ErrInvalidMonth):
+		return "Invalid month format.", true
+	default:
+		return "An unexpected error occurred. Please try again later.", false
+	}
+}
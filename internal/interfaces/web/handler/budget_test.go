@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-playground/form/v4"
+	"github.com/madalinpopa/gocost-web/internal/config"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/respond"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestBudgetHandler(session *MockSessionManager, budgetUC *MockBudgetUseCase) BudgetHandler {
+	cfg := &config.Config{Currency: "USD"}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	return NewBudgetHandler(HandlerContext{
+		Config:   cfg,
+		Decoder:  form.NewDecoder(),
+		Logger:   logger,
+		Session:  session,
+		Errors:   newTestErrors(logger, new(MockErrorHandler)),
+		Notify:   respond.NewNotify(logger),
+		Template: web.NewTemplate(logger, cfg),
+	}, budgetUC)
+}
+
+func newTestAssignment() *usecase.BudgetAssignmentResponse {
+	return &usecase.BudgetAssignmentResponse{
+		Month:         "2024-03",
+		IncomeCents:   200000,
+		BudgetedCents: 120000,
+		LeftCents:     80000,
+		Categories: []usecase.AssignableCategoryResponse{
+			{GroupID: "g1", GroupName: "Home", CategoryID: "c1", Name: "Food", BudgetCents: 20000, LastMonthSpentCents: 30000},
+		},
+	}
+}
+
+func TestBudgetHandler_ToggleZeroBased(t *testing.T) {
+	// Arrange
+	mockSession := new(MockSessionManager)
+	mockBudgetUC := new(MockBudgetUseCase)
+	handler := newTestBudgetHandler(mockSession, mockBudgetUC)
+
+	req := newFormRequest("/budget/zero-based", url.Values{"enabled": {"true"}})
+	rec := httptest.NewRecorder()
+
+	mockSession.On("GetUserID", req.Context()).Return("user-123")
+	mockBudgetUC.On("SetZeroBased", req.Context(), "user-123", true).Return(nil)
+
+	// Act
+	handler.ToggleZeroBased(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Contains(t, rec.Header().Get("HX-Trigger"), "dashboard:refresh")
+	mockBudgetUC.AssertExpectations(t)
+}
+
+func TestBudgetHandler_GetAssignForm(t *testing.T) {
+	// Arrange
+	mockSession := new(MockSessionManager)
+	mockBudgetUC := new(MockBudgetUseCase)
+	handler := newTestBudgetHandler(mockSession, mockBudgetUC)
+
+	req := httptest.NewRequest(http.MethodGet, "/budget/assign/form?month=2024-03", nil)
+	rec := httptest.NewRecorder()
+
+	mockSession.On("GetUserID", req.Context()).Return("user-123")
+	mockSession.On("GetCurrency", req.Context()).Return("USD")
+	mockBudgetUC.On("Assignment", req.Context(), "user-123", "2024-03").Return(newTestAssignment(), nil)
+
+	// Act
+	handler.GetAssignForm(rec, req)
+
+	// Assert
+	body := rec.Body.String()
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, body, "Left to assign")
+	assert.Contains(t, body, "Food")
+	assert.Contains(t, body, `hx-post="/budget/fill"`)
+	assert.Contains(t, body, `name="percent[c1]"`)
+}
+
+func TestBudgetHandler_FillFromLastMonth(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockBudgetUC := new(MockBudgetUseCase)
+		handler := newTestBudgetHandler(mockSession, mockBudgetUC)
+
+		req := newFormRequest("/budget/fill", url.Values{
+			"month":       {"2024-03"},
+			"group-id":    {"g1"},
+			"category-id": {"c1"},
+		})
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockBudgetUC.On("FillFromLastMonth", req.Context(), &usecase.FillBudgetRequest{
+			UserID:     "user-123",
+			Currency:   "USD",
+			GroupID:    "g1",
+			CategoryID: "c1",
+			Month:      "2024-03",
+		}).Return(newTestAssignment(), nil)
+		mockBudgetUC.On("Assignment", req.Context(), "user-123", "2024-03").Return(newTestAssignment(), nil)
+
+		// Act
+		handler.FillFromLastMonth(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "Budget filled from last month.")
+		assert.Contains(t, rec.Body.String(), `id="assign-budget-form"`)
+		mockBudgetUC.AssertExpectations(t)
+	})
+
+	t.Run("refuses to over-assign", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockBudgetUC := new(MockBudgetUseCase)
+		handler := newTestBudgetHandler(mockSession, mockBudgetUC)
+
+		req := newFormRequest("/budget/fill", url.Values{
+			"month":       {"2024-03"},
+			"category-id": {"c1"},
+		})
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockBudgetUC.On("FillFromLastMonth", req.Context(), mock.Anything).Return(nil, usecase.ErrOverAssigned)
+		mockBudgetUC.On("Assignment", req.Context(), "user-123", "2024-03").Return(newTestAssignment(), nil)
+
+		// Act
+		handler.FillFromLastMonth(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "That would assign more than the income left this month.")
+	})
+}
+
+func TestBudgetHandler_Distribute(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockBudgetUC := new(MockBudgetUseCase)
+		handler := newTestBudgetHandler(mockSession, mockBudgetUC)
+
+		req := newFormRequest("/budget/distribute", url.Values{
+			"month":       {"2024-03"},
+			"percent[c1]": {"60"},
+		})
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockBudgetUC.On("Distribute", req.Context(), &usecase.DistributeBudgetRequest{
+			UserID:   "user-123",
+			Currency: "USD",
+			Month:    "2024-03",
+			Shares:   []usecase.BudgetShare{{CategoryID: "c1", Percent: 60}},
+		}).Return(newTestAssignment(), nil)
+
+		// Act
+		handler.Distribute(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "assign-budget-modal")
+		mockBudgetUC.AssertExpectations(t)
+	})
+
+	t.Run("re-renders the form for invalid percentages", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockBudgetUC := new(MockBudgetUseCase)
+		handler := newTestBudgetHandler(mockSession, mockBudgetUC)
+
+		req := newFormRequest("/budget/distribute", url.Values{
+			"month":       {"2024-03"},
+			"percent[c1]": {"150"},
+		})
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockBudgetUC.On("Assignment", req.Context(), "user-123", "2024-03").Return(newTestAssignment(), nil)
+
+		// Act
+		handler.Distribute(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Percentages cannot add up to more than 100%.")
+		mockBudgetUC.AssertNotCalled(t, "Distribute", mock.Anything, mock.Anything)
+	})
+}
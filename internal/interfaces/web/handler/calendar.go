@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/views"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/madalinpopa/gocost-web/ui/templates/components"
+	"github.com/madalinpopa/gocost-web/ui/templates/pages/private"
+)
+
+type CalendarHandler struct {
+	app     HandlerContext
+	expense usecase.ExpenseUseCase
+	group   usecase.GroupUseCase
+}
+
+func NewCalendarHandler(app HandlerContext, expense usecase.ExpenseUseCase, group usecase.GroupUseCase) CalendarHandler {
+	return CalendarHandler{
+		app:     app,
+		expense: expense,
+		group:   group,
+	}
+}
+
+// ShowCalendarPage shows the expenses of the month on the day they were
+// spent or are due.
+func (h *CalendarHandler) ShowCalendarPage(w http.ResponseWriter, r *http.Request) {
+	data := h.app.Template.GetData(r)
+
+	calendar, err := h.calendarView(r)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	h.app.Template.Render(w, r, private.CalendarPage(data, calendar), http.StatusOK)
+}
+
+// GetCalendarGrid renders the calendar of the month alone, to refresh it
+// after an expense is added.
+func (h *CalendarHandler) GetCalendarGrid(w http.ResponseWriter, r *http.Request) {
+	calendar, err := h.calendarView(r)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	h.app.Template.Render(w, r, components.CalendarGrid(calendar), http.StatusOK)
+}
+
+func (h *CalendarHandler) calendarView(r *http.Request) (views.CalendarView, error) {
+	month, _, _ := web.GetMonthParam(r)
+	userID := h.app.Session.GetUserID(r.Context())
+
+	expenses, err := h.expense.ListByMonth(r.Context(), userID, month.Format("2006-01"))
+	if err != nil {
+		return views.CalendarView{}, err
+	}
+
+	groups, err := h.group.List(r.Context(), userID)
+	if err != nil {
+		return views.CalendarView{}, err
+	}
+
+	// Archived categories are kept: their expenses still show in the months
+	// they were recorded in.
+	categoryNames := make(map[string]string)
+	for _, g := range groups {
+		for _, c := range g.Categories {
+			categoryNames[c.ID] = c.Name
+		}
+	}
+
+	return views.NewCalendarView(month, expenses, categoryNames, h.app.Session.GetCurrency(r.Context()), time.Now())
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-playground/form/v4"
+	"github.com/madalinpopa/gocost-web/internal/config"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/respond"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCalendarHandler(session *MockSessionManager, expenseUC *MockExpenseUseCase, groupUC *MockGroupUseCase) CalendarHandler {
+	cfg := &config.Config{Currency: "USD"}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	errHandler := newTestErrors(logger, new(MockErrorHandler))
+
+	return NewCalendarHandler(HandlerContext{
+		Config:   cfg,
+		Decoder:  form.NewDecoder(),
+		Logger:   logger,
+		Session:  session,
+		Errors:   errHandler,
+		Htmx:     respond.NewHtmx(errHandler),
+		Notify:   respond.NewNotify(logger),
+		Template: web.NewTemplate(logger, cfg),
+	}, expenseUC, groupUC)
+}
+
+func TestCalendarHandler_GetCalendarGrid(t *testing.T) {
+	// Arrange
+	mockSession := new(MockSessionManager)
+	mockExpenseUC := new(MockExpenseUseCase)
+	mockGroupUC := new(MockGroupUseCase)
+	handler := newTestCalendarHandler(mockSession, mockExpenseUC, mockGroupUC)
+
+	req := httptest.NewRequest(http.MethodGet, "/calendar/grid?month=2024-02", nil)
+	rec := httptest.NewRecorder()
+
+	mockSession.On("GetUserID", req.Context()).Return("user-123")
+	mockSession.On("GetCurrency", req.Context()).Return("USD")
+	mockExpenseUC.On("ListByMonth", req.Context(), "user-123", "2024-02").Return([]*usecase.ExpenseResponse{
+		{ID: "rent", CategoryID: "housing", AmountCents: 120000, SpentAt: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), IsPaid: true},
+		{ID: "power", CategoryID: "utilities", Description: "Electricity", AmountCents: 6000, SpentAt: time.Date(2024, time.February, 15, 0, 0, 0, 0, time.UTC)},
+	}, nil)
+	mockGroupUC.On("List", req.Context(), "user-123").Return([]*usecase.GroupResponse{
+		{ID: "home", Name: "Home", Categories: []usecase.CategoryResponse{
+			{ID: "housing", Name: "Rent"},
+			{ID: "utilities", Name: "Utilities", Archived: true},
+		}},
+	}, nil)
+
+	// Act
+	handler.GetCalendarGrid(rec, req)
+
+	// Assert
+	body := rec.Body.String()
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, body, `id="calendar-grid"`)
+	assert.Contains(t, body, `hx-get="/calendar/grid?month=2024-02"`)
+	assert.Contains(t, body, "date: &#39;2024-02-15&#39;")
+	assert.Contains(t, body, "Utilities · Electricity · $ 60.00")
+	assert.Contains(t, body, "bg-rose-50")
+	assert.Contains(t, body, "$ 1,200.00")
+	mockExpenseUC.AssertExpectations(t)
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/form"
+)
+
+// newCaptcha issues the challenge a public form asks the browser to solve, or
+// none when challenges are turned off. A form that cannot get one is shown
+// without it, and then fails the check.
+func newCaptcha(app HandlerContext, r *http.Request) form.Captcha {
+	if app.Captcha == nil {
+		return form.Captcha{}
+	}
+
+	challenge, err := app.Captcha.Issue()
+	if err != nil {
+		app.Errors.LogServerError(r, err)
+		return form.Captcha{}
+	}
+	return form.Captcha{Challenge: challenge.Token, Difficulty: challenge.Difficulty}
+}
+
+// checkCaptcha adds an error to f unless the browser solved the challenge of
+// c, when challenges are turned on.
+func checkCaptcha(app HandlerContext, f *form.Base, c form.Captcha) {
+	if app.Captcha == nil {
+		return
+	}
+	f.CheckCaptcha(c, app.Captcha)
+}
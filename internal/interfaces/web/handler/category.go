@@ -2,11 +2,14 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
 	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
 	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
 	"github.com/madalinpopa/gocost-web/internal/interfaces/web/form"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/views"
 	"github.com/madalinpopa/gocost-web/internal/usecase"
 	"github.com/madalinpopa/gocost-web/ui/templates/components"
 )
@@ -14,12 +17,14 @@ import (
 type CategoryHandler struct {
 	app      HandlerContext
 	category usecase.CategoryUseCase
+	group    usecase.GroupUseCase
 }
 
-func NewCategoryHandler(app HandlerContext, category usecase.CategoryUseCase) CategoryHandler {
+func NewCategoryHandler(app HandlerContext, category usecase.CategoryUseCase, group usecase.GroupUseCase) CategoryHandler {
 	return CategoryHandler{
 		app:      app,
 		category: category,
+		group:    group,
 	}
 }
 
@@ -38,6 +43,7 @@ func (h *CategoryHandler) GetCreateForm(w http.ResponseWriter, r *http.Request)
 
 	categoryForm := &form.CreateCategoryForm{
 		GroupID:    groupID,
+		ParentID:   web.GetOptionalQueryParam(r, "parent-id", ""),
 		StartMonth: categoryStart,
 	}
 
@@ -71,6 +77,7 @@ func (h *CategoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request)
 
 	req := &usecase.CreateCategoryRequest{
 		GroupID:     categoryForm.GroupID,
+		ParentID:    categoryForm.ParentID,
 		UserID:      userID,
 		Currency:    currency,
 		Name:        categoryForm.Name,
@@ -155,20 +162,287 @@ func (h *CategoryHandler) UpdateCategory(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *CategoryHandler) GetDeleteForm(w http.ResponseWriter, r *http.Request) {
+	groupID, err := web.GetRequiredQueryParam(r, "group-id")
+	if err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	categoryID, err := web.GetRequiredQueryParam(r, "category-id")
+	if err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	deleteForm := &form.DeleteCategoryForm{
+		GroupID:    groupID,
+		CategoryID: categoryID,
+		Month:      r.URL.Query().Get("current-month"),
+	}
+
+	h.renderDeleteForm(w, r, deleteForm, http.StatusOK)
+}
+
 func (h *CategoryHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	var deleteForm form.DeleteCategoryForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &deleteForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !deleteForm.IsValid() {
+		h.renderDeleteForm(w, r, &deleteForm, http.StatusUnprocessableEntity)
+		return
+	}
+
+	userID := h.app.Session.GetUserID(r.Context())
+
+	err := h.category.Delete(r.Context(), &usecase.DeleteCategoryRequest{
+		ID:               deleteForm.CategoryID,
+		GroupID:          deleteForm.GroupID,
+		UserID:           userID,
+		Mode:             deleteForm.Mode,
+		TargetCategoryID: deleteForm.TargetCategoryID,
+		Month:            deleteForm.Month,
+	})
+	if err != nil {
+		errMessage, isUserFacing := translateCategoryError(err)
+		deleteForm.AddNonFieldError(errMessage)
+		h.renderDeleteForm(w, r, &deleteForm, http.StatusUnprocessableEntity)
+
+		if !isUserFacing {
+			h.app.Logger.Error("failed to delete category", "mode", deleteForm.Mode, "error", err)
+		}
+		return
+	}
+
+	message := "Category deleted successfully."
+	switch deleteForm.Mode {
+	case usecase.DeleteModeReassign:
+		message = "Expenses moved and category deleted."
+	case usecase.DeleteModeEnd:
+		message = "Category ended. Earlier months are kept."
+	}
+
+	triggerDashboardRefresh(w, h.app.Notify, web.Success, message, "delete-category-modal")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *CategoryHandler) renderDeleteForm(w http.ResponseWriter, r *http.Request, deleteForm *form.DeleteCategoryForm, status int) {
+	userID := h.app.Session.GetUserID(r.Context())
+
+	impact, err := h.category.DeletionImpact(r.Context(), userID, deleteForm.GroupID, deleteForm.CategoryID, deleteForm.Month)
+	if err != nil {
+		h.app.Errors.Error(w, r, http.StatusNotFound, err)
+		return
+	}
+
+	groups, err := h.group.List(r.Context(), userID)
+	if err != nil {
+		h.app.Errors.Error(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	options := categoryOptions(groups, "", deleteForm.CategoryID)
+	component := components.DeleteCategoryForm(deleteForm, views.NewDeletionImpactView(impact), options)
+	h.app.Template.Render(w, r, component, status)
+}
+
+func (h *CategoryHandler) ReorderCategories(w http.ResponseWriter, r *http.Request) {
+	var reorderForm form.ReorderForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &reorderForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !reorderForm.IsValid() {
+		h.app.Errors.Error(w, r, http.StatusUnprocessableEntity, errors.New("invalid category order"))
+		return
+	}
+
+	groupID := r.PathValue("groupID")
+	userID := h.app.Session.GetUserID(r.Context())
+
+	if err := h.category.Reorder(r.Context(), userID, groupID, reorderForm.IDs); err != nil {
+		errMessage, isUserFacing := translateCategoryError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to reorder categories", "error", err)
+		}
+
+		triggerDashboardRefresh(w, h.app.Notify, web.ErrorMsg, errMessage, "")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *CategoryHandler) ArchiveCategory(w http.ResponseWriter, r *http.Request) {
+	h.setArchived(w, r, true)
+}
+
+func (h *CategoryHandler) RestoreCategory(w http.ResponseWriter, r *http.Request) {
+	h.setArchived(w, r, false)
+}
+
+func (h *CategoryHandler) setArchived(w http.ResponseWriter, r *http.Request, archived bool) {
 	groupID := r.PathValue("groupID")
 	categoryID := r.PathValue("id")
 	userID := h.app.Session.GetUserID(r.Context())
 
-	if err := h.category.Delete(r.Context(), userID, groupID, categoryID); err != nil {
-		h.app.Errors.Error(w, r, http.StatusInternalServerError, err)
+	update, message := h.category.Restore, "Category restored successfully."
+	if archived {
+		update, message = h.category.Archive, "Category archived. It still shows in months with expenses."
+	}
+
+	if err := update(r.Context(), userID, groupID, categoryID); err != nil {
+		errMessage, isUserFacing := translateCategoryError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to update category archive state", "error", err)
+		}
+
+		triggerDashboardRefresh(w, h.app.Notify, web.ErrorMsg, errMessage, "")
+		w.WriteHeader(http.StatusUnprocessableEntity)
 		return
 	}
 
-	triggerDashboardRefresh(w, h.app.Notify, web.Success, "Category deleted successfully.", "")
+	triggerDashboardRefresh(w, h.app.Notify, web.Success, message, "")
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *CategoryHandler) GetTransferForm(w http.ResponseWriter, r *http.Request) {
+	groupID, err := web.GetRequiredQueryParam(r, "group-id")
+	if err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	categoryID, err := web.GetRequiredQueryParam(r, "category-id")
+	if err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	transferForm := &form.TransferCategoryForm{
+		GroupID:    groupID,
+		CategoryID: categoryID,
+		Action:     "move",
+	}
+
+	h.renderTransferForm(w, r, transferForm, http.StatusOK)
+}
+
+func (h *CategoryHandler) TransferCategory(w http.ResponseWriter, r *http.Request) {
+	var transferForm form.TransferCategoryForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &transferForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !transferForm.IsValid() {
+		h.renderTransferForm(w, r, &transferForm, http.StatusUnprocessableEntity)
+		return
+	}
+
+	userID := h.app.Session.GetUserID(r.Context())
+
+	var err error
+	var message string
+	switch transferForm.Action {
+	case "merge":
+		_, err = h.category.Merge(r.Context(), &usecase.MergeCategoryRequest{
+			ID:               transferForm.CategoryID,
+			GroupID:          transferForm.GroupID,
+			UserID:           userID,
+			TargetCategoryID: transferForm.TargetCategoryID,
+		})
+		message = "Categories merged successfully."
+	default:
+		_, err = h.category.Move(r.Context(), &usecase.MoveCategoryRequest{
+			ID:            transferForm.CategoryID,
+			GroupID:       transferForm.GroupID,
+			UserID:        userID,
+			TargetGroupID: transferForm.TargetGroupID,
+		})
+		message = "Category moved successfully."
+	}
+
+	if err != nil {
+		errMessage, isUserFacing := translateCategoryError(err)
+		transferForm.AddNonFieldError(errMessage)
+		h.renderTransferForm(w, r, &transferForm, http.StatusUnprocessableEntity)
+
+		if !isUserFacing {
+			h.app.Logger.Error("failed to transfer category", "action", transferForm.Action, "error", err)
+		}
+		return
+	}
+
+	triggerDashboardRefresh(w, h.app.Notify, web.Success, message, "transfer-category-modal")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *CategoryHandler) renderTransferForm(w http.ResponseWriter, r *http.Request, transferForm *form.TransferCategoryForm, status int) {
+	userID := h.app.Session.GetUserID(r.Context())
+
+	groups, err := h.group.List(r.Context(), userID)
+	if err != nil {
+		h.app.Errors.Error(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	groupOptions, categoryOptions := transferOptions(groups, transferForm.GroupID, transferForm.CategoryID)
+	component := components.TransferCategoryForm(transferForm, groupOptions, categoryOptions)
+	h.app.Template.Render(w, r, component, status)
+}
+
+func transferOptions(groups []*usecase.GroupResponse, groupID string, categoryID string) ([]components.SelectOption, []components.SelectOption) {
+	groupOptions := []components.SelectOption{{Value: "", Label: "Select a group"}}
+	for _, g := range groups {
+		if g.ID != groupID && !g.Archived {
+			groupOptions = append(groupOptions, components.SelectOption{Value: g.ID, Label: g.Name})
+		}
+	}
+
+	return groupOptions, categoryOptions(groups, "", categoryID)
+}
+
+// categoryOptions lists the categories of all groups except the excluded
+// group and category, labelled with their group and active period. Archived
+// groups and categories are left out.
+func categoryOptions(groups []*usecase.GroupResponse, excludeGroupID string, excludeCategoryID string) []components.SelectOption {
+	options := []components.SelectOption{{Value: "", Label: "Select a category"}}
+
+	for _, g := range groups {
+		if g.ID == excludeGroupID || g.Archived {
+			continue
+		}
+		for _, c := range g.Categories {
+			if c.ID == excludeCategoryID || c.Archived {
+				continue
+			}
+			options = append(options, components.SelectOption{
+				Value: c.ID,
+				Label: fmt.Sprintf("%s / %s (%s)", g.Name, c.Name, categoryPeriodLabel(c)),
+			})
+		}
+	}
+
+	return options
+}
+
+func categoryPeriodLabel(c usecase.CategoryResponse) string {
+	switch {
+	case !c.IsRecurrent:
+		return c.StartMonth
+	case c.EndMonth == "":
+		return "from " + c.StartMonth
+	default:
+		return c.StartMonth + " to " + c.EndMonth
+	}
+}
+
 func translateCategoryError(err error) (string, bool) {
 	switch {
 	case errors.Is(err, tracking.ErrEmptyName):
@@ -189,6 +463,26 @@ func translateCategoryError(err error) (string, bool) {
 		return "Category does not belong to this group.", true
 	case errors.Is(err, tracking.ErrGroupNotFound):
 		return "Group not found.", true
+	case errors.Is(err, tracking.ErrCategoryNotFound):
+		return "Category not found.", true
+	case errors.Is(err, tracking.ErrInvalidCategoryOrder):
+		return "The category list is out of date. Please try again.", true
+	case errors.Is(err, tracking.ErrSameGroup):
+		return "Category already belongs to this group.", true
+	case errors.Is(err, tracking.ErrGroupArchived):
+		return "This group is archived. Restore it first.", true
+	case errors.Is(err, tracking.ErrParentCategoryNotFound):
+		return "Parent category not found in this group.", true
+	case errors.Is(err, tracking.ErrCategoryHasSubcategories):
+		return "Move or delete its subcategories first.", true
+	case errors.Is(err, tracking.ErrSameCategory):
+		return "A category cannot be merged into itself.", true
+	case errors.Is(err, tracking.ErrMergePeriodMismatch):
+		return "The target category must be active in every month of this category.", true
+	case errors.Is(err, usecase.ErrInvalidDeleteMode):
+		return "Please choose what happens to the expenses.", true
+	case errors.Is(err, closing.ErrMonthClosed):
+		return monthClosedMessage, true
 	default:
 		return "An unexpected error occurred. Please try again later.", false
 	}
@@ -36,7 +36,7 @@ func TestCategoryHandler_CreateCategory(t *testing.T) {
 			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewCategoryHandler(appCtx, mockCategoryUC)
+		handler := NewCategoryHandler(appCtx, mockCategoryUC, new(MockGroupUseCase))
 
 		formValues := url.Values{}
 		formValues.Set("group-id", "group-123")
@@ -95,7 +95,7 @@ func TestCategoryHandler_CreateCategory(t *testing.T) {
 			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewCategoryHandler(appCtx, mockCategoryUC)
+		handler := NewCategoryHandler(appCtx, mockCategoryUC, new(MockGroupUseCase))
 
 		// Missing group-id and name
 		formValues := url.Values{}
@@ -133,7 +133,7 @@ func TestCategoryHandler_CreateCategory(t *testing.T) {
 			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewCategoryHandler(appCtx, mockCategoryUC)
+		handler := NewCategoryHandler(appCtx, mockCategoryUC, new(MockGroupUseCase))
 
 		formValues := url.Values{}
 		formValues.Set("group-id", "group-123")
@@ -179,7 +179,7 @@ func TestCategoryHandler_CreateCategory(t *testing.T) {
 			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewCategoryHandler(appCtx, mockCategoryUC)
+		handler := NewCategoryHandler(appCtx, mockCategoryUC, new(MockGroupUseCase))
 
 		formValues := url.Values{}
 		formValues.Set("group-id", "group-123")
@@ -211,76 +211,151 @@ func TestCategoryHandler_CreateCategory(t *testing.T) {
 }
 
 func TestCategoryHandler_DeleteCategory(t *testing.T) {
-	t.Run("success", func(t *testing.T) {
-		// Arrange
+	newDeleteHandler := func() (CategoryHandler, *MockCategoryUseCase, *MockGroupUseCase, *MockSessionManager) {
 		mockCategoryUC := new(MockCategoryUseCase)
+		mockGroupUC := new(MockGroupUseCase)
 		mockErrorHandler := new(MockErrorHandler)
 		mockSession := new(MockSessionManager)
 		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
 		appCtx := HandlerContext{
 			Config:  &config.Config{Currency: "USD"},
+			Decoder: form.NewDecoder(),
 			Logger:  logger,
 			Session: mockSession,
 			Errors:  newTestErrors(logger, mockErrorHandler),
 			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewCategoryHandler(appCtx, mockCategoryUC)
+		return NewCategoryHandler(appCtx, mockCategoryUC, mockGroupUC), mockCategoryUC, mockGroupUC, mockSession
+	}
 
-		req := httptest.NewRequest(http.MethodDelete, "/groups/group-1/categories/cat-1", nil)
-		req.SetPathValue("groupID", "group-1")
-		req.SetPathValue("id", "cat-1")
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		handler, mockCategoryUC, _, mockSession := newDeleteHandler()
+
+		formValues := url.Values{}
+		formValues.Set("group-id", "group-1")
+		formValues.Set("category-id", "cat-1")
+		formValues.Set("current-month", "2024-05")
+		formValues.Set("delete-mode", "end")
+
+		req := httptest.NewRequest(http.MethodPost, "/categories/delete", strings.NewReader(formValues.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		rec := httptest.NewRecorder()
 
 		mockSession.On("GetUserID", req.Context()).Return("user-123")
-		mockCategoryUC.On("Delete", req.Context(), "user-123", "group-1", "cat-1").Return(nil)
+		mockCategoryUC.On("Delete", req.Context(), &usecase.DeleteCategoryRequest{
+			ID:      "cat-1",
+			GroupID: "group-1",
+			UserID:  "user-123",
+			Mode:    "end",
+			Month:   "2024-05",
+		}).Return(nil)
 
 		// Act
 		handler.DeleteCategory(rec, req)
 
 		// Assert
 		assert.Equal(t, http.StatusNoContent, rec.Code)
-		assert.Contains(t, rec.Header().Get("HX-Trigger"), "dashboard:refresh")
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "Category ended. Earlier months are kept.")
 		mockCategoryUC.AssertExpectations(t)
 		mockSession.AssertExpectations(t)
 	})
 
 	t.Run("usecase error", func(t *testing.T) {
+		// Arrange
+		handler, mockCategoryUC, mockGroupUC, mockSession := newDeleteHandler()
+
+		formValues := url.Values{}
+		formValues.Set("group-id", "group-1")
+		formValues.Set("category-id", "cat-1")
+		formValues.Set("current-month", "2024-05")
+		formValues.Set("delete-mode", "reassign")
+		formValues.Set("target-category-id", "cat-2")
+
+		req := httptest.NewRequest(http.MethodPost, "/categories/delete", strings.NewReader(formValues.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockCategoryUC.On("Delete", req.Context(), mock.Anything).Return(tracking.ErrMergePeriodMismatch)
+		mockCategoryUC.On("DeletionImpact", req.Context(), "user-123", "group-1", "cat-1", "2024-05").Return(&usecase.DeletionImpactResponse{
+			ExpenseCount: 2, MonthCount: 1, FirstMonth: "2024-05", LastMonth: "2024-05", LaterExpenseCount: 2,
+		}, nil)
+		mockGroupUC.On("List", req.Context(), "user-123").Return([]*usecase.GroupResponse{}, nil)
+
+		// Act
+		handler.DeleteCategory(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "The target category must be active in every month of this category.")
+		mockCategoryUC.AssertExpectations(t)
+	})
+}
+
+func TestCategoryHandler_GetDeleteForm(t *testing.T) {
+	t.Run("falls back to plain delete without expenses", func(t *testing.T) {
 		// Arrange
 		mockCategoryUC := new(MockCategoryUseCase)
+		mockGroupUC := new(MockGroupUseCase)
 		mockErrorHandler := new(MockErrorHandler)
 		mockSession := new(MockSessionManager)
 		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
 		appCtx := HandlerContext{
-			Config:  &config.Config{Currency: "USD"},
 			Logger:  logger,
 			Session: mockSession,
 			Errors:  newTestErrors(logger, mockErrorHandler),
-			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewCategoryHandler(appCtx, mockCategoryUC)
+		handler := NewCategoryHandler(appCtx, mockCategoryUC, mockGroupUC)
 
-		req := httptest.NewRequest(http.MethodDelete, "/groups/group-1/categories/cat-1", nil)
-		req.SetPathValue("groupID", "group-1")
-		req.SetPathValue("id", "cat-1")
+		req := httptest.NewRequest(http.MethodGet, "/categories/delete/form?group-id=group-1&category-id=cat-1&current-month=2024-05", nil)
 		rec := httptest.NewRecorder()
 
-		expectedErr := errors.New("delete failed")
 		mockSession.On("GetUserID", req.Context()).Return("user-123")
-		mockCategoryUC.On("Delete", req.Context(), "user-123", "group-1", "cat-1").Return(expectedErr)
+		mockCategoryUC.On("DeletionImpact", req.Context(), "user-123", "group-1", "cat-1", "2024-05").Return(&usecase.DeletionImpactResponse{}, nil)
+		mockGroupUC.On("List", req.Context(), "user-123").Return([]*usecase.GroupResponse{}, nil)
 
-		mockErrorHandler.On("Error", rec, req, http.StatusInternalServerError, expectedErr).Return()
+		// Act
+		handler.GetDeleteForm(rec, req)
+
+		// Assert
+		body := rec.Body.String()
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, body, "No expenses recorded")
+		assert.Contains(t, body, `name="delete-mode" value="hard"`)
+	})
+
+	t.Run("not found when category is not accessible", func(t *testing.T) {
+		// Arrange
+		mockCategoryUC := new(MockCategoryUseCase)
+		mockErrorHandler := new(MockErrorHandler)
+		mockSession := new(MockSessionManager)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		appCtx := HandlerContext{
+			Logger:  logger,
+			Session: mockSession,
+			Errors:  newTestErrors(logger, mockErrorHandler),
+		}
+
+		handler := NewCategoryHandler(appCtx, mockCategoryUC, new(MockGroupUseCase))
+
+		req := httptest.NewRequest(http.MethodGet, "/categories/delete/form?group-id=group-1&category-id=cat-1", nil)
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockCategoryUC.On("DeletionImpact", req.Context(), "user-123", "group-1", "cat-1", "").Return(nil, tracking.ErrGroupNotFound)
+		mockErrorHandler.On("Error", rec, req, http.StatusNotFound, tracking.ErrGroupNotFound).Return()
 
 		// Act
-		handler.DeleteCategory(rec, req)
+		handler.GetDeleteForm(rec, req)
 
 		// Assert
-		mockCategoryUC.AssertExpectations(t)
 		mockErrorHandler.AssertExpectations(t)
-		mockSession.AssertExpectations(t)
 	})
 }
 
@@ -300,7 +375,7 @@ func TestCategoryHandler_GetCreateForm(t *testing.T) {
 			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewCategoryHandler(appCtx, mockCategoryUC)
+		handler := NewCategoryHandler(appCtx, mockCategoryUC, new(MockGroupUseCase))
 
 		req := httptest.NewRequest(http.MethodGet, "/categories/form?group-id=group-1&category-start=2023-01", nil)
 		rec := httptest.NewRecorder()
@@ -330,7 +405,7 @@ func TestCategoryHandler_GetCreateForm(t *testing.T) {
 			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewCategoryHandler(appCtx, mockCategoryUC)
+		handler := NewCategoryHandler(appCtx, mockCategoryUC, new(MockGroupUseCase))
 
 		req := httptest.NewRequest(http.MethodGet, "/categories/form?category-start=2023-01", nil)
 		rec := httptest.NewRecorder()
@@ -359,7 +434,7 @@ func TestCategoryHandler_GetCreateForm(t *testing.T) {
 			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewCategoryHandler(appCtx, mockCategoryUC)
+		handler := NewCategoryHandler(appCtx, mockCategoryUC, new(MockGroupUseCase))
 
 		req := httptest.NewRequest(http.MethodGet, "/categories/form?group-id=group-1", nil)
 		rec := httptest.NewRecorder()
@@ -373,3 +448,341 @@ func TestCategoryHandler_GetCreateForm(t *testing.T) {
 		mockErrorHandler.AssertExpectations(t)
 	})
 }
+
+func TestCategoryHandler_ReorderCategories(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockCategoryUC := new(MockCategoryUseCase)
+		mockErrorHandler := new(MockErrorHandler)
+		mockSession := new(MockSessionManager)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		appCtx := HandlerContext{
+			Logger:  logger,
+			Session: mockSession,
+			Decoder: form.NewDecoder(),
+			Errors:  newTestErrors(logger, mockErrorHandler),
+			Notify:  respond.NewNotify(logger),
+		}
+
+		handler := NewCategoryHandler(appCtx, mockCategoryUC, new(MockGroupUseCase))
+
+		formValues := url.Values{"ids": {"cat-2", "cat-1"}}
+		req := httptest.NewRequest(http.MethodPost, "/groups/group-1/categories/reorder", strings.NewReader(formValues.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetPathValue("groupID", "group-1")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockCategoryUC.On("Reorder", req.Context(), "user-123", "group-1", []string{"cat-2", "cat-1"}).Return(nil)
+
+		// Act
+		handler.ReorderCategories(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		mockCategoryUC.AssertExpectations(t)
+		mockSession.AssertExpectations(t)
+	})
+
+	t.Run("usecase error refreshes dashboard", func(t *testing.T) {
+		// Arrange
+		mockCategoryUC := new(MockCategoryUseCase)
+		mockErrorHandler := new(MockErrorHandler)
+		mockSession := new(MockSessionManager)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		appCtx := HandlerContext{
+			Logger:  logger,
+			Session: mockSession,
+			Decoder: form.NewDecoder(),
+			Errors:  newTestErrors(logger, mockErrorHandler),
+			Notify:  respond.NewNotify(logger),
+		}
+
+		handler := NewCategoryHandler(appCtx, mockCategoryUC, new(MockGroupUseCase))
+
+		formValues := url.Values{"ids": {"cat-1"}}
+		req := httptest.NewRequest(http.MethodPost, "/groups/group-1/categories/reorder", strings.NewReader(formValues.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetPathValue("groupID", "group-1")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockCategoryUC.On("Reorder", req.Context(), "user-123", "group-1", []string{"cat-1"}).Return(tracking.ErrCategoryNotFound)
+
+		// Act
+		handler.ReorderCategories(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "dashboard:refresh")
+		mockCategoryUC.AssertExpectations(t)
+	})
+}
+
+func TestCategoryHandler_TransferCategory(t *testing.T) {
+	newTransferHandler := func() (CategoryHandler, *MockCategoryUseCase, *MockGroupUseCase, *MockSessionManager) {
+		mockCategoryUC := new(MockCategoryUseCase)
+		mockGroupUC := new(MockGroupUseCase)
+		mockErrorHandler := new(MockErrorHandler)
+		mockSession := new(MockSessionManager)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		appCtx := HandlerContext{
+			Config:  &config.Config{Currency: "USD"},
+			Decoder: form.NewDecoder(),
+			Logger:  logger,
+			Session: mockSession,
+			Errors:  newTestErrors(logger, mockErrorHandler),
+			Notify:  respond.NewNotify(logger),
+		}
+
+		return NewCategoryHandler(appCtx, mockCategoryUC, mockGroupUC), mockCategoryUC, mockGroupUC, mockSession
+	}
+
+	t.Run("moves category", func(t *testing.T) {
+		// Arrange
+		handler, mockCategoryUC, _, mockSession := newTransferHandler()
+
+		formValues := url.Values{}
+		formValues.Set("group-id", "group-1")
+		formValues.Set("category-id", "cat-1")
+		formValues.Set("transfer-action", "move")
+		formValues.Set("target-group-id", "group-2")
+
+		req := httptest.NewRequest(http.MethodPost, "/categories/transfer", strings.NewReader(formValues.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockCategoryUC.On("Move", req.Context(), &usecase.MoveCategoryRequest{
+			ID:            "cat-1",
+			GroupID:       "group-1",
+			UserID:        "user-123",
+			TargetGroupID: "group-2",
+		}).Return(&usecase.CategoryResponse{ID: "cat-1"}, nil)
+
+		// Act
+		handler.TransferCategory(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "dashboard:refresh")
+		mockCategoryUC.AssertExpectations(t)
+		mockCategoryUC.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything)
+	})
+
+	t.Run("merges category", func(t *testing.T) {
+		// Arrange
+		handler, mockCategoryUC, _, mockSession := newTransferHandler()
+
+		formValues := url.Values{}
+		formValues.Set("group-id", "group-1")
+		formValues.Set("category-id", "cat-1")
+		formValues.Set("transfer-action", "merge")
+		formValues.Set("target-category-id", "cat-2")
+
+		req := httptest.NewRequest(http.MethodPost, "/categories/transfer", strings.NewReader(formValues.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockCategoryUC.On("Merge", req.Context(), &usecase.MergeCategoryRequest{
+			ID:               "cat-1",
+			GroupID:          "group-1",
+			UserID:           "user-123",
+			TargetCategoryID: "cat-2",
+		}).Return(&usecase.CategoryResponse{ID: "cat-2"}, nil)
+
+		// Act
+		handler.TransferCategory(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "Categories merged successfully.")
+		mockCategoryUC.AssertExpectations(t)
+	})
+
+	t.Run("invalid form re-renders with options", func(t *testing.T) {
+		// Arrange
+		handler, mockCategoryUC, mockGroupUC, mockSession := newTransferHandler()
+
+		formValues := url.Values{}
+		formValues.Set("group-id", "group-1")
+		formValues.Set("category-id", "cat-1")
+		formValues.Set("transfer-action", "move")
+
+		req := httptest.NewRequest(http.MethodPost, "/categories/transfer", strings.NewReader(formValues.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockGroupUC.On("List", req.Context(), "user-123").Return([]*usecase.GroupResponse{
+			{ID: "group-1", Name: "Utilities"},
+			{ID: "group-2", Name: "Subscriptions"},
+		}, nil)
+
+		// Act
+		handler.TransferCategory(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "please choose a group")
+		assert.Contains(t, rec.Body.String(), "Subscriptions")
+		assert.NotContains(t, rec.Body.String(), "Utilities")
+		mockCategoryUC.AssertNotCalled(t, "Move", mock.Anything, mock.Anything)
+	})
+
+	t.Run("usecase error - user facing", func(t *testing.T) {
+		// Arrange
+		handler, mockCategoryUC, mockGroupUC, mockSession := newTransferHandler()
+
+		formValues := url.Values{}
+		formValues.Set("group-id", "group-1")
+		formValues.Set("category-id", "cat-1")
+		formValues.Set("transfer-action", "move")
+		formValues.Set("target-group-id", "group-2")
+
+		req := httptest.NewRequest(http.MethodPost, "/categories/transfer", strings.NewReader(formValues.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockCategoryUC.On("Move", req.Context(), mock.Anything).Return(nil, tracking.ErrCategoryNameExists)
+		mockGroupUC.On("List", req.Context(), "user-123").Return([]*usecase.GroupResponse{}, nil)
+
+		// Act
+		handler.TransferCategory(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Category name already exists")
+	})
+}
+
+func TestCategoryHandler_GetTransferForm(t *testing.T) {
+	t.Run("lists other groups and categories", func(t *testing.T) {
+		// Arrange
+		mockCategoryUC := new(MockCategoryUseCase)
+		mockGroupUC := new(MockGroupUseCase)
+		mockErrorHandler := new(MockErrorHandler)
+		mockSession := new(MockSessionManager)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		appCtx := HandlerContext{
+			Logger:  logger,
+			Session: mockSession,
+			Errors:  newTestErrors(logger, mockErrorHandler),
+		}
+
+		handler := NewCategoryHandler(appCtx, mockCategoryUC, mockGroupUC)
+
+		req := httptest.NewRequest(http.MethodGet, "/categories/transfer/form?group-id=group-1&category-id=cat-1", nil)
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockGroupUC.On("List", req.Context(), "user-123").Return([]*usecase.GroupResponse{
+			{ID: "group-1", Name: "Utilities", Categories: []usecase.CategoryResponse{
+				{ID: "cat-1", Name: "Internet", StartMonth: "2024-01", IsRecurrent: true},
+				{ID: "cat-3", Name: "Power", StartMonth: "2024-01", IsRecurrent: true, EndMonth: "2024-12"},
+			}},
+			{ID: "group-2", Name: "Subscriptions", Categories: []usecase.CategoryResponse{
+				{ID: "cat-2", Name: "Streaming", StartMonth: "2024-03"},
+			}},
+		}, nil)
+
+		// Act
+		handler.GetTransferForm(rec, req)
+
+		// Assert
+		body := rec.Body.String()
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, body, "Utilities / Power (2024-01 to 2024-12)")
+		assert.Contains(t, body, "Subscriptions / Streaming (2024-03)")
+		assert.NotContains(t, body, "Internet")
+		mockGroupUC.AssertExpectations(t)
+	})
+
+	t.Run("missing category-id", func(t *testing.T) {
+		// Arrange
+		mockErrorHandler := new(MockErrorHandler)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		appCtx := HandlerContext{
+			Logger: logger,
+			Errors: newTestErrors(logger, mockErrorHandler),
+		}
+
+		handler := NewCategoryHandler(appCtx, new(MockCategoryUseCase), new(MockGroupUseCase))
+
+		req := httptest.NewRequest(http.MethodGet, "/categories/transfer/form?group-id=group-1", nil)
+		rec := httptest.NewRecorder()
+
+		mockErrorHandler.On("Error", rec, req, http.StatusBadRequest, mock.Anything).Return()
+
+		// Act
+		handler.GetTransferForm(rec, req)
+
+		// Assert
+		mockErrorHandler.AssertExpectations(t)
+	})
+}
+
+func TestCategoryHandler_ArchiveCategory(t *testing.T) {
+	newArchiveHandler := func() (CategoryHandler, *MockCategoryUseCase, *MockSessionManager) {
+		mockSession := new(MockSessionManager)
+		mockCategoryUC := new(MockCategoryUseCase)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		appCtx := HandlerContext{
+			Session: mockSession,
+			Logger:  logger,
+			Errors:  newTestErrors(logger, new(MockErrorHandler)),
+			Notify:  respond.NewNotify(logger),
+		}
+
+		return NewCategoryHandler(appCtx, mockCategoryUC, new(MockGroupUseCase)), mockCategoryUC, mockSession
+	}
+
+	t.Run("archives category", func(t *testing.T) {
+		// Arrange
+		handler, mockCategoryUC, mockSession := newArchiveHandler()
+
+		req := httptest.NewRequest(http.MethodPost, "/groups/group-1/categories/cat-1/archive", nil)
+		req.SetPathValue("groupID", "group-1")
+		req.SetPathValue("id", "cat-1")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockCategoryUC.On("Archive", req.Context(), "user-123", "group-1", "cat-1").Return(nil)
+
+		// Act
+		handler.ArchiveCategory(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "Category archived.")
+		mockCategoryUC.AssertExpectations(t)
+	})
+
+	t.Run("restore reports category not found", func(t *testing.T) {
+		// Arrange
+		handler, mockCategoryUC, mockSession := newArchiveHandler()
+
+		req := httptest.NewRequest(http.MethodPost, "/groups/group-1/categories/cat-1/restore", nil)
+		req.SetPathValue("groupID", "group-1")
+		req.SetPathValue("id", "cat-1")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockCategoryUC.On("Restore", req.Context(), "user-123", "group-1", "cat-1").Return(tracking.ErrCategoryNotFound)
+
+		// Act
+		handler.RestoreCategory(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "Category not found.")
+	})
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
+	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/form"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/views"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/madalinpopa/gocost-web/ui/templates/components"
+)
+
+// monthClosedMessage is shown when a write is refused because its month is
+// closed.
+const monthClosedMessage = "This month is closed. Reopen it to make changes."
+
+type ClosingHandler struct {
+	app     HandlerContext
+	closing usecase.ClosingUseCase
+}
+
+func NewClosingHandler(app HandlerContext, closing usecase.ClosingUseCase) ClosingHandler {
+	return ClosingHandler{
+		app:     app,
+		closing: closing,
+	}
+}
+
+func (h *ClosingHandler) GetCloseForm(w http.ResponseWriter, r *http.Request) {
+	month, err := web.GetRequiredQueryParam(r, "month")
+	if err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	h.renderCloseForm(w, r, &form.CloseMonthForm{Month: month}, http.StatusOK)
+}
+
+func (h *ClosingHandler) CloseMonth(w http.ResponseWriter, r *http.Request) {
+	var closeForm form.CloseMonthForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &closeForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !closeForm.IsValid() {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, errors.New("invalid month"))
+		return
+	}
+
+	_, err := h.closing.Close(r.Context(), &usecase.CloseMonthRequest{
+		UserID:       h.app.Session.GetUserID(r.Context()),
+		Currency:     h.app.Session.GetCurrency(r.Context()),
+		Month:        closeForm.Month,
+		AcceptUnpaid: closeForm.AcceptUnpaid,
+	})
+	if err != nil {
+		errMessage, isUserFacing := translateClosingError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to close month", "error", err)
+		}
+		closeForm.AddNonFieldError(errMessage)
+		h.renderCloseForm(w, r, &closeForm, http.StatusUnprocessableEntity)
+		return
+	}
+
+	triggerDashboardRefresh(w, h.app.Notify, web.Success, "Month closed.", "close-month-modal")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ClosingHandler) ReopenMonth(w http.ResponseWriter, r *http.Request) {
+	month := r.PathValue("month")
+	userID := h.app.Session.GetUserID(r.Context())
+
+	if err := h.closing.Reopen(r.Context(), userID, month); err != nil {
+		errMessage, isUserFacing := translateClosingError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to reopen month", "error", err)
+		}
+
+		triggerDashboardRefresh(w, h.app.Notify, web.ErrorMsg, errMessage, "")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		return
+	}
+
+	triggerDashboardRefresh(w, h.app.Notify, web.Success, "Month reopened.", "")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ClosingHandler) renderCloseForm(w http.ResponseWriter, r *http.Request, closeForm *form.CloseMonthForm, status int) {
+	userID := h.app.Session.GetUserID(r.Context())
+
+	monthStatus, err := h.closing.Status(r.Context(), userID, closeForm.Month)
+	if err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	view, err := views.NewMonthStatusView(monthStatus, h.app.Session.GetCurrency(r.Context()))
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	h.app.Template.Render(w, r, components.CloseMonthForm(view, closeForm), status)
+}
+
+func translateClosingError(err error) (string, bool) {
+	switch {
+	case errors.Is(err, closing.ErrUnpaidExpenses):
+		return "Some expenses are still unpaid. Pay them or accept them as unpaid to close the month.", true
+	case errors.Is(err, closing.ErrMonthClosed):
+		return "This month is already closed.", true
+	case errors.Is(err, closing.ErrMonthNotClosed):
+		return "This month is not closed.", true
+	case errors.Is(err, tracking.ErrInvalidMonth):
+		return "Invalid month format.", true
+	default:
+		return "An unexpected error occurred. Please try again later.", false
+	}
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/form/v4"
+	"github.com/madalinpopa/gocost-web/internal/config"
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/respond"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestClosingHandler(session *MockSessionManager, closingUC *MockClosingUseCase) ClosingHandler {
+	cfg := &config.Config{Currency: "USD"}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	return NewClosingHandler(HandlerContext{
+		Config:   cfg,
+		Decoder:  form.NewDecoder(),
+		Logger:   logger,
+		Session:  session,
+		Errors:   newTestErrors(logger, new(MockErrorHandler)),
+		Notify:   respond.NewNotify(logger),
+		Template: web.NewTemplate(logger, cfg),
+	}, closingUC)
+}
+
+func TestClosingHandler_GetCloseForm(t *testing.T) {
+	// Arrange
+	mockSession := new(MockSessionManager)
+	mockClosingUC := new(MockClosingUseCase)
+	handler := newTestClosingHandler(mockSession, mockClosingUC)
+
+	req := httptest.NewRequest(http.MethodGet, "/months/close/form?month=2024-03", nil)
+	rec := httptest.NewRecorder()
+
+	mockSession.On("GetUserID", req.Context()).Return("user-123")
+	mockSession.On("GetCurrency", req.Context()).Return("USD")
+	mockClosingUC.On("Status", req.Context(), "user-123", "2024-03").Return(&usecase.MonthStatusResponse{
+		Month:       "2024-03",
+		UnpaidCount: 2,
+		UnpaidCents: 4550,
+	}, nil)
+
+	// Act
+	handler.GetCloseForm(rec, req)
+
+	// Assert
+	body := rec.Body.String()
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, body, "March 2024")
+	assert.Contains(t, body, "2 unpaid expense(s)")
+	assert.Contains(t, body, `name="accept-unpaid"`)
+}
+
+func TestClosingHandler_CloseMonth(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockClosingUC := new(MockClosingUseCase)
+		handler := newTestClosingHandler(mockSession, mockClosingUC)
+
+		formValues := url.Values{}
+		formValues.Set("month", "2024-03")
+		formValues.Set("accept-unpaid", "true")
+
+		req := httptest.NewRequest(http.MethodPost, "/months/close", strings.NewReader(formValues.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockClosingUC.On("Close", req.Context(), &usecase.CloseMonthRequest{
+			UserID:       "user-123",
+			Currency:     "USD",
+			Month:        "2024-03",
+			AcceptUnpaid: true,
+		}).Return(&usecase.MonthStatusResponse{Month: "2024-03", Closed: true}, nil)
+
+		// Act
+		handler.CloseMonth(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "Month closed.")
+		mockClosingUC.AssertExpectations(t)
+	})
+
+	t.Run("re-renders the form when expenses are unpaid", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockClosingUC := new(MockClosingUseCase)
+		handler := newTestClosingHandler(mockSession, mockClosingUC)
+
+		formValues := url.Values{}
+		formValues.Set("month", "2024-03")
+
+		req := httptest.NewRequest(http.MethodPost, "/months/close", strings.NewReader(formValues.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockClosingUC.On("Close", req.Context(), mock.Anything).Return(nil, closing.ErrUnpaidExpenses)
+		mockClosingUC.On("Status", req.Context(), "user-123", "2024-03").Return(&usecase.MonthStatusResponse{
+			Month:       "2024-03",
+			UnpaidCount: 1,
+			UnpaidCents: 1000,
+		}, nil)
+
+		// Act
+		handler.CloseMonth(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Some expenses are still unpaid.")
+	})
+}
+
+func TestClosingHandler_ReopenMonth(t *testing.T) {
+	// Arrange
+	mockSession := new(MockSessionManager)
+	mockClosingUC := new(MockClosingUseCase)
+	handler := newTestClosingHandler(mockSession, mockClosingUC)
+
+	req := httptest.NewRequest(http.MethodPost, "/months/2024-03/reopen", nil)
+	req.SetPathValue("month", "2024-03")
+	rec := httptest.NewRecorder()
+
+	mockSession.On("GetUserID", req.Context()).Return("user-123")
+	mockClosingUC.On("Reopen", req.Context(), "user-123", "2024-03").Return(nil)
+
+	// Act
+	handler.ReopenMonth(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Contains(t, rec.Header().Get("HX-Trigger"), "Month reopened.")
+	mockClosingUC.AssertExpectations(t)
+}
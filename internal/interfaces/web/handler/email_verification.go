@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/madalinpopa/gocost-web/ui/templates/pages/public"
+)
+
+// verifyEmailPath is the page the emailed verification links open.
+const verifyEmailPath = "/verify-email"
+
+type EmailVerificationHandler struct {
+	app          HandlerContext
+	verification usecase.EmailVerificationUseCase
+}
+
+func NewEmailVerificationHandler(app HandlerContext, verification usecase.EmailVerificationUseCase) EmailVerificationHandler {
+	return EmailVerificationHandler{
+		app:          app,
+		verification: verification,
+	}
+}
+
+// VerifyEmail confirms the address in the emailed link. It works whether or
+// not the user is signed in.
+func (h EmailVerificationHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	user, err := h.verification.VerifyEmail(r.Context(), r.URL.Query().Get("token"))
+	if err != nil && !errors.Is(err, identity.ErrInvalidVerificationToken) {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	data := h.app.Template.GetData(r)
+	verified := err == nil
+	if verified && data.User.ID == user.ID {
+		h.app.Session.SetEmailVerified(r.Context(), true)
+		data.User.EmailVerified = true
+	}
+
+	h.app.Template.Render(w, r, public.VerifyEmailPage(data, verified), http.StatusOK)
+}
+
+// ResendVerification emails the signed in user a new verification link.
+func (h EmailVerificationHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	err := sendVerification(r.Context(), h.app, h.verification, h.app.Session.GetUserID(r.Context()))
+	switch {
+	case errors.Is(err, identity.ErrVerificationRateLimited):
+		h.app.Notify.Toast(w, web.Warning, "A link was sent moments ago. Please wait a couple of minutes before asking again.")
+	case err != nil:
+		h.app.Errors.ServerError(w, r, err)
+		return
+	default:
+		h.app.Notify.Toast(w, web.Success, "Verification link sent. Check your inbox.")
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func sendVerification(ctx context.Context, app HandlerContext, verification usecase.EmailVerificationUseCase, userID string) error {
+	return verification.SendVerification(ctx, &usecase.SendVerificationRequest{
+		UserID:    userID,
+		VerifyURL: app.Config.BaseURL + verifyEmailPath,
+	})
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/form/v4"
+	"github.com/madalinpopa/gocost-web/internal/config"
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/respond"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestEmailVerificationHandler(session *MockSessionManager, verificationUC *MockEmailVerificationUseCase) EmailVerificationHandler {
+	cfg := &config.Config{Currency: "USD", BaseURL: "https://gocost.example"}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	errHandler := newTestErrors(logger, new(MockErrorHandler))
+
+	return NewEmailVerificationHandler(HandlerContext{
+		Config:   cfg,
+		Decoder:  form.NewDecoder(),
+		Logger:   logger,
+		Session:  session,
+		Errors:   errHandler,
+		Htmx:     respond.NewHtmx(errHandler),
+		Notify:   respond.NewNotify(logger),
+		Template: web.NewTemplate(logger, cfg),
+	}, verificationUC)
+}
+
+func TestEmailVerificationHandler_VerifyEmail(t *testing.T) {
+	t.Run("verifies the address and clears the banner of the signed in user", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockVerificationUC := new(MockEmailVerificationUseCase)
+		handler := newTestEmailVerificationHandler(mockSession, mockVerificationUC)
+
+		req := httptest.NewRequest(http.MethodGet, "/verify-email?token=abc", nil)
+		ctx := context.WithValue(req.Context(), web.AuthenticatedUserKey, web.AuthenticatedUser{ID: "user-123", Username: "alice"})
+		req = req.WithContext(ctx)
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetCurrency", req.Context()).Return("USD").Maybe()
+		mockSession.On("SetEmailVerified", req.Context(), true).Return()
+		mockVerificationUC.On("VerifyEmail", req.Context(), "abc").Return(&usecase.UserResponse{ID: "user-123", EmailVerified: true}, nil)
+
+		// Act
+		handler.VerifyEmail(rec, req)
+
+		// Assert
+		body := rec.Body.String()
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, body, "VERIFIED")
+		assert.NotContains(t, body, `id="verify-email-banner"`)
+		mockSession.AssertExpectations(t)
+	})
+
+	t.Run("explains an invalid link", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockVerificationUC := new(MockEmailVerificationUseCase)
+		handler := newTestEmailVerificationHandler(mockSession, mockVerificationUC)
+
+		req := httptest.NewRequest(http.MethodGet, "/verify-email?token=abc", nil)
+		rec := httptest.NewRecorder()
+
+		mockVerificationUC.On("VerifyEmail", req.Context(), "abc").Return(nil, identity.ErrInvalidVerificationToken)
+
+		// Act
+		handler.VerifyEmail(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "This verification link is invalid or has expired.")
+		mockSession.AssertNotCalled(t, "SetEmailVerified", mock.Anything, mock.Anything)
+	})
+}
+
+func TestEmailVerificationHandler_ResendVerification(t *testing.T) {
+	t.Run("sends a link to the verify page", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockVerificationUC := new(MockEmailVerificationUseCase)
+		handler := newTestEmailVerificationHandler(mockSession, mockVerificationUC)
+
+		req := httptest.NewRequest(http.MethodPost, "/verify-email/resend", nil)
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockVerificationUC.On("SendVerification", req.Context(), &usecase.SendVerificationRequest{
+			UserID:    "user-123",
+			VerifyURL: "https://gocost.example/verify-email",
+		}).Return(nil)
+
+		// Act
+		handler.ResendVerification(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "Verification link sent.")
+		mockVerificationUC.AssertExpectations(t)
+	})
+
+	t.Run("asks to wait when a link was just sent", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockVerificationUC := new(MockEmailVerificationUseCase)
+		handler := newTestEmailVerificationHandler(mockSession, mockVerificationUC)
+
+		req := httptest.NewRequest(http.MethodPost, "/verify-email/resend", nil)
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockVerificationUC.On("SendVerification", req.Context(), mock.Anything).Return(identity.ErrVerificationRateLimited)
+
+		// Act
+		handler.ResendVerification(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "warning")
+	})
+}
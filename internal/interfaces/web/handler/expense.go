@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/madalinpopa/gocost-web/internal/domain/account"
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
 	"github.com/madalinpopa/gocost-web/internal/domain/expense"
 	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
 	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
@@ -16,20 +18,31 @@ import (
 type ExpenseHandler struct {
 	app     HandlerContext
 	expense usecase.ExpenseUseCase
+	group   usecase.GroupUseCase
 }
 
-func NewExpenseHandler(app HandlerContext, expense usecase.ExpenseUseCase) ExpenseHandler {
+func NewExpenseHandler(app HandlerContext, expense usecase.ExpenseUseCase, group usecase.GroupUseCase) ExpenseHandler {
 	return ExpenseHandler{
 		app:     app,
 		expense: expense,
+		group:   group,
 	}
 }
 
+// GetCreateForm renders the form to add an expense to the category. With a
+// date instead, as from the bill calendar, the category is picked in the
+// form.
 func (h *ExpenseHandler) GetCreateForm(w http.ResponseWriter, r *http.Request) {
-	categoryID, err := web.GetRequiredQueryParam(r, "category-id")
-	if err != nil {
-		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
-		return
+	date := r.URL.Query().Get("date")
+
+	var categoryID string
+	if date == "" {
+		var err error
+		categoryID, err = web.GetRequiredQueryParam(r, "category-id")
+		if err != nil {
+			h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+			return
+		}
 	}
 
 	month, err := web.GetRequiredQueryParam(r, "month")
@@ -39,12 +52,13 @@ func (h *ExpenseHandler) GetCreateForm(w http.ResponseWriter, r *http.Request) {
 	}
 
 	expenseForm := &form.CreateExpenseForm{
-		CategoryID: categoryID,
-		Month:      month,
+		CategoryID:     categoryID,
+		Month:          month,
+		Date:           date,
+		ChooseCategory: date != "",
 	}
 
-	component := components.AddExpenseForm(expenseForm, h.app.Config.Currency)
-	h.app.Template.Render(w, r, component, http.StatusOK)
+	h.renderCreateForm(w, r, expenseForm, http.StatusOK)
 }
 
 func (h *ExpenseHandler) CreateExpense(w http.ResponseWriter, r *http.Request) {
@@ -61,14 +75,7 @@ func (h *ExpenseHandler) CreateExpense(w http.ResponseWriter, r *http.Request) {
 
 	expenseForm.Validate()
 	if !expenseForm.IsValid() {
-		component := components.AddExpenseForm(&expenseForm, h.app.Config.Currency)
-		h.app.Template.Render(w, r, component, http.StatusUnprocessableEntity)
-		return
-	}
-
-	spentAt, err := time.Parse("2006-01", expenseForm.Month)
-	if err != nil {
-		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		h.renderCreateForm(w, r, &expenseForm, http.StatusUnprocessableEntity)
 		return
 	}
 
@@ -88,17 +95,17 @@ func (h *ExpenseHandler) CreateExpense(w http.ResponseWriter, r *http.Request) {
 		CategoryID:  expenseForm.CategoryID,
 		Amount:      expenseForm.ParsedAmount(),
 		Description: expenseForm.Description,
-		SpentAt:     spentAt,
+		SpentAt:     expenseForm.ParsedSpentAt(),
 		IsPaid:      isPaid,
 		PaidAt:      paidAt,
+		AccountID:   expenseForm.AccountID,
 	}
 
-	_, err = h.expense.Create(r.Context(), req)
+	_, err := h.expense.Create(r.Context(), req)
 	if err != nil {
 		errMessage, isUserFacing := translateExpenseError(err)
 		expenseForm.AddNonFieldError(errMessage)
-		component := components.AddExpenseForm(&expenseForm, h.app.Config.Currency)
-		h.app.Template.Render(w, r, component, http.StatusUnprocessableEntity)
+		h.renderCreateForm(w, r, &expenseForm, http.StatusUnprocessableEntity)
 
 		if !isUserFacing {
 			h.app.Logger.Error("failed to create expense", "error", err)
@@ -169,6 +176,7 @@ func (h *ExpenseHandler) EditExpense(w http.ResponseWriter, r *http.Request) {
 		SpentAt:     existing.SpentAt,
 		IsPaid:      isPaid,
 		PaidAt:      paidAt,
+		AccountID:   expenseForm.AccountID,
 	}
 
 	_, err = h.expense.Update(r.Context(), req)
@@ -194,6 +202,11 @@ func (h *ExpenseHandler) DeleteExpense(w http.ResponseWriter, r *http.Request) {
 	expenseID := r.PathValue("id")
 
 	if err := h.expense.Delete(r.Context(), userID, expenseID); err != nil {
+		if errors.Is(err, closing.ErrMonthClosed) {
+			triggerDashboardRefresh(w, h.app.Notify, web.ErrorMsg, monthClosedMessage, "")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
 		h.app.Errors.Error(w, r, http.StatusInternalServerError, err)
 		return
 	}
@@ -202,6 +215,23 @@ func (h *ExpenseHandler) DeleteExpense(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// renderCreateForm renders the add expense form, with the categories to
+// pick from when the form asks for one.
+func (h *ExpenseHandler) renderCreateForm(w http.ResponseWriter, r *http.Request, expenseForm *form.CreateExpenseForm, status int) {
+	var categories []components.SelectOption
+	if expenseForm.ChooseCategory {
+		groups, err := h.group.List(r.Context(), h.app.Session.GetUserID(r.Context()))
+		if err != nil {
+			h.app.Errors.ServerError(w, r, err)
+			return
+		}
+		categories = categoryOptions(groups, "", "")
+	}
+
+	component := components.AddExpenseForm(expenseForm, h.app.Config.Currency, categories)
+	h.app.Template.Render(w, r, component, status)
+}
+
 func translateExpenseError(err error) (string, bool) {
 	switch {
 	case errors.Is(err, expense.ErrInvalidAmount):
@@ -212,6 +242,10 @@ func translateExpenseError(err error) (string, bool) {
 		return "Category not found.", true
 	case errors.Is(err, expense.ErrExpenseNotFound):
 		return "Expense not found.", true
+	case errors.Is(err, account.ErrAccountNotFound):
+		return "Account not found.", true
+	case errors.Is(err, closing.ErrMonthClosed):
+		return monthClosedMessage, true
 	default:
 		return "An unexpected error occurred. Please try again later.", false
 	}
@@ -38,7 +38,7 @@ func TestExpenseHandler_CreateExpense(t *testing.T) {
 			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewExpenseHandler(appCtx, mockExpenseUC)
+		handler := NewExpenseHandler(appCtx, mockExpenseUC, new(MockGroupUseCase))
 
 		formValues := url.Values{}
 		formValues.Set("category-id", "cat-123")
@@ -94,7 +94,7 @@ func TestExpenseHandler_CreateExpense(t *testing.T) {
 			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewExpenseHandler(appCtx, mockExpenseUC)
+		handler := NewExpenseHandler(appCtx, mockExpenseUC, new(MockGroupUseCase))
 
 		formValues := url.Values{}
 		formValues.Set("category-id", "cat-123")
@@ -131,6 +131,53 @@ func TestExpenseHandler_CreateExpense(t *testing.T) {
 		mockExpenseUC.AssertExpectations(t)
 	})
 
+	t.Run("success on a day", func(t *testing.T) {
+		// Arrange
+		mockExpenseUC := new(MockExpenseUseCase)
+		mockSession := new(MockSessionManager)
+		mockErrorHandler := new(MockErrorHandler)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		appCtx := HandlerContext{
+			Config:  &config.Config{Currency: "USD"},
+			Decoder: form.NewDecoder(),
+			Logger:  logger,
+			Session: mockSession,
+			Errors:  newTestErrors(logger, mockErrorHandler),
+			Notify:  respond.NewNotify(logger),
+		}
+
+		handler := NewExpenseHandler(appCtx, mockExpenseUC, new(MockGroupUseCase))
+
+		formValues := url.Values{}
+		formValues.Set("category-id", "cat-123")
+		formValues.Set("expense-amount", "60.00")
+		formValues.Set("month", "2023-10")
+		formValues.Set("expense-date", "2023-10-15")
+		formValues.Set("payment-status", "unpaid")
+		formValues.Set("choose-category", "true")
+
+		req := httptest.NewRequest(http.MethodPost, "/expenses", strings.NewReader(formValues.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+
+		expectedSpentAt := time.Date(2023, time.October, 15, 0, 0, 0, 0, time.UTC)
+
+		mockExpenseUC.On("Create", req.Context(), mock.MatchedBy(func(r *usecase.CreateExpenseRequest) bool {
+			return r.CategoryID == "cat-123" && r.SpentAt.Equal(expectedSpentAt)
+		})).Return(&usecase.ExpenseResponse{ID: "exp-3"}, nil)
+
+		// Act
+		handler.CreateExpense(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		mockExpenseUC.AssertExpectations(t)
+	})
+
 	t.Run("invalid form data", func(t *testing.T) {
 		// Arrange
 		mockExpenseUC := new(MockExpenseUseCase)
@@ -147,7 +194,7 @@ func TestExpenseHandler_CreateExpense(t *testing.T) {
 			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewExpenseHandler(appCtx, mockExpenseUC)
+		handler := NewExpenseHandler(appCtx, mockExpenseUC, new(MockGroupUseCase))
 
 		formValues := url.Values{}
 		// Missing category-id and amount
@@ -183,7 +230,7 @@ func TestExpenseHandler_CreateExpense(t *testing.T) {
 			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewExpenseHandler(appCtx, mockExpenseUC)
+		handler := NewExpenseHandler(appCtx, mockExpenseUC, new(MockGroupUseCase))
 
 		formValues := url.Values{}
 		formValues.Set("category-id", "cat-123")
@@ -228,7 +275,7 @@ func TestExpenseHandler_CreateExpense(t *testing.T) {
 			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewExpenseHandler(appCtx, mockExpenseUC)
+		handler := NewExpenseHandler(appCtx, mockExpenseUC, new(MockGroupUseCase))
 
 		formValues := url.Values{}
 		formValues.Set("category-id", "cat-123")
@@ -275,7 +322,7 @@ func TestExpenseHandler_EditExpense(t *testing.T) {
 			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewExpenseHandler(appCtx, mockExpenseUC)
+		handler := NewExpenseHandler(appCtx, mockExpenseUC, new(MockGroupUseCase))
 
 		formValues := url.Values{}
 		formValues.Set("expense-id", "exp-123")
@@ -334,7 +381,7 @@ func TestExpenseHandler_EditExpense(t *testing.T) {
 			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewExpenseHandler(appCtx, mockExpenseUC)
+		handler := NewExpenseHandler(appCtx, mockExpenseUC, new(MockGroupUseCase))
 
 		formValues := url.Values{}
 		// Missing ID and amount
@@ -367,7 +414,7 @@ func TestExpenseHandler_EditExpense(t *testing.T) {
 			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewExpenseHandler(appCtx, mockExpenseUC)
+		handler := NewExpenseHandler(appCtx, mockExpenseUC, new(MockGroupUseCase))
 
 		formValues := url.Values{}
 		formValues.Set("expense-id", "exp-123")
@@ -417,7 +464,7 @@ func TestExpenseHandler_EditExpense(t *testing.T) {
 			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewExpenseHandler(appCtx, mockExpenseUC)
+		handler := NewExpenseHandler(appCtx, mockExpenseUC, new(MockGroupUseCase))
 
 		formValues := url.Values{}
 		formValues.Set("expense-id", "exp-123")
@@ -462,7 +509,7 @@ func TestExpenseHandler_DeleteExpense(t *testing.T) {
 			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewExpenseHandler(appCtx, mockExpenseUC)
+		handler := NewExpenseHandler(appCtx, mockExpenseUC, new(MockGroupUseCase))
 
 		req := httptest.NewRequest(http.MethodDelete, "/expenses/exp-1", nil)
 		req.SetPathValue("id", "exp-1")
@@ -496,7 +543,7 @@ func TestExpenseHandler_DeleteExpense(t *testing.T) {
 			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewExpenseHandler(appCtx, mockExpenseUC)
+		handler := NewExpenseHandler(appCtx, mockExpenseUC, new(MockGroupUseCase))
 
 		req := httptest.NewRequest(http.MethodDelete, "/expenses/exp-1", nil)
 		req.SetPathValue("id", "exp-1")
@@ -534,7 +581,7 @@ func TestExpenseHandler_GetCreateForm(t *testing.T) {
 			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewExpenseHandler(appCtx, mockExpenseUC)
+		handler := NewExpenseHandler(appCtx, mockExpenseUC, new(MockGroupUseCase))
 
 		req := httptest.NewRequest(http.MethodGet, "/expenses/form?category-id=cat-1&month=2023-10", nil)
 		rec := httptest.NewRecorder()
@@ -549,6 +596,45 @@ func TestExpenseHandler_GetCreateForm(t *testing.T) {
 		assert.Contains(t, rec.Body.String(), "2023-10")
 	})
 
+	t.Run("picks the category for a date", func(t *testing.T) {
+		// Arrange
+		mockExpenseUC := new(MockExpenseUseCase)
+		mockGroupUC := new(MockGroupUseCase)
+		mockSession := new(MockSessionManager)
+		mockErrorHandler := new(MockErrorHandler)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		appCtx := HandlerContext{
+			Config:  &config.Config{Currency: "USD"},
+			Logger:  logger,
+			Session: mockSession,
+			Errors:  newTestErrors(logger, mockErrorHandler),
+			Notify:  respond.NewNotify(logger),
+		}
+
+		handler := NewExpenseHandler(appCtx, mockExpenseUC, mockGroupUC)
+
+		req := httptest.NewRequest(http.MethodGet, "/expenses/form?month=2023-10&date=2023-10-15", nil)
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockGroupUC.On("List", req.Context(), "user-123").Return([]*usecase.GroupResponse{
+			{ID: "home", Name: "Home", Categories: []usecase.CategoryResponse{
+				{ID: "cat-1", Name: "Utilities", IsRecurrent: true, StartMonth: "2023-01"},
+			}},
+		}, nil)
+
+		// Act
+		handler.GetCreateForm(rec, req)
+
+		// Assert
+		body := rec.Body.String()
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, body, `name="choose-category"`)
+		assert.Contains(t, body, "Home / Utilities (from 2023-01)")
+		assert.Contains(t, body, `value="2023-10-15"`)
+	})
+
 	t.Run("missing category-id", func(t *testing.T) {
 		// Arrange
 		mockExpenseUC := new(MockExpenseUseCase)
@@ -564,7 +650,7 @@ func TestExpenseHandler_GetCreateForm(t *testing.T) {
 			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewExpenseHandler(appCtx, mockExpenseUC)
+		handler := NewExpenseHandler(appCtx, mockExpenseUC, new(MockGroupUseCase))
 
 		req := httptest.NewRequest(http.MethodGet, "/expenses/form?month=2023-10", nil)
 		rec := httptest.NewRecorder()
@@ -593,7 +679,7 @@ func TestExpenseHandler_GetCreateForm(t *testing.T) {
 			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewExpenseHandler(appCtx, mockExpenseUC)
+		handler := NewExpenseHandler(appCtx, mockExpenseUC, new(MockGroupUseCase))
 
 		req := httptest.NewRequest(http.MethodGet, "/expenses/form?category-id=cat-1", nil)
 		rec := httptest.NewRecorder()
@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
+	"github.com/madalinpopa/gocost-web/internal/domain/saving"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/form"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/views"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/madalinpopa/gocost-web/ui/templates/components"
+)
+
+type GoalHandler struct {
+	app  HandlerContext
+	goal usecase.GoalUseCase
+}
+
+func NewGoalHandler(app HandlerContext, goal usecase.GoalUseCase) GoalHandler {
+	return GoalHandler{
+		app:  app,
+		goal: goal,
+	}
+}
+
+func (h *GoalHandler) ListGoals(w http.ResponseWriter, r *http.Request) {
+	month, err := web.GetRequiredQueryParam(r, "month")
+	if err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	h.renderGoals(w, r, month, &form.GoalForm{Month: month}, nil, http.StatusOK)
+}
+
+// CreateGoal starts the goal in the month on display.
+func (h *GoalHandler) CreateGoal(w http.ResponseWriter, r *http.Request) {
+	var goalForm form.GoalForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &goalForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !goalForm.IsValid() {
+		h.renderGoals(w, r, goalForm.Month, &goalForm, nil, http.StatusUnprocessableEntity)
+		return
+	}
+
+	_, err := h.goal.Create(r.Context(), &usecase.CreateGoalRequest{
+		UserID:     h.app.Session.GetUserID(r.Context()),
+		Currency:   h.app.Session.GetCurrency(r.Context()),
+		Name:       goalForm.Name,
+		Target:     goalForm.ParsedTarget(),
+		StartMonth: goalForm.Month,
+		TargetDate: goalForm.ParsedTargetDate(),
+	})
+	if err != nil {
+		errMessage, isUserFacing := translateGoalError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to create goal", "error", err)
+		}
+		goalForm.AddNonFieldError(errMessage)
+		h.renderGoals(w, r, goalForm.Month, &goalForm, nil, http.StatusUnprocessableEntity)
+		return
+	}
+
+	triggerDashboardRefresh(w, h.app.Notify, web.Success, "Goal created.", "")
+	h.renderGoals(w, r, goalForm.Month, &form.GoalForm{Month: goalForm.Month}, nil, http.StatusOK)
+}
+
+func (h *GoalHandler) UpdateGoal(w http.ResponseWriter, r *http.Request) {
+	var goalForm form.GoalForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &goalForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+	goalForm.ID = r.PathValue("id")
+
+	month := goalForm.Month
+	if !goalForm.IsValid() {
+		h.renderGoals(w, r, month, &form.GoalForm{Month: month}, fieldErrorMessages(goalForm.FieldErrors), http.StatusUnprocessableEntity)
+		return
+	}
+
+	_, err := h.goal.Update(r.Context(), &usecase.UpdateGoalRequest{
+		ID:         goalForm.ID,
+		UserID:     h.app.Session.GetUserID(r.Context()),
+		Currency:   h.app.Session.GetCurrency(r.Context()),
+		Name:       goalForm.Name,
+		Target:     goalForm.ParsedTarget(),
+		TargetDate: goalForm.ParsedTargetDate(),
+	})
+	if err != nil {
+		errMessage, isUserFacing := translateGoalError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to update goal", "error", err)
+		}
+		h.renderGoals(w, r, month, &form.GoalForm{Month: month}, []string{errMessage}, http.StatusUnprocessableEntity)
+		return
+	}
+
+	triggerDashboardRefresh(w, h.app.Notify, web.Success, "Goal updated.", "")
+	h.renderGoals(w, r, month, &form.GoalForm{Month: month}, nil, http.StatusOK)
+}
+
+func (h *GoalHandler) DeleteGoal(w http.ResponseWriter, r *http.Request) {
+	month, err := web.GetRequiredQueryParam(r, "month")
+	if err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := h.app.Session.GetUserID(r.Context())
+	if err := h.goal.Delete(r.Context(), userID, r.PathValue("id")); err != nil {
+		errMessage, isUserFacing := translateGoalError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to delete goal", "error", err)
+		}
+		h.renderGoals(w, r, month, &form.GoalForm{Month: month}, []string{errMessage}, http.StatusUnprocessableEntity)
+		return
+	}
+
+	triggerDashboardRefresh(w, h.app.Notify, web.Success, "Goal deleted.", "")
+	h.renderGoals(w, r, month, &form.GoalForm{Month: month}, nil, http.StatusOK)
+}
+
+func (h *GoalHandler) Contribute(w http.ResponseWriter, r *http.Request) {
+	var contributeForm form.ContributeGoalForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &contributeForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	month := contributeForm.Month
+	if !contributeForm.IsValid() {
+		h.renderGoals(w, r, month, &form.GoalForm{Month: month}, fieldErrorMessages(contributeForm.FieldErrors), http.StatusUnprocessableEntity)
+		return
+	}
+
+	_, err := h.goal.Contribute(r.Context(), &usecase.ContributeGoalRequest{
+		UserID:   h.app.Session.GetUserID(r.Context()),
+		Currency: h.app.Session.GetCurrency(r.Context()),
+		GoalID:   r.PathValue("id"),
+		Month:    month,
+		Amount:   contributeForm.ParsedAmount(),
+	})
+	if err != nil {
+		errMessage, isUserFacing := translateGoalError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to record goal contribution", "error", err)
+		}
+		h.renderGoals(w, r, month, &form.GoalForm{Month: month}, []string{errMessage}, http.StatusUnprocessableEntity)
+		return
+	}
+
+	triggerDashboardRefresh(w, h.app.Notify, web.Success, "Contribution recorded.", "")
+	h.renderGoals(w, r, month, &form.GoalForm{Month: month}, nil, http.StatusOK)
+}
+
+func (h *GoalHandler) RemoveContribution(w http.ResponseWriter, r *http.Request) {
+	month, err := web.GetRequiredQueryParam(r, "month")
+	if err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := h.app.Session.GetUserID(r.Context())
+	err = h.goal.RemoveContribution(r.Context(), userID, r.PathValue("id"), r.PathValue("contributionID"))
+	if err != nil {
+		errMessage, isUserFacing := translateGoalError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to remove goal contribution", "error", err)
+		}
+		h.renderGoals(w, r, month, &form.GoalForm{Month: month}, []string{errMessage}, http.StatusUnprocessableEntity)
+		return
+	}
+
+	triggerDashboardRefresh(w, h.app.Notify, web.Success, "Contribution removed.", "")
+	h.renderGoals(w, r, month, &form.GoalForm{Month: month}, nil, http.StatusOK)
+}
+
+// renderGoals renders the goal manager. Errors from the per-goal actions are
+// shown above the list, the create form keeps its own.
+func (h *GoalHandler) renderGoals(w http.ResponseWriter, r *http.Request, month string, goalForm *form.GoalForm, actionErrors []string, status int) {
+	userID := h.app.Session.GetUserID(r.Context())
+
+	goals, err := h.goal.List(r.Context(), userID, month)
+	if err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	view, err := views.NewGoalsView(month, goals, h.app.Session.GetCurrency(r.Context()))
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	h.app.Template.Render(w, r, components.GoalsManager(view, goalForm, actionErrors), status)
+}
+
+func fieldErrorMessages(fieldErrors map[string]string) []string {
+	messages := make([]string, 0, len(fieldErrors))
+	for _, message := range fieldErrors {
+		messages = append(messages, message)
+	}
+	sort.Strings(messages)
+	return messages
+}
+
+func translateGoalError(err error) (string, bool) {
+	switch {
+	case errors.Is(err, saving.ErrEmptyName):
+		return "Goal name cannot be empty.", true
+	case errors.Is(err, saving.ErrNameTooLong):
+		return "Goal name is too long.", true
+	case errors.Is(err, saving.ErrInvalidTarget):
+		return "Target must be greater than zero.", true
+	case errors.Is(err, saving.ErrTargetDateBeforeStart):
+		return "Target date cannot be before the month the goal starts.", true
+	case errors.Is(err, saving.ErrInvalidMonth):
+		return "Contributions cannot be recorded before the goal starts.", true
+	case errors.Is(err, saving.ErrInvalidContribution):
+		return "Contribution must be greater than zero.", true
+	case errors.Is(err, saving.ErrContributionNotFound):
+		return "Contribution not found.", true
+	case errors.Is(err, saving.ErrGoalNotFound):
+		return "Goal not found.", true
+	case errors.Is(err, closing.ErrMonthClosed):
+		return monthClosedMessage, true
+	default:
+		return "An unexpected error occurred. Please try again later.", false
+	}
+}
@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-playground/form/v4"
+	"github.com/madalinpopa/gocost-web/internal/config"
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/respond"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestGoalHandler(session *MockSessionManager, goalUC *MockGoalUseCase) GoalHandler {
+	cfg := &config.Config{Currency: "USD"}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	return NewGoalHandler(HandlerContext{
+		Config:   cfg,
+		Decoder:  form.NewDecoder(),
+		Logger:   logger,
+		Session:  session,
+		Errors:   newTestErrors(logger, new(MockErrorHandler)),
+		Notify:   respond.NewNotify(logger),
+		Template: web.NewTemplate(logger, cfg),
+	}, goalUC)
+}
+
+func newTestGoals() []usecase.GoalResponse {
+	return []usecase.GoalResponse{
+		{
+			ID:                   "goal-1",
+			Name:                 "Emergency fund",
+			Currency:             "USD",
+			TargetCents:          500000,
+			TargetDate:           time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC),
+			Month:                "2024-03",
+			SavedCents:           50000,
+			RemainingCents:       450000,
+			RequiredMonthlyCents: 50000,
+			Percent:              10,
+			Status:               "behind",
+		},
+	}
+}
+
+func TestGoalHandler_ListGoals(t *testing.T) {
+	t.Run("renders the goals of the month", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockGoalUC := new(MockGoalUseCase)
+		handler := newTestGoalHandler(mockSession, mockGoalUC)
+
+		req := httptest.NewRequest(http.MethodGet, "/goals?month=2024-03", nil)
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockGoalUC.On("List", req.Context(), "user-123", "2024-03").Return(newTestGoals(), nil)
+
+		// Act
+		handler.ListGoals(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Emergency fund")
+		assert.Contains(t, rec.Body.String(), "Behind")
+		mockGoalUC.AssertExpectations(t)
+	})
+}
+
+func TestGoalHandler_CreateGoal(t *testing.T) {
+	t.Run("creates the goal and refreshes the dashboard", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockGoalUC := new(MockGoalUseCase)
+		handler := newTestGoalHandler(mockSession, mockGoalUC)
+
+		req := newFormRequest("/goals", url.Values{
+			"month":       {"2024-03"},
+			"goal-name":   {"Emergency fund"},
+			"goal-target": {"5000"},
+			"goal-date":   {"2024-12-31"},
+		})
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockGoalUC.On("Create", req.Context(), mock.MatchedBy(func(r *usecase.CreateGoalRequest) bool {
+			return r.UserID == "user-123" && r.StartMonth == "2024-03" && r.Target == 5000 &&
+				r.TargetDate.Equal(time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC))
+		})).Return(&newTestGoals()[0], nil)
+		mockGoalUC.On("List", req.Context(), "user-123", "2024-03").Return(newTestGoals(), nil)
+
+		// Act
+		handler.CreateGoal(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "dashboard:refresh")
+		mockGoalUC.AssertExpectations(t)
+	})
+
+	t.Run("re-renders the form with validation errors", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockGoalUC := new(MockGoalUseCase)
+		handler := newTestGoalHandler(mockSession, mockGoalUC)
+
+		req := newFormRequest("/goals", url.Values{
+			"month":       {"2024-03"},
+			"goal-target": {"abc"},
+		})
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockGoalUC.On("List", req.Context(), "user-123", "2024-03").Return(newTestGoals(), nil)
+
+		// Act
+		handler.CreateGoal(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "target must be a number")
+		mockGoalUC.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGoalHandler_Contribute(t *testing.T) {
+	t.Run("records the contribution", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockGoalUC := new(MockGoalUseCase)
+		handler := newTestGoalHandler(mockSession, mockGoalUC)
+
+		req := newFormRequest("/goals/goal-1/contributions", url.Values{
+			"month":               {"2024-03"},
+			"contribution-amount": {"250"},
+		})
+		req.SetPathValue("id", "goal-1")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockGoalUC.On("Contribute", req.Context(), &usecase.ContributeGoalRequest{
+			UserID:   "user-123",
+			Currency: "USD",
+			GoalID:   "goal-1",
+			Month:    "2024-03",
+			Amount:   250,
+		}).Return(&newTestGoals()[0], nil)
+		mockGoalUC.On("List", req.Context(), "user-123", "2024-03").Return(newTestGoals(), nil)
+
+		// Act
+		handler.Contribute(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "dashboard:refresh")
+		mockGoalUC.AssertExpectations(t)
+	})
+
+	t.Run("shows an error when the month is closed", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockGoalUC := new(MockGoalUseCase)
+		handler := newTestGoalHandler(mockSession, mockGoalUC)
+
+		req := newFormRequest("/goals/goal-1/contributions", url.Values{
+			"month":               {"2024-03"},
+			"contribution-amount": {"250"},
+		})
+		req.SetPathValue("id", "goal-1")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockGoalUC.On("Contribute", req.Context(), mock.Anything).Return(nil, closing.ErrMonthClosed)
+		mockGoalUC.On("List", req.Context(), "user-123", "2024-03").Return(newTestGoals(), nil)
+
+		// Act
+		handler.Contribute(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), monthClosedMessage)
+	})
+}
+
+func TestGoalHandler_RemoveContribution(t *testing.T) {
+	// Arrange
+	mockSession := new(MockSessionManager)
+	mockGoalUC := new(MockGoalUseCase)
+	handler := newTestGoalHandler(mockSession, mockGoalUC)
+
+	req := httptest.NewRequest(http.MethodDelete, "/goals/goal-1/contributions/c-1?month=2024-03", nil)
+	req.SetPathValue("id", "goal-1")
+	req.SetPathValue("contributionID", "c-1")
+	rec := httptest.NewRecorder()
+
+	mockSession.On("GetUserID", req.Context()).Return("user-123")
+	mockSession.On("GetCurrency", req.Context()).Return("USD")
+	mockGoalUC.On("RemoveContribution", req.Context(), "user-123", "goal-1", "c-1").Return(nil)
+	mockGoalUC.On("List", req.Context(), "user-123", "2024-03").Return(newTestGoals(), nil)
+
+	// Act
+	handler.RemoveContribution(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockGoalUC.AssertExpectations(t)
+}
@@ -4,9 +4,11 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
 	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
 	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
 	"github.com/madalinpopa/gocost-web/internal/interfaces/web/form"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/views"
 	"github.com/madalinpopa/gocost-web/internal/usecase"
 	"github.com/madalinpopa/gocost-web/ui/templates/components"
 )
@@ -121,16 +123,143 @@ func (h *GroupHandler) UpdateGroup(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *GroupHandler) GetDeleteForm(w http.ResponseWriter, r *http.Request) {
+	groupID, err := web.GetRequiredQueryParam(r, "group-id")
+	if err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	deleteForm := &form.DeleteGroupForm{
+		ID:    groupID,
+		Month: r.URL.Query().Get("current-month"),
+	}
+
+	h.renderDeleteForm(w, r, deleteForm, http.StatusOK)
+}
+
 func (h *GroupHandler) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	var deleteForm form.DeleteGroupForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &deleteForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !deleteForm.IsValid() {
+		h.renderDeleteForm(w, r, &deleteForm, http.StatusUnprocessableEntity)
+		return
+	}
+
 	userID := h.app.Session.GetUserID(r.Context())
-	groupID := r.PathValue("id")
 
-	if err := h.group.Delete(r.Context(), userID, groupID); err != nil {
+	err := h.group.Delete(r.Context(), &usecase.DeleteGroupRequest{
+		ID:               deleteForm.ID,
+		UserID:           userID,
+		Mode:             deleteForm.Mode,
+		TargetCategoryID: deleteForm.TargetCategoryID,
+		Month:            deleteForm.Month,
+	})
+	if err != nil {
+		errMessage, isUserFacing := translateGroupError(err)
+		deleteForm.AddNonFieldError(errMessage)
+		h.renderDeleteForm(w, r, &deleteForm, http.StatusUnprocessableEntity)
+
+		if !isUserFacing {
+			h.app.Logger.Error("failed to delete group", "mode", deleteForm.Mode, "error", err)
+		}
+		return
+	}
+
+	message := "Group deleted successfully."
+	switch deleteForm.Mode {
+	case usecase.DeleteModeReassign:
+		message = "Expenses moved and group deleted."
+	case usecase.DeleteModeEnd:
+		message = "Group categories ended. Earlier months are kept."
+	}
+
+	triggerDashboardRefresh(w, h.app.Notify, web.Success, message, "delete-group-modal")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *GroupHandler) renderDeleteForm(w http.ResponseWriter, r *http.Request, deleteForm *form.DeleteGroupForm, status int) {
+	userID := h.app.Session.GetUserID(r.Context())
+
+	impact, err := h.group.DeletionImpact(r.Context(), userID, deleteForm.ID, deleteForm.Month)
+	if err != nil {
+		h.app.Errors.Error(w, r, http.StatusNotFound, err)
+		return
+	}
+
+	groups, err := h.group.List(r.Context(), userID)
+	if err != nil {
 		h.app.Errors.Error(w, r, http.StatusInternalServerError, err)
 		return
 	}
 
-	triggerDashboardRefresh(w, h.app.Notify, web.Success, "Group deleted successfully.", "")
+	options := categoryOptions(groups, deleteForm.ID, "")
+	component := components.DeleteGroupForm(deleteForm, views.NewDeletionImpactView(impact), options)
+	h.app.Template.Render(w, r, component, status)
+}
+
+func (h *GroupHandler) ReorderGroups(w http.ResponseWriter, r *http.Request) {
+	var reorderForm form.ReorderForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &reorderForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !reorderForm.IsValid() {
+		h.app.Errors.Error(w, r, http.StatusUnprocessableEntity, errors.New("invalid group order"))
+		return
+	}
+
+	userID := h.app.Session.GetUserID(r.Context())
+
+	if err := h.group.Reorder(r.Context(), userID, reorderForm.IDs); err != nil {
+		errMessage, isUserFacing := translateGroupError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to reorder groups", "error", err)
+		}
+
+		// Reload the dashboard so the list reflects the stored order again.
+		triggerDashboardRefresh(w, h.app.Notify, web.ErrorMsg, errMessage, "")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *GroupHandler) ArchiveGroup(w http.ResponseWriter, r *http.Request) {
+	h.setArchived(w, r, true)
+}
+
+func (h *GroupHandler) RestoreGroup(w http.ResponseWriter, r *http.Request) {
+	h.setArchived(w, r, false)
+}
+
+func (h *GroupHandler) setArchived(w http.ResponseWriter, r *http.Request, archived bool) {
+	groupID := r.PathValue("id")
+	userID := h.app.Session.GetUserID(r.Context())
+
+	update, message := h.group.Restore, "Group restored successfully."
+	if archived {
+		update, message = h.group.Archive, "Group archived. It still shows in months with expenses."
+	}
+
+	if err := update(r.Context(), userID, groupID); err != nil {
+		errMessage, isUserFacing := translateGroupError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to update group archive state", "error", err)
+		}
+
+		triggerDashboardRefresh(w, h.app.Notify, web.ErrorMsg, errMessage, "")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		return
+	}
+
+	triggerDashboardRefresh(w, h.app.Notify, web.Success, message, "")
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -144,6 +273,26 @@ func translateGroupError(err error) (string, bool) {
 		return "Description is too long.", true
 	case errors.Is(err, tracking.ErrInvalidOrder):
 		return "Order must be non-negative.", true
+	case errors.Is(err, tracking.ErrInvalidGroupOrder):
+		return "The group list is out of date. Please try again.", true
+	case errors.Is(err, tracking.ErrCategoryNotFound):
+		return "Category not found.", true
+	case errors.Is(err, tracking.ErrTargetInDeletedGroup):
+		return "Choose a category outside the group being deleted.", true
+	case errors.Is(err, tracking.ErrMergePeriodMismatch):
+		return "The target category must be active in every month of this group's categories.", true
+	case errors.Is(err, tracking.ErrOneOffReassignTarget):
+		return "A one-off category can only take the expenses of one-off categories of its month. Choose a recurring category.", true
+	case errors.Is(err, tracking.ErrCategoryNameExists):
+		return "Category name already exists in the target group.", true
+	case errors.Is(err, tracking.ErrInvalidMonth):
+		return "Invalid month format.", true
+	case errors.Is(err, tracking.ErrCategoryHasSubcategories):
+		return "A category in this group still has subcategories.", true
+	case errors.Is(err, usecase.ErrInvalidDeleteMode):
+		return "Please choose what happens to the expenses.", true
+	case errors.Is(err, closing.ErrMonthClosed):
+		return monthClosedMessage, true
 	default:
 		return "An unexpected error occurred. Please try again later.", false
 	}
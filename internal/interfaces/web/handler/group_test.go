@@ -192,8 +192,7 @@ func TestGroupHandler_CreateGroup(t *testing.T) {
 }
 
 func TestGroupHandler_DeleteGroup(t *testing.T) {
-	t.Run("success", func(t *testing.T) {
-		// Arrange
+	newDeleteHandler := func() (GroupHandler, *MockGroupUseCase, *MockSessionManager) {
 		mockSession := new(MockSessionManager)
 		mockGroupUC := new(MockGroupUseCase)
 		mockErrorHandler := new(MockErrorHandler)
@@ -202,19 +201,35 @@ func TestGroupHandler_DeleteGroup(t *testing.T) {
 		appCtx := HandlerContext{
 			Config:  &config.Config{Currency: "USD"},
 			Session: mockSession,
+			Decoder: form.NewDecoder(),
 			Logger:  logger,
 			Errors:  newTestErrors(logger, mockErrorHandler),
 			Notify:  respond.NewNotify(logger),
 		}
 
-		handler := NewGroupHandler(appCtx, mockGroupUC)
+		return NewGroupHandler(appCtx, mockGroupUC), mockGroupUC, mockSession
+	}
 
-		req := httptest.NewRequest(http.MethodDelete, "/groups/group-1", nil)
-		req.SetPathValue("id", "group-1")
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		handler, mockGroupUC, mockSession := newDeleteHandler()
+
+		formValues := url.Values{}
+		formValues.Set("group-id", "group-1")
+		formValues.Set("current-month", "2024-05")
+		formValues.Set("delete-mode", "hard")
+
+		req := httptest.NewRequest(http.MethodPost, "/groups/delete", strings.NewReader(formValues.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 		rec := httptest.NewRecorder()
 
 		mockSession.On("GetUserID", req.Context()).Return("user-123")
-		mockGroupUC.On("Delete", req.Context(), "user-123", "group-1").Return(nil)
+		mockGroupUC.On("Delete", req.Context(), &usecase.DeleteGroupRequest{
+			ID:     "group-1",
+			UserID: "user-123",
+			Mode:   "hard",
+			Month:  "2024-05",
+		}).Return(nil)
 
 		// Act
 		handler.DeleteGroup(rec, req)
@@ -226,7 +241,64 @@ func TestGroupHandler_DeleteGroup(t *testing.T) {
 		mockGroupUC.AssertExpectations(t)
 	})
 
+	t.Run("reassign requires a target category", func(t *testing.T) {
+		// Arrange
+		handler, mockGroupUC, mockSession := newDeleteHandler()
+
+		formValues := url.Values{}
+		formValues.Set("group-id", "group-1")
+		formValues.Set("current-month", "2024-05")
+		formValues.Set("delete-mode", "reassign")
+
+		req := httptest.NewRequest(http.MethodPost, "/groups/delete", strings.NewReader(formValues.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockGroupUC.On("DeletionImpact", req.Context(), "user-123", "group-1", "2024-05").Return(&usecase.DeletionImpactResponse{
+			ExpenseCount: 3, MonthCount: 1, FirstMonth: "2024-04", LastMonth: "2024-04",
+		}, nil)
+		mockGroupUC.On("List", req.Context(), "user-123").Return([]*usecase.GroupResponse{}, nil)
+
+		// Act
+		handler.DeleteGroup(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "please choose a category")
+		mockGroupUC.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
 	t.Run("usecase error", func(t *testing.T) {
+		// Arrange
+		handler, mockGroupUC, mockSession := newDeleteHandler()
+
+		formValues := url.Values{}
+		formValues.Set("group-id", "group-1")
+		formValues.Set("delete-mode", "reassign")
+		formValues.Set("target-category-id", "cat-1")
+
+		req := httptest.NewRequest(http.MethodPost, "/groups/delete", strings.NewReader(formValues.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockGroupUC.On("Delete", req.Context(), mock.Anything).Return(tracking.ErrTargetInDeletedGroup)
+		mockGroupUC.On("DeletionImpact", req.Context(), "user-123", "group-1", "").Return(&usecase.DeletionImpactResponse{}, nil)
+		mockGroupUC.On("List", req.Context(), "user-123").Return([]*usecase.GroupResponse{}, nil)
+
+		// Act
+		handler.DeleteGroup(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Choose a category outside the group being deleted.")
+		mockGroupUC.AssertExpectations(t)
+	})
+}
+
+func TestGroupHandler_GetDeleteForm(t *testing.T) {
+	t.Run("shows impact and categories of other groups", func(t *testing.T) {
 		// Arrange
 		mockSession := new(MockSessionManager)
 		mockGroupUC := new(MockGroupUseCase)
@@ -234,31 +306,238 @@ func TestGroupHandler_DeleteGroup(t *testing.T) {
 		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
 		appCtx := HandlerContext{
-			Config:  &config.Config{Currency: "USD"},
 			Session: mockSession,
 			Logger:  logger,
 			Errors:  newTestErrors(logger, mockErrorHandler),
-			Notify:  respond.NewNotify(logger),
 		}
 
 		handler := NewGroupHandler(appCtx, mockGroupUC)
 
-		req := httptest.NewRequest(http.MethodDelete, "/groups/group-1", nil)
-		req.SetPathValue("id", "group-1")
+		req := httptest.NewRequest(http.MethodGet, "/groups/delete/form?group-id=group-1&current-month=2024-05", nil)
 		rec := httptest.NewRecorder()
 
 		mockSession.On("GetUserID", req.Context()).Return("user-123")
-		expectedErr := errors.New("delete failed")
-		mockGroupUC.On("Delete", req.Context(), "user-123", "group-1").Return(expectedErr)
+		mockGroupUC.On("DeletionImpact", req.Context(), "user-123", "group-1", "2024-05").Return(&usecase.DeletionImpactResponse{
+			ExpenseCount: 4, MonthCount: 2, FirstMonth: "2024-03", LastMonth: "2024-05", LaterExpenseCount: 1,
+		}, nil)
+		mockGroupUC.On("List", req.Context(), "user-123").Return([]*usecase.GroupResponse{
+			{ID: "group-1", Name: "Old apartment", Categories: []usecase.CategoryResponse{{ID: "cat-1", Name: "Rent", StartMonth: "2024-01", IsRecurrent: true}}},
+			{ID: "group-2", Name: "Housing", Categories: []usecase.CategoryResponse{{ID: "cat-2", Name: "Mortgage", StartMonth: "2024-01", IsRecurrent: true}}},
+		}, nil)
+
+		// Act
+		handler.GetDeleteForm(rec, req)
+
+		// Assert
+		body := rec.Body.String()
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, body, "4 expenses across 2 months, from 2024-03 to 2024-05")
+		assert.Contains(t, body, "Housing / Mortgage (from 2024-01)")
+		assert.NotContains(t, body, "Old apartment / Rent")
+		mockGroupUC.AssertExpectations(t)
+	})
+
+	t.Run("missing group-id", func(t *testing.T) {
+		// Arrange
+		mockErrorHandler := new(MockErrorHandler)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		appCtx := HandlerContext{
+			Logger: logger,
+			Errors: newTestErrors(logger, mockErrorHandler),
+		}
+
+		handler := NewGroupHandler(appCtx, new(MockGroupUseCase))
+
+		req := httptest.NewRequest(http.MethodGet, "/groups/delete/form", nil)
+		rec := httptest.NewRecorder()
 
-		mockErrorHandler.On("Error", rec, req, http.StatusInternalServerError, expectedErr).Return()
+		mockErrorHandler.On("Error", rec, req, http.StatusBadRequest, mock.Anything).Return()
 
 		// Act
-		handler.DeleteGroup(rec, req)
+		handler.GetDeleteForm(rec, req)
 
 		// Assert
+		mockErrorHandler.AssertExpectations(t)
+	})
+}
+
+func TestGroupHandler_ReorderGroups(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockGroupUC := new(MockGroupUseCase)
+		mockErrorHandler := new(MockErrorHandler)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		appCtx := HandlerContext{
+			Session: mockSession,
+			Decoder: form.NewDecoder(),
+			Logger:  logger,
+			Errors:  newTestErrors(logger, mockErrorHandler),
+			Notify:  respond.NewNotify(logger),
+		}
+
+		handler := NewGroupHandler(appCtx, mockGroupUC)
+
+		formValues := url.Values{"ids": {"group-2", "group-1"}}
+		req := httptest.NewRequest(http.MethodPost, "/groups/reorder", strings.NewReader(formValues.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockGroupUC.On("Reorder", req.Context(), "user-123", []string{"group-2", "group-1"}).Return(nil)
+
+		// Act
+		handler.ReorderGroups(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Empty(t, rec.Header().Get("HX-Trigger"))
 		mockSession.AssertExpectations(t)
 		mockGroupUC.AssertExpectations(t)
+	})
+
+	t.Run("missing ids", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockGroupUC := new(MockGroupUseCase)
+		mockErrorHandler := new(MockErrorHandler)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		appCtx := HandlerContext{
+			Session: mockSession,
+			Decoder: form.NewDecoder(),
+			Logger:  logger,
+			Errors:  newTestErrors(logger, mockErrorHandler),
+			Notify:  respond.NewNotify(logger),
+		}
+
+		handler := NewGroupHandler(appCtx, mockGroupUC)
+
+		req := httptest.NewRequest(http.MethodPost, "/groups/reorder", strings.NewReader(""))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		mockErrorHandler.On("Error", rec, req, http.StatusUnprocessableEntity, mock.Anything).Return()
+
+		// Act
+		handler.ReorderGroups(rec, req)
+
+		// Assert
 		mockErrorHandler.AssertExpectations(t)
+		mockGroupUC.AssertNotCalled(t, "Reorder", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("usecase error refreshes dashboard", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockGroupUC := new(MockGroupUseCase)
+		mockErrorHandler := new(MockErrorHandler)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		appCtx := HandlerContext{
+			Session: mockSession,
+			Decoder: form.NewDecoder(),
+			Logger:  logger,
+			Errors:  newTestErrors(logger, mockErrorHandler),
+			Notify:  respond.NewNotify(logger),
+		}
+
+		handler := NewGroupHandler(appCtx, mockGroupUC)
+
+		formValues := url.Values{"ids": {"group-1"}}
+		req := httptest.NewRequest(http.MethodPost, "/groups/reorder", strings.NewReader(formValues.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockGroupUC.On("Reorder", req.Context(), "user-123", []string{"group-1"}).Return(tracking.ErrInvalidGroupOrder)
+
+		// Act
+		handler.ReorderGroups(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "dashboard:refresh")
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "out of date")
+		mockGroupUC.AssertExpectations(t)
+	})
+}
+
+func TestGroupHandler_ArchiveGroup(t *testing.T) {
+	newArchiveHandler := func() (GroupHandler, *MockGroupUseCase, *MockSessionManager) {
+		mockSession := new(MockSessionManager)
+		mockGroupUC := new(MockGroupUseCase)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		appCtx := HandlerContext{
+			Session: mockSession,
+			Logger:  logger,
+			Errors:  newTestErrors(logger, new(MockErrorHandler)),
+			Notify:  respond.NewNotify(logger),
+		}
+
+		return NewGroupHandler(appCtx, mockGroupUC), mockGroupUC, mockSession
+	}
+
+	t.Run("archives group", func(t *testing.T) {
+		// Arrange
+		handler, mockGroupUC, mockSession := newArchiveHandler()
+
+		req := httptest.NewRequest(http.MethodPost, "/groups/group-1/archive", nil)
+		req.SetPathValue("id", "group-1")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockGroupUC.On("Archive", req.Context(), "user-123", "group-1").Return(nil)
+
+		// Act
+		handler.ArchiveGroup(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "dashboard:refresh")
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "Group archived.")
+		mockGroupUC.AssertExpectations(t)
+	})
+
+	t.Run("restores group", func(t *testing.T) {
+		// Arrange
+		handler, mockGroupUC, mockSession := newArchiveHandler()
+
+		req := httptest.NewRequest(http.MethodPost, "/groups/group-1/restore", nil)
+		req.SetPathValue("id", "group-1")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockGroupUC.On("Restore", req.Context(), "user-123", "group-1").Return(nil)
+
+		// Act
+		handler.RestoreGroup(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "Group restored successfully.")
+		mockGroupUC.AssertExpectations(t)
+	})
+
+	t.Run("usecase error", func(t *testing.T) {
+		// Arrange
+		handler, mockGroupUC, mockSession := newArchiveHandler()
+
+		req := httptest.NewRequest(http.MethodPost, "/groups/group-1/archive", nil)
+		req.SetPathValue("id", "group-1")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockGroupUC.On("Archive", req.Context(), "user-123", "group-1").Return(errors.New("unauthorized"))
+
+		// Act
+		handler.ArchiveGroup(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "An unexpected error occurred")
 	})
 }
@@ -7,22 +7,40 @@ import (
 	"github.com/madalinpopa/gocost-web/internal/config"
 	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
 	"github.com/madalinpopa/gocost-web/internal/interfaces/web/respond"
+	"github.com/madalinpopa/gocost-web/internal/platform/captcha"
+	"github.com/madalinpopa/gocost-web/internal/platform/ratelimit"
 	"github.com/madalinpopa/gocost-web/internal/usecase"
 )
 
 type PublicHandlers struct {
-	IndexHandler    IndexHandler
-	LoginHandler    LoginHandler
-	LogoutHandler   LogoutHandler
-	RegisterHandler RegisterHandler
+	IndexHandler         IndexHandler
+	LoginHandler         LoginHandler
+	LogoutHandler        LogoutHandler
+	RegisterHandler      RegisterHandler
+	PasswordResetHandler PasswordResetHandler
+	VerificationHandler  EmailVerificationHandler
 }
 
 type PrivateHandlers struct {
-	HomeHandler     HomeHandler
-	IncomeHandler   IncomeHandler
-	GroupHandler    GroupHandler
-	CategoryHandler CategoryHandler
-	ExpenseHandler  ExpenseHandler
+	HomeHandler      HomeHandler
+	IncomeHandler    IncomeHandler
+	GroupHandler     GroupHandler
+	CategoryHandler  CategoryHandler
+	ExpenseHandler   ExpenseHandler
+	ArchiveHandler   ArchiveHandler
+	PlanHandler      PlanHandler
+	ClosingHandler   ClosingHandler
+	BudgetHandler    BudgetHandler
+	GoalHandler      GoalHandler
+	AccountHandler   AccountHandler
+	LoanHandler      LoanHandler
+	NetWorthHandler  NetWorthHandler
+	CalendarHandler  CalendarHandler
+	AlertHandler     AlertHandler
+	ProfileHandler   ProfileHandler
+	TwoFactorHandler TwoFactorHandler
+	PasskeyHandler   PasskeyHandler
+	SessionHandler   SessionHandler
 }
 
 type Handlers struct {
@@ -33,17 +51,33 @@ type Handlers struct {
 func New(app HandlerContext, uc *usecase.UseCase) Handlers {
 	return Handlers{
 		Public: PublicHandlers{
-			IndexHandler:    NewIndexHandler(app),
-			LoginHandler:    NewLoginHandler(app, uc.AuthUseCase),
-			LogoutHandler:   NewLogoutHandler(app, uc.AuthUseCase),
-			RegisterHandler: NewRegisterHandler(app, uc.AuthUseCase),
+			IndexHandler:         NewIndexHandler(app),
+			LoginHandler:         NewLoginHandler(app, uc.AuthUseCase, uc.VerificationUseCase, uc.TwoFactorUseCase, uc.PasskeyUseCase),
+			LogoutHandler:        NewLogoutHandler(app, uc.AuthUseCase),
+			RegisterHandler:      NewRegisterHandler(app, uc.AuthUseCase, uc.VerificationUseCase),
+			PasswordResetHandler: NewPasswordResetHandler(app, uc.PasswordResetUseCase),
+			VerificationHandler:  NewEmailVerificationHandler(app, uc.VerificationUseCase),
 		},
 		Private: PrivateHandlers{
-			HomeHandler:     NewHomeHandler(app, uc.DashboardUseCase),
-			IncomeHandler:   NewIncomeHandler(app, uc.IncomeUseCase, uc.ExpenseUseCase),
-			GroupHandler:    NewGroupHandler(app, uc.GroupUseCase),
-			CategoryHandler: NewCategoryHandler(app, uc.CategoryUseCase),
-			ExpenseHandler:  NewExpenseHandler(app, uc.ExpenseUseCase),
+			HomeHandler:      NewHomeHandler(app, uc.DashboardUseCase),
+			IncomeHandler:    NewIncomeHandler(app, uc.IncomeUseCase, uc.ExpenseUseCase),
+			GroupHandler:     NewGroupHandler(app, uc.GroupUseCase),
+			CategoryHandler:  NewCategoryHandler(app, uc.CategoryUseCase, uc.GroupUseCase),
+			ExpenseHandler:   NewExpenseHandler(app, uc.ExpenseUseCase, uc.GroupUseCase),
+			ArchiveHandler:   NewArchiveHandler(app, uc.GroupUseCase),
+			PlanHandler:      NewPlanHandler(app, uc.PlanUseCase),
+			ClosingHandler:   NewClosingHandler(app, uc.ClosingUseCase),
+			BudgetHandler:    NewBudgetHandler(app, uc.BudgetUseCase),
+			GoalHandler:      NewGoalHandler(app, uc.GoalUseCase),
+			AccountHandler:   NewAccountHandler(app, uc.AccountUseCase),
+			LoanHandler:      NewLoanHandler(app, uc.LoanUseCase, uc.GroupUseCase),
+			NetWorthHandler:  NewNetWorthHandler(app, uc.NetWorthUseCase),
+			CalendarHandler:  NewCalendarHandler(app, uc.ExpenseUseCase, uc.GroupUseCase),
+			AlertHandler:     NewAlertHandler(app, uc.AlertUseCase),
+			ProfileHandler:   NewProfileHandler(app, uc.ProfileUseCase, uc.VerificationUseCase),
+			TwoFactorHandler: NewTwoFactorHandler(app, uc.TwoFactorUseCase),
+			PasskeyHandler:   NewPasskeyHandler(app, uc.PasskeyUseCase),
+			SessionHandler:   NewSessionHandler(app, uc.SessionUseCase),
 		},
 	}
 }
@@ -58,4 +92,6 @@ type HandlerContext struct {
 	Errors   respond.ErrorHandler
 	Htmx     respond.HtmxHandler
 	Notify   respond.NotifyHandler
+	Limiter  *ratelimit.Limiter
+	Captcha  *captcha.Captcha
 }
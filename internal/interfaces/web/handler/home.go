@@ -66,7 +66,7 @@ func (hh HomeHandler) GetDashboardGroups(w http.ResponseWriter, r *http.Request)
 	dashboardData.NextMonth = nextDate.Format("2006-01")
 
 	// 1. Render the Groups List (Main Target)
-	err = components.DashboardGroups(dashboardData.Groups, monthStr).Render(r.Context(), w)
+	err = components.DashboardGroups(dashboardData.Groups, monthStr, dashboardData.IsClosed).Render(r.Context(), w)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -86,7 +86,13 @@ func (hh HomeHandler) GetDashboardGroups(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Dashboard Actions
-	err = components.DashboardActions(monthStr, true).Render(r.Context(), w)
+	err = components.DashboardActions(dashboardData, true).Render(r.Context(), w)
+	if err != nil {
+		hh.app.Errors.LogServerError(r, err)
+	}
+
+	// Savings Goals
+	err = components.GoalsSummary(dashboardData, true).Render(r.Context(), w)
 	if err != nil {
 		hh.app.Errors.LogServerError(r, err)
 	}
@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
+	"github.com/madalinpopa/gocost-web/internal/domain/account"
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
 	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
 	"github.com/madalinpopa/gocost-web/internal/interfaces/web/form"
 	"github.com/madalinpopa/gocost-web/internal/interfaces/web/views"
@@ -64,11 +67,22 @@ func (h *IncomeHandler) CreateIncome(w http.ResponseWriter, r *http.Request) {
 		Amount:     incomeForm.ParsedAmount(),
 		Source:     incomeForm.Description,
 		ReceivedAt: date,
+		AccountID:  incomeForm.AccountID,
 	}
 
 	_, err = h.income.Create(r.Context(), req)
 	if err != nil {
-		h.app.Errors.LogServerError(r, err)
+		switch {
+		case errors.Is(err, closing.ErrMonthClosed):
+			incomeForm.AddNonFieldError(monthClosedMessage)
+		case errors.Is(err, account.ErrAccountNotFound):
+			incomeForm.AddNonFieldError("Account not found.")
+		default:
+			h.app.Errors.LogServerError(r, err)
+			return
+		}
+		component := components.AddIncomeForm(&incomeForm, h.app.Config.Currency, incomeForm.CurrentMonth)
+		h.app.Template.Render(w, r, component, http.StatusUnprocessableEntity)
 		return
 	}
 
@@ -103,6 +117,11 @@ func (h *IncomeHandler) DeleteIncome(w http.ResponseWriter, r *http.Request) {
 
 	err := h.income.Delete(r.Context(), userID, id)
 	if err != nil {
+		if errors.Is(err, closing.ErrMonthClosed) {
+			triggerDashboardRefresh(w, h.app.Notify, web.ErrorMsg, monthClosedMessage, "")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
 		h.app.Errors.LogServerError(r, err)
 		return
 	}
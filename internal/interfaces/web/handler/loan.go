@@ -0,0 +1,279 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/loan"
+	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/form"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/views"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/madalinpopa/gocost-web/ui/templates/components"
+	"github.com/madalinpopa/gocost-web/ui/templates/pages/private"
+)
+
+type LoanHandler struct {
+	app   HandlerContext
+	loan  usecase.LoanUseCase
+	group usecase.GroupUseCase
+}
+
+func NewLoanHandler(app HandlerContext, loan usecase.LoanUseCase, group usecase.GroupUseCase) LoanHandler {
+	return LoanHandler{
+		app:   app,
+		loan:  loan,
+		group: group,
+	}
+}
+
+func (h *LoanHandler) ShowLoansPage(w http.ResponseWriter, r *http.Request) {
+	data := h.app.Template.GetData(r)
+
+	loans, err := h.loansView(r)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	categories, err := h.categoryOptions(r)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	page := private.LoansPage(data, loans, &form.LoanForm{StartMonth: currentMonth()}, categories)
+	h.app.Template.Render(w, r, page, http.StatusOK)
+}
+
+func (h *LoanHandler) CreateLoan(w http.ResponseWriter, r *http.Request) {
+	var loanForm form.LoanForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &loanForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !loanForm.IsValid() {
+		h.renderLoans(w, r, &loanForm, nil, http.StatusUnprocessableEntity)
+		return
+	}
+
+	_, err := h.loan.Create(r.Context(), &usecase.CreateLoanRequest{
+		UserID:      h.app.Session.GetUserID(r.Context()),
+		Currency:    h.app.Session.GetCurrency(r.Context()),
+		Name:        loanForm.Name,
+		Principal:   loanForm.ParsedPrincipal(),
+		RatePercent: loanForm.ParsedRate(),
+		TermMonths:  loanForm.ParsedTerm(),
+		StartMonth:  loanForm.StartMonth,
+		CategoryID:  loanForm.CategoryID,
+	})
+	if err != nil {
+		errMessage, isUserFacing := translateLoanError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to create loan", "error", err)
+		}
+		loanForm.AddNonFieldError(errMessage)
+		h.renderLoans(w, r, &loanForm, nil, http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.app.Notify.Toast(w, web.Success, "Loan added.")
+	h.renderLoans(w, r, nil, nil, http.StatusOK)
+}
+
+func (h *LoanHandler) UpdateLoan(w http.ResponseWriter, r *http.Request) {
+	var loanForm form.LoanForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &loanForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+	loanForm.ID = r.PathValue("id")
+
+	if !loanForm.IsValid() {
+		h.renderLoans(w, r, nil, fieldErrorMessages(loanForm.FieldErrors), http.StatusUnprocessableEntity)
+		return
+	}
+
+	_, err := h.loan.Update(r.Context(), &usecase.UpdateLoanRequest{
+		ID:          loanForm.ID,
+		UserID:      h.app.Session.GetUserID(r.Context()),
+		Currency:    h.app.Session.GetCurrency(r.Context()),
+		Name:        loanForm.Name,
+		Principal:   loanForm.ParsedPrincipal(),
+		RatePercent: loanForm.ParsedRate(),
+		TermMonths:  loanForm.ParsedTerm(),
+		StartMonth:  loanForm.StartMonth,
+		CategoryID:  loanForm.CategoryID,
+	})
+	if err != nil {
+		errMessage, isUserFacing := translateLoanError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to update loan", "error", err)
+		}
+		h.renderLoans(w, r, nil, []string{errMessage}, http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.app.Notify.Toast(w, web.Success, "Loan updated.")
+	h.renderLoans(w, r, nil, nil, http.StatusOK)
+}
+
+func (h *LoanHandler) DeleteLoan(w http.ResponseWriter, r *http.Request) {
+	userID := h.app.Session.GetUserID(r.Context())
+	if err := h.loan.Delete(r.Context(), userID, r.PathValue("id")); err != nil {
+		errMessage, isUserFacing := translateLoanError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to delete loan", "error", err)
+		}
+		h.renderLoans(w, r, nil, []string{errMessage}, http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.app.Notify.Toast(w, web.Success, "Loan deleted.")
+	h.renderLoans(w, r, nil, nil, http.StatusOK)
+}
+
+// ShowSchedulePage shows the amortization schedule of the loan with the
+// payments recorded in its category, as of the current month.
+func (h *LoanHandler) ShowSchedulePage(w http.ResponseWriter, r *http.Request) {
+	data := h.app.Template.GetData(r)
+
+	schedule, err := h.loan.Schedule(r.Context(), data.User.ID, r.PathValue("id"), currentMonth())
+	if err != nil {
+		if errors.Is(err, loan.ErrLoanNotFound) {
+			h.app.Errors.Error(w, r, http.StatusNotFound, err)
+			return
+		}
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	view, err := views.NewLoanScheduleView(schedule, data.Currency)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	page := private.LoanSchedulePage(data, view, &form.LoanSimulationForm{LumpSumMonth: currentMonth()})
+	h.app.Template.Render(w, r, page, http.StatusOK)
+}
+
+// Simulate shows what paying extra from the current month on would save.
+// Nothing is saved.
+func (h *LoanHandler) Simulate(w http.ResponseWriter, r *http.Request) {
+	var simulationForm form.LoanSimulationForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &simulationForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	loanID := r.PathValue("id")
+	if !simulationForm.IsValid() {
+		h.app.Template.Render(w, r, components.LoanSimulationPanel(loanID, nil, &simulationForm), http.StatusUnprocessableEntity)
+		return
+	}
+
+	resp, err := h.loan.Simulate(r.Context(), &usecase.SimulateLoanRequest{
+		UserID:       h.app.Session.GetUserID(r.Context()),
+		Currency:     h.app.Session.GetCurrency(r.Context()),
+		LoanID:       loanID,
+		Month:        currentMonth(),
+		ExtraMonthly: simulationForm.ParsedExtraMonthly(),
+		LumpSum:      simulationForm.ParsedLumpSum(),
+		LumpSumMonth: simulationForm.LumpSumMonth,
+	})
+	if err != nil {
+		errMessage, isUserFacing := translateLoanError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to simulate loan", "error", err)
+		}
+		simulationForm.AddNonFieldError(errMessage)
+		h.app.Template.Render(w, r, components.LoanSimulationPanel(loanID, nil, &simulationForm), http.StatusUnprocessableEntity)
+		return
+	}
+
+	view, err := views.NewLoanSimulationView(resp, h.app.Session.GetCurrency(r.Context()))
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	h.app.Template.Render(w, r, components.LoanSimulationPanel(loanID, &view, &simulationForm), http.StatusOK)
+}
+
+// renderLoans renders the loan manager. Errors from the per-loan actions
+// are shown above the list; the create form keeps its own.
+func (h *LoanHandler) renderLoans(w http.ResponseWriter, r *http.Request, loanForm *form.LoanForm, actionErrors []string, status int) {
+	loans, err := h.loansView(r)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	categories, err := h.categoryOptions(r)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	if loanForm == nil {
+		loanForm = &form.LoanForm{StartMonth: currentMonth()}
+	}
+
+	h.app.Template.Render(w, r, components.LoansManager(loans, loanForm, categories, actionErrors), status)
+}
+
+func (h *LoanHandler) loansView(r *http.Request) (views.LoansView, error) {
+	userID := h.app.Session.GetUserID(r.Context())
+
+	loans, err := h.loan.List(r.Context(), userID, currentMonth())
+	if err != nil {
+		return views.LoansView{}, err
+	}
+
+	return views.NewLoansView(loans, h.app.Session.GetCurrency(r.Context()))
+}
+
+// categoryOptions lists the categories a loan can record its payments in.
+func (h *LoanHandler) categoryOptions(r *http.Request) ([]components.SelectOption, error) {
+	groups, err := h.group.List(r.Context(), h.app.Session.GetUserID(r.Context()))
+	if err != nil {
+		return nil, err
+	}
+
+	options := categoryOptions(groups, "", "")
+	options[0].Label = "Not linked"
+	return options, nil
+}
+
+func currentMonth() string {
+	return time.Now().Format("2006-01")
+}
+
+func translateLoanError(err error) (string, bool) {
+	switch {
+	case errors.Is(err, loan.ErrEmptyName):
+		return "Loan name cannot be empty.", true
+	case errors.Is(err, loan.ErrNameTooLong):
+		return "Loan name is too long.", true
+	case errors.Is(err, loan.ErrInvalidPrincipal):
+		return "Principal must be greater than zero.", true
+	case errors.Is(err, loan.ErrInvalidRate):
+		return "Interest rate must be between 0 and 50 percent.", true
+	case errors.Is(err, loan.ErrInvalidTerm):
+		return "Term must be between 1 and 600 months.", true
+	case errors.Is(err, loan.ErrInvalidMonth):
+		return "Choose a valid month.", true
+	case errors.Is(err, loan.ErrInvalidExtra):
+		return "Extra payments cannot be negative.", true
+	case errors.Is(err, loan.ErrLoanNotFound):
+		return "Loan not found.", true
+	case errors.Is(err, tracking.ErrCategoryNotFound):
+		return "Category not found.", true
+	default:
+		return "An unexpected error occurred. Please try again later.", false
+	}
+}
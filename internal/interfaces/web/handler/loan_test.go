@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-playground/form/v4"
+	"github.com/madalinpopa/gocost-web/internal/config"
+	"github.com/madalinpopa/gocost-web/internal/domain/loan"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/respond"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestLoanHandler(session *MockSessionManager, loanUC *MockLoanUseCase, groupUC *MockGroupUseCase) LoanHandler {
+	cfg := &config.Config{Currency: "USD"}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	errHandler := newTestErrors(logger, new(MockErrorHandler))
+
+	return NewLoanHandler(HandlerContext{
+		Config:   cfg,
+		Decoder:  form.NewDecoder(),
+		Logger:   logger,
+		Session:  session,
+		Errors:   errHandler,
+		Htmx:     respond.NewHtmx(errHandler),
+		Notify:   respond.NewNotify(logger),
+		Template: web.NewTemplate(logger, cfg),
+	}, loanUC, groupUC)
+}
+
+func newTestLoans() []usecase.LoanResponse {
+	return []usecase.LoanResponse{
+		{ID: "car", Name: "Car", Currency: "USD", PrincipalCents: 2000000, RatePercent: 4.9, TermMonths: 60, StartMonth: "2024-01", CategoryID: "car-payment", PaymentCents: 37651, BalanceCents: 1500000, PayoffMonth: "2028-12"},
+	}
+}
+
+func newTestLoanGroups() []*usecase.GroupResponse {
+	return []*usecase.GroupResponse{
+		{ID: "debt", Name: "Debt", Categories: []usecase.CategoryResponse{
+			{ID: "car-payment", Name: "Car payment", IsRecurrent: true, StartMonth: "2024-01"},
+		}},
+	}
+}
+
+func TestLoanHandler_CreateLoan(t *testing.T) {
+	t.Run("creates the loan and renders the manager", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockLoanUC := new(MockLoanUseCase)
+		mockGroupUC := new(MockGroupUseCase)
+		handler := newTestLoanHandler(mockSession, mockLoanUC, mockGroupUC)
+
+		req := newFormRequest("/loans", url.Values{
+			"loan-name":      {"Car"},
+			"loan-principal": {"20000"},
+			"loan-rate":      {"4.9"},
+			"loan-term":      {"60"},
+			"loan-start":     {"2024-01"},
+			"loan-category":  {"car-payment"},
+		})
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockLoanUC.On("Create", req.Context(), mock.MatchedBy(func(r *usecase.CreateLoanRequest) bool {
+			return r.UserID == "user-123" && r.Principal == 20000 && r.RatePercent == 4.9 && r.TermMonths == 60 && r.CategoryID == "car-payment"
+		})).Return(&usecase.LoanResponse{ID: "car"}, nil)
+		mockLoanUC.On("List", req.Context(), "user-123", mock.Anything).Return(newTestLoans(), nil)
+		mockGroupUC.On("List", req.Context(), "user-123").Return(newTestLoanGroups(), nil)
+
+		// Act
+		handler.CreateLoan(rec, req)
+
+		// Assert
+		body := rec.Body.String()
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, body, `id="loans-manager"`)
+		assert.Contains(t, body, `href="/loans/car"`)
+		assert.Contains(t, body, "December 2028")
+		assert.Contains(t, body, `<option value="car-payment" selected>`)
+		assert.Contains(t, body, "Not linked")
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "Loan added.")
+		mockLoanUC.AssertExpectations(t)
+	})
+
+	t.Run("re-renders the form when the term is not a number", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockLoanUC := new(MockLoanUseCase)
+		mockGroupUC := new(MockGroupUseCase)
+		handler := newTestLoanHandler(mockSession, mockLoanUC, mockGroupUC)
+
+		req := newFormRequest("/loans", url.Values{
+			"loan-name":      {"Car"},
+			"loan-principal": {"20000"},
+			"loan-rate":      {"4.9"},
+			"loan-term":      {"five years"},
+			"loan-start":     {"2024-01"},
+		})
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockLoanUC.On("List", req.Context(), "user-123", mock.Anything).Return(newTestLoans(), nil)
+		mockGroupUC.On("List", req.Context(), "user-123").Return(newTestLoanGroups(), nil)
+
+		// Act
+		handler.CreateLoan(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "term must be a whole number of months")
+		mockLoanUC.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestLoanHandler_DeleteLoan(t *testing.T) {
+	// Arrange
+	mockSession := new(MockSessionManager)
+	mockLoanUC := new(MockLoanUseCase)
+	mockGroupUC := new(MockGroupUseCase)
+	handler := newTestLoanHandler(mockSession, mockLoanUC, mockGroupUC)
+
+	req := httptest.NewRequest(http.MethodDelete, "/loans/gone", nil)
+	req.SetPathValue("id", "gone")
+	rec := httptest.NewRecorder()
+
+	mockSession.On("GetUserID", req.Context()).Return("user-123")
+	mockSession.On("GetCurrency", req.Context()).Return("USD")
+	mockLoanUC.On("Delete", req.Context(), "user-123", "gone").Return(loan.ErrLoanNotFound)
+	mockLoanUC.On("List", req.Context(), "user-123", mock.Anything).Return(newTestLoans(), nil)
+	mockGroupUC.On("List", req.Context(), "user-123").Return(newTestLoanGroups(), nil)
+
+	// Act
+	handler.DeleteLoan(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Loan not found.")
+}
+
+func TestLoanHandler_Simulate(t *testing.T) {
+	t.Run("shows the payoff month and interest saved", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockLoanUC := new(MockLoanUseCase)
+		handler := newTestLoanHandler(mockSession, mockLoanUC, new(MockGroupUseCase))
+
+		req := newFormRequest("/loans/car/simulate", url.Values{
+			"extra-monthly":  {"100"},
+			"lump-sum":       {""},
+			"lump-sum-month": {"2024-03"},
+		})
+		req.SetPathValue("id", "car")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockLoanUC.On("Simulate", req.Context(), mock.MatchedBy(func(r *usecase.SimulateLoanRequest) bool {
+			return r.LoanID == "car" && r.ExtraMonthly == 100 && r.LumpSum == 0 && r.Month != ""
+		})).Return(&usecase.LoanSimulationResponse{
+			Loan:               newTestLoans()[0],
+			PayoffMonth:        "2027-11",
+			TotalInterestCents: 202541,
+			InterestSavedCents: 62011,
+			MonthsSaved:        13,
+		}, nil)
+
+		// Act
+		handler.Simulate(rec, req)
+
+		// Assert
+		body := rec.Body.String()
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, body, `id="loan-simulation"`)
+		assert.Contains(t, body, "November 2027")
+		assert.Contains(t, body, "(13 months sooner)")
+		assert.Contains(t, body, "620.11")
+	})
+
+	t.Run("asks for the month of a lump sum", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockLoanUC := new(MockLoanUseCase)
+		handler := newTestLoanHandler(mockSession, mockLoanUC, new(MockGroupUseCase))
+
+		req := newFormRequest("/loans/car/simulate", url.Values{
+			"lump-sum": {"5000"},
+		})
+		req.SetPathValue("id", "car")
+		rec := httptest.NewRecorder()
+
+		// Act
+		handler.Simulate(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "invalid month format")
+		mockLoanUC.AssertNotCalled(t, "Simulate", mock.Anything, mock.Anything)
+	})
+}
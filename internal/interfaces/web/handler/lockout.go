@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+)
+
+// lockedOut tells the client to wait, and returns true, while logging in to
+// account from its IP address is locked out after repeated failures. Every
+// form that checks the password or code of an account counts as logging in.
+func lockedOut(app HandlerContext, w http.ResponseWriter, r *http.Request, account string) bool {
+	if app.Limiter == nil {
+		return false
+	}
+
+	wait, err := app.Limiter.LockedFor(r.Context(), loginLockoutKey(r, account))
+	if err != nil {
+		app.Logger.Error("failed to check login lockout", "error", err)
+		return false
+	}
+	if wait <= 0 {
+		return false
+	}
+
+	web.TooManyRequests(w, r, wait)
+	return true
+}
+
+// loginFailed records a failed login to account and, once it locks the
+// client out, tells it to wait and returns true.
+func loginFailed(app HandlerContext, w http.ResponseWriter, r *http.Request, account string) bool {
+	if app.Limiter == nil {
+		return false
+	}
+
+	wait, err := app.Limiter.Fail(r.Context(), loginLockoutKey(r, account))
+	if err != nil {
+		app.Logger.Error("failed to record failed login", "error", err)
+		return false
+	}
+	if wait <= 0 {
+		return false
+	}
+
+	app.Logger.Warn("login locked out", "ip", web.ClientIP(r), "wait", wait)
+	web.TooManyRequests(w, r, wait)
+	return true
+}
+
+// loginSucceeded forgets the failed logins to account from the client.
+func loginSucceeded(app HandlerContext, r *http.Request, account string) {
+	if app.Limiter == nil {
+		return
+	}
+
+	if err := app.Limiter.Succeed(r.Context(), loginLockoutKey(r, account)); err != nil {
+		app.Logger.Error("failed to clear failed logins", "error", err)
+	}
+}
+
+// loginLockoutKey counts failed logins to an account from each IP address
+// apart, so that nobody can lock the owner out of their account.
+func loginLockoutKey(r *http.Request, account string) string {
+	return fmt.Sprintf("login:%s:%s", strings.ToLower(strings.TrimSpace(account)), web.ClientIP(r))
+}
@@ -5,20 +5,33 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/madalinpopa/gocost-web/internal/config"
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
 	"github.com/madalinpopa/gocost-web/internal/interfaces/web/form"
 	"github.com/madalinpopa/gocost-web/internal/usecase"
 	"github.com/madalinpopa/gocost-web/ui/templates/pages/public"
 )
 
+// twoFactorLoginPath is the second step of a login, for users with
+// two-factor authentication.
+const twoFactorLoginPath = "/login/two-factor"
+
 type LoginHandler struct {
-	app  HandlerContext
-	auth usecase.AuthUseCase
+	app          HandlerContext
+	auth         usecase.AuthUseCase
+	verification usecase.EmailVerificationUseCase
+	twoFactor    usecase.TwoFactorUseCase
+	passkeys     usecase.PasskeyUseCase
 }
 
-func NewLoginHandler(app HandlerContext, auth usecase.AuthUseCase) LoginHandler {
+func NewLoginHandler(app HandlerContext, auth usecase.AuthUseCase, verification usecase.EmailVerificationUseCase, twoFactor usecase.TwoFactorUseCase, passkeys usecase.PasskeyUseCase) LoginHandler {
 	return LoginHandler{
-		app:  app,
-		auth: auth,
+		app:          app,
+		auth:         auth,
+		verification: verification,
+		twoFactor:    twoFactor,
+		passkeys:     passkeys,
 	}
 }
 
@@ -53,6 +66,10 @@ func (lh LoginHandler) SubmitLoginForm(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if lockedOut(lh.app, w, r, loginForm.Email) {
+		return
+	}
+
 	req := &usecase.LoginRequest{
 		EmailOrUsername: loginForm.Email,
 		Password:        loginForm.Password,
@@ -61,6 +78,9 @@ func (lh LoginHandler) SubmitLoginForm(w http.ResponseWriter, r *http.Request) {
 	resp, err := lh.auth.Login(r.Context(), req)
 	if err != nil {
 		if errors.Is(err, usecase.ErrInvalidCredentials) {
+			if loginFailed(lh.app, w, r, loginForm.Email) {
+				return
+			}
 			loginForm.AddNonFieldError("Invalid email or password.")
 			page := public.LoginForm(loginForm)
 			lh.app.Template.Render(w, r, page, http.StatusUnprocessableEntity)
@@ -69,6 +89,20 @@ func (lh LoginHandler) SubmitLoginForm(w http.ResponseWriter, r *http.Request) {
 		lh.app.Errors.Error(w, r, http.StatusInternalServerError, err)
 		return
 	}
+	loginSucceeded(lh.app, r, loginForm.Email)
+
+	// Unverified users cannot sign in until they follow the emailed link,
+	// when verification is enforced.
+	if !resp.EmailVerified && lh.app.Config.EmailVerification == config.EmailVerificationBlock {
+		err := sendVerification(r.Context(), lh.app, lh.verification, resp.UserID)
+		if err != nil && !errors.Is(err, identity.ErrVerificationRateLimited) {
+			lh.app.Logger.Error("failed to send verification email", "error", err)
+		}
+		loginForm.AddNonFieldError("Please verify your email address first. We sent a link to your inbox.")
+		page := public.LoginForm(loginForm)
+		lh.app.Template.Render(w, r, page, http.StatusUnprocessableEntity)
+		return
+	}
 
 	err = lh.app.Session.RenewToken(r.Context())
 	if err != nil {
@@ -80,9 +114,219 @@ func (lh LoginHandler) SubmitLoginForm(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	lh.app.Session.SetRememberMe(r.Context(), loginForm.RememberMe)
+	lh.app.Session.ConfirmIdentity(r.Context())
+
+	// The password is right, but users with two-factor authentication are
+	// signed in only once they enter a code.
+	if resp.TwoFactorRequired {
+		lh.app.Session.SetPendingUserID(r.Context(), resp.UserID)
+		lh.app.Htmx.Redirect(w, twoFactorLoginPath)
+		return
+	}
+
 	lh.app.Session.SetUserID(r.Context(), resp.UserID)
 	lh.app.Session.SetUsername(r.Context(), resp.Username)
 	lh.app.Session.SetCurrency(r.Context(), resp.Currency)
+	lh.app.Session.SetEmailVerified(r.Context(), resp.EmailVerified)
+
+	lh.app.Htmx.Redirect(w, "/home")
+}
+
+// ShowTwoFactorPage asks for the code of the second step of a login. Without
+// a login in progress it sends back to the first.
+func (lh LoginHandler) ShowTwoFactorPage(w http.ResponseWriter, r *http.Request) {
+	if lh.app.Session.GetPendingUserID(r.Context()) == "" {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	data := lh.app.Template.GetData(r)
+	page := public.TwoFactorLoginPage(data, form.TwoFactorForm{})
+	lh.app.Template.Render(w, r, page, http.StatusOK)
+}
+
+// SubmitTwoFactorForm signs in the user who passed the password step once the
+// code is right.
+func (lh LoginHandler) SubmitTwoFactorForm(w http.ResponseWriter, r *http.Request) {
+	var twoFactorForm form.TwoFactorForm
+	if err := form.ParseAndValidateForm(r, lh.app.Decoder, &twoFactorForm); err != nil {
+		lh.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := lh.app.Session.GetPendingUserID(r.Context())
+	if userID == "" {
+		twoFactorForm.AddNonFieldError("Your login has expired. Please log in again.")
+	}
+	if !twoFactorForm.IsValid() {
+		lh.app.Template.Render(w, r, public.TwoFactorLoginForm(twoFactorForm), http.StatusUnprocessableEntity)
+		return
+	}
+
+	if lockedOut(lh.app, w, r, userID) {
+		return
+	}
+
+	user, err := lh.twoFactor.Verify(r.Context(), &usecase.VerifyTwoFactorRequest{
+		UserID: userID,
+		Code:   twoFactorForm.Code,
+	})
+	if err != nil {
+		if errors.Is(err, identity.ErrInvalidTwoFactorCode) {
+			if loginFailed(lh.app, w, r, userID) {
+				return
+			}
+			twoFactorForm.AddFieldError("code", "this code is not valid")
+			lh.app.Template.Render(w, r, public.TwoFactorLoginForm(twoFactorForm), http.StatusUnprocessableEntity)
+			return
+		}
+		lh.app.Errors.Error(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	loginSucceeded(lh.app, r, userID)
+
+	err = lh.app.Session.RenewToken(r.Context())
+	if err != nil {
+		lh.app.Errors.Error(
+			w, r,
+			http.StatusInternalServerError,
+			fmt.Errorf("failed to renew session token: %w", err),
+		)
+		return
+	}
+
+	lh.app.Session.SetPendingUserID(r.Context(), "")
+	lh.app.Session.SetUserID(r.Context(), user.ID)
+	lh.app.Session.SetUsername(r.Context(), user.Username)
+	lh.app.Session.SetCurrency(r.Context(), user.Currency)
+	lh.app.Session.SetEmailVerified(r.Context(), user.EmailVerified)
+
+	lh.app.Htmx.Redirect(w, "/home")
+}
+
+// PasskeyLoginOptions returns the options the browser asks for a passkey
+// with, as JSON. The challenge is kept in the session until the passkey
+// answers it.
+func (lh LoginHandler) PasskeyLoginOptions(w http.ResponseWriter, r *http.Request) {
+	resp, err := lh.passkeys.BeginLogin(r.Context(), lh.app.Config.BaseURL)
+	if err != nil {
+		lh.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	lh.app.Session.SetPasskeyChallenge(r.Context(), resp.Challenge)
+	writeJSON(w, http.StatusOK, resp.Options)
+}
+
+// SubmitPasskeyLogin signs in the user whose passkey answered the challenge.
+// The passkey already verified the user, so no code is asked for.
+func (lh LoginHandler) SubmitPasskeyLogin(w http.ResponseWriter, r *http.Request) {
+	var passkeyForm form.PasskeyLoginForm
+	if err := form.ParseAndValidateForm(r, lh.app.Decoder, &passkeyForm); err != nil {
+		lh.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	challenge := lh.app.Session.PopPasskeyChallenge(r.Context())
+	if challenge == nil && passkeyForm.IsValid() {
+		passkeyForm.AddNonFieldError("Your login has expired. Please try again.")
+	}
+	if !passkeyForm.IsValid() {
+		lh.app.Template.Render(w, r, public.PasskeyLoginForm(passkeyForm), http.StatusUnprocessableEntity)
+		return
+	}
+
+	user, err := lh.passkeys.FinishLogin(r.Context(), &usecase.FinishPasskeyLoginRequest{
+		Origin:     lh.app.Config.BaseURL,
+		Challenge:  challenge,
+		Credential: passkeyForm.Assertion,
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidPasskey) {
+			passkeyForm.AddNonFieldError("This passkey could not be verified.")
+			lh.app.Template.Render(w, r, public.PasskeyLoginForm(passkeyForm), http.StatusUnprocessableEntity)
+			return
+		}
+		lh.app.Errors.Error(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	if !user.EmailVerified && lh.app.Config.EmailVerification == config.EmailVerificationBlock {
+		err := sendVerification(r.Context(), lh.app, lh.verification, user.ID)
+		if err != nil && !errors.Is(err, identity.ErrVerificationRateLimited) {
+			lh.app.Logger.Error("failed to send verification email", "error", err)
+		}
+		passkeyForm.AddNonFieldError("Please verify your email address first. We sent a link to your inbox.")
+		lh.app.Template.Render(w, r, public.PasskeyLoginForm(passkeyForm), http.StatusUnprocessableEntity)
+		return
+	}
+
+	err = lh.app.Session.RenewToken(r.Context())
+	if err != nil {
+		lh.app.Errors.Error(
+			w, r,
+			http.StatusInternalServerError,
+			fmt.Errorf("failed to renew session token: %w", err),
+		)
+		return
+	}
+
+	lh.app.Session.SetRememberMe(r.Context(), passkeyForm.RememberMe)
+	lh.app.Session.ConfirmIdentity(r.Context())
+	lh.app.Session.SetUserID(r.Context(), user.ID)
+	lh.app.Session.SetUsername(r.Context(), user.Username)
+	lh.app.Session.SetCurrency(r.Context(), user.Currency)
+	lh.app.Session.SetEmailVerified(r.Context(), user.EmailVerified)
 
 	lh.app.Htmx.Redirect(w, "/home")
 }
+
+// ShowReauthPage asks for the password again before a sensitive action.
+func (lh LoginHandler) ShowReauthPage(w http.ResponseWriter, r *http.Request) {
+	reauthForm := form.ReauthForm{Next: web.LocalPath(r.URL.Query().Get("next"))}
+	data := lh.app.Template.GetData(r)
+	page := public.ReauthPage(data, reauthForm)
+	lh.app.Template.Render(w, r, page, http.StatusOK)
+}
+
+// SubmitReauthForm checks the password and goes back to the page the
+// sensitive action was on.
+func (lh LoginHandler) SubmitReauthForm(w http.ResponseWriter, r *http.Request) {
+	var reauthForm form.ReauthForm
+	if err := form.ParseAndValidateForm(r, lh.app.Decoder, &reauthForm); err != nil {
+		lh.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !reauthForm.IsValid() {
+		lh.app.Template.Render(w, r, public.ReauthForm(reauthForm), http.StatusUnprocessableEntity)
+		return
+	}
+
+	userID := lh.app.Session.GetUserID(r.Context())
+	if lockedOut(lh.app, w, r, userID) {
+		return
+	}
+
+	err := lh.auth.ConfirmPassword(r.Context(), &usecase.ConfirmPasswordRequest{
+		UserID:   userID,
+		Password: reauthForm.Password,
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidCredentials) {
+			if loginFailed(lh.app, w, r, userID) {
+				return
+			}
+			reauthForm.AddFieldError("password", "password is incorrect")
+			lh.app.Template.Render(w, r, public.ReauthForm(reauthForm), http.StatusUnprocessableEntity)
+			return
+		}
+		lh.app.Errors.Error(w, r, http.StatusInternalServerError, err)
+		return
+	}
+	loginSucceeded(lh.app, r, userID)
+
+	lh.app.Session.ConfirmIdentity(r.Context())
+	lh.app.Htmx.Redirect(w, web.LocalPath(reauthForm.Next))
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"bytes"
 	"io"
 	"log/slog"
 	"net/http"
@@ -8,17 +9,21 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-playground/form/v4"
 	"github.com/madalinpopa/gocost-web/internal/config"
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
 	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
 	"github.com/madalinpopa/gocost-web/internal/interfaces/web/respond"
+	"github.com/madalinpopa/gocost-web/internal/platform/ratelimit"
+	"github.com/madalinpopa/gocost-web/internal/platform/webauthn"
 	"github.com/madalinpopa/gocost-web/internal/usecase"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
-func newTestLoginHandler(authMock *MockAuthUseCase, sessionMock *MockSessionManager) LoginHandler {
+func newTestLoginHandler(authMock *MockAuthUseCase, sessionMock *MockSessionManager, verificationMock *MockEmailVerificationUseCase, twoFactorMock *MockTwoFactorUseCase, passkeyMock *MockPasskeyUseCase) LoginHandler {
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	cfg := config.New()
 	templater := web.NewTemplate(logger, cfg)
@@ -43,12 +48,24 @@ func newTestLoginHandler(authMock *MockAuthUseCase, sessionMock *MockSessionMana
 		authMock = new(MockAuthUseCase)
 	}
 
-	return NewLoginHandler(appCtx, authMock)
+	if verificationMock == nil {
+		verificationMock = new(MockEmailVerificationUseCase)
+	}
+
+	if twoFactorMock == nil {
+		twoFactorMock = new(MockTwoFactorUseCase)
+	}
+
+	if passkeyMock == nil {
+		passkeyMock = new(MockPasskeyUseCase)
+	}
+
+	return NewLoginHandler(appCtx, authMock, verificationMock, twoFactorMock, passkeyMock)
 }
 
 func TestLoginHandler_ShowLoginPage(t *testing.T) {
 	t.Run("renders the login page", func(t *testing.T) {
-		handler := newTestLoginHandler(nil, nil)
+		handler := newTestLoginHandler(nil, nil, nil, nil, nil)
 		req := httptest.NewRequest(http.MethodGet, "/login", nil)
 		rec := httptest.NewRecorder()
 
@@ -62,12 +79,25 @@ func TestLoginHandler_ShowLoginPage(t *testing.T) {
 		assert.Contains(t, body, "<title>Go Cost - Expense Tracker</title>")
 		assert.Contains(t, body, "LOGIN")
 		assert.Contains(t, body, "ACCESS YOUR DASHBOARD")
+		assert.Contains(t, body, `href="/register"`)
+	})
+
+	t.Run("hides the register link while registration is closed", func(t *testing.T) {
+		handler := newTestLoginHandler(nil, nil, nil, nil, nil)
+		handler.app.Config.Registration = config.RegistrationClosed
+		req := httptest.NewRequest(http.MethodGet, "/login", nil)
+		rec := httptest.NewRecorder()
+
+		handler.ShowLoginPage(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotContains(t, rec.Body.String(), `href="/register"`)
 	})
 }
 
 func TestLoginHandler_ShowLoginForm(t *testing.T) {
 	t.Run("renders the login form", func(t *testing.T) {
-		handler := newTestLoginHandler(nil, nil)
+		handler := newTestLoginHandler(nil, nil, nil, nil, nil)
 		req := httptest.NewRequest(http.MethodGet, "/login/form", nil)
 		rec := httptest.NewRecorder()
 
@@ -88,7 +118,7 @@ func TestLoginHandler_SubmitLoginForm(t *testing.T) {
 	t.Run("successful login", func(t *testing.T) {
 		authMock := new(MockAuthUseCase)
 		sessionMock := new(MockSessionManager)
-		handler := newTestLoginHandler(authMock, sessionMock)
+		handler := newTestLoginHandler(authMock, sessionMock, nil, nil, nil)
 
 		formVals := url.Values{}
 		formVals.Add("email", "test@example.com")
@@ -107,12 +137,17 @@ func TestLoginHandler_SubmitLoginForm(t *testing.T) {
 			UserID:   "user-123",
 			Username: "testuser",
 			Currency: "USD",
+
+			EmailVerified: true,
 		}, nil)
 
 		sessionMock.On("RenewToken", req.Context()).Return(nil)
+		sessionMock.On("SetRememberMe", req.Context(), false).Return()
+		sessionMock.On("ConfirmIdentity", req.Context()).Return()
 		sessionMock.On("SetUserID", req.Context(), "user-123").Return()
 		sessionMock.On("SetUsername", req.Context(), "testuser").Return()
 		sessionMock.On("SetCurrency", req.Context(), "USD").Return()
+		sessionMock.On("SetEmailVerified", req.Context(), true).Return()
 
 		handler.SubmitLoginForm(rec, req)
 
@@ -126,10 +161,42 @@ func TestLoginHandler_SubmitLoginForm(t *testing.T) {
 		sessionMock.AssertExpectations(t)
 	})
 
+	t.Run("unverified user cannot log in when verification is enforced", func(t *testing.T) {
+		authMock := new(MockAuthUseCase)
+		sessionMock := new(MockSessionManager)
+		verificationMock := new(MockEmailVerificationUseCase)
+		handler := newTestLoginHandler(authMock, sessionMock, verificationMock, nil, nil)
+		handler.app.Config.EmailVerification = config.EmailVerificationBlock
+
+		formVals := url.Values{}
+		formVals.Add("email", "test@example.com")
+		formVals.Add("password", "password123")
+
+		req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(formVals.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		authMock.On("Login", req.Context(), mock.Anything).Return(&usecase.LoginResponse{
+			UserID:   "user-123",
+			Username: "testuser",
+			Currency: "USD",
+		}, nil)
+		verificationMock.On("SendVerification", req.Context(), mock.MatchedBy(func(r *usecase.SendVerificationRequest) bool {
+			return r.UserID == "user-123"
+		})).Return(identity.ErrVerificationRateLimited)
+
+		handler.SubmitLoginForm(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Please verify your email address first.")
+		sessionMock.AssertNotCalled(t, "SetUserID", mock.Anything, mock.Anything)
+		verificationMock.AssertExpectations(t)
+	})
+
 	t.Run("invalid credentials", func(t *testing.T) {
 		authMock := new(MockAuthUseCase)
 		sessionMock := new(MockSessionManager)
-		handler := newTestLoginHandler(authMock, sessionMock)
+		handler := newTestLoginHandler(authMock, sessionMock, nil, nil, nil)
 
 		formVals := url.Values{}
 		formVals.Add("email", "test@example.com")
@@ -153,4 +220,413 @@ func TestLoginHandler_SubmitLoginForm(t *testing.T) {
 		authMock.AssertExpectations(t)
 		sessionMock.AssertNotCalled(t, "RenewToken", mock.Anything)
 	})
+
+	t.Run("repeated failures lock the client out", func(t *testing.T) {
+		authMock := new(MockAuthUseCase)
+		sessionMock := new(MockSessionManager)
+		handler := newTestLoginHandler(authMock, sessionMock, nil, nil, nil)
+		handler.app.Limiter = ratelimit.New(ratelimit.NewMemoryStore(), ratelimit.Lockout{Threshold: 2, Duration: time.Minute, MaxDuration: time.Hour})
+
+		submit := func(remoteAddr string) *httptest.ResponseRecorder {
+			formVals := url.Values{}
+			formVals.Add("email", "test@example.com")
+			formVals.Add("password", "wrongpassword")
+
+			req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(formVals.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.RemoteAddr = remoteAddr
+			rec := httptest.NewRecorder()
+			handler.SubmitLoginForm(rec, req)
+			return rec
+		}
+
+		authMock.On("Login", mock.Anything, mock.Anything).Return(nil, usecase.ErrInvalidCredentials)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, submit("192.0.2.1:1234").Code)
+
+		rec := submit("192.0.2.1:1234")
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+
+		rec = submit("192.0.2.1:1234")
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		authMock.AssertNumberOfCalls(t, "Login", 2)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, submit("192.0.2.2:1234").Code, "other clients are not locked out")
+	})
+
+	t.Run("user with two-factor authentication is sent to the second step", func(t *testing.T) {
+		authMock := new(MockAuthUseCase)
+		sessionMock := new(MockSessionManager)
+		handler := newTestLoginHandler(authMock, sessionMock, nil, nil, nil)
+
+		formVals := url.Values{}
+		formVals.Add("email", "test@example.com")
+		formVals.Add("password", "password123")
+		formVals.Add("remember", "true")
+
+		req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(formVals.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		authMock.On("Login", req.Context(), mock.Anything).Return(&usecase.LoginResponse{
+			UserID:            "user-123",
+			Username:          "testuser",
+			Currency:          "USD",
+			EmailVerified:     true,
+			TwoFactorRequired: true,
+		}, nil)
+		sessionMock.On("RenewToken", req.Context()).Return(nil)
+		sessionMock.On("SetRememberMe", req.Context(), true).Return()
+		sessionMock.On("ConfirmIdentity", req.Context()).Return()
+		sessionMock.On("SetPendingUserID", req.Context(), "user-123").Return()
+
+		handler.SubmitLoginForm(rec, req)
+
+		assert.Equal(t, http.StatusFound, rec.Code)
+		assert.Equal(t, "/login/two-factor", rec.Header().Get("HX-Redirect"))
+		sessionMock.AssertExpectations(t)
+		sessionMock.AssertNotCalled(t, "SetUserID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestLoginHandler_ShowTwoFactorPage(t *testing.T) {
+	t.Run("redirects to login without a pending login", func(t *testing.T) {
+		sessionMock := new(MockSessionManager)
+		handler := newTestLoginHandler(nil, sessionMock, nil, nil, nil)
+		req := httptest.NewRequest(http.MethodGet, "/login/two-factor", nil)
+		rec := httptest.NewRecorder()
+
+		sessionMock.On("GetPendingUserID", req.Context()).Return("")
+
+		handler.ShowTwoFactorPage(rec, req)
+
+		assert.Equal(t, http.StatusSeeOther, rec.Code)
+		assert.Equal(t, "/login", rec.Header().Get("Location"))
+	})
+
+	t.Run("renders the code form", func(t *testing.T) {
+		sessionMock := new(MockSessionManager)
+		handler := newTestLoginHandler(nil, sessionMock, nil, nil, nil)
+		req := httptest.NewRequest(http.MethodGet, "/login/two-factor", nil)
+		rec := httptest.NewRecorder()
+
+		sessionMock.On("GetPendingUserID", req.Context()).Return("user-123")
+
+		handler.ShowTwoFactorPage(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "name=\"code\"")
+	})
+}
+
+func TestLoginHandler_SubmitTwoFactorForm(t *testing.T) {
+	newRequest := func(code string) *http.Request {
+		formVals := url.Values{}
+		formVals.Add("code", code)
+		req := httptest.NewRequest(http.MethodPost, "/login/two-factor", strings.NewReader(formVals.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req
+	}
+
+	t.Run("signs in with a valid code", func(t *testing.T) {
+		// Arrange
+		sessionMock := new(MockSessionManager)
+		twoFactorMock := new(MockTwoFactorUseCase)
+		handler := newTestLoginHandler(nil, sessionMock, nil, twoFactorMock, nil)
+		req := newRequest("123456")
+		rec := httptest.NewRecorder()
+
+		sessionMock.On("GetPendingUserID", req.Context()).Return("user-123")
+		twoFactorMock.On("Verify", req.Context(), &usecase.VerifyTwoFactorRequest{UserID: "user-123", Code: "123456"}).
+			Return(&usecase.UserResponse{ID: "user-123", Username: "testuser", Currency: "USD", EmailVerified: true}, nil)
+		sessionMock.On("RenewToken", req.Context()).Return(nil)
+		sessionMock.On("SetPendingUserID", req.Context(), "").Return()
+		sessionMock.On("SetUserID", req.Context(), "user-123").Return()
+		sessionMock.On("SetUsername", req.Context(), "testuser").Return()
+		sessionMock.On("SetCurrency", req.Context(), "USD").Return()
+		sessionMock.On("SetEmailVerified", req.Context(), true).Return()
+
+		// Act
+		handler.SubmitTwoFactorForm(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusFound, rec.Code)
+		assert.Equal(t, "/home", rec.Header().Get("HX-Redirect"))
+		sessionMock.AssertExpectations(t)
+		twoFactorMock.AssertExpectations(t)
+	})
+
+	t.Run("rejects a wrong code", func(t *testing.T) {
+		// Arrange
+		sessionMock := new(MockSessionManager)
+		twoFactorMock := new(MockTwoFactorUseCase)
+		handler := newTestLoginHandler(nil, sessionMock, nil, twoFactorMock, nil)
+		req := newRequest("000000")
+		rec := httptest.NewRecorder()
+
+		sessionMock.On("GetPendingUserID", req.Context()).Return("user-123")
+		twoFactorMock.On("Verify", req.Context(), mock.Anything).Return(nil, identity.ErrInvalidTwoFactorCode)
+
+		// Act
+		handler.SubmitTwoFactorForm(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "this code is not valid")
+		sessionMock.AssertNotCalled(t, "SetUserID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("expired login", func(t *testing.T) {
+		// Arrange
+		sessionMock := new(MockSessionManager)
+		twoFactorMock := new(MockTwoFactorUseCase)
+		handler := newTestLoginHandler(nil, sessionMock, nil, twoFactorMock, nil)
+		req := newRequest("123456")
+		rec := httptest.NewRecorder()
+
+		sessionMock.On("GetPendingUserID", req.Context()).Return("")
+
+		// Act
+		handler.SubmitTwoFactorForm(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Your login has expired.")
+		twoFactorMock.AssertNotCalled(t, "Verify", mock.Anything, mock.Anything)
+	})
+}
+
+func TestLoginHandler_PasskeyLoginOptions(t *testing.T) {
+	t.Run("returns the options and keeps the challenge", func(t *testing.T) {
+		// Arrange
+		sessionMock := new(MockSessionManager)
+		passkeyMock := new(MockPasskeyUseCase)
+		handler := newTestLoginHandler(nil, sessionMock, nil, nil, passkeyMock)
+		req := httptest.NewRequest(http.MethodGet, "/login/passkey/options", nil)
+		rec := httptest.NewRecorder()
+
+		challenge := []byte{1, 2, 3}
+		passkeyMock.On("BeginLogin", req.Context(), mock.Anything).Return(&usecase.PasskeyRequestResponse{
+			Options:   webauthn.RequestOptions{Challenge: challenge, RPID: "example.com"},
+			Challenge: challenge,
+		}, nil)
+		sessionMock.On("SetPasskeyChallenge", req.Context(), challenge).Return()
+
+		// Act
+		handler.PasskeyLoginOptions(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), `"challenge":"AQID"`)
+		assert.Contains(t, rec.Body.String(), `"rpId":"example.com"`)
+		sessionMock.AssertExpectations(t)
+	})
+}
+
+func TestLoginHandler_SubmitPasskeyLogin(t *testing.T) {
+	const credential = `{"id":"BAUG","rawId":"BAUG","type":"public-key","response":{"clientDataJSON":"AQ","authenticatorData":"Ag","signature":"Aw","userHandle":null}}`
+
+	newRequest := func(credential string) *http.Request {
+		formVals := url.Values{}
+		formVals.Add("credential", credential)
+		formVals.Add("remember", "true")
+		req := httptest.NewRequest(http.MethodPost, "/login/passkey", strings.NewReader(formVals.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req
+	}
+
+	t.Run("signs in with a verified passkey", func(t *testing.T) {
+		// Arrange
+		sessionMock := new(MockSessionManager)
+		passkeyMock := new(MockPasskeyUseCase)
+		handler := newTestLoginHandler(nil, sessionMock, nil, nil, passkeyMock)
+		req := newRequest(credential)
+		rec := httptest.NewRecorder()
+
+		challenge := []byte{1, 2, 3}
+		sessionMock.On("PopPasskeyChallenge", req.Context()).Return(challenge)
+		passkeyMock.On("FinishLogin", req.Context(), mock.MatchedBy(func(r *usecase.FinishPasskeyLoginRequest) bool {
+			return bytes.Equal(r.Challenge, challenge) && bytes.Equal(r.Credential.RawID, []byte{4, 5, 6})
+		})).Return(&usecase.UserResponse{ID: "user-123", Username: "testuser", Currency: "USD", EmailVerified: true}, nil)
+		sessionMock.On("RenewToken", req.Context()).Return(nil)
+		sessionMock.On("SetRememberMe", req.Context(), true).Return()
+		sessionMock.On("ConfirmIdentity", req.Context()).Return()
+		sessionMock.On("SetUserID", req.Context(), "user-123").Return()
+		sessionMock.On("SetUsername", req.Context(), "testuser").Return()
+		sessionMock.On("SetCurrency", req.Context(), "USD").Return()
+		sessionMock.On("SetEmailVerified", req.Context(), true).Return()
+
+		// Act
+		handler.SubmitPasskeyLogin(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusFound, rec.Code)
+		assert.Equal(t, "/home", rec.Header().Get("HX-Redirect"))
+		sessionMock.AssertExpectations(t)
+		passkeyMock.AssertExpectations(t)
+	})
+
+	t.Run("rejects a passkey that cannot be verified", func(t *testing.T) {
+		// Arrange
+		sessionMock := new(MockSessionManager)
+		passkeyMock := new(MockPasskeyUseCase)
+		handler := newTestLoginHandler(nil, sessionMock, nil, nil, passkeyMock)
+		req := newRequest(credential)
+		rec := httptest.NewRecorder()
+
+		sessionMock.On("PopPasskeyChallenge", req.Context()).Return([]byte{1, 2, 3})
+		passkeyMock.On("FinishLogin", req.Context(), mock.Anything).Return(nil, usecase.ErrInvalidPasskey)
+
+		// Act
+		handler.SubmitPasskeyLogin(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "This passkey could not be verified.")
+		sessionMock.AssertNotCalled(t, "SetUserID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("expired challenge", func(t *testing.T) {
+		// Arrange
+		sessionMock := new(MockSessionManager)
+		passkeyMock := new(MockPasskeyUseCase)
+		handler := newTestLoginHandler(nil, sessionMock, nil, nil, passkeyMock)
+		req := newRequest(credential)
+		rec := httptest.NewRecorder()
+
+		sessionMock.On("PopPasskeyChallenge", req.Context()).Return([]byte(nil))
+
+		// Act
+		handler.SubmitPasskeyLogin(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Your login has expired.")
+		passkeyMock.AssertNotCalled(t, "FinishLogin", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unreadable credential", func(t *testing.T) {
+		// Arrange
+		sessionMock := new(MockSessionManager)
+		passkeyMock := new(MockPasskeyUseCase)
+		handler := newTestLoginHandler(nil, sessionMock, nil, nil, passkeyMock)
+		req := newRequest("not json")
+		rec := httptest.NewRecorder()
+
+		sessionMock.On("PopPasskeyChallenge", req.Context()).Return([]byte{1, 2, 3})
+
+		// Act
+		handler.SubmitPasskeyLogin(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "The passkey could not be read.")
+		passkeyMock.AssertNotCalled(t, "FinishLogin", mock.Anything, mock.Anything)
+	})
+}
+
+func TestLoginHandler_SubmitReauthForm(t *testing.T) {
+	newRequest := func(password, next string) *http.Request {
+		formVals := url.Values{}
+		formVals.Add("password", password)
+		formVals.Add("next", next)
+		req := httptest.NewRequest(http.MethodPost, "/confirm-password", strings.NewReader(formVals.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req
+	}
+
+	t.Run("goes back to the page once the password is right", func(t *testing.T) {
+		// Arrange
+		authMock := new(MockAuthUseCase)
+		sessionMock := new(MockSessionManager)
+		handler := newTestLoginHandler(authMock, sessionMock, nil, nil, nil)
+		req := newRequest("password123", "/profile")
+		rec := httptest.NewRecorder()
+
+		sessionMock.On("GetUserID", req.Context()).Return("user-123")
+		authMock.On("ConfirmPassword", req.Context(), &usecase.ConfirmPasswordRequest{UserID: "user-123", Password: "password123"}).Return(nil)
+		sessionMock.On("ConfirmIdentity", req.Context()).Return()
+
+		// Act
+		handler.SubmitReauthForm(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusFound, rec.Code)
+		assert.Equal(t, "/profile", rec.Header().Get("HX-Redirect"))
+		sessionMock.AssertExpectations(t)
+	})
+
+	t.Run("never goes back to another site", func(t *testing.T) {
+		// Arrange
+		authMock := new(MockAuthUseCase)
+		sessionMock := new(MockSessionManager)
+		handler := newTestLoginHandler(authMock, sessionMock, nil, nil, nil)
+		req := newRequest("password123", "//evil.example.com")
+		rec := httptest.NewRecorder()
+
+		sessionMock.On("GetUserID", req.Context()).Return("user-123")
+		authMock.On("ConfirmPassword", req.Context(), mock.Anything).Return(nil)
+		sessionMock.On("ConfirmIdentity", req.Context()).Return()
+
+		// Act
+		handler.SubmitReauthForm(rec, req)
+
+		// Assert
+		assert.Equal(t, "/home", rec.Header().Get("HX-Redirect"))
+	})
+
+	t.Run("rejects a wrong password", func(t *testing.T) {
+		// Arrange
+		authMock := new(MockAuthUseCase)
+		sessionMock := new(MockSessionManager)
+		handler := newTestLoginHandler(authMock, sessionMock, nil, nil, nil)
+		req := newRequest("wrong-password", "/profile")
+		rec := httptest.NewRecorder()
+
+		sessionMock.On("GetUserID", req.Context()).Return("user-123")
+		authMock.On("ConfirmPassword", req.Context(), mock.Anything).Return(usecase.ErrInvalidCredentials)
+
+		// Act
+		handler.SubmitReauthForm(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "password is incorrect")
+		sessionMock.AssertNotCalled(t, "ConfirmIdentity", mock.Anything)
+	})
+
+	t.Run("repeated wrong passwords lock the client out", func(t *testing.T) {
+		// Arrange
+		authMock := new(MockAuthUseCase)
+		sessionMock := new(MockSessionManager)
+		handler := newTestLoginHandler(authMock, sessionMock, nil, nil, nil)
+		handler.app.Limiter = ratelimit.New(ratelimit.NewMemoryStore(), ratelimit.Lockout{Threshold: 2, Duration: time.Minute, MaxDuration: time.Hour})
+
+		submit := func(userID string) *httptest.ResponseRecorder {
+			req := newRequest("wrong-password", "/profile")
+			req.RemoteAddr = "192.0.2.1:1234"
+			rec := httptest.NewRecorder()
+			sessionMock.On("GetUserID", req.Context()).Return(userID).Once()
+			handler.SubmitReauthForm(rec, req)
+			return rec
+		}
+
+		authMock.On("ConfirmPassword", mock.Anything, mock.Anything).Return(usecase.ErrInvalidCredentials)
+
+		// Act & Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, submit("user-123").Code)
+
+		rec := submit("user-123")
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+
+		rec = submit("user-123")
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		authMock.AssertNumberOfCalls(t, "ConfirmPassword", 2)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, submit("user-456").Code, "other users are not locked out")
+		sessionMock.AssertNotCalled(t, "ConfirmIdentity", mock.Anything)
+	})
 }
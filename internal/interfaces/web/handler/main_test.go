@@ -2,6 +2,10 @@ package handler
 
 import (
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 
 	"github.com/madalinpopa/gocost-web/internal/interfaces/web/respond"
 )
@@ -12,3 +16,9 @@ func newTestErrors(logger *slog.Logger, errHandler respond.ErrorHandler) respond
 	}
 	return respond.NewErrorHandler(logger)
 }
+
+func newFormRequest(path string, values url.Values) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(values.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/alexedwards/scs/v2"
 	"github.com/madalinpopa/gocost-web/internal/usecase"
@@ -76,6 +77,94 @@ func (m *MockSessionManager) SetCurrency(ctx context.Context, currency string) {
 	m.Called(ctx, currency)
 }
 
+func (m *MockSessionManager) IsEmailVerified(ctx context.Context) bool {
+	args := m.Called(ctx)
+	return args.Bool(0)
+}
+
+func (m *MockSessionManager) SetEmailVerified(ctx context.Context, verified bool) {
+	m.Called(ctx, verified)
+}
+
+func (m *MockSessionManager) GetPendingUserID(ctx context.Context) string {
+	args := m.Called(ctx)
+	return args.String(0)
+}
+
+func (m *MockSessionManager) SetPendingUserID(ctx context.Context, userID string) {
+	m.Called(ctx, userID)
+}
+
+func (m *MockSessionManager) SetPasskeyChallenge(ctx context.Context, challenge []byte) {
+	m.Called(ctx, challenge)
+}
+
+func (m *MockSessionManager) PopPasskeyChallenge(ctx context.Context) []byte {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).([]byte)
+}
+
+func (m *MockSessionManager) SetRememberMe(ctx context.Context, remember bool) {
+	m.Called(ctx, remember)
+}
+
+func (m *MockSessionManager) KeepAlive(ctx context.Context) {
+	m.Called(ctx)
+}
+
+func (m *MockSessionManager) ConfirmIdentity(ctx context.Context) {
+	m.Called(ctx)
+}
+
+func (m *MockSessionManager) GetIdentityConfirmedAt(ctx context.Context) time.Time {
+	args := m.Called(ctx)
+	return args.Get(0).(time.Time)
+}
+
+func (m *MockSessionManager) DestroyOtherSessions(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockSessionManager) DestroyUserSessions(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockSessionManager) GetSessionID(ctx context.Context) string {
+	args := m.Called(ctx)
+	return args.String(0)
+}
+
+func (m *MockSessionManager) SetSessionID(ctx context.Context, id string) {
+	m.Called(ctx, id)
+}
+
+func (m *MockSessionManager) GetLastSeenAt(ctx context.Context) time.Time {
+	args := m.Called(ctx)
+	return args.Get(0).(time.Time)
+}
+
+func (m *MockSessionManager) SetLastSeenAt(ctx context.Context, at time.Time) {
+	m.Called(ctx, at)
+}
+
+func (m *MockSessionManager) ActiveSessionIDs(ctx context.Context, userID string) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockSessionManager) DestroySession(ctx context.Context, userID string, id string) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
 type MockAuthUseCase struct {
 	mock.Mock
 }
@@ -96,6 +185,11 @@ func (m *MockAuthUseCase) Login(ctx context.Context, req *usecase.LoginRequest)
 	return args.Get(0).(*usecase.LoginResponse), args.Error(1)
 }
 
+func (m *MockAuthUseCase) ConfirmPassword(ctx context.Context, req *usecase.ConfirmPasswordRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
 type MockIncomeUseCase struct {
 	mock.Mock
 }
@@ -170,11 +264,19 @@ func (m *MockGroupUseCase) Update(ctx context.Context, req *usecase.UpdateGroupR
 	return args.Get(0).(*usecase.GroupResponse), args.Error(1)
 }
 
-func (m *MockGroupUseCase) Delete(ctx context.Context, userID string, id string) error {
-	args := m.Called(ctx, userID, id)
+func (m *MockGroupUseCase) Delete(ctx context.Context, req *usecase.DeleteGroupRequest) error {
+	args := m.Called(ctx, req)
 	return args.Error(0)
 }
 
+func (m *MockGroupUseCase) DeletionImpact(ctx context.Context, userID string, id string, month string) (*usecase.DeletionImpactResponse, error) {
+	args := m.Called(ctx, userID, id, month)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.DeletionImpactResponse), args.Error(1)
+}
+
 func (m *MockGroupUseCase) Get(ctx context.Context, userID string, id string) (*usecase.GroupResponse, error) {
 	args := m.Called(ctx, userID, id)
 	if args.Get(0) == nil {
@@ -191,6 +293,21 @@ func (m *MockGroupUseCase) List(ctx context.Context, userID string) ([]*usecase.
 	return args.Get(0).([]*usecase.GroupResponse), args.Error(1)
 }
 
+func (m *MockGroupUseCase) Reorder(ctx context.Context, userID string, ids []string) error {
+	args := m.Called(ctx, userID, ids)
+	return args.Error(0)
+}
+
+func (m *MockGroupUseCase) Archive(ctx context.Context, userID string, id string) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockGroupUseCase) Restore(ctx context.Context, userID string, id string) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
 type MockCategoryUseCase struct {
 	mock.Mock
 }
@@ -211,11 +328,19 @@ func (m *MockCategoryUseCase) Update(ctx context.Context, req *usecase.UpdateCat
 	return args.Get(0).(*usecase.CategoryResponse), args.Error(1)
 }
 
-func (m *MockCategoryUseCase) Delete(ctx context.Context, userID string, groupID string, id string) error {
-	args := m.Called(ctx, userID, groupID, id)
+func (m *MockCategoryUseCase) Delete(ctx context.Context, req *usecase.DeleteCategoryRequest) error {
+	args := m.Called(ctx, req)
 	return args.Error(0)
 }
 
+func (m *MockCategoryUseCase) DeletionImpact(ctx context.Context, userID string, groupID string, id string, month string) (*usecase.DeletionImpactResponse, error) {
+	args := m.Called(ctx, userID, groupID, id, month)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.DeletionImpactResponse), args.Error(1)
+}
+
 func (m *MockCategoryUseCase) Get(ctx context.Context, userID string, groupID string, id string) (*usecase.CategoryResponse, error) {
 	args := m.Called(ctx, userID, groupID, id)
 	if args.Get(0) == nil {
@@ -232,6 +357,37 @@ func (m *MockCategoryUseCase) List(ctx context.Context, userID string, groupID s
 	return args.Get(0).([]usecase.CategoryResponse), args.Error(1)
 }
 
+func (m *MockCategoryUseCase) Reorder(ctx context.Context, userID string, groupID string, ids []string) error {
+	args := m.Called(ctx, userID, groupID, ids)
+	return args.Error(0)
+}
+
+func (m *MockCategoryUseCase) Archive(ctx context.Context, userID string, groupID string, id string) error {
+	args := m.Called(ctx, userID, groupID, id)
+	return args.Error(0)
+}
+
+func (m *MockCategoryUseCase) Restore(ctx context.Context, userID string, groupID string, id string) error {
+	args := m.Called(ctx, userID, groupID, id)
+	return args.Error(0)
+}
+
+func (m *MockCategoryUseCase) Move(ctx context.Context, req *usecase.MoveCategoryRequest) (*usecase.CategoryResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.CategoryResponse), args.Error(1)
+}
+
+func (m *MockCategoryUseCase) Merge(ctx context.Context, req *usecase.MergeCategoryRequest) (*usecase.CategoryResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.CategoryResponse), args.Error(1)
+}
+
 type MockExpenseUseCase struct {
 	mock.Mock
 }
@@ -297,3 +453,507 @@ func (m *MockDashboardUseCase) Get(ctx context.Context, req *usecase.DashboardRe
 	}
 	return args.Get(0).(*usecase.DashboardResponse), args.Error(1)
 }
+
+type MockPlanUseCase struct {
+	mock.Mock
+}
+
+func (m *MockPlanUseCase) Preview(ctx context.Context, userID string, fromMonth string) (*usecase.PlanPreviewResponse, error) {
+	args := m.Called(ctx, userID, fromMonth)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.PlanPreviewResponse), args.Error(1)
+}
+
+func (m *MockPlanUseCase) Apply(ctx context.Context, req *usecase.PlanMonthRequest) (*usecase.PlanMonthResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.PlanMonthResponse), args.Error(1)
+}
+
+type MockClosingUseCase struct {
+	mock.Mock
+}
+
+func (m *MockClosingUseCase) Status(ctx context.Context, userID string, month string) (*usecase.MonthStatusResponse, error) {
+	args := m.Called(ctx, userID, month)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.MonthStatusResponse), args.Error(1)
+}
+
+func (m *MockClosingUseCase) Close(ctx context.Context, req *usecase.CloseMonthRequest) (*usecase.MonthStatusResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.MonthStatusResponse), args.Error(1)
+}
+
+func (m *MockClosingUseCase) Reopen(ctx context.Context, userID string, month string) error {
+	args := m.Called(ctx, userID, month)
+	return args.Error(0)
+}
+
+type MockBudgetUseCase struct {
+	mock.Mock
+}
+
+func (m *MockBudgetUseCase) SetZeroBased(ctx context.Context, userID string, enabled bool) error {
+	args := m.Called(ctx, userID, enabled)
+	return args.Error(0)
+}
+
+func (m *MockBudgetUseCase) Assignment(ctx context.Context, userID string, month string) (*usecase.BudgetAssignmentResponse, error) {
+	args := m.Called(ctx, userID, month)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.BudgetAssignmentResponse), args.Error(1)
+}
+
+func (m *MockBudgetUseCase) FillFromLastMonth(ctx context.Context, req *usecase.FillBudgetRequest) (*usecase.BudgetAssignmentResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.BudgetAssignmentResponse), args.Error(1)
+}
+
+func (m *MockBudgetUseCase) Distribute(ctx context.Context, req *usecase.DistributeBudgetRequest) (*usecase.BudgetAssignmentResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.BudgetAssignmentResponse), args.Error(1)
+}
+
+type MockGoalUseCase struct {
+	mock.Mock
+}
+
+func (m *MockGoalUseCase) Create(ctx context.Context, req *usecase.CreateGoalRequest) (*usecase.GoalResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.GoalResponse), args.Error(1)
+}
+
+func (m *MockGoalUseCase) Update(ctx context.Context, req *usecase.UpdateGoalRequest) (*usecase.GoalResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.GoalResponse), args.Error(1)
+}
+
+func (m *MockGoalUseCase) Delete(ctx context.Context, userID string, id string) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockGoalUseCase) Get(ctx context.Context, userID string, id string, month string) (*usecase.GoalResponse, error) {
+	args := m.Called(ctx, userID, id, month)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.GoalResponse), args.Error(1)
+}
+
+func (m *MockGoalUseCase) List(ctx context.Context, userID string, month string) ([]usecase.GoalResponse, error) {
+	args := m.Called(ctx, userID, month)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]usecase.GoalResponse), args.Error(1)
+}
+
+func (m *MockGoalUseCase) Contribute(ctx context.Context, req *usecase.ContributeGoalRequest) (*usecase.GoalResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.GoalResponse), args.Error(1)
+}
+
+func (m *MockGoalUseCase) RemoveContribution(ctx context.Context, userID string, goalID string, contributionID string) error {
+	args := m.Called(ctx, userID, goalID, contributionID)
+	return args.Error(0)
+}
+
+type MockAccountUseCase struct {
+	mock.Mock
+}
+
+func (m *MockAccountUseCase) Create(ctx context.Context, req *usecase.CreateAccountRequest) (*usecase.AccountResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.AccountResponse), args.Error(1)
+}
+
+func (m *MockAccountUseCase) Update(ctx context.Context, req *usecase.UpdateAccountRequest) (*usecase.AccountResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.AccountResponse), args.Error(1)
+}
+
+func (m *MockAccountUseCase) Delete(ctx context.Context, userID string, id string) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockAccountUseCase) List(ctx context.Context, userID string) ([]usecase.AccountResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]usecase.AccountResponse), args.Error(1)
+}
+
+func (m *MockAccountUseCase) Ledger(ctx context.Context, userID string, id string) (*usecase.AccountLedgerResponse, error) {
+	args := m.Called(ctx, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.AccountLedgerResponse), args.Error(1)
+}
+
+func (m *MockAccountUseCase) Transfer(ctx context.Context, req *usecase.TransferRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAccountUseCase) DeleteTransfer(ctx context.Context, userID string, id string) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockAccountUseCase) CompareStatement(ctx context.Context, req *usecase.ReconcileRequest) (*usecase.ReconciliationResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ReconciliationResponse), args.Error(1)
+}
+
+func (m *MockAccountUseCase) Reconcile(ctx context.Context, req *usecase.ReconcileRequest) (*usecase.ReconciliationResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ReconciliationResponse), args.Error(1)
+}
+
+type MockLoanUseCase struct {
+	mock.Mock
+}
+
+func (m *MockLoanUseCase) Create(ctx context.Context, req *usecase.CreateLoanRequest) (*usecase.LoanResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.LoanResponse), args.Error(1)
+}
+
+func (m *MockLoanUseCase) Update(ctx context.Context, req *usecase.UpdateLoanRequest) (*usecase.LoanResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.LoanResponse), args.Error(1)
+}
+
+func (m *MockLoanUseCase) Delete(ctx context.Context, userID string, id string) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockLoanUseCase) List(ctx context.Context, userID string, month string) ([]usecase.LoanResponse, error) {
+	args := m.Called(ctx, userID, month)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]usecase.LoanResponse), args.Error(1)
+}
+
+func (m *MockLoanUseCase) Schedule(ctx context.Context, userID string, id string, month string) (*usecase.LoanScheduleResponse, error) {
+	args := m.Called(ctx, userID, id, month)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.LoanScheduleResponse), args.Error(1)
+}
+
+func (m *MockLoanUseCase) Simulate(ctx context.Context, req *usecase.SimulateLoanRequest) (*usecase.LoanSimulationResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.LoanSimulationResponse), args.Error(1)
+}
+
+type MockNetWorthUseCase struct {
+	mock.Mock
+}
+
+func (m *MockNetWorthUseCase) CreateAsset(ctx context.Context, req *usecase.CreateAssetRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockNetWorthUseCase) UpdateAsset(ctx context.Context, req *usecase.UpdateAssetRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockNetWorthUseCase) DeleteAsset(ctx context.Context, userID string, id string) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockNetWorthUseCase) RecordValuation(ctx context.Context, req *usecase.RecordValuationRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockNetWorthUseCase) RemoveValuation(ctx context.Context, userID string, assetID string, month string) error {
+	args := m.Called(ctx, userID, assetID, month)
+	return args.Error(0)
+}
+
+func (m *MockNetWorthUseCase) Summary(ctx context.Context, req *usecase.NetWorthRequest) (*usecase.NetWorthResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.NetWorthResponse), args.Error(1)
+}
+
+type MockAlertUseCase struct {
+	mock.Mock
+}
+
+func (m *MockAlertUseCase) Settings(ctx context.Context, userID string, month string) ([]usecase.AlertGroupResponse, error) {
+	args := m.Called(ctx, userID, month)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]usecase.AlertGroupResponse), args.Error(1)
+}
+
+func (m *MockAlertUseCase) SaveThresholds(ctx context.Context, req *usecase.SaveThresholdsRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAlertUseCase) Notifications(ctx context.Context, userID string, limit int) ([]usecase.NotificationResponse, error) {
+	args := m.Called(ctx, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]usecase.NotificationResponse), args.Error(1)
+}
+
+func (m *MockAlertUseCase) UnreadCount(ctx context.Context, userID string) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockAlertUseCase) MarkRead(ctx context.Context, userID string, id string) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockAlertUseCase) MarkAllRead(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+type MockProfileUseCase struct {
+	mock.Mock
+}
+
+func (m *MockProfileUseCase) Get(ctx context.Context, userID string) (*usecase.UserResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.UserResponse), args.Error(1)
+}
+
+func (m *MockProfileUseCase) UpdateProfile(ctx context.Context, req *usecase.UpdateProfileRequest) (*usecase.UserResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.UserResponse), args.Error(1)
+}
+
+func (m *MockProfileUseCase) ChangeCurrency(ctx context.Context, req *usecase.ChangeCurrencyRequest) (*usecase.UserResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.UserResponse), args.Error(1)
+}
+
+func (m *MockProfileUseCase) ChangePassword(ctx context.Context, req *usecase.ChangePasswordRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+type MockPasswordResetUseCase struct {
+	mock.Mock
+}
+
+func (m *MockPasswordResetUseCase) RequestReset(ctx context.Context, req *usecase.RequestPasswordResetRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockPasswordResetUseCase) ResetPassword(ctx context.Context, req *usecase.ResetPasswordRequest) (string, error) {
+	args := m.Called(ctx, req)
+	return args.String(0), args.Error(1)
+}
+
+type MockTwoFactorUseCase struct {
+	mock.Mock
+}
+
+func (m *MockTwoFactorUseCase) Status(ctx context.Context, userID string) (*usecase.TwoFactorStatusResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.TwoFactorStatusResponse), args.Error(1)
+}
+
+func (m *MockTwoFactorUseCase) BeginSetup(ctx context.Context, userID string) (*usecase.TwoFactorSetupResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.TwoFactorSetupResponse), args.Error(1)
+}
+
+func (m *MockTwoFactorUseCase) ConfirmSetup(ctx context.Context, req *usecase.ConfirmTwoFactorRequest) ([]string, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockTwoFactorUseCase) Disable(ctx context.Context, req *usecase.DisableTwoFactorRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorUseCase) Verify(ctx context.Context, req *usecase.VerifyTwoFactorRequest) (*usecase.UserResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.UserResponse), args.Error(1)
+}
+
+type MockEmailVerificationUseCase struct {
+	mock.Mock
+}
+
+func (m *MockEmailVerificationUseCase) SendVerification(ctx context.Context, req *usecase.SendVerificationRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockEmailVerificationUseCase) VerifyEmail(ctx context.Context, token string) (*usecase.UserResponse, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.UserResponse), args.Error(1)
+}
+
+type MockPasskeyUseCase struct {
+	mock.Mock
+}
+
+func (m *MockPasskeyUseCase) List(ctx context.Context, userID string) ([]usecase.PasskeyResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]usecase.PasskeyResponse), args.Error(1)
+}
+
+func (m *MockPasskeyUseCase) BeginRegistration(ctx context.Context, req *usecase.BeginPasskeyRegistrationRequest) (*usecase.PasskeyCreationResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.PasskeyCreationResponse), args.Error(1)
+}
+
+func (m *MockPasskeyUseCase) FinishRegistration(ctx context.Context, req *usecase.FinishPasskeyRegistrationRequest) (*usecase.PasskeyResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.PasskeyResponse), args.Error(1)
+}
+
+func (m *MockPasskeyUseCase) BeginLogin(ctx context.Context, origin string) (*usecase.PasskeyRequestResponse, error) {
+	args := m.Called(ctx, origin)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.PasskeyRequestResponse), args.Error(1)
+}
+
+func (m *MockPasskeyUseCase) FinishLogin(ctx context.Context, req *usecase.FinishPasskeyLoginRequest) (*usecase.UserResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.UserResponse), args.Error(1)
+}
+
+func (m *MockPasskeyUseCase) Revoke(ctx context.Context, req *usecase.RevokePasskeyRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+type MockSessionUseCase struct {
+	mock.Mock
+}
+
+func (m *MockSessionUseCase) Start(ctx context.Context, req *usecase.StartSessionRequest) (*usecase.UserSessionResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.UserSessionResponse), args.Error(1)
+}
+
+func (m *MockSessionUseCase) Touch(ctx context.Context, req *usecase.TouchSessionRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockSessionUseCase) List(ctx context.Context, userID string, activeIDs []string) ([]usecase.UserSessionResponse, error) {
+	args := m.Called(ctx, userID, activeIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]usecase.UserSessionResponse), args.Error(1)
+}
@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/networth"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/form"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/views"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/madalinpopa/gocost-web/ui/templates/components"
+	"github.com/madalinpopa/gocost-web/ui/templates/pages/private"
+)
+
+// netWorthMonths is how many months the net worth page shows, ending with
+// the month asked for.
+const netWorthMonths = 12
+
+type NetWorthHandler struct {
+	app      HandlerContext
+	netWorth usecase.NetWorthUseCase
+}
+
+func NewNetWorthHandler(app HandlerContext, netWorth usecase.NetWorthUseCase) NetWorthHandler {
+	return NetWorthHandler{
+		app:      app,
+		netWorth: netWorth,
+	}
+}
+
+func (h *NetWorthHandler) ShowNetWorthPage(w http.ResponseWriter, r *http.Request) {
+	data := h.app.Template.GetData(r)
+
+	netWorth, err := h.netWorthView(r)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	end := netWorthEnd(r)
+	earlier := end.AddDate(0, -netWorthMonths, 0).Format("2006-01")
+	later := end.AddDate(0, netWorthMonths, 0).Format("2006-01")
+
+	page := private.NetWorthPage(data, netWorth, &form.AssetForm{}, earlier, later)
+	h.app.Template.Render(w, r, page, http.StatusOK)
+}
+
+func (h *NetWorthHandler) CreateAsset(w http.ResponseWriter, r *http.Request) {
+	var assetForm form.AssetForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &assetForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !assetForm.IsValid() {
+		h.renderNetWorth(w, r, &assetForm, nil, http.StatusUnprocessableEntity)
+		return
+	}
+
+	err := h.netWorth.CreateAsset(r.Context(), &usecase.CreateAssetRequest{
+		UserID: h.app.Session.GetUserID(r.Context()),
+		Name:   assetForm.Name,
+		Kind:   assetForm.Kind,
+	})
+	if err != nil {
+		errMessage, isUserFacing := translateNetWorthError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to create asset", "error", err)
+		}
+		assetForm.AddNonFieldError(errMessage)
+		h.renderNetWorth(w, r, &assetForm, nil, http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.app.Notify.Toast(w, web.Success, "Asset added.")
+	h.renderNetWorth(w, r, nil, nil, http.StatusOK)
+}
+
+func (h *NetWorthHandler) UpdateAsset(w http.ResponseWriter, r *http.Request) {
+	var assetForm form.AssetForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &assetForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+	assetForm.ID = r.PathValue("id")
+
+	if !assetForm.IsValid() {
+		h.renderNetWorth(w, r, nil, fieldErrorMessages(assetForm.FieldErrors), http.StatusUnprocessableEntity)
+		return
+	}
+
+	err := h.netWorth.UpdateAsset(r.Context(), &usecase.UpdateAssetRequest{
+		ID:     assetForm.ID,
+		UserID: h.app.Session.GetUserID(r.Context()),
+		Name:   assetForm.Name,
+		Kind:   assetForm.Kind,
+	})
+	if err != nil {
+		errMessage, isUserFacing := translateNetWorthError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to update asset", "error", err)
+		}
+		h.renderNetWorth(w, r, nil, []string{errMessage}, http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.app.Notify.Toast(w, web.Success, "Asset updated.")
+	h.renderNetWorth(w, r, nil, nil, http.StatusOK)
+}
+
+func (h *NetWorthHandler) DeleteAsset(w http.ResponseWriter, r *http.Request) {
+	userID := h.app.Session.GetUserID(r.Context())
+	if err := h.netWorth.DeleteAsset(r.Context(), userID, r.PathValue("id")); err != nil {
+		errMessage, isUserFacing := translateNetWorthError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to delete asset", "error", err)
+		}
+		h.renderNetWorth(w, r, nil, []string{errMessage}, http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.app.Notify.Toast(w, web.Success, "Asset deleted.")
+	h.renderNetWorth(w, r, nil, nil, http.StatusOK)
+}
+
+// RecordValuation sets what the asset was worth at the end of a month. It
+// counts for the months after until another value is recorded.
+func (h *NetWorthHandler) RecordValuation(w http.ResponseWriter, r *http.Request) {
+	var valuationForm form.ValuationForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &valuationForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !valuationForm.IsValid() {
+		h.renderNetWorth(w, r, nil, fieldErrorMessages(valuationForm.FieldErrors), http.StatusUnprocessableEntity)
+		return
+	}
+
+	err := h.netWorth.RecordValuation(r.Context(), &usecase.RecordValuationRequest{
+		UserID:   h.app.Session.GetUserID(r.Context()),
+		Currency: h.app.Session.GetCurrency(r.Context()),
+		AssetID:  r.PathValue("id"),
+		Month:    valuationForm.Month,
+		Value:    valuationForm.ParsedValue(),
+	})
+	if err != nil {
+		errMessage, isUserFacing := translateNetWorthError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to record valuation", "error", err)
+		}
+		h.renderNetWorth(w, r, nil, []string{errMessage}, http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.app.Notify.Toast(w, web.Success, "Value recorded.")
+	h.renderNetWorth(w, r, nil, nil, http.StatusOK)
+}
+
+func (h *NetWorthHandler) RemoveValuation(w http.ResponseWriter, r *http.Request) {
+	userID := h.app.Session.GetUserID(r.Context())
+	if err := h.netWorth.RemoveValuation(r.Context(), userID, r.PathValue("id"), r.PathValue("month")); err != nil {
+		errMessage, isUserFacing := translateNetWorthError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to remove valuation", "error", err)
+		}
+		h.renderNetWorth(w, r, nil, []string{errMessage}, http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.app.Notify.Toast(w, web.Success, "Value removed.")
+	h.renderNetWorth(w, r, nil, nil, http.StatusOK)
+}
+
+// renderNetWorth renders the net worth manager for the range on display.
+// Errors from the per-asset actions are shown above the chart; the create
+// form keeps its own.
+func (h *NetWorthHandler) renderNetWorth(w http.ResponseWriter, r *http.Request, assetForm *form.AssetForm, actionErrors []string, status int) {
+	netWorth, err := h.netWorthView(r)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	if assetForm == nil {
+		assetForm = &form.AssetForm{}
+	}
+
+	h.app.Template.Render(w, r, components.NetWorthManager(netWorth, assetForm, actionErrors), status)
+}
+
+func (h *NetWorthHandler) netWorthView(r *http.Request) (views.NetWorthView, error) {
+	end := netWorthEnd(r)
+	currency := h.app.Session.GetCurrency(r.Context())
+
+	resp, err := h.netWorth.Summary(r.Context(), &usecase.NetWorthRequest{
+		UserID:    h.app.Session.GetUserID(r.Context()),
+		Currency:  currency,
+		FromMonth: end.AddDate(0, 1-netWorthMonths, 0).Format("2006-01"),
+		ToMonth:   end.Format("2006-01"),
+	})
+	if err != nil {
+		return views.NetWorthView{}, err
+	}
+
+	return views.NewNetWorthView(resp, currency)
+}
+
+// netWorthEnd is the first day of the last month on display, the month in
+// the query or the current one.
+func netWorthEnd(r *http.Request) time.Time {
+	month, _, _ := web.GetMonthParam(r)
+	return time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func translateNetWorthError(err error) (string, bool) {
+	switch {
+	case errors.Is(err, networth.ErrEmptyName):
+		return "Asset name cannot be empty.", true
+	case errors.Is(err, networth.ErrNameTooLong):
+		return "Asset name is too long.", true
+	case errors.Is(err, networth.ErrInvalidKind):
+		return "Choose a valid kind of asset.", true
+	case errors.Is(err, networth.ErrInvalidMonth):
+		return "Choose a valid month.", true
+	case errors.Is(err, networth.ErrInvalidValue):
+		return "Value cannot be negative.", true
+	case errors.Is(err, networth.ErrAssetNotFound):
+		return "Asset not found.", true
+	case errors.Is(err, networth.ErrValuationNotFound):
+		return "Value not found.", true
+	default:
+		return "An unexpected error occurred. Please try again later.", false
+	}
+}
@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-playground/form/v4"
+	"github.com/madalinpopa/gocost-web/internal/config"
+	"github.com/madalinpopa/gocost-web/internal/domain/networth"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/respond"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestNetWorthHandler(session *MockSessionManager, netWorthUC *MockNetWorthUseCase) NetWorthHandler {
+	cfg := &config.Config{Currency: "USD"}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	errHandler := newTestErrors(logger, new(MockErrorHandler))
+
+	return NewNetWorthHandler(HandlerContext{
+		Config:   cfg,
+		Decoder:  form.NewDecoder(),
+		Logger:   logger,
+		Session:  session,
+		Errors:   errHandler,
+		Htmx:     respond.NewHtmx(errHandler),
+		Notify:   respond.NewNotify(logger),
+		Template: web.NewTemplate(logger, cfg),
+	}, netWorthUC)
+}
+
+func newTestNetWorth() *usecase.NetWorthResponse {
+	return &usecase.NetWorthResponse{
+		FromMonth: "2023-04",
+		ToMonth:   "2024-03",
+		Months: []usecase.NetWorthPointResponse{
+			{Month: "2024-02", AssetsCents: 2000000, LiabilitiesCents: 2500000, NetWorthCents: -500000},
+			{Month: "2024-03", AssetsCents: 3000000, LiabilitiesCents: 1000000, NetWorthCents: 2000000},
+		},
+		Items: []usecase.NetWorthItemResponse{
+			{ID: "house", Name: "House", Source: usecase.NetWorthSourceAsset, Kind: "property", ValueCents: 3000000, Valued: true, ValuedMonth: "2024-03", Valuations: []usecase.AssetValuationResponse{
+				{Month: "2024-03", ValueCents: 3000000},
+			}},
+			{ID: "car", Name: "Car", Source: usecase.NetWorthSourceLoan, Kind: usecase.NetWorthSourceLoan, Liability: true, ValueCents: 1000000, Valued: true},
+		},
+	}
+}
+
+func TestNetWorthHandler_CreateAsset(t *testing.T) {
+	t.Run("creates the asset and renders the manager", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockNetWorthUC := new(MockNetWorthUseCase)
+		handler := newTestNetWorthHandler(mockSession, mockNetWorthUC)
+
+		req := newFormRequest("/net-worth/assets?month=2024-03", url.Values{
+			"asset-name": {"House"},
+			"asset-kind": {"property"},
+		})
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockNetWorthUC.On("CreateAsset", req.Context(), mock.MatchedBy(func(r *usecase.CreateAssetRequest) bool {
+			return r.UserID == "user-123" && r.Name == "House" && r.Kind == "property"
+		})).Return(nil)
+		mockNetWorthUC.On("Summary", req.Context(), mock.MatchedBy(func(r *usecase.NetWorthRequest) bool {
+			return r.FromMonth == "2023-04" && r.ToMonth == "2024-03" && r.Currency == "USD"
+		})).Return(newTestNetWorth(), nil)
+
+		// Act
+		handler.CreateAsset(rec, req)
+
+		// Assert
+		body := rec.Body.String()
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, body, `id="net-worth-manager"`)
+		assert.Contains(t, body, "Net worth, March 2024")
+		assert.Contains(t, body, `class="fill-rose-500"`)
+		assert.Contains(t, body, `hx-post="/net-worth/assets/house/valuations?month=2024-03"`)
+		assert.Contains(t, body, `href="/loans/car"`)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "Asset added.")
+		mockNetWorthUC.AssertExpectations(t)
+	})
+
+	t.Run("re-renders the form when the kind is unknown", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockNetWorthUC := new(MockNetWorthUseCase)
+		handler := newTestNetWorthHandler(mockSession, mockNetWorthUC)
+
+		req := newFormRequest("/net-worth/assets?month=2024-03", url.Values{
+			"asset-name": {"Boat"},
+			"asset-kind": {"boat"},
+		})
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockNetWorthUC.On("Summary", req.Context(), mock.Anything).Return(newTestNetWorth(), nil)
+
+		// Act
+		handler.CreateAsset(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "invalid asset kind")
+		mockNetWorthUC.AssertNotCalled(t, "CreateAsset", mock.Anything, mock.Anything)
+	})
+}
+
+func TestNetWorthHandler_RecordValuation(t *testing.T) {
+	t.Run("records the value for the month", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockNetWorthUC := new(MockNetWorthUseCase)
+		handler := newTestNetWorthHandler(mockSession, mockNetWorthUC)
+
+		req := newFormRequest("/net-worth/assets/house/valuations?month=2024-03", url.Values{
+			"valuation-month": {"2024-01"},
+			"valuation-value": {"300000"},
+		})
+		req.SetPathValue("id", "house")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockNetWorthUC.On("RecordValuation", req.Context(), mock.MatchedBy(func(r *usecase.RecordValuationRequest) bool {
+			return r.AssetID == "house" && r.Month == "2024-01" && r.Value == 300000 && r.Currency == "USD"
+		})).Return(nil)
+		mockNetWorthUC.On("Summary", req.Context(), mock.Anything).Return(newTestNetWorth(), nil)
+
+		// Act
+		handler.RecordValuation(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "Value recorded.")
+		mockNetWorthUC.AssertExpectations(t)
+	})
+
+	t.Run("shows why the value was refused", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockNetWorthUC := new(MockNetWorthUseCase)
+		handler := newTestNetWorthHandler(mockSession, mockNetWorthUC)
+
+		req := newFormRequest("/net-worth/assets/gone/valuations", url.Values{
+			"valuation-month": {"2024-01"},
+			"valuation-value": {"10"},
+		})
+		req.SetPathValue("id", "gone")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockNetWorthUC.On("RecordValuation", req.Context(), mock.Anything).Return(networth.ErrAssetNotFound)
+		mockNetWorthUC.On("Summary", req.Context(), mock.Anything).Return(newTestNetWorth(), nil)
+
+		// Act
+		handler.RecordValuation(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Asset not found.")
+	})
+}
+
+func TestNetWorthHandler_RemoveValuation(t *testing.T) {
+	// Arrange
+	mockSession := new(MockSessionManager)
+	mockNetWorthUC := new(MockNetWorthUseCase)
+	handler := newTestNetWorthHandler(mockSession, mockNetWorthUC)
+
+	req := httptest.NewRequest(http.MethodDelete, "/net-worth/assets/house/valuations/2024-03", nil)
+	req.SetPathValue("id", "house")
+	req.SetPathValue("month", "2024-03")
+	rec := httptest.NewRecorder()
+
+	mockSession.On("GetUserID", req.Context()).Return("user-123")
+	mockSession.On("GetCurrency", req.Context()).Return("USD")
+	mockNetWorthUC.On("RemoveValuation", req.Context(), "user-123", "house", "2024-03").Return(nil)
+	mockNetWorthUC.On("Summary", req.Context(), mock.Anything).Return(newTestNetWorth(), nil)
+
+	// Act
+	handler.RemoveValuation(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("HX-Trigger"), "Value removed.")
+	mockNetWorthUC.AssertExpectations(t)
+}
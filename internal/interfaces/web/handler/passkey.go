@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/form"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/views"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/madalinpopa/gocost-web/ui/templates/components"
+)
+
+type PasskeyHandler struct {
+	app      HandlerContext
+	passkeys usecase.PasskeyUseCase
+}
+
+func NewPasskeyHandler(app HandlerContext, passkeys usecase.PasskeyUseCase) PasskeyHandler {
+	return PasskeyHandler{
+		app:      app,
+		passkeys: passkeys,
+	}
+}
+
+// ShowSettings renders the passkeys section of the profile page.
+func (h *PasskeyHandler) ShowSettings(w http.ResponseWriter, r *http.Request) {
+	h.render(w, r, form.PasskeyForm{}, http.StatusOK)
+}
+
+// RegistrationOptions returns the options the browser creates a passkey
+// with, as JSON. The challenge is kept in the session until the passkey
+// comes back.
+func (h *PasskeyHandler) RegistrationOptions(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.passkeys.BeginRegistration(r.Context(), &usecase.BeginPasskeyRegistrationRequest{
+		UserID: h.app.Session.GetUserID(r.Context()),
+		Origin: h.app.Config.BaseURL,
+	})
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	h.app.Session.SetPasskeyChallenge(r.Context(), resp.Challenge)
+	writeJSON(w, http.StatusOK, resp.Options)
+}
+
+// Register saves the passkey the browser created under the name given to it.
+func (h *PasskeyHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var passkeyForm form.PasskeyForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &passkeyForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	challenge := h.app.Session.PopPasskeyChallenge(r.Context())
+	if challenge == nil && passkeyForm.IsValid() {
+		passkeyForm.AddNonFieldError("This request has expired. Please try again.")
+	}
+	if !passkeyForm.IsValid() {
+		h.render(w, r, passkeyForm, http.StatusUnprocessableEntity)
+		return
+	}
+
+	_, err := h.passkeys.FinishRegistration(r.Context(), &usecase.FinishPasskeyRegistrationRequest{
+		UserID:     h.app.Session.GetUserID(r.Context()),
+		Origin:     h.app.Config.BaseURL,
+		Name:       passkeyForm.Name,
+		Challenge:  challenge,
+		Credential: passkeyForm.Registration,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidPasskey):
+			passkeyForm.AddNonFieldError("This passkey could not be verified.")
+		case errors.Is(err, identity.ErrPasskeyAlreadyRegistered):
+			passkeyForm.AddNonFieldError("This passkey is already registered.")
+		case errors.Is(err, identity.ErrInvalidPasskeyName):
+			passkeyForm.AddFieldError("name", "this field is required")
+		default:
+			h.app.Logger.Error("failed to register passkey", "error", err)
+			passkeyForm.AddNonFieldError("An unexpected error occurred. Please try again later.")
+		}
+		h.render(w, r, passkeyForm, http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.app.Notify.Toast(w, web.Success, "Passkey added.")
+	h.render(w, r, form.PasskeyForm{}, http.StatusOK)
+}
+
+// Revoke removes a passkey. It can no longer log in.
+func (h *PasskeyHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	err := h.passkeys.Revoke(r.Context(), &usecase.RevokePasskeyRequest{
+		UserID:    h.app.Session.GetUserID(r.Context()),
+		PasskeyID: r.PathValue("id"),
+	})
+	if err != nil {
+		var passkeyForm form.PasskeyForm
+		if errors.Is(err, identity.ErrPasskeyNotFound) {
+			passkeyForm.AddNonFieldError("This passkey was already removed.")
+		} else {
+			h.app.Logger.Error("failed to revoke passkey", "error", err)
+			passkeyForm.AddNonFieldError("An unexpected error occurred. Please try again later.")
+		}
+		h.render(w, r, passkeyForm, http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.app.Notify.Toast(w, web.Success, "Passkey removed.")
+	h.render(w, r, form.PasskeyForm{}, http.StatusOK)
+}
+
+// render shows the passkeys of the user with the form that adds one.
+func (h *PasskeyHandler) render(w http.ResponseWriter, r *http.Request, f form.PasskeyForm, status int) {
+	passkeys, err := h.passkeys.List(r.Context(), h.app.Session.GetUserID(r.Context()))
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+	h.app.Template.Render(w, r, components.PasskeySettings(views.NewPasskeyViews(passkeys), f), status)
+}
+
+// writeJSON writes v as the JSON body of the response, for the options the
+// browser starts a passkey ceremony with.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-playground/form/v4"
+	"github.com/madalinpopa/gocost-web/internal/config"
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/respond"
+	"github.com/madalinpopa/gocost-web/internal/platform/webauthn"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestPasskeyHandler(session *MockSessionManager, passkeyUC *MockPasskeyUseCase) PasskeyHandler {
+	cfg := &config.Config{Currency: "USD", BaseURL: "https://gocost.example.com"}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	errHandler := newTestErrors(logger, new(MockErrorHandler))
+
+	return NewPasskeyHandler(HandlerContext{
+		Config:   cfg,
+		Decoder:  form.NewDecoder(),
+		Logger:   logger,
+		Session:  session,
+		Errors:   errHandler,
+		Htmx:     respond.NewHtmx(errHandler),
+		Notify:   respond.NewNotify(logger),
+		Template: web.NewTemplate(logger, cfg),
+	}, passkeyUC)
+}
+
+func TestPasskeyHandler_ShowSettings(t *testing.T) {
+	t.Run("lists the passkeys of the user", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockPasskeyUC := new(MockPasskeyUseCase)
+		handler := newTestPasskeyHandler(mockSession, mockPasskeyUC)
+
+		req := httptest.NewRequest(http.MethodGet, "/profile/passkeys", nil)
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockPasskeyUC.On("List", req.Context(), "user-123").Return([]usecase.PasskeyResponse{
+			{ID: "pk-1", Name: "Laptop", CreatedAt: time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)},
+		}, nil)
+
+		// Act
+		handler.ShowSettings(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Laptop")
+		assert.Contains(t, rec.Body.String(), "/profile/passkeys/pk-1")
+	})
+}
+
+func TestPasskeyHandler_RegistrationOptions(t *testing.T) {
+	t.Run("returns the options and keeps the challenge", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockPasskeyUC := new(MockPasskeyUseCase)
+		handler := newTestPasskeyHandler(mockSession, mockPasskeyUC)
+
+		req := httptest.NewRequest(http.MethodGet, "/profile/passkeys/options", nil)
+		rec := httptest.NewRecorder()
+
+		challenge := []byte{1, 2, 3}
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockPasskeyUC.On("BeginRegistration", req.Context(), &usecase.BeginPasskeyRegistrationRequest{
+			UserID: "user-123",
+			Origin: "https://gocost.example.com",
+		}).Return(&usecase.PasskeyCreationResponse{
+			Options:   webauthn.CreationOptions{Challenge: challenge},
+			Challenge: challenge,
+		}, nil)
+		mockSession.On("SetPasskeyChallenge", req.Context(), challenge).Return()
+
+		// Act
+		handler.RegistrationOptions(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), `"challenge":"AQID"`)
+		mockSession.AssertExpectations(t)
+	})
+}
+
+func TestPasskeyHandler_Register(t *testing.T) {
+	const credential = `{"id":"BAUG","rawId":"BAUG","type":"public-key","response":{"clientDataJSON":"AQ","attestationObject":"Ag"}}`
+	values := url.Values{"name": {"Laptop"}, "credential": {credential}}
+
+	t.Run("saves the passkey", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockPasskeyUC := new(MockPasskeyUseCase)
+		handler := newTestPasskeyHandler(mockSession, mockPasskeyUC)
+
+		req := newFormRequest("/profile/passkeys", values)
+		rec := httptest.NewRecorder()
+
+		mockSession.On("PopPasskeyChallenge", req.Context()).Return([]byte{1, 2, 3})
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockPasskeyUC.On("FinishRegistration", req.Context(), mock.MatchedBy(func(r *usecase.FinishPasskeyRegistrationRequest) bool {
+			return r.UserID == "user-123" && r.Name == "Laptop" && r.Origin == "https://gocost.example.com"
+		})).Return(&usecase.PasskeyResponse{ID: "pk-1", Name: "Laptop"}, nil)
+		mockPasskeyUC.On("List", req.Context(), "user-123").Return([]usecase.PasskeyResponse{
+			{ID: "pk-1", Name: "Laptop", CreatedAt: time.Now()},
+		}, nil)
+
+		// Act
+		handler.Register(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "Passkey added.")
+		assert.Contains(t, rec.Body.String(), "Laptop")
+		mockPasskeyUC.AssertExpectations(t)
+	})
+
+	t.Run("passkey already registered", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockPasskeyUC := new(MockPasskeyUseCase)
+		handler := newTestPasskeyHandler(mockSession, mockPasskeyUC)
+
+		req := newFormRequest("/profile/passkeys", values)
+		rec := httptest.NewRecorder()
+
+		mockSession.On("PopPasskeyChallenge", req.Context()).Return([]byte{1, 2, 3})
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockPasskeyUC.On("FinishRegistration", req.Context(), mock.Anything).Return(nil, identity.ErrPasskeyAlreadyRegistered)
+		mockPasskeyUC.On("List", req.Context(), "user-123").Return([]usecase.PasskeyResponse{}, nil)
+
+		// Act
+		handler.Register(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "This passkey is already registered.")
+	})
+
+	t.Run("expired challenge", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockPasskeyUC := new(MockPasskeyUseCase)
+		handler := newTestPasskeyHandler(mockSession, mockPasskeyUC)
+
+		req := newFormRequest("/profile/passkeys", values)
+		rec := httptest.NewRecorder()
+
+		mockSession.On("PopPasskeyChallenge", req.Context()).Return([]byte(nil))
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockPasskeyUC.On("List", req.Context(), "user-123").Return([]usecase.PasskeyResponse{}, nil)
+
+		// Act
+		handler.Register(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "This request has expired.")
+		mockPasskeyUC.AssertNotCalled(t, "FinishRegistration", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPasskeyHandler_Revoke(t *testing.T) {
+	t.Run("removes the passkey", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockPasskeyUC := new(MockPasskeyUseCase)
+		handler := newTestPasskeyHandler(mockSession, mockPasskeyUC)
+
+		req := httptest.NewRequest(http.MethodDelete, "/profile/passkeys/pk-1", nil)
+		req.SetPathValue("id", "pk-1")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockPasskeyUC.On("Revoke", req.Context(), &usecase.RevokePasskeyRequest{UserID: "user-123", PasskeyID: "pk-1"}).Return(nil)
+		mockPasskeyUC.On("List", req.Context(), "user-123").Return([]usecase.PasskeyResponse{}, nil)
+
+		// Act
+		handler.Revoke(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "Passkey removed.")
+		mockPasskeyUC.AssertExpectations(t)
+	})
+
+	t.Run("passkey not found", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockPasskeyUC := new(MockPasskeyUseCase)
+		handler := newTestPasskeyHandler(mockSession, mockPasskeyUC)
+
+		req := httptest.NewRequest(http.MethodDelete, "/profile/passkeys/pk-1", nil)
+		req.SetPathValue("id", "pk-1")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockPasskeyUC.On("Revoke", req.Context(), mock.Anything).Return(identity.ErrPasskeyNotFound)
+		mockPasskeyUC.On("List", req.Context(), "user-123").Return([]usecase.PasskeyResponse{}, nil)
+
+		// Act
+		handler.Revoke(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "This passkey was already removed.")
+	})
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/form"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/madalinpopa/gocost-web/ui/templates/pages/public"
+)
+
+// resetPasswordPath is the page the emailed reset links open.
+const resetPasswordPath = "/password/reset"
+
+type PasswordResetHandler struct {
+	app   HandlerContext
+	reset usecase.PasswordResetUseCase
+}
+
+func NewPasswordResetHandler(app HandlerContext, reset usecase.PasswordResetUseCase) PasswordResetHandler {
+	return PasswordResetHandler{
+		app:   app,
+		reset: reset,
+	}
+}
+
+func (h PasswordResetHandler) ShowForgotPage(w http.ResponseWriter, r *http.Request) {
+	data := h.app.Template.GetData(r)
+	page := public.ForgotPasswordPage(data)
+	h.app.Template.Render(w, r, page, http.StatusOK)
+}
+
+func (h PasswordResetHandler) ShowForgotForm(w http.ResponseWriter, r *http.Request) {
+	forgotForm := form.ForgotPasswordForm{Captcha: newCaptcha(h.app, r)}
+	h.app.Template.Render(w, r, public.ForgotPasswordForm(forgotForm), http.StatusOK)
+}
+
+// SubmitForgotForm emails a reset link. The answer is the same whether or not
+// an account uses the email.
+func (h PasswordResetHandler) SubmitForgotForm(w http.ResponseWriter, r *http.Request) {
+	var forgotForm form.ForgotPasswordForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &forgotForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	checkCaptcha(h.app, &forgotForm.Base, forgotForm.Captcha)
+	if !forgotForm.IsValid() {
+		forgotForm.Captcha = newCaptcha(h.app, r)
+		h.app.Template.Render(w, r, public.ForgotPasswordForm(forgotForm), http.StatusUnprocessableEntity)
+		return
+	}
+
+	err := h.reset.RequestReset(r.Context(), &usecase.RequestPasswordResetRequest{
+		EmailRequest: usecase.EmailRequest{Email: forgotForm.Email},
+		ResetURL:     h.app.Config.BaseURL + resetPasswordPath,
+	})
+	if err != nil {
+		h.app.Errors.Error(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	h.app.Template.Render(w, r, public.ForgotPasswordSent(forgotForm.Email), http.StatusOK)
+}
+
+func (h PasswordResetHandler) ShowResetPage(w http.ResponseWriter, r *http.Request) {
+	data := h.app.Template.GetData(r)
+	resetForm := form.ResetPasswordForm{
+		Token:   r.URL.Query().Get("token"),
+		Captcha: newCaptcha(h.app, r),
+	}
+	page := public.ResetPasswordPage(data, resetForm)
+	h.app.Template.Render(w, r, page, http.StatusOK)
+}
+
+// SubmitResetForm sets the new password and signs the user out everywhere.
+func (h PasswordResetHandler) SubmitResetForm(w http.ResponseWriter, r *http.Request) {
+	var resetForm form.ResetPasswordForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &resetForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	checkCaptcha(h.app, &resetForm.Base, resetForm.Captcha)
+	if !resetForm.IsValid() {
+		resetForm.Captcha = newCaptcha(h.app, r)
+		h.app.Template.Render(w, r, public.ResetPasswordForm(resetForm), http.StatusUnprocessableEntity)
+		return
+	}
+
+	userID, err := h.reset.ResetPassword(r.Context(), &usecase.ResetPasswordRequest{
+		Token:       resetForm.Token,
+		NewPassword: resetForm.NewPassword,
+	})
+	if err != nil {
+		if errors.Is(err, identity.ErrInvalidResetToken) {
+			resetForm.AddNonFieldError("This reset link is invalid or has expired.")
+		} else {
+			errMessage, isUserFacing := translateError(err)
+			if !isUserFacing {
+				h.app.Logger.Error("failed to reset password", "error", err)
+			}
+			resetForm.AddNonFieldError(errMessage)
+		}
+		resetForm.Captcha = newCaptcha(h.app, r)
+		h.app.Template.Render(w, r, public.ResetPasswordForm(resetForm), http.StatusUnprocessableEntity)
+		return
+	}
+
+	if err := h.app.Session.DestroyUserSessions(r.Context(), userID); err != nil {
+		h.app.Errors.ServerError(w, r, fmt.Errorf("failed to sign out sessions: %w", err))
+		return
+	}
+
+	h.app.Template.Render(w, r, public.ResetPasswordDone(), http.StatusOK)
+}
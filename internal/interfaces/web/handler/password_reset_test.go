@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/form/v4"
+	"github.com/madalinpopa/gocost-web/internal/config"
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/respond"
+	"github.com/madalinpopa/gocost-web/internal/platform/captcha"
+	"github.com/madalinpopa/gocost-web/internal/platform/security"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestPasswordResetHandler(session *MockSessionManager, resetUC *MockPasswordResetUseCase, mockErrors *MockErrorHandler) PasswordResetHandler {
+	cfg := &config.Config{Currency: "USD", BaseURL: "https://gocost.example"}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	errHandler := newTestErrors(logger, mockErrors)
+
+	return NewPasswordResetHandler(HandlerContext{
+		Config:   cfg,
+		Decoder:  form.NewDecoder(),
+		Logger:   logger,
+		Session:  session,
+		Errors:   errHandler,
+		Htmx:     respond.NewHtmx(errHandler),
+		Notify:   respond.NewNotify(logger),
+		Template: web.NewTemplate(logger, cfg),
+	}, resetUC)
+}
+
+func TestPasswordResetHandler_SubmitForgotForm(t *testing.T) {
+	t.Run("requests a link to the reset page", func(t *testing.T) {
+		// Arrange
+		mockResetUC := new(MockPasswordResetUseCase)
+		handler := newTestPasswordResetHandler(new(MockSessionManager), mockResetUC, new(MockErrorHandler))
+
+		req := httptest.NewRequest(http.MethodPost, "/password/forgot", strings.NewReader(url.Values{"email": {"alice@example.com"}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		mockResetUC.On("RequestReset", req.Context(), &usecase.RequestPasswordResetRequest{
+			EmailRequest: usecase.EmailRequest{Email: "alice@example.com"},
+			ResetURL:     "https://gocost.example/password/reset",
+		}).Return(nil)
+
+		// Act
+		handler.SubmitForgotForm(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "If an account uses")
+		assert.Contains(t, rec.Body.String(), "alice@example.com")
+		mockResetUC.AssertExpectations(t)
+	})
+
+	t.Run("keeps the form when the email is not valid", func(t *testing.T) {
+		// Arrange
+		mockResetUC := new(MockPasswordResetUseCase)
+		handler := newTestPasswordResetHandler(new(MockSessionManager), mockResetUC, new(MockErrorHandler))
+
+		req := httptest.NewRequest(http.MethodPost, "/password/forgot", strings.NewReader(url.Values{"email": {"alice"}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		// Act
+		handler.SubmitForgotForm(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "please enter a valid e-mail address")
+		mockResetUC.AssertNotCalled(t, "RequestReset", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPasswordResetHandler_ShowForgotForm(t *testing.T) {
+	t.Run("asks the browser to solve a challenge", func(t *testing.T) {
+		// Arrange
+		handler := newTestPasswordResetHandler(new(MockSessionManager), new(MockPasswordResetUseCase), new(MockErrorHandler))
+		handler.app.Captcha = captcha.New(security.NewSigner([]byte("test-key")), 12)
+
+		req := httptest.NewRequest(http.MethodGet, "/password/forgot/form", nil)
+		rec := httptest.NewRecorder()
+
+		// Act
+		handler.ShowForgotForm(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `name="captcha-challenge"`)
+		assert.Contains(t, rec.Body.String(), `data-captcha-difficulty="12"`)
+	})
+}
+
+func TestPasswordResetHandler_ShowResetPage(t *testing.T) {
+	// Arrange
+	handler := newTestPasswordResetHandler(new(MockSessionManager), new(MockPasswordResetUseCase), new(MockErrorHandler))
+	req := httptest.NewRequest(http.MethodGet, "/password/reset?token=abc123", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ShowResetPage(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `name="token" value="abc123"`)
+}
+
+func TestPasswordResetHandler_ShowResetPage_Captcha(t *testing.T) {
+	// Arrange
+	handler := newTestPasswordResetHandler(new(MockSessionManager), new(MockPasswordResetUseCase), new(MockErrorHandler))
+	handler.app.Captcha = captcha.New(security.NewSigner([]byte("test-key")), 12)
+	req := httptest.NewRequest(http.MethodGet, "/password/reset?token=abc123", nil)
+	rec := httptest.NewRecorder()
+
+	// Act
+	handler.ShowResetPage(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `name="captcha-challenge"`)
+	assert.Contains(t, rec.Body.String(), `data-captcha-difficulty="12"`)
+}
+
+func TestPasswordResetHandler_SubmitResetForm(t *testing.T) {
+	formValues := url.Values{
+		"token":            {"abc123"},
+		"new-password":     {"new-password"},
+		"confirm-password": {"new-password"},
+	}
+
+	t.Run("sets the password and signs the user out everywhere", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockResetUC := new(MockPasswordResetUseCase)
+		handler := newTestPasswordResetHandler(mockSession, mockResetUC, new(MockErrorHandler))
+
+		req := httptest.NewRequest(http.MethodPost, "/password/reset", strings.NewReader(formValues.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		mockResetUC.On("ResetPassword", req.Context(), &usecase.ResetPasswordRequest{
+			Token:       "abc123",
+			NewPassword: "new-password",
+		}).Return("user-123", nil)
+		mockSession.On("DestroyUserSessions", req.Context(), "user-123").Return(nil)
+
+		// Act
+		handler.SubmitResetForm(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Password changed")
+		mockSession.AssertExpectations(t)
+	})
+
+	t.Run("tells when the link is invalid or expired", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockResetUC := new(MockPasswordResetUseCase)
+		handler := newTestPasswordResetHandler(mockSession, mockResetUC, new(MockErrorHandler))
+
+		req := httptest.NewRequest(http.MethodPost, "/password/reset", strings.NewReader(formValues.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		mockResetUC.On("ResetPassword", req.Context(), mock.Anything).Return("", identity.ErrInvalidResetToken)
+
+		// Act
+		handler.SubmitResetForm(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "This reset link is invalid or has expired.")
+		assert.Contains(t, rec.Body.String(), `href="/password/forgot"`)
+		mockSession.AssertNotCalled(t, "DestroyUserSessions", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a missing captcha", func(t *testing.T) {
+		// Arrange
+		mockResetUC := new(MockPasswordResetUseCase)
+		handler := newTestPasswordResetHandler(new(MockSessionManager), mockResetUC, new(MockErrorHandler))
+		handler.app.Captcha = captcha.New(security.NewSigner([]byte("test-key")), 12)
+
+		req := httptest.NewRequest(http.MethodPost, "/password/reset", strings.NewReader(formValues.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		// Act
+		handler.SubmitResetForm(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "The browser check did not pass or has expired.")
+		assert.Contains(t, rec.Body.String(), `data-captcha-difficulty="12"`)
+		mockResetUC.AssertNotCalled(t, "ResetPassword", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an unsolved captcha with a new challenge", func(t *testing.T) {
+		// Arrange
+		mockResetUC := new(MockPasswordResetUseCase)
+		handler := newTestPasswordResetHandler(new(MockSessionManager), mockResetUC, new(MockErrorHandler))
+		handler.app.Captcha = captcha.New(security.NewSigner([]byte("test-key")), 16)
+
+		challenge, err := handler.app.Captcha.Issue()
+		assert.NoError(t, err)
+		values := url.Values{
+			"token":             {"abc123"},
+			"new-password":      {"new-password"},
+			"confirm-password":  {"new-password"},
+			"captcha-challenge": {challenge.Token},
+			"captcha-nonce":     {"not-a-solution"},
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/password/reset", strings.NewReader(values.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		// Act
+		handler.SubmitResetForm(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "The browser check did not pass or has expired.")
+		assert.NotContains(t, rec.Body.String(), challenge.Token)
+		mockResetUC.AssertNotCalled(t, "ResetPassword", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
+	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/form"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/views"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/madalinpopa/gocost-web/ui/templates/components"
+	"github.com/madalinpopa/gocost-web/ui/templates/pages/private"
+)
+
+type PlanHandler struct {
+	app  HandlerContext
+	plan usecase.PlanUseCase
+}
+
+func NewPlanHandler(app HandlerContext, plan usecase.PlanUseCase) PlanHandler {
+	return PlanHandler{
+		app:  app,
+		plan: plan,
+	}
+}
+
+func (h *PlanHandler) ShowPlanPage(w http.ResponseWriter, r *http.Request) {
+	data := h.app.Template.GetData(r)
+	currentDate, _, _ := web.GetMonthParam(r)
+
+	preview, err := h.plan.Preview(r.Context(), data.User.ID, currentDate.Format("2006-01"))
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	plan, err := views.NewPlanView(preview, data.Currency)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	page := private.PlanPage(data, plan, &form.PlanMonthForm{FromMonth: plan.FromMonth})
+	h.app.Template.Render(w, r, page, http.StatusOK)
+}
+
+func (h *PlanHandler) ApplyPlan(w http.ResponseWriter, r *http.Request) {
+	var planForm form.PlanMonthForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &planForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := h.app.Session.GetUserID(r.Context())
+	currency := h.app.Session.GetCurrency(r.Context())
+
+	if !planForm.IsValid() {
+		h.renderPlanForm(w, r, userID, currency, &planForm)
+		return
+	}
+
+	items := make([]usecase.PlanItemRequest, 0, len(planForm.Selected))
+	for _, id := range planForm.Selected {
+		items = append(items, usecase.PlanItemRequest{
+			CategoryID: id,
+			Budget:     planForm.ParsedBudget(id),
+		})
+	}
+
+	resp, err := h.plan.Apply(r.Context(), &usecase.PlanMonthRequest{
+		UserID:        userID,
+		Currency:      currency,
+		FromMonth:     planForm.FromMonth,
+		AdjustPercent: planForm.ParsedAdjustPercent(),
+		Items:         items,
+	})
+	if err != nil {
+		errMessage, isUserFacing := translatePlanError(err)
+		if !isUserFacing {
+			h.app.Logger.Error("failed to apply plan", "error", err)
+		}
+		planForm.AddNonFieldError(errMessage)
+		h.renderPlanForm(w, r, userID, currency, &planForm)
+		return
+	}
+
+	result := views.NewPlanResultView(resp)
+	h.app.Notify.Toast(w, web.Success, fmt.Sprintf("%s is planned.", result.ToMonthLabel))
+	h.app.Template.Render(w, r, components.PlanResult(result), http.StatusOK)
+}
+
+// renderPlanForm shows the wizard again with the submitted values and errors.
+func (h *PlanHandler) renderPlanForm(w http.ResponseWriter, r *http.Request, userID, currency string, planForm *form.PlanMonthForm) {
+	preview, err := h.plan.Preview(r.Context(), userID, planForm.FromMonth)
+	if err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	plan, err := views.NewPlanView(preview, currency)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	h.app.Template.Render(w, r, components.PlanForm(plan, planForm), http.StatusUnprocessableEntity)
+}
+
+func translatePlanError(err error) (string, bool) {
+	switch {
+	case errors.Is(err, tracking.ErrInvalidMonth):
+		return "Invalid month format.", true
+	case errors.Is(err, tracking.ErrGroupArchived):
+		return "This group is archived. Restore it first.", true
+	case errors.Is(err, closing.ErrMonthClosed):
+		return monthClosedMessage, true
+	default:
+		return "An unexpected error occurred. Please try again later.", false
+	}
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/form/v4"
+	"github.com/madalinpopa/gocost-web/internal/config"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/respond"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestPlanHandler(session *MockSessionManager, planUC *MockPlanUseCase) PlanHandler {
+	cfg := &config.Config{Currency: "USD"}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	return NewPlanHandler(HandlerContext{
+		Config:   cfg,
+		Decoder:  form.NewDecoder(),
+		Logger:   logger,
+		Session:  session,
+		Errors:   newTestErrors(logger, new(MockErrorHandler)),
+		Notify:   respond.NewNotify(logger),
+		Template: web.NewTemplate(logger, cfg),
+	}, planUC)
+}
+
+func TestPlanHandler_ApplyPlan(t *testing.T) {
+	preview := &usecase.PlanPreviewResponse{
+		FromMonth: "2024-03",
+		ToMonth:   "2024-04",
+		Groups: []usecase.PlanGroupResponse{
+			{ID: "group-1", Name: "Home", Items: []usecase.PlanItemResponse{
+				{CategoryID: "cat-1", Name: "Gifts", BudgetCents: 5000},
+			}},
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockPlanUC := new(MockPlanUseCase)
+		handler := newTestPlanHandler(mockSession, mockPlanUC)
+
+		formValues := url.Values{}
+		formValues.Set("from-month", "2024-03")
+		formValues.Set("adjust-percent", "10")
+		formValues.Add("selected", "cat-1")
+		formValues.Set("budget[cat-1]", "60")
+
+		req := httptest.NewRequest(http.MethodPost, "/plan", strings.NewReader(formValues.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockPlanUC.On("Apply", req.Context(), mock.MatchedBy(func(r *usecase.PlanMonthRequest) bool {
+			return r.UserID == "user-123" &&
+				r.FromMonth == "2024-03" &&
+				r.AdjustPercent == 10 &&
+				len(r.Items) == 1 &&
+				r.Items[0].CategoryID == "cat-1" &&
+				r.Items[0].Budget == 60
+		})).Return(&usecase.PlanMonthResponse{ToMonth: "2024-04", Created: 1, Skipped: []string{"Trip"}}, nil)
+
+		// Act
+		handler.ApplyPlan(rec, req)
+
+		// Assert
+		body := rec.Body.String()
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, body, "April 2024 is ready")
+		assert.Contains(t, body, "Trip")
+		assert.Contains(t, body, `href="/home?month=2024-04"`)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "April 2024 is planned.")
+		mockPlanUC.AssertExpectations(t)
+	})
+
+	t.Run("re-renders the wizard when nothing is selected", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockPlanUC := new(MockPlanUseCase)
+		handler := newTestPlanHandler(mockSession, mockPlanUC)
+
+		formValues := url.Values{}
+		formValues.Set("from-month", "2024-03")
+		formValues.Set("budget[cat-1]", "50.00")
+
+		req := httptest.NewRequest(http.MethodPost, "/plan", strings.NewReader(formValues.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetCurrency", req.Context()).Return("USD")
+		mockPlanUC.On("Preview", req.Context(), "user-123", "2024-03").Return(preview, nil)
+
+		// Act
+		handler.ApplyPlan(rec, req)
+
+		// Assert
+		body := rec.Body.String()
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, body, "Select at least one category to carry over.")
+		assert.Contains(t, body, "Gifts")
+		mockPlanUC.AssertNotCalled(t, "Apply", mock.Anything, mock.Anything)
+	})
+}
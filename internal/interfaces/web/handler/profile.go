@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/form"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/madalinpopa/gocost-web/ui/templates/components"
+	"github.com/madalinpopa/gocost-web/ui/templates/pages/private"
+)
+
+type ProfileHandler struct {
+	app          HandlerContext
+	profile      usecase.ProfileUseCase
+	verification usecase.EmailVerificationUseCase
+}
+
+func NewProfileHandler(app HandlerContext, profile usecase.ProfileUseCase, verification usecase.EmailVerificationUseCase) ProfileHandler {
+	return ProfileHandler{
+		app:          app,
+		profile:      profile,
+		verification: verification,
+	}
+}
+
+func (h *ProfileHandler) ShowProfilePage(w http.ResponseWriter, r *http.Request) {
+	data := h.app.Template.GetData(r)
+
+	user, err := h.profile.Get(r.Context(), data.User.ID)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	page := private.ProfilePage(data,
+		form.ProfileForm{Email: user.Email, Username: user.Username},
+		form.CurrencyForm{Currency: user.Currency},
+	)
+	h.app.Template.Render(w, r, page, http.StatusOK)
+}
+
+// UpdateProfile changes the email and username, and the username kept in the
+// session. A new email is sent a link to verify it.
+func (h *ProfileHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	var profileForm form.ProfileForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &profileForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !profileForm.IsValid() {
+		h.app.Template.Render(w, r, components.ProfileForm(profileForm), http.StatusUnprocessableEntity)
+		return
+	}
+
+	user, err := h.profile.UpdateProfile(r.Context(), &usecase.UpdateProfileRequest{
+		UserID:          h.app.Session.GetUserID(r.Context()),
+		EmailRequest:    usecase.EmailRequest{Email: profileForm.Email},
+		UsernameRequest: usecase.UsernameRequest{Username: profileForm.Username},
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, identity.ErrEmailTaken):
+			profileForm.AddFieldError("email", "this email is already in use")
+		case errors.Is(err, identity.ErrUsernameTaken):
+			profileForm.AddFieldError("username", "this username is already in use")
+		default:
+			errMessage, isUserFacing := translateError(err)
+			if !isUserFacing {
+				h.app.Logger.Error("failed to update profile", "error", err)
+			}
+			profileForm.AddNonFieldError(errMessage)
+		}
+		h.app.Template.Render(w, r, components.ProfileForm(profileForm), http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.app.Session.SetUsername(r.Context(), user.Username)
+	message := "Profile saved."
+	if !user.EmailVerified {
+		h.app.Session.SetEmailVerified(r.Context(), false)
+		err := sendVerification(r.Context(), h.app, h.verification, user.ID)
+		if err != nil && !errors.Is(err, identity.ErrVerificationRateLimited) {
+			h.app.Logger.Error("failed to send verification email", "error", err)
+		}
+		message = "Profile saved. Check your inbox to verify your email."
+	}
+	h.app.Notify.Toast(w, web.Success, message)
+	h.app.Template.Render(w, r, components.ProfileForm(form.ProfileForm{Email: user.Email, Username: user.Username}), http.StatusOK)
+}
+
+// UpdateCurrency changes the currency, and the currency kept in the session.
+func (h *ProfileHandler) UpdateCurrency(w http.ResponseWriter, r *http.Request) {
+	var currencyForm form.CurrencyForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &currencyForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !currencyForm.IsValid() {
+		h.app.Template.Render(w, r, components.CurrencyForm(currencyForm), http.StatusUnprocessableEntity)
+		return
+	}
+
+	user, err := h.profile.ChangeCurrency(r.Context(), &usecase.ChangeCurrencyRequest{
+		UserID:   h.app.Session.GetUserID(r.Context()),
+		Currency: currencyForm.ParsedCurrency(),
+	})
+	if err != nil {
+		if errors.Is(err, identity.ErrInvalidCurrency) {
+			currencyForm.AddFieldError("currency", "unknown currency code")
+		} else {
+			h.app.Logger.Error("failed to change currency", "error", err)
+			currencyForm.AddNonFieldError("An unexpected error occurred. Please try again later.")
+		}
+		h.app.Template.Render(w, r, components.CurrencyForm(currencyForm), http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.app.Session.SetCurrency(r.Context(), user.Currency)
+	h.app.Notify.Toast(w, web.Success, "Currency saved.")
+	h.app.Template.Render(w, r, components.CurrencyForm(form.CurrencyForm{Currency: user.Currency}), http.StatusOK)
+}
+
+// ChangePassword sets a new password and signs the user out of every other
+// session.
+func (h *ProfileHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	var passwordForm form.PasswordForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &passwordForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !passwordForm.IsValid() {
+		h.app.Template.Render(w, r, components.PasswordForm(passwordForm), http.StatusUnprocessableEntity)
+		return
+	}
+
+	userID := h.app.Session.GetUserID(r.Context())
+	if lockedOut(h.app, w, r, userID) {
+		return
+	}
+
+	err := h.profile.ChangePassword(r.Context(), &usecase.ChangePasswordRequest{
+		UserID:          userID,
+		CurrentPassword: passwordForm.CurrentPassword,
+		NewPassword:     passwordForm.NewPassword,
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidCredentials) {
+			if loginFailed(h.app, w, r, userID) {
+				return
+			}
+			passwordForm.AddFieldError("current-password", "current password is incorrect")
+		} else {
+			errMessage, isUserFacing := translateError(err)
+			if !isUserFacing {
+				h.app.Logger.Error("failed to change password", "error", err)
+			}
+			passwordForm.AddNonFieldError(errMessage)
+		}
+		h.app.Template.Render(w, r, components.PasswordForm(passwordForm), http.StatusUnprocessableEntity)
+		return
+	}
+	loginSucceeded(h.app, r, userID)
+
+	if err := h.app.Session.RenewToken(r.Context()); err != nil {
+		h.app.Errors.ServerError(w, r, fmt.Errorf("failed to renew session token: %w", err))
+		return
+	}
+	if err := h.app.Session.DestroyOtherSessions(r.Context(), userID); err != nil {
+		h.app.Errors.ServerError(w, r, fmt.Errorf("failed to sign out other sessions: %w", err))
+		return
+	}
+
+	h.app.Notify.Toast(w, web.Success, "Password changed. Other sessions were signed out.")
+	h.app.Template.Render(w, r, components.PasswordForm(form.PasswordForm{}), http.StatusOK)
+}
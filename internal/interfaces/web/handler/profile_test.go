@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-playground/form/v4"
+	"github.com/madalinpopa/gocost-web/internal/config"
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/respond"
+	"github.com/madalinpopa/gocost-web/internal/platform/ratelimit"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestProfileHandler(session *MockSessionManager, profileUC *MockProfileUseCase, verificationUC *MockEmailVerificationUseCase) ProfileHandler {
+	cfg := &config.Config{Currency: "USD"}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	errHandler := newTestErrors(logger, new(MockErrorHandler))
+
+	return NewProfileHandler(HandlerContext{
+		Config:   cfg,
+		Decoder:  form.NewDecoder(),
+		Logger:   logger,
+		Session:  session,
+		Errors:   errHandler,
+		Htmx:     respond.NewHtmx(errHandler),
+		Notify:   respond.NewNotify(logger),
+		Template: web.NewTemplate(logger, cfg),
+	}, profileUC, verificationUC)
+}
+
+func TestProfileHandler_UpdateProfile(t *testing.T) {
+	t.Run("saves and refreshes the username in the session", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockProfileUC := new(MockProfileUseCase)
+		handler := newTestProfileHandler(mockSession, mockProfileUC, nil)
+
+		req := newFormRequest("/profile", url.Values{"email": {"alice@example.org"}, "username": {"alice_b"}})
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("SetUsername", req.Context(), "alice_b").Return()
+		mockProfileUC.On("UpdateProfile", req.Context(), mock.MatchedBy(func(r *usecase.UpdateProfileRequest) bool {
+			return r.UserID == "user-123" && r.Email == "alice@example.org" && r.Username == "alice_b"
+		})).Return(&usecase.UserResponse{ID: "user-123", Email: "alice@example.org", Username: "alice_b", Currency: "USD", EmailVerified: true}, nil)
+
+		// Act
+		handler.UpdateProfile(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `value="alice_b"`)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "Profile saved.")
+		mockSession.AssertExpectations(t)
+	})
+
+	t.Run("sends a link to verify a new email", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockProfileUC := new(MockProfileUseCase)
+		mockVerificationUC := new(MockEmailVerificationUseCase)
+		handler := newTestProfileHandler(mockSession, mockProfileUC, mockVerificationUC)
+
+		req := newFormRequest("/profile", url.Values{"email": {"alice@example.net"}, "username": {"alice"}})
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("SetUsername", req.Context(), "alice").Return()
+		mockSession.On("SetEmailVerified", req.Context(), false).Return()
+		mockProfileUC.On("UpdateProfile", req.Context(), mock.Anything).Return(&usecase.UserResponse{ID: "user-123", Email: "alice@example.net", Username: "alice", Currency: "USD"}, nil)
+		mockVerificationUC.On("SendVerification", req.Context(), mock.MatchedBy(func(r *usecase.SendVerificationRequest) bool {
+			return r.UserID == "user-123"
+		})).Return(nil)
+
+		// Act
+		handler.UpdateProfile(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "Check your inbox to verify your email.")
+		mockSession.AssertExpectations(t)
+		mockVerificationUC.AssertExpectations(t)
+	})
+
+	t.Run("shows a taken email next to the field", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockProfileUC := new(MockProfileUseCase)
+		handler := newTestProfileHandler(mockSession, mockProfileUC, nil)
+
+		req := newFormRequest("/profile", url.Values{"email": {"bob@example.com"}, "username": {"alice"}})
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockProfileUC.On("UpdateProfile", req.Context(), mock.Anything).Return(nil, identity.ErrEmailTaken)
+
+		// Act
+		handler.UpdateProfile(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "this email is already in use")
+		mockSession.AssertNotCalled(t, "SetUsername", mock.Anything, mock.Anything)
+	})
+}
+
+func TestProfileHandler_UpdateCurrency(t *testing.T) {
+	// Arrange
+	mockSession := new(MockSessionManager)
+	mockProfileUC := new(MockProfileUseCase)
+	handler := newTestProfileHandler(mockSession, mockProfileUC, nil)
+
+	req := newFormRequest("/profile/currency", url.Values{"currency": {"eur"}})
+	rec := httptest.NewRecorder()
+
+	mockSession.On("GetUserID", req.Context()).Return("user-123")
+	mockSession.On("SetCurrency", req.Context(), "EUR").Return()
+	mockProfileUC.On("ChangeCurrency", req.Context(), &usecase.ChangeCurrencyRequest{UserID: "user-123", Currency: "EUR"}).
+		Return(&usecase.UserResponse{ID: "user-123", Currency: "EUR"}, nil)
+
+	// Act
+	handler.UpdateCurrency(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `value="EUR"`)
+	mockSession.AssertExpectations(t)
+}
+
+func TestProfileHandler_ChangePassword(t *testing.T) {
+	values := url.Values{
+		"current-password": {"old-password"},
+		"new-password":     {"new-password"},
+		"confirm-password": {"new-password"},
+	}
+
+	t.Run("signs out the other sessions", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockProfileUC := new(MockProfileUseCase)
+		handler := newTestProfileHandler(mockSession, mockProfileUC, nil)
+
+		req := newFormRequest("/profile/password", values)
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("RenewToken", req.Context()).Return(nil)
+		mockSession.On("DestroyOtherSessions", req.Context(), "user-123").Return(nil)
+		mockProfileUC.On("ChangePassword", req.Context(), &usecase.ChangePasswordRequest{
+			UserID:          "user-123",
+			CurrentPassword: "old-password",
+			NewPassword:     "new-password",
+		}).Return(nil)
+
+		// Act
+		handler.ChangePassword(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "Password changed.")
+		mockSession.AssertExpectations(t)
+	})
+
+	t.Run("keeps the sessions when the current password is wrong", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockProfileUC := new(MockProfileUseCase)
+		handler := newTestProfileHandler(mockSession, mockProfileUC, nil)
+
+		req := newFormRequest("/profile/password", values)
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockProfileUC.On("ChangePassword", req.Context(), mock.Anything).Return(usecase.ErrInvalidCredentials)
+
+		// Act
+		handler.ChangePassword(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "current password is incorrect")
+		mockSession.AssertNotCalled(t, "DestroyOtherSessions", mock.Anything, mock.Anything)
+	})
+
+	t.Run("repeated wrong passwords lock the client out", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockProfileUC := new(MockProfileUseCase)
+		handler := newTestProfileHandler(mockSession, mockProfileUC, nil)
+		handler.app.Limiter = ratelimit.New(ratelimit.NewMemoryStore(), ratelimit.Lockout{Threshold: 2, Duration: time.Minute, MaxDuration: time.Hour})
+
+		submit := func() *httptest.ResponseRecorder {
+			req := newFormRequest("/profile/password", values)
+			rec := httptest.NewRecorder()
+			handler.ChangePassword(rec, req)
+			return rec
+		}
+
+		mockSession.On("GetUserID", mock.Anything).Return("user-123")
+		mockProfileUC.On("ChangePassword", mock.Anything, mock.Anything).Return(usecase.ErrInvalidCredentials)
+
+		// Act & Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, submit().Code)
+
+		rec := submit()
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+
+		assert.Equal(t, http.StatusTooManyRequests, submit().Code)
+		mockProfileUC.AssertNumberOfCalls(t, "ChangePassword", 2)
+	})
+}
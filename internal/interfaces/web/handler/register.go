@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/madalinpopa/gocost-web/internal/config"
 	"github.com/madalinpopa/gocost-web/internal/domain/identity"
 	"github.com/madalinpopa/gocost-web/internal/interfaces/web/form"
 	"github.com/madalinpopa/gocost-web/internal/usecase"
@@ -12,30 +13,50 @@ import (
 )
 
 type RegisterHandler struct {
-	app  HandlerContext
-	auth usecase.AuthUseCase
+	app          HandlerContext
+	auth         usecase.AuthUseCase
+	verification usecase.EmailVerificationUseCase
 }
 
-func NewRegisterHandler(app HandlerContext, auth usecase.AuthUseCase) RegisterHandler {
+func NewRegisterHandler(app HandlerContext, auth usecase.AuthUseCase, verification usecase.EmailVerificationUseCase) RegisterHandler {
 	return RegisterHandler{
-		app:  app,
-		auth: auth,
+		app:          app,
+		auth:         auth,
+		verification: verification,
 	}
 }
 
 func (rh RegisterHandler) ShowRegisterPage(w http.ResponseWriter, r *http.Request) {
+	if rh.closed(w, r) {
+		return
+	}
+
 	data := rh.app.Template.GetData(r)
-	page := public.RegisterPage(data)
+	page := public.RegisterPage(data, r.URL.Query().Get("invite"))
 	rh.app.Template.Render(w, r, page, http.StatusOK)
 }
 
 func (rh RegisterHandler) ShowRegisterForm(w http.ResponseWriter, r *http.Request) {
-	registerForm := form.RegisterForm{}
+	if rh.closed(w, r) {
+		return
+	}
+
+	registerForm := form.RegisterForm{
+		InviteRequired: rh.inviteRequired(),
+		Captcha:        newCaptcha(rh.app, r),
+	}
+	if registerForm.InviteRequired {
+		registerForm.InviteCode = r.URL.Query().Get("invite")
+	}
 	page := public.RegisterForm(registerForm)
 	rh.app.Template.Render(w, r, page, http.StatusOK)
 }
 
 func (rh RegisterHandler) SubmitRegisterForm(w http.ResponseWriter, r *http.Request) {
+	if rh.closed(w, r) {
+		return
+	}
+
 	if err := r.ParseForm(); err != nil {
 		rh.app.Errors.Error(w, r, http.StatusBadRequest, err)
 		return
@@ -47,8 +68,11 @@ func (rh RegisterHandler) SubmitRegisterForm(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	registerForm.InviteRequired = rh.inviteRequired()
 	registerForm.Validate()
+	checkCaptcha(rh.app, &registerForm.Base, registerForm.Captcha)
 	if !registerForm.IsValid() {
+		registerForm.Captcha = newCaptcha(rh.app, r)
 		component := public.RegisterForm(registerForm)
 		rh.app.Template.Render(w, r, component, http.StatusUnprocessableEntity)
 		return
@@ -59,6 +83,7 @@ func (rh RegisterHandler) SubmitRegisterForm(w http.ResponseWriter, r *http.Requ
 		UsernameRequest: usecase.UsernameRequest{Username: registerForm.Username},
 		Password:        registerForm.Password,
 		Currency:        rh.app.Config.Currency,
+		InviteCode:      registerForm.InviteCode,
 	}
 
 	// Register the user
@@ -66,6 +91,7 @@ func (rh RegisterHandler) SubmitRegisterForm(w http.ResponseWriter, r *http.Requ
 	if err != nil {
 		errMessage, isUserFacing := translateError(err)
 		registerForm.AddNonFieldError(errMessage)
+		registerForm.Captcha = newCaptcha(rh.app, r)
 		component := public.RegisterForm(registerForm)
 		rh.app.Template.Render(w, r, component, http.StatusUnprocessableEntity)
 		if !isUserFacing {
@@ -74,6 +100,18 @@ func (rh RegisterHandler) SubmitRegisterForm(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// Send the link to verify the email
+	if err := sendVerification(r.Context(), rh.app, rh.verification, userResponse.ID); err != nil {
+		rh.app.Logger.Error("failed to send verification email", "error", err)
+	}
+
+	// Sign in only once the email is verified, when verification is enforced
+	if rh.app.Config.EmailVerification == config.EmailVerificationBlock {
+		component := public.RegisterVerifyEmail(userResponse.Email)
+		rh.app.Template.Render(w, r, component, http.StatusOK)
+		return
+	}
+
 	// Renew session token
 	err = rh.app.Session.RenewToken(r.Context())
 	if err != nil {
@@ -89,11 +127,26 @@ func (rh RegisterHandler) SubmitRegisterForm(w http.ResponseWriter, r *http.Requ
 	rh.app.Session.SetUserID(r.Context(), userResponse.ID)
 	rh.app.Session.SetUsername(r.Context(), userResponse.Username)
 	rh.app.Session.SetCurrency(r.Context(), userResponse.Currency)
+	rh.app.Session.SetEmailVerified(r.Context(), false)
 
 	// Redirect to admin home
 	rh.app.Htmx.Redirect(w, "/home")
 }
 
+// closed responds as if there was no register page while registration is
+// closed, and reports whether it did.
+func (rh RegisterHandler) closed(w http.ResponseWriter, r *http.Request) bool {
+	if rh.app.Config.Registration != config.RegistrationClosed {
+		return false
+	}
+	http.NotFound(w, r)
+	return true
+}
+
+func (rh RegisterHandler) inviteRequired() bool {
+	return rh.app.Config.Registration == config.RegistrationInvite
+}
+
 func translateError(err error) (string, bool) {
 	switch {
 	case errors.Is(err, identity.ErrUserAlreadyExists):
@@ -102,6 +155,10 @@ func translateError(err error) (string, bool) {
 		return "Please enter a valid email address.", true
 	case errors.Is(err, identity.ErrPasswordTooShort):
 		return "Password must be at least 8 characters long.", true
+	case errors.Is(err, identity.ErrInvalidInvite):
+		return "This invite code is invalid, used up or expired.", true
+	case errors.Is(err, usecase.ErrRegistrationClosed):
+		return "Registration is closed.", true
 	default:
 		return "An unexpected error occurred. Please try again later.", false
 	}
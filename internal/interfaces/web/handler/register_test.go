@@ -16,12 +16,14 @@ import (
 	"github.com/madalinpopa/gocost-web/internal/domain/identity"
 	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
 	"github.com/madalinpopa/gocost-web/internal/interfaces/web/respond"
+	"github.com/madalinpopa/gocost-web/internal/platform/captcha"
+	"github.com/madalinpopa/gocost-web/internal/platform/security"
 	"github.com/madalinpopa/gocost-web/internal/usecase"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
-func newTestRegisterHandler(session *MockSessionManager, auth *MockAuthUseCase) RegisterHandler {
+func newTestRegisterHandler(session *MockSessionManager, auth *MockAuthUseCase, verification *MockEmailVerificationUseCase) RegisterHandler {
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	cfg := config.New()
 	cfg.Currency = "USD"
@@ -35,6 +37,9 @@ func newTestRegisterHandler(session *MockSessionManager, auth *MockAuthUseCase)
 	if auth == nil {
 		auth = new(MockAuthUseCase)
 	}
+	if verification == nil {
+		verification = new(MockEmailVerificationUseCase)
+	}
 
 	appCtx := HandlerContext{
 		Config:   cfg,
@@ -47,12 +52,12 @@ func newTestRegisterHandler(session *MockSessionManager, auth *MockAuthUseCase)
 		Notify:   respond.NewNotify(logger),
 	}
 
-	return NewRegisterHandler(appCtx, auth)
+	return NewRegisterHandler(appCtx, auth, verification)
 }
 
 func TestRegisterHandler_ShowRegisterPage(t *testing.T) {
 	t.Run("renders the register page", func(t *testing.T) {
-		handler := newTestRegisterHandler(nil, nil)
+		handler := newTestRegisterHandler(nil, nil, nil)
 		req := httptest.NewRequest(http.MethodGet, "/register", nil)
 		rec := httptest.NewRecorder()
 
@@ -68,11 +73,35 @@ func TestRegisterHandler_ShowRegisterPage(t *testing.T) {
 		assert.Contains(t, body, "CREATE YOUR ACCOUNT")
 		assert.Contains(t, body, strconv.Itoa(time.Now().Year()))
 	})
+
+	t.Run("passes the invite code of the link to the form", func(t *testing.T) {
+		handler := newTestRegisterHandler(nil, nil, nil)
+		handler.app.Config.Registration = config.RegistrationInvite
+		req := httptest.NewRequest(http.MethodGet, "/register?invite=abcde-fghij-klmno", nil)
+		rec := httptest.NewRecorder()
+
+		handler.ShowRegisterPage(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `hx-get="/register/form?invite=abcde-fghij-klmno"`)
+	})
+
+	t.Run("is not found while registration is closed", func(t *testing.T) {
+		handler := newTestRegisterHandler(nil, nil, nil)
+		handler.app.Config.Registration = config.RegistrationClosed
+		req := httptest.NewRequest(http.MethodGet, "/register", nil)
+		rec := httptest.NewRecorder()
+
+		handler.ShowRegisterPage(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "CREATE YOUR ACCOUNT")
+	})
 }
 
 func TestRegisterHandler_ShowRegisterForm(t *testing.T) {
 	t.Run("renders the register form", func(t *testing.T) {
-		handler := newTestRegisterHandler(nil, nil)
+		handler := newTestRegisterHandler(nil, nil, nil)
 		req := httptest.NewRequest(http.MethodGet, "/register/form", nil)
 		rec := httptest.NewRecorder()
 
@@ -87,6 +116,21 @@ func TestRegisterHandler_ShowRegisterForm(t *testing.T) {
 		assert.Contains(t, body, "name=\"username\"")
 		assert.Contains(t, body, "name=\"password\"")
 		assert.Contains(t, body, "CREATE ACCOUNT")
+		assert.NotContains(t, body, "name=\"invite\"")
+	})
+
+	t.Run("asks for the invite code while registration is by invitation", func(t *testing.T) {
+		handler := newTestRegisterHandler(nil, nil, nil)
+		handler.app.Config.Registration = config.RegistrationInvite
+		req := httptest.NewRequest(http.MethodGet, "/register/form?invite=abcde-fghij-klmno", nil)
+		rec := httptest.NewRecorder()
+
+		handler.ShowRegisterForm(rec, req)
+
+		body := rec.Body.String()
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, body, "name=\"invite\"")
+		assert.Contains(t, body, "value=\"abcde-fghij-klmno\"")
 	})
 }
 
@@ -94,7 +138,8 @@ func TestRegisterHandler_SubmitRegisterForm(t *testing.T) {
 	t.Run("successful registration redirects to home", func(t *testing.T) {
 		session := new(MockSessionManager)
 		auth := new(MockAuthUseCase)
-		handler := newTestRegisterHandler(session, auth)
+		verification := new(MockEmailVerificationUseCase)
+		handler := newTestRegisterHandler(session, auth, verification)
 
 		formData := url.Values{}
 		formData.Set("email", "test@example.com")
@@ -119,6 +164,11 @@ func TestRegisterHandler_SubmitRegisterForm(t *testing.T) {
 		session.On("SetUserID", req.Context(), "user-1").Return()
 		session.On("SetUsername", req.Context(), "testuser").Return()
 		session.On("SetCurrency", req.Context(), "USD").Return()
+		session.On("SetEmailVerified", req.Context(), false).Return()
+		verification.On("SendVerification", req.Context(), &usecase.SendVerificationRequest{
+			UserID:    "user-1",
+			VerifyURL: "/verify-email",
+		}).Return(nil)
 
 		handler.SubmitRegisterForm(rec, req)
 
@@ -130,12 +180,44 @@ func TestRegisterHandler_SubmitRegisterForm(t *testing.T) {
 
 		session.AssertExpectations(t)
 		auth.AssertExpectations(t)
+		verification.AssertExpectations(t)
+	})
+
+	t.Run("asks to verify the email before logging in when verification is enforced", func(t *testing.T) {
+		session := new(MockSessionManager)
+		auth := new(MockAuthUseCase)
+		verification := new(MockEmailVerificationUseCase)
+		handler := newTestRegisterHandler(session, auth, verification)
+		handler.app.Config.EmailVerification = config.EmailVerificationBlock
+
+		formData := url.Values{}
+		formData.Set("email", "test@example.com")
+		formData.Set("username", "testuser")
+		formData.Set("password", "password123")
+
+		req := httptest.NewRequest(http.MethodPost, "/register/form", strings.NewReader(formData.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		auth.On("Register", req.Context(), mock.Anything).Return(&usecase.UserResponse{
+			ID: "user-1", Email: "test@example.com", Username: "testuser", Currency: "USD",
+		}, nil)
+		verification.On("SendVerification", req.Context(), mock.Anything).Return(nil)
+
+		handler.SubmitRegisterForm(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Check your inbox")
+		assert.Contains(t, rec.Body.String(), "test@example.com")
+		assert.Empty(t, rec.Header().Get("HX-Redirect"))
+		session.AssertNotCalled(t, "SetUserID", mock.Anything, mock.Anything)
+		verification.AssertExpectations(t)
 	})
 
 	t.Run("validation error re-renders form", func(t *testing.T) {
 		session := new(MockSessionManager)
 		auth := new(MockAuthUseCase)
-		handler := newTestRegisterHandler(session, auth)
+		handler := newTestRegisterHandler(session, auth, nil)
 
 		formData := url.Values{}
 		formData.Set("email", "invalid-email")
@@ -163,7 +245,7 @@ func TestRegisterHandler_SubmitRegisterForm(t *testing.T) {
 	t.Run("registration error (user exists) re-renders form with error", func(t *testing.T) {
 		session := new(MockSessionManager)
 		auth := new(MockAuthUseCase)
-		handler := newTestRegisterHandler(session, auth)
+		handler := newTestRegisterHandler(session, auth, nil)
 
 		formData := url.Values{}
 		formData.Set("email", "exists@example.com")
@@ -188,4 +270,145 @@ func TestRegisterHandler_SubmitRegisterForm(t *testing.T) {
 		auth.AssertExpectations(t)
 		session.AssertNotCalled(t, "RenewToken", mock.Anything)
 	})
+	t.Run("unsolved captcha re-renders form with a new challenge", func(t *testing.T) {
+		auth := new(MockAuthUseCase)
+		handler := newTestRegisterHandler(nil, auth, nil)
+		handler.app.Captcha = captcha.New(security.NewSigner([]byte("test-key")), 16)
+
+		challenge, err := handler.app.Captcha.Issue()
+		assert.NoError(t, err)
+
+		formData := url.Values{}
+		formData.Set("email", "test@example.com")
+		formData.Set("username", "testuser")
+		formData.Set("password", "password123")
+		formData.Set("captcha-challenge", challenge.Token)
+
+		req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(formData.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		handler.SubmitRegisterForm(rec, req)
+
+		body := rec.Body.String()
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, body, "The browser check did not pass or has expired.")
+		assert.Contains(t, body, "data-captcha-difficulty=\"16\"")
+		assert.NotContains(t, body, challenge.Token)
+		auth.AssertNotCalled(t, "Register", mock.Anything, mock.Anything)
+	})
+
+	t.Run("solved captcha is accepted", func(t *testing.T) {
+		session := new(MockSessionManager)
+		auth := new(MockAuthUseCase)
+		verification := new(MockEmailVerificationUseCase)
+		handler := newTestRegisterHandler(session, auth, verification)
+		handler.app.Captcha = captcha.New(security.NewSigner([]byte("test-key")), 4)
+
+		challenge, err := handler.app.Captcha.Issue()
+		assert.NoError(t, err)
+		nonce := 0
+		for captcha.LeadingZeroBits(challenge.Token, strconv.Itoa(nonce)) < challenge.Difficulty {
+			nonce++
+		}
+
+		formData := url.Values{}
+		formData.Set("email", "test@example.com")
+		formData.Set("username", "testuser")
+		formData.Set("password", "password123")
+		formData.Set("captcha-challenge", challenge.Token)
+		formData.Set("captcha-nonce", strconv.Itoa(nonce))
+
+		req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(formData.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		auth.On("Register", req.Context(), mock.Anything).Return(&usecase.UserResponse{
+			ID: "user-1", Username: "testuser", Currency: "USD",
+		}, nil)
+		verification.On("SendVerification", req.Context(), mock.Anything).Return(nil)
+		session.On("RenewToken", req.Context()).Return(nil)
+		session.On("SetUserID", req.Context(), "user-1").Return()
+		session.On("SetUsername", req.Context(), "testuser").Return()
+		session.On("SetCurrency", req.Context(), "USD").Return()
+		session.On("SetEmailVerified", req.Context(), false).Return()
+
+		handler.SubmitRegisterForm(rec, req)
+
+		assert.Equal(t, http.StatusFound, rec.Code)
+		assert.Equal(t, "/home", rec.Header().Get("HX-Redirect"))
+		auth.AssertExpectations(t)
+	})
+
+	t.Run("is not found while registration is closed", func(t *testing.T) {
+		auth := new(MockAuthUseCase)
+		handler := newTestRegisterHandler(nil, auth, nil)
+		handler.app.Config.Registration = config.RegistrationClosed
+
+		formData := url.Values{}
+		formData.Set("email", "test@example.com")
+		formData.Set("username", "testuser")
+		formData.Set("password", "password123")
+
+		req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(formData.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		handler.SubmitRegisterForm(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		auth.AssertNotCalled(t, "Register", mock.Anything, mock.Anything)
+	})
+
+	t.Run("requires the invite code while registration is by invitation", func(t *testing.T) {
+		auth := new(MockAuthUseCase)
+		handler := newTestRegisterHandler(nil, auth, nil)
+		handler.app.Config.Registration = config.RegistrationInvite
+
+		formData := url.Values{}
+		formData.Set("email", "test@example.com")
+		formData.Set("username", "testuser")
+		formData.Set("password", "password123")
+
+		req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(formData.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		handler.SubmitRegisterForm(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "name=\"invite\"")
+		assert.Contains(t, rec.Body.String(), "this field is required")
+		auth.AssertNotCalled(t, "Register", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an invalid invite code", func(t *testing.T) {
+		auth := new(MockAuthUseCase)
+		handler := newTestRegisterHandler(nil, auth, nil)
+		handler.app.Config.Registration = config.RegistrationInvite
+
+		formData := url.Values{}
+		formData.Set("email", "test@example.com")
+		formData.Set("username", "testuser")
+		formData.Set("password", "password123")
+		formData.Set("invite", "abcde-fghij-klmno")
+
+		req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(formData.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		auth.On("Register", req.Context(), &usecase.RegisterUserRequest{
+			EmailRequest:    usecase.EmailRequest{Email: "test@example.com"},
+			UsernameRequest: usecase.UsernameRequest{Username: "testuser"},
+			Password:        "password123",
+			Currency:        "USD",
+			InviteCode:      "abcde-fghij-klmno",
+		}).Return(nil, identity.ErrInvalidInvite)
+
+		handler.SubmitRegisterForm(rec, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "This invite code is invalid, used up or expired.")
+		auth.AssertExpectations(t)
+	})
 }
@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/views"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/madalinpopa/gocost-web/ui/templates/components"
+)
+
+// sessionsPath is where on the settings page the sessions are listed, linked
+// to from new device alerts.
+const sessionsPath = "/profile#sessions"
+
+type SessionHandler struct {
+	app      HandlerContext
+	sessions usecase.SessionUseCase
+}
+
+func NewSessionHandler(app HandlerContext, sessions usecase.SessionUseCase) SessionHandler {
+	return SessionHandler{
+		app:      app,
+		sessions: sessions,
+	}
+}
+
+// ShowSettings renders the sessions section of the profile page.
+func (h *SessionHandler) ShowSettings(w http.ResponseWriter, r *http.Request) {
+	h.render(w, r, nil, http.StatusOK)
+}
+
+// Revoke signs out one of the other sessions of the user.
+func (h *SessionHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == h.app.Session.GetSessionID(r.Context()) {
+		h.render(w, r, []string{"Use log out to end the session on this device."}, http.StatusUnprocessableEntity)
+		return
+	}
+
+	err := h.app.Session.DestroySession(r.Context(), h.app.Session.GetUserID(r.Context()), id)
+	if err != nil {
+		if errors.Is(err, web.ErrSessionNotFound) {
+			h.render(w, r, []string{"This session has already ended."}, http.StatusUnprocessableEntity)
+			return
+		}
+		h.app.Errors.ServerError(w, r, fmt.Errorf("failed to sign out session: %w", err))
+		return
+	}
+
+	h.app.Notify.Toast(w, web.Success, "Session signed out.")
+	h.render(w, r, nil, http.StatusOK)
+}
+
+// RevokeOthers signs the user out everywhere but on this device.
+func (h *SessionHandler) RevokeOthers(w http.ResponseWriter, r *http.Request) {
+	if err := h.app.Session.DestroyOtherSessions(r.Context(), h.app.Session.GetUserID(r.Context())); err != nil {
+		h.app.Errors.ServerError(w, r, fmt.Errorf("failed to sign out other sessions: %w", err))
+		return
+	}
+
+	h.app.Notify.Toast(w, web.Success, "Other sessions were signed out.")
+	h.render(w, r, nil, http.StatusOK)
+}
+
+// render shows the active sessions of the user. The session store knows
+// which sessions are active; the records say where they are from.
+func (h *SessionHandler) render(w http.ResponseWriter, r *http.Request, errs []string, status int) {
+	userID := h.app.Session.GetUserID(r.Context())
+	activeIDs, err := h.app.Session.ActiveSessionIDs(r.Context(), userID)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, fmt.Errorf("failed to list active sessions: %w", err))
+		return
+	}
+
+	sessions, err := h.sessions.List(r.Context(), userID, activeIDs)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	page := components.SessionSettings(views.NewSessionViews(sessions, h.app.Session.GetSessionID(r.Context())), errs)
+	h.app.Template.Render(w, r, page, status)
+}
+
+// SessionTracker records the sessions of users through the session use case,
+// emailing logins from new devices when the configuration asks to.
+type SessionTracker struct {
+	app      HandlerContext
+	sessions usecase.SessionUseCase
+}
+
+func NewSessionTracker(app HandlerContext, sessions usecase.SessionUseCase) SessionTracker {
+	return SessionTracker{
+		app:      app,
+		sessions: sessions,
+	}
+}
+
+func (t SessionTracker) Start(ctx context.Context, userID, ipAddress, userAgent string) (string, error) {
+	resp, err := t.sessions.Start(ctx, &usecase.StartSessionRequest{
+		UserID:      userID,
+		IPAddress:   ipAddress,
+		UserAgent:   userAgent,
+		Alert:       t.app.Config.Session.NewDeviceAlerts,
+		SessionsURL: t.app.Config.BaseURL + sessionsPath,
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+func (t SessionTracker) Touch(ctx context.Context, userID, sessionID, ipAddress string) error {
+	return t.sessions.Touch(ctx, &usecase.TouchSessionRequest{
+		UserID:    userID,
+		SessionID: sessionID,
+		IPAddress: ipAddress,
+	})
+}
+
+var _ web.SessionTracker = SessionTracker{}
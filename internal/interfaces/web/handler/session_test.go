@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-playground/form/v4"
+	"github.com/madalinpopa/gocost-web/internal/config"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/respond"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestSessionHandler(session *MockSessionManager, sessionUC *MockSessionUseCase) SessionHandler {
+	cfg := &config.Config{Currency: "USD", BaseURL: "https://gocost.example.com"}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	errHandler := newTestErrors(logger, nil)
+
+	return NewSessionHandler(HandlerContext{
+		Config:   cfg,
+		Decoder:  form.NewDecoder(),
+		Logger:   logger,
+		Session:  session,
+		Errors:   errHandler,
+		Htmx:     respond.NewHtmx(errHandler),
+		Notify:   respond.NewNotify(logger),
+		Template: web.NewTemplate(logger, cfg),
+	}, sessionUC)
+}
+
+var testSessions = []usecase.UserSessionResponse{
+	{ID: "session-1", Device: "Firefox on Windows", IPAddress: "203.0.113.7", CreatedAt: time.Now(), LastSeenAt: time.Now()},
+	{ID: "session-2", Device: "Safari on iOS", IPAddress: "198.51.100.2", CreatedAt: time.Now(), LastSeenAt: time.Now()},
+}
+
+func TestSessionHandler_ShowSettings(t *testing.T) {
+	// Arrange
+	mockSession := new(MockSessionManager)
+	mockSessionUC := new(MockSessionUseCase)
+	handler := newTestSessionHandler(mockSession, mockSessionUC)
+
+	req := httptest.NewRequest(http.MethodGet, "/profile/sessions", nil)
+	rec := httptest.NewRecorder()
+
+	mockSession.On("GetUserID", req.Context()).Return("user-123")
+	mockSession.On("GetSessionID", req.Context()).Return("session-1")
+	mockSession.On("ActiveSessionIDs", req.Context(), "user-123").Return([]string{"session-1", "session-2"}, nil)
+	mockSessionUC.On("List", req.Context(), "user-123", []string{"session-1", "session-2"}).Return(testSessions, nil)
+
+	// Act
+	handler.ShowSettings(rec, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, "Firefox on Windows")
+	assert.Contains(t, body, "This device")
+	assert.Contains(t, body, "/profile/sessions/session-2")
+	assert.NotContains(t, body, "/profile/sessions/session-1")
+	assert.Contains(t, body, "Sign out everywhere else")
+}
+
+func TestSessionHandler_Revoke(t *testing.T) {
+	newRequest := func(id string) *http.Request {
+		req := httptest.NewRequest(http.MethodDelete, "/profile/sessions/"+id, nil)
+		req.SetPathValue("id", id)
+		return req
+	}
+
+	t.Run("signs out the session", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockSessionUC := new(MockSessionUseCase)
+		handler := newTestSessionHandler(mockSession, mockSessionUC)
+		req := newRequest("session-2")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetSessionID", req.Context()).Return("session-1")
+		mockSession.On("DestroySession", req.Context(), "user-123", "session-2").Return(nil)
+		mockSession.On("ActiveSessionIDs", req.Context(), "user-123").Return([]string{"session-1"}, nil)
+		mockSessionUC.On("List", req.Context(), "user-123", []string{"session-1"}).Return(testSessions[:1], nil)
+
+		// Act
+		handler.Revoke(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "Session signed out.")
+		mockSession.AssertExpectations(t)
+	})
+
+	t.Run("session already ended", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockSessionUC := new(MockSessionUseCase)
+		handler := newTestSessionHandler(mockSession, mockSessionUC)
+		req := newRequest("session-2")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetSessionID", req.Context()).Return("session-1")
+		mockSession.On("DestroySession", req.Context(), "user-123", "session-2").Return(web.ErrSessionNotFound)
+		mockSession.On("ActiveSessionIDs", req.Context(), "user-123").Return([]string{"session-1"}, nil)
+		mockSessionUC.On("List", req.Context(), "user-123", []string{"session-1"}).Return(testSessions[:1], nil)
+
+		// Act
+		handler.Revoke(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "This session has already ended.")
+	})
+
+	t.Run("does not sign out the current session", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockSessionUC := new(MockSessionUseCase)
+		handler := newTestSessionHandler(mockSession, mockSessionUC)
+		req := newRequest("session-1")
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetSessionID", req.Context()).Return("session-1")
+		mockSession.On("ActiveSessionIDs", req.Context(), "user-123").Return([]string{"session-1"}, nil)
+		mockSessionUC.On("List", req.Context(), "user-123", []string{"session-1"}).Return(testSessions[:1], nil)
+
+		// Act
+		handler.Revoke(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		mockSession.AssertNotCalled(t, "DestroySession", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSessionHandler_RevokeOthers(t *testing.T) {
+	t.Run("signs out every other session", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockSessionUC := new(MockSessionUseCase)
+		handler := newTestSessionHandler(mockSession, mockSessionUC)
+		req := httptest.NewRequest(http.MethodPost, "/profile/sessions/sign-out-others", nil)
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("GetSessionID", req.Context()).Return("session-1")
+		mockSession.On("DestroyOtherSessions", req.Context(), "user-123").Return(nil)
+		mockSession.On("ActiveSessionIDs", req.Context(), "user-123").Return([]string{"session-1"}, nil)
+		mockSessionUC.On("List", req.Context(), "user-123", []string{"session-1"}).Return(testSessions[:1], nil)
+
+		// Act
+		handler.RevokeOthers(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "Other sessions were signed out.")
+		assert.NotContains(t, rec.Body.String(), "Sign out everywhere else")
+	})
+
+	t.Run("store failure", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		handler := newTestSessionHandler(mockSession, new(MockSessionUseCase))
+		req := httptest.NewRequest(http.MethodPost, "/profile/sessions/sign-out-others", nil)
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockSession.On("DestroyOtherSessions", req.Context(), "user-123").Return(errors.New("database is locked"))
+
+		// Act
+		handler.RevokeOthers(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestSessionTracker_Start(t *testing.T) {
+	// Arrange
+	mockSessionUC := new(MockSessionUseCase)
+	tracker := NewSessionTracker(HandlerContext{
+		Config: &config.Config{
+			BaseURL: "https://gocost.example.com",
+			Session: config.SessionConfig{NewDeviceAlerts: true},
+		},
+	}, mockSessionUC)
+	ctx := httptest.NewRequest(http.MethodGet, "/home", nil).Context()
+
+	mockSessionUC.On("Start", ctx, &usecase.StartSessionRequest{
+		UserID:      "user-123",
+		IPAddress:   "203.0.113.7",
+		UserAgent:   "Firefox/128.0",
+		Alert:       true,
+		SessionsURL: "https://gocost.example.com/profile#sessions",
+	}).Return(&usecase.UserSessionResponse{ID: "session-1"}, nil)
+
+	// Act
+	id, err := tracker.Start(ctx, "user-123", "203.0.113.7", "Firefox/128.0")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "session-1", id)
+}
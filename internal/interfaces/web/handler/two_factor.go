@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/form"
+	"github.com/madalinpopa/gocost-web/internal/platform/qrcode"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/madalinpopa/gocost-web/ui/templates/components"
+)
+
+type TwoFactorHandler struct {
+	app       HandlerContext
+	twoFactor usecase.TwoFactorUseCase
+}
+
+func NewTwoFactorHandler(app HandlerContext, twoFactor usecase.TwoFactorUseCase) TwoFactorHandler {
+	return TwoFactorHandler{
+		app:       app,
+		twoFactor: twoFactor,
+	}
+}
+
+// ShowSettings renders the two-factor section of the profile page.
+func (h *TwoFactorHandler) ShowSettings(w http.ResponseWriter, r *http.Request) {
+	status, err := h.twoFactor.Status(r.Context(), h.app.Session.GetUserID(r.Context()))
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	settings := components.TwoFactorSettings(status.Enabled, status.RecoveryCodesLeft, form.DisableTwoFactorForm{})
+	h.app.Template.Render(w, r, settings, http.StatusOK)
+}
+
+// BeginSetup makes a new secret and shows it as a QR code.
+func (h *TwoFactorHandler) BeginSetup(w http.ResponseWriter, r *http.Request) {
+	setup, err := h.twoFactor.BeginSetup(r.Context(), h.app.Session.GetUserID(r.Context()))
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	svg, err := qrcode.SVG(setup.URI)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+
+	h.app.Template.Render(w, r, components.TwoFactorSetup(svg, groupSecret(setup.Secret)), http.StatusOK)
+}
+
+// ConfirmSetup turns two-factor authentication on with the first code from
+// the authenticator app, and shows the recovery codes in place of the section.
+func (h *TwoFactorHandler) ConfirmSetup(w http.ResponseWriter, r *http.Request) {
+	var confirmForm form.TwoFactorForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &confirmForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !confirmForm.IsValid() {
+		h.app.Template.Render(w, r, components.TwoFactorConfirmForm(confirmForm), http.StatusUnprocessableEntity)
+		return
+	}
+
+	codes, err := h.twoFactor.ConfirmSetup(r.Context(), &usecase.ConfirmTwoFactorRequest{
+		UserID: h.app.Session.GetUserID(r.Context()),
+		Code:   confirmForm.Code,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, identity.ErrInvalidTwoFactorCode):
+			confirmForm.AddFieldError("code", "this code is not valid")
+		case errors.Is(err, identity.ErrTwoFactorNotFound), errors.Is(err, identity.ErrTwoFactorAlreadyEnabled):
+			confirmForm.AddNonFieldError("This setup is no longer valid. Reload the page and start again.")
+		default:
+			h.app.Logger.Error("failed to confirm two-factor setup", "error", err)
+			confirmForm.AddNonFieldError("An unexpected error occurred. Please try again later.")
+		}
+		h.app.Template.Render(w, r, components.TwoFactorConfirmForm(confirmForm), http.StatusUnprocessableEntity)
+		return
+	}
+
+	w.Header().Set("HX-Retarget", "#two-factor")
+	w.Header().Set("HX-Reswap", "outerHTML")
+	h.app.Notify.Toast(w, web.Success, "Two-factor authentication turned on.")
+	h.app.Template.Render(w, r, components.TwoFactorRecoveryCodes(codes), http.StatusOK)
+}
+
+// Disable turns two-factor authentication off once the password is checked.
+func (h *TwoFactorHandler) Disable(w http.ResponseWriter, r *http.Request) {
+	var disableForm form.DisableTwoFactorForm
+	if err := form.ParseAndValidateForm(r, h.app.Decoder, &disableForm); err != nil {
+		h.app.Errors.Error(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := h.app.Session.GetUserID(r.Context())
+	if !disableForm.IsValid() {
+		h.renderEnabled(w, r, userID, disableForm)
+		return
+	}
+
+	if lockedOut(h.app, w, r, userID) {
+		return
+	}
+
+	err := h.twoFactor.Disable(r.Context(), &usecase.DisableTwoFactorRequest{
+		UserID:   userID,
+		Password: disableForm.Password,
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidCredentials) {
+			if loginFailed(h.app, w, r, userID) {
+				return
+			}
+			disableForm.AddFieldError("password", "password is incorrect")
+		} else {
+			h.app.Logger.Error("failed to disable two-factor authentication", "error", err)
+			disableForm.AddNonFieldError("An unexpected error occurred. Please try again later.")
+		}
+		h.renderEnabled(w, r, userID, disableForm)
+		return
+	}
+	loginSucceeded(h.app, r, userID)
+
+	h.app.Notify.Toast(w, web.Success, "Two-factor authentication turned off.")
+	h.app.Template.Render(w, r, components.TwoFactorSettings(false, 0, form.DisableTwoFactorForm{}), http.StatusOK)
+}
+
+// renderEnabled shows the form that turns two-factor authentication off again,
+// with its errors.
+func (h *TwoFactorHandler) renderEnabled(w http.ResponseWriter, r *http.Request, userID string, f form.DisableTwoFactorForm) {
+	status, err := h.twoFactor.Status(r.Context(), userID)
+	if err != nil {
+		h.app.Errors.ServerError(w, r, err)
+		return
+	}
+	h.app.Template.Render(w, r, components.TwoFactorSettings(status.Enabled, status.RecoveryCodesLeft, f), http.StatusUnprocessableEntity)
+}
+
+// groupSecret splits the secret in groups of four, which is easier to type.
+func groupSecret(secret string) string {
+	var grouped []byte
+	for i := 0; i < len(secret); i++ {
+		if i > 0 && i%4 == 0 {
+			grouped = append(grouped, ' ')
+		}
+		grouped = append(grouped, secret[i])
+	}
+	return string(grouped)
+}
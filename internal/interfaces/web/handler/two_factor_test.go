@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-playground/form/v4"
+	"github.com/madalinpopa/gocost-web/internal/config"
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web"
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/respond"
+	"github.com/madalinpopa/gocost-web/internal/platform/ratelimit"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestTwoFactorHandler(session *MockSessionManager, twoFactorUC *MockTwoFactorUseCase) TwoFactorHandler {
+	cfg := &config.Config{Currency: "USD"}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	errHandler := newTestErrors(logger, new(MockErrorHandler))
+
+	return NewTwoFactorHandler(HandlerContext{
+		Config:   cfg,
+		Decoder:  form.NewDecoder(),
+		Logger:   logger,
+		Session:  session,
+		Errors:   errHandler,
+		Htmx:     respond.NewHtmx(errHandler),
+		Notify:   respond.NewNotify(logger),
+		Template: web.NewTemplate(logger, cfg),
+	}, twoFactorUC)
+}
+
+func TestTwoFactorHandler_BeginSetup(t *testing.T) {
+	t.Run("shows the secret as a QR code and text", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockTwoFactorUC := new(MockTwoFactorUseCase)
+		handler := newTestTwoFactorHandler(mockSession, mockTwoFactorUC)
+
+		req := httptest.NewRequest(http.MethodPost, "/profile/two-factor/setup", nil)
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockTwoFactorUC.On("BeginSetup", req.Context(), "user-123").Return(&usecase.TwoFactorSetupResponse{
+			Secret: "JBSWY3DPEHPK3PXP",
+			URI:    "otpauth://totp/GoCost:alice@example.com?secret=JBSWY3DPEHPK3PXP&issuer=GoCost",
+		}, nil)
+
+		// Act
+		handler.BeginSetup(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "<svg")
+		assert.Contains(t, rec.Body.String(), "JBSW Y3DP EHPK 3PXP")
+	})
+}
+
+func TestTwoFactorHandler_ConfirmSetup(t *testing.T) {
+	values := url.Values{"code": {"123456"}}
+
+	t.Run("shows the recovery codes in place of the section", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockTwoFactorUC := new(MockTwoFactorUseCase)
+		handler := newTestTwoFactorHandler(mockSession, mockTwoFactorUC)
+
+		req := newFormRequest("/profile/two-factor/confirm", values)
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockTwoFactorUC.On("ConfirmSetup", req.Context(), &usecase.ConfirmTwoFactorRequest{
+			UserID: "user-123",
+			Code:   "123456",
+		}).Return([]string{"abcde-fghij", "klmno-pqrst"}, nil)
+
+		// Act
+		handler.ConfirmSetup(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "#two-factor", rec.Header().Get("HX-Retarget"))
+		assert.Contains(t, rec.Body.String(), "abcde-fghij")
+		assert.Contains(t, rec.Body.String(), "klmno-pqrst")
+	})
+
+	t.Run("keeps the setup when the code is wrong", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockTwoFactorUC := new(MockTwoFactorUseCase)
+		handler := newTestTwoFactorHandler(mockSession, mockTwoFactorUC)
+
+		req := newFormRequest("/profile/two-factor/confirm", values)
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockTwoFactorUC.On("ConfirmSetup", req.Context(), mock.Anything).Return(nil, identity.ErrInvalidTwoFactorCode)
+
+		// Act
+		handler.ConfirmSetup(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Empty(t, rec.Header().Get("HX-Retarget"))
+		assert.Contains(t, rec.Body.String(), "this code is not valid")
+	})
+}
+
+func TestTwoFactorHandler_Disable(t *testing.T) {
+	values := url.Values{"password": {"secret-password"}}
+
+	t.Run("turns two-factor authentication off", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockTwoFactorUC := new(MockTwoFactorUseCase)
+		handler := newTestTwoFactorHandler(mockSession, mockTwoFactorUC)
+
+		req := newFormRequest("/profile/two-factor/disable", values)
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockTwoFactorUC.On("Disable", req.Context(), &usecase.DisableTwoFactorRequest{
+			UserID:   "user-123",
+			Password: "secret-password",
+		}).Return(nil)
+
+		// Act
+		handler.Disable(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get("HX-Trigger"), "turned off")
+		assert.Contains(t, rec.Body.String(), "Turn on")
+	})
+
+	t.Run("keeps it on when the password is wrong", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockTwoFactorUC := new(MockTwoFactorUseCase)
+		handler := newTestTwoFactorHandler(mockSession, mockTwoFactorUC)
+
+		req := newFormRequest("/profile/two-factor/disable", values)
+		rec := httptest.NewRecorder()
+
+		mockSession.On("GetUserID", req.Context()).Return("user-123")
+		mockTwoFactorUC.On("Disable", req.Context(), mock.Anything).Return(usecase.ErrInvalidCredentials)
+		mockTwoFactorUC.On("Status", req.Context(), "user-123").Return(&usecase.TwoFactorStatusResponse{
+			Enabled:           true,
+			RecoveryCodesLeft: 10,
+		}, nil)
+
+		// Act
+		handler.Disable(rec, req)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "password is incorrect")
+		assert.Contains(t, rec.Body.String(), "Turn off")
+	})
+
+	t.Run("repeated wrong passwords lock the client out", func(t *testing.T) {
+		// Arrange
+		mockSession := new(MockSessionManager)
+		mockTwoFactorUC := new(MockTwoFactorUseCase)
+		handler := newTestTwoFactorHandler(mockSession, mockTwoFactorUC)
+		handler.app.Limiter = ratelimit.New(ratelimit.NewMemoryStore(), ratelimit.Lockout{Threshold: 2, Duration: time.Minute, MaxDuration: time.Hour})
+
+		submit := func() *httptest.ResponseRecorder {
+			req := newFormRequest("/profile/two-factor/disable", values)
+			rec := httptest.NewRecorder()
+			handler.Disable(rec, req)
+			return rec
+		}
+
+		mockSession.On("GetUserID", mock.Anything).Return("user-123")
+		mockTwoFactorUC.On("Disable", mock.Anything, mock.Anything).Return(usecase.ErrInvalidCredentials)
+		mockTwoFactorUC.On("Status", mock.Anything, "user-123").Return(&usecase.TwoFactorStatusResponse{
+			Enabled:           true,
+			RecoveryCodesLeft: 10,
+		}, nil)
+
+		// Act & Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, submit().Code)
+
+		rec := submit()
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+
+		assert.Equal(t, http.StatusTooManyRequests, submit().Code)
+		mockTwoFactorUC.AssertNumberOfCalls(t, "Disable", 2)
+	})
+}
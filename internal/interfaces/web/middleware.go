@@ -6,15 +6,35 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"net/url"
 	"runtime/debug"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/justinas/nosurf"
 	"github.com/madalinpopa/gocost-web/internal/config"
 	"github.com/madalinpopa/gocost-web/internal/interfaces/web/respond"
+	"github.com/madalinpopa/gocost-web/internal/platform/ratelimit"
 )
 
+// ReauthPath is the page that asks for the password again before sensitive
+// actions.
+const ReauthPath = "/confirm-password"
+
+// sessionTouchInterval is how often the use of a session is recorded, rather
+// than on every request.
+const sessionTouchInterval = time.Minute
+
+// SessionTracker keeps the records of sessions users see in the settings:
+// where each was started from and when it was last used.
+type SessionTracker interface {
+	// Start records a new session of the user and returns its ID.
+	Start(ctx context.Context, userID, ipAddress, userAgent string) (string, error)
+	// Touch records a request of the session.
+	Touch(ctx context.Context, userID, sessionID, ipAddress string) error
+}
+
 // responseWriter is a wrapper around responseWriter that captures the status code of the response.
 type responseWriter struct {
 	http.ResponseWriter
@@ -37,6 +57,8 @@ type Middleware struct {
 	config  *config.Config
 	session AuthSessionManager
 	errors  respond.ErrorHandler
+	tracker SessionTracker
+	limiter *ratelimit.Limiter
 
 	trustedProxyParseOnce sync.Once
 	trustedProxyCIDRs     []*net.IPNet
@@ -64,6 +86,18 @@ func NewMiddleware(l *slog.Logger, c *config.Config, s AuthSessionManager, error
 	return m
 }
 
+// WithSessionTracker records the sessions of users with t.
+func (m *Middleware) WithSessionTracker(t SessionTracker) *Middleware {
+	m.tracker = t
+	return m
+}
+
+// WithRateLimiter limits the requests to rate limited routes with l.
+func (m *Middleware) WithRateLimiter(l *ratelimit.Limiter) *Middleware {
+	m.limiter = l
+	return m
+}
+
 // Headers sets HTTP security headers to enhance security and forwards the request to the next handler.
 func (m *Middleware) Headers(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -338,10 +372,15 @@ func (m *Middleware) Authenticate(next http.Handler) http.Handler {
 			return
 		}
 
+		// Push back the end of the session, which is not idle.
+		m.session.KeepAlive(r.Context())
+
 		// Create a user object from the session data.
 		user := AuthenticatedUser{
 			ID:       userID,
 			Username: m.session.GetUsername(r.Context()),
+
+			EmailVerified: m.session.IsEmailVerified(r.Context()),
 		}
 
 		// Add the authentication status and user info to the request context.
@@ -354,6 +393,39 @@ func (m *Middleware) Authenticate(next http.Handler) http.Handler {
 	})
 }
 
+// TrackSession records the session of a logged-in user the first time it is
+// used, whichever way the user logged in, and then when it was last used at
+// most every sessionTouchInterval. A session that cannot be recorded is not
+// kept from working.
+func (m *Middleware) TrackSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		userID := m.session.GetUserID(ctx)
+		if m.tracker == nil || userID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		sessionID := m.session.GetSessionID(ctx)
+		switch {
+		case sessionID == "":
+			id, err := m.tracker.Start(ctx, userID, m.getClientIP(r), r.UserAgent())
+			if err != nil {
+				m.logger.Error("failed to record session", "error", err)
+				break
+			}
+			m.session.SetSessionID(ctx, id)
+		case time.Since(m.session.GetLastSeenAt(ctx)) >= sessionTouchInterval:
+			if err := m.tracker.Touch(ctx, userID, sessionID, m.getClientIP(r)); err != nil {
+				m.logger.Error("failed to record session use", "error", err)
+			}
+			m.session.SetLastSeenAt(ctx, time.Now())
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 func (m *Middleware) LoginRequired(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		userID := m.session.GetUserID(r.Context())
@@ -378,3 +450,119 @@ func (m *Middleware) LoginRequired(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// RecentLoginRequired asks for the password again before sensitive actions,
+// when the user last gave it longer ago than the re-authentication setting
+// allows. They come back to the page they were on once they have.
+func (m *Middleware) RecentLoginRequired(next http.Handler) http.Handler {
+	if m.config == nil || m.config.Session.ReauthAfter <= 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if time.Since(m.session.GetIdentityConfirmedAt(r.Context())) <= m.config.Session.ReauthAfter {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		target := ReauthPath + "?next=" + url.QueryEscape(returnPath(r))
+		if r.Header.Get("HX-Request") == "true" {
+			w.Header().Set("HX-Redirect", target)
+			w.WriteHeader(http.StatusFound)
+			return
+		}
+		http.Redirect(w, r, target, http.StatusSeeOther)
+	})
+}
+
+// RateLimit limits the requests to the routes of scope from each IP address,
+// and for each account they name, to the policy configured for scope. Once
+// either is used up the client is told how long to wait. A limit that cannot
+// be checked does not keep the request from going through.
+func (m *Middleware) RateLimit(scope RateLimitScope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		policy, ok := m.rateLimitPolicy(scope)
+		if !ok {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := m.getClientIP(r)
+			r = withClientIP(r, ip)
+
+			keys := []string{fmt.Sprintf("%s:ip:%s", scope, ip)}
+			if account := m.rateLimitAccount(r); account != "" {
+				keys = append(keys, fmt.Sprintf("%s:account:%s", scope, account))
+			}
+
+			var wait time.Duration
+			for _, key := range keys {
+				d, err := m.limiter.Allow(r.Context(), key, policy)
+				if err != nil {
+					m.logger.Error("failed to check rate limit", "error", err)
+					continue
+				}
+				wait = max(wait, d)
+			}
+
+			if wait > 0 {
+				m.logger.Warn("rate limit exceeded", "scope", scope, "ip", ip, "url", r.URL.RequestURI())
+				TooManyRequests(w, r, wait)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rateLimitPolicy returns the policy configured for scope, and whether
+// requests are limited at all.
+func (m *Middleware) rateLimitPolicy(scope RateLimitScope) (ratelimit.Policy, bool) {
+	if m.limiter == nil || m.config == nil || !m.config.RateLimit.Enabled {
+		return ratelimit.Policy{}, false
+	}
+
+	switch scope {
+	case RateLimitLogin:
+		return m.config.RateLimit.Login, true
+	case RateLimitRegister:
+		return m.config.RateLimit.Register, true
+	case RateLimitPasswordReset:
+		return m.config.RateLimit.PasswordReset, true
+	default:
+		return ratelimit.Policy{}, false
+	}
+}
+
+// rateLimitAccount returns the account a request is for: the email of the
+// form, or the user halfway through logging in.
+func (m *Middleware) rateLimitAccount(r *http.Request) string {
+	if email := strings.ToLower(strings.TrimSpace(r.PostFormValue("email"))); email != "" {
+		return email
+	}
+	return m.session.GetPendingUserID(r.Context())
+}
+
+// returnPath is the page to come back to after a detour: the page htmx
+// sent the request from, or the page requested.
+func returnPath(r *http.Request) string {
+	if current := r.Header.Get("HX-Current-URL"); current != "" {
+		if u, err := url.Parse(current); err == nil {
+			return LocalPath(u.RequestURI())
+		}
+	}
+	if r.Method == http.MethodGet {
+		return LocalPath(r.URL.RequestURI())
+	}
+	return "/home"
+}
+
+// LocalPath returns path when it stays on this site, and the home page
+// otherwise, so that a redirect to it cannot send users elsewhere.
+func LocalPath(path string) string {
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") || strings.HasPrefix(path, "/\\") {
+		return "/home"
+	}
+	return path
+}
@@ -7,10 +7,14 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/alexedwards/scs/v2"
 	"github.com/madalinpopa/gocost-web/internal/config"
+	"github.com/madalinpopa/gocost-web/internal/platform/ratelimit"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -20,6 +24,10 @@ type stubAuthSessionManager struct {
 	username        string
 	destroyErr      error
 	destroyCalled   bool
+	confirmedAt     time.Time
+	keptAlive       bool
+	sessionID       string
+	lastSeenAt      time.Time
 }
 
 func (s *stubAuthSessionManager) RenewToken(context.Context) error {
@@ -57,6 +65,88 @@ func (s *stubAuthSessionManager) SetUsername(context.Context, string) {}
 
 func (s *stubAuthSessionManager) SetCurrency(context.Context, string) {}
 
+func (s *stubAuthSessionManager) IsEmailVerified(context.Context) bool {
+	return true
+}
+
+func (s *stubAuthSessionManager) SetEmailVerified(context.Context, bool) {}
+
+func (s *stubAuthSessionManager) GetPendingUserID(context.Context) string {
+	return ""
+}
+
+func (s *stubAuthSessionManager) SetPendingUserID(context.Context, string) {}
+
+func (s *stubAuthSessionManager) SetPasskeyChallenge(context.Context, []byte) {}
+
+func (s *stubAuthSessionManager) PopPasskeyChallenge(context.Context) []byte {
+	return nil
+}
+
+func (s *stubAuthSessionManager) SetRememberMe(context.Context, bool) {}
+
+func (s *stubAuthSessionManager) KeepAlive(context.Context) {
+	s.keptAlive = true
+}
+
+func (s *stubAuthSessionManager) ConfirmIdentity(context.Context) {}
+
+func (s *stubAuthSessionManager) GetIdentityConfirmedAt(context.Context) time.Time {
+	return s.confirmedAt
+}
+
+func (s *stubAuthSessionManager) DestroyOtherSessions(context.Context, string) error {
+	return nil
+}
+
+func (s *stubAuthSessionManager) DestroyUserSessions(context.Context, string) error {
+	return nil
+}
+
+func (s *stubAuthSessionManager) GetSessionID(context.Context) string {
+	return s.sessionID
+}
+
+func (s *stubAuthSessionManager) SetSessionID(_ context.Context, id string) {
+	s.sessionID = id
+	s.lastSeenAt = time.Now()
+}
+
+func (s *stubAuthSessionManager) GetLastSeenAt(context.Context) time.Time {
+	return s.lastSeenAt
+}
+
+func (s *stubAuthSessionManager) SetLastSeenAt(_ context.Context, at time.Time) {
+	s.lastSeenAt = at
+}
+
+func (s *stubAuthSessionManager) ActiveSessionIDs(context.Context, string) ([]string, error) {
+	return nil, nil
+}
+
+func (s *stubAuthSessionManager) DestroySession(context.Context, string, string) error {
+	return nil
+}
+
+type stubSessionTracker struct {
+	startErr error
+	started  []string
+	touched  []string
+}
+
+func (s *stubSessionTracker) Start(_ context.Context, userID, ipAddress, userAgent string) (string, error) {
+	if s.startErr != nil {
+		return "", s.startErr
+	}
+	s.started = append(s.started, userID+" "+ipAddress+" "+userAgent)
+	return "session-1", nil
+}
+
+func (s *stubSessionTracker) Touch(_ context.Context, userID, sessionID, ipAddress string) error {
+	s.touched = append(s.touched, userID+" "+sessionID+" "+ipAddress)
+	return nil
+}
+
 type stubErrorHandler struct {
 	logServerErrorCalls int
 }
@@ -318,3 +408,244 @@ func TestMiddleware_LoginRequired(t *testing.T) {
 		})
 	}
 }
+
+func TestMiddleware_RecentLoginRequired(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		reauthAfter    time.Duration
+		confirmedAt    time.Time
+		htmx           bool
+		wantStatus     int
+		wantLocation   string
+		wantHxRedirect string
+		wantNextCalled bool
+	}{
+		{
+			name:           "allows request when re-authentication is off",
+			reauthAfter:    0,
+			wantStatus:     http.StatusNoContent,
+			wantNextCalled: true,
+		},
+		{
+			name:           "allows request when the password was given recently",
+			reauthAfter:    15 * time.Minute,
+			confirmedAt:    time.Now().Add(-time.Minute),
+			wantStatus:     http.StatusNoContent,
+			wantNextCalled: true,
+		},
+		{
+			name:         "asks for the password again",
+			reauthAfter:  15 * time.Minute,
+			confirmedAt:  time.Now().Add(-time.Hour),
+			wantStatus:   http.StatusSeeOther,
+			wantLocation: "/confirm-password?next=%2Fprofile%3Ftab%3Dsecurity",
+		},
+		{
+			name:           "asks htmx requests to redirect to the page they were sent from",
+			reauthAfter:    15 * time.Minute,
+			htmx:           true,
+			wantStatus:     http.StatusFound,
+			wantHxRedirect: "/confirm-password?next=%2Fsettings",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := config.New()
+			cfg.Session.ReauthAfter = tt.reauthAfter
+			m := &Middleware{
+				logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+				config:  cfg,
+				session: &stubAuthSessionManager{userID: "user-123", confirmedAt: tt.confirmedAt},
+				errors:  &stubErrorHandler{},
+			}
+
+			nextCalled := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				nextCalled = true
+				w.WriteHeader(http.StatusNoContent)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/profile?tab=security", nil)
+			if tt.htmx {
+				req = httptest.NewRequest(http.MethodPost, "/profile/password", nil)
+				req.Header.Set("HX-Request", "true")
+				req.Header.Set("HX-Current-URL", "https://gocost.example.com/settings")
+			}
+			rec := httptest.NewRecorder()
+
+			m.RecentLoginRequired(next).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantLocation, rec.Header().Get("Location"))
+			assert.Equal(t, tt.wantHxRedirect, rec.Header().Get("HX-Redirect"))
+			assert.Equal(t, tt.wantNextCalled, nextCalled)
+		})
+	}
+}
+
+func TestLocalPath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "/profile?tab=security", LocalPath("/profile?tab=security"))
+	assert.Equal(t, "/home", LocalPath("https://evil.example.com"))
+	assert.Equal(t, "/home", LocalPath("//evil.example.com"))
+	assert.Equal(t, "/home", LocalPath(`/\evil.example.com`))
+	assert.Equal(t, "/home", LocalPath(""))
+}
+
+func TestMiddleware_TrackSession(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		session     *stubAuthSessionManager
+		startErr    error
+		wantStarted []string
+		wantTouched []string
+		wantID      string
+	}{
+		{
+			name:    "anonymous request",
+			session: &stubAuthSessionManager{},
+		},
+		{
+			name:        "records a new session",
+			session:     &stubAuthSessionManager{userID: "user-123"},
+			wantStarted: []string{"user-123 192.0.2.1 Firefox/128.0"},
+			wantID:      "session-1",
+		},
+		{
+			name:     "goes on when the session cannot be recorded",
+			session:  &stubAuthSessionManager{userID: "user-123"},
+			startErr: errors.New("database is locked"),
+		},
+		{
+			name:    "recently recorded use",
+			session: &stubAuthSessionManager{userID: "user-123", sessionID: "session-1", lastSeenAt: time.Now()},
+			wantID:  "session-1",
+		},
+		{
+			name:        "records the use of the session",
+			session:     &stubAuthSessionManager{userID: "user-123", sessionID: "session-1", lastSeenAt: time.Now().Add(-2 * time.Minute)},
+			wantTouched: []string{"user-123 session-1 192.0.2.1"},
+			wantID:      "session-1",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tracker := &stubSessionTracker{startErr: tt.startErr}
+			m := (&Middleware{
+				logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+				config:  config.New(),
+				session: tt.session,
+				errors:  &stubErrorHandler{},
+			}).WithSessionTracker(tracker)
+
+			nextCalled := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				nextCalled = true
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/home", nil)
+			req.Header.Set("User-Agent", "Firefox/128.0")
+			m.TrackSession(next).ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.True(t, nextCalled)
+			assert.Equal(t, tt.wantStarted, tracker.started)
+			assert.Equal(t, tt.wantTouched, tracker.touched)
+			assert.Equal(t, tt.wantID, tt.session.sessionID)
+			if tt.wantTouched != nil {
+				assert.WithinDuration(t, time.Now(), tt.session.lastSeenAt, time.Second)
+			}
+		})
+	}
+}
+
+func TestMiddleware_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	newMiddleware := func(enabled bool) *Middleware {
+		cfg := config.New()
+		cfg.RateLimit.Enabled = enabled
+		cfg.RateLimit.Login = ratelimit.Policy{Requests: 2, Window: time.Minute}
+		return (&Middleware{
+			logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+			config:  cfg,
+			session: &stubAuthSessionManager{},
+			errors:  &stubErrorHandler{},
+		}).WithRateLimiter(ratelimit.New(ratelimit.NewMemoryStore(), ratelimit.Lockout{}))
+	}
+
+	login := func(handler http.Handler, remoteAddr, email string, htmx bool) *httptest.ResponseRecorder {
+		body := url.Values{"email": {email}}.Encode()
+		req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.RemoteAddr = remoteAddr
+		if htmx {
+			req.Header.Set("HX-Request", "true")
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(ClientIP(r)))
+	})
+
+	t.Run("limits requests from an IP address", func(t *testing.T) {
+		t.Parallel()
+		handler := newMiddleware(true).RateLimit(RateLimitLogin)(next)
+
+		assert.Equal(t, http.StatusOK, login(handler, "192.0.2.1:1234", "alice@example.com", false).Code)
+		assert.Equal(t, http.StatusOK, login(handler, "192.0.2.1:1234", "bob@example.com", false).Code)
+
+		rr := login(handler, "192.0.2.1:1234", "carol@example.com", false)
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		assert.Equal(t, "30", rr.Header().Get("Retry-After"))
+		assert.Contains(t, rr.Body.String(), "Please try again in 30 seconds.")
+
+		rr = login(handler, "192.0.2.2:1234", "dave@example.com", false)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "192.0.2.2", rr.Body.String())
+	})
+
+	t.Run("limits requests for an account", func(t *testing.T) {
+		t.Parallel()
+		handler := newMiddleware(true).RateLimit(RateLimitLogin)(next)
+
+		assert.Equal(t, http.StatusOK, login(handler, "192.0.2.1:1234", "alice@example.com", false).Code)
+		assert.Equal(t, http.StatusOK, login(handler, "192.0.2.2:1234", "Alice@example.com", false).Code)
+
+		rr := login(handler, "192.0.2.3:1234", "alice@example.com", true)
+		assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+		assert.Equal(t, "none", rr.Header().Get("HX-Reswap"))
+		assert.Contains(t, rr.Header().Get("HX-Trigger"), "showToast")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+		handler := newMiddleware(false).RateLimit(RateLimitLogin)(next)
+
+		for i := 0; i < 5; i++ {
+			assert.Equal(t, http.StatusOK, login(handler, "192.0.2.1:1234", "alice@example.com", false).Code)
+		}
+	})
+}
+
+func TestWaitText(t *testing.T) {
+	assert.Equal(t, "1 second", waitText(200*time.Millisecond))
+	assert.Equal(t, "45 seconds", waitText(45*time.Second))
+	assert.Equal(t, "1 minute", waitText(time.Minute))
+	assert.Equal(t, "3 minutes", waitText(2*time.Minute+time.Second))
+}
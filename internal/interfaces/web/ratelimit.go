@@ -0,0 +1,73 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/interfaces/web/respond"
+)
+
+// RateLimitScope names a group of routes limited together, each with the
+// policy configured for it.
+type RateLimitScope string
+
+const (
+	RateLimitLogin         RateLimitScope = "login"
+	RateLimitRegister      RateLimitScope = "register"
+	RateLimitPasswordReset RateLimitScope = "password-reset"
+)
+
+// ClientIPKey holds the IP address of the client on rate limited routes.
+const ClientIPKey = contextKey("clientIP")
+
+// ClientIP returns the IP address of the client, as seen through the trusted
+// proxies on rate limited routes.
+func ClientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(ClientIPKey).(string); ok && ip != "" {
+		return ip
+	}
+	return extractRemoteIP(r.RemoteAddr)
+}
+
+// withClientIP returns r with ip as the IP address of the client.
+func withClientIP(r *http.Request, ip string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), ClientIPKey, ip))
+}
+
+// TooManyRequests asks the client to wait retryAfter before trying again. The
+// page of HTMX requests stays as it is and shows the message in a toast.
+func TooManyRequests(w http.ResponseWriter, r *http.Request, retryAfter time.Duration) {
+	message := fmt.Sprintf("Too many attempts. Please try again in %s.", waitText(retryAfter))
+
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	if r.Header.Get("HX-Request") == "true" {
+		if events, err := json.Marshal(respond.ToastEvent(respond.ErrorMsg, message)); err == nil {
+			w.Header().Set("HX-Trigger", string(events))
+		}
+		w.Header().Set("HX-Reswap", "none")
+	}
+	http.Error(w, message, http.StatusTooManyRequests)
+}
+
+// waitText writes d in whole seconds under a minute, and in whole minutes
+// otherwise, rounded up.
+func waitText(d time.Duration) string {
+	if d < time.Minute {
+		seconds := max(int(math.Ceil(d.Seconds())), 1)
+		if seconds == 1 {
+			return "1 second"
+		}
+		return fmt.Sprintf("%d seconds", seconds)
+	}
+
+	minutes := int(math.Ceil(d.Minutes()))
+	if minutes == 1 {
+		return "1 minute"
+	}
+	return fmt.Sprintf("%d minutes", minutes)
+}
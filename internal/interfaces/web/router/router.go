@@ -16,19 +16,22 @@ type Router struct {
 	baseRoutes      alice.Chain
 	dynamicRoutes   alice.Chain
 	protectedRoutes alice.Chain
+	sensitiveRoutes alice.Chain
 }
 
 // New creates and returns a new Router instance with the provided middleware.
 func New(m *web.Middleware) *Router {
 	baseRoutes := alice.New(m.Recover, m.Logging, m.Headers)
-	dynamicRoutes := alice.New(m.LoadSession, m.CsrfToken, m.CheckAllowedHosts, m.Authenticate)
+	dynamicRoutes := alice.New(m.LoadSession, m.CsrfToken, m.CheckAllowedHosts, m.Authenticate, m.TrackSession)
 	protectedRoutes := dynamicRoutes.Append(m.LoginRequired)
+	sensitiveRoutes := protectedRoutes.Append(m.RecentLoginRequired)
 	return &Router{
 		mux:             http.NewServeMux(),
 		middleware:      m,
 		baseRoutes:      baseRoutes,
 		dynamicRoutes:   dynamicRoutes,
 		protectedRoutes: protectedRoutes,
+		sensitiveRoutes: sensitiveRoutes,
 	}
 }
 
@@ -37,11 +40,31 @@ func (r *Router) RegisterPublicHandler(method, url string, handler http.HandlerF
 	r.mux.Handle(fmt.Sprintf("%s %s", method, url), r.dynamicRoutes.ThenFunc(handler))
 }
 
+// RegisterLimitedHandler registers a public HTTP handler with CSRF protection whose requests are limited to the policy of scope.
+func (r *Router) RegisterLimitedHandler(method, url string, scope web.RateLimitScope, handler http.HandlerFunc) {
+	r.mux.Handle(fmt.Sprintf("%s %s", method, url), r.dynamicRoutes.Append(r.middleware.RateLimit(scope)).ThenFunc(handler))
+}
+
+// RegisterLimitedPrivateHandler registers a private HTTP handler whose requests are limited to the policy of scope.
+func (r *Router) RegisterLimitedPrivateHandler(method, url string, scope web.RateLimitScope, handler http.HandlerFunc) {
+	r.mux.Handle(fmt.Sprintf("%s %s", method, url), r.protectedRoutes.Append(r.middleware.RateLimit(scope)).ThenFunc(handler))
+}
+
 // RegisterPrivateHandler registers a private HTTP handler for the given method and URL without applying m.
 func (r *Router) RegisterPrivateHandler(method, url string, handler http.HandlerFunc) {
 	r.mux.Handle(fmt.Sprintf("%s %s", method, url), r.protectedRoutes.ThenFunc(handler))
 }
 
+// RegisterSensitiveHandler registers a private HTTP handler that asks for the password again when it was last given too long ago.
+func (r *Router) RegisterSensitiveHandler(method, url string, handler http.HandlerFunc) {
+	r.mux.Handle(fmt.Sprintf("%s %s", method, url), r.sensitiveRoutes.ThenFunc(handler))
+}
+
+// RegisterLimitedSensitiveHandler registers a sensitive HTTP handler whose requests are limited to the policy of scope.
+func (r *Router) RegisterLimitedSensitiveHandler(method, url string, scope web.RateLimitScope, handler http.HandlerFunc) {
+	r.mux.Handle(fmt.Sprintf("%s %s", method, url), r.sensitiveRoutes.Append(r.middleware.RateLimit(scope)).ThenFunc(handler))
+}
+
 // RegisterUnprotectedHandler registers an unprotected HTTP handler (without CSRF protection) for the specified method and URL path.
 func (r *Router) RegisterUnprotectedHandler(method, url string, handler http.HandlerFunc) {
 	r.mux.Handle(fmt.Sprintf("%s %s", method, url), r.baseRoutes.ThenFunc(handler))
@@ -69,11 +92,21 @@ func (r *Router) RegisterRoutes(h handler.Handlers) {
 	r.RegisterPublicHandler(http.MethodGet, "/{$}", http.HandlerFunc(h.Public.IndexHandler.ShowIndexPage))
 	r.RegisterPublicHandler(http.MethodGet, "/login", http.HandlerFunc(h.Public.LoginHandler.ShowLoginPage))
 	r.RegisterPublicHandler(http.MethodGet, "/login/form", http.HandlerFunc(h.Public.LoginHandler.ShowLoginForm))
-	r.RegisterPublicHandler(http.MethodPost, "/login", http.HandlerFunc(h.Public.LoginHandler.SubmitLoginForm))
+	r.RegisterLimitedHandler(http.MethodPost, "/login", web.RateLimitLogin, http.HandlerFunc(h.Public.LoginHandler.SubmitLoginForm))
+	r.RegisterPublicHandler(http.MethodGet, "/login/two-factor", http.HandlerFunc(h.Public.LoginHandler.ShowTwoFactorPage))
+	r.RegisterLimitedHandler(http.MethodPost, "/login/two-factor", web.RateLimitLogin, http.HandlerFunc(h.Public.LoginHandler.SubmitTwoFactorForm))
+	r.RegisterPublicHandler(http.MethodGet, "/login/passkey/options", http.HandlerFunc(h.Public.LoginHandler.PasskeyLoginOptions))
+	r.RegisterLimitedHandler(http.MethodPost, "/login/passkey", web.RateLimitLogin, http.HandlerFunc(h.Public.LoginHandler.SubmitPasskeyLogin))
 	r.RegisterPublicHandler(http.MethodPost, "/logout", http.HandlerFunc(h.Public.LogoutHandler.SubmitLogout))
 	r.RegisterPublicHandler(http.MethodGet, "/register", http.HandlerFunc(h.Public.RegisterHandler.ShowRegisterPage))
 	r.RegisterPublicHandler(http.MethodGet, "/register/form", http.HandlerFunc(h.Public.RegisterHandler.ShowRegisterForm))
-	r.RegisterPublicHandler(http.MethodPost, "/register", http.HandlerFunc(h.Public.RegisterHandler.SubmitRegisterForm))
+	r.RegisterLimitedHandler(http.MethodPost, "/register", web.RateLimitRegister, http.HandlerFunc(h.Public.RegisterHandler.SubmitRegisterForm))
+	r.RegisterPublicHandler(http.MethodGet, "/password/forgot", http.HandlerFunc(h.Public.PasswordResetHandler.ShowForgotPage))
+	r.RegisterPublicHandler(http.MethodGet, "/password/forgot/form", http.HandlerFunc(h.Public.PasswordResetHandler.ShowForgotForm))
+	r.RegisterLimitedHandler(http.MethodPost, "/password/forgot", web.RateLimitPasswordReset, http.HandlerFunc(h.Public.PasswordResetHandler.SubmitForgotForm))
+	r.RegisterPublicHandler(http.MethodGet, "/password/reset", http.HandlerFunc(h.Public.PasswordResetHandler.ShowResetPage))
+	r.RegisterLimitedHandler(http.MethodPost, "/password/reset", web.RateLimitPasswordReset, http.HandlerFunc(h.Public.PasswordResetHandler.SubmitResetForm))
+	r.RegisterPublicHandler(http.MethodGet, "/verify-email", http.HandlerFunc(h.Public.VerificationHandler.VerifyEmail))
 
 	// Private pages
 	r.RegisterPrivateHandler(http.MethodGet, "/home", http.HandlerFunc(h.Private.HomeHandler.ShowHomePage))
@@ -85,11 +118,84 @@ func (r *Router) RegisterRoutes(h handler.Handlers) {
 	r.RegisterPrivateHandler(http.MethodGet, "/groups/form", http.HandlerFunc(h.Private.GroupHandler.GetCreateForm))
 	r.RegisterPrivateHandler(http.MethodPost, "/groups", http.HandlerFunc(h.Private.GroupHandler.CreateGroup))
 	r.RegisterPrivateHandler(http.MethodPost, "/groups/edit", http.HandlerFunc(h.Private.GroupHandler.UpdateGroup))
-	r.RegisterPrivateHandler(http.MethodDelete, "/groups/{id}", http.HandlerFunc(h.Private.GroupHandler.DeleteGroup))
+	r.RegisterPrivateHandler(http.MethodPost, "/groups/reorder", http.HandlerFunc(h.Private.GroupHandler.ReorderGroups))
+	r.RegisterPrivateHandler(http.MethodGet, "/groups/delete/form", http.HandlerFunc(h.Private.GroupHandler.GetDeleteForm))
+	r.RegisterPrivateHandler(http.MethodPost, "/groups/delete", http.HandlerFunc(h.Private.GroupHandler.DeleteGroup))
+	r.RegisterPrivateHandler(http.MethodPost, "/groups/{id}/archive", http.HandlerFunc(h.Private.GroupHandler.ArchiveGroup))
+	r.RegisterPrivateHandler(http.MethodPost, "/groups/{id}/restore", http.HandlerFunc(h.Private.GroupHandler.RestoreGroup))
 	r.RegisterPrivateHandler(http.MethodGet, "/categories/form", http.HandlerFunc(h.Private.CategoryHandler.GetCreateForm))
 	r.RegisterPrivateHandler(http.MethodPost, "/categories", http.HandlerFunc(h.Private.CategoryHandler.CreateCategory))
 	r.RegisterPrivateHandler(http.MethodPost, "/categories/edit", http.HandlerFunc(h.Private.CategoryHandler.UpdateCategory))
-	r.RegisterPrivateHandler(http.MethodDelete, "/groups/{groupID}/categories/{id}", http.HandlerFunc(h.Private.CategoryHandler.DeleteCategory))
+	r.RegisterPrivateHandler(http.MethodGet, "/categories/transfer/form", http.HandlerFunc(h.Private.CategoryHandler.GetTransferForm))
+	r.RegisterPrivateHandler(http.MethodPost, "/categories/transfer", http.HandlerFunc(h.Private.CategoryHandler.TransferCategory))
+	r.RegisterPrivateHandler(http.MethodGet, "/categories/delete/form", http.HandlerFunc(h.Private.CategoryHandler.GetDeleteForm))
+	r.RegisterPrivateHandler(http.MethodPost, "/categories/delete", http.HandlerFunc(h.Private.CategoryHandler.DeleteCategory))
+	r.RegisterPrivateHandler(http.MethodPost, "/groups/{groupID}/categories/reorder", http.HandlerFunc(h.Private.CategoryHandler.ReorderCategories))
+	r.RegisterPrivateHandler(http.MethodPost, "/groups/{groupID}/categories/{id}/archive", http.HandlerFunc(h.Private.CategoryHandler.ArchiveCategory))
+	r.RegisterPrivateHandler(http.MethodPost, "/groups/{groupID}/categories/{id}/restore", http.HandlerFunc(h.Private.CategoryHandler.RestoreCategory))
+	r.RegisterPrivateHandler(http.MethodGet, "/archive", http.HandlerFunc(h.Private.ArchiveHandler.ShowArchivePage))
+	r.RegisterPrivateHandler(http.MethodGet, "/archive/list", http.HandlerFunc(h.Private.ArchiveHandler.GetArchiveList))
+	r.RegisterPrivateHandler(http.MethodGet, "/plan", http.HandlerFunc(h.Private.PlanHandler.ShowPlanPage))
+	r.RegisterPrivateHandler(http.MethodPost, "/plan", http.HandlerFunc(h.Private.PlanHandler.ApplyPlan))
+	r.RegisterPrivateHandler(http.MethodGet, "/months/close/form", http.HandlerFunc(h.Private.ClosingHandler.GetCloseForm))
+	r.RegisterPrivateHandler(http.MethodPost, "/months/close", http.HandlerFunc(h.Private.ClosingHandler.CloseMonth))
+	r.RegisterPrivateHandler(http.MethodPost, "/months/{month}/reopen", http.HandlerFunc(h.Private.ClosingHandler.ReopenMonth))
+	r.RegisterPrivateHandler(http.MethodPost, "/budget/zero-based", http.HandlerFunc(h.Private.BudgetHandler.ToggleZeroBased))
+	r.RegisterPrivateHandler(http.MethodGet, "/budget/assign/form", http.HandlerFunc(h.Private.BudgetHandler.GetAssignForm))
+	r.RegisterPrivateHandler(http.MethodPost, "/budget/fill", http.HandlerFunc(h.Private.BudgetHandler.FillFromLastMonth))
+	r.RegisterPrivateHandler(http.MethodPost, "/budget/distribute", http.HandlerFunc(h.Private.BudgetHandler.Distribute))
+	r.RegisterPrivateHandler(http.MethodGet, "/goals", http.HandlerFunc(h.Private.GoalHandler.ListGoals))
+	r.RegisterPrivateHandler(http.MethodPost, "/goals", http.HandlerFunc(h.Private.GoalHandler.CreateGoal))
+	r.RegisterPrivateHandler(http.MethodPost, "/goals/{id}/edit", http.HandlerFunc(h.Private.GoalHandler.UpdateGoal))
+	r.RegisterPrivateHandler(http.MethodDelete, "/goals/{id}", http.HandlerFunc(h.Private.GoalHandler.DeleteGoal))
+	r.RegisterPrivateHandler(http.MethodPost, "/goals/{id}/contributions", http.HandlerFunc(h.Private.GoalHandler.Contribute))
+	r.RegisterPrivateHandler(http.MethodDelete, "/goals/{id}/contributions/{contributionID}", http.HandlerFunc(h.Private.GoalHandler.RemoveContribution))
+	r.RegisterPrivateHandler(http.MethodGet, "/accounts", http.HandlerFunc(h.Private.AccountHandler.ShowAccountsPage))
+	r.RegisterPrivateHandler(http.MethodPost, "/accounts", http.HandlerFunc(h.Private.AccountHandler.CreateAccount))
+	r.RegisterPrivateHandler(http.MethodGet, "/accounts/options", http.HandlerFunc(h.Private.AccountHandler.GetAccountOptions))
+	r.RegisterPrivateHandler(http.MethodPost, "/accounts/transfers", http.HandlerFunc(h.Private.AccountHandler.CreateTransfer))
+	r.RegisterPrivateHandler(http.MethodGet, "/accounts/{id}", http.HandlerFunc(h.Private.AccountHandler.ShowLedgerPage))
+	r.RegisterPrivateHandler(http.MethodPost, "/accounts/{id}/edit", http.HandlerFunc(h.Private.AccountHandler.UpdateAccount))
+	r.RegisterPrivateHandler(http.MethodDelete, "/accounts/{id}", http.HandlerFunc(h.Private.AccountHandler.DeleteAccount))
+	r.RegisterPrivateHandler(http.MethodPost, "/accounts/{id}/reconcile", http.HandlerFunc(h.Private.AccountHandler.Reconcile))
+	r.RegisterPrivateHandler(http.MethodDelete, "/accounts/{id}/transfers/{transferID}", http.HandlerFunc(h.Private.AccountHandler.DeleteTransfer))
+	r.RegisterPrivateHandler(http.MethodGet, "/loans", http.HandlerFunc(h.Private.LoanHandler.ShowLoansPage))
+	r.RegisterPrivateHandler(http.MethodPost, "/loans", http.HandlerFunc(h.Private.LoanHandler.CreateLoan))
+	r.RegisterPrivateHandler(http.MethodGet, "/loans/{id}", http.HandlerFunc(h.Private.LoanHandler.ShowSchedulePage))
+	r.RegisterPrivateHandler(http.MethodPost, "/loans/{id}/edit", http.HandlerFunc(h.Private.LoanHandler.UpdateLoan))
+	r.RegisterPrivateHandler(http.MethodDelete, "/loans/{id}", http.HandlerFunc(h.Private.LoanHandler.DeleteLoan))
+	r.RegisterPrivateHandler(http.MethodPost, "/loans/{id}/simulate", http.HandlerFunc(h.Private.LoanHandler.Simulate))
+	r.RegisterPrivateHandler(http.MethodGet, "/net-worth", http.HandlerFunc(h.Private.NetWorthHandler.ShowNetWorthPage))
+	r.RegisterPrivateHandler(http.MethodPost, "/net-worth/assets", http.HandlerFunc(h.Private.NetWorthHandler.CreateAsset))
+	r.RegisterPrivateHandler(http.MethodPost, "/net-worth/assets/{id}/edit", http.HandlerFunc(h.Private.NetWorthHandler.UpdateAsset))
+	r.RegisterPrivateHandler(http.MethodDelete, "/net-worth/assets/{id}", http.HandlerFunc(h.Private.NetWorthHandler.DeleteAsset))
+	r.RegisterPrivateHandler(http.MethodPost, "/net-worth/assets/{id}/valuations", http.HandlerFunc(h.Private.NetWorthHandler.RecordValuation))
+	r.RegisterPrivateHandler(http.MethodDelete, "/net-worth/assets/{id}/valuations/{month}", http.HandlerFunc(h.Private.NetWorthHandler.RemoveValuation))
+	r.RegisterPrivateHandler(http.MethodGet, "/calendar", http.HandlerFunc(h.Private.CalendarHandler.ShowCalendarPage))
+	r.RegisterPrivateHandler(http.MethodGet, "/calendar/grid", http.HandlerFunc(h.Private.CalendarHandler.GetCalendarGrid))
+	r.RegisterPrivateHandler(http.MethodGet, "/alerts", http.HandlerFunc(h.Private.AlertHandler.ShowAlertsPage))
+	r.RegisterPrivateHandler(http.MethodPost, "/alerts/categories/{id}", http.HandlerFunc(h.Private.AlertHandler.SaveThresholds))
+	r.RegisterPrivateHandler(http.MethodGet, "/notifications", http.HandlerFunc(h.Private.AlertHandler.GetNotifications))
+	r.RegisterPrivateHandler(http.MethodPost, "/notifications/read", http.HandlerFunc(h.Private.AlertHandler.MarkAllRead))
+	r.RegisterPrivateHandler(http.MethodPost, "/notifications/{id}/read", http.HandlerFunc(h.Private.AlertHandler.MarkRead))
+	r.RegisterPrivateHandler(http.MethodGet, "/confirm-password", http.HandlerFunc(h.Public.LoginHandler.ShowReauthPage))
+	r.RegisterLimitedPrivateHandler(http.MethodPost, "/confirm-password", web.RateLimitLogin, http.HandlerFunc(h.Public.LoginHandler.SubmitReauthForm))
+	r.RegisterPrivateHandler(http.MethodGet, "/profile", http.HandlerFunc(h.Private.ProfileHandler.ShowProfilePage))
+	r.RegisterSensitiveHandler(http.MethodPost, "/profile", http.HandlerFunc(h.Private.ProfileHandler.UpdateProfile))
+	r.RegisterPrivateHandler(http.MethodPost, "/profile/currency", http.HandlerFunc(h.Private.ProfileHandler.UpdateCurrency))
+	r.RegisterLimitedSensitiveHandler(http.MethodPost, "/profile/password", web.RateLimitLogin, http.HandlerFunc(h.Private.ProfileHandler.ChangePassword))
+	r.RegisterPrivateHandler(http.MethodGet, "/profile/two-factor", http.HandlerFunc(h.Private.TwoFactorHandler.ShowSettings))
+	r.RegisterSensitiveHandler(http.MethodPost, "/profile/two-factor/setup", http.HandlerFunc(h.Private.TwoFactorHandler.BeginSetup))
+	r.RegisterSensitiveHandler(http.MethodPost, "/profile/two-factor/confirm", http.HandlerFunc(h.Private.TwoFactorHandler.ConfirmSetup))
+	r.RegisterLimitedSensitiveHandler(http.MethodPost, "/profile/two-factor/disable", web.RateLimitLogin, http.HandlerFunc(h.Private.TwoFactorHandler.Disable))
+	r.RegisterPrivateHandler(http.MethodGet, "/profile/passkeys", http.HandlerFunc(h.Private.PasskeyHandler.ShowSettings))
+	r.RegisterSensitiveHandler(http.MethodGet, "/profile/passkeys/options", http.HandlerFunc(h.Private.PasskeyHandler.RegistrationOptions))
+	r.RegisterSensitiveHandler(http.MethodPost, "/profile/passkeys", http.HandlerFunc(h.Private.PasskeyHandler.Register))
+	r.RegisterSensitiveHandler(http.MethodDelete, "/profile/passkeys/{id}", http.HandlerFunc(h.Private.PasskeyHandler.Revoke))
+	r.RegisterPrivateHandler(http.MethodGet, "/profile/sessions", http.HandlerFunc(h.Private.SessionHandler.ShowSettings))
+	r.RegisterSensitiveHandler(http.MethodDelete, "/profile/sessions/{id}", http.HandlerFunc(h.Private.SessionHandler.Revoke))
+	r.RegisterSensitiveHandler(http.MethodPost, "/profile/sessions/sign-out-others", http.HandlerFunc(h.Private.SessionHandler.RevokeOthers))
+	r.RegisterPrivateHandler(http.MethodPost, "/verify-email/resend", http.HandlerFunc(h.Public.VerificationHandler.ResendVerification))
 	r.RegisterPrivateHandler(http.MethodGet, "/expenses/form", http.HandlerFunc(h.Private.ExpenseHandler.GetCreateForm))
 	r.RegisterPrivateHandler(http.MethodPost, "/expenses", http.HandlerFunc(h.Private.ExpenseHandler.CreateExpense))
 	r.RegisterPrivateHandler(http.MethodPost, "/expenses/edit", http.HandlerFunc(h.Private.ExpenseHandler.EditExpense))
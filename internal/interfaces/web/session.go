@@ -3,6 +3,8 @@ package web
 import (
 	"context"
 	"database/sql"
+	"encoding/gob"
+	"errors"
 	"net/http"
 	"time"
 
@@ -19,8 +21,34 @@ const (
 	authenticatedUserID   = "authenticatedUserID"
 	authenticatedUsername = "authenticatedUsername"
 	authenticatedCurrency = "authenticatedCurrency"
+	unverifiedEmail       = "unverifiedEmail"
+	pendingUserID         = "pendingUserID"
+	pendingSince          = "pendingSince"
+	passkeyChallenge      = "passkeyChallenge"
+	passkeyChallengeSince = "passkeyChallengeSince"
+	rememberMe            = "rememberMe"
+	authenticatedAt       = "authenticatedAt"
+	identityConfirmedAt   = "identityConfirmedAt"
+	sessionID             = "sessionID"
+	sessionLastSeenAt     = "sessionLastSeenAt"
 )
 
+// ErrSessionNotFound means the session to sign out already ended.
+var ErrSessionNotFound = errors.New("session not found")
+
+// pendingLoginTTL is how long users have for the second step of a login.
+const pendingLoginTTL = 5 * time.Minute
+
+// passkeyChallengeTTL is how long the browser has to answer the challenge of
+// a passkey ceremony.
+const passkeyChallengeTTL = 5 * time.Minute
+
+// Session values are gob encoded in the store, which needs the times kept in
+// them registered.
+func init() {
+	gob.Register(time.Time{})
+}
+
 type AuthSessionManager interface {
 	RenewToken(ctx context.Context) error
 	Destroy(ctx context.Context) error
@@ -32,6 +60,24 @@ type AuthSessionManager interface {
 	SetUserID(ctx context.Context, userID string)
 	SetUsername(ctx context.Context, username string)
 	SetCurrency(ctx context.Context, currency string)
+	IsEmailVerified(ctx context.Context) bool
+	SetEmailVerified(ctx context.Context, verified bool)
+	GetPendingUserID(ctx context.Context) string
+	SetPendingUserID(ctx context.Context, userID string)
+	SetPasskeyChallenge(ctx context.Context, challenge []byte)
+	PopPasskeyChallenge(ctx context.Context) []byte
+	SetRememberMe(ctx context.Context, remember bool)
+	KeepAlive(ctx context.Context)
+	ConfirmIdentity(ctx context.Context)
+	GetIdentityConfirmedAt(ctx context.Context) time.Time
+	DestroyOtherSessions(ctx context.Context, userID string) error
+	DestroyUserSessions(ctx context.Context, userID string) error
+	GetSessionID(ctx context.Context) string
+	SetSessionID(ctx context.Context, id string)
+	GetLastSeenAt(ctx context.Context) time.Time
+	SetLastSeenAt(ctx context.Context, at time.Time)
+	ActiveSessionIDs(ctx context.Context, userID string) ([]string, error)
+	DestroySession(ctx context.Context, userID string, id string) error
 }
 
 // AuthenticatedUser represents the user data stored in the session and context.
@@ -40,27 +86,40 @@ type AuthenticatedUser struct {
 	ID       string
 	Username string
 	Currency string
+
+	EmailVerified bool
 }
 
 // Manager provides a wrapper around scs.SessionManager to manage session operations.
 type Manager struct {
 	Manager *scs.SessionManager
+
+	lifetime           time.Duration
+	idleTimeout        time.Duration
+	rememberMeLifetime time.Duration
 }
 
-// NewSession initializes and returns a new instance of Manager with a SQLite store and a 12-hour session lifetime.
+// NewSession initializes and returns a new instance of Manager with a SQLite
+// store and the session lifetimes of the config. The cookie lasts until the
+// browser closes, unless the user asks to be remembered.
+//
+// The idle timeout is kept by the Manager rather than scs, which would apply
+// it to remembered sessions too.
 func NewSession(db *sql.DB, c *config.Config) *Manager {
 	sessionManager := scs.New()
 	sessionManager.Store = sqlite3store.New(db)
-	sessionManager.Lifetime = 1 * time.Hour
-	sessionManager.IdleTimeout = 20 * time.Minute
+	sessionManager.Lifetime = c.Session.Lifetime
 	sessionManager.Cookie.HttpOnly = true
-	sessionManager.Cookie.Persist = false // TODO: Need to implement remember me
+	sessionManager.Cookie.Persist = false
 	if c.GetEnvironment() == "production" {
 		sessionManager.Cookie.SameSite = http.SameSiteStrictMode
 		sessionManager.Cookie.Secure = true
 	}
 	return &Manager{
-		Manager: sessionManager,
+		Manager:            sessionManager,
+		lifetime:           c.Session.Lifetime,
+		idleTimeout:        c.Session.IdleTimeout,
+		rememberMeLifetime: c.Session.RememberMeLifetime,
 	}
 }
 
@@ -92,7 +151,12 @@ func (m *Manager) GetCurrency(ctx context.Context) string {
 	return m.Manager.GetString(ctx, authenticatedCurrency)
 }
 
+// SetUserID signs the user in. A session that was someone else's is
+// recorded anew.
 func (m *Manager) SetUserID(ctx context.Context, userID string) {
+	if m.GetUserID(ctx) != userID {
+		m.Manager.Remove(ctx, sessionID)
+	}
 	m.Manager.Put(ctx, authenticatedUserID, userID)
 }
 
@@ -103,3 +167,186 @@ func (m *Manager) SetUsername(ctx context.Context, username string) {
 func (m *Manager) SetCurrency(ctx context.Context, currency string) {
 	m.Manager.Put(ctx, authenticatedCurrency, currency)
 }
+
+// IsEmailVerified reports whether the email of the user was verified when it
+// was last checked. Sessions that never recorded it count as verified.
+func (m *Manager) IsEmailVerified(ctx context.Context) bool {
+	return !m.Manager.GetBool(ctx, unverifiedEmail)
+}
+
+func (m *Manager) SetEmailVerified(ctx context.Context, verified bool) {
+	if verified {
+		m.Manager.Remove(ctx, unverifiedEmail)
+		return
+	}
+	m.Manager.Put(ctx, unverifiedEmail, true)
+}
+
+// GetPendingUserID returns the user who passed the password step of a login
+// and has yet to pass the second one, for a few minutes.
+func (m *Manager) GetPendingUserID(ctx context.Context) string {
+	if time.Since(m.Manager.GetTime(ctx, pendingSince)) > pendingLoginTTL {
+		return ""
+	}
+	return m.Manager.GetString(ctx, pendingUserID)
+}
+
+// SetPendingUserID starts the second step of a login. An empty ID ends it.
+func (m *Manager) SetPendingUserID(ctx context.Context, userID string) {
+	if userID == "" {
+		m.Manager.Remove(ctx, pendingUserID)
+		m.Manager.Remove(ctx, pendingSince)
+		return
+	}
+	m.Manager.Put(ctx, pendingUserID, userID)
+	m.Manager.Put(ctx, pendingSince, time.Now())
+}
+
+// SetPasskeyChallenge keeps the challenge of a passkey ceremony until the
+// browser answers it. A new ceremony replaces the challenge of the last one.
+func (m *Manager) SetPasskeyChallenge(ctx context.Context, challenge []byte) {
+	m.Manager.Put(ctx, passkeyChallenge, challenge)
+	m.Manager.Put(ctx, passkeyChallengeSince, time.Now())
+}
+
+// PopPasskeyChallenge returns the challenge of the passkey ceremony under way
+// and forgets it, so that it is answered once. It is nil when there is none
+// or it expired.
+func (m *Manager) PopPasskeyChallenge(ctx context.Context) []byte {
+	challenge := m.Manager.PopBytes(ctx, passkeyChallenge)
+	since := m.Manager.PopTime(ctx, passkeyChallengeSince)
+	if time.Since(since) > passkeyChallengeTTL {
+		return nil
+	}
+	return challenge
+}
+
+// SetRememberMe starts the lifetime of the session of a user who just
+// logged in. A remembered session keeps its cookie when the browser closes
+// and lasts the remember-me lifetime, idle or not; any other lasts the
+// session lifetime, ending sooner when unused for the idle timeout.
+func (m *Manager) SetRememberMe(ctx context.Context, remember bool) {
+	now := time.Now()
+	m.Manager.Put(ctx, authenticatedAt, now)
+	m.Manager.RememberMe(ctx, remember)
+
+	if remember {
+		m.Manager.Put(ctx, rememberMe, true)
+		m.Manager.SetDeadline(ctx, now.Add(m.rememberMeLifetime))
+		return
+	}
+
+	m.Manager.Remove(ctx, rememberMe)
+	m.Manager.SetDeadline(ctx, now.Add(m.lifetime))
+	m.KeepAlive(ctx)
+}
+
+// KeepAlive pushes back the end of a session that is not remembered by the
+// idle timeout, never past its lifetime. It is called on every request of a
+// logged-in user.
+func (m *Manager) KeepAlive(ctx context.Context) {
+	if m.idleTimeout <= 0 || m.Manager.GetBool(ctx, rememberMe) {
+		return
+	}
+	start := m.Manager.GetTime(ctx, authenticatedAt)
+	if start.IsZero() {
+		return
+	}
+
+	deadline := start.Add(m.lifetime)
+	if idle := time.Now().Add(m.idleTimeout); idle.Before(deadline) {
+		deadline = idle
+	}
+	m.Manager.SetDeadline(ctx, deadline)
+}
+
+// ConfirmIdentity records that the user just gave the password, or used a
+// passkey, so sensitive actions do not ask for it again for a while.
+func (m *Manager) ConfirmIdentity(ctx context.Context) {
+	m.Manager.Put(ctx, identityConfirmedAt, time.Now())
+}
+
+// GetIdentityConfirmedAt returns when the user last gave the password, or
+// used a passkey. It is zero when they never did in this session.
+func (m *Manager) GetIdentityConfirmedAt(ctx context.Context) time.Time {
+	return m.Manager.GetTime(ctx, identityConfirmedAt)
+}
+
+// DestroyOtherSessions signs the user out everywhere but in the session of
+// ctx.
+func (m *Manager) DestroyOtherSessions(ctx context.Context, userID string) error {
+	current := m.Manager.Token(ctx)
+	return m.Manager.Iterate(ctx, func(sessionCtx context.Context) error {
+		if m.Manager.Token(sessionCtx) == current || m.GetUserID(sessionCtx) != userID {
+			return nil
+		}
+		return m.Manager.Destroy(sessionCtx)
+	})
+}
+
+// DestroyUserSessions signs the user out everywhere.
+func (m *Manager) DestroyUserSessions(ctx context.Context, userID string) error {
+	return m.Manager.Iterate(ctx, func(sessionCtx context.Context) error {
+		if m.GetUserID(sessionCtx) != userID {
+			return nil
+		}
+		return m.Manager.Destroy(sessionCtx)
+	})
+}
+
+// GetSessionID returns the ID the session is recorded under, as listed in
+// the settings. It is empty until the session is recorded.
+func (m *Manager) GetSessionID(ctx context.Context) string {
+	return m.Manager.GetString(ctx, sessionID)
+}
+
+// SetSessionID keeps the ID the session was just recorded under.
+func (m *Manager) SetSessionID(ctx context.Context, id string) {
+	m.Manager.Put(ctx, sessionID, id)
+	m.Manager.Put(ctx, sessionLastSeenAt, time.Now())
+}
+
+// GetLastSeenAt returns when the use of the session was last recorded.
+func (m *Manager) GetLastSeenAt(ctx context.Context) time.Time {
+	return m.Manager.GetTime(ctx, sessionLastSeenAt)
+}
+
+func (m *Manager) SetLastSeenAt(ctx context.Context, at time.Time) {
+	m.Manager.Put(ctx, sessionLastSeenAt, at)
+}
+
+// ActiveSessionIDs returns the IDs the sessions of the user that have not
+// ended are recorded under.
+func (m *Manager) ActiveSessionIDs(ctx context.Context, userID string) ([]string, error) {
+	var ids []string
+	err := m.Manager.Iterate(ctx, func(sessionCtx context.Context) error {
+		if m.GetUserID(sessionCtx) != userID {
+			return nil
+		}
+		if id := m.GetSessionID(sessionCtx); id != "" {
+			ids = append(ids, id)
+		}
+		return nil
+	})
+	return ids, err
+}
+
+// DestroySession signs the user out of the session recorded under id. It
+// returns ErrSessionNotFound when the session already ended.
+func (m *Manager) DestroySession(ctx context.Context, userID string, id string) error {
+	found := false
+	err := m.Manager.Iterate(ctx, func(sessionCtx context.Context) error {
+		if m.GetUserID(sessionCtx) != userID || m.GetSessionID(sessionCtx) != id {
+			return nil
+		}
+		found = true
+		return m.Manager.Destroy(sessionCtx)
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrSessionNotFound
+	}
+	return nil
+}
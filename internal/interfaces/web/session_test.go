@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -64,7 +65,7 @@ func TestNew_NonProductionDefaults(t *testing.T) {
 	t.Cleanup(store.StopCleanup)
 
 	assert.Equal(t, time.Hour, manager.Manager.Lifetime)
-	assert.Equal(t, 20*time.Minute, manager.Manager.IdleTimeout)
+	assert.Zero(t, manager.Manager.IdleTimeout, "the idle timeout is kept by the manager")
 	assert.True(t, manager.Manager.Cookie.HttpOnly)
 	assert.False(t, manager.Manager.Cookie.Persist)
 	assert.Equal(t, http.SameSiteLaxMode, manager.Manager.Cookie.SameSite)
@@ -110,6 +111,104 @@ func TestManager_UserAccessors(t *testing.T) {
 	assert.Equal(t, "alice", manager.GetUsername(ctx))
 }
 
+func TestManager_EmailVerified(t *testing.T) {
+	manager, ctx := newTestManagerWithContext(t)
+
+	assert.True(t, manager.IsEmailVerified(ctx))
+
+	manager.SetEmailVerified(ctx, false)
+	assert.False(t, manager.IsEmailVerified(ctx))
+
+	manager.SetEmailVerified(ctx, true)
+	assert.True(t, manager.IsEmailVerified(ctx))
+}
+
+func TestManager_PendingUserID(t *testing.T) {
+	manager, ctx := newTestManagerWithContext(t)
+
+	assert.Empty(t, manager.GetPendingUserID(ctx))
+
+	manager.SetPendingUserID(ctx, "user-123")
+	assert.Equal(t, "user-123", manager.GetPendingUserID(ctx))
+	assert.False(t, manager.IsAuthenticated(ctx))
+
+	manager.Manager.Put(ctx, "pendingSince", time.Now().Add(-time.Hour))
+	assert.Empty(t, manager.GetPendingUserID(ctx))
+
+	manager.SetPendingUserID(ctx, "")
+	assert.Empty(t, manager.GetPendingUserID(ctx))
+}
+
+func TestManager_PasskeyChallenge(t *testing.T) {
+	manager, ctx := newTestManagerWithContext(t)
+
+	assert.Nil(t, manager.PopPasskeyChallenge(ctx))
+
+	manager.SetPasskeyChallenge(ctx, []byte("challenge"))
+	assert.Equal(t, []byte("challenge"), manager.PopPasskeyChallenge(ctx))
+	assert.Nil(t, manager.PopPasskeyChallenge(ctx), "a challenge is answered once")
+
+	manager.SetPasskeyChallenge(ctx, []byte("challenge"))
+	manager.Manager.Put(ctx, "passkeyChallengeSince", time.Now().Add(-time.Hour))
+	assert.Nil(t, manager.PopPasskeyChallenge(ctx))
+}
+
+func TestManager_RememberMe(t *testing.T) {
+	newManager := func(t *testing.T) (*web.Manager, context.Context) {
+		t.Helper()
+		manager := web.NewSession(newTestDB(t), config.New().WithEnvironment("development"))
+		t.Cleanup(manager.Manager.Store.(*sqlite3store.SQLite3Store).StopCleanup)
+		ctx, err := manager.Manager.Load(context.Background(), "")
+		if err != nil {
+			t.Fatalf("load session context: %v", err)
+		}
+		return manager, ctx
+	}
+	cookie := func(manager *web.Manager, ctx context.Context) string {
+		rec := httptest.NewRecorder()
+		manager.Manager.WriteSessionCookie(ctx, rec, "token", manager.Manager.Deadline(ctx))
+		return rec.Header().Get("Set-Cookie")
+	}
+
+	t.Run("session ends when the browser closes or after the idle timeout", func(t *testing.T) {
+		manager, ctx := newManager(t)
+
+		manager.SetRememberMe(ctx, false)
+
+		assert.WithinDuration(t, time.Now().Add(20*time.Minute), manager.Manager.Deadline(ctx), time.Second)
+		assert.NotContains(t, cookie(manager, ctx), "Max-Age")
+	})
+
+	t.Run("remembered session keeps its cookie for the remember-me lifetime", func(t *testing.T) {
+		manager, ctx := newManager(t)
+
+		manager.SetRememberMe(ctx, true)
+		manager.KeepAlive(ctx)
+
+		assert.WithinDuration(t, time.Now().Add(30*24*time.Hour), manager.Manager.Deadline(ctx), time.Second)
+		assert.Contains(t, cookie(manager, ctx), "Max-Age")
+	})
+
+	t.Run("activity never keeps a session past its lifetime", func(t *testing.T) {
+		manager, ctx := newManager(t)
+
+		manager.SetRememberMe(ctx, false)
+		manager.Manager.Put(ctx, "authenticatedAt", time.Now().Add(-55*time.Minute))
+		manager.KeepAlive(ctx)
+
+		assert.WithinDuration(t, time.Now().Add(5*time.Minute), manager.Manager.Deadline(ctx), time.Second)
+	})
+}
+
+func TestManager_ConfirmIdentity(t *testing.T) {
+	manager, ctx := newTestManagerWithContext(t)
+
+	assert.True(t, manager.GetIdentityConfirmedAt(ctx).IsZero())
+
+	manager.ConfirmIdentity(ctx)
+	assert.WithinDuration(t, time.Now(), manager.GetIdentityConfirmedAt(ctx), time.Second)
+}
+
 func TestManager_RenewTokenAndDestroy(t *testing.T) {
 	manager, ctx := newTestManagerWithContext(t)
 
@@ -122,3 +221,143 @@ func TestManager_RenewTokenAndDestroy(t *testing.T) {
 	assert.False(t, manager.IsAuthenticated(ctx))
 	assert.Empty(t, manager.GetSessionStore().Token(ctx))
 }
+
+func TestManager_DestroyOtherSessions(t *testing.T) {
+	db := newTestDB(t)
+	db.SetMaxOpenConns(1)
+	_, err := db.Exec(`CREATE TABLE sessions (token TEXT PRIMARY KEY, data BLOB NOT NULL, expiry REAL NOT NULL)`)
+	assert.NoError(t, err)
+
+	manager := web.NewSession(db, config.New().WithEnvironment("development"))
+	store := manager.Manager.Store.(*sqlite3store.SQLite3Store)
+	t.Cleanup(store.StopCleanup)
+
+	signIn := func(userID string) context.Context {
+		ctx, err := manager.Manager.Load(context.Background(), "")
+		assert.NoError(t, err)
+		manager.SetUserID(ctx, userID)
+		_, _, err = manager.Manager.Commit(ctx)
+		assert.NoError(t, err)
+		return ctx
+	}
+	current := signIn("user-123")
+	other := signIn("user-123")
+	someoneElse := signIn("user-456")
+
+	assert.NoError(t, manager.DestroyOtherSessions(current, "user-123"))
+
+	exists := func(ctx context.Context) bool {
+		_, found, err := store.Find(manager.Manager.Token(ctx))
+		assert.NoError(t, err)
+		return found
+	}
+	assert.True(t, exists(current))
+	assert.False(t, exists(other))
+	assert.True(t, exists(someoneElse))
+}
+
+func TestManager_DestroyUserSessions(t *testing.T) {
+	db := newTestDB(t)
+	db.SetMaxOpenConns(1)
+	_, err := db.Exec(`CREATE TABLE sessions (token TEXT PRIMARY KEY, data BLOB NOT NULL, expiry REAL NOT NULL)`)
+	assert.NoError(t, err)
+
+	manager := web.NewSession(db, config.New().WithEnvironment("development"))
+	store := manager.Manager.Store.(*sqlite3store.SQLite3Store)
+	t.Cleanup(store.StopCleanup)
+
+	signIn := func(userID string) context.Context {
+		ctx, err := manager.Manager.Load(context.Background(), "")
+		assert.NoError(t, err)
+		manager.SetUserID(ctx, userID)
+		_, _, err = manager.Manager.Commit(ctx)
+		assert.NoError(t, err)
+		return ctx
+	}
+	first := signIn("user-123")
+	second := signIn("user-123")
+	someoneElse := signIn("user-456")
+
+	assert.NoError(t, manager.DestroyUserSessions(context.Background(), "user-123"))
+
+	exists := func(ctx context.Context) bool {
+		_, found, err := store.Find(manager.Manager.Token(ctx))
+		assert.NoError(t, err)
+		return found
+	}
+	assert.False(t, exists(first))
+	assert.False(t, exists(second))
+	assert.True(t, exists(someoneElse))
+}
+
+func TestManager_SessionID(t *testing.T) {
+	manager, ctx := newTestManagerWithContext(t)
+
+	assert.Empty(t, manager.GetSessionID(ctx))
+	assert.True(t, manager.GetLastSeenAt(ctx).IsZero())
+
+	manager.SetSessionID(ctx, "session-1")
+	assert.Equal(t, "session-1", manager.GetSessionID(ctx))
+	assert.WithinDuration(t, time.Now(), manager.GetLastSeenAt(ctx), time.Second)
+
+	seenAt := time.Now().Add(time.Minute)
+	manager.SetLastSeenAt(ctx, seenAt)
+	assert.WithinDuration(t, seenAt, manager.GetLastSeenAt(ctx), time.Millisecond)
+
+	manager.SetUserID(ctx, "user-123")
+	manager.SetSessionID(ctx, "session-2")
+	manager.SetUserID(ctx, "user-123")
+	assert.Equal(t, "session-2", manager.GetSessionID(ctx))
+
+	manager.SetUserID(ctx, "user-456")
+	assert.Empty(t, manager.GetSessionID(ctx))
+}
+
+func TestManager_RecordedSessions(t *testing.T) {
+	db := newTestDB(t)
+	db.SetMaxOpenConns(1)
+	_, err := db.Exec(`CREATE TABLE sessions (token TEXT PRIMARY KEY, data BLOB NOT NULL, expiry REAL NOT NULL)`)
+	assert.NoError(t, err)
+
+	manager := web.NewSession(db, config.New().WithEnvironment("development"))
+	store := manager.Manager.Store.(*sqlite3store.SQLite3Store)
+	t.Cleanup(store.StopCleanup)
+
+	signIn := func(userID, id string) context.Context {
+		ctx, err := manager.Manager.Load(context.Background(), "")
+		assert.NoError(t, err)
+		manager.SetUserID(ctx, userID)
+		if id != "" {
+			manager.SetSessionID(ctx, id)
+		}
+		_, _, err = manager.Manager.Commit(ctx)
+		assert.NoError(t, err)
+		return ctx
+	}
+	laptop := signIn("user-123", "session-1")
+	phone := signIn("user-123", "session-2")
+	signIn("user-123", "")
+	signIn("user-456", "session-3")
+
+	t.Run("lists the recorded sessions of the user", func(t *testing.T) {
+		ids, err := manager.ActiveSessionIDs(laptop, "user-123")
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"session-1", "session-2"}, ids)
+	})
+
+	t.Run("signs out of one session", func(t *testing.T) {
+		assert.NoError(t, manager.DestroySession(laptop, "user-123", "session-2"))
+
+		_, found, err := store.Find(manager.Manager.Token(phone))
+		assert.NoError(t, err)
+		assert.False(t, found)
+		_, found, err = store.Find(manager.Manager.Token(laptop))
+		assert.NoError(t, err)
+		assert.True(t, found)
+	})
+
+	t.Run("cannot sign out of a session of someone else", func(t *testing.T) {
+		err := manager.DestroySession(laptop, "user-123", "session-3")
+		assert.ErrorIs(t, err, web.ErrSessionNotFound)
+	})
+}
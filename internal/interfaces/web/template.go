@@ -21,6 +21,9 @@ type Data struct {
 	User        AuthenticatedUser
 	Version     string
 	Currency    string
+	// RegistrationOpen is set unless registration is closed, when the links
+	// to the register page are hidden.
+	RegistrationOpen bool
 }
 
 func (d *Data) SetToast(toastType ToastType, message string) {
@@ -85,5 +88,7 @@ func (t *Template) GetData(r *http.Request) Data {
 		User:        user,
 		Version:     t.config.Version,
 		Currency:    t.config.Currency,
+
+		RegistrationOpen: t.config.Registration != config.RegistrationClosed,
 	}
 }
@@ -0,0 +1,214 @@
+package views
+
+import (
+	"fmt"
+
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+)
+
+type AccountType string
+
+const (
+	AccountTypeChecking   AccountType = "checking"
+	AccountTypeSavings    AccountType = "savings"
+	AccountTypeCreditCard AccountType = "credit_card"
+	AccountTypeCash       AccountType = "cash"
+)
+
+// AccountTypes lists the account types in the order they are offered.
+var AccountTypes = []AccountType{AccountTypeChecking, AccountTypeSavings, AccountTypeCreditCard, AccountTypeCash}
+
+func (t AccountType) Label() string {
+	switch t {
+	case AccountTypeChecking:
+		return "Checking"
+	case AccountTypeSavings:
+		return "Savings"
+	case AccountTypeCreditCard:
+		return "Credit card"
+	case AccountTypeCash:
+		return "Cash"
+	default:
+		return string(t)
+	}
+}
+
+type AccountView struct {
+	ID                string
+	Name              string
+	Type              AccountType
+	OpeningBalance    money.Money
+	OpeningInput      string
+	Balance           money.Money
+	UnreconciledCount int
+}
+
+// IsOverdrawn reports whether the account balance is below zero, as for a
+// card carrying debt.
+func (a AccountView) IsOverdrawn() bool {
+	isNegative, _ := a.Balance.IsNegative()
+	return isNegative
+}
+
+// AccountsView lists the accounts of the user. Total is the sum of their
+// balances, so a card in debt lowers it.
+type AccountsView struct {
+	Currency string
+	Accounts []AccountView
+	Total    money.Money
+}
+
+func NewAccountsView(accounts []usecase.AccountResponse, currency string) (AccountsView, error) {
+	view := AccountsView{Currency: currency}
+	var totalCents int64
+	for _, a := range accounts {
+		accountView, err := NewAccountView(a, currency)
+		if err != nil {
+			return AccountsView{}, err
+		}
+		view.Accounts = append(view.Accounts, accountView)
+		totalCents += a.BalanceCents
+	}
+
+	total, err := money.New(totalCents, currency)
+	if err != nil {
+		return AccountsView{}, err
+	}
+	view.Total = total
+	return view, nil
+}
+
+func NewAccountView(a usecase.AccountResponse, currency string) (AccountView, error) {
+	if a.Currency != "" {
+		currency = a.Currency
+	}
+
+	opening, err := money.New(a.OpeningBalanceCents, currency)
+	if err != nil {
+		return AccountView{}, err
+	}
+	balance, err := money.New(a.BalanceCents, currency)
+	if err != nil {
+		return AccountView{}, err
+	}
+
+	return AccountView{
+		ID:                a.ID,
+		Name:              a.Name,
+		Type:              AccountType(a.Type),
+		OpeningBalance:    opening,
+		OpeningInput:      fmt.Sprintf("%.2f", opening.Amount()),
+		Balance:           balance,
+		UnreconciledCount: a.UnreconciledCount,
+	}, nil
+}
+
+// AccountEntryView is a line of an account ledger. Amount is negative when
+// money left the account.
+type AccountEntryView struct {
+	ID          string
+	Kind        string
+	Date        string
+	Description string
+	Amount      money.Money
+	Balance     money.Money
+	Reconciled  bool
+	IsTransfer  bool
+}
+
+func (e AccountEntryView) IsOutflow() bool {
+	isNegative, _ := e.Amount.IsNegative()
+	return isNegative
+}
+
+type AccountLedgerView struct {
+	Account AccountView
+	Entries []AccountEntryView
+}
+
+func NewAccountLedgerView(ledger *usecase.AccountLedgerResponse, currency string) (AccountLedgerView, error) {
+	accountView, err := NewAccountView(ledger.Account, currency)
+	if err != nil {
+		return AccountLedgerView{}, err
+	}
+
+	entries, err := newAccountEntryViews(ledger.Entries, accountView.Balance.Currency())
+	if err != nil {
+		return AccountLedgerView{}, err
+	}
+
+	return AccountLedgerView{Account: accountView, Entries: entries}, nil
+}
+
+// ReconciliationView compares an account with a statement. Matches is set
+// when the computed balance equals the statement balance.
+type ReconciliationView struct {
+	Account          AccountView
+	StatementDate    string
+	StatementBalance money.Money
+	ComputedBalance  money.Money
+	Difference       money.Money
+	Unreconciled     []AccountEntryView
+	Matches          bool
+	Reconciled       bool
+}
+
+func NewReconciliationView(resp *usecase.ReconciliationResponse, currency string) (ReconciliationView, error) {
+	accountView, err := NewAccountView(resp.Account, currency)
+	if err != nil {
+		return ReconciliationView{}, err
+	}
+	currency = accountView.Balance.Currency()
+
+	amounts := []int64{resp.StatementBalanceCents, resp.ComputedBalanceCents, resp.DifferenceCents}
+	values := make([]money.Money, len(amounts))
+	for i, cents := range amounts {
+		value, err := money.New(cents, currency)
+		if err != nil {
+			return ReconciliationView{}, err
+		}
+		values[i] = value
+	}
+
+	unreconciled, err := newAccountEntryViews(resp.Unreconciled, currency)
+	if err != nil {
+		return ReconciliationView{}, err
+	}
+
+	return ReconciliationView{
+		Account:          accountView,
+		StatementDate:    resp.StatementDate.Format(dateLayout),
+		StatementBalance: values[0],
+		ComputedBalance:  values[1],
+		Difference:       values[2],
+		Unreconciled:     unreconciled,
+		Matches:          resp.DifferenceCents == 0,
+		Reconciled:       resp.Reconciled,
+	}, nil
+}
+
+func newAccountEntryViews(entries []usecase.AccountEntryResponse, currency string) ([]AccountEntryView, error) {
+	views := make([]AccountEntryView, 0, len(entries))
+	for _, e := range entries {
+		amount, err := money.New(e.AmountCents, currency)
+		if err != nil {
+			return nil, err
+		}
+		balance, err := money.New(e.BalanceCents, currency)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, AccountEntryView{
+			ID:          e.ID,
+			Kind:        e.Kind,
+			Date:        e.Date.Format(dateLayout),
+			Description: e.Description,
+			Amount:      amount,
+			Balance:     balance,
+			Reconciled:  e.Reconciled,
+			IsTransfer:  e.Kind == "transfer_in" || e.Kind == "transfer_out",
+		})
+	}
+	return views, nil
+}
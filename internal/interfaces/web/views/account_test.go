@@ -0,0 +1,61 @@
+package views
+
+import (
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAccountsView(t *testing.T) {
+	view, err := NewAccountsView([]usecase.AccountResponse{
+		{ID: "checking", Name: "Checking", Type: "checking", Currency: "USD", OpeningBalanceCents: 10000, BalanceCents: 58000, UnreconciledCount: 2},
+		{ID: "visa", Name: "Visa", Type: "credit_card", Currency: "USD", OpeningBalanceCents: -25050, BalanceCents: -30000},
+	}, "USD")
+
+	require.NoError(t, err)
+	require.Len(t, view.Accounts, 2)
+	assert.Equal(t, int64(28000), view.Total.Cents())
+	assert.Equal(t, "Credit card", view.Accounts[1].Type.Label())
+	assert.Equal(t, "-250.50", view.Accounts[1].OpeningInput)
+	assert.Equal(t, 2, view.Accounts[0].UnreconciledCount)
+}
+
+func TestNewAccountLedgerView(t *testing.T) {
+	view, err := NewAccountLedgerView(&usecase.AccountLedgerResponse{
+		Account: usecase.AccountResponse{ID: "checking", Name: "Checking", Type: "checking", Currency: "USD", BalanceCents: 48000},
+		Entries: []usecase.AccountEntryResponse{
+			{Kind: "income", ID: "salary", Date: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), AmountCents: 50000, BalanceCents: 50000},
+			{Kind: "transfer_out", ID: "move", Date: time.Date(2024, time.March, 3, 0, 0, 0, 0, time.UTC), AmountCents: -2000, BalanceCents: 48000, Reconciled: true},
+		},
+	}, "EUR")
+
+	require.NoError(t, err)
+	require.Len(t, view.Entries, 2)
+	assert.Equal(t, "USD", view.Entries[0].Amount.Currency())
+	assert.False(t, view.Entries[0].IsOutflow())
+	assert.True(t, view.Entries[1].IsOutflow())
+	assert.True(t, view.Entries[1].IsTransfer)
+	assert.Equal(t, "2024-03-03", view.Entries[1].Date)
+}
+
+func TestNewReconciliationView(t *testing.T) {
+	view, err := NewReconciliationView(&usecase.ReconciliationResponse{
+		Account:               usecase.AccountResponse{ID: "checking", Name: "Checking", Type: "checking", Currency: "USD"},
+		StatementDate:         time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC),
+		StatementBalanceCents: 57000,
+		ComputedBalanceCents:  58000,
+		DifferenceCents:       -1000,
+		Unreconciled: []usecase.AccountEntryResponse{
+			{Kind: "expense", ID: "rent", AmountCents: -2000, BalanceCents: 58000},
+		},
+	}, "USD")
+
+	require.NoError(t, err)
+	assert.Equal(t, "2024-03-31", view.StatementDate)
+	assert.Equal(t, int64(-1000), view.Difference.Cents())
+	assert.False(t, view.Matches)
+	assert.Len(t, view.Unreconciled, 1)
+}
@@ -0,0 +1,102 @@
+package views
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+)
+
+// AlertCategoryView is a category with its thresholds written the way they
+// are entered, such as "80, 100". Error is why the thresholds last entered
+// for it were not saved.
+type AlertCategoryView struct {
+	ID            string
+	Name          string
+	IsSubcategory bool
+	Thresholds    string
+	Error         string
+}
+
+type AlertGroupView struct {
+	ID         string
+	Name       string
+	Categories []AlertCategoryView
+}
+
+func NewAlertGroupViews(groups []usecase.AlertGroupResponse) []AlertGroupView {
+	views := make([]AlertGroupView, 0, len(groups))
+	for _, g := range groups {
+		view := AlertGroupView{ID: g.ID, Name: g.Name}
+		for _, c := range g.Categories {
+			view.Categories = append(view.Categories, NewAlertCategoryView(c))
+		}
+		views = append(views, view)
+	}
+	return views
+}
+
+func NewAlertCategoryView(c usecase.AlertCategoryResponse) AlertCategoryView {
+	thresholds := make([]string, 0, len(c.Thresholds))
+	for _, t := range c.Thresholds {
+		thresholds = append(thresholds, strconv.Itoa(t))
+	}
+	return AlertCategoryView{
+		ID:            c.ID,
+		Name:          c.Name,
+		IsSubcategory: c.ParentID != "",
+		Thresholds:    strings.Join(thresholds, ", "),
+	}
+}
+
+// NotificationView is a budget alert. Link opens the dashboard of the month
+// at the category.
+type NotificationView struct {
+	ID      string
+	Title   string
+	Detail  string
+	Link    string
+	Created string
+	IsRead  bool
+}
+
+// NotificationsView is the notification center: the latest notifications
+// and how many of all of them are unread.
+type NotificationsView struct {
+	Unread        int
+	Notifications []NotificationView
+}
+
+// UnreadLabel is the badge on the bell, capped so it stays small.
+func (v NotificationsView) UnreadLabel() string {
+	if v.Unread > 9 {
+		return "9+"
+	}
+	return strconv.Itoa(v.Unread)
+}
+
+func NewNotificationsView(notifications []usecase.NotificationResponse, unread int, currency string) (NotificationsView, error) {
+	view := NotificationsView{Unread: unread}
+	for _, n := range notifications {
+		spent, err := money.New(n.SpentCents, currency)
+		if err != nil {
+			return NotificationsView{}, err
+		}
+		budget, err := money.New(n.BudgetCents, currency)
+		if err != nil {
+			return NotificationsView{}, err
+		}
+
+		view.Notifications = append(view.Notifications, NotificationView{
+			ID:      n.ID,
+			Title:   fmt.Sprintf("%s reached %d%% of its budget", n.CategoryName, n.Threshold),
+			Detail:  fmt.Sprintf("%s of %s in %s", spent.Display(), budget.Display(), monthLabel(n.Month)),
+			Link:    fmt.Sprintf("/home?month=%s#category-%s", n.Month, n.CategoryID),
+			Created: n.CreatedAt.Format("Jan 2, 15:04"),
+			IsRead:  n.IsRead,
+		})
+	}
+	return view, nil
+}
@@ -0,0 +1,49 @@
+package views
+
+import (
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAlertGroupViews(t *testing.T) {
+	views := NewAlertGroupViews([]usecase.AlertGroupResponse{
+		{ID: "home", Name: "Home", Categories: []usecase.AlertCategoryResponse{
+			{ID: "food", Name: "Food", Thresholds: []int{80, 100}},
+			{ID: "groceries", ParentID: "food", Name: "Groceries"},
+		}},
+	})
+
+	require.Len(t, views, 1)
+	assert.Equal(t, "80, 100", views[0].Categories[0].Thresholds)
+	assert.False(t, views[0].Categories[0].IsSubcategory)
+	assert.Equal(t, "", views[0].Categories[1].Thresholds)
+	assert.True(t, views[0].Categories[1].IsSubcategory)
+}
+
+func TestNewNotificationsView(t *testing.T) {
+	view, err := NewNotificationsView([]usecase.NotificationResponse{
+		{
+			ID:           "n1",
+			CategoryID:   "food",
+			CategoryName: "Food",
+			Month:        "2024-03",
+			Threshold:    80,
+			SpentCents:   9000,
+			BudgetCents:  10000,
+			CreatedAt:    time.Date(2024, time.March, 12, 9, 30, 0, 0, time.UTC),
+		},
+	}, 12, "USD")
+
+	require.NoError(t, err)
+	assert.Equal(t, "9+", view.UnreadLabel())
+	require.Len(t, view.Notifications, 1)
+	n := view.Notifications[0]
+	assert.Equal(t, "Food reached 80% of its budget", n.Title)
+	assert.Equal(t, "$ 90.00 of $ 100.00 in March 2024", n.Detail)
+	assert.Equal(t, "/home?month=2024-03#category-food", n.Link)
+	assert.Equal(t, "Mar 12, 09:30", n.Created)
+}
@@ -0,0 +1,64 @@
+package views
+
+import "github.com/madalinpopa/gocost-web/internal/usecase"
+
+type ArchivedGroupView struct {
+	ID            string
+	Name          string
+	Description   string
+	CategoryCount int
+}
+
+type ArchivedCategoryView struct {
+	ID          string
+	GroupID     string
+	GroupName   string
+	Name        string
+	Description string
+}
+
+type ArchiveView struct {
+	Groups     []ArchivedGroupView
+	Categories []ArchivedCategoryView
+}
+
+// NewArchiveView lists the archived groups and the archived categories of
+// groups that are still active. Categories of an archived group come back
+// together with their group, so they are not listed on their own.
+func NewArchiveView(groups []*usecase.GroupResponse) ArchiveView {
+	view := ArchiveView{
+		Groups:     make([]ArchivedGroupView, 0),
+		Categories: make([]ArchivedCategoryView, 0),
+	}
+
+	for _, g := range groups {
+		if g.Archived {
+			view.Groups = append(view.Groups, ArchivedGroupView{
+				ID:            g.ID,
+				Name:          g.Name,
+				Description:   g.Description,
+				CategoryCount: len(g.Categories),
+			})
+			continue
+		}
+
+		for _, c := range g.Categories {
+			if !c.Archived {
+				continue
+			}
+			view.Categories = append(view.Categories, ArchivedCategoryView{
+				ID:          c.ID,
+				GroupID:     g.ID,
+				GroupName:   g.Name,
+				Name:        c.Name,
+				Description: c.Description,
+			})
+		}
+	}
+
+	return view
+}
+
+func (v ArchiveView) IsEmpty() bool {
+	return len(v.Groups) == 0 && len(v.Categories) == 0
+}
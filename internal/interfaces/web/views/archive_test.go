@@ -0,0 +1,42 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewArchiveView(t *testing.T) {
+	groups := []*usecase.GroupResponse{
+		{
+			ID:   "g1",
+			Name: "Personal",
+			Categories: []usecase.CategoryResponse{
+				{ID: "c1", Name: "Food"},
+				{ID: "c2", Name: "Gym", Archived: true},
+			},
+		},
+		{
+			ID:       "g2",
+			Name:     "Old apartment",
+			Archived: true,
+			Categories: []usecase.CategoryResponse{
+				{ID: "c3", Name: "Rent"},
+				{ID: "c4", Name: "Utilities", Archived: true},
+			},
+		},
+	}
+
+	view := NewArchiveView(groups)
+
+	assert.Equal(t, []ArchivedGroupView{{ID: "g2", Name: "Old apartment", CategoryCount: 2}}, view.Groups)
+	assert.Equal(t, []ArchivedCategoryView{{ID: "c2", GroupID: "g1", GroupName: "Personal", Name: "Gym"}}, view.Categories)
+	assert.False(t, view.IsEmpty())
+}
+
+func TestNewArchiveView_Empty(t *testing.T) {
+	view := NewArchiveView([]*usecase.GroupResponse{{ID: "g1", Name: "Personal"}})
+
+	assert.True(t, view.IsEmpty())
+}
@@ -0,0 +1,83 @@
+package views
+
+import (
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+)
+
+type AssignableCategoryView struct {
+	GroupID        string
+	GroupName      string
+	CategoryID     string
+	Name           string
+	Budget         money.Money
+	LastMonthSpent money.Money
+}
+
+// CanFill reports whether filling the category from last month's spend would
+// change its budget.
+func (v AssignableCategoryView) CanFill() bool {
+	return v.LastMonthSpent.Cents() > 0 && v.LastMonthSpent.Cents() != v.Budget.Cents()
+}
+
+type BudgetAssignmentView struct {
+	Month          string
+	MonthLabel     string
+	Income         money.Money
+	Budgeted       money.Money
+	LeftToAssign   money.Money
+	OverAssigned   money.Money
+	IsOverAssigned bool
+	Categories     []AssignableCategoryView
+}
+
+func NewBudgetAssignmentView(assignment *usecase.BudgetAssignmentResponse, currency string) (BudgetAssignmentView, error) {
+	income, err := money.New(assignment.IncomeCents, currency)
+	if err != nil {
+		return BudgetAssignmentView{}, err
+	}
+	budgeted, err := money.New(assignment.BudgetedCents, currency)
+	if err != nil {
+		return BudgetAssignmentView{}, err
+	}
+	left, err := money.New(assignment.LeftCents, currency)
+	if err != nil {
+		return BudgetAssignmentView{}, err
+	}
+	over, err := money.New(max(-assignment.LeftCents, 0), currency)
+	if err != nil {
+		return BudgetAssignmentView{}, err
+	}
+
+	view := BudgetAssignmentView{
+		Month:          assignment.Month,
+		MonthLabel:     monthLabel(assignment.Month),
+		Income:         income,
+		Budgeted:       budgeted,
+		LeftToAssign:   left,
+		OverAssigned:   over,
+		IsOverAssigned: assignment.LeftCents < 0,
+		Categories:     make([]AssignableCategoryView, 0, len(assignment.Categories)),
+	}
+
+	for _, c := range assignment.Categories {
+		budget, err := money.New(c.BudgetCents, currency)
+		if err != nil {
+			return BudgetAssignmentView{}, err
+		}
+		spent, err := money.New(c.LastMonthSpentCents, currency)
+		if err != nil {
+			return BudgetAssignmentView{}, err
+		}
+		view.Categories = append(view.Categories, AssignableCategoryView{
+			GroupID:        c.GroupID,
+			GroupName:      c.GroupName,
+			CategoryID:     c.CategoryID,
+			Name:           c.Name,
+			Budget:         budget,
+			LastMonthSpent: spent,
+		})
+	}
+
+	return view, nil
+}
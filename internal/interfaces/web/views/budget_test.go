@@ -0,0 +1,47 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBudgetAssignmentView(t *testing.T) {
+	t.Run("income left to assign", func(t *testing.T) {
+		view, err := NewBudgetAssignmentView(&usecase.BudgetAssignmentResponse{
+			Month:         "2024-03",
+			IncomeCents:   200000,
+			BudgetedCents: 120000,
+			LeftCents:     80000,
+			Categories: []usecase.AssignableCategoryResponse{
+				{CategoryID: "food", Name: "Food", BudgetCents: 20000, LastMonthSpentCents: 30000},
+				{CategoryID: "rent", Name: "Rent", BudgetCents: 100000, LastMonthSpentCents: 100000},
+				{CategoryID: "gifts", Name: "Gifts", BudgetCents: 5000},
+			},
+		}, "USD")
+
+		require.NoError(t, err)
+		assert.Equal(t, "March 2024", view.MonthLabel)
+		assert.Equal(t, 800.0, view.LeftToAssign.Amount())
+		assert.False(t, view.IsOverAssigned)
+		require.Len(t, view.Categories, 3)
+		assert.True(t, view.Categories[0].CanFill())
+		assert.False(t, view.Categories[1].CanFill())
+		assert.False(t, view.Categories[2].CanFill())
+	})
+
+	t.Run("over-assigned", func(t *testing.T) {
+		view, err := NewBudgetAssignmentView(&usecase.BudgetAssignmentResponse{
+			Month:         "2024-03",
+			IncomeCents:   100000,
+			BudgetedCents: 125000,
+			LeftCents:     -25000,
+		}, "USD")
+
+		require.NoError(t, err)
+		assert.True(t, view.IsOverAssigned)
+		assert.Equal(t, 250.0, view.OverAssigned.Amount())
+	})
+}
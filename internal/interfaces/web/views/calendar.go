@@ -0,0 +1,168 @@
+package views
+
+import (
+	"sort"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+)
+
+// CalendarWeekdays are the column headings of the bill calendar. Weeks start
+// on Monday.
+var CalendarWeekdays = []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+type CalendarExpenseView struct {
+	ID           string
+	CategoryName string
+	Description  string
+	Amount       money.Money
+	IsPaid       bool
+}
+
+// Label names the expense by its description, or its category when it has
+// none.
+func (e CalendarExpenseView) Label() string {
+	if e.Description != "" {
+		return e.Description
+	}
+	return e.CategoryName
+}
+
+// CalendarDayView is a cell of the bill calendar. Days of the weeks around
+// the month fill the first and last rows and are not InMonth.
+type CalendarDayView struct {
+	Date     string
+	Day      int
+	InMonth  bool
+	IsToday  bool
+	Expenses []CalendarExpenseView
+	Total    money.Money
+}
+
+func (d CalendarDayView) HasExpenses() bool {
+	return len(d.Expenses) > 0
+}
+
+// HasUnpaid reports whether any expense of the day is still to be paid.
+func (d CalendarDayView) HasUnpaid() bool {
+	for _, e := range d.Expenses {
+		if !e.IsPaid {
+			return true
+		}
+	}
+	return false
+}
+
+// CalendarView places the expenses of a month on the day they were spent or
+// are due.
+type CalendarView struct {
+	Month      string
+	MonthLabel string
+	PrevMonth  string
+	NextMonth  string
+	Weeks      [][]CalendarDayView
+	Paid       money.Money
+	Unpaid     money.Money
+}
+
+// NewCalendarView lays out the month in weeks. Category names are looked up
+// by ID; today marks the current day when it falls in the month.
+func NewCalendarView(month time.Time, expenses []*usecase.ExpenseResponse, categoryNames map[string]string, currency string, today time.Time) (CalendarView, error) {
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	zero, err := money.New(0, currency)
+	if err != nil {
+		return CalendarView{}, err
+	}
+
+	byDay := make(map[int][]*usecase.ExpenseResponse)
+	var paidCents, unpaidCents int64
+	for _, e := range expenses {
+		if e.SpentAt.Year() != first.Year() || e.SpentAt.Month() != first.Month() {
+			continue
+		}
+		byDay[e.SpentAt.Day()] = append(byDay[e.SpentAt.Day()], e)
+		if e.IsPaid {
+			paidCents += e.AmountCents
+		} else {
+			unpaidCents += e.AmountCents
+		}
+	}
+
+	view := CalendarView{
+		Month:      first.Format("2006-01"),
+		MonthLabel: first.Format("January 2006"),
+		PrevMonth:  first.AddDate(0, -1, 0).Format("2006-01"),
+		NextMonth:  first.AddDate(0, 1, 0).Format("2006-01"),
+	}
+	if view.Paid, err = money.New(paidCents, currency); err != nil {
+		return CalendarView{}, err
+	}
+	if view.Unpaid, err = money.New(unpaidCents, currency); err != nil {
+		return CalendarView{}, err
+	}
+
+	// Go counts weekdays from Sunday; step back to the Monday on or before
+	// the first of the month.
+	offset := (int(first.Weekday()) + 6) % 7
+	day := first.AddDate(0, 0, -offset)
+	last := first.AddDate(0, 1, -1)
+	for !day.After(last) {
+		week := make([]CalendarDayView, 0, len(CalendarWeekdays))
+		for range CalendarWeekdays {
+			dayView := CalendarDayView{
+				Date:    day.Format("2006-01-02"),
+				Day:     day.Day(),
+				InMonth: day.Month() == first.Month(),
+				IsToday: day.Format("2006-01-02") == today.Format("2006-01-02"),
+				Total:   zero,
+			}
+			if dayView.InMonth {
+				dayView.Expenses, dayView.Total, err = newCalendarExpenseViews(byDay[day.Day()], categoryNames, currency)
+				if err != nil {
+					return CalendarView{}, err
+				}
+			}
+			week = append(week, dayView)
+			day = day.AddDate(0, 0, 1)
+		}
+		view.Weeks = append(view.Weeks, week)
+	}
+	return view, nil
+}
+
+// newCalendarExpenseViews lists the expenses of a day, unpaid first and then
+// the largest, with their total.
+func newCalendarExpenseViews(expenses []*usecase.ExpenseResponse, categoryNames map[string]string, currency string) ([]CalendarExpenseView, money.Money, error) {
+	sorted := make([]*usecase.ExpenseResponse, len(expenses))
+	copy(sorted, expenses)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].IsPaid != sorted[j].IsPaid {
+			return !sorted[i].IsPaid
+		}
+		return sorted[i].AmountCents > sorted[j].AmountCents
+	})
+
+	views := make([]CalendarExpenseView, 0, len(sorted))
+	var totalCents int64
+	for _, e := range sorted {
+		amount, err := money.New(e.AmountCents, currency)
+		if err != nil {
+			return nil, money.Money{}, err
+		}
+		views = append(views, CalendarExpenseView{
+			ID:           e.ID,
+			CategoryName: categoryNames[e.CategoryID],
+			Description:  e.Description,
+			Amount:       amount,
+			IsPaid:       e.IsPaid,
+		})
+		totalCents += e.AmountCents
+	}
+
+	total, err := money.New(totalCents, currency)
+	if err != nil {
+		return nil, money.Money{}, err
+	}
+	return views, total, nil
+}
@@ -0,0 +1,49 @@
+package views
+
+import (
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCalendarView(t *testing.T) {
+	month := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2024, time.February, d, 0, 0, 0, 0, time.UTC) }
+
+	view, err := NewCalendarView(month, []*usecase.ExpenseResponse{
+		{ID: "rent", CategoryID: "housing", AmountCents: 120000, SpentAt: day(1), IsPaid: true},
+		{ID: "power", CategoryID: "utilities", Description: "Electricity", AmountCents: 6000, SpentAt: day(15)},
+		{ID: "water", CategoryID: "utilities", AmountCents: 9000, SpentAt: day(15), IsPaid: true},
+		{ID: "march", CategoryID: "housing", AmountCents: 500, SpentAt: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)},
+	}, map[string]string{"housing": "Rent", "utilities": "Utilities"}, "USD", day(15))
+
+	require.NoError(t, err)
+	assert.Equal(t, "February 2024", view.MonthLabel)
+	assert.Equal(t, "2024-01", view.PrevMonth)
+	assert.Equal(t, "2024-03", view.NextMonth)
+	assert.Equal(t, int64(129000), view.Paid.Cents())
+	assert.Equal(t, int64(6000), view.Unpaid.Cents())
+
+	// February 2024 starts on a Thursday and ends on a Thursday.
+	require.Len(t, view.Weeks, 5)
+	first := view.Weeks[0]
+	assert.Equal(t, "2024-01-29", first[0].Date)
+	assert.False(t, first[0].InMonth)
+	assert.Equal(t, "2024-02-01", first[3].Date)
+	assert.Equal(t, "Rent", first[3].Expenses[0].Label())
+	assert.False(t, first[3].HasUnpaid())
+	assert.Equal(t, "2024-03-03", view.Weeks[4][6].Date)
+	assert.False(t, view.Weeks[4][4].HasExpenses())
+
+	mid := view.Weeks[2][3]
+	assert.Equal(t, 15, mid.Day)
+	assert.True(t, mid.IsToday)
+	assert.True(t, mid.HasUnpaid())
+	assert.Equal(t, int64(15000), mid.Total.Cents())
+	require.Len(t, mid.Expenses, 2)
+	assert.Equal(t, "Electricity", mid.Expenses[0].Label())
+	assert.Equal(t, "Utilities", mid.Expenses[1].Label())
+}
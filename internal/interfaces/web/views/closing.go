@@ -0,0 +1,40 @@
+package views
+
+import (
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+)
+
+type MonthStatusView struct {
+	Month       string
+	MonthLabel  string
+	IsClosed    bool
+	ClosedAt    string
+	UnpaidCount int
+	UnpaidTotal money.Money
+}
+
+// HasUnpaid reports whether closing the month needs the unpaid expenses to be
+// accepted first.
+func (v MonthStatusView) HasUnpaid() bool {
+	return v.UnpaidCount > 0
+}
+
+func NewMonthStatusView(status *usecase.MonthStatusResponse, currency string) (MonthStatusView, error) {
+	unpaid, err := money.New(status.UnpaidCents, currency)
+	if err != nil {
+		return MonthStatusView{}, err
+	}
+
+	view := MonthStatusView{
+		Month:       status.Month,
+		MonthLabel:  monthLabel(status.Month),
+		IsClosed:    status.Closed,
+		UnpaidCount: status.UnpaidCount,
+		UnpaidTotal: unpaid,
+	}
+	if status.Closed {
+		view.ClosedAt = status.ClosedAt.Format(dateLayout)
+	}
+	return view, nil
+}
@@ -0,0 +1,40 @@
+package views
+
+import (
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMonthStatusView(t *testing.T) {
+	t.Run("open month with unpaid expenses", func(t *testing.T) {
+		view, err := NewMonthStatusView(&usecase.MonthStatusResponse{
+			Month:       "2024-03",
+			UnpaidCount: 2,
+			UnpaidCents: 4550,
+		}, "USD")
+
+		require.NoError(t, err)
+		assert.Equal(t, "March 2024", view.MonthLabel)
+		assert.False(t, view.IsClosed)
+		assert.Empty(t, view.ClosedAt)
+		assert.True(t, view.HasUnpaid())
+		assert.Equal(t, int64(4550), view.UnpaidTotal.Cents())
+	})
+
+	t.Run("closed month", func(t *testing.T) {
+		view, err := NewMonthStatusView(&usecase.MonthStatusResponse{
+			Month:    "2024-03",
+			Closed:   true,
+			ClosedAt: time.Date(2024, time.April, 2, 10, 0, 0, 0, time.UTC),
+		}, "USD")
+
+		require.NoError(t, err)
+		assert.True(t, view.IsClosed)
+		assert.Equal(t, "2024-04-02", view.ClosedAt)
+		assert.False(t, view.HasUnpaid())
+	})
+}
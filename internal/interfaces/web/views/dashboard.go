@@ -34,20 +34,25 @@ type ExpenseView struct {
 	Status      ExpenseStatus
 	SpentAt     string
 	PaidAt      string
+	AccountID   string
 }
 
 type CategoryView struct {
 	ID               string
+	ParentID         string
 	Name             string
 	Type             CategoryType
 	Description      string
 	StartMonth       string	
 	EndMonth         string
 	Budget           money.Money
+	OwnBudget        money.Money
 	IsBudgetPositive bool
 	Spent            money.Money
 	Currency         string
+	Archived         bool
 	Expenses         []ExpenseView
+	Subcategories    []CategoryView
 
 	// Progress Bar Fields
 	PaidSpent        money.Money
@@ -66,6 +71,7 @@ type GroupView struct {
 	Name        string
 	Description string
 	Order       int
+	Archived    bool
 	Categories  []CategoryView
 }
 
@@ -77,9 +83,38 @@ type DashboardView struct {
 	IsTotalBudgetedNegative bool
 	Currency                string
 	Groups                  []GroupView
+	IsClosed                bool
+	ClosedAt                string
+	// Zero-based budgeting
+	ZeroBased    bool
+	LeftToAssign money.Money
+	OverAssigned money.Money
+	AssignStatus BudgetStatus
+	// Savings goals
+	Savings money.Money
+	Goals   []GoalView
+	// Forecast of the month in progress
+	Forecast *ForecastView
 	// Navigation
 	CurrentMonth      string
 	CurrentMonthParam string
 	NextMonth         string
 	PrevMonth         string
 }
+
+// ForecastView is what the month in progress is projected to end at.
+type ForecastView struct {
+	Progress          string
+	ProjectedExpenses money.Money
+	ProjectedBalance  money.Money
+	IsBalanceNegative bool
+	AtRisk            []AtRiskView
+}
+
+// AtRiskView is a category projected to end the month over its budget.
+type AtRiskView struct {
+	ID        string
+	Name      string
+	Budget    money.Money
+	Projected money.Money
+}
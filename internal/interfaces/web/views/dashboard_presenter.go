@@ -78,10 +78,20 @@ func (p *DashboardPresenter) Present(data *usecase.DashboardResponse) (Dashboard
 		return DashboardView{}, err
 	}
 
+	savings, err := p.moneyFromCents(data.SavingsCents)
+	if err != nil {
+		return DashboardView{}, err
+	}
+
+	// Money put aside for goals leaves the balance just like a payment.
 	displayBudget, err := totalBudgeted.Subtract(paidExpensesTotal)
 	if err != nil {
 		return DashboardView{}, err
 	}
+	displayBudget, err = displayBudget.Subtract(savings)
+	if err != nil {
+		return DashboardView{}, err
+	}
 
 	status := budgetStatus(totalBudgeted, totalIncome)
 	isTotalBudgetedNegative, _ := displayBudget.IsNegative()
@@ -90,73 +100,11 @@ func (p *DashboardPresenter) Present(data *usecase.DashboardResponse) (Dashboard
 	for _, grp := range data.Groups {
 		categoryViews := make([]CategoryView, 0, len(grp.Categories))
 		for _, cat := range grp.Categories {
-			catBudget, err := p.moneyFromCents(cat.BudgetCents)
-			if err != nil {
-				return DashboardView{}, err
-			}
-
-			spent, err := p.moneyFromCents(cat.SpentCents)
-			if err != nil {
-				return DashboardView{}, err
-			}
-
-			paidSpent, err := p.moneyFromCents(cat.PaidSpentCents)
-			if err != nil {
-				return DashboardView{}, err
-			}
-
-			unpaidSpent, err := spent.Subtract(paidSpent)
-			if err != nil {
-				return DashboardView{}, err
-			}
-
-			usagePercentage := budgetUsagePercentage(catBudget, spent)
-			paidPercentage, unpaidPercentage := budgetSplitPercentages(catBudget, paidSpent, unpaidSpent)
-
-			isOverBudget, _ := spent.GreaterThan(catBudget)
-			isNearBudget := !isOverBudget && usagePercentage > 85
-			budgetStatus := categoryBudgetStatus(catBudget, spent)
-
-			overBudgetAmount := p.zero
-			remainingBudget := p.zero
-			if isOverBudget {
-				overBudgetAmount, err = spent.Subtract(catBudget)
-			} else {
-				remainingBudget, err = catBudget.Subtract(spent)
-			}
+			categoryView, err := p.presentCategory(cat)
 			if err != nil {
 				return DashboardView{}, err
 			}
-
-			expenseViews, err := p.mapExpenseViews(cat.Expenses)
-			if err != nil {
-				return DashboardView{}, err
-			}
-
-			isBudgetPositive, _ := catBudget.IsPositive()
-
-			categoryViews = append(categoryViews, CategoryView{
-				ID:               cat.ID,
-				Name:             cat.Name,
-				Type:             categoryType(cat.IsRecurrent),
-				Description:      cat.Description,
-				StartMonth:       cat.StartMonth,
-				EndMonth:         cat.EndMonth,
-				Budget:           catBudget,
-				IsBudgetPositive: isBudgetPositive,
-				Spent:            spent,
-				Currency:         p.Currency,
-				Expenses:         expenseViews,
-				PaidSpent:        paidSpent,
-				UnpaidSpent:      unpaidSpent,
-				PaidPercentage:   paidPercentage,
-				UnpaidPercentage: unpaidPercentage,
-				BudgetStatus:     budgetStatus,
-				IsNearBudget:     isNearBudget,
-				IsOverBudget:     isOverBudget,
-				OverBudgetAmount: overBudgetAmount,
-				RemainingBudget:  remainingBudget,
-			})
+			categoryViews = append(categoryViews, categoryView)
 		}
 
 		groupViews = append(groupViews, GroupView{
@@ -164,11 +112,12 @@ func (p *DashboardPresenter) Present(data *usecase.DashboardResponse) (Dashboard
 			Name:        grp.Name,
 			Description: grp.Description,
 			Order:       grp.Order,
+			Archived:    grp.Archived,
 			Categories:  categoryViews,
 		})
 	}
 
-	return DashboardView{
+	view := DashboardView{
 		TotalIncome:             totalIncome,
 		TotalExpenses:           totalExpenses,
 		TotalBudgeted:           displayBudget,
@@ -176,6 +125,138 @@ func (p *DashboardPresenter) Present(data *usecase.DashboardResponse) (Dashboard
 		IsTotalBudgetedNegative: isTotalBudgetedNegative,
 		Currency:                p.Currency,
 		Groups:                  groupViews,
+		IsClosed:                data.Closed,
+	}
+	if data.Closed {
+		view.ClosedAt = data.ClosedAt.Format(dateLayout)
+	}
+
+	goals, err := NewGoalViews(data.Goals, p.Currency)
+	if err != nil {
+		return DashboardView{}, err
+	}
+	view.Savings = savings
+	view.Goals = goals
+
+	if data.Forecast != nil {
+		view.Forecast, err = p.presentForecast(data.Forecast)
+		if err != nil {
+			return DashboardView{}, err
+		}
+	}
+
+	// Left to assign compares income with the budgets themselves, not with
+	// what remains of them after payments. Savings are assigned income too.
+	assigned, err := totalBudgeted.Add(savings)
+	if err != nil {
+		return DashboardView{}, err
+	}
+	leftToAssign, err := totalIncome.Subtract(assigned)
+	if err != nil {
+		return DashboardView{}, err
+	}
+	assignStatus := budgetStatus(assigned, totalIncome)
+	view.ZeroBased = data.ZeroBased
+	view.LeftToAssign = leftToAssign
+	view.OverAssigned = p.zero
+	view.AssignStatus = assignStatus
+	if assignStatus == BudgetStatusOver {
+		view.OverAssigned, err = assigned.Subtract(totalIncome)
+		if err != nil {
+			return DashboardView{}, err
+		}
+	}
+
+	return view, nil
+}
+
+// presentCategory builds the view of a category and its subcategories. The
+// budget and spending figures already include the subcategories.
+func (p *DashboardPresenter) presentCategory(cat usecase.DashboardCategoryResponse) (CategoryView, error) {
+	catBudget, err := p.moneyFromCents(cat.BudgetCents)
+	if err != nil {
+		return CategoryView{}, err
+	}
+
+	ownBudget, err := p.moneyFromCents(cat.OwnBudgetCents)
+	if err != nil {
+		return CategoryView{}, err
+	}
+
+	spent, err := p.moneyFromCents(cat.SpentCents)
+	if err != nil {
+		return CategoryView{}, err
+	}
+
+	paidSpent, err := p.moneyFromCents(cat.PaidSpentCents)
+	if err != nil {
+		return CategoryView{}, err
+	}
+
+	unpaidSpent, err := spent.Subtract(paidSpent)
+	if err != nil {
+		return CategoryView{}, err
+	}
+
+	usagePercentage := budgetUsagePercentage(catBudget, spent)
+	paidPercentage, unpaidPercentage := budgetSplitPercentages(catBudget, paidSpent, unpaidSpent)
+
+	isOverBudget, _ := spent.GreaterThan(catBudget)
+	isNearBudget := !isOverBudget && usagePercentage > 85
+	budgetStatus := categoryBudgetStatus(catBudget, spent)
+
+	overBudgetAmount := p.zero
+	remainingBudget := p.zero
+	if isOverBudget {
+		overBudgetAmount, err = spent.Subtract(catBudget)
+	} else {
+		remainingBudget, err = catBudget.Subtract(spent)
+	}
+	if err != nil {
+		return CategoryView{}, err
+	}
+
+	expenseViews, err := p.mapExpenseViews(cat.Expenses)
+	if err != nil {
+		return CategoryView{}, err
+	}
+
+	subcategories := make([]CategoryView, 0, len(cat.Subcategories))
+	for _, sub := range cat.Subcategories {
+		subView, err := p.presentCategory(sub)
+		if err != nil {
+			return CategoryView{}, err
+		}
+		subcategories = append(subcategories, subView)
+	}
+
+	isBudgetPositive, _ := catBudget.IsPositive()
+
+	return CategoryView{
+		ID:               cat.ID,
+		ParentID:         cat.ParentID,
+		Name:             cat.Name,
+		Type:             categoryType(cat.IsRecurrent),
+		Description:      cat.Description,
+		StartMonth:       cat.StartMonth,
+		EndMonth:         cat.EndMonth,
+		Budget:           catBudget,
+		OwnBudget:        ownBudget,
+		IsBudgetPositive: isBudgetPositive,
+		Spent:            spent,
+		Currency:         p.Currency,
+		Archived:         cat.Archived,
+		Expenses:         expenseViews,
+		Subcategories:    subcategories,
+		PaidSpent:        paidSpent,
+		UnpaidSpent:      unpaidSpent,
+		PaidPercentage:   paidPercentage,
+		UnpaidPercentage: unpaidPercentage,
+		BudgetStatus:     budgetStatus,
+		IsNearBudget:     isNearBudget,
+		IsOverBudget:     isOverBudget,
+		OverBudgetAmount: overBudgetAmount,
+		RemainingBudget:  remainingBudget,
 	}, nil
 }
 
@@ -213,6 +294,7 @@ func (p *DashboardPresenter) mapExpenseViews(expenses []*usecase.ExpenseResponse
 			Status:      status,
 			SpentAt:     exp.SpentAt.Format(dateLayout),
 			PaidAt:      paidAt,
+			AccountID:   exp.AccountID,
 		})
 	}
 
@@ -264,6 +346,45 @@ func budgetSplitPercentages(budget, paidSpent, unpaidSpent money.Money) (float64
 	return paidPercentage, unpaidPercentage
 }
 
+func (p *DashboardPresenter) presentForecast(forecast *usecase.ForecastResponse) (*ForecastView, error) {
+	projectedExpenses, err := p.moneyFromCents(forecast.ProjectedExpensesCents)
+	if err != nil {
+		return nil, err
+	}
+
+	projectedBalance, err := p.moneyFromCents(forecast.ProjectedBalanceCents)
+	if err != nil {
+		return nil, err
+	}
+	isBalanceNegative, _ := projectedBalance.IsNegative()
+
+	atRisk := make([]AtRiskView, 0, len(forecast.AtRisk))
+	for _, category := range forecast.AtRisk {
+		budget, err := p.moneyFromCents(category.BudgetCents)
+		if err != nil {
+			return nil, err
+		}
+		projected, err := p.moneyFromCents(category.ProjectedCents)
+		if err != nil {
+			return nil, err
+		}
+		atRisk = append(atRisk, AtRiskView{
+			ID:        category.ID,
+			Name:      category.Name,
+			Budget:    budget,
+			Projected: projected,
+		})
+	}
+
+	return &ForecastView{
+		Progress:          fmt.Sprintf("Day %d of %d", forecast.DaysElapsed, forecast.DaysInMonth),
+		ProjectedExpenses: projectedExpenses,
+		ProjectedBalance:  projectedBalance,
+		IsBalanceNegative: isBalanceNegative,
+		AtRisk:            atRisk,
+	}, nil
+}
+
 func (p *DashboardPresenter) moneyFromCents(cents int64) (money.Money, error) {
 	return money.New(cents, p.Currency)
 }
@@ -2,6 +2,7 @@ package views
 
 import (
 	"testing"
+	"time"
 
 	"github.com/madalinpopa/gocost-web/internal/usecase"
 	"github.com/stretchr/testify/assert"
@@ -145,3 +146,161 @@ func TestDashboardPresenter_Present_TotalBudgetedSubtractsPaidExpensesOnly(t *te
 
 	assert.Equal(t, 170.0, view.TotalBudgeted.Amount())
 }
+
+func TestDashboardPresenter_Present_Subcategories(t *testing.T) {
+	presenter, err := NewDashboardPresenter("USD")
+	require.NoError(t, err)
+
+	data := &usecase.DashboardResponse{
+		TotalIncomeCents:   100000,
+		TotalBudgetedCents: 10000,
+		Groups: []usecase.DashboardGroupResponse{
+			{
+				ID: "g1",
+				Categories: []usecase.DashboardCategoryResponse{
+					{
+						ID:             "c1",
+						Name:           "Utilities",
+						StartMonth:     "2024-01",
+						BudgetCents:    10000,
+						SpentCents:     9000,
+						PaidSpentCents: 4000,
+						Subcategories: []usecase.DashboardCategoryResponse{
+							{
+								ID:             "c2",
+								ParentID:       "c1",
+								Name:           "Electricity",
+								StartMonth:     "2024-01",
+								BudgetCents:    4000,
+								OwnBudgetCents: 4000,
+								SpentCents:     5000,
+								PaidSpentCents: 4000,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	view, err := presenter.Present(data)
+	require.NoError(t, err)
+
+	parent := view.Groups[0].Categories[0]
+	assert.Equal(t, 100.0, parent.Budget.Amount())
+	assert.Equal(t, 0.0, parent.OwnBudget.Amount())
+	assert.Equal(t, BudgetStatusUnder, parent.BudgetStatus)
+	require.Len(t, parent.Subcategories, 1)
+
+	sub := parent.Subcategories[0]
+	assert.Equal(t, "c1", sub.ParentID)
+	assert.True(t, sub.IsOverBudget)
+	assert.Equal(t, 10.0, sub.OverBudgetAmount.Amount())
+	assert.Equal(t, 40.0, sub.OwnBudget.Amount())
+}
+
+func TestDashboardPresenter_Present_ClosedMonth(t *testing.T) {
+	presenter, err := NewDashboardPresenter("USD")
+	require.NoError(t, err)
+
+	view, err := presenter.Present(&usecase.DashboardResponse{
+		Closed:   true,
+		ClosedAt: time.Date(2024, time.April, 2, 10, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	assert.True(t, view.IsClosed)
+	assert.Equal(t, "2024-04-02", view.ClosedAt)
+
+	open, err := presenter.Present(&usecase.DashboardResponse{})
+	require.NoError(t, err)
+	assert.False(t, open.IsClosed)
+	assert.Empty(t, open.ClosedAt)
+}
+
+func TestDashboardPresenter_Present_LeftToAssign(t *testing.T) {
+	presenter, err := NewDashboardPresenter("USD")
+	require.NoError(t, err)
+
+	t.Run("under", func(t *testing.T) {
+		view, err := presenter.Present(&usecase.DashboardResponse{
+			TotalIncomeCents:   100000,
+			TotalBudgetedCents: 60000,
+			PaidExpensesCents:  20000,
+			ZeroBased:          true,
+		})
+		require.NoError(t, err)
+		assert.True(t, view.ZeroBased)
+		assert.Equal(t, 400.0, view.LeftToAssign.Amount())
+		assert.Equal(t, 0.0, view.OverAssigned.Amount())
+		assert.Equal(t, BudgetStatusUnder, view.AssignStatus)
+	})
+
+	t.Run("over", func(t *testing.T) {
+		view, err := presenter.Present(&usecase.DashboardResponse{
+			TotalIncomeCents:   100000,
+			TotalBudgetedCents: 125000,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, -250.0, view.LeftToAssign.Amount())
+		assert.Equal(t, 250.0, view.OverAssigned.Amount())
+		assert.Equal(t, BudgetStatusOver, view.AssignStatus)
+	})
+}
+
+func TestDashboardPresenter_Present_Savings(t *testing.T) {
+	presenter, err := NewDashboardPresenter("USD")
+	require.NoError(t, err)
+
+	view, err := presenter.Present(&usecase.DashboardResponse{
+		TotalIncomeCents:   100000,
+		TotalExpensesCents: 20000,
+		TotalBudgetedCents: 60000,
+		PaidExpensesCents:  20000,
+		SavingsCents:       50000,
+		Goals: []usecase.GoalResponse{
+			{ID: "goal", Name: "Car", TargetCents: 500000, SavedCents: 50000, Status: "on_track"},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 500.0, view.Savings.Amount())
+	assert.Equal(t, 200.0, view.TotalExpenses.Amount())
+	assert.Equal(t, -100.0, view.TotalBudgeted.Amount())
+	assert.Equal(t, -100.0, view.LeftToAssign.Amount())
+	assert.Equal(t, 100.0, view.OverAssigned.Amount())
+	assert.Equal(t, BudgetStatusOver, view.AssignStatus)
+	assert.Equal(t, BudgetStatusUnder, view.TotalBudgetedStatus)
+	require.Len(t, view.Goals, 1)
+	assert.Equal(t, GoalStatusOnTrack, view.Goals[0].Status)
+}
+
+func TestDashboardPresenter_Present_Forecast(t *testing.T) {
+	presenter, err := NewDashboardPresenter("USD")
+	require.NoError(t, err)
+
+	view, err := presenter.Present(&usecase.DashboardResponse{
+		TotalIncomeCents: 100000,
+		Forecast: &usecase.ForecastResponse{
+			DaysElapsed:            15,
+			DaysInMonth:            31,
+			ProjectedExpensesCents: 112000,
+			ProjectedBalanceCents:  -12000,
+			AtRisk: []usecase.AtRiskCategoryResponse{
+				{ID: "food", Name: "Food", BudgetCents: 30000, ProjectedCents: 42000},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, view.Forecast)
+	assert.Equal(t, "Day 15 of 31", view.Forecast.Progress)
+	assert.Equal(t, 1120.0, view.Forecast.ProjectedExpenses.Amount())
+	assert.Equal(t, -120.0, view.Forecast.ProjectedBalance.Amount())
+	assert.True(t, view.Forecast.IsBalanceNegative)
+	require.Len(t, view.Forecast.AtRisk, 1)
+	assert.Equal(t, 420.0, view.Forecast.AtRisk[0].Projected.Amount())
+
+	past, err := presenter.Present(&usecase.DashboardResponse{})
+	require.NoError(t, err)
+	assert.Nil(t, past.Forecast)
+}
@@ -0,0 +1,49 @@
+package views
+
+import (
+	"fmt"
+
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+)
+
+type DeletionImpactView struct {
+	ExpenseCount      int
+	LaterExpenseCount int
+	Summary           string
+	EndWarning        string
+	DeleteWarning     string
+}
+
+func NewDeletionImpactView(impact *usecase.DeletionImpactResponse) DeletionImpactView {
+	if impact == nil || impact.ExpenseCount == 0 {
+		return DeletionImpactView{Summary: "No expenses recorded"}
+	}
+
+	period := "in " + impact.FirstMonth
+	if impact.FirstMonth != impact.LastMonth {
+		period = fmt.Sprintf("from %s to %s", impact.FirstMonth, impact.LastMonth)
+	}
+
+	view := DeletionImpactView{
+		ExpenseCount:      impact.ExpenseCount,
+		LaterExpenseCount: impact.LaterExpenseCount,
+		Summary: fmt.Sprintf("%s across %s, %s",
+			pluralize(impact.ExpenseCount, "expense"),
+			pluralize(impact.MonthCount, "month"),
+			period,
+		),
+		DeleteWarning: fmt.Sprintf("%s will be permanently deleted.", pluralize(impact.ExpenseCount, "expense")),
+	}
+	if impact.LaterExpenseCount > 0 {
+		view.EndWarning = fmt.Sprintf("%s recorded from this month onwards will be deleted.", pluralize(impact.LaterExpenseCount, "expense"))
+	}
+
+	return view
+}
+
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,43 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDeletionImpactView_SummarisesPeriod(t *testing.T) {
+	view := NewDeletionImpactView(&usecase.DeletionImpactResponse{
+		ExpenseCount:      7,
+		MonthCount:        3,
+		FirstMonth:        "2024-01",
+		LastMonth:         "2024-04",
+		LaterExpenseCount: 2,
+	})
+
+	assert.Equal(t, 7, view.ExpenseCount)
+	assert.Equal(t, 2, view.LaterExpenseCount)
+	assert.Equal(t, "7 expenses across 3 months, from 2024-01 to 2024-04", view.Summary)
+	assert.Equal(t, "2 expenses recorded from this month onwards will be deleted.", view.EndWarning)
+	assert.Equal(t, "7 expenses will be permanently deleted.", view.DeleteWarning)
+}
+
+func TestNewDeletionImpactView_SingleMonth(t *testing.T) {
+	view := NewDeletionImpactView(&usecase.DeletionImpactResponse{
+		ExpenseCount: 1,
+		MonthCount:   1,
+		FirstMonth:   "2024-02",
+		LastMonth:    "2024-02",
+	})
+
+	assert.Equal(t, "1 expense across 1 month, in 2024-02", view.Summary)
+	assert.Empty(t, view.EndWarning)
+}
+
+func TestNewDeletionImpactView_NoExpenses(t *testing.T) {
+	view := NewDeletionImpactView(&usecase.DeletionImpactResponse{})
+
+	assert.Zero(t, view.ExpenseCount)
+	assert.Equal(t, "No expenses recorded", view.Summary)
+}
@@ -0,0 +1,141 @@
+package views
+
+import (
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+)
+
+type GoalStatus string
+
+const (
+	GoalStatusAchieved GoalStatus = "achieved"
+	GoalStatusOnTrack  GoalStatus = "on_track"
+	GoalStatusBehind   GoalStatus = "behind"
+	GoalStatusOverdue  GoalStatus = "overdue"
+)
+
+func (s GoalStatus) Label() string {
+	switch s {
+	case GoalStatusAchieved:
+		return "Achieved"
+	case GoalStatusOnTrack:
+		return "On track"
+	case GoalStatusBehind:
+		return "Behind"
+	case GoalStatusOverdue:
+		return "Overdue"
+	default:
+		return string(s)
+	}
+}
+
+type GoalContributionView struct {
+	ID         string
+	Month      string
+	MonthLabel string
+	Amount     money.Money
+}
+
+// GoalView describes a goal as of the end of the month on display.
+// Contributions holds only that month's contributions.
+type GoalView struct {
+	ID                string
+	Name              string
+	Target            money.Money
+	TargetDate        string
+	TargetMonthLabel  string
+	Saved             money.Money
+	Remaining         money.Money
+	RequiredMonthly   money.Money
+	MonthContribution money.Money
+	Percent           float64
+	Status            GoalStatus
+	Contributions     []GoalContributionView
+}
+
+// GoalsView is the goal manager for a month.
+type GoalsView struct {
+	Month      string
+	MonthLabel string
+	Currency   string
+	Goals      []GoalView
+}
+
+func NewGoalsView(month string, goals []usecase.GoalResponse, currency string) (GoalsView, error) {
+	goalViews, err := NewGoalViews(goals, currency)
+	if err != nil {
+		return GoalsView{}, err
+	}
+	return GoalsView{
+		Month:      month,
+		MonthLabel: monthLabel(month),
+		Currency:   currency,
+		Goals:      goalViews,
+	}, nil
+}
+
+func NewGoalViews(goals []usecase.GoalResponse, currency string) ([]GoalView, error) {
+	views := make([]GoalView, 0, len(goals))
+	for _, goal := range goals {
+		view, err := NewGoalView(goal, currency)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+func NewGoalView(goal usecase.GoalResponse, currency string) (GoalView, error) {
+	if goal.Currency != "" {
+		currency = goal.Currency
+	}
+
+	amounts := []int64{
+		goal.TargetCents,
+		goal.SavedCents,
+		goal.RemainingCents,
+		goal.RequiredMonthlyCents,
+		goal.MonthContributionCents,
+	}
+	values := make([]money.Money, len(amounts))
+	for i, cents := range amounts {
+		value, err := money.New(cents, currency)
+		if err != nil {
+			return GoalView{}, err
+		}
+		values[i] = value
+	}
+
+	view := GoalView{
+		ID:                goal.ID,
+		Name:              goal.Name,
+		Target:            values[0],
+		TargetDate:        goal.TargetDate.Format(dateLayout),
+		TargetMonthLabel:  goal.TargetDate.Format("January 2006"),
+		Saved:             values[1],
+		Remaining:         values[2],
+		RequiredMonthly:   values[3],
+		MonthContribution: values[4],
+		Percent:           goal.Percent,
+		Status:            GoalStatus(goal.Status),
+	}
+
+	for _, c := range goal.Contributions {
+		if c.Month != goal.Month {
+			continue
+		}
+		amount, err := money.New(c.AmountCents, currency)
+		if err != nil {
+			return GoalView{}, err
+		}
+		view.Contributions = append(view.Contributions, GoalContributionView{
+			ID:         c.ID,
+			Month:      c.Month,
+			MonthLabel: monthLabel(c.Month),
+			Amount:     amount,
+		})
+	}
+
+	return view, nil
+}
@@ -0,0 +1,55 @@
+package views
+
+import (
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGoalView(t *testing.T) {
+	view, err := NewGoalView(usecase.GoalResponse{
+		ID:                     "goal",
+		Name:                   "Holiday",
+		Currency:               "USD",
+		TargetCents:            120000,
+		TargetDate:             time.Date(2024, time.December, 15, 0, 0, 0, 0, time.UTC),
+		Month:                  "2024-03",
+		SavedCents:             15000,
+		RemainingCents:         105000,
+		RequiredMonthlyCents:   11250,
+		MonthContributionCents: 5000,
+		Percent:                12.5,
+		Status:                 "behind",
+		Contributions: []usecase.GoalContributionResponse{
+			{ID: "jan", Month: "2024-01", AmountCents: 10000},
+			{ID: "mar", Month: "2024-03", AmountCents: 5000},
+		},
+	}, "EUR")
+
+	require.NoError(t, err)
+	assert.Equal(t, "USD", view.Target.Currency())
+	assert.Equal(t, "2024-12-15", view.TargetDate)
+	assert.Equal(t, "December 2024", view.TargetMonthLabel)
+	assert.Equal(t, int64(11250), view.RequiredMonthly.Cents())
+	assert.Equal(t, int64(5000), view.MonthContribution.Cents())
+	assert.Equal(t, GoalStatusBehind, view.Status)
+	assert.Equal(t, "Behind", view.Status.Label())
+	require.Len(t, view.Contributions, 1)
+	assert.Equal(t, "mar", view.Contributions[0].ID)
+	assert.Equal(t, "March 2024", view.Contributions[0].MonthLabel)
+}
+
+func TestNewGoalsView(t *testing.T) {
+	view, err := NewGoalsView("2024-03", []usecase.GoalResponse{
+		{ID: "a", Name: "Car", Month: "2024-03", TargetCents: 100, Status: "achieved"},
+	}, "USD")
+
+	require.NoError(t, err)
+	assert.Equal(t, "March 2024", view.MonthLabel)
+	require.Len(t, view.Goals, 1)
+	assert.Equal(t, "USD", view.Goals[0].Target.Currency())
+	assert.Equal(t, "Achieved", view.Goals[0].Status.Label())
+}
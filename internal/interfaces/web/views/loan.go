@@ -0,0 +1,236 @@
+package views
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+)
+
+// LoanView describes a loan as of the end of Month. Paid is the part of the
+// principal already repaid.
+type LoanView struct {
+	ID               string
+	Name             string
+	Principal        money.Money
+	PrincipalInput   string
+	RatePercent      float64
+	RateInput        string
+	TermMonths       int
+	StartMonth       string
+	StartMonthLabel  string
+	CategoryID       string
+	Month            string
+	Payment          money.Money
+	Balance          money.Money
+	Paid             money.Money
+	TotalInterest    money.Money
+	PayoffMonth      string
+	PayoffMonthLabel string
+	Percent          float64
+}
+
+// IsPaidOff reports whether nothing is owed any more.
+func (l LoanView) IsPaidOff() bool {
+	return l.Balance.Cents() == 0
+}
+
+// LoansView lists the loans of the user. TotalBalance is what is still owed
+// on all of them.
+type LoansView struct {
+	Currency     string
+	Loans        []LoanView
+	TotalBalance money.Money
+}
+
+func NewLoansView(loans []usecase.LoanResponse, currency string) (LoansView, error) {
+	view := LoansView{Currency: currency}
+	var totalCents int64
+	for _, l := range loans {
+		loanView, err := NewLoanView(l, currency)
+		if err != nil {
+			return LoansView{}, err
+		}
+		view.Loans = append(view.Loans, loanView)
+		totalCents += l.BalanceCents
+	}
+
+	total, err := money.New(totalCents, currency)
+	if err != nil {
+		return LoansView{}, err
+	}
+	view.TotalBalance = total
+	return view, nil
+}
+
+func NewLoanView(l usecase.LoanResponse, currency string) (LoanView, error) {
+	if l.Currency != "" {
+		currency = l.Currency
+	}
+
+	amounts := []int64{
+		l.PrincipalCents,
+		l.PaymentCents,
+		l.BalanceCents,
+		l.PrincipalCents - l.BalanceCents,
+		l.TotalInterestCents,
+	}
+	values := make([]money.Money, len(amounts))
+	for i, cents := range amounts {
+		value, err := money.New(cents, currency)
+		if err != nil {
+			return LoanView{}, err
+		}
+		values[i] = value
+	}
+
+	var percent float64
+	if l.PrincipalCents > 0 {
+		percent = float64(l.PrincipalCents-l.BalanceCents) / float64(l.PrincipalCents) * 100
+	}
+
+	return LoanView{
+		ID:               l.ID,
+		Name:             l.Name,
+		Principal:        values[0],
+		PrincipalInput:   fmt.Sprintf("%.2f", values[0].Amount()),
+		RatePercent:      l.RatePercent,
+		RateInput:        strconv.FormatFloat(l.RatePercent, 'f', -1, 64),
+		TermMonths:       l.TermMonths,
+		StartMonth:       l.StartMonth,
+		StartMonthLabel:  monthLabel(l.StartMonth),
+		CategoryID:       l.CategoryID,
+		Month:            l.Month,
+		Payment:          values[1],
+		Balance:          values[2],
+		Paid:             values[3],
+		TotalInterest:    values[4],
+		PayoffMonth:      l.PayoffMonth,
+		PayoffMonthLabel: monthLabel(l.PayoffMonth),
+		Percent:          percent,
+	}, nil
+}
+
+// InstallmentView is a month of an amortization schedule. Recorded is what
+// was paid in the linked category that month, and IsDue is set for the
+// months up to the one on display.
+type InstallmentView struct {
+	Number     int
+	Month      string
+	MonthLabel string
+	Payment    money.Money
+	Principal  money.Money
+	Interest   money.Money
+	Extra      money.Money
+	Balance    money.Money
+	Recorded   money.Money
+	IsDue      bool
+}
+
+func (i InstallmentView) HasExtra() bool {
+	return i.Extra.Cents() > 0
+}
+
+// IsUnpaid reports whether a due installment has less recorded against it
+// than the payment.
+func (i InstallmentView) IsUnpaid() bool {
+	return i.IsDue && i.Recorded.Cents() < i.Payment.Cents()
+}
+
+type LoanScheduleView struct {
+	Loan         LoanView
+	Linked       bool
+	Installments []InstallmentView
+}
+
+func NewLoanScheduleView(schedule *usecase.LoanScheduleResponse, currency string) (LoanScheduleView, error) {
+	loanView, err := NewLoanView(schedule.Loan, currency)
+	if err != nil {
+		return LoanScheduleView{}, err
+	}
+
+	installments, err := newInstallmentViews(schedule.Installments, loanView.Month, loanView.Principal.Currency())
+	if err != nil {
+		return LoanScheduleView{}, err
+	}
+
+	return LoanScheduleView{
+		Loan:         loanView,
+		Linked:       loanView.CategoryID != "",
+		Installments: installments,
+	}, nil
+}
+
+// LoanSimulationView compares the loan with extra payments against the loan
+// as it stands.
+type LoanSimulationView struct {
+	Loan             LoanView
+	PayoffMonth      string
+	PayoffMonthLabel string
+	TotalInterest    money.Money
+	InterestSaved    money.Money
+	MonthsSaved      int
+	Installments     []InstallmentView
+}
+
+func NewLoanSimulationView(simulation *usecase.LoanSimulationResponse, currency string) (LoanSimulationView, error) {
+	loanView, err := NewLoanView(simulation.Loan, currency)
+	if err != nil {
+		return LoanSimulationView{}, err
+	}
+	currency = loanView.Principal.Currency()
+
+	totalInterest, err := money.New(simulation.TotalInterestCents, currency)
+	if err != nil {
+		return LoanSimulationView{}, err
+	}
+	interestSaved, err := money.New(simulation.InterestSavedCents, currency)
+	if err != nil {
+		return LoanSimulationView{}, err
+	}
+
+	installments, err := newInstallmentViews(simulation.Installments, loanView.Month, currency)
+	if err != nil {
+		return LoanSimulationView{}, err
+	}
+
+	return LoanSimulationView{
+		Loan:             loanView,
+		PayoffMonth:      simulation.PayoffMonth,
+		PayoffMonthLabel: monthLabel(simulation.PayoffMonth),
+		TotalInterest:    totalInterest,
+		InterestSaved:    interestSaved,
+		MonthsSaved:      simulation.MonthsSaved,
+		Installments:     installments,
+	}, nil
+}
+
+func newInstallmentViews(installments []usecase.InstallmentResponse, month string, currency string) ([]InstallmentView, error) {
+	views := make([]InstallmentView, 0, len(installments))
+	for _, i := range installments {
+		amounts := []int64{i.PaymentCents, i.PrincipalCents, i.InterestCents, i.ExtraCents, i.BalanceCents, i.RecordedCents}
+		values := make([]money.Money, len(amounts))
+		for n, cents := range amounts {
+			value, err := money.New(cents, currency)
+			if err != nil {
+				return nil, err
+			}
+			values[n] = value
+		}
+
+		views = append(views, InstallmentView{
+			Number:     i.Number,
+			Month:      i.Month,
+			MonthLabel: monthLabel(i.Month),
+			Payment:    values[0],
+			Principal:  values[1],
+			Interest:   values[2],
+			Extra:      values[3],
+			Balance:    values[4],
+			Recorded:   values[5],
+			IsDue:      i.Month <= month,
+		})
+	}
+	return views, nil
+}
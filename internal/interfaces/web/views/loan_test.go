@@ -0,0 +1,64 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLoansView(t *testing.T) {
+	view, err := NewLoansView([]usecase.LoanResponse{
+		{ID: "car", Name: "Car", Currency: "USD", PrincipalCents: 2000000, RatePercent: 4.9, TermMonths: 60, StartMonth: "2024-01", Month: "2024-03", BalanceCents: 1500000, PayoffMonth: "2028-12"},
+		{ID: "laptop", Name: "Laptop", Currency: "USD", PrincipalCents: 120000, TermMonths: 12, StartMonth: "2023-01", Month: "2024-03", PayoffMonth: "2023-12"},
+	}, "USD")
+
+	require.NoError(t, err)
+	require.Len(t, view.Loans, 2)
+	assert.Equal(t, int64(1500000), view.TotalBalance.Cents())
+
+	car := view.Loans[0]
+	assert.Equal(t, "20000.00", car.PrincipalInput)
+	assert.Equal(t, "4.9", car.RateInput)
+	assert.Equal(t, int64(500000), car.Paid.Cents())
+	assert.Equal(t, 25.0, car.Percent)
+	assert.Equal(t, "December 2028", car.PayoffMonthLabel)
+	assert.False(t, car.IsPaidOff())
+	assert.True(t, view.Loans[1].IsPaidOff())
+}
+
+func TestNewLoanScheduleView(t *testing.T) {
+	view, err := NewLoanScheduleView(&usecase.LoanScheduleResponse{
+		Loan: usecase.LoanResponse{ID: "car", Currency: "USD", PrincipalCents: 30000, Month: "2024-02", CategoryID: "c"},
+		Installments: []usecase.InstallmentResponse{
+			{Number: 1, Month: "2024-01", PaymentCents: 10000, RecordedCents: 10000, BalanceCents: 20000},
+			{Number: 2, Month: "2024-02", PaymentCents: 10000, BalanceCents: 10000},
+			{Number: 3, Month: "2024-03", PaymentCents: 10000, ExtraCents: 500},
+		},
+	}, "EUR")
+
+	require.NoError(t, err)
+	assert.True(t, view.Linked)
+	require.Len(t, view.Installments, 3)
+	assert.Equal(t, "USD", view.Installments[0].Payment.Currency())
+	assert.False(t, view.Installments[0].IsUnpaid())
+	assert.True(t, view.Installments[1].IsUnpaid())
+	assert.False(t, view.Installments[2].IsDue)
+	assert.True(t, view.Installments[2].HasExtra())
+}
+
+func TestNewLoanSimulationView(t *testing.T) {
+	view, err := NewLoanSimulationView(&usecase.LoanSimulationResponse{
+		Loan:               usecase.LoanResponse{ID: "car", Currency: "USD", PrincipalCents: 2000000, PayoffMonth: "2028-12"},
+		PayoffMonth:        "2027-11",
+		TotalInterestCents: 202541,
+		InterestSavedCents: 62011,
+		MonthsSaved:        13,
+	}, "USD")
+
+	require.NoError(t, err)
+	assert.Equal(t, "November 2027", view.PayoffMonthLabel)
+	assert.Equal(t, int64(62011), view.InterestSaved.Cents())
+	assert.Equal(t, 13, view.MonthsSaved)
+}
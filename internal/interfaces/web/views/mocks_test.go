@@ -2,6 +2,7 @@ package views
 
 import (
 	"context"
+	"time"
 
 	"github.com/alexedwards/scs/v2"
 	"github.com/stretchr/testify/mock"
@@ -57,3 +58,91 @@ func (m *mockAuthSessionManager) SetUsername(ctx context.Context, username strin
 func (m *mockAuthSessionManager) SetCurrency(ctx context.Context, currency string) {
 	m.Called(ctx, currency)
 }
+
+func (m *mockAuthSessionManager) IsEmailVerified(ctx context.Context) bool {
+	args := m.Called(ctx)
+	return args.Bool(0)
+}
+
+func (m *mockAuthSessionManager) SetEmailVerified(ctx context.Context, verified bool) {
+	m.Called(ctx, verified)
+}
+
+func (m *mockAuthSessionManager) GetPendingUserID(ctx context.Context) string {
+	args := m.Called(ctx)
+	return args.String(0)
+}
+
+func (m *mockAuthSessionManager) SetPendingUserID(ctx context.Context, userID string) {
+	m.Called(ctx, userID)
+}
+
+func (m *mockAuthSessionManager) SetPasskeyChallenge(ctx context.Context, challenge []byte) {
+	m.Called(ctx, challenge)
+}
+
+func (m *mockAuthSessionManager) PopPasskeyChallenge(ctx context.Context) []byte {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).([]byte)
+}
+
+func (m *mockAuthSessionManager) SetRememberMe(ctx context.Context, remember bool) {
+	m.Called(ctx, remember)
+}
+
+func (m *mockAuthSessionManager) KeepAlive(ctx context.Context) {
+	m.Called(ctx)
+}
+
+func (m *mockAuthSessionManager) ConfirmIdentity(ctx context.Context) {
+	m.Called(ctx)
+}
+
+func (m *mockAuthSessionManager) GetIdentityConfirmedAt(ctx context.Context) time.Time {
+	args := m.Called(ctx)
+	return args.Get(0).(time.Time)
+}
+
+func (m *mockAuthSessionManager) DestroyOtherSessions(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *mockAuthSessionManager) DestroyUserSessions(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *mockAuthSessionManager) GetSessionID(ctx context.Context) string {
+	args := m.Called(ctx)
+	return args.String(0)
+}
+
+func (m *mockAuthSessionManager) SetSessionID(ctx context.Context, id string) {
+	m.Called(ctx, id)
+}
+
+func (m *mockAuthSessionManager) GetLastSeenAt(ctx context.Context) time.Time {
+	args := m.Called(ctx)
+	return args.Get(0).(time.Time)
+}
+
+func (m *mockAuthSessionManager) SetLastSeenAt(ctx context.Context, at time.Time) {
+	m.Called(ctx, at)
+}
+
+func (m *mockAuthSessionManager) ActiveSessionIDs(ctx context.Context, userID string) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *mockAuthSessionManager) DestroySession(ctx context.Context, userID string, id string) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
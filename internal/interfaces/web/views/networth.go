@@ -0,0 +1,303 @@
+package views
+
+import (
+	"fmt"
+
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+)
+
+type AssetKind string
+
+const (
+	AssetKindInvestment AssetKind = "investment"
+	AssetKindProperty   AssetKind = "property"
+	AssetKindVehicle    AssetKind = "vehicle"
+	AssetKindOther      AssetKind = "other"
+	AssetKindDebt       AssetKind = "debt"
+)
+
+// AssetKinds lists the kinds of asset entered by hand in the order they are
+// offered.
+var AssetKinds = []AssetKind{AssetKindInvestment, AssetKindProperty, AssetKindVehicle, AssetKindOther, AssetKindDebt}
+
+func (k AssetKind) Label() string {
+	switch k {
+	case AssetKindInvestment:
+		return "Investment"
+	case AssetKindProperty:
+		return "Property"
+	case AssetKindVehicle:
+		return "Vehicle"
+	case AssetKindOther:
+		return "Other asset"
+	case AssetKindDebt:
+		return "Debt"
+	default:
+		return string(k)
+	}
+}
+
+// The net worth chart is drawn in a fixed viewBox and scaled by the browser.
+// The bottom of the box is left for the month labels.
+const (
+	chartWidth       = 720.0
+	chartHeight      = 240.0
+	chartLabelHeight = 20.0
+)
+
+// NetWorthBar is the bar of a month in the net worth chart, in viewBox
+// coordinates.
+type NetWorthBar struct {
+	Label      string
+	Title      string
+	X          float64
+	Y          float64
+	Width      float64
+	Height     float64
+	LabelX     float64
+	IsNegative bool
+}
+
+// NetWorthChart draws the net worth of every month as a bar above the zero
+// line, or below it when more is owed than owned.
+type NetWorthChart struct {
+	Width  float64
+	Height float64
+	LabelY float64
+	ZeroY  float64
+	Bars   []NetWorthBar
+}
+
+func (c NetWorthChart) ViewBox() string {
+	return fmt.Sprintf("0 0 %.0f %.0f", c.Width, c.Height)
+}
+
+// NetWorthPointView is the net worth at the end of a month.
+type NetWorthPointView struct {
+	Month       string
+	MonthLabel  string
+	Assets      money.Money
+	Liabilities money.Money
+	NetWorth    money.Money
+}
+
+func (p NetWorthPointView) IsNegative() bool {
+	isNegative, _ := p.NetWorth.IsNegative()
+	return isNegative
+}
+
+type ValuationView struct {
+	Month      string
+	MonthLabel string
+	Value      money.Money
+}
+
+// NetWorthItemView is something owned or owed as of the last month of the
+// range. Accounts and loans are tracked elsewhere and only shown; assets
+// entered by hand carry their recorded values.
+type NetWorthItemView struct {
+	ID               string
+	Name             string
+	Source           string
+	Kind             string
+	KindLabel        string
+	Liability        bool
+	Value            money.Money
+	Valued           bool
+	ValuedMonthLabel string
+	Valuations       []ValuationView
+}
+
+func (i NetWorthItemView) IsManual() bool {
+	return i.Source == usecase.NetWorthSourceAsset
+}
+
+// Link is the page where an item tracked elsewhere is managed.
+func (i NetWorthItemView) Link() string {
+	switch i.Source {
+	case usecase.NetWorthSourceAccount:
+		return "/accounts/" + i.ID
+	case usecase.NetWorthSourceLoan:
+		return "/loans/" + i.ID
+	default:
+		return ""
+	}
+}
+
+// NetWorthView is the net worth over the months of the range. Latest is the
+// last month, which the assets and liabilities are listed as of.
+type NetWorthView struct {
+	Currency    string
+	FromMonth   string
+	ToMonth     string
+	Points      []NetWorthPointView
+	Latest      NetWorthPointView
+	Chart       NetWorthChart
+	Assets      []NetWorthItemView
+	Liabilities []NetWorthItemView
+}
+
+// HasManualAssets reports whether any asset or debt was entered by hand.
+func (v NetWorthView) HasManualAssets() bool {
+	for _, items := range [][]NetWorthItemView{v.Assets, v.Liabilities} {
+		for _, item := range items {
+			if item.IsManual() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func NewNetWorthView(resp *usecase.NetWorthResponse, currency string) (NetWorthView, error) {
+	view := NetWorthView{
+		Currency:  currency,
+		FromMonth: resp.FromMonth,
+		ToMonth:   resp.ToMonth,
+	}
+
+	for _, m := range resp.Months {
+		point, err := newNetWorthPointView(m, currency)
+		if err != nil {
+			return NetWorthView{}, err
+		}
+		view.Points = append(view.Points, point)
+	}
+	if len(view.Points) > 0 {
+		view.Latest = view.Points[len(view.Points)-1]
+	}
+	view.Chart = newNetWorthChart(resp.Months, view.Points)
+
+	for _, item := range resp.Items {
+		itemView, err := newNetWorthItemView(item, currency)
+		if err != nil {
+			return NetWorthView{}, err
+		}
+		if item.Liability {
+			view.Liabilities = append(view.Liabilities, itemView)
+		} else {
+			view.Assets = append(view.Assets, itemView)
+		}
+	}
+	return view, nil
+}
+
+func newNetWorthPointView(m usecase.NetWorthPointResponse, currency string) (NetWorthPointView, error) {
+	amounts := []int64{m.AssetsCents, m.LiabilitiesCents, m.NetWorthCents}
+	values := make([]money.Money, len(amounts))
+	for i, cents := range amounts {
+		value, err := money.New(cents, currency)
+		if err != nil {
+			return NetWorthPointView{}, err
+		}
+		values[i] = value
+	}
+
+	return NetWorthPointView{
+		Month:       m.Month,
+		MonthLabel:  monthLabel(m.Month),
+		Assets:      values[0],
+		Liabilities: values[1],
+		NetWorth:    values[2],
+	}, nil
+}
+
+// newNetWorthChart scales the bars so the highest net worth and the lowest
+// one below zero fit the chart, with the zero line in between.
+func newNetWorthChart(months []usecase.NetWorthPointResponse, points []NetWorthPointView) NetWorthChart {
+	chart := NetWorthChart{
+		Width:  chartWidth,
+		Height: chartHeight,
+		LabelY: chartHeight - 6,
+	}
+
+	var top, bottom int64
+	for _, m := range months {
+		top = max(top, m.NetWorthCents)
+		bottom = min(bottom, m.NetWorthCents)
+	}
+	span := float64(top - bottom)
+	if span == 0 {
+		span = 1
+	}
+
+	plotHeight := chartHeight - chartLabelHeight
+	chart.ZeroY = float64(top) / span * plotHeight
+	if top == 0 && bottom == 0 {
+		chart.ZeroY = plotHeight
+	}
+
+	if len(months) == 0 {
+		return chart
+	}
+	slot := chartWidth / float64(len(months))
+	for i, m := range months {
+		height := float64(m.NetWorthCents) / span * plotHeight
+		bar := NetWorthBar{
+			Label:  points[i].MonthLabel[:3],
+			Title:  points[i].MonthLabel + ": " + points[i].NetWorth.Display(),
+			X:      float64(i)*slot + slot*0.2,
+			Y:      chart.ZeroY - height,
+			Width:  slot * 0.6,
+			Height: height,
+			LabelX: float64(i)*slot + slot/2,
+		}
+		if m.NetWorthCents < 0 {
+			bar.Y = chart.ZeroY
+			bar.Height = -height
+			bar.IsNegative = true
+		}
+		chart.Bars = append(chart.Bars, bar)
+	}
+	return chart
+}
+
+func newNetWorthItemView(item usecase.NetWorthItemResponse, currency string) (NetWorthItemView, error) {
+	value, err := money.New(item.ValueCents, currency)
+	if err != nil {
+		return NetWorthItemView{}, err
+	}
+	if item.Liability && item.Source == usecase.NetWorthSourceAccount {
+		// An overdrawn account is listed with what is owed on it.
+		value, err = money.New(-item.ValueCents, currency)
+		if err != nil {
+			return NetWorthItemView{}, err
+		}
+	}
+
+	view := NetWorthItemView{
+		ID:               item.ID,
+		Name:             item.Name,
+		Source:           item.Source,
+		Kind:             item.Kind,
+		KindLabel:        netWorthKindLabel(item.Source, item.Kind),
+		Liability:        item.Liability,
+		Value:            value,
+		Valued:           item.Valued,
+		ValuedMonthLabel: monthLabel(item.ValuedMonth),
+	}
+	for _, v := range item.Valuations {
+		value, err := money.New(v.ValueCents, currency)
+		if err != nil {
+			return NetWorthItemView{}, err
+		}
+		view.Valuations = append(view.Valuations, ValuationView{
+			Month:      v.Month,
+			MonthLabel: monthLabel(v.Month),
+			Value:      value,
+		})
+	}
+	return view, nil
+}
+
+func netWorthKindLabel(source string, kind string) string {
+	switch source {
+	case usecase.NetWorthSourceAccount:
+		return AccountType(kind).Label()
+	case usecase.NetWorthSourceLoan:
+		return "Loan"
+	default:
+		return AssetKind(kind).Label()
+	}
+}
@@ -0,0 +1,82 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNetWorthView(t *testing.T) {
+	view, err := NewNetWorthView(&usecase.NetWorthResponse{
+		FromMonth: "2024-01",
+		ToMonth:   "2024-02",
+		Months: []usecase.NetWorthPointResponse{
+			{Month: "2024-01", AssetsCents: 10000, LiabilitiesCents: 30000, NetWorthCents: -20000},
+			{Month: "2024-02", AssetsCents: 70000, LiabilitiesCents: 10000, NetWorthCents: 60000},
+		},
+		Items: []usecase.NetWorthItemResponse{
+			{ID: "checking", Name: "Checking", Source: usecase.NetWorthSourceAccount, Kind: "checking", ValueCents: 70000, Valued: true},
+			{ID: "card", Name: "Card", Source: usecase.NetWorthSourceAccount, Kind: "credit_card", Liability: true, ValueCents: -10000, Valued: true},
+			{ID: "house", Name: "House", Source: usecase.NetWorthSourceAsset, Kind: "property", Valuations: []usecase.AssetValuationResponse{
+				{Month: "2024-03", ValueCents: 500000},
+			}},
+			{ID: "car", Name: "Car", Source: usecase.NetWorthSourceLoan, Kind: usecase.NetWorthSourceLoan, Liability: true},
+		},
+	}, "USD")
+
+	require.NoError(t, err)
+	assert.Equal(t, "February 2024", view.Latest.MonthLabel)
+	assert.Equal(t, int64(60000), view.Latest.NetWorth.Cents())
+	assert.True(t, view.Points[0].IsNegative())
+
+	require.Len(t, view.Assets, 2)
+	require.Len(t, view.Liabilities, 2)
+	assert.Equal(t, "/accounts/checking", view.Assets[0].Link())
+	assert.Equal(t, "Property", view.Assets[1].KindLabel)
+	assert.True(t, view.Assets[1].IsManual())
+	assert.Equal(t, "March 2024", view.Assets[1].Valuations[0].MonthLabel)
+	assert.Equal(t, int64(10000), view.Liabilities[0].Value.Cents())
+	assert.Equal(t, "Credit card", view.Liabilities[0].KindLabel)
+	assert.Equal(t, "Loan", view.Liabilities[1].KindLabel)
+	assert.True(t, view.HasManualAssets())
+}
+
+func TestNewNetWorthChart(t *testing.T) {
+	view, err := NewNetWorthView(&usecase.NetWorthResponse{
+		Months: []usecase.NetWorthPointResponse{
+			{Month: "2024-01", NetWorthCents: -20000},
+			{Month: "2024-02", NetWorthCents: 60000},
+		},
+	}, "USD")
+
+	require.NoError(t, err)
+	chart := view.Chart
+	require.Len(t, chart.Bars, 2)
+	assert.Equal(t, "0 0 720 240", chart.ViewBox())
+	assert.InDelta(t, 165.0, chart.ZeroY, 0.001)
+
+	negative := chart.Bars[0]
+	assert.True(t, negative.IsNegative)
+	assert.Equal(t, "Jan", negative.Label)
+	assert.InDelta(t, 72.0, negative.X, 0.001)
+	assert.InDelta(t, chart.ZeroY, negative.Y, 0.001)
+	assert.InDelta(t, 55.0, negative.Height, 0.001)
+
+	positive := chart.Bars[1]
+	assert.False(t, positive.IsNegative)
+	assert.InDelta(t, 0.0, positive.Y, 0.001)
+	assert.InDelta(t, 165.0, positive.Height, 0.001)
+	assert.InDelta(t, 540.0, positive.LabelX, 0.001)
+}
+
+func TestNewNetWorthChart_NothingRecorded(t *testing.T) {
+	view, err := NewNetWorthView(&usecase.NetWorthResponse{
+		Months: []usecase.NetWorthPointResponse{{Month: "2024-01"}},
+	}, "USD")
+
+	require.NoError(t, err)
+	assert.InDelta(t, 220.0, view.Chart.ZeroY, 0.001)
+	assert.InDelta(t, 0.0, view.Chart.Bars[0].Height, 0.001)
+}
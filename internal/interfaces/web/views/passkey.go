@@ -0,0 +1,29 @@
+package views
+
+import "github.com/madalinpopa/gocost-web/internal/usecase"
+
+type PasskeyView struct {
+	ID       string
+	Name     string
+	Created  string
+	LastUsed string
+}
+
+// NewPasskeyViews lists the passkeys of the user. A passkey never used to
+// log in shows "Never".
+func NewPasskeyViews(passkeys []usecase.PasskeyResponse) []PasskeyView {
+	views := make([]PasskeyView, 0, len(passkeys))
+	for _, p := range passkeys {
+		view := PasskeyView{
+			ID:       p.ID,
+			Name:     p.Name,
+			Created:  p.CreatedAt.Format(dateLayout),
+			LastUsed: "Never",
+		}
+		if p.LastUsedAt != nil {
+			view.LastUsed = p.LastUsedAt.Format(dateLayout)
+		}
+		views = append(views, view)
+	}
+	return views
+}
@@ -0,0 +1,24 @@
+package views
+
+import (
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPasskeyViews(t *testing.T) {
+	usedAt := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	passkeys := []usecase.PasskeyResponse{
+		{ID: "pk-1", Name: "Laptop", CreatedAt: time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC), LastUsedAt: &usedAt},
+		{ID: "pk-2", Name: "Phone", CreatedAt: time.Date(2026, 2, 3, 9, 0, 0, 0, time.UTC)},
+	}
+
+	views := NewPasskeyViews(passkeys)
+
+	assert.Equal(t, []PasskeyView{
+		{ID: "pk-1", Name: "Laptop", Created: "2026-01-02", LastUsed: "2026-03-04"},
+		{ID: "pk-2", Name: "Phone", Created: "2026-02-03", LastUsed: "Never"},
+	}, views)
+}
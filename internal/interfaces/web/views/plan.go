@@ -0,0 +1,96 @@
+package views
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+)
+
+type PlanItemView struct {
+	CategoryID  string
+	Name        string
+	ParentName  string
+	Type        CategoryType
+	Budget      money.Money
+	BudgetValue string
+	Conflict    bool
+}
+
+type PlanGroupView struct {
+	ID    string
+	Name  string
+	Items []PlanItemView
+}
+
+type PlanView struct {
+	FromMonth      string
+	ToMonth        string
+	FromMonthLabel string
+	ToMonthLabel   string
+	Groups         []PlanGroupView
+}
+
+func NewPlanView(preview *usecase.PlanPreviewResponse, currency string) (PlanView, error) {
+	view := PlanView{
+		FromMonth:      preview.FromMonth,
+		ToMonth:        preview.ToMonth,
+		FromMonthLabel: monthLabel(preview.FromMonth),
+		ToMonthLabel:   monthLabel(preview.ToMonth),
+		Groups:         make([]PlanGroupView, 0, len(preview.Groups)),
+	}
+
+	for _, g := range preview.Groups {
+		items := make([]PlanItemView, 0, len(g.Items))
+		for _, item := range g.Items {
+			budget, err := money.New(item.BudgetCents, currency)
+			if err != nil {
+				return PlanView{}, err
+			}
+			items = append(items, PlanItemView{
+				CategoryID:  item.CategoryID,
+				Name:        item.Name,
+				ParentName:  item.ParentName,
+				Type:        categoryType(item.IsRecurrent),
+				Budget:      budget,
+				BudgetValue: fmt.Sprintf("%.2f", budget.Amount()),
+				Conflict:    item.Conflict,
+			})
+		}
+		view.Groups = append(view.Groups, PlanGroupView{ID: g.ID, Name: g.Name, Items: items})
+	}
+
+	return view, nil
+}
+
+func (v PlanView) IsEmpty() bool {
+	return len(v.Groups) == 0
+}
+
+// PlanResultView summarises what the wizard did to the next month.
+type PlanResultView struct {
+	ToMonth      string
+	ToMonthLabel string
+	Created      int
+	Updated      int
+	Skipped      []string
+}
+
+func NewPlanResultView(resp *usecase.PlanMonthResponse) PlanResultView {
+	return PlanResultView{
+		ToMonth:      resp.ToMonth,
+		ToMonthLabel: monthLabel(resp.ToMonth),
+		Created:      resp.Created,
+		Updated:      resp.Updated,
+		Skipped:      resp.Skipped,
+	}
+}
+
+func monthLabel(month string) string {
+	t, err := time.Parse("2006-01", month)
+	if err != nil {
+		return month
+	}
+	return t.Format("January 2006")
+}
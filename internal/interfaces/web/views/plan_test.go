@@ -0,0 +1,34 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPlanView(t *testing.T) {
+	preview := &usecase.PlanPreviewResponse{
+		FromMonth: "2024-12",
+		ToMonth:   "2025-01",
+		Groups: []usecase.PlanGroupResponse{
+			{ID: "g1", Name: "Home", Items: []usecase.PlanItemResponse{
+				{CategoryID: "c1", Name: "Rent", IsRecurrent: true, BudgetCents: 120050},
+				{CategoryID: "c2", Name: "Gifts", BudgetCents: 5000, Conflict: true},
+			}},
+		},
+	}
+
+	view, err := NewPlanView(preview, "USD")
+
+	require.NoError(t, err)
+	assert.Equal(t, "December 2024", view.FromMonthLabel)
+	assert.Equal(t, "January 2025", view.ToMonthLabel)
+	assert.False(t, view.IsEmpty())
+	items := view.Groups[0].Items
+	assert.Equal(t, TypeRecurrent, items[0].Type)
+	assert.Equal(t, "1200.50", items[0].BudgetValue)
+	assert.Equal(t, TypeMonthly, items[1].Type)
+	assert.True(t, items[1].Conflict)
+}
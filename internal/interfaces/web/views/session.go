@@ -0,0 +1,37 @@
+package views
+
+import "github.com/madalinpopa/gocost-web/internal/usecase"
+
+// dateTimeLayout shows when a session started and was last used.
+const dateTimeLayout = "2006-01-02 15:04"
+
+type SessionView struct {
+	ID        string
+	Device    string
+	IPAddress string
+	Started   string
+	LastSeen  string
+	Current   bool
+}
+
+// NewSessionViews lists the active sessions of the user, the session of
+// this browser first.
+func NewSessionViews(sessions []usecase.UserSessionResponse, currentID string) []SessionView {
+	views := make([]SessionView, 0, len(sessions))
+	for _, s := range sessions {
+		view := SessionView{
+			ID:        s.ID,
+			Device:    s.Device,
+			IPAddress: s.IPAddress,
+			Started:   s.CreatedAt.Format(dateTimeLayout),
+			LastSeen:  s.LastSeenAt.Format(dateTimeLayout),
+			Current:   s.ID == currentID,
+		}
+		if view.Current {
+			views = append([]SessionView{view}, views...)
+			continue
+		}
+		views = append(views, view)
+	}
+	return views
+}
@@ -0,0 +1,35 @@
+package views
+
+import (
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSessionViews(t *testing.T) {
+	sessions := []usecase.UserSessionResponse{
+		{
+			ID:         "session-1",
+			Device:     "Safari on iOS",
+			IPAddress:  "198.51.100.2",
+			CreatedAt:  time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC),
+			LastSeenAt: time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			ID:         "session-2",
+			Device:     "Firefox on Windows",
+			IPAddress:  "203.0.113.7",
+			CreatedAt:  time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC),
+			LastSeenAt: time.Date(2026, 3, 3, 18, 5, 0, 0, time.UTC),
+		},
+	}
+
+	views := NewSessionViews(sessions, "session-2")
+
+	assert.Equal(t, []SessionView{
+		{ID: "session-2", Device: "Firefox on Windows", IPAddress: "203.0.113.7", Started: "2026-03-02 09:00", LastSeen: "2026-03-03 18:05", Current: true},
+		{ID: "session-1", Device: "Safari on iOS", IPAddress: "198.51.100.2", Started: "2026-03-01 08:00", LastSeen: "2026-03-04 10:30"},
+	}, views)
+}
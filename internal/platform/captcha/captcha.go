@@ -0,0 +1,129 @@
+// Package captcha keeps bots from public forms with proof-of-work challenges
+// solved by the browser, without calling out to a CAPTCHA service.
+package captcha
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/platform/security"
+)
+
+// purpose keeps tokens signed for challenges apart from the other tokens of
+// the signer.
+const purpose = "captcha"
+
+// challengeTTL is how long a challenge can be solved and submitted.
+const challengeTTL = 15 * time.Minute
+
+// saltBytes is the randomness of each challenge.
+const saltBytes = 16
+
+var (
+	ErrInvalidChallenge = errors.New("captcha challenge is invalid or has expired")
+	ErrUnsolved         = errors.New("captcha challenge is not solved")
+	ErrReplayed         = errors.New("captcha challenge was already used")
+)
+
+// Challenge asks for a nonce that makes the SHA-256 hash of the token
+// followed by the nonce start with Difficulty zero bits.
+type Challenge struct {
+	Token      string
+	Difficulty int
+}
+
+// Captcha issues challenges signed so that they cannot be made easier, and
+// accepts each solution once.
+type Captcha struct {
+	signer     security.Signer
+	difficulty int
+	now        func() time.Time
+
+	mu   sync.Mutex
+	used map[string]time.Time
+}
+
+func New(signer security.Signer, difficulty int) *Captcha {
+	return &Captcha{
+		signer:     signer,
+		difficulty: difficulty,
+		now:        time.Now,
+		used:       make(map[string]time.Time),
+	}
+}
+
+// Issue returns a new challenge at the configured difficulty.
+func (c *Captcha) Issue() (Challenge, error) {
+	salt := make([]byte, saltBytes)
+	if _, err := rand.Read(salt); err != nil {
+		return Challenge{}, fmt.Errorf("failed to generate captcha challenge: %w", err)
+	}
+
+	value := base64.RawURLEncoding.EncodeToString(salt) + ":" + strconv.Itoa(c.difficulty)
+	return Challenge{
+		Token:      c.signer.Sign(purpose, value, c.now().Add(challengeTTL)),
+		Difficulty: c.difficulty,
+	}, nil
+}
+
+// Verify checks that nonce solves the challenge of token, which then cannot
+// be used again.
+func (c *Captcha) Verify(token, nonce string) error {
+	now := c.now()
+
+	value, err := c.signer.Verify(purpose, token, now)
+	if err != nil {
+		return ErrInvalidChallenge
+	}
+
+	_, difficulty, ok := strings.Cut(value, ":")
+	if !ok {
+		return ErrInvalidChallenge
+	}
+	want, err := strconv.Atoi(difficulty)
+	if err != nil {
+		return ErrInvalidChallenge
+	}
+
+	if nonce == "" || LeadingZeroBits(token, nonce) < want {
+		return ErrUnsolved
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for t, expiresAt := range c.used {
+		if !now.Before(expiresAt) {
+			delete(c.used, t)
+		}
+	}
+	if _, ok := c.used[token]; ok {
+		return ErrReplayed
+	}
+	c.used[token] = now.Add(challengeTTL)
+
+	return nil
+}
+
+// LeadingZeroBits counts the zero bits the SHA-256 hash of token followed by
+// nonce starts with.
+func LeadingZeroBits(token, nonce string) int {
+	sum := sha256.Sum256([]byte(token + nonce))
+
+	n := 0
+	for _, b := range sum {
+		if b != 0 {
+			return n + bits.LeadingZeros8(b)
+		}
+		n += 8
+	}
+	return n
+}
@@ -0,0 +1,79 @@
+package captcha
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/platform/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// solve finds the nonce of a challenge the way the browser does.
+func solve(c Challenge) string {
+	for n := 0; ; n++ {
+		nonce := strconv.Itoa(n)
+		if LeadingZeroBits(c.Token, nonce) >= c.Difficulty {
+			return nonce
+		}
+	}
+}
+
+func TestCaptcha_Verify(t *testing.T) {
+	newCaptcha := func(difficulty int) *Captcha {
+		return New(security.NewSigner([]byte("test-key")), difficulty)
+	}
+
+	t.Run("accepts a solved challenge once", func(t *testing.T) {
+		c := newCaptcha(8)
+		challenge, err := c.Issue()
+		require.NoError(t, err)
+		assert.Equal(t, 8, challenge.Difficulty)
+
+		nonce := solve(challenge)
+		assert.NoError(t, c.Verify(challenge.Token, nonce))
+		assert.ErrorIs(t, c.Verify(challenge.Token, nonce), ErrReplayed)
+	})
+
+	t.Run("rejects a wrong nonce", func(t *testing.T) {
+		c := newCaptcha(16)
+		challenge, err := c.Issue()
+		require.NoError(t, err)
+
+		nonce := solve(challenge)
+		for n := 0; ; n++ {
+			wrong := strconv.Itoa(n)
+			if LeadingZeroBits(challenge.Token, wrong) < 16 {
+				assert.ErrorIs(t, c.Verify(challenge.Token, wrong), ErrUnsolved)
+				break
+			}
+		}
+		assert.ErrorIs(t, c.Verify(challenge.Token, ""), ErrUnsolved)
+		assert.NoError(t, c.Verify(challenge.Token, nonce))
+	})
+
+	t.Run("rejects an expired challenge", func(t *testing.T) {
+		c := newCaptcha(4)
+		challenge, err := c.Issue()
+		require.NoError(t, err)
+
+		c.now = func() time.Time { return time.Now().Add(challengeTTL) }
+		assert.ErrorIs(t, c.Verify(challenge.Token, solve(challenge)), ErrInvalidChallenge)
+	})
+
+	t.Run("rejects a challenge made easier", func(t *testing.T) {
+		c := newCaptcha(20)
+		easier := security.NewSigner([]byte("other-key")).Sign(purpose, "c2FsdA:0", time.Now().Add(time.Minute))
+
+		assert.ErrorIs(t, c.Verify(easier, "0"), ErrInvalidChallenge)
+		assert.ErrorIs(t, c.Verify("not-a-token", "0"), ErrInvalidChallenge)
+	})
+}
+
+func TestLeadingZeroBits(t *testing.T) {
+	// The SHA-256 hash of "abc" starts with 0xba.
+	assert.Equal(t, 0, LeadingZeroBits("ab", "c"))
+	// The SHA-256 hash of "abc252" starts with 0x00 0xe6.
+	assert.Equal(t, 8, LeadingZeroBits("abc", "252"))
+}
@@ -2,6 +2,7 @@ package money
 
 import (
 	"errors"
+	"math"
 
 	"github.com/Rhymond/go-money"
 )
@@ -147,6 +148,16 @@ func (m Money) Multiply(factor int64) Money {
 	return Money{m: m.m.Multiply(factor)}
 }
 
+// AdjustByPercent returns the amount changed by the given percentage, rounded
+// to the nearest cent. A negative percentage lowers the amount.
+func (m Money) AdjustByPercent(percent float64) Money {
+	if m.m == nil {
+		return Money{}
+	}
+	delta := int64(math.Round(float64(m.m.Amount()) * percent / 100))
+	return Money{m: money.New(m.m.Amount()+delta, m.m.Currency().Code)}
+}
+
 func (m Money) GreaterThan(other Money) (bool, error) {
 	if m.m == nil || other.m == nil {
 		return false, errors.New("uninitialized money")
@@ -121,6 +121,14 @@ func TestMoney_Operations(t *testing.T) {
 		assert.True(t, isNegative)
 	})
 
+	t.Run("AdjustByPercent rounds to the nearest cent", func(t *testing.T) {
+		m, _ := money.New(10005, "USD")
+		assert.Equal(t, int64(11006), m.AdjustByPercent(10).Cents())
+		assert.Equal(t, int64(9505), m.AdjustByPercent(-5).Cents())
+		assert.Equal(t, int64(10005), m.AdjustByPercent(0).Cents())
+		assert.Equal(t, "USD", m.AdjustByPercent(10).Currency())
+	})
+
 	t.Run("Display formatting", func(t *testing.T) {
 		m, _ := money.New(12345, "USD")
 		assert.Equal(t, "$ 123.45", m.Display())
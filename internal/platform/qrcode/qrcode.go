@@ -0,0 +1,37 @@
+// Package qrcode draws QR codes as SVG, so pages can show them without
+// loading anything from elsewhere.
+package qrcode
+
+import (
+	"fmt"
+	"strings"
+
+	"rsc.io/qr"
+)
+
+// quietZone is the blank border, in modules, scanners need around the code.
+const quietZone = 4
+
+// SVG returns an SVG image of the QR code of content. It scales to the size
+// of its container.
+func SVG(content string) (string, error) {
+	code, err := qr.Encode(content, qr.M)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode qr code: %w", err)
+	}
+
+	size := code.Size + 2*quietZone
+	var path strings.Builder
+	for y := range code.Size {
+		for x := range code.Size {
+			if code.Black(x, y) {
+				fmt.Fprintf(&path, "M%d %dh1v1h-1z", x+quietZone, y+quietZone)
+			}
+		}
+	}
+
+	return fmt.Sprintf(
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges" role="img" aria-label="QR code"><rect width="%d" height="%d" fill="#fff"/><path d="%s" fill="#000"/></svg>`,
+		size, size, size, size, path.String(),
+	), nil
+}
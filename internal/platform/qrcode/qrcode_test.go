@@ -0,0 +1,19 @@
+package qrcode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSVG(t *testing.T) {
+	svg, err := SVG("otpauth://totp/GoCost:alice@example.com?secret=GEZDGNBVGY3TQOJQ&issuer=GoCost")
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 `))
+	assert.True(t, strings.HasSuffix(svg, "</svg>"))
+	// The top left finder pattern starts inside the quiet zone.
+	assert.Contains(t, svg, `d="M4 4h1v1h-1z`)
+}
@@ -0,0 +1,116 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often entries no longer needed are removed.
+const sweepInterval = time.Minute
+
+// bucket holds the allowance of a key as the time it is full again.
+type bucket struct {
+	fullAt time.Time
+}
+
+type failures struct {
+	count     int
+	last      time.Time
+	expiresAt time.Time
+}
+
+// MemoryStore keeps the limits in memory. They are lost when the process
+// stops and not shared between processes.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]bucket
+	failures  map[string]failures
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets:  make(map[string]bucket),
+		failures: make(map[string]failures),
+	}
+}
+
+// Take keeps each bucket as the time it is full again: every request moves it
+// an interval later, and requests are allowed as long as it stays within the
+// window from now.
+func (s *MemoryStore) Take(_ context.Context, key string, policy Policy, now time.Time) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(now)
+
+	fullAt := now
+	if b, ok := s.buckets[key]; ok && b.fullAt.After(now) {
+		fullAt = b.fullAt
+	}
+
+	fullAt = fullAt.Add(policy.interval())
+	if wait := fullAt.Sub(now) - policy.Window; wait > 0 {
+		return wait, nil
+	}
+
+	s.buckets[key] = bucket{fullAt: fullAt}
+	return 0, nil
+}
+
+func (s *MemoryStore) AddFailure(_ context.Context, key string, now time.Time, ttl time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(now)
+
+	f := s.failures[key]
+	if !now.Before(f.expiresAt) {
+		f = failures{}
+	}
+	f.count++
+	f.last = now
+	f.expiresAt = now.Add(ttl)
+	s.failures[key] = f
+
+	return f.count, nil
+}
+
+func (s *MemoryStore) Failures(_ context.Context, key string, now time.Time) (int, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.failures[key]
+	if !ok || !now.Before(f.expiresAt) {
+		return 0, time.Time{}, nil
+	}
+	return f.count, f.last, nil
+}
+
+func (s *MemoryStore) ClearFailures(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.failures, key)
+	return nil
+}
+
+// sweep removes the full buckets and the forgotten failures, at most every
+// sweepInterval, so that the store does not grow with every client it has
+// seen.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if !b.fullAt.After(now) {
+			delete(s.buckets, key)
+		}
+	}
+	for key, f := range s.failures {
+		if !now.Before(f.expiresAt) {
+			delete(s.failures, key)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_Take(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := Policy{Requests: 2, Window: time.Minute}
+
+	t.Run("refills evenly over the window", func(t *testing.T) {
+		s := NewMemoryStore()
+
+		for i := 0; i < 2; i++ {
+			wait, err := s.Take(ctx, "key", policy, now)
+			require.NoError(t, err)
+			assert.Zero(t, wait)
+		}
+
+		wait, err := s.Take(ctx, "key", policy, now.Add(10*time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, 20*time.Second, wait)
+
+		wait, err = s.Take(ctx, "key", policy, now.Add(30*time.Second))
+		require.NoError(t, err)
+		assert.Zero(t, wait)
+	})
+
+	t.Run("removes full buckets", func(t *testing.T) {
+		s := NewMemoryStore()
+
+		_, err := s.Take(ctx, "key", policy, now)
+		require.NoError(t, err)
+		require.Len(t, s.buckets, 1)
+
+		_, err = s.Take(ctx, "other", policy, now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Len(t, s.buckets, 1)
+		assert.Contains(t, s.buckets, "other")
+	})
+}
+
+func TestMemoryStore_Failures(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("counts failures until they expire", func(t *testing.T) {
+		s := NewMemoryStore()
+
+		count, err := s.AddFailure(ctx, "key", now, time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		count, err = s.AddFailure(ctx, "key", now.Add(time.Minute), time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		count, last, err := s.Failures(ctx, "key", now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.Equal(t, now.Add(time.Minute), last)
+
+		count, _, err = s.Failures(ctx, "key", now.Add(2*time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, count)
+
+		count, err = s.AddFailure(ctx, "key", now.Add(2*time.Hour), time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("clears failures", func(t *testing.T) {
+		s := NewMemoryStore()
+
+		_, err := s.AddFailure(ctx, "key", now, time.Hour)
+		require.NoError(t, err)
+		require.NoError(t, s.ClearFailures(ctx, "key"))
+
+		count, _, err := s.Failures(ctx, "key", now)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
@@ -0,0 +1,144 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// failureTTL is how long failed attempts are remembered after the last one.
+const failureTTL = 24 * time.Hour
+
+var ErrInvalidPolicy = errors.New("rate limit policy must look like 10/1m")
+
+// Policy allows Requests in each Window, in bursts of up to Requests. The
+// allowance comes back evenly over the window rather than all at once.
+type Policy struct {
+	Requests int
+	Window   time.Duration
+}
+
+// ParsePolicy reads a policy written as requests/window, such as 10/1m.
+func ParsePolicy(value string) (Policy, error) {
+	requests, window, ok := strings.Cut(strings.TrimSpace(value), "/")
+	if !ok {
+		return Policy{}, ErrInvalidPolicy
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(requests))
+	if err != nil || n <= 0 {
+		return Policy{}, ErrInvalidPolicy
+	}
+
+	d, err := time.ParseDuration(strings.TrimSpace(window))
+	if err != nil || d <= 0 {
+		return Policy{}, ErrInvalidPolicy
+	}
+
+	return Policy{Requests: n, Window: d}, nil
+}
+
+func (p Policy) String() string {
+	return fmt.Sprintf("%d/%s", p.Requests, p.Window)
+}
+
+// interval is how long it takes for one request to be allowed again.
+func (p Policy) interval() time.Duration {
+	return p.Window / time.Duration(p.Requests)
+}
+
+// Lockout locks a key out after Threshold failed attempts in a row, for
+// Duration, doubled at each further failure up to MaxDuration. A zero
+// Threshold never locks out.
+type Lockout struct {
+	Threshold   int
+	Duration    time.Duration
+	MaxDuration time.Duration
+}
+
+// For returns how long a key is locked out after the given number of failed
+// attempts in a row.
+func (l Lockout) For(failures int) time.Duration {
+	if l.Threshold <= 0 || failures < l.Threshold {
+		return 0
+	}
+
+	d := l.Duration
+	for i := l.Threshold; i < failures && d < l.MaxDuration; i++ {
+		d *= 2
+	}
+	if l.MaxDuration > 0 && d > l.MaxDuration {
+		d = l.MaxDuration
+	}
+	return d
+}
+
+// Store keeps the state of the limits. MemoryStore keeps it in the memory of
+// the process; other stores can share it between several instances.
+type Store interface {
+	// Take takes one request from the allowance of key. It returns zero when
+	// the request is allowed, or how long until the next one is.
+	Take(ctx context.Context, key string, policy Policy, now time.Time) (time.Duration, error)
+	// AddFailure records a failed attempt of key and returns how many
+	// followed each other. They are forgotten ttl after the last one.
+	AddFailure(ctx context.Context, key string, now time.Time, ttl time.Duration) (int, error)
+	// Failures returns how many failed attempts of key followed each other,
+	// and when the last one was.
+	Failures(ctx context.Context, key string, now time.Time) (int, time.Time, error)
+	// ClearFailures forgets the failed attempts of key.
+	ClearFailures(ctx context.Context, key string) error
+}
+
+// Limiter limits how often keys, such as IP addresses or accounts, can make
+// requests, and locks out keys after repeated failed attempts.
+type Limiter struct {
+	store   Store
+	lockout Lockout
+	now     func() time.Time
+}
+
+func New(store Store, lockout Lockout) *Limiter {
+	return &Limiter{
+		store:   store,
+		lockout: lockout,
+		now:     time.Now,
+	}
+}
+
+// Allow returns zero when key can make a request under policy, or how long it
+// has to wait.
+func (l *Limiter) Allow(ctx context.Context, key string, policy Policy) (time.Duration, error) {
+	return l.store.Take(ctx, key, policy, l.now())
+}
+
+// LockedFor returns how long key is still locked out, or zero.
+func (l *Limiter) LockedFor(ctx context.Context, key string) (time.Duration, error) {
+	failures, last, err := l.store.Failures(ctx, key, l.now())
+	if err != nil {
+		return 0, err
+	}
+
+	wait := last.Add(l.lockout.For(failures)).Sub(l.now())
+	if wait < 0 {
+		return 0, nil
+	}
+	return wait, nil
+}
+
+// Fail records a failed attempt of key and returns how long it is locked out
+// because of it, or zero.
+func (l *Limiter) Fail(ctx context.Context, key string) (time.Duration, error) {
+	failures, err := l.store.AddFailure(ctx, key, l.now(), failureTTL)
+	if err != nil {
+		return 0, err
+	}
+	return l.lockout.For(failures), nil
+}
+
+// Succeed forgets the failed attempts of key.
+func (l *Limiter) Succeed(ctx context.Context, key string) error {
+	return l.store.ClearFailures(ctx, key)
+}
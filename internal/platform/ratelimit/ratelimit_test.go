@@ -0,0 +1,118 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    Policy
+		wantErr bool
+	}{
+		{name: "requests per minute", value: "10/1m", want: Policy{Requests: 10, Window: time.Minute}},
+		{name: "with spaces", value: " 5 / 1h ", want: Policy{Requests: 5, Window: time.Hour}},
+		{name: "missing window", value: "10", wantErr: true},
+		{name: "zero requests", value: "0/1m", wantErr: true},
+		{name: "invalid window", value: "10/soon", wantErr: true},
+		{name: "zero window", value: "10/0s", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePolicy(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPolicy)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLockout_For(t *testing.T) {
+	lockout := Lockout{Threshold: 3, Duration: time.Minute, MaxDuration: 5 * time.Minute}
+
+	assert.Zero(t, lockout.For(2))
+	assert.Equal(t, time.Minute, lockout.For(3))
+	assert.Equal(t, 2*time.Minute, lockout.For(4))
+	assert.Equal(t, 4*time.Minute, lockout.For(5))
+	assert.Equal(t, 5*time.Minute, lockout.For(6))
+	assert.Equal(t, 5*time.Minute, lockout.For(60))
+
+	assert.Zero(t, Lockout{}.For(100), "a zero threshold never locks out")
+}
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newLimiter := func() *Limiter {
+		l := New(NewMemoryStore(), Lockout{Threshold: 2, Duration: time.Minute, MaxDuration: time.Hour})
+		l.now = func() time.Time { return now }
+		return l
+	}
+
+	t.Run("allows requests up to the policy", func(t *testing.T) {
+		l := newLimiter()
+		policy := Policy{Requests: 3, Window: time.Minute}
+
+		for i := 0; i < 3; i++ {
+			wait, err := l.Allow(ctx, "ip:1", policy)
+			require.NoError(t, err)
+			assert.Zero(t, wait)
+		}
+
+		wait, err := l.Allow(ctx, "ip:1", policy)
+		require.NoError(t, err)
+		assert.Equal(t, 20*time.Second, wait)
+
+		wait, err = l.Allow(ctx, "ip:2", policy)
+		require.NoError(t, err)
+		assert.Zero(t, wait, "other keys have their own allowance")
+	})
+
+	t.Run("locks out after repeated failures", func(t *testing.T) {
+		l := newLimiter()
+
+		locked, err := l.Fail(ctx, "login:alice")
+		require.NoError(t, err)
+		assert.Zero(t, locked)
+
+		locked, err = l.Fail(ctx, "login:alice")
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, locked)
+
+		wait, err := l.LockedFor(ctx, "login:alice")
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, wait)
+
+		now = now.Add(time.Minute)
+		wait, err = l.LockedFor(ctx, "login:alice")
+		require.NoError(t, err)
+		assert.Zero(t, wait)
+
+		locked, err = l.Fail(ctx, "login:alice")
+		require.NoError(t, err)
+		assert.Equal(t, 2*time.Minute, locked, "each further failure locks out for longer")
+	})
+
+	t.Run("success forgets failures", func(t *testing.T) {
+		l := newLimiter()
+
+		_, err := l.Fail(ctx, "login:bob")
+		require.NoError(t, err)
+		require.NoError(t, l.Succeed(ctx, "login:bob"))
+
+		locked, err := l.Fail(ctx, "login:bob")
+		require.NoError(t, err)
+		assert.Zero(t, locked)
+	})
+}
@@ -0,0 +1,85 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid token signature")
+	ErrTokenExpired     = errors.New("token has expired")
+)
+
+// Signer issues tokens that carry a value until they expire, and that cannot
+// be forged or altered without the key. The value is readable by anyone who
+// has the token.
+type Signer struct {
+	key []byte
+}
+
+func NewSigner(key []byte) Signer {
+	return Signer{key: key}
+}
+
+// NewRandomKey returns a key for a Signer, for when none is configured.
+// Tokens signed with it stop working once the key is lost.
+func NewRandomKey() ([]byte, error) {
+	key := make([]byte, tokenBytes)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	return key, nil
+}
+
+// Sign returns a token for the value until expiresAt. The purpose keeps a
+// token issued for one use from being accepted for another.
+func (s Signer) Sign(purpose, value string, expiresAt time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(value)) + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	return payload + "." + s.mac(purpose, payload)
+}
+
+// Verify returns the value of a token signed for the purpose, if it has not
+// expired at now.
+func (s Signer) Verify(purpose, token string, now time.Time) (string, error) {
+	i := strings.LastIndexByte(token, '.')
+	if i < 0 {
+		return "", ErrInvalidSignature
+	}
+	payload, signature := token[:i], token[i+1:]
+	if !hmac.Equal([]byte(signature), []byte(s.mac(purpose, payload))) {
+		return "", ErrInvalidSignature
+	}
+
+	encoded, expiry, ok := strings.Cut(payload, ".")
+	if !ok {
+		return "", ErrInvalidSignature
+	}
+	expiresAt, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil {
+		return "", ErrInvalidSignature
+	}
+	if now.Unix() >= expiresAt {
+		return "", ErrTokenExpired
+	}
+
+	value, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ErrInvalidSignature
+	}
+	return string(value), nil
+}
+
+func (s Signer) mac(purpose, payload string) string {
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(purpose))
+	h.Write([]byte{0})
+	h.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
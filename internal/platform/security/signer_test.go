@@ -0,0 +1,67 @@
+package security
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigner(t *testing.T) {
+	signer := NewSigner([]byte("secret"))
+	now := time.Date(2024, time.March, 12, 9, 0, 0, 0, time.UTC)
+	token := signer.Sign("verify", "user-123 alice@example.com", now.Add(time.Hour))
+
+	t.Run("returns the value of a valid token", func(t *testing.T) {
+		value, err := signer.Verify("verify", token, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, "user-123 alice@example.com", value)
+	})
+
+	t.Run("rejects an expired token", func(t *testing.T) {
+		_, err := signer.Verify("verify", token, now.Add(time.Hour))
+
+		assert.ErrorIs(t, err, ErrTokenExpired)
+	})
+
+	t.Run("rejects a token for another purpose", func(t *testing.T) {
+		_, err := signer.Verify("reset", token, now)
+
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("rejects a token signed with another key", func(t *testing.T) {
+		_, err := NewSigner([]byte("other")).Verify("verify", token, now)
+
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("rejects an altered token", func(t *testing.T) {
+		forged := signer.Sign("verify", "user-456 alice@example.com", now.Add(time.Hour))
+		payload := forged[:strings.LastIndexByte(forged, '.')]
+		signature := token[strings.LastIndexByte(token, '.'):]
+
+		_, err := signer.Verify("verify", payload+signature, now)
+
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("rejects garbage", func(t *testing.T) {
+		_, err := signer.Verify("verify", "not-a-token", now)
+
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+}
+
+func TestNewRandomKey(t *testing.T) {
+	first, err := NewRandomKey()
+	require.NoError(t, err)
+	second, err := NewRandomKey()
+	require.NoError(t, err)
+
+	assert.Len(t, first, 32)
+	assert.NotEqual(t, first, second)
+}
@@ -0,0 +1,98 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const tokenBytes = 32
+
+// NewToken returns a random URL-safe token, for links sent by email.
+func NewToken() (string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns the SHA-256 of the token, the form it is stored in so
+// that reading the database does not give it away.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+const recoveryCodeChars = 10
+
+var recoveryCodeEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// NewRecoveryCode returns a random one-time code to sign in with when the
+// authenticator app is lost, as two groups of five letters and digits.
+func NewRecoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeChars*5/8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate recovery code: %w", err)
+	}
+	return groupCode(recoveryCodeEncoding.EncodeToString(b)), nil
+}
+
+// NormalizeRecoveryCode returns the code the way NewRecoveryCode wrote it,
+// whatever case and separators it was typed with.
+func NormalizeRecoveryCode(code string) string {
+	return normalizeCode(code, recoveryCodeChars)
+}
+
+// inviteCodeChars is the length of an invite code, without separators. It
+// is longer than a recovery code as it works without a password.
+const inviteCodeChars = 15
+
+// NewInviteCode returns a random code to register with while registration
+// is by invitation, as three groups of five letters and digits.
+func NewInviteCode() (string, error) {
+	b := make([]byte, inviteCodeChars*5/8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate invite code: %w", err)
+	}
+	return groupCode(recoveryCodeEncoding.EncodeToString(b)), nil
+}
+
+// NormalizeInviteCode returns the code the way NewInviteCode wrote it,
+// whatever case and separators it was typed with.
+func NormalizeInviteCode(code string) string {
+	return normalizeCode(code, inviteCodeChars)
+}
+
+// normalizeCode lowercases code and groups it again, when it has the length
+// of the codes it is compared with.
+func normalizeCode(code string, chars int) string {
+	code = strings.Map(func(r rune) rune {
+		if r == '-' || unicode.IsSpace(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, code)
+	if len(code) != chars {
+		return code
+	}
+	return groupCode(code)
+}
+
+// groupCode separates code into groups of five characters, easier to read
+// and type.
+func groupCode(code string) string {
+	var b strings.Builder
+	for i := 0; i < len(code); i += 5 {
+		if i > 0 {
+			b.WriteByte('-')
+		}
+		b.WriteString(code[i:min(i+5, len(code))])
+	}
+	return b.String()
+}
@@ -0,0 +1,68 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewToken(t *testing.T) {
+	// Act
+	first, err := NewToken()
+	require.NoError(t, err)
+	second, err := NewToken()
+	require.NoError(t, err)
+
+	// Assert
+	assert.Len(t, first, 43)
+	assert.NotEqual(t, first, second)
+}
+
+func TestHashToken(t *testing.T) {
+	// Act
+	hash := HashToken("token")
+
+	// Assert
+	assert.Equal(t, "3c469e9d6c5875d37a43f353d4f88e61fcf812c66eee3457465a40b0da4153e0", hash)
+	assert.Equal(t, hash, HashToken("token"))
+	assert.NotEqual(t, hash, HashToken("other"))
+}
+
+func TestNewRecoveryCode(t *testing.T) {
+	// Act
+	first, err := NewRecoveryCode()
+	require.NoError(t, err)
+	second, err := NewRecoveryCode()
+	require.NoError(t, err)
+
+	// Assert
+	assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, first)
+	assert.NotEqual(t, first, second)
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	assert.Equal(t, "abcde-fgh23", NormalizeRecoveryCode("abcde-fgh23"))
+	assert.Equal(t, "abcde-fgh23", NormalizeRecoveryCode(" ABCDE FGH23 "))
+	assert.Equal(t, "abcde-fgh23", NormalizeRecoveryCode("abcdefgh23"))
+	assert.Equal(t, "abc", NormalizeRecoveryCode("ABC"))
+}
+
+func TestNewInviteCode(t *testing.T) {
+	// Act
+	first, err := NewInviteCode()
+	require.NoError(t, err)
+	second, err := NewInviteCode()
+	require.NoError(t, err)
+
+	// Assert
+	assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}-[a-z2-7]{5}$`, first)
+	assert.NotEqual(t, first, second)
+}
+
+func TestNormalizeInviteCode(t *testing.T) {
+	assert.Equal(t, "abcde-fgh23-ijklm", NormalizeInviteCode("abcde-fgh23-ijklm"))
+	assert.Equal(t, "abcde-fgh23-ijklm", NormalizeInviteCode(" ABCDE FGH23 IJKLM "))
+	assert.Equal(t, "abcde-fgh23-ijklm", NormalizeInviteCode("abcdefgh23ijklm"))
+	assert.Equal(t, "abcdefgh23", NormalizeInviteCode("ABCDE-FGH23"))
+}
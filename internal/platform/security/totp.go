@@ -0,0 +1,129 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	totpSecretBytes = 20
+	totpDigits      = 6
+	totpPeriod      = 30 * time.Second
+	// totpSkew is how many periods before and after the current one a code
+	// is still accepted, for clocks that drift.
+	totpSkew = 1
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTP computes the time-based one-time passwords of RFC 6238 that
+// authenticator apps show.
+type TOTP struct {
+	Secret []byte
+	Digits int
+	Period time.Duration
+	Hash   func() hash.Hash
+}
+
+// NewTOTP returns the TOTP authenticator apps use by default: HMAC-SHA1, six
+// digits, a new code every 30 seconds.
+func NewTOTP(secret []byte) TOTP {
+	return TOTP{
+		Secret: secret,
+		Digits: totpDigits,
+		Period: totpPeriod,
+		Hash:   sha1.New,
+	}
+}
+
+// NewTOTPSecret returns a random secret in the base32 form authenticator apps
+// are given.
+func NewTOTPSecret() (string, error) {
+	b := make([]byte, totpSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+	return totpEncoding.EncodeToString(b), nil
+}
+
+// ParseTOTPSecret decodes a base32 secret, ignoring case, spaces and padding.
+func ParseTOTPSecret(secret string) (TOTP, error) {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	b, err := totpEncoding.DecodeString(strings.TrimRight(secret, "="))
+	if err != nil {
+		return TOTP{}, fmt.Errorf("invalid totp secret: %w", err)
+	}
+	return NewTOTP(b), nil
+}
+
+// Step returns the time step t falls in.
+func (t TOTP) Step(at time.Time) int64 {
+	return at.Unix() / int64(t.Period/time.Second)
+}
+
+// Code returns the code shown at t.
+func (t TOTP) Code(at time.Time) string {
+	return t.code(t.Step(at))
+}
+
+// Validate reports whether code is shown within the accepted skew of at, and
+// the time step it belongs to. Callers keep the last step used so that a code
+// cannot be replayed.
+func (t TOTP) Validate(code string, at time.Time) (int64, bool) {
+	if len(code) != t.Digits {
+		return 0, false
+	}
+
+	current := t.Step(at)
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if subtle.ConstantTimeCompare([]byte(t.code(step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// URI returns the otpauth URI authenticator apps read from a QR code.
+func (t TOTP) URI(issuer, account string) string {
+	q := url.Values{}
+	q.Set("secret", totpEncoding.EncodeToString(t.Secret))
+	q.Set("issuer", issuer)
+	q.Set("digits", fmt.Sprint(t.Digits))
+	q.Set("period", fmt.Sprint(int(t.Period/time.Second)))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: q.Encode(),
+	}
+	return u.String()
+}
+
+// code is the HOTP of RFC 4226 for the counter.
+func (t TOTP) code(counter int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(counter))
+
+	mac := hmac.New(t.Hash, t.Secret)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for range t.Digits {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", t.Digits, value%mod)
+}
@@ -0,0 +1,126 @@
+package security
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The test vectors of RFC 6238, appendix B.
+func TestTOTP_Code_RFC6238(t *testing.T) {
+	seeds := map[string]struct {
+		secret string
+		hash   func() hash.Hash
+	}{
+		"SHA1":   {"12345678901234567890", sha1.New},
+		"SHA256": {"12345678901234567890123456789012", sha256.New},
+		"SHA512": {"1234567890123456789012345678901234567890123456789012345678901234", sha512.New},
+	}
+
+	tests := []struct {
+		time int64
+		mode string
+		want string
+	}{
+		{59, "SHA1", "94287082"},
+		{59, "SHA256", "46119246"},
+		{59, "SHA512", "90693936"},
+		{1111111109, "SHA1", "07081804"},
+		{1111111109, "SHA256", "68084774"},
+		{1111111109, "SHA512", "25091201"},
+		{1111111111, "SHA1", "14050471"},
+		{1111111111, "SHA256", "67062674"},
+		{1111111111, "SHA512", "99943326"},
+		{1234567890, "SHA1", "89005924"},
+		{1234567890, "SHA256", "91819424"},
+		{1234567890, "SHA512", "93441116"},
+		{2000000000, "SHA1", "69279037"},
+		{2000000000, "SHA256", "90698825"},
+		{2000000000, "SHA512", "38618901"},
+		{20000000000, "SHA1", "65353130"},
+		{20000000000, "SHA256", "77737706"},
+		{20000000000, "SHA512", "47863826"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode+"/"+time.Unix(tt.time, 0).UTC().Format(time.RFC3339), func(t *testing.T) {
+			seed := seeds[tt.mode]
+			totp := TOTP{Secret: []byte(seed.secret), Digits: 8, Period: 30 * time.Second, Hash: seed.hash}
+
+			assert.Equal(t, tt.want, totp.Code(time.Unix(tt.time, 0)))
+		})
+	}
+}
+
+func TestTOTP_Validate(t *testing.T) {
+	totp := NewTOTP([]byte("12345678901234567890"))
+	now := time.Unix(1111111111, 0)
+
+	t.Run("accepts the current code", func(t *testing.T) {
+		step, ok := totp.Validate(totp.Code(now), now)
+
+		assert.True(t, ok)
+		assert.Equal(t, totp.Step(now), step)
+	})
+
+	t.Run("accepts the code of the period before", func(t *testing.T) {
+		step, ok := totp.Validate(totp.Code(now.Add(-30*time.Second)), now)
+
+		assert.True(t, ok)
+		assert.Equal(t, totp.Step(now)-1, step)
+	})
+
+	t.Run("rejects an older code", func(t *testing.T) {
+		_, ok := totp.Validate(totp.Code(now.Add(-90*time.Second)), now)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("rejects a code of the wrong length", func(t *testing.T) {
+		_, ok := totp.Validate("1234", now)
+
+		assert.False(t, ok)
+	})
+}
+
+func TestNewTOTPSecret(t *testing.T) {
+	secret, err := NewTOTPSecret()
+	require.NoError(t, err)
+
+	totp, err := ParseTOTPSecret(secret)
+	require.NoError(t, err)
+	assert.Len(t, totp.Secret, totpSecretBytes)
+
+	other, err := NewTOTPSecret()
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, other)
+}
+
+func TestParseTOTPSecret(t *testing.T) {
+	totp, err := ParseTOTPSecret("gezd gnbv gy3t qojq")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("1234567890"), totp.Secret)
+
+	_, err = ParseTOTPSecret("not base32!")
+	assert.Error(t, err)
+}
+
+func TestTOTP_URI(t *testing.T) {
+	totp := NewTOTP([]byte("1234567890"))
+
+	u, err := url.Parse(totp.URI("GoCost", "alice@example.com"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/GoCost:alice@example.com", u.Path)
+	assert.Equal(t, "GEZDGNBVGY3TQOJQ", u.Query().Get("secret"))
+	assert.Equal(t, "GoCost", u.Query().Get("issuer"))
+}
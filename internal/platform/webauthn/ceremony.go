@@ -0,0 +1,220 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// Flags of the authenticator data.
+const (
+	flagUserPresent  = 0x01
+	flagUserVerified = 0x04
+	flagAttestedData = 0x40
+)
+
+// RegistrationResponse is the PublicKeyCredential navigator.credentials.create
+// resolves with, as the page posts it.
+type RegistrationResponse struct {
+	ID       string `json:"id"`
+	RawID    Bytes  `json:"rawId"`
+	Type     string `json:"type"`
+	Response struct {
+		ClientDataJSON    Bytes `json:"clientDataJSON"`
+		AttestationObject Bytes `json:"attestationObject"`
+	} `json:"response"`
+}
+
+// AssertionResponse is the PublicKeyCredential navigator.credentials.get
+// resolves with, as the page posts it.
+type AssertionResponse struct {
+	ID       string `json:"id"`
+	RawID    Bytes  `json:"rawId"`
+	Type     string `json:"type"`
+	Response struct {
+		ClientDataJSON    Bytes `json:"clientDataJSON"`
+		AuthenticatorData Bytes `json:"authenticatorData"`
+		Signature         Bytes `json:"signature"`
+		UserHandle        Bytes `json:"userHandle"`
+	} `json:"response"`
+}
+
+// Credential is what the server keeps of a registered passkey: the public
+// key in COSE form and the last signature count.
+type Credential struct {
+	ID        []byte
+	PublicKey []byte
+	SignCount uint32
+}
+
+type clientData struct {
+	Type      string `json:"type"`
+	Challenge Bytes  `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+type attestationObject struct {
+	Format   string          `cbor:"fmt"`
+	AttStmt  cbor.RawMessage `cbor:"attStmt"`
+	AuthData []byte          `cbor:"authData"`
+}
+
+type authenticatorData struct {
+	RPIDHash     []byte
+	Flags        byte
+	SignCount    uint32
+	CredentialID []byte
+	PublicKey    []byte
+}
+
+// VerifyRegistration checks the response to the creation options made with
+// challenge and returns the new credential.
+func (rp RelyingParty) VerifyRegistration(challenge []byte, resp RegistrationResponse) (Credential, error) {
+	if err := rp.verifyClientData(resp.Response.ClientDataJSON, "webauthn.create", challenge); err != nil {
+		return Credential{}, err
+	}
+
+	var attestation attestationObject
+	if err := cbor.Unmarshal(resp.Response.AttestationObject, &attestation); err != nil {
+		return Credential{}, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	if attestation.Format != "none" {
+		return Credential{}, fmt.Errorf("%w: %q", ErrUnsupportedAttestation, attestation.Format)
+	}
+	var attStmt map[string]any
+	if err := cbor.Unmarshal(attestation.AttStmt, &attStmt); err != nil || len(attStmt) != 0 {
+		return Credential{}, fmt.Errorf("%w: attestation statement of none is not empty", ErrInvalidResponse)
+	}
+
+	authData, err := parseAuthenticatorData(attestation.AuthData)
+	if err != nil {
+		return Credential{}, err
+	}
+	if err := rp.verifyAuthenticatorData(authData); err != nil {
+		return Credential{}, err
+	}
+	if authData.Flags&flagAttestedData == 0 {
+		return Credential{}, fmt.Errorf("%w: no attested credential data", ErrInvalidResponse)
+	}
+	if len(resp.RawID) > 0 && !bytes.Equal(resp.RawID, authData.CredentialID) {
+		return Credential{}, fmt.Errorf("%w: credential id does not match", ErrInvalidResponse)
+	}
+	if _, err := parsePublicKey(authData.PublicKey); err != nil {
+		return Credential{}, err
+	}
+
+	return Credential{
+		ID:        authData.CredentialID,
+		PublicKey: authData.PublicKey,
+		SignCount: authData.SignCount,
+	}, nil
+}
+
+// VerifyAssertion checks the response to the request options made with
+// challenge was signed with the credential, and returns the new signature
+// count to store.
+func (rp RelyingParty) VerifyAssertion(challenge []byte, credential Credential, resp AssertionResponse) (uint32, error) {
+	if err := rp.verifyClientData(resp.Response.ClientDataJSON, "webauthn.get", challenge); err != nil {
+		return 0, err
+	}
+
+	authData, err := parseAuthenticatorData(resp.Response.AuthenticatorData)
+	if err != nil {
+		return 0, err
+	}
+	if err := rp.verifyAuthenticatorData(authData); err != nil {
+		return 0, err
+	}
+
+	key, err := parsePublicKey(credential.PublicKey)
+	if err != nil {
+		return 0, err
+	}
+	clientDataHash := sha256.Sum256(resp.Response.ClientDataJSON)
+	signed := append(bytes.Clone(resp.Response.AuthenticatorData), clientDataHash[:]...)
+	if err := key.verify(signed, resp.Response.Signature); err != nil {
+		return 0, err
+	}
+
+	// Authenticators that keep no counter always report zero.
+	if (authData.SignCount != 0 || credential.SignCount != 0) && authData.SignCount <= credential.SignCount {
+		return 0, ErrCounterRegressed
+	}
+
+	return authData.SignCount, nil
+}
+
+func (rp RelyingParty) verifyClientData(raw []byte, ceremony string, challenge []byte) error {
+	var data clientData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	if data.Type != ceremony {
+		return fmt.Errorf("%w: client data is for %q", ErrInvalidResponse, data.Type)
+	}
+	if len(challenge) == 0 || subtle.ConstantTimeCompare(data.Challenge, challenge) != 1 {
+		return ErrChallengeMismatch
+	}
+	if data.Origin != rp.Origin {
+		return fmt.Errorf("%w: %q", ErrOriginMismatch, data.Origin)
+	}
+	return nil
+}
+
+func (rp RelyingParty) verifyAuthenticatorData(authData authenticatorData) error {
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if !bytes.Equal(authData.RPIDHash, rpIDHash[:]) {
+		return ErrRelyingPartyMismatch
+	}
+	if authData.Flags&flagUserPresent == 0 {
+		return ErrUserNotPresent
+	}
+	if rp.RequireUserVerification && authData.Flags&flagUserVerified == 0 {
+		return ErrUserNotVerified
+	}
+	return nil
+}
+
+// parseAuthenticatorData reads the authenticator data: the hash of the
+// relying party ID, the flags, the signature count and, on registration, the
+// credential ID and public key. Extensions are ignored.
+func parseAuthenticatorData(raw []byte) (authenticatorData, error) {
+	const headerLen = 32 + 1 + 4
+	if len(raw) < headerLen {
+		return authenticatorData{}, fmt.Errorf("%w: authenticator data too short", ErrInvalidResponse)
+	}
+
+	data := authenticatorData{
+		RPIDHash:  raw[:32],
+		Flags:     raw[32],
+		SignCount: binary.BigEndian.Uint32(raw[33:37]),
+	}
+	if data.Flags&flagAttestedData == 0 {
+		return data, nil
+	}
+
+	// The AAGUID of the authenticator model is skipped.
+	rest := raw[headerLen:]
+	if len(rest) < 16+2 {
+		return authenticatorData{}, fmt.Errorf("%w: attested credential data too short", ErrInvalidResponse)
+	}
+	idLen := int(binary.BigEndian.Uint16(rest[16:18]))
+	rest = rest[18:]
+	if len(rest) < idLen {
+		return authenticatorData{}, fmt.Errorf("%w: credential id too short", ErrInvalidResponse)
+	}
+	data.CredentialID = rest[:idLen]
+	rest = rest[idLen:]
+
+	var key cbor.RawMessage
+	if _, err := cbor.UnmarshalFirst(rest, &key); err != nil {
+		return authenticatorData{}, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+	data.PublicKey = key
+	return data, nil
+}
@@ -0,0 +1,96 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// COSE key parameters, from RFC 9053.
+const (
+	coseKeyType   = 1
+	coseAlgorithm = 3
+	coseCurve     = -1
+	coseX         = -2
+	coseY         = -3
+	coseModulus   = -1
+	coseExponent  = -2
+
+	coseKeyTypeEC2 = 2
+	coseKeyTypeRSA = 3
+	coseCurveP256  = 1
+)
+
+type publicKey struct {
+	alg   int
+	ecdsa *ecdsa.PublicKey
+	rsa   *rsa.PublicKey
+}
+
+// parsePublicKey decodes a COSE public key of one of the accepted
+// algorithms.
+func parsePublicKey(raw []byte) (publicKey, error) {
+	var params map[int]any
+	if err := cbor.Unmarshal(raw, &params); err != nil {
+		return publicKey{}, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+	}
+
+	kty, _ := params[coseKeyType].(uint64)
+	alg, _ := params[coseAlgorithm].(int64)
+
+	switch {
+	case kty == coseKeyTypeEC2 && alg == AlgES256:
+		crv, _ := params[coseCurve].(uint64)
+		x, _ := params[coseX].([]byte)
+		y, _ := params[coseY].([]byte)
+		if crv != coseCurveP256 || len(x) != 32 || len(y) != 32 {
+			return publicKey{}, fmt.Errorf("%w: invalid P-256 key", ErrInvalidResponse)
+		}
+		point := append([]byte{0x04}, append(x, y...)...)
+		key, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), point)
+		if err != nil {
+			return publicKey{}, fmt.Errorf("%w: %v", ErrInvalidResponse, err)
+		}
+		return publicKey{alg: AlgES256, ecdsa: key}, nil
+
+	case kty == coseKeyTypeRSA && alg == AlgRS256:
+		n, _ := params[coseModulus].([]byte)
+		e, _ := params[coseExponent].([]byte)
+		if len(n) == 0 || len(e) == 0 || len(e) > 4 {
+			return publicKey{}, fmt.Errorf("%w: invalid RSA key", ErrInvalidResponse)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		return publicKey{alg: AlgRS256, rsa: &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(exponent.Int64()),
+		}}, nil
+
+	default:
+		return publicKey{}, fmt.Errorf("%w: key type %d, algorithm %d", ErrUnsupportedAlgorithm, kty, alg)
+	}
+}
+
+// verify checks signature is over the SHA-256 of data.
+func (k publicKey) verify(data, signature []byte) error {
+	digest := sha256.Sum256(data)
+
+	switch k.alg {
+	case AlgES256:
+		if !ecdsa.VerifyASN1(k.ecdsa, digest[:], signature) {
+			return ErrInvalidSignature
+		}
+	case AlgRS256:
+		if err := rsa.VerifyPKCS1v15(k.rsa, crypto.SHA256, digest[:], signature); err != nil {
+			return ErrInvalidSignature
+		}
+	default:
+		return ErrUnsupportedAlgorithm
+	}
+	return nil
+}
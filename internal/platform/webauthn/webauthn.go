@@ -0,0 +1,193 @@
+// Package webauthn runs the server side of the Web Authentication ceremonies
+// that register passkeys and log in with them. It accepts attestation "none"
+// and credentials signed with ES256 or RS256.
+package webauthn
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// COSE algorithm identifiers of the signatures accepted.
+const (
+	AlgES256 = -7
+	AlgRS256 = -257
+)
+
+const (
+	challengeBytes = 32
+	// Timeout is how long the browser waits for the user to touch their
+	// authenticator.
+	Timeout = 5 * time.Minute
+)
+
+var (
+	ErrInvalidResponse        = errors.New("webauthn: malformed authenticator response")
+	ErrChallengeMismatch      = errors.New("webauthn: challenge does not match")
+	ErrOriginMismatch         = errors.New("webauthn: origin does not match")
+	ErrRelyingPartyMismatch   = errors.New("webauthn: credential is scoped to another site")
+	ErrUserNotPresent         = errors.New("webauthn: user was not present")
+	ErrUserNotVerified        = errors.New("webauthn: user was not verified")
+	ErrUnsupportedAttestation = errors.New("webauthn: unsupported attestation format")
+	ErrUnsupportedAlgorithm   = errors.New("webauthn: unsupported public key algorithm")
+	ErrInvalidSignature       = errors.New("webauthn: invalid signature")
+	// ErrCounterRegressed means the authenticator reported a signature count
+	// that did not go up, a sign that the credential was cloned.
+	ErrCounterRegressed = errors.New("webauthn: signature counter did not increase")
+)
+
+// Bytes is binary data, sent to and from the browser in unpadded base64url.
+type Bytes []byte
+
+func (b Bytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base64.RawURLEncoding.EncodeToString(b))
+}
+
+func (b *Bytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return fmt.Errorf("webauthn: invalid base64url: %w", err)
+	}
+	*b = decoded
+	return nil
+}
+
+// RelyingParty is the site passkeys are registered with. Its ID is the
+// domain and Origin where the ceremonies run, as the browser reports it.
+type RelyingParty struct {
+	ID     string
+	Name   string
+	Origin string
+	// RequireUserVerification rejects ceremonies where the authenticator did
+	// not check a PIN or biometric, only that someone touched it.
+	RequireUserVerification bool
+}
+
+// NewRelyingParty returns the relying party of the site at baseURL.
+func NewRelyingParty(name, baseURL string) (RelyingParty, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return RelyingParty{}, fmt.Errorf("webauthn: invalid base url %q", baseURL)
+	}
+	return RelyingParty{
+		ID:                      u.Hostname(),
+		Name:                    name,
+		Origin:                  u.Scheme + "://" + u.Host,
+		RequireUserVerification: true,
+	}, nil
+}
+
+// NewChallenge returns the random challenge of a ceremony. It is kept on the
+// server until the response comes back, and used once.
+func NewChallenge() ([]byte, error) {
+	challenge := make([]byte, challengeBytes)
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, fmt.Errorf("failed to generate challenge: %w", err)
+	}
+	return challenge, nil
+}
+
+// CredentialDescriptor names a credential the browser should or should not
+// use.
+type CredentialDescriptor struct {
+	Type string `json:"type"`
+	ID   Bytes  `json:"id"`
+}
+
+type CredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+type RelyingPartyEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type UserEntity struct {
+	ID          Bytes  `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type AuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// CreationOptions are given to navigator.credentials.create to register a
+// passkey.
+type CreationOptions struct {
+	Challenge              Bytes                  `json:"challenge"`
+	RP                     RelyingPartyEntity     `json:"rp"`
+	User                   UserEntity             `json:"user"`
+	PubKeyCredParams       []CredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// RequestOptions are given to navigator.credentials.get to log in with a
+// passkey. No credentials are listed, so the browser offers every passkey
+// it has for the site.
+type RequestOptions struct {
+	Challenge        Bytes                  `json:"challenge"`
+	Timeout          int64                  `json:"timeout"`
+	RPID             string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+// CreationOptions asks for a discoverable passkey for the user, other than
+// the ones in exclude.
+func (rp RelyingParty) CreationOptions(challenge, userHandle []byte, userName string, exclude [][]byte) CreationOptions {
+	excluded := make([]CredentialDescriptor, 0, len(exclude))
+	for _, id := range exclude {
+		excluded = append(excluded, CredentialDescriptor{Type: "public-key", ID: id})
+	}
+
+	return CreationOptions{
+		Challenge: challenge,
+		RP:        RelyingPartyEntity{ID: rp.ID, Name: rp.Name},
+		User:      UserEntity{ID: userHandle, Name: userName, DisplayName: userName},
+		PubKeyCredParams: []CredentialParameter{
+			{Type: "public-key", Alg: AlgES256},
+			{Type: "public-key", Alg: AlgRS256},
+		},
+		Timeout:            Timeout.Milliseconds(),
+		ExcludeCredentials: excluded,
+		AuthenticatorSelection: AuthenticatorSelection{
+			ResidentKey:      "required",
+			UserVerification: rp.userVerification(),
+		},
+		Attestation: "none",
+	}
+}
+
+func (rp RelyingParty) RequestOptions(challenge []byte) RequestOptions {
+	return RequestOptions{
+		Challenge:        challenge,
+		Timeout:          Timeout.Milliseconds(),
+		RPID:             rp.ID,
+		AllowCredentials: []CredentialDescriptor{},
+		UserVerification: rp.userVerification(),
+	}
+}
+
+func (rp RelyingParty) userVerification() string {
+	if rp.RequireUserVerification {
+		return "required"
+	}
+	return "preferred"
+}
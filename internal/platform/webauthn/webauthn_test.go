@@ -0,0 +1,256 @@
+package webauthn
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Authenticator responses published with the Web Authentication Level 3
+// specification, §16 Test Vectors. They were made for the relying party
+// example.org at https://example.org.
+const (
+	// §16.2 None Attestation - ES256.
+	noneES256Challenge               = "00c30fb78531c464d2b6771dab8d7b603c01162f2fa486bea70f283ae556e130"
+	noneES256CredentialID            = "f91f391db4c9b2fde0ea70189cba3fb63f579ba6122b33ad94ff3ec330084be4"
+	noneES256ClientDataJSON          = "7b2274797065223a22776562617574686e2e637265617465222c226368616c6c656e6765223a22414d4d507434557878475453746e63647134313759447742466938767049612d7077386f4f755657345441222c226f726967696e223a2268747470733a2f2f6578616d706c652e6f7267222c2263726f73734f726967696e223a66616c73652c22657874726144617461223a22636c69656e74446174614a534f4e206d617920626520657874656e6465642077697468206164646974696f6e616c206669656c647320696e20746865206675747572652c207375636820617320746869733a20426b5165446a646354427258426941774a544c453551227d"
+	noneES256AttestationObject       = "a363666d74646e6f6e656761747453746d74a068617574684461746158a4bfabc37432958b063360d3ad6461c9c4735ae7f8edd46592a5e0f01452b2e4b559000000008446ccb9ab1db374750b2367ff6f3a1f0020f91f391db4c9b2fde0ea70189cba3fb63f579ba6122b33ad94ff3ec330084be4a5010203262001215820afefa16f97ca9b2d23eb86ccb64098d20db90856062eb249c33a9b672f26df61225820930a56b87a2fca66334b03458abf879717c12cc68ed73290af2e2664796b9220"
+	noneES256AssertionChallenge      = "39c0e7521417ba54d43e8dc95174f423dee9bf3cd804ff6d65c857c9abf4d408"
+	noneES256AuthenticatorData       = "bfabc37432958b063360d3ad6461c9c4735ae7f8edd46592a5e0f01452b2e4b51900000000"
+	noneES256AssertionClientDataJSON = "7b2274797065223a22776562617574686e2e676574222c226368616c6c656e6765223a224f63446e55685158756c5455506f334a5558543049393770767a7a59425039745a63685879617630314167222c226f726967696e223a2268747470733a2f2f6578616d706c652e6f7267222c2263726f73734f726967696e223a66616c73657d"
+	noneES256Signature               = "3046022100f50a4e2e4409249c4a853ba361282f09841df4dd4547a13a87780218deffcd380221008480ac0f0b93538174f575bf11a1dd5d78c6e486013f937295ea13653e331e87"
+	noneES256PublicKey               = "a5010203262001215820afefa16f97ca9b2d23eb86ccb64098d20db90856062eb249c33a9b672f26df61225820930a56b87a2fca66334b03458abf879717c12cc68ed73290af2e2664796b9220"
+
+	// §16.3 Self Attestation (Packed) - ES256.
+	packedES256Challenge         = "7869c2b772d4b58eba9378cf8f29e26cf935aa77df0da89fa99c0bdc0a76f7e5"
+	packedES256ClientDataJSON    = "7b2274797065223a22776562617574686e2e637265617465222c226368616c6c656e6765223a2265476e4374334c55745936366b336a506a796e6962506b31716e666644616966715a774c33417032392d55222c226f726967696e223a2268747470733a2f2f6578616d706c652e6f7267222c2263726f73734f726967696e223a66616c73652c22657874726144617461223a22636c69656e74446174614a534f4e206d617920626520657874656e6465642077697468206164646974696f6e616c206669656c647320696e20746865206675747572652c207375636820617320746869733a205539685458764b453255526b4d6e625f307859485667227d"
+	packedES256AttestationObject = "a363666d74667061636b65646761747453746d74a263616c672663736967584630440220067a20754ab925005dbf378097c92120031581c73228d1fb4f5b881bcd7da98302207fc7b147558c7c0eba3af18bd9d121fa3d3a26d17fe3f220272178f473b6006d68617574684461746158a4bfabc37432958b063360d3ad6461c9c4735ae7f8edd46592a5e0f01452b2e4b55d00000000df850e09db6afbdfab51697791506cfc0020455ef34e2043a87db3d4afeb39bbcb6cc32df9347c789a865ecdca129cbef58ca5010203262001215820eb151c8176b225cc651559fecf07af450fd85802046656b34c18f6cf193843c5225820927b8aa427a2be1b8834d233a2d34f61f13bfd44119c325d5896e183fee484f2"
+
+	// §16.10 Packed Attestation - RS256.
+	rs256AssertionChallenge      = "295f59f5fa8fe62c5aca9e27626c78c8da376ae6d8cd2dd29aebad601e1bc4c5"
+	rs256AuthenticatorData       = "bfabc37432958b063360d3ad6461c9c4735ae7f8edd46592a5e0f01452b2e4b51900000000"
+	rs256AssertionClientDataJSON = "7b2274797065223a22776562617574686e2e676574222c226368616c6c656e6765223a224b56395a39667150356978617970346e596d7834794e6f33617562597a5333536d75757459423462784d55222c226f726967696e223a2268747470733a2f2f6578616d706c652e6f7267222c2263726f73734f726967696e223a66616c73657d"
+	rs256Signature               = "01063d52d7c39b4d432fc7063c5d93e582bdcb16889cd71f888d67d880ea730a428498d3bc8e1ee11f2b1ecbe6c292b118c55ffaaddefa8cad0a54dd137c51f1eec673f1bb6c4d1789d6826a222b22d0f585fc901fdc933212e579d199b89d672aa44891333e6a1355536025e82b25590256c3538229b55737083b2f6b9377e49e2472f11952f79fdd0da180b5ffd901b4049a8f081bb40711bef76c62aed943571f2d0575304cb549d68d8892f95086a30f93716aee818f8dc06e96c0d5e0ed4cfa9fd8773d90464b68cf140f7986666ff9c9e3302acd0535d60d769f465e2ab57ef8aabc89fccfef7ba32a64154a8b3d26be2298f470b8cc5377dbe3dfd4b0b45f8f01e63bde6cfc76b62771f9b70aa27cf40152cad93aa5acd784fd4b90f676e2ea828d0bf2400aebbaae4153e5838f537f88b6228346782a93a899be66ec77de45b3efcf311da6321c92e6b0cd11bfe653bf3e98cee8e341f02d67dbb6f9c98d9e8178090cfb5b70fbc6d541599ac794ae2f1d4de1286ec8de8c2daf7b1d15c8438e90d924df5c19045220a4c8438c1b979bbe016cf3d0eeec23c3999d4882cc645b776de930756612cdc6dd398160ff02a6"
+	rs256PublicKey               = "a4010303390100205901b403fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff800000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000012143010001"
+)
+
+func specRelyingParty() RelyingParty {
+	return RelyingParty{ID: "example.org", Name: "Example", Origin: "https://example.org"}
+}
+
+func decodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func registrationResponse(t *testing.T, credentialID, clientDataJSON, attestationObject string) RegistrationResponse {
+	t.Helper()
+	var resp RegistrationResponse
+	resp.ID = "credential"
+	resp.RawID = decodeHex(t, credentialID)
+	resp.Type = "public-key"
+	resp.Response.ClientDataJSON = decodeHex(t, clientDataJSON)
+	resp.Response.AttestationObject = decodeHex(t, attestationObject)
+	return resp
+}
+
+func assertionResponse(t *testing.T, authenticatorData, clientDataJSON, signature string) AssertionResponse {
+	t.Helper()
+	var resp AssertionResponse
+	resp.Type = "public-key"
+	resp.Response.AuthenticatorData = decodeHex(t, authenticatorData)
+	resp.Response.ClientDataJSON = decodeHex(t, clientDataJSON)
+	resp.Response.Signature = decodeHex(t, signature)
+	return resp
+}
+
+func TestNewRelyingParty(t *testing.T) {
+	rp, err := NewRelyingParty("GoCost", "https://gocost.example.com:8443")
+	require.NoError(t, err)
+
+	assert.Equal(t, "gocost.example.com", rp.ID)
+	assert.Equal(t, "https://gocost.example.com:8443", rp.Origin)
+	assert.True(t, rp.RequireUserVerification)
+
+	_, err = NewRelyingParty("GoCost", "gocost.example.com")
+	assert.Error(t, err)
+}
+
+func TestBytes_JSON(t *testing.T) {
+	data, err := json.Marshal(Bytes{0xfb, 0xff, 0x01})
+	require.NoError(t, err)
+	assert.Equal(t, `"-_8B"`, string(data))
+
+	var b Bytes
+	require.NoError(t, json.Unmarshal([]byte(`"-_8B"`), &b))
+	assert.Equal(t, Bytes{0xfb, 0xff, 0x01}, b)
+
+	assert.Error(t, json.Unmarshal([]byte(`"+/8B"`), &b))
+}
+
+func TestRelyingParty_CreationOptions(t *testing.T) {
+	rp := specRelyingParty()
+	rp.RequireUserVerification = true
+
+	options := rp.CreationOptions([]byte{1, 2}, []byte{3}, "alice", [][]byte{{4}})
+
+	data, err := json.Marshal(options)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"challenge": "AQI",
+		"rp": {"id": "example.org", "name": "Example"},
+		"user": {"id": "Aw", "name": "alice", "displayName": "alice"},
+		"pubKeyCredParams": [{"type": "public-key", "alg": -7}, {"type": "public-key", "alg": -257}],
+		"timeout": 300000,
+		"excludeCredentials": [{"type": "public-key", "id": "BA"}],
+		"authenticatorSelection": {"residentKey": "required", "userVerification": "required"},
+		"attestation": "none"
+	}`, string(data))
+}
+
+func TestRelyingParty_VerifyRegistration(t *testing.T) {
+	challenge := func(t *testing.T) []byte { return decodeHex(t, noneES256Challenge) }
+	response := func(t *testing.T) RegistrationResponse {
+		return registrationResponse(t, noneES256CredentialID, noneES256ClientDataJSON, noneES256AttestationObject)
+	}
+
+	t.Run("accepts none attestation", func(t *testing.T) {
+		credential, err := specRelyingParty().VerifyRegistration(challenge(t), response(t))
+
+		require.NoError(t, err)
+		assert.Equal(t, decodeHex(t, noneES256CredentialID), credential.ID)
+		assert.Equal(t, decodeHex(t, noneES256PublicKey), credential.PublicKey)
+		assert.Equal(t, uint32(0), credential.SignCount)
+	})
+
+	t.Run("rejects another challenge", func(t *testing.T) {
+		_, err := specRelyingParty().VerifyRegistration([]byte("another challenge"), response(t))
+		assert.ErrorIs(t, err, ErrChallengeMismatch)
+	})
+
+	t.Run("rejects another origin", func(t *testing.T) {
+		rp := specRelyingParty()
+		rp.Origin = "https://example.com"
+
+		_, err := rp.VerifyRegistration(challenge(t), response(t))
+		assert.ErrorIs(t, err, ErrOriginMismatch)
+	})
+
+	t.Run("rejects a credential of another relying party", func(t *testing.T) {
+		rp := specRelyingParty()
+		rp.ID = "example.com"
+
+		_, err := rp.VerifyRegistration(challenge(t), response(t))
+		assert.ErrorIs(t, err, ErrRelyingPartyMismatch)
+	})
+
+	t.Run("rejects a user who was not verified when it is required", func(t *testing.T) {
+		rp := specRelyingParty()
+		rp.RequireUserVerification = true
+
+		_, err := rp.VerifyRegistration(challenge(t), response(t))
+		assert.ErrorIs(t, err, ErrUserNotVerified)
+	})
+
+	t.Run("rejects an assertion", func(t *testing.T) {
+		resp := response(t)
+		resp.Response.ClientDataJSON = decodeHex(t, noneES256AssertionClientDataJSON)
+
+		_, err := specRelyingParty().VerifyRegistration(decodeHex(t, noneES256AssertionChallenge), resp)
+		assert.ErrorIs(t, err, ErrInvalidResponse)
+	})
+
+	t.Run("rejects other attestation formats", func(t *testing.T) {
+		resp := registrationResponse(t, noneES256CredentialID, packedES256ClientDataJSON, packedES256AttestationObject)
+		resp.RawID = nil
+
+		_, err := specRelyingParty().VerifyRegistration(decodeHex(t, packedES256Challenge), resp)
+		assert.ErrorIs(t, err, ErrUnsupportedAttestation)
+	})
+
+	t.Run("rejects a mismatched credential id", func(t *testing.T) {
+		resp := response(t)
+		resp.RawID = []byte("another credential")
+
+		_, err := specRelyingParty().VerifyRegistration(challenge(t), resp)
+		assert.ErrorIs(t, err, ErrInvalidResponse)
+	})
+}
+
+func TestRelyingParty_VerifyAssertion(t *testing.T) {
+	es256 := func(t *testing.T) (Credential, AssertionResponse) {
+		credential := Credential{ID: decodeHex(t, noneES256CredentialID), PublicKey: decodeHex(t, noneES256PublicKey)}
+		resp := assertionResponse(t, noneES256AuthenticatorData, noneES256AssertionClientDataJSON, noneES256Signature)
+		return credential, resp
+	}
+
+	t.Run("verifies an ES256 signature", func(t *testing.T) {
+		credential, resp := es256(t)
+
+		count, err := specRelyingParty().VerifyAssertion(decodeHex(t, noneES256AssertionChallenge), credential, resp)
+
+		require.NoError(t, err)
+		assert.Equal(t, uint32(0), count)
+	})
+
+	t.Run("verifies an RS256 signature", func(t *testing.T) {
+		credential := Credential{PublicKey: decodeHex(t, rs256PublicKey)}
+		resp := assertionResponse(t, rs256AuthenticatorData, rs256AssertionClientDataJSON, rs256Signature)
+
+		_, err := specRelyingParty().VerifyAssertion(decodeHex(t, rs256AssertionChallenge), credential, resp)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects a tampered signature", func(t *testing.T) {
+		credential, resp := es256(t)
+		resp.Response.Signature[len(resp.Response.Signature)-1] ^= 0x01
+
+		_, err := specRelyingParty().VerifyAssertion(decodeHex(t, noneES256AssertionChallenge), credential, resp)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("rejects tampered authenticator data", func(t *testing.T) {
+		credential, resp := es256(t)
+		resp.Response.AuthenticatorData[len(resp.Response.AuthenticatorData)-1] = 0x01
+
+		_, err := specRelyingParty().VerifyAssertion(decodeHex(t, noneES256AssertionChallenge), credential, resp)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("rejects another key", func(t *testing.T) {
+		_, resp := es256(t)
+		credential := Credential{PublicKey: decodeHex(t, rs256PublicKey)}
+
+		_, err := specRelyingParty().VerifyAssertion(decodeHex(t, noneES256AssertionChallenge), credential, resp)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("rejects a replayed challenge", func(t *testing.T) {
+		credential, resp := es256(t)
+
+		_, err := specRelyingParty().VerifyAssertion(decodeHex(t, noneES256Challenge), credential, resp)
+		assert.ErrorIs(t, err, ErrChallengeMismatch)
+	})
+
+	t.Run("rejects a counter that did not increase", func(t *testing.T) {
+		credential, resp := es256(t)
+		credential.SignCount = 5
+
+		_, err := specRelyingParty().VerifyAssertion(decodeHex(t, noneES256AssertionChallenge), credential, resp)
+		assert.ErrorIs(t, err, ErrCounterRegressed)
+	})
+
+	t.Run("rejects an unsupported key", func(t *testing.T) {
+		credential, resp := es256(t)
+		credential.PublicKey = []byte{0xa1, 0x01, 0x01} // {1: 1}, an OKP key
+
+		_, err := specRelyingParty().VerifyAssertion(decodeHex(t, noneES256AssertionChallenge), credential, resp)
+		assert.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+	})
+}
@@ -0,0 +1,460 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain"
+	"github.com/madalinpopa/gocost-web/internal/domain/account"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+)
+
+type AccountUseCaseImpl struct {
+	uow    domain.UnitOfWork
+	logger *slog.Logger
+}
+
+func NewAccountUseCase(uow domain.UnitOfWork, logger *slog.Logger) AccountUseCaseImpl {
+	return AccountUseCaseImpl{
+		uow:    uow,
+		logger: logger,
+	}
+}
+
+func (u AccountUseCaseImpl) Create(ctx context.Context, req *CreateAccountRequest) (*AccountResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	uID, err := identifier.ParseID(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	name, accountType, opening, err := parseAccountFields(req.Name, req.Type, req.OpeningBalance, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := identifier.NewID()
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := account.NewAccount(id, uID, name, accountType, opening)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.save(ctx, a); err != nil {
+		return nil, err
+	}
+
+	resp := mapAccountToResponse(account.NewLedger(*a, nil))
+	return &resp, nil
+}
+
+func (u AccountUseCaseImpl) Update(ctx context.Context, req *UpdateAccountRequest) (*AccountResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	ledger, err := u.ledger(ctx, req.UserID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	name, accountType, opening, err := parseAccountFields(req.Name, req.Type, req.OpeningBalance, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	a := ledger.Account
+	if err := a.Update(name, accountType, opening); err != nil {
+		return nil, err
+	}
+
+	if err := u.save(ctx, &a); err != nil {
+		return nil, err
+	}
+
+	resp, err := u.response(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (u AccountUseCaseImpl) Delete(ctx context.Context, userID string, id string) error {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return err
+	}
+
+	accountID, err := identifier.ParseID(id)
+	if err != nil {
+		return err
+	}
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := txUOW.AccountRepository().Delete(ctx, uID, accountID); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+// List reports every account with its current balance.
+func (u AccountUseCaseImpl) List(ctx context.Context, userID string) ([]AccountResponse, error) {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := u.uow.AccountRepository().FindByUserID(ctx, uID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]AccountResponse, 0, len(accounts))
+	for _, a := range accounts {
+		resp, err := u.response(ctx, a)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, resp)
+	}
+	return responses, nil
+}
+
+// Ledger lists the entries of an account with the running balance after each.
+func (u AccountUseCaseImpl) Ledger(ctx context.Context, userID string, id string) (*AccountLedgerResponse, error) {
+	ledger, err := u.ledger(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountLedgerResponse{
+		Account: mapAccountToResponse(*ledger),
+		Entries: mapLinesToEntries(ledger.Lines),
+	}, nil
+}
+
+// Transfer moves money between two accounts. It changes both balances but is
+// not recorded as income or expense.
+func (u AccountUseCaseImpl) Transfer(ctx context.Context, req *TransferRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	uID, err := identifier.ParseID(req.UserID)
+	if err != nil {
+		return err
+	}
+
+	fromID, err := resolveAccountID(ctx, u.uow, uID, req.FromAccountID)
+	if err != nil {
+		return err
+	}
+
+	toID, err := resolveAccountID(ctx, u.uow, uID, req.ToAccountID)
+	if err != nil {
+		return err
+	}
+	if fromID.IsZero() || toID.IsZero() {
+		return account.ErrAccountNotFound
+	}
+
+	amount, err := money.NewFromFloat(req.Amount, req.Currency)
+	if err != nil {
+		return err
+	}
+
+	id, err := identifier.NewID()
+	if err != nil {
+		return err
+	}
+
+	transfer, err := account.NewTransfer(id, uID, fromID, toID, amount, req.Date)
+	if err != nil {
+		return err
+	}
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := txUOW.AccountRepository().SaveTransfer(ctx, *transfer); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func (u AccountUseCaseImpl) DeleteTransfer(ctx context.Context, userID string, id string) error {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return err
+	}
+
+	transferID, err := identifier.ParseID(id)
+	if err != nil {
+		return err
+	}
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := txUOW.AccountRepository().DeleteTransfer(ctx, uID, transferID); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+// CompareStatement reports how the computed balance at the statement date
+// differs from the statement, without reconciling anything.
+func (u AccountUseCaseImpl) CompareStatement(ctx context.Context, req *ReconcileRequest) (*ReconciliationResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	ledger, statementBalance, err := u.statement(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := mapReconciliationToResponse(*ledger, req.StatementDate, statementBalance)
+	return &resp, nil
+}
+
+// Reconcile marks every entry up to the statement date as reconciled. It
+// fails with account.ErrReconciliationMismatch while the computed balance
+// differs from the statement.
+func (u AccountUseCaseImpl) Reconcile(ctx context.Context, req *ReconcileRequest) (*ReconciliationResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	ledger, statementBalance, err := u.statement(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := identifier.NewID()
+	if err != nil {
+		return nil, err
+	}
+
+	reconciliation, err := ledger.Reconcile(id, req.StatementDate, statementBalance)
+	if err != nil {
+		return nil, err
+	}
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := txUOW.AccountRepository().SaveReconciliation(ctx, *reconciliation); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
+	resp := mapReconciliationToResponse(*ledger, req.StatementDate, statementBalance)
+	return &resp, nil
+}
+
+func (u AccountUseCaseImpl) statement(ctx context.Context, req *ReconcileRequest) (*account.Ledger, money.Money, error) {
+	ledger, err := u.ledger(ctx, req.UserID, req.AccountID)
+	if err != nil {
+		return nil, money.Money{}, err
+	}
+
+	statementBalance, err := money.NewFromFloat(req.StatementBalance, req.Currency)
+	if err != nil {
+		return nil, money.Money{}, err
+	}
+	return ledger, statementBalance, nil
+}
+
+func (u AccountUseCaseImpl) ledger(ctx context.Context, userID string, id string) (*account.Ledger, error) {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	accountID, err := identifier.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := u.uow.AccountRepository()
+	a, err := repo.FindByID(ctx, uID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := repo.Entries(ctx, uID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	ledger := account.NewLedger(a, entries)
+	return &ledger, nil
+}
+
+func (u AccountUseCaseImpl) response(ctx context.Context, a account.Account) (AccountResponse, error) {
+	entries, err := u.uow.AccountRepository().Entries(ctx, a.UserID, a.ID)
+	if err != nil {
+		return AccountResponse{}, err
+	}
+	return mapAccountToResponse(account.NewLedger(a, entries)), nil
+}
+
+func (u AccountUseCaseImpl) save(ctx context.Context, a *account.Account) error {
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := txUOW.AccountRepository().Save(ctx, *a); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func parseAccountFields(name string, accountType string, openingBalance float64, currency string) (account.NameVO, account.Type, money.Money, error) {
+	nameVO, err := account.NewNameVO(name)
+	if err != nil {
+		return account.NameVO{}, "", money.Money{}, err
+	}
+
+	t, err := account.NewType(accountType)
+	if err != nil {
+		return account.NameVO{}, "", money.Money{}, err
+	}
+
+	opening, err := money.NewFromFloat(openingBalance, currency)
+	if err != nil {
+		return account.NameVO{}, "", money.Money{}, err
+	}
+
+	return nameVO, t, opening, nil
+}
+
+// resolveAccountID checks that the account an income, expense or transfer is
+// tied to belongs to the user. An empty id means no account.
+func resolveAccountID(ctx context.Context, uow domain.UnitOfWork, userID identifier.ID, id string) (identifier.ID, error) {
+	if id == "" {
+		return identifier.ID{}, nil
+	}
+
+	accountID, err := identifier.ParseID(id)
+	if err != nil {
+		return identifier.ID{}, account.ErrAccountNotFound
+	}
+
+	a, err := uow.AccountRepository().FindByID(ctx, userID, accountID)
+	if err != nil {
+		return identifier.ID{}, err
+	}
+	return a.ID, nil
+}
+
+func accountIDString(id identifier.ID) string {
+	if id.IsZero() {
+		return ""
+	}
+	return id.String()
+}
+
+func mapAccountToResponse(ledger account.Ledger) AccountResponse {
+	a := ledger.Account
+	return AccountResponse{
+		ID:                  a.ID.String(),
+		Name:                a.Name.Value(),
+		Type:                a.Type.Value(),
+		Currency:            a.OpeningBalance.Currency(),
+		OpeningBalanceCents: a.OpeningBalance.Cents(),
+		BalanceCents:        ledger.Balance().Cents(),
+		UnreconciledCount:   len(ledger.Unreconciled(time.Now())),
+	}
+}
+
+func mapLinesToEntries(lines []account.Line) []AccountEntryResponse {
+	entries := make([]AccountEntryResponse, 0, len(lines))
+	for _, line := range lines {
+		amount := line.Amount.Cents()
+		if !line.IsInflow() {
+			amount = -amount
+		}
+		entries = append(entries, AccountEntryResponse{
+			Kind:         string(line.Kind),
+			ID:           line.ID.String(),
+			Date:         line.Date,
+			Description:  line.Description,
+			AmountCents:  amount,
+			BalanceCents: line.Balance.Cents(),
+			Reconciled:   line.Reconciled,
+		})
+	}
+	return entries
+}
+
+func mapReconciliationToResponse(ledger account.Ledger, statementDate time.Time, statementBalance money.Money) ReconciliationResponse {
+	unreconciled := ledger.Unreconciled(statementDate)
+	computed := ledger.BalanceAt(statementDate).Cents()
+
+	return ReconciliationResponse{
+		Account:               mapAccountToResponse(ledger),
+		StatementDate:         statementDate,
+		StatementBalanceCents: statementBalance.Cents(),
+		ComputedBalanceCents:  computed,
+		DifferenceCents:       statementBalance.Cents() - computed,
+		Unreconciled:          mapLinesToEntries(unreconciled),
+		Reconciled:            len(unreconciled) == 0 && computed == statementBalance.Cents(),
+	}
+}
+
+var _ AccountUseCase = (*AccountUseCaseImpl)(nil)
@@ -0,0 +1,269 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/account"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestAccount is a checking account opened with 100.00.
+func newTestAccount(t *testing.T, userID identifier.ID, name string) account.Account {
+	t.Helper()
+
+	id, _ := identifier.NewID()
+	nameVO, err := account.NewNameVO(name)
+	require.NoError(t, err)
+	opening, err := money.New(10000, "USD")
+	require.NoError(t, err)
+
+	a, err := account.NewAccount(id, userID, nameVO, account.TypeChecking, opening)
+	require.NoError(t, err)
+	return *a
+}
+
+func newTestAccountEntry(kind account.EntryKind, cents int64, day int, reconciled bool) account.Entry {
+	id, _ := identifier.NewID()
+	amount, _ := money.New(cents, "USD")
+	return account.Entry{
+		Kind:       kind,
+		ID:         id,
+		Date:       time.Date(2024, time.March, day, 9, 0, 0, 0, time.UTC),
+		Amount:     amount,
+		Reconciled: reconciled,
+	}
+}
+
+// newTestAccountUseCase returns the use case over the accounts repository and
+// the transactional repository that records changes.
+func newTestAccountUseCase(repo *MockAccountRepository) (AccountUseCaseImpl, *MockAccountRepository, *MockUnitOfWork) {
+	txRepo := &MockAccountRepository{}
+	txUOW := &MockUnitOfWork{AccountRepo: txRepo}
+	txUOW.On("Commit").Return(nil).Maybe()
+	txUOW.On("Rollback").Return(nil).Maybe()
+
+	baseUOW := &MockUnitOfWork{AccountRepo: repo}
+	baseUOW.On("Begin", mock.Anything).Return(txUOW, nil).Maybe()
+
+	return NewAccountUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil))), txRepo, txUOW
+}
+
+func TestAccountUseCase_Create(t *testing.T) {
+	t.Run("returns error for nil request", func(t *testing.T) {
+		usecase, _, _ := newTestAccountUseCase(&MockAccountRepository{})
+		resp, err := usecase.Create(context.Background(), nil)
+		assert.Nil(t, resp)
+		assert.EqualError(t, err, "request cannot be nil")
+	})
+
+	t.Run("saves a card with a negative opening balance", func(t *testing.T) {
+		userID, _ := identifier.NewID()
+		usecase, txRepo, txUOW := newTestAccountUseCase(&MockAccountRepository{})
+		txRepo.On("Save", mock.Anything, mock.MatchedBy(func(a account.Account) bool {
+			return a.UserID == userID && a.Type == account.TypeCreditCard && a.OpeningBalance.Cents() == -25000
+		})).Return(nil)
+
+		resp, err := usecase.Create(context.Background(), &CreateAccountRequest{
+			UserID:         userID.String(),
+			Currency:       "USD",
+			Name:           "Visa",
+			Type:           "credit_card",
+			OpeningBalance: -250,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(-25000), resp.BalanceCents)
+		txRepo.AssertExpectations(t)
+		txUOW.AssertCalled(t, "Commit")
+	})
+
+	t.Run("rejects an unknown type", func(t *testing.T) {
+		userID, _ := identifier.NewID()
+		usecase, txRepo, _ := newTestAccountUseCase(&MockAccountRepository{})
+
+		_, err := usecase.Create(context.Background(), &CreateAccountRequest{
+			UserID:   userID.String(),
+			Currency: "USD",
+			Name:     "Coins",
+			Type:     "crypto",
+		})
+
+		assert.ErrorIs(t, err, account.ErrInvalidType)
+		txRepo.AssertNotCalled(t, "Save")
+	})
+}
+
+func TestAccountUseCase_List(t *testing.T) {
+	userID, _ := identifier.NewID()
+	checking := newTestAccount(t, userID, "Checking")
+	repo := &MockAccountRepository{}
+	repo.On("FindByUserID", mock.Anything, userID).Return([]account.Account{checking}, nil)
+	repo.On("Entries", mock.Anything, userID, checking.ID).Return([]account.Entry{
+		newTestAccountEntry(account.EntryIncome, 50000, 1, true),
+		newTestAccountEntry(account.EntryExpense, 2000, 2, false),
+		newTestAccountEntry(account.EntryTransferOut, 10000, 3, false),
+	}, nil)
+	usecase, _, _ := newTestAccountUseCase(repo)
+
+	accounts, err := usecase.List(context.Background(), userID.String())
+
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	assert.Equal(t, int64(48000), accounts[0].BalanceCents)
+	assert.Equal(t, 2, accounts[0].UnreconciledCount)
+}
+
+func TestAccountUseCase_Ledger(t *testing.T) {
+	userID, _ := identifier.NewID()
+	checking := newTestAccount(t, userID, "Checking")
+	repo := &MockAccountRepository{}
+	repo.On("FindByID", mock.Anything, userID, checking.ID).Return(checking, nil)
+	repo.On("Entries", mock.Anything, userID, checking.ID).Return([]account.Entry{
+		newTestAccountEntry(account.EntryExpense, 2000, 2, false),
+		newTestAccountEntry(account.EntryIncome, 50000, 1, false),
+	}, nil)
+	usecase, _, _ := newTestAccountUseCase(repo)
+
+	ledger, err := usecase.Ledger(context.Background(), userID.String(), checking.ID.String())
+
+	require.NoError(t, err)
+	require.Len(t, ledger.Entries, 2)
+	assert.Equal(t, int64(50000), ledger.Entries[0].AmountCents)
+	assert.Equal(t, int64(-2000), ledger.Entries[1].AmountCents)
+	assert.Equal(t, int64(58000), ledger.Entries[1].BalanceCents)
+}
+
+func TestAccountUseCase_Transfer(t *testing.T) {
+	userID, _ := identifier.NewID()
+	checking := newTestAccount(t, userID, "Checking")
+	savings := newTestAccount(t, userID, "Savings")
+	date := time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC)
+
+	t.Run("saves the transfer", func(t *testing.T) {
+		repo := &MockAccountRepository{}
+		repo.On("FindByID", mock.Anything, userID, checking.ID).Return(checking, nil)
+		repo.On("FindByID", mock.Anything, userID, savings.ID).Return(savings, nil)
+		usecase, txRepo, _ := newTestAccountUseCase(repo)
+		txRepo.On("SaveTransfer", mock.Anything, mock.MatchedBy(func(transfer account.Transfer) bool {
+			return transfer.FromAccountID == checking.ID && transfer.ToAccountID == savings.ID && transfer.Amount.Cents() == 5000
+		})).Return(nil)
+
+		err := usecase.Transfer(context.Background(), &TransferRequest{
+			UserID:        userID.String(),
+			Currency:      "USD",
+			FromAccountID: checking.ID.String(),
+			ToAccountID:   savings.ID.String(),
+			Amount:        50,
+			Date:          date,
+		})
+
+		require.NoError(t, err)
+		txRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects an account of another user", func(t *testing.T) {
+		otherID, _ := identifier.NewID()
+		repo := &MockAccountRepository{}
+		repo.On("FindByID", mock.Anything, userID, checking.ID).Return(checking, nil)
+		repo.On("FindByID", mock.Anything, userID, otherID).Return(account.Account{}, account.ErrAccountNotFound)
+		usecase, txRepo, _ := newTestAccountUseCase(repo)
+
+		err := usecase.Transfer(context.Background(), &TransferRequest{
+			UserID:        userID.String(),
+			Currency:      "USD",
+			FromAccountID: checking.ID.String(),
+			ToAccountID:   otherID.String(),
+			Amount:        50,
+			Date:          date,
+		})
+
+		assert.ErrorIs(t, err, account.ErrAccountNotFound)
+		txRepo.AssertNotCalled(t, "SaveTransfer")
+	})
+}
+
+func TestAccountUseCase_Reconcile(t *testing.T) {
+	userID, _ := identifier.NewID()
+	checking := newTestAccount(t, userID, "Checking")
+	statementDate := time.Date(2024, time.March, 2, 0, 0, 0, 0, time.UTC)
+	newRepo := func() *MockAccountRepository {
+		repo := &MockAccountRepository{}
+		repo.On("FindByID", mock.Anything, userID, checking.ID).Return(checking, nil)
+		repo.On("Entries", mock.Anything, userID, checking.ID).Return([]account.Entry{
+			newTestAccountEntry(account.EntryIncome, 50000, 1, false),
+			newTestAccountEntry(account.EntryExpense, 2000, 2, false),
+			newTestAccountEntry(account.EntryExpense, 1000, 20, false),
+		}, nil)
+		return repo
+	}
+	request := func(balance float64) *ReconcileRequest {
+		return &ReconcileRequest{
+			UserID:           userID.String(),
+			Currency:         "USD",
+			AccountID:        checking.ID.String(),
+			StatementDate:    statementDate,
+			StatementBalance: balance,
+		}
+	}
+
+	t.Run("compares the statement without saving", func(t *testing.T) {
+		usecase, txRepo, _ := newTestAccountUseCase(newRepo())
+
+		resp, err := usecase.CompareStatement(context.Background(), request(570))
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(58000), resp.ComputedBalanceCents)
+		assert.Equal(t, int64(-1000), resp.DifferenceCents)
+		assert.Len(t, resp.Unreconciled, 2)
+		assert.False(t, resp.Reconciled)
+		txRepo.AssertNotCalled(t, "SaveReconciliation")
+	})
+
+	t.Run("saves the entries up to the statement date", func(t *testing.T) {
+		usecase, txRepo, _ := newTestAccountUseCase(newRepo())
+		txRepo.On("SaveReconciliation", mock.Anything, mock.MatchedBy(func(r account.Reconciliation) bool {
+			return r.AccountID == checking.ID && len(r.Entries) == 2 && r.StatementBalance.Cents() == 58000
+		})).Return(nil)
+
+		resp, err := usecase.Reconcile(context.Background(), request(580))
+
+		require.NoError(t, err)
+		assert.True(t, resp.Reconciled)
+		assert.Empty(t, resp.Unreconciled)
+		txRepo.AssertExpectations(t)
+	})
+
+	t.Run("refuses a statement that does not match", func(t *testing.T) {
+		usecase, txRepo, _ := newTestAccountUseCase(newRepo())
+
+		resp, err := usecase.Reconcile(context.Background(), request(570))
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, account.ErrReconciliationMismatch)
+		txRepo.AssertNotCalled(t, "SaveReconciliation")
+	})
+}
+
+func TestResolveAccountID(t *testing.T) {
+	userID, _ := identifier.NewID()
+
+	t.Run("no account", func(t *testing.T) {
+		id, err := resolveAccountID(context.Background(), &MockUnitOfWork{}, userID, "")
+		require.NoError(t, err)
+		assert.True(t, id.IsZero())
+	})
+
+	t.Run("account of another user", func(t *testing.T) {
+		otherID, _ := identifier.NewID()
+		_, err := resolveAccountID(context.Background(), &MockUnitOfWork{}, userID, otherID.String())
+		assert.ErrorIs(t, err, account.ErrAccountNotFound)
+	})
+}
@@ -0,0 +1,305 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain"
+	"github.com/madalinpopa/gocost-web/internal/domain/alert"
+	"github.com/madalinpopa/gocost-web/internal/domain/expense"
+	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+)
+
+type AlertUseCaseImpl struct {
+	uow    domain.UnitOfWork
+	logger *slog.Logger
+}
+
+func NewAlertUseCase(uow domain.UnitOfWork, logger *slog.Logger) AlertUseCaseImpl {
+	return AlertUseCaseImpl{
+		uow:    uow,
+		logger: logger,
+	}
+}
+
+// Settings lists the categories active in the month, subcategories right
+// after their parent, with the thresholds set for each.
+func (u AlertUseCaseImpl) Settings(ctx context.Context, userID string, month string) ([]AlertGroupResponse, error) {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := u.uow.TrackingRepository().FindByUserIDAndMonth(ctx, uID, month)
+	if err != nil {
+		return nil, err
+	}
+
+	thresholds, err := u.uow.AlertRepository().Thresholds(ctx, uID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]AlertGroupResponse, 0, len(groups))
+	for i := range groups {
+		group := &groups[i]
+		response := AlertGroupResponse{ID: group.ID.String(), Name: group.Name.Value()}
+		for _, category := range group.TopLevelCategories() {
+			response.Categories = append(response.Categories, mapAlertCategoryToResponse(category, thresholds))
+			for _, sub := range group.Subcategories(category.ID) {
+				response.Categories = append(response.Categories, mapAlertCategoryToResponse(sub, thresholds))
+			}
+		}
+		if len(response.Categories) > 0 {
+			responses = append(responses, response)
+		}
+	}
+	return responses, nil
+}
+
+// SaveThresholds replaces the thresholds of a category. No thresholds turn
+// its alerts off.
+func (u AlertUseCaseImpl) SaveThresholds(ctx context.Context, req *SaveThresholdsRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	uID, err := identifier.ParseID(req.UserID)
+	if err != nil {
+		return err
+	}
+
+	categoryID, err := identifier.ParseID(req.CategoryID)
+	if err != nil {
+		return tracking.ErrCategoryNotFound
+	}
+
+	group, err := u.uow.TrackingRepository().FindGroupByCategoryID(ctx, categoryID)
+	if err != nil {
+		return err
+	}
+	if group.UserID != uID {
+		return tracking.ErrCategoryNotFound
+	}
+
+	thresholds, err := alert.NewThresholds(req.Thresholds)
+	if err != nil {
+		return err
+	}
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := txUOW.AlertRepository().SaveThresholds(ctx, categoryID, thresholds); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func (u AlertUseCaseImpl) Notifications(ctx context.Context, userID string, limit int) ([]NotificationResponse, error) {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, err := u.uow.AlertRepository().Notifications(ctx, uID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]NotificationResponse, 0, len(notifications))
+	for _, n := range notifications {
+		responses = append(responses, NotificationResponse{
+			ID:           n.ID.String(),
+			CategoryID:   n.CategoryID.String(),
+			CategoryName: n.CategoryName,
+			Month:        n.Month,
+			Threshold:    n.Threshold.Value(),
+			SpentCents:   n.Spent.Cents(),
+			BudgetCents:  n.Budget.Cents(),
+			CreatedAt:    n.CreatedAt,
+			IsRead:       n.IsRead(),
+		})
+	}
+	return responses, nil
+}
+
+func (u AlertUseCaseImpl) UnreadCount(ctx context.Context, userID string) (int, error) {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return u.uow.AlertRepository().CountUnread(ctx, uID)
+}
+
+func (u AlertUseCaseImpl) MarkRead(ctx context.Context, userID string, id string) error {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return err
+	}
+
+	notificationID, err := identifier.ParseID(id)
+	if err != nil {
+		return alert.ErrNotificationNotFound
+	}
+
+	return u.uow.AlertRepository().MarkRead(ctx, uID, notificationID, time.Now())
+}
+
+func (u AlertUseCaseImpl) MarkAllRead(ctx context.Context, userID string) error {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return err
+	}
+
+	return u.uow.AlertRepository().MarkAllRead(ctx, uID, time.Now())
+}
+
+func mapAlertCategoryToResponse(category *tracking.Category, thresholds map[identifier.ID][]alert.Threshold) AlertCategoryResponse {
+	response := AlertCategoryResponse{
+		ID:       category.ID.String(),
+		ParentID: parentIDString(category.ParentID),
+		Name:     category.Name.Value(),
+	}
+	for _, t := range thresholds[category.ID] {
+		response.Thresholds = append(response.Thresholds, t.Value())
+	}
+	return response
+}
+
+// budgetWatch tells which alert thresholds a change to a user's expenses
+// crosses. It compares the spending of the categories in the given groups,
+// rolled up the way the dashboard shows it, before and after the change.
+type budgetWatch struct {
+	userID     identifier.ID
+	currency   string
+	groups     []tracking.Group
+	thresholds map[identifier.ID][]alert.Threshold
+	before     map[string]map[string]spendingTotals
+}
+
+// watchBudgets records the spending in the months before a change. It
+// returns nil when no category in the groups has thresholds.
+func watchBudgets(ctx context.Context, uow domain.UnitOfWork, userID identifier.ID, currency string, groups []tracking.Group, months []string) (*budgetWatch, error) {
+	thresholds, err := uow.AlertRepository().Thresholds(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	watched := false
+	for _, group := range groups {
+		for _, category := range group.Categories {
+			if len(thresholds[category.ID]) > 0 {
+				watched = true
+			}
+		}
+	}
+	if !watched {
+		return nil, nil
+	}
+
+	w := &budgetWatch{
+		userID:     userID,
+		currency:   currency,
+		groups:     groups,
+		thresholds: thresholds,
+		before:     make(map[string]map[string]spendingTotals, len(months)),
+	}
+	for _, month := range months {
+		if _, ok := w.before[month]; ok {
+			continue
+		}
+		totals, err := uow.ExpenseRepository().TotalsByCategoryAndMonth(ctx, userID, month)
+		if err != nil {
+			return nil, err
+		}
+		w.before[month] = mapSpendingTotals(totals)
+	}
+	return w, nil
+}
+
+// notify records a notification for every threshold the change crossed.
+// It runs in the transaction of the change.
+func (w *budgetWatch) notify(ctx context.Context, txUOW domain.UnitOfWork, now time.Time) error {
+	if w == nil {
+		return nil
+	}
+
+	for month, before := range w.before {
+		totals, err := txUOW.ExpenseRepository().TotalsByCategoryAndMonth(ctx, w.userID, month)
+		if err != nil {
+			return err
+		}
+		after := mapSpendingTotals(totals)
+
+		for i := range w.groups {
+			group := &w.groups[i]
+			for _, category := range group.Categories {
+				thresholds := w.thresholds[category.ID]
+				if len(thresholds) == 0 {
+					continue
+				}
+
+				was := buildDashboardCategory(group, category, before, nil)
+				is := buildDashboardCategory(group, category, after, nil)
+				for _, t := range alert.Crossed(thresholds, is.BudgetCents, was.SpentCents, is.SpentCents) {
+					if err := w.record(ctx, txUOW, category.ID, month, t, is, now); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (w *budgetWatch) record(ctx context.Context, txUOW domain.UnitOfWork, categoryID identifier.ID, month string, threshold alert.Threshold, category DashboardCategoryResponse, now time.Time) error {
+	id, err := identifier.NewID()
+	if err != nil {
+		return err
+	}
+
+	spent, err := money.New(category.SpentCents, w.currency)
+	if err != nil {
+		return err
+	}
+	budget, err := money.New(category.BudgetCents, w.currency)
+	if err != nil {
+		return err
+	}
+
+	notification, err := alert.NewNotification(id, w.userID, categoryID, month, threshold, spent, budget, now)
+	if err != nil {
+		return err
+	}
+
+	_, err = txUOW.AlertRepository().SaveNotification(ctx, *notification)
+	return err
+}
+
+func mapSpendingTotals(totals []expense.CategoryTotals) map[string]spendingTotals {
+	byCategory := make(map[string]spendingTotals, len(totals))
+	for _, t := range totals {
+		byCategory[t.CategoryID.String()] = spendingTotals{
+			spentCents: t.Total.Cents(),
+			paidCents:  t.PaidTotal.Cents(),
+		}
+	}
+	return byCategory
+}
+
+var _ AlertUseCase = (*AlertUseCaseImpl)(nil)
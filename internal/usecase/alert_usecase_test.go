@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/alert"
+	"github.com/madalinpopa/gocost-web/internal/domain/expense"
+	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCategoryTotals(categoryID identifier.ID, cents int64) expense.CategoryTotals {
+	total, _ := money.New(cents, "USD")
+	paid, _ := money.New(0, "USD")
+	return expense.CategoryTotals{CategoryID: categoryID, Total: total, PaidTotal: paid}
+}
+
+func TestAlertUseCase_SaveThresholds(t *testing.T) {
+	userID, _ := identifier.NewID()
+	group := newTestGroup(t, userID)
+	categoryID, _ := identifier.NewID()
+
+	trackingRepo := &MockGroupRepository{}
+	trackingRepo.On("FindGroupByCategoryID", mock.Anything, categoryID).Return(*group, nil)
+
+	t.Run("saves the thresholds sorted", func(t *testing.T) {
+		txRepo := &MockAlertRepository{}
+		txRepo.On("SaveThresholds", mock.Anything, categoryID, []alert.Threshold{80, 100}).Return(nil)
+		txUOW := &MockUnitOfWork{AlertRepo: txRepo}
+		txUOW.On("Commit").Return(nil)
+		uow := &MockUnitOfWork{TrackingRepo: trackingRepo}
+		uow.On("Begin", mock.Anything).Return(txUOW, nil)
+		usecase := NewAlertUseCase(uow, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		err := usecase.SaveThresholds(context.Background(), &SaveThresholdsRequest{
+			UserID:     userID.String(),
+			CategoryID: categoryID.String(),
+			Thresholds: []int{100, 80},
+		})
+
+		require.NoError(t, err)
+		txRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects a category of another user", func(t *testing.T) {
+		otherUserID, _ := identifier.NewID()
+		usecase := NewAlertUseCase(&MockUnitOfWork{TrackingRepo: trackingRepo}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		err := usecase.SaveThresholds(context.Background(), &SaveThresholdsRequest{
+			UserID:     otherUserID.String(),
+			CategoryID: categoryID.String(),
+			Thresholds: []int{80},
+		})
+
+		assert.ErrorIs(t, err, tracking.ErrCategoryNotFound)
+	})
+
+	t.Run("rejects a threshold out of range", func(t *testing.T) {
+		usecase := NewAlertUseCase(&MockUnitOfWork{TrackingRepo: trackingRepo}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		err := usecase.SaveThresholds(context.Background(), &SaveThresholdsRequest{
+			UserID:     userID.String(),
+			CategoryID: categoryID.String(),
+			Thresholds: []int{0},
+		})
+
+		assert.ErrorIs(t, err, alert.ErrInvalidThreshold)
+	})
+}
+
+func TestExpenseUseCase_Create_BudgetAlerts(t *testing.T) {
+	userID, _ := identifier.NewID()
+	group := newTestGroup(t, userID)
+
+	name, _ := tracking.NewNameVO("Food")
+	subName, _ := tracking.NewNameVO("Groceries")
+	desc, _ := tracking.NewDescriptionVO("")
+	startMonth, _ := tracking.ParseMonth("2024-01")
+	budget, _ := money.New(10000, "USD")
+	noBudget, _ := money.New(0, "USD")
+
+	parentID, _ := identifier.NewID()
+	subID, _ := identifier.NewID()
+	_, err := group.CreateCategory(parentID, name, desc, true, startMonth, tracking.Month{}, noBudget)
+	require.NoError(t, err)
+	_, err = group.CreateSubcategory(parentID, subID, subName, desc, true, startMonth, tracking.Month{}, budget)
+	require.NoError(t, err)
+
+	trackingRepo := &MockGroupRepository{}
+	trackingRepo.On("FindGroupByCategoryID", mock.Anything, subID).Return(*group, nil)
+
+	alertRepo := &MockAlertRepository{}
+	alertRepo.On("Thresholds", mock.Anything, userID).Return(map[identifier.ID][]alert.Threshold{
+		parentID: {80, 100},
+		subID:    {100},
+	}, nil)
+
+	// 70.00 spent before and 90.00 after the expense: the parent, budgeted
+	// at the sum of its subcategories, reaches 80% and nothing else.
+	expenseRepo := &MockExpenseRepository{}
+	expenseRepo.On("TotalsByCategoryAndMonth", mock.Anything, userID, "2024-03").Return([]expense.CategoryTotals{
+		newTestCategoryTotals(subID, 7000),
+	}, nil)
+	txExpenseRepo := &MockExpenseRepository{}
+	txExpenseRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	txExpenseRepo.On("TotalsByCategoryAndMonth", mock.Anything, userID, "2024-03").Return([]expense.CategoryTotals{
+		newTestCategoryTotals(subID, 9000),
+	}, nil)
+
+	txAlertRepo := &MockAlertRepository{}
+	txAlertRepo.On("SaveNotification", mock.Anything, mock.MatchedBy(func(n alert.Notification) bool {
+		return n.CategoryID == parentID && n.Month == "2024-03" && n.Threshold == 80 &&
+			n.Spent.Cents() == 9000 && n.Budget.Cents() == 10000
+	})).Return(true, nil).Once()
+
+	txUOW := &MockUnitOfWork{ExpenseRepo: txExpenseRepo, AlertRepo: txAlertRepo}
+	txUOW.On("Commit").Return(nil)
+	uow := &MockUnitOfWork{TrackingRepo: trackingRepo, ExpenseRepo: expenseRepo, AlertRepo: alertRepo}
+	uow.On("Begin", mock.Anything).Return(txUOW, nil)
+	usecase := NewExpenseUseCase(uow, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	_, err = usecase.Create(context.Background(), &CreateExpenseRequest{
+		UserID:      userID.String(),
+		Currency:    "USD",
+		CategoryID:  subID.String(),
+		Amount:      20,
+		Description: "Market",
+		SpentAt:     time.Date(2024, time.March, 12, 0, 0, 0, 0, time.UTC),
+	})
+
+	require.NoError(t, err)
+	txAlertRepo.AssertExpectations(t)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"time"
 
 	"github.com/madalinpopa/gocost-web/internal/domain"
 	"github.com/madalinpopa/gocost-web/internal/domain/identity"
@@ -11,28 +12,51 @@ import (
 	"github.com/madalinpopa/gocost-web/internal/platform/security"
 )
 
-var ErrInvalidCredentials = errors.New("invalid credentials")
+var (
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrRegistrationClosed = errors.New("registration is closed")
+)
 
 const minPasswordLength = 8
 
+// RegistrationMode says who can register.
+type RegistrationMode string
+
+const (
+	// RegistrationOpen lets anyone register.
+	RegistrationOpen RegistrationMode = "open"
+	// RegistrationInvite asks for a valid invite code to register.
+	RegistrationInvite RegistrationMode = "invite"
+	// RegistrationClosed refuses every registration.
+	RegistrationClosed RegistrationMode = "closed"
+)
+
 type AuthUseCaseImpl struct {
-	uow    domain.UnitOfWork
-	logger *slog.Logger
-	hasher security.PasswordHasher
+	uow          domain.UnitOfWork
+	logger       *slog.Logger
+	hasher       security.PasswordHasher
+	registration RegistrationMode
 }
 
-func NewAuthUseCase(uow domain.UnitOfWork, logger *slog.Logger, h security.PasswordHasher) AuthUseCaseImpl {
+func NewAuthUseCase(uow domain.UnitOfWork, logger *slog.Logger, h security.PasswordHasher, registration RegistrationMode) AuthUseCaseImpl {
 	return AuthUseCaseImpl{
-		uow:    uow,
-		logger: logger,
-		hasher: h,
+		uow:          uow,
+		logger:       logger,
+		hasher:       h,
+		registration: registration,
 	}
 }
 
+// Register creates a user, as far as the registration mode allows: with an
+// invite code while registration is by invitation, and never while it is
+// closed.
 func (u AuthUseCaseImpl) Register(ctx context.Context, req *RegisterUserRequest) (*UserResponse, error) {
 	if req == nil {
 		return nil, errors.New("register request is nil")
 	}
+	if u.registration != RegistrationOpen && u.registration != RegistrationInvite {
+		return nil, ErrRegistrationClosed
+	}
 
 	email, err := identity.NewEmailVO(req.Email)
 	if err != nil {
@@ -95,6 +119,13 @@ func (u AuthUseCaseImpl) Register(ctx context.Context, req *RegisterUserRequest)
 		return nil, err
 	}
 
+	if u.registration == RegistrationInvite {
+		if err := useInvite(ctx, txUOW, req.InviteCode); err != nil {
+			_ = txUOW.Rollback()
+			return nil, err
+		}
+	}
+
 	if err := txUOW.UserRepository().Save(ctx, *user); err != nil {
 		_ = txUOW.Rollback()
 		return nil, err
@@ -105,12 +136,25 @@ func (u AuthUseCaseImpl) Register(ctx context.Context, req *RegisterUserRequest)
 		return nil, err
 	}
 
-	return &UserResponse{
-		ID:       user.ID.String(),
-		Email:    user.Email.Value(),
-		Username: user.Username.Value(),
-		Currency: user.Currency.Value(),
-	}, nil
+	return mapUserToResponse(*user), nil
+}
+
+// useInvite takes one use of the invite with code, within the transaction
+// that registers the user so that a failed registration does not use it up.
+func useInvite(ctx context.Context, uow domain.UnitOfWork, code string) error {
+	if code == "" {
+		return identity.ErrInvalidInvite
+	}
+
+	repo := uow.InviteRepository()
+	invite, err := repo.FindByCodeHash(ctx, security.HashToken(security.NormalizeInviteCode(code)))
+	if err != nil {
+		return err
+	}
+	if err := invite.Use(time.Now()); err != nil {
+		return err
+	}
+	return repo.Save(ctx, invite)
 }
 
 func (u AuthUseCaseImpl) Login(ctx context.Context, req *LoginRequest) (*LoginResponse, error) {
@@ -151,6 +195,11 @@ func (u AuthUseCaseImpl) Login(ctx context.Context, req *LoginRequest) (*LoginRe
 		return nil, ErrInvalidCredentials
 	}
 
+	twoFactor, err := u.uow.TwoFactorRepository().FindByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, identity.ErrTwoFactorNotFound) {
+		return nil, err
+	}
+
 	return &LoginResponse{
 		UserID:   user.ID.String(),
 		Email:    user.Email.Value(),
@@ -158,5 +207,31 @@ func (u AuthUseCaseImpl) Login(ctx context.Context, req *LoginRequest) (*LoginRe
 		FullName: "",
 		Role:     "",
 		Currency: user.Currency.Value(),
+
+		EmailVerified:     user.EmailVerified,
+		TwoFactorRequired: err == nil && twoFactor.IsEnabled(),
 	}, nil
 }
+
+// ConfirmPassword checks the password of a logged-in user, who is asked for it
+// again before sensitive actions.
+func (u AuthUseCaseImpl) ConfirmPassword(ctx context.Context, req *ConfirmPasswordRequest) error {
+	if req == nil {
+		return errors.New("confirm password request is nil")
+	}
+
+	uID, err := identifier.ParseID(req.UserID)
+	if err != nil {
+		return err
+	}
+
+	user, err := u.uow.UserRepository().FindByID(ctx, uID)
+	if err != nil {
+		return err
+	}
+	if !u.hasher.CheckPasswordHash(req.Password, user.Password.Value()) {
+		return ErrInvalidCredentials
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/madalinpopa/gocost-web/internal/domain/identity"
 	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
@@ -21,17 +22,21 @@ func newTestAuthUseCase(repo *MockUserRepository) AuthUseCaseImpl {
 		repo = &MockUserRepository{}
 	}
 
+	twoFactorRepo := &MockTwoFactorRepository{}
+	twoFactorRepo.On("FindByUserID", mock.Anything, mock.Anything).Return(identity.TwoFactor{}, identity.ErrTwoFactorNotFound)
+
 	txUOW := &MockUnitOfWork{UserRepo: repo}
 	txUOW.On("Commit").Return(nil)
 	txUOW.On("Rollback").Return(nil)
 
-	baseUOW := &MockUnitOfWork{UserRepo: repo}
+	baseUOW := &MockUnitOfWork{UserRepo: repo, TwoFactorRepo: twoFactorRepo}
 	baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
 
 	return NewAuthUseCase(
 		baseUOW,
 		slog.New(slog.NewTextHandler(io.Discard, nil)),
 		security.NewPasswordHasher(),
+		RegistrationOpen,
 	)
 }
 
@@ -270,6 +275,145 @@ func TestAuthUseCase_Register(t *testing.T) {
 	})
 }
 
+func TestAuthUseCase_RegisterWithInvite(t *testing.T) {
+	newUseCase := func(userRepo *MockUserRepository, inviteRepo *MockInviteRepository) AuthUseCaseImpl {
+		txUOW := &MockUnitOfWork{UserRepo: userRepo, InviteRepo: inviteRepo}
+		txUOW.On("Commit").Return(nil)
+		txUOW.On("Rollback").Return(nil)
+
+		baseUOW := &MockUnitOfWork{UserRepo: userRepo, InviteRepo: inviteRepo}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+
+		return NewAuthUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)), security.NewPasswordHasher(), RegistrationInvite)
+	}
+
+	newUserRepo := func() *MockUserRepository {
+		repo := &MockUserRepository{}
+		repo.On("ExistsByEmail", mock.Anything, mock.Anything).Return(false, nil)
+		repo.On("ExistsByUsername", mock.Anything, mock.Anything).Return(false, nil)
+		repo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		return repo
+	}
+
+	newRequest := func(code string) *RegisterUserRequest {
+		return &RegisterUserRequest{
+			EmailRequest:    EmailRequest{Email: "user@example.com"},
+			UsernameRequest: UsernameRequest{Username: "validuser"},
+			Password:        "password1",
+			Currency:        "USD",
+			InviteCode:      code,
+		}
+	}
+
+	t.Run("uses the invite as typed by the user", func(t *testing.T) {
+		invite := newTestInvite(t, "abcde-fghij-klmno", 2)
+		inviteRepo := &MockInviteRepository{}
+		inviteRepo.On("FindByCodeHash", mock.Anything, invite.CodeHash).Return(invite, nil)
+		inviteRepo.On("Save", mock.Anything, mock.MatchedBy(func(i identity.Invite) bool {
+			return i.ID == invite.ID && i.Uses == 1
+		})).Return(nil)
+		userRepo := newUserRepo()
+
+		resp, err := newUseCase(userRepo, inviteRepo).Register(context.Background(), newRequest(" ABCDE FGHIJ KLMNO "))
+
+		require.NoError(t, err)
+		assert.NotNil(t, resp)
+		inviteRepo.AssertExpectations(t)
+		userRepo.AssertCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a missing code", func(t *testing.T) {
+		userRepo := newUserRepo()
+
+		resp, err := newUseCase(userRepo, &MockInviteRepository{}).Register(context.Background(), newRequest(""))
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, identity.ErrInvalidInvite)
+		userRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an unknown code", func(t *testing.T) {
+		inviteRepo := &MockInviteRepository{}
+		inviteRepo.On("FindByCodeHash", mock.Anything, mock.Anything).Return(identity.Invite{}, identity.ErrInvalidInvite)
+		userRepo := newUserRepo()
+
+		resp, err := newUseCase(userRepo, inviteRepo).Register(context.Background(), newRequest("abcde-fghij-klmno"))
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, identity.ErrInvalidInvite)
+		userRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a used up code", func(t *testing.T) {
+		invite := newTestInvite(t, "abcde-fghij-klmno", 1)
+		invite.Uses = 1
+		inviteRepo := &MockInviteRepository{}
+		inviteRepo.On("FindByCodeHash", mock.Anything, invite.CodeHash).Return(invite, nil)
+		userRepo := newUserRepo()
+
+		resp, err := newUseCase(userRepo, inviteRepo).Register(context.Background(), newRequest("abcde-fghij-klmno"))
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, identity.ErrInvalidInvite)
+		inviteRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		userRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthUseCase_RegisterModes(t *testing.T) {
+	newUseCase := func(userRepo *MockUserRepository, inviteRepo *MockInviteRepository, registration RegistrationMode) AuthUseCaseImpl {
+		txUOW := &MockUnitOfWork{UserRepo: userRepo, InviteRepo: inviteRepo}
+		txUOW.On("Commit").Return(nil)
+		txUOW.On("Rollback").Return(nil)
+
+		baseUOW := &MockUnitOfWork{UserRepo: userRepo, InviteRepo: inviteRepo}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+
+		return NewAuthUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)), security.NewPasswordHasher(), registration)
+	}
+
+	req := &RegisterUserRequest{
+		EmailRequest:    EmailRequest{Email: "user@example.com"},
+		UsernameRequest: UsernameRequest{Username: "validuser"},
+		Password:        "password1",
+		Currency:        "USD",
+		InviteCode:      "abcde-fghij-klmno",
+	}
+
+	t.Run("refuses every registration while closed", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		inviteRepo := &MockInviteRepository{}
+
+		resp, err := newUseCase(userRepo, inviteRepo, RegistrationClosed).Register(context.Background(), req)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, ErrRegistrationClosed)
+		userRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		inviteRepo.AssertNotCalled(t, "FindByCodeHash", mock.Anything, mock.Anything)
+	})
+
+	t.Run("refuses registration without a mode", func(t *testing.T) {
+		resp, err := newUseCase(&MockUserRepository{}, &MockInviteRepository{}, "").Register(context.Background(), req)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, ErrRegistrationClosed)
+	})
+
+	t.Run("does not use invites while open", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		userRepo.On("ExistsByEmail", mock.Anything, mock.Anything).Return(false, nil)
+		userRepo.On("ExistsByUsername", mock.Anything, mock.Anything).Return(false, nil)
+		userRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		inviteRepo := &MockInviteRepository{}
+
+		resp, err := newUseCase(userRepo, inviteRepo, RegistrationOpen).Register(context.Background(), req)
+
+		require.NoError(t, err)
+		assert.NotNil(t, resp)
+		inviteRepo.AssertNotCalled(t, "FindByCodeHash", mock.Anything, mock.Anything)
+	})
+}
+
 func TestAuthUseCase_Login(t *testing.T) {
 	t.Run("returns error for nil request", func(t *testing.T) {
 		usecase := newTestAuthUseCase(&MockUserRepository{})
@@ -455,4 +599,64 @@ func TestAuthUseCase_Login(t *testing.T) {
 		assert.Equal(t, user.Username.Value(), resp.Username)
 		assert.Equal(t, "USD", resp.Currency)
 	})
+	t.Run("asks for a second factor when it is enabled", func(t *testing.T) {
+		hasher := security.NewPasswordHasher()
+		hash, err := hasher.HashPassword("password1")
+		require.NoError(t, err)
+
+		user := newTestUser(t, "user@example.com", "validuser", hash)
+		repo := &MockUserRepository{}
+		repo.On("FindByEmail", mock.Anything, mock.Anything).Return(user, nil)
+		twoFactor := identity.NewTwoFactor(user.ID, "SECRET")
+		require.NoError(t, twoFactor.Confirm(1, nil, time.Now()))
+		twoFactorRepo := &MockTwoFactorRepository{}
+		twoFactorRepo.On("FindByUserID", mock.Anything, user.ID).Return(*twoFactor, nil)
+
+		usecase := NewAuthUseCase(
+			&MockUnitOfWork{UserRepo: repo, TwoFactorRepo: twoFactorRepo},
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+			hasher,
+			RegistrationOpen,
+		)
+
+		resp, err := usecase.Login(context.Background(), &LoginRequest{
+			EmailOrUsername: "user@example.com",
+			Password:        "password1",
+		})
+
+		require.NoError(t, err)
+		assert.True(t, resp.TwoFactorRequired)
+	})
+}
+
+func TestAuthUseCase_ConfirmPassword(t *testing.T) {
+	hash, err := security.NewPasswordHasher().HashPassword("password1")
+	require.NoError(t, err)
+	user := newTestUser(t, "alice@example.com", "alice", hash)
+
+	t.Run("accepts the password of the user", func(t *testing.T) {
+		repo := &MockUserRepository{}
+		repo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+		usecase := newTestAuthUseCase(repo)
+
+		err := usecase.ConfirmPassword(context.Background(), &ConfirmPasswordRequest{
+			UserID:   user.ID.String(),
+			Password: "password1",
+		})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects a wrong password", func(t *testing.T) {
+		repo := &MockUserRepository{}
+		repo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+		usecase := newTestAuthUseCase(repo)
+
+		err := usecase.ConfirmPassword(context.Background(), &ConfirmPasswordRequest{
+			UserID:   user.ID.String(),
+			Password: "wrong-password",
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
 }
@@ -0,0 +1,382 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+
+	"github.com/madalinpopa/gocost-web/internal/domain"
+	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+)
+
+var (
+	ErrOverAssigned          = errors.New("budget exceeds the income left to assign")
+	ErrNothingToAssign       = errors.New("there is no income left to assign")
+	ErrInvalidShares         = errors.New("shares must be positive and add up to at most 100 percent")
+	ErrCategoryNotAssignable = errors.New("category cannot be assigned a budget this month")
+)
+
+type BudgetUseCaseImpl struct {
+	uow    domain.UnitOfWork
+	logger *slog.Logger
+}
+
+func NewBudgetUseCase(uow domain.UnitOfWork, logger *slog.Logger) BudgetUseCaseImpl {
+	return BudgetUseCaseImpl{
+		uow:    uow,
+		logger: logger,
+	}
+}
+
+// SetZeroBased turns zero-based budgeting on or off for the user.
+func (u BudgetUseCaseImpl) SetZeroBased(ctx context.Context, userID string, enabled bool) error {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return err
+	}
+
+	user, err := u.uow.UserRepository().FindByID(ctx, uID)
+	if err != nil {
+		return err
+	}
+	if user.ZeroBasedBudgeting == enabled {
+		return nil
+	}
+	user.ZeroBasedBudgeting = enabled
+
+	return u.uow.UserRepository().Save(ctx, user)
+}
+
+// Assignment reports how much of the month's income is left to assign and
+// the categories that can take it. A top-level category is assignable when it
+// has a budget of its own or no subcategories to sum up.
+func (u BudgetUseCaseImpl) Assignment(ctx context.Context, userID string, month string) (*BudgetAssignmentResponse, error) {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := tracking.ParseMonth(month)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.assignment(ctx, uID, m)
+}
+
+// FillFromLastMonth sets the category's budget to what it spent the month
+// before. Raising the budget beyond the income left to assign is refused.
+func (u BudgetUseCaseImpl) FillFromLastMonth(ctx context.Context, req *FillBudgetRequest) (*BudgetAssignmentResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	uID, err := identifier.ParseID(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := tracking.ParseMonth(req.Month)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ensureMonthOpen(ctx, u.uow, uID, m.Value()); err != nil {
+		return nil, err
+	}
+
+	assignment, err := u.assignment(ctx, uID, m)
+	if err != nil {
+		return nil, err
+	}
+
+	category, err := findAssignable(assignment, req.GroupID, req.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	increase := category.LastMonthSpentCents - category.BudgetCents
+	if increase == 0 {
+		return assignment, nil
+	}
+	if increase > 0 && increase > assignment.LeftCents {
+		return nil, ErrOverAssigned
+	}
+
+	budgets := map[string]int64{category.CategoryID: category.LastMonthSpentCents}
+	if err := u.applyBudgets(ctx, uID, m, req.Currency, budgets); err != nil {
+		return nil, err
+	}
+
+	return u.assignment(ctx, uID, m)
+}
+
+// Distribute splits the income left to assign between categories by
+// percentage, adding each share to the category's current budget. Shares are
+// rounded down to the cent so the month is never over-assigned.
+func (u BudgetUseCaseImpl) Distribute(ctx context.Context, req *DistributeBudgetRequest) (*BudgetAssignmentResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	if err := validateShares(req.Shares); err != nil {
+		return nil, err
+	}
+
+	uID, err := identifier.ParseID(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := tracking.ParseMonth(req.Month)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ensureMonthOpen(ctx, u.uow, uID, m.Value()); err != nil {
+		return nil, err
+	}
+
+	assignment, err := u.assignment(ctx, uID, m)
+	if err != nil {
+		return nil, err
+	}
+	if assignment.LeftCents <= 0 {
+		return nil, ErrNothingToAssign
+	}
+
+	budgets := make(map[string]int64, len(req.Shares))
+	for _, share := range req.Shares {
+		category, err := findAssignable(assignment, "", share.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+
+		amount := int64(math.Floor(float64(assignment.LeftCents) * share.Percent / 100))
+		if amount == 0 {
+			continue
+		}
+		budgets[category.CategoryID] = category.BudgetCents + amount
+	}
+
+	if len(budgets) == 0 {
+		return assignment, nil
+	}
+
+	if err := u.applyBudgets(ctx, uID, m, req.Currency, budgets); err != nil {
+		return nil, err
+	}
+
+	return u.assignment(ctx, uID, m)
+}
+
+func (u BudgetUseCaseImpl) assignment(ctx context.Context, uID identifier.ID, month tracking.Month) (*BudgetAssignmentResponse, error) {
+	current, err := buildDashboard(ctx, u.uow, uID, month.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := buildDashboard(ctx, u.uow, uID, month.Previous().Value())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &BudgetAssignmentResponse{
+		Month:         month.Value(),
+		IncomeCents:   current.TotalIncomeCents,
+		BudgetedCents: current.TotalBudgetedCents,
+		LeftCents:     current.TotalIncomeCents - current.TotalBudgetedCents - current.SavingsCents,
+	}
+
+	for _, group := range current.Groups {
+		if group.Archived {
+			continue
+		}
+		for _, category := range group.Categories {
+			if category.Archived || (category.OwnBudgetCents <= 0 && len(category.Subcategories) > 0) {
+				continue
+			}
+			resp.Categories = append(resp.Categories, AssignableCategoryResponse{
+				GroupID:             group.ID,
+				GroupName:           group.Name,
+				CategoryID:          category.ID,
+				Name:                category.Name,
+				BudgetCents:         category.BudgetCents,
+				LastMonthSpentCents: lastMonthSpent(previous, group, category),
+			})
+		}
+	}
+
+	return resp, nil
+}
+
+// lastMonthSpent looks the category up in the previous month's dashboard by
+// ID, or by name within the same group when it has since been forked.
+func lastMonthSpent(previous *DashboardResponse, group DashboardGroupResponse, category DashboardCategoryResponse) int64 {
+	for _, g := range previous.Groups {
+		if g.ID != group.ID {
+			continue
+		}
+		for _, c := range g.Categories {
+			if c.ID == category.ID {
+				return c.SpentCents
+			}
+		}
+		for _, c := range g.Categories {
+			if c.Name == category.Name {
+				return c.SpentCents
+			}
+		}
+	}
+	return 0
+}
+
+// applyBudgets sets the month's budget of each category. Recurring categories
+// that started earlier are forked so that previous months keep their budget;
+// their subcategories are carried over to the fork.
+func (u BudgetUseCaseImpl) applyBudgets(ctx context.Context, uID identifier.ID, month tracking.Month, currency string, budgets map[string]int64) error {
+	groups, err := u.uow.TrackingRepository().FindByUserID(ctx, uID)
+	if err != nil {
+		return err
+	}
+
+	forked := make(map[tracking.ID]tracking.ID)
+	var changed []tracking.Group
+	applied := 0
+
+	for i := range groups {
+		group := &groups[i]
+		groupChanged := false
+
+		for _, c := range group.TopLevelCategories() {
+			cents, ok := budgets[c.ID.String()]
+			if !ok || !c.IsActiveFor(month) {
+				continue
+			}
+
+			budget, err := money.New(cents, currency)
+			if err != nil {
+				return err
+			}
+
+			if !c.IsRecurrent || !c.StartMonth.Before(month) {
+				if _, err := group.UpdateCategory(c.ID, c.Name, c.Description, c.IsRecurrent, c.StartMonth, c.EndMonth, budget); err != nil {
+					return err
+				}
+			} else if err := forkWithSubcategories(group, c, month, budget, forked); err != nil {
+				return err
+			}
+
+			applied++
+			groupChanged = true
+		}
+
+		if groupChanged {
+			changed = append(changed, *group)
+		}
+	}
+
+	if applied != len(budgets) {
+		return ErrCategoryNotAssignable
+	}
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range changed {
+		if err := txUOW.TrackingRepository().Save(ctx, group); err != nil {
+			_ = txUOW.Rollback()
+			return err
+		}
+	}
+
+	for oldID, newID := range forked {
+		if err := txUOW.ExpenseRepository().ReassignCategoryFromMonth(ctx, uID, oldID, newID, month.Value()); err != nil {
+			_ = txUOW.Rollback()
+			return err
+		}
+		if err := txUOW.AlertRepository().ForkCategory(ctx, oldID, newID, month.Value()); err != nil {
+			_ = txUOW.Rollback()
+			return err
+		}
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func forkWithSubcategories(group *tracking.Group, category *tracking.Category, month tracking.Month, budget money.Money, forked map[tracking.ID]tracking.ID) error {
+	descendants := group.Descendants(category.ID)
+
+	newID, err := identifier.NewID()
+	if err != nil {
+		return err
+	}
+	if _, err := group.ForkCategory(category.ID, newID, category.ParentID, month, budget); err != nil {
+		return err
+	}
+	forked[category.ID] = newID
+
+	for _, sub := range descendants {
+		newParentID, ok := forked[sub.ParentID]
+		if !ok || !sub.IsRecurrent || !sub.StartMonth.Before(month) || !sub.IsActiveFor(month) {
+			continue
+		}
+
+		subID, err := identifier.NewID()
+		if err != nil {
+			return err
+		}
+		if _, err := group.ForkCategory(sub.ID, subID, newParentID, month, sub.Budget); err != nil {
+			return err
+		}
+		forked[sub.ID] = subID
+	}
+
+	return nil
+}
+
+func findAssignable(assignment *BudgetAssignmentResponse, groupID string, categoryID string) (*AssignableCategoryResponse, error) {
+	for i := range assignment.Categories {
+		c := &assignment.Categories[i]
+		if c.CategoryID == categoryID && (groupID == "" || c.GroupID == groupID) {
+			return c, nil
+		}
+	}
+	return nil, ErrCategoryNotAssignable
+}
+
+func validateShares(shares []BudgetShare) error {
+	if len(shares) == 0 {
+		return ErrInvalidShares
+	}
+
+	seen := make(map[string]struct{}, len(shares))
+	var total float64
+	for _, share := range shares {
+		if share.Percent <= 0 {
+			return ErrInvalidShares
+		}
+		if _, ok := seen[share.CategoryID]; ok {
+			return ErrInvalidShares
+		}
+		seen[share.CategoryID] = struct{}{}
+		total += share.Percent
+	}
+	if total > 100 {
+		return ErrInvalidShares
+	}
+
+	return nil
+}
+
+var _ BudgetUseCase = (*BudgetUseCaseImpl)(nil)
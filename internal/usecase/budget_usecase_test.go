@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
+	"github.com/madalinpopa/gocost-web/internal/domain/expense"
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// budgetFixture is a group with Food (200.00) and Rent (1000.00) budgeted
+// since January, where Food spent 300.00 in February.
+type budgetFixture struct {
+	userID       identifier.ID
+	group        *tracking.Group
+	food         *tracking.Category
+	rent         *tracking.Category
+	trackingRepo *MockGroupRepository
+	incomeRepo   *MockIncomeRepository
+	expenseRepo  *MockExpenseRepository
+}
+
+func newBudgetFixture(t *testing.T, incomeCents int64) *budgetFixture {
+	t.Helper()
+
+	userID, _ := identifier.NewID()
+	group := newTestGroup(t, userID)
+	f := &budgetFixture{
+		userID:       userID,
+		group:        group,
+		food:         addPlanCategory(t, group, "Food", true, "2024-01", 20000),
+		rent:         addPlanCategory(t, group, "Rent", true, "2024-01", 100000),
+		trackingRepo: &MockGroupRepository{},
+		incomeRepo:   &MockIncomeRepository{},
+		expenseRepo:  &MockExpenseRepository{},
+	}
+
+	income, err := money.New(incomeCents, "USD")
+	require.NoError(t, err)
+	foodSpent, err := money.New(30000, "USD")
+	require.NoError(t, err)
+
+	f.stubMonth("2024-03", income, nil)
+	f.stubMonth("2024-02", money.Money{}, []expense.CategoryTotals{
+		{CategoryID: f.food.ID, Total: foodSpent, PaidTotal: foodSpent},
+	})
+	f.trackingRepo.On("FindByUserID", mock.Anything, userID).Return([]tracking.Group{*group}, nil).Maybe()
+
+	return f
+}
+
+func (f *budgetFixture) stubMonth(month string, income money.Money, totals []expense.CategoryTotals) {
+	f.trackingRepo.On("FindByUserIDAndMonth", mock.Anything, f.userID, month).Return([]tracking.Group{*f.group}, nil).Maybe()
+	f.incomeRepo.On("TotalByUserIDAndMonth", mock.Anything, f.userID, month).Return(income, nil).Maybe()
+	f.expenseRepo.On("Total", mock.Anything, f.userID, month).Return(money.Money{}, nil).Maybe()
+	f.expenseRepo.On("TotalsByCategoryAndMonth", mock.Anything, f.userID, month).Return(totals, nil).Maybe()
+	f.expenseRepo.On("FindByUserIDAndMonth", mock.Anything, f.userID, month).Return([]expense.Expense{}, nil).Maybe()
+}
+
+// newUseCase returns the use case and the unit of work used for the write
+// transaction, which records the saved group.
+func (f *budgetFixture) newUseCase(saved *tracking.Group) (BudgetUseCaseImpl, *MockUnitOfWork) {
+	txRepo := &MockGroupRepository{}
+	txRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		*saved = args.Get(1).(tracking.Group)
+	}).Maybe()
+	txExpenseRepo := &MockExpenseRepository{}
+	txExpenseRepo.On("ReassignCategoryFromMonth", mock.Anything, f.userID, mock.Anything, mock.Anything, "2024-03").Return(nil).Maybe()
+	txUOW := &MockUnitOfWork{TrackingRepo: txRepo, ExpenseRepo: txExpenseRepo}
+	txUOW.On("Commit").Return(nil).Maybe()
+
+	baseUOW := &MockUnitOfWork{TrackingRepo: f.trackingRepo, IncomeRepo: f.incomeRepo, ExpenseRepo: f.expenseRepo}
+	baseUOW.On("Begin", mock.Anything).Return(txUOW, nil).Maybe()
+
+	return NewBudgetUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil))), txUOW
+}
+
+func marchBudgets(t *testing.T, group tracking.Group) map[string]int64 {
+	t.Helper()
+
+	march, _ := tracking.ParseMonth("2024-03")
+	active, err := group.CategoriesForMonth(march)
+	require.NoError(t, err)
+
+	budgets := make(map[string]int64)
+	for _, c := range active {
+		budgets[c.Name.Value()] = c.Budget.Cents()
+	}
+	return budgets
+}
+
+func TestBudgetUseCase_SetZeroBased(t *testing.T) {
+	userID, _ := identifier.NewID()
+
+	userRepo := &MockUserRepository{}
+	userRepo.On("FindByID", mock.Anything, userID).Return(identity.User{ID: userID}, nil)
+	userRepo.On("Save", mock.Anything, mock.MatchedBy(func(u identity.User) bool {
+		return u.ID == userID && u.ZeroBasedBudgeting
+	})).Return(nil)
+
+	usecase := NewBudgetUseCase(&MockUnitOfWork{UserRepo: userRepo}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	err := usecase.SetZeroBased(context.Background(), userID.String(), true)
+
+	require.NoError(t, err)
+	userRepo.AssertExpectations(t)
+}
+
+func TestBudgetUseCase_Assignment(t *testing.T) {
+	f := newBudgetFixture(t, 200000)
+	var saved tracking.Group
+	usecase, _ := f.newUseCase(&saved)
+
+	resp, err := usecase.Assignment(context.Background(), f.userID.String(), "2024-03")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(200000), resp.IncomeCents)
+	assert.Equal(t, int64(120000), resp.BudgetedCents)
+	assert.Equal(t, int64(80000), resp.LeftCents)
+	require.Len(t, resp.Categories, 2)
+	assert.Equal(t, "Food", resp.Categories[0].Name)
+	assert.Equal(t, int64(30000), resp.Categories[0].LastMonthSpentCents)
+	assert.Equal(t, int64(0), resp.Categories[1].LastMonthSpentCents)
+}
+
+func TestBudgetUseCase_FillFromLastMonth(t *testing.T) {
+	t.Run("returns error for nil request", func(t *testing.T) {
+		usecase := NewBudgetUseCase(&MockUnitOfWork{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		resp, err := usecase.FillFromLastMonth(context.Background(), nil)
+		assert.Nil(t, resp)
+		assert.EqualError(t, err, "request cannot be nil")
+	})
+
+	t.Run("forks the category with last month's spend", func(t *testing.T) {
+		f := newBudgetFixture(t, 200000)
+		var saved tracking.Group
+		usecase, txUOW := f.newUseCase(&saved)
+
+		_, err := usecase.FillFromLastMonth(context.Background(), &FillBudgetRequest{
+			UserID:     f.userID.String(),
+			Currency:   "USD",
+			GroupID:    f.group.ID.String(),
+			CategoryID: f.food.ID.String(),
+			Month:      "2024-03",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int64{"Food": 30000, "Rent": 100000}, marchBudgets(t, saved))
+		txUOW.ExpenseRepo.AssertCalled(t, "ReassignCategoryFromMonth", mock.Anything, f.userID, f.food.ID, mock.Anything, "2024-03")
+	})
+
+	t.Run("refuses to over-assign", func(t *testing.T) {
+		f := newBudgetFixture(t, 125000)
+		var saved tracking.Group
+		usecase, txUOW := f.newUseCase(&saved)
+
+		resp, err := usecase.FillFromLastMonth(context.Background(), &FillBudgetRequest{
+			UserID:     f.userID.String(),
+			Currency:   "USD",
+			CategoryID: f.food.ID.String(),
+			Month:      "2024-03",
+		})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, ErrOverAssigned)
+		txUOW.AssertNotCalled(t, "Commit")
+	})
+
+	t.Run("returns error when the month is closed", func(t *testing.T) {
+		closingRepo := &MockClosingRepository{}
+		closingRepo.On("IsClosed", mock.Anything, mock.Anything, "2024-03").Return(true, nil)
+		usecase := NewBudgetUseCase(&MockUnitOfWork{ClosingRepo: closingRepo}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		userID, _ := identifier.NewID()
+
+		resp, err := usecase.FillFromLastMonth(context.Background(), &FillBudgetRequest{
+			UserID:   userID.String(),
+			Currency: "USD",
+			Month:    "2024-03",
+		})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, closing.ErrMonthClosed)
+	})
+}
+
+func TestBudgetUseCase_Distribute(t *testing.T) {
+	t.Run("adds shares of the remainder to budgets", func(t *testing.T) {
+		f := newBudgetFixture(t, 200000)
+		var saved tracking.Group
+		usecase, _ := f.newUseCase(&saved)
+
+		_, err := usecase.Distribute(context.Background(), &DistributeBudgetRequest{
+			UserID:   f.userID.String(),
+			Currency: "USD",
+			Month:    "2024-03",
+			Shares: []BudgetShare{
+				{CategoryID: f.food.ID.String(), Percent: 50},
+				{CategoryID: f.rent.ID.String(), Percent: 25},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int64{"Food": 60000, "Rent": 120000}, marchBudgets(t, saved))
+	})
+
+	t.Run("rejects invalid shares", func(t *testing.T) {
+		usecase := NewBudgetUseCase(&MockUnitOfWork{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		tests := map[string][]BudgetShare{
+			"empty":     nil,
+			"negative":  {{CategoryID: "a", Percent: -10}},
+			"over 100":  {{CategoryID: "a", Percent: 60}, {CategoryID: "b", Percent: 50}},
+			"duplicate": {{CategoryID: "a", Percent: 10}, {CategoryID: "a", Percent: 10}},
+		}
+
+		for name, shares := range tests {
+			t.Run(name, func(t *testing.T) {
+				resp, err := usecase.Distribute(context.Background(), &DistributeBudgetRequest{Month: "2024-03", Shares: shares})
+				assert.Nil(t, resp)
+				assert.ErrorIs(t, err, ErrInvalidShares)
+			})
+		}
+	})
+
+	t.Run("returns error when nothing is left to assign", func(t *testing.T) {
+		f := newBudgetFixture(t, 100000)
+		var saved tracking.Group
+		usecase, _ := f.newUseCase(&saved)
+
+		resp, err := usecase.Distribute(context.Background(), &DistributeBudgetRequest{
+			UserID:   f.userID.String(),
+			Currency: "USD",
+			Month:    "2024-03",
+			Shares:   []BudgetShare{{CategoryID: f.food.ID.String(), Percent: 100}},
+		})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, ErrNothingToAssign)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"slices"
 
 	"github.com/madalinpopa/gocost-web/internal/domain"
 	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
@@ -48,6 +49,10 @@ func (u CategoryUseCaseImpl) Create(ctx context.Context, req *CreateCategoryRequ
 		return nil, err
 	}
 
+	if err := ensureMonthOpen(ctx, u.uow, group.UserID, startMonth.Value()); err != nil {
+		return nil, err
+	}
+
 	var endMonth tracking.Month
 	if req.EndMonth != "" {
 		endMonth, err = tracking.ParseMonth(req.EndMonth)
@@ -66,9 +71,21 @@ func (u CategoryUseCaseImpl) Create(ctx context.Context, req *CreateCategoryRequ
 		return nil, err
 	}
 
-	category, err := group.CreateCategory(id, name, description, req.IsRecurrent, startMonth, endMonth, budget)
-	if err != nil {
-		return nil, err
+	var category *tracking.Category
+	if req.ParentID != "" {
+		parentID, err := identifier.ParseID(req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		category, err = group.CreateSubcategory(parentID, id, name, description, req.IsRecurrent, startMonth, endMonth, budget)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		category, err = group.CreateCategory(id, name, description, req.IsRecurrent, startMonth, endMonth, budget)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	txUOW, err := u.uow.Begin(ctx)
@@ -157,24 +174,24 @@ func (u CategoryUseCaseImpl) Update(ctx context.Context, req *UpdateCategoryRequ
 		}
 	}
 
+	editedMonth := startMonth
+	if !viewMonth.IsZero() {
+		editedMonth = viewMonth
+	}
+	if err := ensureMonthOpen(ctx, u.uow, group.UserID, editedMonth.Value()); err != nil {
+		return nil, err
+	}
+
 	var category *tracking.Category
+	forked := make(map[tracking.ID]tracking.ID)
 
 	if shouldFork && existingCategory.IsRecurrent {
-		// 1. Terminate old category
-		cutoff := viewMonth.Previous()
-		_, err = group.UpdateCategory(existingCategory.ID, existingCategory.Name, existingCategory.Description, existingCategory.IsRecurrent, existingCategory.StartMonth, cutoff, existingCategory.Budget)
-		if err != nil {
+		// Earlier months keep the category as it was; the edit applies from the
+		// month the user is on, subcategories included.
+		if err := forkWithSubcategories(group, existingCategory, viewMonth, budget, forked); err != nil {
 			return nil, err
 		}
-
-		// 2. Create new category
-		newID, err := identifier.NewID()
-		if err != nil {
-			return nil, err
-		}
-
-		// New category starts at viewMonth (where the user is)
-		category, err = group.CreateCategory(newID, name, description, req.IsRecurrent, viewMonth, endMonth, budget)
+		category, err = group.UpdateCategory(forked[cID], name, description, req.IsRecurrent, viewMonth, endMonth, budget)
 		if err != nil {
 			return nil, err
 		}
@@ -196,8 +213,12 @@ func (u CategoryUseCaseImpl) Update(ctx context.Context, req *UpdateCategoryRequ
 		return nil, err
 	}
 
-	if shouldFork && existingCategory.IsRecurrent {
-		if err := txUOW.ExpenseRepository().ReassignCategoryFromMonth(ctx, group.UserID, existingCategory.ID, category.ID, viewMonth.Value()); err != nil {
+	for oldID, newID := range forked {
+		if err := txUOW.ExpenseRepository().ReassignCategoryFromMonth(ctx, group.UserID, oldID, newID, viewMonth.Value()); err != nil {
+			_ = txUOW.Rollback()
+			return nil, err
+		}
+		if err := txUOW.AlertRepository().ForkCategory(ctx, oldID, newID, viewMonth.Value()); err != nil {
 			_ = txUOW.Rollback()
 			return nil, err
 		}
@@ -211,13 +232,59 @@ func (u CategoryUseCaseImpl) Update(ctx context.Context, req *UpdateCategoryRequ
 	return u.mapToResponse(category), nil
 }
 
-func (u CategoryUseCaseImpl) Delete(ctx context.Context, userID string, groupID string, id string) error {
+func (u CategoryUseCaseImpl) Delete(ctx context.Context, req *DeleteCategoryRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	switch req.Mode {
+	case DeleteModeHard:
+		return u.hardDelete(ctx, req)
+	case DeleteModeReassign:
+		_, err := u.Merge(ctx, &MergeCategoryRequest{
+			ID:               req.ID,
+			GroupID:          req.GroupID,
+			UserID:           req.UserID,
+			TargetCategoryID: req.TargetCategoryID,
+		})
+		return err
+	case DeleteModeEnd:
+		return u.end(ctx, req)
+	default:
+		return ErrInvalidDeleteMode
+	}
+}
+
+func (u CategoryUseCaseImpl) DeletionImpact(ctx context.Context, userID string, groupID string, id string, month string) (*DeletionImpactResponse, error) {
 	group, err := u.verifyGroupOwnership(ctx, userID, groupID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	cID, err := identifier.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := group.FindCategory(cID); err != nil {
+		return nil, err
+	}
+
+	counts, err := u.uow.ExpenseRepository().CountByCategoriesPerMonth(ctx, group.UserID, []identifier.ID{cID})
+	if err != nil {
+		return nil, err
+	}
+
+	return newDeletionImpact(counts, month), nil
+}
+
+func (u CategoryUseCaseImpl) hardDelete(ctx context.Context, req *DeleteCategoryRequest) error {
+	group, err := u.verifyGroupOwnership(ctx, req.UserID, req.GroupID)
+	if err != nil {
+		return err
+	}
+
+	cID, err := identifier.ParseID(req.ID)
 	if err != nil {
 		return err
 	}
@@ -226,6 +293,10 @@ func (u CategoryUseCaseImpl) Delete(ctx context.Context, userID string, groupID
 		return err
 	}
 
+	if err := ensureExpenseMonthsOpen(ctx, u.uow, group.UserID, []identifier.ID{cID}, ""); err != nil {
+		return err
+	}
+
 	txUOW, err := u.uow.Begin(ctx)
 	if err != nil {
 		return err
@@ -244,6 +315,76 @@ func (u CategoryUseCaseImpl) Delete(ctx context.Context, userID string, groupID
 	return nil
 }
 
+// end closes the category at the month before req.Month so earlier months keep
+// their history, and drops the expenses recorded from req.Month onwards.
+func (u CategoryUseCaseImpl) end(ctx context.Context, req *DeleteCategoryRequest) error {
+	group, err := u.verifyGroupOwnership(ctx, req.UserID, req.GroupID)
+	if err != nil {
+		return err
+	}
+
+	cID, err := identifier.ParseID(req.ID)
+	if err != nil {
+		return err
+	}
+
+	month, err := tracking.ParseMonth(req.Month)
+	if err != nil {
+		return err
+	}
+
+	if err := ensureMonthOpen(ctx, u.uow, group.UserID, month.Value()); err != nil {
+		return err
+	}
+
+	subtree := []identifier.ID{cID}
+	for _, c := range group.Descendants(cID) {
+		subtree = append(subtree, c.ID)
+	}
+
+	if err := ensureExpenseMonthsOpen(ctx, u.uow, group.UserID, subtree, month.Value()); err != nil {
+		return err
+	}
+
+	removed, err := group.EndCategory(cID, month)
+	if err != nil {
+		return err
+	}
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(removed, cID) {
+		if err := txUOW.TrackingRepository().Save(ctx, *group); err != nil {
+			_ = txUOW.Rollback()
+			return err
+		}
+	}
+
+	for _, id := range subtree {
+		if slices.Contains(removed, id) {
+			if err := txUOW.TrackingRepository().DeleteCategory(ctx, id); err != nil {
+				_ = txUOW.Rollback()
+				return err
+			}
+			continue
+		}
+		if err := txUOW.ExpenseRepository().DeleteByCategoryFromMonth(ctx, group.UserID, id, month.Value()); err != nil {
+			_ = txUOW.Rollback()
+			return err
+		}
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
 func (u CategoryUseCaseImpl) Get(ctx context.Context, userID string, groupID string, id string) (*CategoryResponse, error) {
 	group, err := u.verifyGroupOwnership(ctx, userID, groupID)
 	if err != nil {
@@ -278,6 +419,239 @@ func (u CategoryUseCaseImpl) List(ctx context.Context, userID string, groupID st
 	return responses, nil
 }
 
+func (u CategoryUseCaseImpl) Reorder(ctx context.Context, userID string, groupID string, ids []string) error {
+	group, err := u.verifyGroupOwnership(ctx, userID, groupID)
+	if err != nil {
+		return err
+	}
+
+	categoryIDs := make([]tracking.ID, 0, len(ids))
+	for _, id := range ids {
+		cID, err := identifier.ParseID(id)
+		if err != nil {
+			return err
+		}
+		categoryIDs = append(categoryIDs, cID)
+	}
+
+	if err := group.ReorderCategories(categoryIDs); err != nil {
+		return err
+	}
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := txUOW.TrackingRepository().Save(ctx, *group); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func (u CategoryUseCaseImpl) Move(ctx context.Context, req *MoveCategoryRequest) (*CategoryResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	sourceGroup, err := u.verifyGroupOwnership(ctx, req.UserID, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	targetGroup, err := u.verifyGroupOwnership(ctx, req.UserID, req.TargetGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	cID, err := identifier.ParseID(req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The expenses of the category and its subcategories count towards the
+	// target group from now on, in every month they were recorded in.
+	subtree := []identifier.ID{cID}
+	for _, c := range sourceGroup.Descendants(cID) {
+		subtree = append(subtree, c.ID)
+	}
+
+	category, err := sourceGroup.MoveCategory(cID, targetGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ensureExpenseMonthsOpen(ctx, u.uow, sourceGroup.UserID, subtree, ""); err != nil {
+		return nil, err
+	}
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Saving the target group upserts the category with its new group id.
+	if err := txUOW.TrackingRepository().Save(ctx, *targetGroup); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
+	return u.mapToResponse(category), nil
+}
+
+func (u CategoryUseCaseImpl) Merge(ctx context.Context, req *MergeCategoryRequest) (*CategoryResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	sourceGroup, err := u.verifyGroupOwnership(ctx, req.UserID, req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceID, err := identifier.ParseID(req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	targetID, err := identifier.ParseID(req.TargetCategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sourceID == targetID {
+		return nil, tracking.ErrSameCategory
+	}
+
+	source, err := sourceGroup.FindCategory(sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	targetGroup := sourceGroup
+	found, err := u.uow.TrackingRepository().FindGroupByCategoryID(ctx, targetID)
+	if err != nil {
+		if errors.Is(err, tracking.ErrGroupNotFound) {
+			return nil, tracking.ErrCategoryNotFound
+		}
+		return nil, err
+	}
+	if found.UserID != sourceGroup.UserID {
+		return nil, tracking.ErrCategoryNotFound
+	}
+	if found.ID != sourceGroup.ID {
+		targetGroup = &found
+	}
+
+	target, err := targetGroup.FindCategory(targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	startMonth, endMonth, err := target.MergedPeriod(source)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sourceGroup.RemoveCategory(sourceID); err != nil {
+		return nil, err
+	}
+
+	if err := ensureExpenseMonthsOpen(ctx, u.uow, sourceGroup.UserID, []identifier.ID{sourceID}, ""); err != nil {
+		return nil, err
+	}
+
+	category, err := targetGroup.UpdateCategory(target.ID, target.Name, target.Description, target.IsRecurrent, startMonth, endMonth, target.Budget)
+	if err != nil {
+		return nil, err
+	}
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := txUOW.TrackingRepository().Save(ctx, *targetGroup); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
+	if err := txUOW.ExpenseRepository().ReassignCategory(ctx, sourceGroup.UserID, sourceID, targetID); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
+	if err := txUOW.TrackingRepository().DeleteCategory(ctx, sourceID); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
+	return u.mapToResponse(category), nil
+}
+
+func (u CategoryUseCaseImpl) Archive(ctx context.Context, userID string, groupID string, id string) error {
+	return u.setArchived(ctx, userID, groupID, id, true)
+}
+
+func (u CategoryUseCaseImpl) Restore(ctx context.Context, userID string, groupID string, id string) error {
+	return u.setArchived(ctx, userID, groupID, id, false)
+}
+
+func (u CategoryUseCaseImpl) setArchived(ctx context.Context, userID string, groupID string, id string, archived bool) error {
+	group, err := u.verifyGroupOwnership(ctx, userID, groupID)
+	if err != nil {
+		return err
+	}
+
+	cID, err := identifier.ParseID(id)
+	if err != nil {
+		return err
+	}
+
+	if archived {
+		err = group.ArchiveCategory(cID)
+	} else {
+		err = group.RestoreCategory(cID)
+	}
+	if err != nil {
+		return err
+	}
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := txUOW.TrackingRepository().Save(ctx, *group); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
 func (u CategoryUseCaseImpl) verifyGroupOwnership(ctx context.Context, userID string, groupID string) (*tracking.Group, error) {
 	gID, err := identifier.ParseID(groupID)
 	if err != nil {
@@ -311,5 +685,15 @@ func (u CategoryUseCaseImpl) mapToResponse(c *tracking.Category) *CategoryRespon
 		StartMonth:  c.StartMonth.Value(),
 		EndMonth:    c.EndMonth.Value(),
 		Budget:      c.Budget.Amount(),
+		Archived:    c.Archived,
+		ParentID:    parentIDString(c.ParentID),
+	}
+}
+
+// parentIDString renders a parent reference, leaving top-level categories empty.
+func parentIDString(id identifier.ID) string {
+	if id.IsZero() {
+		return ""
 	}
+	return id.String()
 }
@@ -2,10 +2,13 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"io"
 	"log/slog"
 	"testing"
 
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
+	"github.com/madalinpopa/gocost-web/internal/domain/expense"
 	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
 	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
 	"github.com/madalinpopa/gocost-web/internal/platform/money"
@@ -111,6 +114,60 @@ func TestCategoryUseCase_Create(t *testing.T) {
 		assert.Len(t, savedGroup.Categories, 1)
 		assert.Equal(t, validReq.Name, savedGroup.Categories[0].Name.Value())
 	})
+
+	t.Run("creates subcategory under parent", func(t *testing.T) {
+		parentGroup := newTestGroup(t, validUserID)
+		parentID, _ := identifier.NewID()
+		name, _ := tracking.NewNameVO("Utilities")
+		desc, _ := tracking.NewDescriptionVO("")
+		startMonth, _ := tracking.ParseMonth("2023-01")
+		_, err := parentGroup.CreateCategory(parentID, name, desc, false, startMonth, tracking.Month{}, money.Money{})
+		require.NoError(t, err)
+
+		var savedGroup tracking.Group
+		repo := &MockGroupRepository{}
+		txRepo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, mock.Anything).Return(*parentGroup, nil)
+		txRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			savedGroup = args.Get(1).(tracking.Group)
+		})
+		txUOW := &MockUnitOfWork{TrackingRepo: txRepo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+		txUOW.On("Commit").Return(nil)
+
+		usecase := NewCategoryUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		req := *validReq
+		req.GroupID = parentGroup.ID.String()
+		req.ParentID = parentID.String()
+		resp, err := usecase.Create(context.Background(), &req)
+
+		require.NoError(t, err)
+		assert.Equal(t, parentID.String(), resp.ParentID)
+		assert.Len(t, savedGroup.Subcategories(parentID), 1)
+	})
+
+	t.Run("returns error when parent category not found", func(t *testing.T) {
+		repo := &MockGroupRepository{}
+		txRepo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, mock.Anything).Return(*group, nil)
+		txUOW := &MockUnitOfWork{TrackingRepo: txRepo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+		txUOW.On("Rollback").Return(nil)
+
+		usecase := NewCategoryUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		missingID, _ := identifier.NewID()
+		req := *validReq
+		req.ParentID = missingID.String()
+		resp, err := usecase.Create(context.Background(), &req)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, tracking.ErrParentCategoryNotFound)
+		txRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
 }
 
 func TestCategoryUseCase_Update(t *testing.T) {
@@ -319,6 +376,143 @@ func TestCategoryUseCase_Update(t *testing.T) {
 		txExpenseRepo.AssertExpectations(t)
 	})
 
+	t.Run("forks subcategory under its parent", func(t *testing.T) {
+		var savedGroup tracking.Group
+		repo := &MockGroupRepository{}
+		txRepo := &MockGroupRepository{}
+		txExpenseRepo := &MockExpenseRepository{}
+
+		forkGroup := newTestGroup(t, validUserID)
+		start, _ := tracking.ParseMonth("2023-01")
+		budget, _ := money.NewFromFloat(100.0, "USD")
+		desc, _ := tracking.NewDescriptionVO("")
+		carID, _ := identifier.NewID()
+		carName, _ := tracking.NewNameVO("Car")
+		_, err := forkGroup.CreateCategory(carID, carName, desc, true, start, tracking.Month{}, budget)
+		require.NoError(t, err)
+		fuelID, _ := identifier.NewID()
+		fuelName, _ := tracking.NewNameVO("Fuel")
+		_, err = forkGroup.CreateSubcategory(carID, fuelID, fuelName, desc, true, start, tracking.Month{}, budget)
+		require.NoError(t, err)
+
+		repo.On("FindByID", mock.Anything, mock.Anything).Return(*forkGroup, nil)
+		txRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			savedGroup = args.Get(1).(tracking.Group)
+		})
+		txExpenseRepo.On("ReassignCategoryFromMonth", mock.Anything, validUserID, fuelID, mock.Anything, "2023-03").Return(nil)
+
+		txUOW := &MockUnitOfWork{TrackingRepo: txRepo, ExpenseRepo: txExpenseRepo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+		txUOW.On("Commit").Return(nil)
+
+		usecase := NewCategoryUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		resp, err := usecase.Update(context.Background(), &UpdateCategoryRequest{
+			ID:           fuelID.String(),
+			UserID:       validUserID.String(),
+			GroupID:      forkGroup.ID.String(),
+			Currency:     "USD",
+			Name:         "Fuel",
+			StartMonth:   "2023-01",
+			CurrentMonth: "2023-03",
+			IsRecurrent:  true,
+			Budget:       150.0,
+		})
+
+		require.NoError(t, err)
+		assert.NotEqual(t, fuelID.String(), resp.ID)
+		assert.Equal(t, carID.String(), resp.ParentID)
+
+		forkID, err := identifier.ParseID(resp.ID)
+		require.NoError(t, err)
+		forked, err := savedGroup.FindCategory(forkID)
+		require.NoError(t, err)
+		assert.Equal(t, carID, forked.ParentID)
+		assert.Equal(t, 150.0, forked.Budget.Amount())
+		original, err := savedGroup.FindCategory(fuelID)
+		require.NoError(t, err)
+		assert.Equal(t, "2023-02", original.EndMonth.Value())
+		txExpenseRepo.AssertExpectations(t)
+	})
+
+	t.Run("forks parent together with its subcategories", func(t *testing.T) {
+		var savedGroup tracking.Group
+		repo := &MockGroupRepository{}
+		txRepo := &MockGroupRepository{}
+		txExpenseRepo := &MockExpenseRepository{}
+
+		forkGroup := newTestGroup(t, validUserID)
+		start, _ := tracking.ParseMonth("2023-01")
+		budget, _ := money.NewFromFloat(100.0, "USD")
+		desc, _ := tracking.NewDescriptionVO("")
+		carID, _ := identifier.NewID()
+		carName, _ := tracking.NewNameVO("Car")
+		_, err := forkGroup.CreateCategory(carID, carName, desc, true, start, tracking.Month{}, budget)
+		require.NoError(t, err)
+		fuelID, _ := identifier.NewID()
+		fuelName, _ := tracking.NewNameVO("Fuel")
+		_, err = forkGroup.CreateSubcategory(carID, fuelID, fuelName, desc, true, start, tracking.Month{}, budget)
+		require.NoError(t, err)
+		tollsID, _ := identifier.NewID()
+		tollsName, _ := tracking.NewNameVO("Tolls")
+		later, _ := tracking.ParseMonth("2023-04")
+		_, err = forkGroup.CreateSubcategory(carID, tollsID, tollsName, desc, true, later, tracking.Month{}, budget)
+		require.NoError(t, err)
+
+		repo.On("FindByID", mock.Anything, mock.Anything).Return(*forkGroup, nil)
+		txRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			savedGroup = args.Get(1).(tracking.Group)
+		})
+		txExpenseRepo.On("ReassignCategoryFromMonth", mock.Anything, validUserID, carID, mock.Anything, "2023-03").Return(nil)
+		txExpenseRepo.On("ReassignCategoryFromMonth", mock.Anything, validUserID, fuelID, mock.Anything, "2023-03").Return(nil)
+
+		txUOW := &MockUnitOfWork{TrackingRepo: txRepo, ExpenseRepo: txExpenseRepo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+		txUOW.On("Commit").Return(nil)
+
+		usecase := NewCategoryUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		resp, err := usecase.Update(context.Background(), &UpdateCategoryRequest{
+			ID:           carID.String(),
+			UserID:       validUserID.String(),
+			GroupID:      forkGroup.ID.String(),
+			Currency:     "USD",
+			Name:         "Vehicle",
+			StartMonth:   "2023-01",
+			CurrentMonth: "2023-03",
+			IsRecurrent:  true,
+			Budget:       300.0,
+		})
+
+		require.NoError(t, err)
+		newCarID, err := identifier.ParseID(resp.ID)
+		require.NoError(t, err)
+		assert.NotEqual(t, carID, newCarID)
+		assert.Equal(t, "Vehicle", resp.Name)
+
+		original, err := savedGroup.FindCategory(fuelID)
+		require.NoError(t, err)
+		assert.Equal(t, carID, original.ParentID)
+		assert.Equal(t, "2023-02", original.EndMonth.Value())
+
+		var forkedFuel *tracking.Category
+		for _, c := range savedGroup.Categories {
+			if c.ID != fuelID && c.Name.Value() == "Fuel" {
+				forkedFuel = c
+			}
+		}
+		require.NotNil(t, forkedFuel)
+		assert.Equal(t, newCarID, forkedFuel.ParentID)
+		assert.Equal(t, "2023-03", forkedFuel.StartMonth.Value())
+
+		tolls, err := savedGroup.FindCategory(tollsID)
+		require.NoError(t, err)
+		assert.Equal(t, newCarID, tolls.ParentID)
+		txExpenseRepo.AssertExpectations(t)
+	})
+
 	t.Run("updates category and saves group", func(t *testing.T) {
 		var savedGroup tracking.Group
 		repo := &MockGroupRepository{}
@@ -366,7 +560,7 @@ func TestCategoryUseCase_Delete(t *testing.T) {
 		usecase := newTestCategoryUseCase(repo, nil, nil)
 
 		newID, _ := identifier.NewID()
-		err := usecase.Delete(context.Background(), validUserID.String(), group.ID.String(), newID.String())
+		err := usecase.Delete(context.Background(), &DeleteCategoryRequest{ID: newID.String(), GroupID: group.ID.String(), UserID: validUserID.String(), Mode: DeleteModeHard})
 		assert.ErrorIs(t, err, tracking.ErrCategoryNotFound)
 	})
 
@@ -377,7 +571,7 @@ func TestCategoryUseCase_Delete(t *testing.T) {
 		usecase := newTestCategoryUseCase(repo, nil, nil)
 		otherUserID, _ := identifier.NewID()
 
-		err := usecase.Delete(context.Background(), otherUserID.String(), group.ID.String(), catID.String())
+		err := usecase.Delete(context.Background(), &DeleteCategoryRequest{ID: catID.String(), GroupID: group.ID.String(), UserID: otherUserID.String(), Mode: DeleteModeHard})
 		assert.ErrorIs(t, err, tracking.ErrGroupNotFound)
 	})
 
@@ -387,7 +581,7 @@ func TestCategoryUseCase_Delete(t *testing.T) {
 		repo.On("FindByID", mock.Anything, mock.Anything).Return(*group, nil)
 		txRepo.On("DeleteCategory", mock.Anything, catID).Return(nil)
 		txUOW := &MockUnitOfWork{TrackingRepo: txRepo}
-		baseUOW := &MockUnitOfWork{TrackingRepo: repo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo, ExpenseRepo: newExpenseCountRepository()}
 		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
 		txUOW.On("Commit").Return(nil)
 
@@ -396,12 +590,568 @@ func TestCategoryUseCase_Delete(t *testing.T) {
 			slog.New(slog.NewTextHandler(io.Discard, nil)),
 		)
 
-		err := usecase.Delete(context.Background(), validUserID.String(), group.ID.String(), catID.String())
+		err := usecase.Delete(context.Background(), &DeleteCategoryRequest{ID: catID.String(), GroupID: group.ID.String(), UserID: validUserID.String(), Mode: DeleteModeHard})
 		require.NoError(t, err)
 		repo.AssertExpectations(t)
 	})
 }
 
+func TestCategoryUseCase_DeleteModes(t *testing.T) {
+	validUserID, _ := identifier.NewID()
+
+	addCategory := func(t *testing.T, group *tracking.Group, n string, isRecurrent bool, start string) identifier.ID {
+		t.Helper()
+		id, _ := identifier.NewID()
+		name, _ := tracking.NewNameVO(n)
+		desc, _ := tracking.NewDescriptionVO("Desc")
+		startMonth, _ := tracking.ParseMonth(start)
+		_, err := group.CreateCategory(id, name, desc, isRecurrent, startMonth, tracking.Month{}, money.Money{})
+		require.NoError(t, err)
+		return id
+	}
+
+	t.Run("returns error for nil request", func(t *testing.T) {
+		usecase := newTestCategoryUseCase(nil, nil, nil)
+		err := usecase.Delete(context.Background(), nil)
+		assert.EqualError(t, err, "request cannot be nil")
+	})
+
+	t.Run("returns error for unknown mode", func(t *testing.T) {
+		usecase := newTestCategoryUseCase(nil, nil, nil)
+		err := usecase.Delete(context.Background(), &DeleteCategoryRequest{Mode: "wipe"})
+		assert.ErrorIs(t, err, ErrInvalidDeleteMode)
+	})
+
+	t.Run("reassign merges into target category", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		sourceID := addCategory(t, group, "Internet", true, "2023-01")
+		targetID := addCategory(t, group, "Web", true, "2023-01")
+
+		repo := &MockGroupRepository{}
+		txRepo := &MockGroupRepository{}
+		txExpenseRepo := &MockExpenseRepository{}
+		repo.On("FindByID", mock.Anything, group.ID).Return(*group, nil)
+		repo.On("FindGroupByCategoryID", mock.Anything, targetID).Return(*group, nil)
+		txRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		txExpenseRepo.On("ReassignCategory", mock.Anything, validUserID, sourceID, targetID).Return(nil)
+		txRepo.On("DeleteCategory", mock.Anything, sourceID).Return(nil)
+		txUOW := &MockUnitOfWork{TrackingRepo: txRepo, ExpenseRepo: txExpenseRepo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo, ExpenseRepo: newExpenseCountRepository()}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+		txUOW.On("Commit").Return(nil)
+
+		usecase := NewCategoryUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		err := usecase.Delete(context.Background(), &DeleteCategoryRequest{
+			ID:               sourceID.String(),
+			GroupID:          group.ID.String(),
+			UserID:           validUserID.String(),
+			Mode:             DeleteModeReassign,
+			TargetCategoryID: targetID.String(),
+		})
+		require.NoError(t, err)
+		txRepo.AssertExpectations(t)
+		txExpenseRepo.AssertExpectations(t)
+	})
+
+	t.Run("end closes recurrent category and drops later expenses", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		catID := addCategory(t, group, "Gym", true, "2023-01")
+
+		var savedGroup tracking.Group
+		repo := &MockGroupRepository{}
+		txRepo := &MockGroupRepository{}
+		txExpenseRepo := &MockExpenseRepository{}
+		repo.On("FindByID", mock.Anything, group.ID).Return(*group, nil)
+		txRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			savedGroup = args.Get(1).(tracking.Group)
+		})
+		txExpenseRepo.On("DeleteByCategoryFromMonth", mock.Anything, validUserID, catID, "2023-06").Return(nil)
+		txUOW := &MockUnitOfWork{TrackingRepo: txRepo, ExpenseRepo: txExpenseRepo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo, ExpenseRepo: newExpenseCountRepository()}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+		txUOW.On("Commit").Return(nil)
+
+		usecase := NewCategoryUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		err := usecase.Delete(context.Background(), &DeleteCategoryRequest{
+			ID:      catID.String(),
+			GroupID: group.ID.String(),
+			UserID:  validUserID.String(),
+			Mode:    DeleteModeEnd,
+			Month:   "2023-06",
+		})
+		require.NoError(t, err)
+		require.Len(t, savedGroup.Categories, 1)
+		assert.Equal(t, "2023-05", savedGroup.Categories[0].EndMonth.Value())
+		txExpenseRepo.AssertExpectations(t)
+		txRepo.AssertNotCalled(t, "DeleteCategory", mock.Anything, mock.Anything)
+	})
+
+	t.Run("end deletes category without earlier months", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		catID := addCategory(t, group, "Gym", true, "2023-06")
+
+		repo := &MockGroupRepository{}
+		txRepo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, group.ID).Return(*group, nil)
+		txRepo.On("DeleteCategory", mock.Anything, catID).Return(nil)
+		txUOW := &MockUnitOfWork{TrackingRepo: txRepo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo, ExpenseRepo: newExpenseCountRepository()}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+		txUOW.On("Commit").Return(nil)
+
+		usecase := NewCategoryUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		err := usecase.Delete(context.Background(), &DeleteCategoryRequest{
+			ID:      catID.String(),
+			GroupID: group.ID.String(),
+			UserID:  validUserID.String(),
+			Mode:    DeleteModeEnd,
+			Month:   "2023-06",
+		})
+		require.NoError(t, err)
+		txRepo.AssertExpectations(t)
+		txRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("end rejects invalid month", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		catID := addCategory(t, group, "Gym", true, "2023-01")
+		repo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, group.ID).Return(*group, nil)
+
+		usecase := newTestCategoryUseCase(repo, nil, nil)
+
+		err := usecase.Delete(context.Background(), &DeleteCategoryRequest{
+			ID:      catID.String(),
+			GroupID: group.ID.String(),
+			UserID:  validUserID.String(),
+			Mode:    DeleteModeEnd,
+		})
+		assert.ErrorIs(t, err, tracking.ErrInvalidMonth)
+	})
+}
+
+func TestCategoryUseCase_DeletionImpact(t *testing.T) {
+	validUserID, _ := identifier.NewID()
+	group := newTestGroup(t, validUserID)
+	catID, _ := identifier.NewID()
+	name, _ := tracking.NewNameVO("Gym")
+	desc, _ := tracking.NewDescriptionVO("Desc")
+	startMonth, _ := tracking.ParseMonth("2023-01")
+	_, _ = group.CreateCategory(catID, name, desc, true, startMonth, tracking.Month{}, money.Money{})
+
+	t.Run("returns error when user does not own group", func(t *testing.T) {
+		repo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, mock.Anything).Return(*group, nil)
+		usecase := newTestCategoryUseCase(repo, nil, nil)
+		otherUserID, _ := identifier.NewID()
+
+		resp, err := usecase.DeletionImpact(context.Background(), otherUserID.String(), group.ID.String(), catID.String(), "2023-03")
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, tracking.ErrGroupNotFound)
+	})
+
+	t.Run("summarises expenses per month", func(t *testing.T) {
+		repo := &MockGroupRepository{}
+		expenseRepo := &MockExpenseRepository{}
+		repo.On("FindByID", mock.Anything, mock.Anything).Return(*group, nil)
+		expenseRepo.On("CountByCategoriesPerMonth", mock.Anything, validUserID, []identifier.ID{catID}).Return([]expense.MonthlyCount{
+			{Month: "2023-01", Count: 2},
+			{Month: "2023-03", Count: 1},
+			{Month: "2023-04", Count: 4},
+		}, nil)
+		usecase := newTestCategoryUseCase(repo, nil, expenseRepo)
+
+		resp, err := usecase.DeletionImpact(context.Background(), validUserID.String(), group.ID.String(), catID.String(), "2023-03")
+		require.NoError(t, err)
+		assert.Equal(t, &DeletionImpactResponse{
+			ExpenseCount:      7,
+			MonthCount:        3,
+			FirstMonth:        "2023-01",
+			LastMonth:         "2023-04",
+			LaterExpenseCount: 5,
+		}, resp)
+	})
+}
+
+func TestCategoryUseCase_Reorder(t *testing.T) {
+	validUserID, _ := identifier.NewID()
+
+	newGroupWithCategories := func(t *testing.T) (*tracking.Group, []identifier.ID) {
+		t.Helper()
+		group := newTestGroup(t, validUserID)
+		startMonth, _ := tracking.ParseMonth("2023-01")
+		ids := make([]identifier.ID, 0, 2)
+		for _, n := range []string{"Rent", "Food"} {
+			id, _ := identifier.NewID()
+			name, _ := tracking.NewNameVO(n)
+			desc, _ := tracking.NewDescriptionVO("Desc")
+			_, err := group.CreateCategory(id, name, desc, true, startMonth, tracking.Month{}, money.Money{})
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+		return group, ids
+	}
+
+	t.Run("returns error when user does not own group", func(t *testing.T) {
+		group, ids := newGroupWithCategories(t)
+		repo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, mock.Anything).Return(*group, nil)
+
+		usecase := newTestCategoryUseCase(repo, nil, nil)
+		otherUserID, _ := identifier.NewID()
+
+		err := usecase.Reorder(context.Background(), otherUserID.String(), group.ID.String(), []string{ids[1].String(), ids[0].String()})
+		assert.ErrorIs(t, err, tracking.ErrGroupNotFound)
+	})
+
+	t.Run("returns error for invalid category ID", func(t *testing.T) {
+		group, _ := newGroupWithCategories(t)
+		repo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, mock.Anything).Return(*group, nil)
+
+		usecase := newTestCategoryUseCase(repo, nil, nil)
+
+		err := usecase.Reorder(context.Background(), validUserID.String(), group.ID.String(), []string{"invalid"})
+		assert.ErrorIs(t, err, identifier.ErrInvalidID)
+	})
+
+	t.Run("returns error for unknown category", func(t *testing.T) {
+		group, _ := newGroupWithCategories(t)
+		repo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, mock.Anything).Return(*group, nil)
+
+		usecase := newTestCategoryUseCase(repo, nil, nil)
+		unknownID, _ := identifier.NewID()
+
+		err := usecase.Reorder(context.Background(), validUserID.String(), group.ID.String(), []string{unknownID.String()})
+		assert.ErrorIs(t, err, tracking.ErrCategoryNotFound)
+	})
+
+	t.Run("saves group with reordered categories", func(t *testing.T) {
+		group, ids := newGroupWithCategories(t)
+		var savedGroup tracking.Group
+		repo := &MockGroupRepository{}
+		txRepo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, mock.Anything).Return(*group, nil)
+		txRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			savedGroup = args.Get(1).(tracking.Group)
+		})
+		txUOW := &MockUnitOfWork{TrackingRepo: txRepo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+		txUOW.On("Commit").Return(nil)
+
+		usecase := NewCategoryUseCase(
+			baseUOW,
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+		)
+
+		err := usecase.Reorder(context.Background(), validUserID.String(), group.ID.String(), []string{ids[1].String(), ids[0].String()})
+		require.NoError(t, err)
+		require.Len(t, savedGroup.Categories, 2)
+		assert.Equal(t, ids[1], savedGroup.Categories[0].ID)
+		assert.Equal(t, 0, savedGroup.Categories[0].Order.Value())
+		assert.Equal(t, ids[0], savedGroup.Categories[1].ID)
+		assert.Equal(t, 1, savedGroup.Categories[1].Order.Value())
+	})
+}
+
+func TestCategoryUseCase_Archive(t *testing.T) {
+	validUserID, _ := identifier.NewID()
+
+	newGroupWithCategory := func(t *testing.T) (*tracking.Group, identifier.ID) {
+		t.Helper()
+		group := newTestGroup(t, validUserID)
+		startMonth, _ := tracking.ParseMonth("2023-01")
+		id, _ := identifier.NewID()
+		name, _ := tracking.NewNameVO("Gym")
+		desc, _ := tracking.NewDescriptionVO("Desc")
+		_, err := group.CreateCategory(id, name, desc, true, startMonth, tracking.Month{}, money.Money{})
+		require.NoError(t, err)
+		return group, id
+	}
+
+	t.Run("returns error when user does not own group", func(t *testing.T) {
+		group, id := newGroupWithCategory(t)
+		repo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, mock.Anything).Return(*group, nil)
+
+		usecase := newTestCategoryUseCase(repo, nil, nil)
+		otherUserID, _ := identifier.NewID()
+
+		err := usecase.Archive(context.Background(), otherUserID.String(), group.ID.String(), id.String())
+		assert.ErrorIs(t, err, tracking.ErrGroupNotFound)
+	})
+
+	t.Run("returns error for unknown category", func(t *testing.T) {
+		group, _ := newGroupWithCategory(t)
+		repo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, mock.Anything).Return(*group, nil)
+
+		usecase := newTestCategoryUseCase(repo, nil, nil)
+		unknownID, _ := identifier.NewID()
+
+		err := usecase.Restore(context.Background(), validUserID.String(), group.ID.String(), unknownID.String())
+		assert.ErrorIs(t, err, tracking.ErrCategoryNotFound)
+	})
+
+	t.Run("archives and restores category", func(t *testing.T) {
+		group, id := newGroupWithCategory(t)
+		var saved []bool
+		repo := &MockGroupRepository{}
+		txRepo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, mock.Anything).Return(*group, nil)
+		txRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			saved = append(saved, args.Get(1).(tracking.Group).Categories[0].Archived)
+		})
+		txUOW := &MockUnitOfWork{TrackingRepo: txRepo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+		txUOW.On("Commit").Return(nil)
+
+		usecase := NewCategoryUseCase(
+			baseUOW,
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+		)
+
+		require.NoError(t, usecase.Archive(context.Background(), validUserID.String(), group.ID.String(), id.String()))
+		require.NoError(t, usecase.Restore(context.Background(), validUserID.String(), group.ID.String(), id.String()))
+		assert.Equal(t, []bool{true, false}, saved)
+	})
+}
+
+func TestCategoryUseCase_Move(t *testing.T) {
+	validUserID, _ := identifier.NewID()
+
+	setup := func(t *testing.T) (*tracking.Group, *tracking.Group, identifier.ID) {
+		t.Helper()
+		source := newTestGroup(t, validUserID)
+		target := newTestGroup(t, validUserID)
+		catID, _ := identifier.NewID()
+		name, _ := tracking.NewNameVO("Internet")
+		desc, _ := tracking.NewDescriptionVO("Desc")
+		startMonth, _ := tracking.ParseMonth("2023-01")
+		_, err := source.CreateCategory(catID, name, desc, true, startMonth, tracking.Month{}, money.Money{})
+		require.NoError(t, err)
+		return source, target, catID
+	}
+
+	t.Run("returns error for nil request", func(t *testing.T) {
+		usecase := newTestCategoryUseCase(nil, nil, nil)
+		resp, err := usecase.Move(context.Background(), nil)
+		assert.Nil(t, resp)
+		assert.EqualError(t, err, "request cannot be nil")
+	})
+
+	t.Run("returns error when user does not own target group", func(t *testing.T) {
+		source, _, catID := setup(t)
+		otherUserID, _ := identifier.NewID()
+		foreign := newTestGroup(t, otherUserID)
+		repo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, source.ID).Return(*source, nil)
+		repo.On("FindByID", mock.Anything, foreign.ID).Return(*foreign, nil)
+
+		usecase := newTestCategoryUseCase(repo, nil, nil)
+
+		resp, err := usecase.Move(context.Background(), &MoveCategoryRequest{
+			ID:            catID.String(),
+			GroupID:       source.ID.String(),
+			UserID:        validUserID.String(),
+			TargetGroupID: foreign.ID.String(),
+		})
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, tracking.ErrGroupNotFound)
+	})
+
+	t.Run("moves category and saves target group", func(t *testing.T) {
+		source, target, catID := setup(t)
+		var savedGroup tracking.Group
+		repo := &MockGroupRepository{}
+		txRepo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, source.ID).Return(*source, nil)
+		repo.On("FindByID", mock.Anything, target.ID).Return(*target, nil)
+		txRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			savedGroup = args.Get(1).(tracking.Group)
+		})
+		txUOW := &MockUnitOfWork{TrackingRepo: txRepo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo, ExpenseRepo: newExpenseCountRepository()}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+		txUOW.On("Commit").Return(nil)
+
+		usecase := NewCategoryUseCase(
+			baseUOW,
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+		)
+
+		resp, err := usecase.Move(context.Background(), &MoveCategoryRequest{
+			ID:            catID.String(),
+			GroupID:       source.ID.String(),
+			UserID:        validUserID.String(),
+			TargetGroupID: target.ID.String(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, catID.String(), resp.ID)
+		assert.Equal(t, target.ID, savedGroup.ID)
+		require.Len(t, savedGroup.Categories, 1)
+		assert.Equal(t, target.ID, savedGroup.Categories[0].GroupID)
+	})
+}
+
+func TestCategoryUseCase_Merge(t *testing.T) {
+	validUserID, _ := identifier.NewID()
+
+	addCategory := func(t *testing.T, group *tracking.Group, n string, isRecurrent bool, start string) identifier.ID {
+		t.Helper()
+		id, _ := identifier.NewID()
+		name, _ := tracking.NewNameVO(n)
+		desc, _ := tracking.NewDescriptionVO("Desc")
+		startMonth, _ := tracking.ParseMonth(start)
+		_, err := group.CreateCategory(id, name, desc, isRecurrent, startMonth, tracking.Month{}, money.Money{})
+		require.NoError(t, err)
+		return id
+	}
+
+	t.Run("returns error when merging into itself", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		catID := addCategory(t, group, "Internet", true, "2023-01")
+		repo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, group.ID).Return(*group, nil)
+
+		usecase := newTestCategoryUseCase(repo, nil, nil)
+
+		resp, err := usecase.Merge(context.Background(), &MergeCategoryRequest{
+			ID:               catID.String(),
+			GroupID:          group.ID.String(),
+			UserID:           validUserID.String(),
+			TargetCategoryID: catID.String(),
+		})
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, tracking.ErrSameCategory)
+	})
+
+	t.Run("returns not found for another user's category", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		sourceID := addCategory(t, group, "Internet", true, "2023-01")
+		otherUserID, _ := identifier.NewID()
+		foreign := newTestGroup(t, otherUserID)
+		foreignID := addCategory(t, foreign, "Internet", true, "2023-01")
+		repo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, group.ID).Return(*group, nil)
+		repo.On("FindGroupByCategoryID", mock.Anything, foreignID).Return(*foreign, nil)
+
+		usecase := newTestCategoryUseCase(repo, nil, nil)
+
+		resp, err := usecase.Merge(context.Background(), &MergeCategoryRequest{
+			ID:               sourceID.String(),
+			GroupID:          group.ID.String(),
+			UserID:           validUserID.String(),
+			TargetCategoryID: foreignID.String(),
+		})
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, tracking.ErrCategoryNotFound)
+	})
+
+	t.Run("returns error when target does not cover source period", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		sourceID := addCategory(t, group, "Internet", true, "2023-01")
+		targetID := addCategory(t, group, "Web", false, "2023-05")
+		repo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, group.ID).Return(*group, nil)
+		repo.On("FindGroupByCategoryID", mock.Anything, targetID).Return(*group, nil)
+
+		usecase := newTestCategoryUseCase(repo, nil, nil)
+
+		resp, err := usecase.Merge(context.Background(), &MergeCategoryRequest{
+			ID:               sourceID.String(),
+			GroupID:          group.ID.String(),
+			UserID:           validUserID.String(),
+			TargetCategoryID: targetID.String(),
+		})
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, tracking.ErrMergePeriodMismatch)
+	})
+
+	t.Run("reassigns expenses and deletes source in one transaction", func(t *testing.T) {
+		sourceGroup := newTestGroup(t, validUserID)
+		targetGroup := newTestGroup(t, validUserID)
+		sourceID := addCategory(t, sourceGroup, "Internet", true, "2023-01")
+		targetID := addCategory(t, targetGroup, "Internet", true, "2023-06")
+
+		var savedGroup tracking.Group
+		repo := &MockGroupRepository{}
+		txRepo := &MockGroupRepository{}
+		txExpenseRepo := &MockExpenseRepository{}
+		repo.On("FindByID", mock.Anything, sourceGroup.ID).Return(*sourceGroup, nil)
+		repo.On("FindGroupByCategoryID", mock.Anything, targetID).Return(*targetGroup, nil)
+		txRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			savedGroup = args.Get(1).(tracking.Group)
+		})
+		txExpenseRepo.On("ReassignCategory", mock.Anything, validUserID, sourceID, targetID).Return(nil)
+		txRepo.On("DeleteCategory", mock.Anything, sourceID).Return(nil)
+		txUOW := &MockUnitOfWork{TrackingRepo: txRepo, ExpenseRepo: txExpenseRepo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo, ExpenseRepo: newExpenseCountRepository()}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+		txUOW.On("Commit").Return(nil)
+
+		usecase := NewCategoryUseCase(
+			baseUOW,
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+		)
+
+		resp, err := usecase.Merge(context.Background(), &MergeCategoryRequest{
+			ID:               sourceID.String(),
+			GroupID:          sourceGroup.ID.String(),
+			UserID:           validUserID.String(),
+			TargetCategoryID: targetID.String(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, targetID.String(), resp.ID)
+		assert.Equal(t, "2023-01", resp.StartMonth)
+		assert.Equal(t, targetGroup.ID, savedGroup.ID)
+		txRepo.AssertExpectations(t)
+		txExpenseRepo.AssertExpectations(t)
+		txUOW.AssertExpectations(t)
+	})
+
+	t.Run("rolls back when reassignment fails", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		sourceID := addCategory(t, group, "Internet", true, "2023-01")
+		targetID := addCategory(t, group, "Web", true, "2023-01")
+		expectedErr := errors.New("db error")
+
+		repo := &MockGroupRepository{}
+		txRepo := &MockGroupRepository{}
+		txExpenseRepo := &MockExpenseRepository{}
+		repo.On("FindByID", mock.Anything, group.ID).Return(*group, nil)
+		repo.On("FindGroupByCategoryID", mock.Anything, targetID).Return(*group, nil)
+		txRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		txExpenseRepo.On("ReassignCategory", mock.Anything, validUserID, sourceID, targetID).Return(expectedErr)
+		txUOW := &MockUnitOfWork{TrackingRepo: txRepo, ExpenseRepo: txExpenseRepo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo, ExpenseRepo: newExpenseCountRepository()}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+		txUOW.On("Rollback").Return(nil)
+
+		usecase := NewCategoryUseCase(
+			baseUOW,
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+		)
+
+		resp, err := usecase.Merge(context.Background(), &MergeCategoryRequest{
+			ID:               sourceID.String(),
+			GroupID:          group.ID.String(),
+			UserID:           validUserID.String(),
+			TargetCategoryID: targetID.String(),
+		})
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, expectedErr)
+		txRepo.AssertNotCalled(t, "DeleteCategory", mock.Anything, mock.Anything)
+		txUOW.AssertExpectations(t)
+	})
+}
+
 func TestCategoryUseCase_Get(t *testing.T) {
 	validUserID, _ := identifier.NewID()
 	group := newTestGroup(t, validUserID)
@@ -489,3 +1239,118 @@ func TestCategoryUseCase_List(t *testing.T) {
 		assert.ErrorIs(t, err, tracking.ErrGroupNotFound)
 	})
 }
+
+func TestCategoryUseCase_ClosedMonths(t *testing.T) {
+	validUserID, _ := identifier.NewID()
+
+	addCategory := func(t *testing.T, group *tracking.Group, n string, start string) identifier.ID {
+		t.Helper()
+		id, _ := identifier.NewID()
+		name, _ := tracking.NewNameVO(n)
+		desc, _ := tracking.NewDescriptionVO("Desc")
+		startMonth, _ := tracking.ParseMonth(start)
+		_, err := group.CreateCategory(id, name, desc, true, startMonth, tracking.Month{}, money.Money{})
+		require.NoError(t, err)
+		return id
+	}
+
+	// newUseCase returns a use case over groups where March 2023 is closed
+	// and holds expenses of every category.
+	newUseCase := func(groups ...*tracking.Group) CategoryUseCaseImpl {
+		repo := &MockGroupRepository{}
+		for _, g := range groups {
+			repo.On("FindByID", mock.Anything, g.ID).Return(*g, nil)
+			for _, c := range g.Categories {
+				repo.On("FindGroupByCategoryID", mock.Anything, c.ID).Return(*g, nil)
+			}
+		}
+		expenseRepo := newExpenseCountRepository(
+			expense.MonthlyCount{Month: "2023-03", Count: 2},
+			expense.MonthlyCount{Month: "2023-07", Count: 1},
+		)
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo, ExpenseRepo: expenseRepo, ClosingRepo: newClosedMonthsRepository("2023-03")}
+		return NewCategoryUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	}
+
+	t.Run("hard delete is rejected", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		catID := addCategory(t, group, "Gym", "2023-01")
+
+		err := newUseCase(group).Delete(context.Background(), &DeleteCategoryRequest{
+			ID:      catID.String(),
+			GroupID: group.ID.String(),
+			UserID:  validUserID.String(),
+			Mode:    DeleteModeHard,
+		})
+		assert.ErrorIs(t, err, closing.ErrMonthClosed)
+	})
+
+	t.Run("merge is rejected", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		sourceID := addCategory(t, group, "Internet", "2023-01")
+		targetID := addCategory(t, group, "Web", "2023-01")
+
+		resp, err := newUseCase(group).Merge(context.Background(), &MergeCategoryRequest{
+			ID:               sourceID.String(),
+			GroupID:          group.ID.String(),
+			UserID:           validUserID.String(),
+			TargetCategoryID: targetID.String(),
+		})
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, closing.ErrMonthClosed)
+	})
+
+	t.Run("move is rejected", func(t *testing.T) {
+		source := newTestGroup(t, validUserID)
+		target := newTestGroup(t, validUserID)
+		catID := addCategory(t, source, "Internet", "2023-01")
+
+		resp, err := newUseCase(source, target).Move(context.Background(), &MoveCategoryRequest{
+			ID:            catID.String(),
+			GroupID:       source.ID.String(),
+			UserID:        validUserID.String(),
+			TargetGroupID: target.ID.String(),
+		})
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, closing.ErrMonthClosed)
+	})
+
+	t.Run("end is rejected when a later month is closed", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		catID := addCategory(t, group, "Gym", "2023-01")
+
+		err := newUseCase(group).Delete(context.Background(), &DeleteCategoryRequest{
+			ID:      catID.String(),
+			GroupID: group.ID.String(),
+			UserID:  validUserID.String(),
+			Mode:    DeleteModeEnd,
+			Month:   "2023-02",
+		})
+		assert.ErrorIs(t, err, closing.ErrMonthClosed)
+	})
+
+	t.Run("end is allowed when only earlier months are closed", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		catID := addCategory(t, group, "Gym", "2023-01")
+
+		txRepo := &MockGroupRepository{}
+		txExpenseRepo := &MockExpenseRepository{}
+		txRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		txExpenseRepo.On("DeleteByCategoryFromMonth", mock.Anything, validUserID, catID, "2023-06").Return(nil)
+		txUOW := &MockUnitOfWork{TrackingRepo: txRepo, ExpenseRepo: txExpenseRepo}
+		txUOW.On("Commit").Return(nil)
+
+		usecase := newUseCase(group)
+		usecase.uow.(*MockUnitOfWork).On("Begin", mock.Anything).Return(txUOW, nil)
+
+		err := usecase.Delete(context.Background(), &DeleteCategoryRequest{
+			ID:      catID.String(),
+			GroupID: group.ID.String(),
+			UserID:  validUserID.String(),
+			Mode:    DeleteModeEnd,
+			Month:   "2023-06",
+		})
+		require.NoError(t, err)
+		txExpenseRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain"
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
+	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+)
+
+type ClosingUseCaseImpl struct {
+	uow    domain.UnitOfWork
+	logger *slog.Logger
+}
+
+func NewClosingUseCase(uow domain.UnitOfWork, logger *slog.Logger) ClosingUseCaseImpl {
+	return ClosingUseCaseImpl{
+		uow:    uow,
+		logger: logger,
+	}
+}
+
+// Status reports whether the month is closed. For an open month it also
+// counts the expenses that are still unpaid.
+func (u ClosingUseCaseImpl) Status(ctx context.Context, userID string, month string) (*MonthStatusResponse, error) {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := tracking.ParseMonth(month)
+	if err != nil {
+		return nil, err
+	}
+
+	monthClose, err := u.uow.ClosingRepository().FindByUserIDAndMonth(ctx, uID, m.Value())
+	if err == nil {
+		return &MonthStatusResponse{
+			Month:       monthClose.Month,
+			Closed:      true,
+			ClosedAt:    monthClose.ClosedAt,
+			UnpaidCount: monthClose.UnpaidCount,
+		}, nil
+	}
+	if !errors.Is(err, closing.ErrMonthNotClosed) {
+		return nil, err
+	}
+
+	return u.openStatus(ctx, uID, m.Value())
+}
+
+// Close snapshots the month's dashboard and locks the month against changes.
+// Unpaid expenses block closing unless the request accepts them.
+func (u ClosingUseCaseImpl) Close(ctx context.Context, req *CloseMonthRequest) (*MonthStatusResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	uID, err := identifier.ParseID(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := tracking.ParseMonth(req.Month)
+	if err != nil {
+		return nil, err
+	}
+	month := m.Value()
+
+	if err := ensureMonthOpen(ctx, u.uow, uID, month); err != nil {
+		return nil, err
+	}
+
+	status, err := u.openStatus(ctx, uID, month)
+	if err != nil {
+		return nil, err
+	}
+	if status.UnpaidCount > 0 && !req.AcceptUnpaid {
+		return nil, closing.ErrUnpaidExpenses
+	}
+
+	dashboard, err := buildDashboard(ctx, u.uow, uID, month)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := json.Marshal(dashboard)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := newClosingTotals(dashboard, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := identifier.NewID()
+	if err != nil {
+		return nil, err
+	}
+
+	monthClose, err := closing.NewMonthClose(id, uID, month, totals, status.UnpaidCount, snapshot, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := txUOW.ClosingRepository().Save(ctx, *monthClose); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
+	return &MonthStatusResponse{
+		Month:       month,
+		Closed:      true,
+		ClosedAt:    monthClose.ClosedAt,
+		UnpaidCount: status.UnpaidCount,
+		UnpaidCents: status.UnpaidCents,
+	}, nil
+}
+
+// Reopen discards the month's snapshot so it can be edited again.
+func (u ClosingUseCaseImpl) Reopen(ctx context.Context, userID string, month string) error {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return err
+	}
+
+	m, err := tracking.ParseMonth(month)
+	if err != nil {
+		return err
+	}
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := txUOW.ClosingRepository().Delete(ctx, uID, m.Value()); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func (u ClosingUseCaseImpl) openStatus(ctx context.Context, uID identifier.ID, month string) (*MonthStatusResponse, error) {
+	expenses, err := u.uow.ExpenseRepository().FindByUserIDAndMonth(ctx, uID, month)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &MonthStatusResponse{Month: month}
+	for _, exp := range expenses {
+		if exp.Payment.IsPaid() {
+			continue
+		}
+		status.UnpaidCount++
+		status.UnpaidCents += exp.Amount.Cents()
+	}
+	return status, nil
+}
+
+func newClosingTotals(dashboard *DashboardResponse, currency string) (closing.Totals, error) {
+	income, err := money.New(dashboard.TotalIncomeCents, currency)
+	if err != nil {
+		return closing.Totals{}, err
+	}
+	expenses, err := money.New(dashboard.TotalExpensesCents, currency)
+	if err != nil {
+		return closing.Totals{}, err
+	}
+	budgeted, err := money.New(dashboard.TotalBudgetedCents, currency)
+	if err != nil {
+		return closing.Totals{}, err
+	}
+	paid, err := money.New(dashboard.PaidExpensesCents, currency)
+	if err != nil {
+		return closing.Totals{}, err
+	}
+
+	return closing.Totals{
+		Income:       income,
+		Expenses:     expenses,
+		Budgeted:     budgeted,
+		PaidExpenses: paid,
+	}, nil
+}
+
+// ensureMonthOpen rejects writes that would change a closed month.
+func ensureMonthOpen(ctx context.Context, uow domain.UnitOfWork, userID identifier.ID, month string) error {
+	closed, err := uow.ClosingRepository().IsClosed(ctx, userID, month)
+	if err != nil {
+		return err
+	}
+	if closed {
+		return closing.ErrMonthClosed
+	}
+	return nil
+}
+
+// ensureExpenseMonthsOpen rejects writes to the expenses of categoryIDs when
+// a month they were recorded in, from month onwards, is closed. An empty month
+// checks every month.
+func ensureExpenseMonthsOpen(ctx context.Context, uow domain.UnitOfWork, userID identifier.ID, categoryIDs []identifier.ID, month string) error {
+	if len(categoryIDs) == 0 {
+		return nil
+	}
+
+	counts, err := uow.ExpenseRepository().CountByCategoriesPerMonth(ctx, userID, categoryIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range counts {
+		if c.Month < month {
+			continue
+		}
+		if err := ensureMonthOpen(ctx, uow, userID, c.Month); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var _ ClosingUseCase = (*ClosingUseCaseImpl)(nil)
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
+	"github.com/madalinpopa/gocost-web/internal/domain/expense"
+	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClosingUseCase_Status(t *testing.T) {
+	userID, _ := identifier.NewID()
+	month := "2024-03"
+	spentAt := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("counts unpaid expenses of an open month", func(t *testing.T) {
+		categoryID, _ := identifier.NewID()
+		paid, err := expense.NewPaidStatus(spentAt)
+		require.NoError(t, err)
+
+		expenseRepo := &MockExpenseRepository{}
+		expenseRepo.On("FindByUserIDAndMonth", mock.Anything, userID, month).Return([]expense.Expense{
+			newDashboardExpense(t, categoryID, 10, "Rent", spentAt, paid),
+			newDashboardExpense(t, categoryID, 25.5, "Power", spentAt, expense.NewUnpaidStatus()),
+		}, nil)
+
+		usecase := NewClosingUseCase(&MockUnitOfWork{ExpenseRepo: expenseRepo}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		status, err := usecase.Status(context.Background(), userID.String(), month)
+
+		require.NoError(t, err)
+		assert.False(t, status.Closed)
+		assert.Equal(t, 1, status.UnpaidCount)
+		assert.Equal(t, int64(2550), status.UnpaidCents)
+	})
+
+	t.Run("reports a closed month from its record", func(t *testing.T) {
+		closedAt := time.Date(2024, time.April, 1, 9, 0, 0, 0, time.UTC)
+		closingRepo := &MockClosingRepository{}
+		closingRepo.On("FindByUserIDAndMonth", mock.Anything, userID, month).Return(closing.MonthClose{
+			Month:       month,
+			UnpaidCount: 2,
+			ClosedAt:    closedAt,
+		}, nil)
+
+		usecase := NewClosingUseCase(&MockUnitOfWork{ClosingRepo: closingRepo}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		status, err := usecase.Status(context.Background(), userID.String(), month)
+
+		require.NoError(t, err)
+		assert.True(t, status.Closed)
+		assert.Equal(t, closedAt, status.ClosedAt)
+		assert.Equal(t, 2, status.UnpaidCount)
+	})
+}
+
+func TestClosingUseCase_Close(t *testing.T) {
+	userID, _ := identifier.NewID()
+	month := "2024-03"
+	spentAt := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	group := newDashboardGroup(t, userID, "Home", 0)
+	category := addDashboardCategory(t, group, "Utilities", 10000)
+	unpaid := newDashboardExpense(t, category.ID, 40, "Power", spentAt, expense.NewUnpaidStatus())
+
+	newRepos := func() (*MockGroupRepository, *MockIncomeRepository, *MockExpenseRepository) {
+		trackingRepo := &MockGroupRepository{}
+		trackingRepo.On("FindByUserIDAndMonth", mock.Anything, userID, month).Return([]tracking.Group{*group}, nil)
+		incomeRepo := &MockIncomeRepository{}
+		incomeRepo.On("TotalByUserIDAndMonth", mock.Anything, userID, month).Return(mustMoneyFromFloat(t, 1000), nil)
+		expenseRepo := &MockExpenseRepository{}
+		expenseRepo.On("FindByUserIDAndMonth", mock.Anything, userID, month).Return([]expense.Expense{unpaid}, nil)
+		expenseRepo.On("Total", mock.Anything, userID, month).Return(mustMoneyFromFloat(t, 40), nil)
+		expenseRepo.On("TotalsByCategoryAndMonth", mock.Anything, userID, month).Return([]expense.CategoryTotals{
+			{CategoryID: category.ID, Total: mustMoneyFromFloat(t, 40), PaidTotal: mustMoneyFromFloat(t, 0)},
+		}, nil)
+		return trackingRepo, incomeRepo, expenseRepo
+	}
+
+	t.Run("returns error for nil request", func(t *testing.T) {
+		usecase := NewClosingUseCase(&MockUnitOfWork{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		resp, err := usecase.Close(context.Background(), nil)
+		assert.Nil(t, resp)
+		assert.EqualError(t, err, "request cannot be nil")
+	})
+
+	t.Run("refuses a month that is already closed", func(t *testing.T) {
+		closingRepo := &MockClosingRepository{}
+		closingRepo.On("IsClosed", mock.Anything, userID, month).Return(true, nil)
+
+		usecase := NewClosingUseCase(&MockUnitOfWork{ClosingRepo: closingRepo}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		resp, err := usecase.Close(context.Background(), &CloseMonthRequest{UserID: userID.String(), Currency: "USD", Month: month})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, closing.ErrMonthClosed)
+	})
+
+	t.Run("refuses unpaid expenses unless accepted", func(t *testing.T) {
+		trackingRepo, incomeRepo, expenseRepo := newRepos()
+		baseUOW := &MockUnitOfWork{TrackingRepo: trackingRepo, IncomeRepo: incomeRepo, ExpenseRepo: expenseRepo}
+
+		usecase := NewClosingUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		resp, err := usecase.Close(context.Background(), &CloseMonthRequest{UserID: userID.String(), Currency: "USD", Month: month})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, closing.ErrUnpaidExpenses)
+		baseUOW.AssertNotCalled(t, "Begin", mock.Anything)
+	})
+
+	t.Run("stores a snapshot of the dashboard", func(t *testing.T) {
+		trackingRepo, incomeRepo, expenseRepo := newRepos()
+
+		var saved closing.MonthClose
+		txClosingRepo := &MockClosingRepository{}
+		txClosingRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(closing.MonthClose)
+		})
+		txUOW := &MockUnitOfWork{ClosingRepo: txClosingRepo}
+		txUOW.On("Commit").Return(nil)
+		baseUOW := &MockUnitOfWork{TrackingRepo: trackingRepo, IncomeRepo: incomeRepo, ExpenseRepo: expenseRepo}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+
+		usecase := NewClosingUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		resp, err := usecase.Close(context.Background(), &CloseMonthRequest{
+			UserID:       userID.String(),
+			Currency:     "USD",
+			Month:        month,
+			AcceptUnpaid: true,
+		})
+
+		require.NoError(t, err)
+		assert.True(t, resp.Closed)
+		assert.Equal(t, 1, resp.UnpaidCount)
+		assert.Equal(t, month, saved.Month)
+		assert.Equal(t, int64(100000), saved.Totals.Income.Cents())
+		assert.Equal(t, int64(4000), saved.Totals.Expenses.Cents())
+		assert.Equal(t, int64(10000), saved.Totals.Budgeted.Cents())
+
+		var snapshot DashboardResponse
+		require.NoError(t, json.Unmarshal(saved.Snapshot, &snapshot))
+		require.Len(t, snapshot.Groups, 1)
+		assert.Equal(t, "Utilities", snapshot.Groups[0].Categories[0].Name)
+		assert.Equal(t, int64(4000), snapshot.Groups[0].Categories[0].SpentCents)
+	})
+}
+
+func TestClosingUseCase_Reopen(t *testing.T) {
+	userID, _ := identifier.NewID()
+
+	t.Run("deletes the month close", func(t *testing.T) {
+		txClosingRepo := &MockClosingRepository{}
+		txClosingRepo.On("Delete", mock.Anything, userID, "2024-03").Return(nil)
+		txUOW := &MockUnitOfWork{ClosingRepo: txClosingRepo}
+		txUOW.On("Commit").Return(nil)
+		baseUOW := &MockUnitOfWork{}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+
+		usecase := NewClosingUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		err := usecase.Reopen(context.Background(), userID.String(), "2024-03")
+
+		require.NoError(t, err)
+		txClosingRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns error when the month is not closed", func(t *testing.T) {
+		txClosingRepo := &MockClosingRepository{}
+		txClosingRepo.On("Delete", mock.Anything, userID, "2024-03").Return(closing.ErrMonthNotClosed)
+		txUOW := &MockUnitOfWork{ClosingRepo: txClosingRepo}
+		txUOW.On("Rollback").Return(nil)
+		baseUOW := &MockUnitOfWork{}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+
+		usecase := NewClosingUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		err := usecase.Reopen(context.Background(), userID.String(), "2024-03")
+
+		assert.ErrorIs(t, err, closing.ErrMonthNotClosed)
+	})
+}
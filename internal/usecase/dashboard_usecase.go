@@ -2,26 +2,39 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"log/slog"
+	"time"
 
 	"github.com/madalinpopa/gocost-web/internal/domain"
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
 	"github.com/madalinpopa/gocost-web/internal/domain/expense"
+	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
 	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
 )
 
 type DashboardUseCaseImpl struct {
 	uow    domain.UnitOfWork
 	logger *slog.Logger
+	now    func() time.Time
 }
 
 func NewDashboardUseCase(uow domain.UnitOfWork, logger *slog.Logger) DashboardUseCaseImpl {
 	return DashboardUseCaseImpl{
 		uow:    uow,
 		logger: logger,
+		now:    time.Now,
 	}
 }
 
+// WithClock returns the use case reading the current time from now, which
+// decides the month in progress and how far into it the forecast is.
+func (u DashboardUseCaseImpl) WithClock(now func() time.Time) DashboardUseCaseImpl {
+	u.now = now
+	return u
+}
+
 func (u DashboardUseCaseImpl) Get(ctx context.Context, req *DashboardRequest) (*DashboardResponse, error) {
 	if req == nil {
 		return nil, errors.New("request cannot be nil")
@@ -38,32 +51,76 @@ func (u DashboardUseCaseImpl) Get(ctx context.Context, req *DashboardRequest) (*
 		return nil, err
 	}
 
-	trackingRepo := u.uow.TrackingRepository()
-	groups, err := trackingRepo.FindByUserIDAndMonth(ctx, uID, req.Month)
+	user, err := u.uow.UserRepository().FindByID(ctx, uID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A closed month is reported exactly as it was when it was closed.
+	monthClose, err := u.uow.ClosingRepository().FindByUserIDAndMonth(ctx, uID, req.Month)
+	if err == nil {
+		var snapshot DashboardResponse
+		if err := json.Unmarshal(monthClose.Snapshot, &snapshot); err != nil {
+			return nil, err
+		}
+		snapshot.Closed = true
+		snapshot.ClosedAt = monthClose.ClosedAt
+		snapshot.ZeroBased = user.ZeroBasedBudgeting
+		return &snapshot, nil
+	}
+	if !errors.Is(err, closing.ErrMonthNotClosed) {
+		return nil, err
+	}
+
+	dashboard, err := buildDashboard(ctx, u.uow, uID, req.Month)
+	if err != nil {
+		return nil, err
+	}
+	dashboard.ZeroBased = user.ZeroBasedBudgeting
+
+	if today := u.now(); tracking.NewMonthFromTime(today).Value() == req.Month {
+		if err := forecastDashboard(ctx, u.uow, uID, today, dashboard); err != nil {
+			return nil, err
+		}
+	}
+
+	return dashboard, nil
+}
+
+// buildDashboard computes the dashboard of a month from the current records.
+func buildDashboard(ctx context.Context, uow domain.UnitOfWork, uID identifier.ID, month string) (*DashboardResponse, error) {
+	trackingRepo := uow.TrackingRepository()
+	groups, err := trackingRepo.FindByUserIDAndMonth(ctx, uID, month)
 	if err != nil {
 		return nil, err
 	}
 
-	incomeTotal, err := u.uow.IncomeRepository().TotalByUserIDAndMonth(ctx, uID, req.Month)
+	incomeTotal, err := uow.IncomeRepository().TotalByUserIDAndMonth(ctx, uID, month)
 	if err != nil {
 		return nil, err
 	}
 
-	expenseTotal, err := u.uow.ExpenseRepository().Total(ctx, uID, req.Month)
+	expenseTotal, err := uow.ExpenseRepository().Total(ctx, uID, month)
 	if err != nil {
 		return nil, err
 	}
 
-	categoryTotals, err := u.uow.ExpenseRepository().TotalsByCategoryAndMonth(ctx, uID, req.Month)
+	categoryTotals, err := uow.ExpenseRepository().TotalsByCategoryAndMonth(ctx, uID, month)
 	if err != nil {
 		return nil, err
 	}
 
-	expenses, err := u.uow.ExpenseRepository().FindByUserIDAndMonth(ctx, uID, req.Month)
+	expenses, err := uow.ExpenseRepository().FindByUserIDAndMonth(ctx, uID, month)
 	if err != nil {
 		return nil, err
 	}
 
+	savingGoals, err := uow.SavingRepository().FindByUserID(ctx, uID)
+	if err != nil {
+		return nil, err
+	}
+	goals, savingsCents := goalsForMonth(savingGoals, month)
+
 	activeCategoryIDs := make(map[string]struct{})
 	for _, group := range groups {
 		for _, category := range group.Categories {
@@ -80,15 +137,11 @@ func (u DashboardUseCaseImpl) Get(ctx context.Context, req *DashboardRequest) (*
 		expensesByCategory[categoryID] = append(expensesByCategory[categoryID], mapExpenseToResponse(&exp))
 	}
 
-	type totals struct {
-		spentCents int64
-		paidCents  int64
-	}
-	totalsByCategory := make(map[string]totals, len(categoryTotals))
+	totalsByCategory := make(map[string]spendingTotals, len(categoryTotals))
 	var paidExpensesCents int64
 	for _, categoryTotal := range categoryTotals {
 		categoryID := categoryTotal.CategoryID.String()
-		totalsByCategory[categoryID] = totals{
+		totalsByCategory[categoryID] = spendingTotals{
 			spentCents: categoryTotal.Total.Cents(),
 			paidCents:  categoryTotal.PaidTotal.Cents(),
 		}
@@ -98,26 +151,12 @@ func (u DashboardUseCaseImpl) Get(ctx context.Context, req *DashboardRequest) (*
 	var totalBudgetedCents int64
 	groupResponses := make([]DashboardGroupResponse, 0, len(groups))
 	for _, group := range groups {
-		categories := make([]DashboardCategoryResponse, 0, len(group.Categories))
-		for _, category := range group.Categories {
-			categoryID := category.ID.String()
-			categoryTotals := totalsByCategory[categoryID]
-
-			budgetCents := category.Budget.Cents()
-			totalBudgetedCents += budgetCents
-
-			categories = append(categories, DashboardCategoryResponse{
-				ID:             categoryID,
-				Name:           category.Name.Value(),
-				Description:    category.Description.Value(),
-				IsRecurrent:    category.IsRecurrent,
-				StartMonth:     category.StartMonth.Value(),
-				EndMonth:       category.EndMonth.Value(),
-				BudgetCents:    budgetCents,
-				SpentCents:     categoryTotals.spentCents,
-				PaidSpentCents: categoryTotals.paidCents,
-				Expenses:       expensesByCategory[categoryID],
-			})
+		topLevel := group.TopLevelCategories()
+		categories := make([]DashboardCategoryResponse, 0, len(topLevel))
+		for _, category := range topLevel {
+			response := buildDashboardCategory(&group, category, totalsByCategory, expensesByCategory)
+			totalBudgetedCents += response.BudgetCents
+			categories = append(categories, response)
 		}
 
 		groupResponses = append(groupResponses, DashboardGroupResponse{
@@ -125,6 +164,7 @@ func (u DashboardUseCaseImpl) Get(ctx context.Context, req *DashboardRequest) (*
 			Name:        group.Name.Value(),
 			Description: group.Description.Value(),
 			Order:       group.Order.Value(),
+			Archived:    group.Archived,
 			Categories:  categories,
 		})
 	}
@@ -134,10 +174,56 @@ func (u DashboardUseCaseImpl) Get(ctx context.Context, req *DashboardRequest) (*
 		TotalExpensesCents: expenseTotal.Cents(),
 		TotalBudgetedCents: totalBudgetedCents,
 		PaidExpensesCents:  paidExpensesCents,
+		SavingsCents:       savingsCents,
 		Groups:             groupResponses,
+		Goals:              goals,
 	}, nil
 }
 
+type spendingTotals struct {
+	spentCents int64
+	paidCents  int64
+}
+
+// buildDashboardCategory maps a category together with its subcategories,
+// rolling spending up to the parent. A parent without a budget of its own is
+// budgeted at the sum of its subcategories.
+func buildDashboardCategory(group *tracking.Group, category *tracking.Category, totalsByCategory map[string]spendingTotals, expensesByCategory map[string][]*ExpenseResponse) DashboardCategoryResponse {
+	categoryID := category.ID.String()
+	totals := totalsByCategory[categoryID]
+	ownBudgetCents := category.Budget.Cents()
+
+	response := DashboardCategoryResponse{
+		ID:             categoryID,
+		ParentID:       parentIDString(category.ParentID),
+		Name:           category.Name.Value(),
+		Description:    category.Description.Value(),
+		IsRecurrent:    category.IsRecurrent,
+		StartMonth:     category.StartMonth.Value(),
+		EndMonth:       category.EndMonth.Value(),
+		BudgetCents:    ownBudgetCents,
+		OwnBudgetCents: ownBudgetCents,
+		SpentCents:     totals.spentCents,
+		PaidSpentCents: totals.paidCents,
+		Archived:       category.Archived,
+		Expenses:       expensesByCategory[categoryID],
+	}
+
+	var subcategoryBudgetCents int64
+	for _, sub := range group.Subcategories(category.ID) {
+		subResponse := buildDashboardCategory(group, sub, totalsByCategory, expensesByCategory)
+		subcategoryBudgetCents += subResponse.BudgetCents
+		response.SpentCents += subResponse.SpentCents
+		response.PaidSpentCents += subResponse.PaidSpentCents
+		response.Subcategories = append(response.Subcategories, subResponse)
+	}
+	if ownBudgetCents <= 0 {
+		response.BudgetCents = subcategoryBudgetCents
+	}
+
+	return response
+}
+
 func mapExpenseToResponse(exp *expense.Expense) *ExpenseResponse {
 	if exp == nil {
 		return nil
@@ -152,6 +238,7 @@ func mapExpenseToResponse(exp *expense.Expense) *ExpenseResponse {
 		SpentAt:     exp.SpentAt,
 		IsPaid:      exp.Payment.IsPaid(),
 		PaidAt:      exp.Payment.PaidAt(),
+		AccountID:   accountIDString(exp.AccountID),
 	}
 }
 
@@ -8,7 +8,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
 	"github.com/madalinpopa/gocost-web/internal/domain/expense"
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/domain/loan"
+	"github.com/madalinpopa/gocost-web/internal/domain/saving"
 	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
 	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
 	"github.com/madalinpopa/gocost-web/internal/platform/money"
@@ -30,6 +34,7 @@ func newTestDashboardUseCase(trackingRepo *MockGroupRepository, incomeRepo *Mock
 
 	return NewDashboardUseCase(
 		&MockUnitOfWork{
+			UserRepo:     newDashboardUserRepo(false),
 			TrackingRepo: trackingRepo,
 			IncomeRepo:   incomeRepo,
 			ExpenseRepo:  expenseRepo,
@@ -38,6 +43,12 @@ func newTestDashboardUseCase(trackingRepo *MockGroupRepository, incomeRepo *Mock
 	)
 }
 
+func newDashboardUserRepo(zeroBased bool) *MockUserRepository {
+	repo := &MockUserRepository{}
+	repo.On("FindByID", mock.Anything, mock.Anything).Return(identity.User{ZeroBasedBudgeting: zeroBased}, nil).Maybe()
+	return repo
+}
+
 func newDashboardGroup(t *testing.T, userID identifier.ID, name string, order int) *tracking.Group {
 	t.Helper()
 
@@ -335,3 +346,214 @@ func TestDashboardUseCase_Get_Success(t *testing.T) {
 	assert.Equal(t, int64(0), categoryCResp.PaidSpentCents)
 	require.Empty(t, categoryCResp.Expenses)
 }
+
+func TestDashboardUseCase_Get_RollsUpSubcategories(t *testing.T) {
+	userID, _ := identifier.NewID()
+	month := "2024-02"
+
+	group := newDashboardGroup(t, userID, "Household", 0)
+	utilities := addDashboardCategory(t, group, "Utilities", 0)
+	startMonth, err := tracking.ParseMonth("2024-01")
+	require.NoError(t, err)
+
+	addSub := func(name string, budgetCents int64) *tracking.Category {
+		id, err := identifier.NewID()
+		require.NoError(t, err)
+		nameVO, err := tracking.NewNameVO(name)
+		require.NoError(t, err)
+		descVO, err := tracking.NewDescriptionVO("")
+		require.NoError(t, err)
+		budget, err := money.New(budgetCents, "USD")
+		require.NoError(t, err)
+		sub, err := group.CreateSubcategory(utilities.ID, id, nameVO, descVO, true, startMonth, tracking.Month{}, budget)
+		require.NoError(t, err)
+		return sub
+	}
+	electricity := addSub("Electricity", 6000)
+	water := addSub("Water", 4000)
+
+	categoryTotals := []expense.CategoryTotals{
+		{CategoryID: electricity.ID, Total: mustMoneyFromFloat(t, 55.0), PaidTotal: mustMoneyFromFloat(t, 55.0)},
+		{CategoryID: water.ID, Total: mustMoneyFromFloat(t, 30.0), PaidTotal: mustMoneyFromFloat(t, 0)},
+	}
+
+	trackingRepo := &MockGroupRepository{}
+	trackingRepo.On("FindByUserIDAndMonth", mock.Anything, userID, month).Return([]tracking.Group{*group}, nil)
+	incomeRepo := &MockIncomeRepository{}
+	incomeRepo.On("TotalByUserIDAndMonth", mock.Anything, userID, month).Return(mustMoneyFromFloat(t, 500.0), nil)
+	expenseRepo := &MockExpenseRepository{}
+	expenseRepo.On("Total", mock.Anything, userID, month).Return(mustMoneyFromFloat(t, 85.0), nil)
+	expenseRepo.On("TotalsByCategoryAndMonth", mock.Anything, userID, month).Return(categoryTotals, nil)
+	expenseRepo.On("FindByUserIDAndMonth", mock.Anything, userID, month).Return([]expense.Expense{}, nil)
+
+	usecase := newTestDashboardUseCase(trackingRepo, incomeRepo, expenseRepo)
+	resp, err := usecase.Get(context.Background(), &DashboardRequest{UserID: userID.String(), Month: month})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(10000), resp.TotalBudgetedCents)
+	require.Len(t, resp.Groups, 1)
+	require.Len(t, resp.Groups[0].Categories, 1)
+
+	parent := resp.Groups[0].Categories[0]
+	assert.Equal(t, utilities.ID.String(), parent.ID)
+	assert.Equal(t, int64(0), parent.OwnBudgetCents)
+	assert.Equal(t, int64(10000), parent.BudgetCents)
+	assert.Equal(t, int64(8500), parent.SpentCents)
+	assert.Equal(t, int64(5500), parent.PaidSpentCents)
+	require.Len(t, parent.Subcategories, 2)
+	assert.Equal(t, utilities.ID.String(), parent.Subcategories[0].ParentID)
+	assert.Equal(t, int64(5500), parent.Subcategories[0].SpentCents)
+}
+
+func TestDashboardUseCase_Get_ClosedMonth(t *testing.T) {
+	userID, _ := identifier.NewID()
+	month := "2024-03"
+	closedAt := time.Date(2024, time.April, 2, 8, 0, 0, 0, time.UTC)
+
+	snapshot := `{"TotalIncomeCents":100000,"TotalExpensesCents":4000,"Groups":[{"Name":"Home"}]}`
+	closingRepo := &MockClosingRepository{}
+	closingRepo.On("FindByUserIDAndMonth", mock.Anything, userID, month).Return(closing.MonthClose{
+		Month:    month,
+		Snapshot: []byte(snapshot),
+		ClosedAt: closedAt,
+	}, nil)
+
+	// The live repositories have no expectations: a closed month must not
+	// be recomputed.
+	usecase := NewDashboardUseCase(&MockUnitOfWork{
+		UserRepo:     newDashboardUserRepo(true),
+		TrackingRepo: &MockGroupRepository{},
+		IncomeRepo:   &MockIncomeRepository{},
+		ExpenseRepo:  &MockExpenseRepository{},
+		ClosingRepo:  closingRepo,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	resp, err := usecase.Get(context.Background(), &DashboardRequest{UserID: userID.String(), Month: month})
+
+	require.NoError(t, err)
+	assert.True(t, resp.Closed)
+	assert.Equal(t, closedAt, resp.ClosedAt)
+	assert.True(t, resp.ZeroBased)
+	assert.Equal(t, int64(100000), resp.TotalIncomeCents)
+	assert.Equal(t, int64(4000), resp.TotalExpensesCents)
+	require.Len(t, resp.Groups, 1)
+	assert.Equal(t, "Home", resp.Groups[0].Name)
+}
+
+func TestDashboardUseCase_Get_Savings(t *testing.T) {
+	userID, _ := identifier.NewID()
+	month := "2024-03"
+
+	started := newTestGoal(t, userID)
+	contributeToGoal(t, started, "2024-02", 10000)
+	contributeToGoal(t, started, "2024-03", 4000)
+	contributeToGoal(t, started, "2024-03", 1000)
+	future := newTestGoal(t, userID)
+	future.StartMonth = "2024-05"
+
+	trackingRepo := &MockGroupRepository{}
+	trackingRepo.On("FindByUserIDAndMonth", mock.Anything, userID, month).Return([]tracking.Group{}, nil)
+	incomeRepo := &MockIncomeRepository{}
+	incomeRepo.On("TotalByUserIDAndMonth", mock.Anything, userID, month).Return(mustMoneyFromFloat(t, 500.0), nil)
+	expenseRepo := &MockExpenseRepository{}
+	expenseRepo.On("Total", mock.Anything, userID, month).Return(mustMoneyFromFloat(t, 0), nil)
+	expenseRepo.On("TotalsByCategoryAndMonth", mock.Anything, userID, month).Return([]expense.CategoryTotals{}, nil)
+	expenseRepo.On("FindByUserIDAndMonth", mock.Anything, userID, month).Return([]expense.Expense{}, nil)
+	savingRepo := &MockSavingRepository{}
+	savingRepo.On("FindByUserID", mock.Anything, userID).Return([]saving.Goal{*started, *future}, nil)
+
+	usecase := NewDashboardUseCase(&MockUnitOfWork{
+		UserRepo:     newDashboardUserRepo(false),
+		TrackingRepo: trackingRepo,
+		IncomeRepo:   incomeRepo,
+		ExpenseRepo:  expenseRepo,
+		SavingRepo:   savingRepo,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	resp, err := usecase.Get(context.Background(), &DashboardRequest{UserID: userID.String(), Month: month})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), resp.SavingsCents)
+	assert.Equal(t, int64(0), resp.TotalExpensesCents)
+	require.Len(t, resp.Goals, 1)
+	assert.Equal(t, started.ID.String(), resp.Goals[0].ID)
+	assert.Equal(t, int64(15000), resp.Goals[0].SavedCents)
+}
+
+func TestDashboardUseCase_Get_Forecast(t *testing.T) {
+	userID, _ := identifier.NewID()
+	today := time.Date(2024, time.March, 15, 18, 0, 0, 0, time.UTC)
+
+	group := newDashboardGroup(t, userID, "Living", 0)
+	food := addDashboardCategory(t, group, "Food", 15000)
+	car := addDashboardCategory(t, group, "Car", 30000)
+
+	trackingRepo := &MockGroupRepository{}
+	trackingRepo.On("FindByUserIDAndMonth", mock.Anything, userID, "2024-03").Return([]tracking.Group{*group}, nil)
+	trackingRepo.On("FindByUserIDAndMonth", mock.Anything, userID, "2024-02").Return([]tracking.Group{*group}, nil)
+	trackingRepo.On("FindByUserIDAndMonth", mock.Anything, userID, mock.Anything).Return([]tracking.Group{}, nil)
+
+	incomeRepo := &MockIncomeRepository{}
+	incomeRepo.On("TotalByUserIDAndMonth", mock.Anything, userID, mock.Anything).Return(mustMoneyFromFloat(t, 2000.0), nil)
+
+	expenseRepo := &MockExpenseRepository{}
+	expenseRepo.On("Total", mock.Anything, userID, mock.Anything).Return(mustMoneyFromFloat(t, 120.0), nil)
+	expenseRepo.On("TotalsByCategoryAndMonth", mock.Anything, userID, "2024-03").Return([]expense.CategoryTotals{
+		newTestCategoryTotals(food.ID, 12000),
+	}, nil)
+	expenseRepo.On("TotalsByCategoryAndMonth", mock.Anything, userID, "2024-02").Return([]expense.CategoryTotals{
+		newTestCategoryTotals(food.ID, 15000),
+	}, nil)
+	expenseRepo.On("TotalsByCategoryAndMonth", mock.Anything, userID, mock.Anything).Return([]expense.CategoryTotals{}, nil)
+	paid, err := expense.NewPaidStatus(time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	expenseRepo.On("FindByUserIDAndMonth", mock.Anything, userID, "2024-03").Return([]expense.Expense{
+		newDashboardExpense(t, food.ID, 100.0, "Groceries", time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC), paid),
+		newDashboardExpense(t, food.ID, 20.0, "Delivery", time.Date(2024, time.March, 20, 0, 0, 0, 0, time.UTC), expense.NewUnpaidStatus()),
+	}, nil)
+	expenseRepo.On("FindByUserIDAndMonth", mock.Anything, userID, mock.Anything).Return([]expense.Expense{}, nil)
+
+	loanRepo := &MockLoanRepository{}
+	loanRepo.On("FindByUserID", mock.Anything, userID).Return([]loan.Loan{newTestLoan(t, userID, car.ID)}, nil)
+	loanRepo.On("Payments", mock.Anything, userID, car.ID).Return([]loan.Payment{}, nil)
+
+	usecase := NewDashboardUseCase(
+		&MockUnitOfWork{
+			UserRepo:     newDashboardUserRepo(false),
+			TrackingRepo: trackingRepo,
+			IncomeRepo:   incomeRepo,
+			ExpenseRepo:  expenseRepo,
+			LoanRepo:     loanRepo,
+		},
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	).WithClock(func() time.Time { return today })
+
+	t.Run("projects the month in progress", func(t *testing.T) {
+		resp, err := usecase.Get(context.Background(), &DashboardRequest{UserID: userID.String(), Month: "2024-03"})
+		require.NoError(t, err)
+		require.NotNil(t, resp.Forecast)
+
+		// Food spent 100.00 in 15 of 31 days and 150.00 in February; the 16
+		// days left are estimated at 91.57, more than the 20.00 recorded for
+		// them. Car has nothing spent but the 100.00 loan installment due.
+		categories := resp.Groups[0].Categories
+		assert.Equal(t, int64(19157), categories[0].ProjectedCents)
+		assert.Equal(t, int64(10000), categories[1].ProjectedCents)
+
+		assert.Equal(t, 15, resp.Forecast.DaysElapsed)
+		assert.Equal(t, 31, resp.Forecast.DaysInMonth)
+		assert.Equal(t, int64(29157), resp.Forecast.ProjectedExpensesCents)
+		assert.Equal(t, int64(170843), resp.Forecast.ProjectedBalanceCents)
+		require.Len(t, resp.Forecast.AtRisk, 1)
+		assert.Equal(t, food.ID.String(), resp.Forecast.AtRisk[0].ID)
+		assert.Equal(t, int64(15000), resp.Forecast.AtRisk[0].BudgetCents)
+	})
+
+	t.Run("does not project other months", func(t *testing.T) {
+		resp, err := usecase.Get(context.Background(), &DashboardRequest{UserID: userID.String(), Month: "2024-02"})
+		require.NoError(t, err)
+
+		assert.Nil(t, resp.Forecast)
+		assert.Zero(t, resp.Groups[0].Categories[0].ProjectedCents)
+	})
+}
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/expense"
+)
+
+// Ways of dealing with the expenses of a group or category being deleted.
+const (
+	DeleteModeHard     = "hard"
+	DeleteModeReassign = "reassign"
+	DeleteModeEnd      = "end"
+)
+
+var ErrInvalidDeleteMode = errors.New("invalid delete mode")
+
+// newDeletionImpact summarises expense counts per month. Expenses recorded in
+// or after month are counted separately since ending a category removes them.
+func newDeletionImpact(counts []expense.MonthlyCount, month string) *DeletionImpactResponse {
+	impact := &DeletionImpactResponse{MonthCount: len(counts)}
+	for _, c := range counts {
+		impact.ExpenseCount += c.Count
+		if month != "" && c.Month >= month {
+			impact.LaterExpenseCount += c.Count
+		}
+	}
+
+	if len(counts) > 0 {
+		impact.FirstMonth = counts[0].Month
+		impact.LastMonth = counts[len(counts)-1].Month
+	}
+
+	return impact
+}
@@ -1,6 +1,10 @@
 package usecase
 
-import "time"
+import (
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/platform/webauthn"
+)
 
 type IDRequest struct {
 	ID string `json:"-"`
@@ -19,6 +23,10 @@ type RegisterUserRequest struct {
 	UsernameRequest
 	Password string `json:"password" validate:"required,min=8"`
 	Currency string `json:"currency"`
+
+	// InviteCode has to be a valid invite code while registration is by
+	// invitation.
+	InviteCode string `json:"invite_code"`
 }
 
 type LoginRequest struct {
@@ -26,6 +34,11 @@ type LoginRequest struct {
 	Password        string `json:"password" validate:"required"`
 }
 
+type ConfirmPasswordRequest struct {
+	UserID   string
+	Password string
+}
+
 type LoginResponse struct {
 	UserID   string `json:"user_id"`
 	Email    string `json:"email"`
@@ -33,6 +46,11 @@ type LoginResponse struct {
 	FullName string `json:"full_name"`
 	Role     string `json:"role"`
 	Currency string `json:"currency"`
+
+	EmailVerified bool `json:"email_verified"`
+	// TwoFactorRequired means the password was right but the user is not
+	// signed in before entering a code from their authenticator app.
+	TwoFactorRequired bool `json:"two_factor_required"`
 }
 
 type UserResponse struct {
@@ -40,6 +58,163 @@ type UserResponse struct {
 	Email    string `json:"email"`
 	Username string `json:"username"`
 	Currency string `json:"currency"`
+
+	EmailVerified bool `json:"email_verified"`
+}
+
+type UpdateProfileRequest struct {
+	UserID string
+	EmailRequest
+	UsernameRequest
+}
+
+type ChangeCurrencyRequest struct {
+	UserID   string
+	Currency string
+}
+
+type ChangePasswordRequest struct {
+	UserID          string
+	CurrentPassword string
+	NewPassword     string
+}
+
+type RequestPasswordResetRequest struct {
+	EmailRequest
+	// ResetURL is the page the emailed link opens; the token is added to it
+	// as the token query parameter.
+	ResetURL string
+}
+
+type SendVerificationRequest struct {
+	UserID string
+	// VerifyURL is the page the emailed link opens; the token is added to it
+	// as the token query parameter.
+	VerifyURL string
+}
+
+type ResetPasswordRequest struct {
+	Token       string
+	NewPassword string
+}
+
+type ConfirmTwoFactorRequest struct {
+	UserID string
+	Code   string
+}
+
+type DisableTwoFactorRequest struct {
+	UserID   string
+	Password string
+}
+
+// VerifyTwoFactorRequest completes a login with a code from the authenticator
+// app or a recovery code.
+type VerifyTwoFactorRequest struct {
+	UserID string
+	Code   string
+}
+
+type TwoFactorStatusResponse struct {
+	Enabled           bool
+	RecoveryCodesLeft int
+}
+
+// TwoFactorSetupResponse is what the authenticator app is given, typed in as
+// the secret or scanned as the URI.
+type TwoFactorSetupResponse struct {
+	Secret string
+	URI    string
+}
+
+// BeginPasskeyRegistrationRequest starts registering a passkey for the user
+// with the site at Origin.
+type BeginPasskeyRegistrationRequest struct {
+	UserID string
+	Origin string
+}
+
+// PasskeyCreationResponse holds the options the browser creates the passkey
+// with. The challenge is kept until the passkey comes back.
+type PasskeyCreationResponse struct {
+	Options   webauthn.CreationOptions
+	Challenge []byte
+}
+
+type FinishPasskeyRegistrationRequest struct {
+	UserID     string
+	Origin     string
+	Name       string
+	Challenge  []byte
+	Credential webauthn.RegistrationResponse
+}
+
+// PasskeyRequestResponse holds the options the browser logs in with. The
+// challenge is kept until the signed assertion comes back.
+type PasskeyRequestResponse struct {
+	Options   webauthn.RequestOptions
+	Challenge []byte
+}
+
+type FinishPasskeyLoginRequest struct {
+	Origin     string
+	Challenge  []byte
+	Credential webauthn.AssertionResponse
+}
+
+type RevokePasskeyRequest struct {
+	UserID    string
+	PasskeyID string
+}
+
+type PasskeyResponse struct {
+	ID         string
+	Name       string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
+
+// StartSessionRequest records a login. With Alert set, a login from a
+// browser the user never logged in from before is emailed to them, with a
+// link to SessionsURL.
+type StartSessionRequest struct {
+	UserID      string
+	IPAddress   string
+	UserAgent   string
+	Alert       bool
+	SessionsURL string
+}
+
+type TouchSessionRequest struct {
+	UserID    string
+	SessionID string
+	IPAddress string
+}
+
+type UserSessionResponse struct {
+	ID         string
+	Device     string
+	IPAddress  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+}
+
+// CreateInviteRequest creates an invite code that registers MaxUses users
+// until ExpiresIn has passed.
+type CreateInviteRequest struct {
+	MaxUses   int
+	ExpiresIn time.Duration
+}
+
+// InviteResponse describes an invite. Code is only known when the invite is
+// created, as just its hash is kept.
+type InviteResponse struct {
+	ID        string
+	Code      string
+	MaxUses   int
+	Uses      int
+	CreatedAt time.Time
+	ExpiresAt time.Time
 }
 
 type CreateIncomeRequest struct {
@@ -48,6 +223,7 @@ type CreateIncomeRequest struct {
 	Amount     float64   `json:"amount" validate:"required,gt=0"`
 	Source     string    `json:"source" validate:"required,max=100"`
 	ReceivedAt time.Time `json:"received_at" validate:"required"`
+	AccountID  string    `json:"account_id,omitempty"`
 }
 
 type UpdateIncomeRequest struct {
@@ -57,6 +233,7 @@ type UpdateIncomeRequest struct {
 	Amount     float64   `json:"amount" validate:"required,gt=0"`
 	Source     string    `json:"source" validate:"required,max=100"`
 	ReceivedAt time.Time `json:"received_at" validate:"required"`
+	AccountID  string    `json:"account_id,omitempty"`
 }
 
 type IncomeResponse struct {
@@ -65,6 +242,7 @@ type IncomeResponse struct {
 	Currency    string    `json:"currency"`
 	Source      string    `json:"source"`
 	ReceivedAt  time.Time `json:"received_at"`
+	AccountID   string    `json:"account_id,omitempty"`
 }
 
 type CreateGroupRequest struct {
@@ -90,6 +268,8 @@ type CategoryResponse struct {
 	StartMonth  string  `json:"start_month"`
 	EndMonth    string  `json:"end_month,omitempty"`
 	Budget      float64 `json:"budget"`
+	Archived    bool    `json:"archived"`
+	ParentID    string  `json:"parent_id,omitempty"`
 }
 
 type GroupResponse struct {
@@ -97,11 +277,13 @@ type GroupResponse struct {
 	Name        string             `json:"name"`
 	Description string             `json:"description"`
 	Order       int                `json:"order"`
+	Archived    bool               `json:"archived"`
 	Categories  []CategoryResponse `json:"categories"`
 }
 
 type CreateCategoryRequest struct {
 	GroupID     string  `json:"group_id" validate:"required"`
+	ParentID    string  `json:"parent_id,omitempty"`
 	UserID      string  `json:"user_id" validate:"required"`
 	Currency    string  `json:"currency" validate:"required"`
 	Name        string  `json:"name" validate:"required,max=100"`
@@ -126,6 +308,45 @@ type UpdateCategoryRequest struct {
 	Budget       float64 `json:"budget" validate:"min=0"`
 }
 
+type MoveCategoryRequest struct {
+	ID            string `json:"-"`
+	GroupID       string `json:"group_id" validate:"required"`
+	UserID        string `json:"user_id" validate:"required"`
+	TargetGroupID string `json:"target_group_id" validate:"required"`
+}
+
+type MergeCategoryRequest struct {
+	ID               string `json:"-"`
+	GroupID          string `json:"group_id" validate:"required"`
+	UserID           string `json:"user_id" validate:"required"`
+	TargetCategoryID string `json:"target_category_id" validate:"required"`
+}
+
+type DeleteGroupRequest struct {
+	ID               string `json:"-"`
+	UserID           string `json:"user_id" validate:"required"`
+	Mode             string `json:"mode" validate:"required"`
+	TargetCategoryID string `json:"target_category_id,omitempty"`
+	Month            string `json:"month,omitempty"`
+}
+
+type DeleteCategoryRequest struct {
+	ID               string `json:"-"`
+	GroupID          string `json:"group_id" validate:"required"`
+	UserID           string `json:"user_id" validate:"required"`
+	Mode             string `json:"mode" validate:"required"`
+	TargetCategoryID string `json:"target_category_id,omitempty"`
+	Month            string `json:"month,omitempty"`
+}
+
+type DeletionImpactResponse struct {
+	ExpenseCount      int    `json:"expense_count"`
+	MonthCount        int    `json:"month_count"`
+	FirstMonth        string `json:"first_month,omitempty"`
+	LastMonth         string `json:"last_month,omitempty"`
+	LaterExpenseCount int    `json:"later_expense_count"`
+}
+
 type CreateExpenseRequest struct {
 	UserID      string     `json:"user_id" validate:"required"`
 	Currency    string     `json:"currency" validate:"required"`
@@ -135,6 +356,7 @@ type CreateExpenseRequest struct {
 	SpentAt     time.Time  `json:"spent_at" validate:"required"`
 	IsPaid      bool       `json:"is_paid"`
 	PaidAt      *time.Time `json:"paid_at,omitempty"`
+	AccountID   string     `json:"account_id,omitempty"`
 }
 
 type UpdateExpenseRequest struct {
@@ -147,6 +369,7 @@ type UpdateExpenseRequest struct {
 	SpentAt     time.Time  `json:"spent_at" validate:"required"`
 	IsPaid      bool       `json:"is_paid"`
 	PaidAt      *time.Time `json:"paid_at,omitempty"`
+	AccountID   string     `json:"account_id,omitempty"`
 }
 
 type ExpenseResponse struct {
@@ -158,6 +381,7 @@ type ExpenseResponse struct {
 	SpentAt     time.Time  `json:"spent_at"`
 	IsPaid      bool       `json:"is_paid"`
 	PaidAt      *time.Time `json:"paid_at,omitempty"`
+	AccountID   string     `json:"account_id,omitempty"`
 }
 
 type DashboardRequest struct {
@@ -165,17 +389,26 @@ type DashboardRequest struct {
 	Month  string
 }
 
+// DashboardCategoryResponse reports budget and spending rolled up over the
+// category's subcategories. OwnBudgetCents is the budget set on the category
+// itself; BudgetCents falls back to the sum of the subcategory budgets when it
+// is zero. ProjectedCents is only set on the dashboard of the current month.
 type DashboardCategoryResponse struct {
 	ID             string
+	ParentID       string
 	Name           string
 	Description    string
 	IsRecurrent    bool
 	StartMonth     string
 	EndMonth       string
 	BudgetCents    int64
+	OwnBudgetCents int64
 	SpentCents     int64
 	PaidSpentCents int64
+	ProjectedCents int64
+	Archived       bool
 	Expenses       []*ExpenseResponse
+	Subcategories  []DashboardCategoryResponse
 }
 
 type DashboardGroupResponse struct {
@@ -183,13 +416,449 @@ type DashboardGroupResponse struct {
 	Name        string
 	Description string
 	Order       int
+	Archived    bool
 	Categories  []DashboardCategoryResponse
 }
 
+type CreateGoalRequest struct {
+	UserID     string
+	Currency   string
+	Name       string
+	Target     float64
+	StartMonth string
+	TargetDate time.Time
+}
+
+type UpdateGoalRequest struct {
+	ID         string
+	UserID     string
+	Currency   string
+	Name       string
+	Target     float64
+	TargetDate time.Time
+}
+
+type ContributeGoalRequest struct {
+	UserID   string
+	Currency string
+	GoalID   string
+	Month    string
+	Amount   float64
+}
+
+type GoalContributionResponse struct {
+	ID          string
+	Month       string
+	AmountCents int64
+}
+
+// GoalResponse reports a savings goal as of the end of Month.
+// MonthContributionCents is what was put aside in that month alone.
+type GoalResponse struct {
+	ID                     string
+	Name                   string
+	Currency               string
+	TargetCents            int64
+	StartMonth             string
+	TargetDate             time.Time
+	Month                  string
+	SavedCents             int64
+	RemainingCents         int64
+	RequiredMonthlyCents   int64
+	MonthContributionCents int64
+	Percent                float64
+	Status                 string
+	Contributions          []GoalContributionResponse
+}
+
+// DashboardResponse describes a month. For a closed month it is the snapshot
+// taken when the month was closed, with Closed and ClosedAt set. SavingsCents
+// is what was put aside for goals in the month, apart from expenses.
 type DashboardResponse struct {
 	TotalIncomeCents   int64
 	TotalExpensesCents int64
 	TotalBudgetedCents int64
 	PaidExpensesCents  int64
 	Groups             []DashboardGroupResponse
+	Closed             bool
+	ClosedAt           time.Time
+	ZeroBased          bool
+	SavingsCents       int64
+	Goals              []GoalResponse
+	Forecast           *ForecastResponse
+}
+
+// ForecastResponse projects the spending of the month in progress to its end.
+// It is only set on the dashboard of the current month.
+type ForecastResponse struct {
+	DaysElapsed            int
+	DaysInMonth            int
+	ProjectedExpensesCents int64
+	ProjectedBalanceCents  int64
+	AtRisk                 []AtRiskCategoryResponse
+}
+
+// AtRiskCategoryResponse is a category projected to end the month over its
+// budget.
+type AtRiskCategoryResponse struct {
+	ID             string
+	Name           string
+	BudgetCents    int64
+	ProjectedCents int64
+}
+
+// AssignableCategoryResponse is a category that can take part of the income
+// in zero-based budgeting, along with what it spent the month before.
+type AssignableCategoryResponse struct {
+	GroupID             string
+	GroupName           string
+	CategoryID          string
+	Name                string
+	BudgetCents         int64
+	LastMonthSpentCents int64
+}
+
+type BudgetAssignmentResponse struct {
+	Month         string
+	IncomeCents   int64
+	BudgetedCents int64
+	LeftCents     int64
+	Categories    []AssignableCategoryResponse
+}
+
+type FillBudgetRequest struct {
+	UserID     string
+	Currency   string
+	GroupID    string
+	CategoryID string
+	Month      string
+}
+
+type BudgetShare struct {
+	CategoryID string
+	Percent    float64
+}
+
+type DistributeBudgetRequest struct {
+	UserID   string
+	Currency string
+	Month    string
+	Shares   []BudgetShare
+}
+
+type CloseMonthRequest struct {
+	UserID       string
+	Currency     string
+	Month        string
+	AcceptUnpaid bool
+}
+
+type MonthStatusResponse struct {
+	Month       string
+	Closed      bool
+	ClosedAt    time.Time
+	UnpaidCount int
+	UnpaidCents int64
+}
+
+type PlanItemResponse struct {
+	CategoryID  string
+	Name        string
+	ParentName  string
+	IsRecurrent bool
+	BudgetCents int64
+	Conflict    bool
+}
+
+type PlanGroupResponse struct {
+	ID    string
+	Name  string
+	Items []PlanItemResponse
+}
+
+// PlanPreviewResponse lists the categories of FromMonth that can be carried
+// into ToMonth: one-off categories to copy and recurring ones whose budget can
+// be adjusted.
+type PlanPreviewResponse struct {
+	FromMonth string
+	ToMonth   string
+	Groups    []PlanGroupResponse
+}
+
+type PlanItemRequest struct {
+	CategoryID string
+	Budget     float64
+}
+
+type PlanMonthRequest struct {
+	UserID        string
+	Currency      string
+	FromMonth     string
+	AdjustPercent float64
+	Items         []PlanItemRequest
+}
+
+type PlanMonthResponse struct {
+	ToMonth string
+	Created int
+	Updated int
+	Skipped []string
+}
+
+type CreateAccountRequest struct {
+	UserID         string
+	Currency       string
+	Name           string
+	Type           string
+	OpeningBalance float64
+}
+
+type UpdateAccountRequest struct {
+	ID             string
+	UserID         string
+	Currency       string
+	Name           string
+	Type           string
+	OpeningBalance float64
+}
+
+type AccountResponse struct {
+	ID                  string
+	Name                string
+	Type                string
+	Currency            string
+	OpeningBalanceCents int64
+	BalanceCents        int64
+	UnreconciledCount   int
+}
+
+type TransferRequest struct {
+	UserID        string
+	Currency      string
+	FromAccountID string
+	ToAccountID   string
+	Amount        float64
+	Date          time.Time
+}
+
+// AccountEntryResponse is an entry of an account ledger. AmountCents is
+// signed: negative when money left the account.
+type AccountEntryResponse struct {
+	Kind         string
+	ID           string
+	Date         time.Time
+	Description  string
+	AmountCents  int64
+	BalanceCents int64
+	Reconciled   bool
+}
+
+type AccountLedgerResponse struct {
+	Account AccountResponse
+	Entries []AccountEntryResponse
+}
+
+type ReconcileRequest struct {
+	UserID           string
+	Currency         string
+	AccountID        string
+	StatementDate    time.Time
+	StatementBalance float64
+}
+
+// ReconciliationResponse compares the computed balance of an account at the
+// statement date with the statement. Unreconciled lists the entries up to
+// that date not yet matched against a statement.
+type ReconciliationResponse struct {
+	Account               AccountResponse
+	StatementDate         time.Time
+	StatementBalanceCents int64
+	ComputedBalanceCents  int64
+	DifferenceCents       int64
+	Unreconciled          []AccountEntryResponse
+	Reconciled            bool
+}
+
+// CreateLoanRequest takes the yearly rate as a percentage, such as 6.5.
+// CategoryID is optional and links the category the payments are recorded in.
+type CreateLoanRequest struct {
+	UserID      string
+	Currency    string
+	Name        string
+	Principal   float64
+	RatePercent float64
+	TermMonths  int
+	StartMonth  string
+	CategoryID  string
+}
+
+type UpdateLoanRequest struct {
+	ID          string
+	UserID      string
+	Currency    string
+	Name        string
+	Principal   float64
+	RatePercent float64
+	TermMonths  int
+	StartMonth  string
+	CategoryID  string
+}
+
+// LoanResponse reports a loan as of the end of Month. Payments recorded
+// above the scheduled payment are counted as extra principal, so the
+// balance and payoff month follow what was actually paid.
+type LoanResponse struct {
+	ID                 string
+	Name               string
+	Currency           string
+	PrincipalCents     int64
+	RatePercent        float64
+	TermMonths         int
+	StartMonth         string
+	CategoryID         string
+	Month              string
+	PaymentCents       int64
+	BalanceCents       int64
+	TotalInterestCents int64
+	PayoffMonth        string
+}
+
+// InstallmentResponse is a month of the amortization schedule. RecordedCents
+// is what was paid in the linked category that month.
+type InstallmentResponse struct {
+	Number         int
+	Month          string
+	PaymentCents   int64
+	PrincipalCents int64
+	InterestCents  int64
+	ExtraCents     int64
+	BalanceCents   int64
+	RecordedCents  int64
+}
+
+type LoanScheduleResponse struct {
+	Loan         LoanResponse
+	Installments []InstallmentResponse
+}
+
+// SimulateLoanRequest adds ExtraMonthly to every payment from Month on and
+// LumpSum once in LumpSumMonth.
+type SimulateLoanRequest struct {
+	UserID       string
+	Currency     string
+	LoanID       string
+	Month        string
+	ExtraMonthly float64
+	LumpSum      float64
+	LumpSumMonth string
+}
+
+// LoanSimulationResponse compares the loan with the simulated extra payments
+// against the loan as it stands.
+type LoanSimulationResponse struct {
+	Loan               LoanResponse
+	PayoffMonth        string
+	TotalInterestCents int64
+	InterestSavedCents int64
+	MonthsSaved        int
+	Installments       []InstallmentResponse
+}
+
+type CreateAssetRequest struct {
+	UserID string
+	Name   string
+	Kind   string
+}
+
+type UpdateAssetRequest struct {
+	ID     string
+	UserID string
+	Name   string
+	Kind   string
+}
+
+// RecordValuationRequest sets the value of an asset at the end of Month.
+type RecordValuationRequest struct {
+	UserID   string
+	Currency string
+	AssetID  string
+	Month    string
+	Value    float64
+}
+
+// NetWorthRequest asks for the net worth at the end of every month from
+// FromMonth to ToMonth.
+type NetWorthRequest struct {
+	UserID    string
+	Currency  string
+	FromMonth string
+	ToMonth   string
+}
+
+type NetWorthPointResponse struct {
+	Month            string
+	AssetsCents      int64
+	LiabilitiesCents int64
+	NetWorthCents    int64
+}
+
+type AssetValuationResponse struct {
+	Month      string
+	ValueCents int64
+}
+
+// NetWorthItemResponse is something counted in the net worth, valued at the
+// end of the last month of the range. Source tells whether it is an asset
+// entered by hand, an account or a loan; Kind is the asset kind or account
+// type. Valued is false for an asset with no value recorded by then.
+type NetWorthItemResponse struct {
+	ID          string
+	Name        string
+	Source      string
+	Kind        string
+	Liability   bool
+	ValueCents  int64
+	Valued      bool
+	ValuedMonth string
+	Valuations  []AssetValuationResponse
+}
+
+type NetWorthResponse struct {
+	FromMonth string
+	ToMonth   string
+	Months    []NetWorthPointResponse
+	Items     []NetWorthItemResponse
+}
+
+type SaveThresholdsRequest struct {
+	UserID     string
+	CategoryID string
+	Thresholds []int
+}
+
+// AlertCategoryResponse is a category with the thresholds, in percent of
+// its budget, that notify when its spending reaches them.
+type AlertCategoryResponse struct {
+	ID         string
+	ParentID   string
+	Name       string
+	Thresholds []int
+}
+
+type AlertGroupResponse struct {
+	ID         string
+	Name       string
+	Categories []AlertCategoryResponse
+}
+
+type NotificationResponse struct {
+	ID           string
+	CategoryID   string
+	CategoryName string
+	Month        string
+	Threshold    int
+	SpentCents   int64
+	BudgetCents  int64
+	CreatedAt    time.Time
+	IsRead       bool
 }
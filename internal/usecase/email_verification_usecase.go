@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain"
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/infrastructure/mail"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/security"
+)
+
+// verificationPurpose keeps verification tokens apart from other signed
+// tokens.
+const verificationPurpose = "email-verification"
+
+type EmailVerificationUseCaseImpl struct {
+	uow    domain.UnitOfWork
+	logger *slog.Logger
+	mailer mail.Mailer
+	signer security.Signer
+}
+
+func NewEmailVerificationUseCase(uow domain.UnitOfWork, logger *slog.Logger, mailer mail.Mailer, signer security.Signer) EmailVerificationUseCaseImpl {
+	return EmailVerificationUseCaseImpl{
+		uow:    uow,
+		logger: logger,
+		mailer: mailer,
+		signer: signer,
+	}
+}
+
+// SendVerification emails the user a link to verify their address, at most
+// once every identity.VerificationResendInterval. An address already verified
+// gets nothing.
+func (u EmailVerificationUseCaseImpl) SendVerification(ctx context.Context, req *SendVerificationRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	uID, err := identifier.ParseID(req.UserID)
+	if err != nil {
+		return err
+	}
+
+	user, err := u.uow.UserRepository().FindByID(ctx, uID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified {
+		return nil
+	}
+
+	now := time.Now()
+	if err := user.RecordVerificationSent(now); err != nil {
+		return err
+	}
+
+	link, err := url.Parse(req.VerifyURL)
+	if err != nil {
+		return fmt.Errorf("invalid verify url: %w", err)
+	}
+	token := u.signer.Sign(verificationPurpose, user.ID.String()+" "+user.Email.Value(), now.Add(identity.VerificationTTL))
+	link.RawQuery = url.Values{"token": {token}}.Encode()
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := txUOW.UserRepository().Save(ctx, user); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return u.mailer.Send(ctx, mail.Message{
+		To:      user.Email.Value(),
+		Subject: "Verify your GoCost email address",
+		Body: fmt.Sprintf(`Hello %s,
+
+Follow this link to confirm that %s is your email address:
+
+%s
+
+The link expires in %d hours. If you did not create a GoCost account, ignore this email.
+`, user.Username.Value(), user.Email.Value(), link.String(), int(identity.VerificationTTL.Hours())),
+	})
+}
+
+// VerifyEmail marks the address in the token as verified, provided it is
+// still the address of the user.
+func (u EmailVerificationUseCaseImpl) VerifyEmail(ctx context.Context, token string) (*UserResponse, error) {
+	value, err := u.signer.Verify(verificationPurpose, token, time.Now())
+	if err != nil {
+		return nil, identity.ErrInvalidVerificationToken
+	}
+
+	userID, email, ok := strings.Cut(value, " ")
+	if !ok {
+		return nil, identity.ErrInvalidVerificationToken
+	}
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return nil, identity.ErrInvalidVerificationToken
+	}
+
+	user, err := u.uow.UserRepository().FindByID(ctx, uID)
+	if err != nil {
+		if errors.Is(err, identity.ErrUserNotFound) {
+			return nil, identity.ErrInvalidVerificationToken
+		}
+		return nil, err
+	}
+	if user.Email.Value() != email {
+		return nil, identity.ErrInvalidVerificationToken
+	}
+	if user.EmailVerified {
+		return mapUserToResponse(user), nil
+	}
+
+	user.VerifyEmail()
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := txUOW.UserRepository().Save(ctx, user); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
+	return mapUserToResponse(user), nil
+}
+
+var _ EmailVerificationUseCase = (*EmailVerificationUseCaseImpl)(nil)
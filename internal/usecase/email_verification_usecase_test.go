@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/infrastructure/mail"
+	"github.com/madalinpopa/gocost-web/internal/platform/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testSigner = security.NewSigner([]byte("test-key"))
+
+func newTestEmailVerificationUseCase(userRepo *MockUserRepository, mailer *MockMailer) EmailVerificationUseCaseImpl {
+	txUOW := &MockUnitOfWork{UserRepo: userRepo}
+	txUOW.On("Commit").Return(nil)
+	txUOW.On("Rollback").Return(nil)
+
+	baseUOW := &MockUnitOfWork{UserRepo: userRepo}
+	baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+
+	return NewEmailVerificationUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)), mailer, testSigner)
+}
+
+func TestEmailVerificationUseCase_SendVerification(t *testing.T) {
+	t.Run("records the send and emails a signed link", func(t *testing.T) {
+		user := newTestUser(t, "alice@example.com", "alice", "$2a$12$abcdefghijklmnopqrstuuMtXbjLYe6Xq/Xq8ZuNnF9Jk9r4dvmWe")
+		userRepo := &MockUserRepository{}
+		userRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+		userRepo.On("Save", mock.Anything, mock.MatchedBy(func(u identity.User) bool {
+			return u.VerificationSentAt != nil
+		})).Return(nil)
+		mailer := &MockMailer{}
+		mailer.On("Send", mock.Anything, mock.Anything).Return(nil)
+		usecase := newTestEmailVerificationUseCase(userRepo, mailer)
+
+		err := usecase.SendVerification(context.Background(), &SendVerificationRequest{
+			UserID:    user.ID.String(),
+			VerifyURL: "https://gocost.example/verify-email",
+		})
+
+		require.NoError(t, err)
+		userRepo.AssertExpectations(t)
+		msg := mailer.Calls[0].Arguments.Get(1).(mail.Message)
+		assert.Equal(t, "alice@example.com", msg.To)
+		link := regexp.MustCompile(`https://gocost\.example/verify-email\?token=\S+`).FindString(msg.Body)
+		require.NotEmpty(t, link)
+		u, err := url.Parse(link)
+		require.NoError(t, err)
+		value, err := testSigner.Verify(verificationPurpose, u.Query().Get("token"), time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, user.ID.String()+" alice@example.com", value)
+	})
+
+	t.Run("sends nothing for a verified address", func(t *testing.T) {
+		user := newTestUser(t, "alice@example.com", "alice", "$2a$12$abcdefghijklmnopqrstuuMtXbjLYe6Xq/Xq8ZuNnF9Jk9r4dvmWe")
+		user.VerifyEmail()
+		userRepo := &MockUserRepository{}
+		userRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+		mailer := &MockMailer{}
+		usecase := newTestEmailVerificationUseCase(userRepo, mailer)
+
+		err := usecase.SendVerification(context.Background(), &SendVerificationRequest{
+			UserID:    user.ID.String(),
+			VerifyURL: "https://gocost.example/verify-email",
+		})
+
+		require.NoError(t, err)
+		mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+
+	t.Run("refuses to send again right away", func(t *testing.T) {
+		user := newTestUser(t, "alice@example.com", "alice", "$2a$12$abcdefghijklmnopqrstuuMtXbjLYe6Xq/Xq8ZuNnF9Jk9r4dvmWe")
+		require.NoError(t, user.RecordVerificationSent(time.Now()))
+		userRepo := &MockUserRepository{}
+		userRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+		mailer := &MockMailer{}
+		usecase := newTestEmailVerificationUseCase(userRepo, mailer)
+
+		err := usecase.SendVerification(context.Background(), &SendVerificationRequest{
+			UserID:    user.ID.String(),
+			VerifyURL: "https://gocost.example/verify-email",
+		})
+
+		assert.ErrorIs(t, err, identity.ErrVerificationRateLimited)
+		mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+}
+
+func TestEmailVerificationUseCase_VerifyEmail(t *testing.T) {
+	user := newTestUser(t, "alice@example.com", "alice", "$2a$12$abcdefghijklmnopqrstuuMtXbjLYe6Xq/Xq8ZuNnF9Jk9r4dvmWe")
+	sign := func(value string, expiresAt time.Time) string {
+		return testSigner.Sign(verificationPurpose, value, expiresAt)
+	}
+
+	t.Run("marks the address as verified", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		userRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+		userRepo.On("Save", mock.Anything, mock.MatchedBy(func(u identity.User) bool {
+			return u.EmailVerified
+		})).Return(nil)
+		usecase := newTestEmailVerificationUseCase(userRepo, &MockMailer{})
+
+		resp, err := usecase.VerifyEmail(context.Background(), sign(user.ID.String()+" alice@example.com", time.Now().Add(time.Hour)))
+
+		require.NoError(t, err)
+		assert.True(t, resp.EmailVerified)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects a token for an address since changed", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		userRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+		usecase := newTestEmailVerificationUseCase(userRepo, &MockMailer{})
+
+		_, err := usecase.VerifyEmail(context.Background(), sign(user.ID.String()+" old@example.com", time.Now().Add(time.Hour)))
+
+		assert.ErrorIs(t, err, identity.ErrInvalidVerificationToken)
+		userRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an expired token", func(t *testing.T) {
+		usecase := newTestEmailVerificationUseCase(&MockUserRepository{}, &MockMailer{})
+
+		_, err := usecase.VerifyEmail(context.Background(), sign(user.ID.String()+" alice@example.com", time.Now().Add(-time.Minute)))
+
+		assert.ErrorIs(t, err, identity.ErrInvalidVerificationToken)
+	})
+
+	t.Run("rejects a token signed with another key", func(t *testing.T) {
+		usecase := newTestEmailVerificationUseCase(&MockUserRepository{}, &MockMailer{})
+		token := security.NewSigner([]byte("other-key")).Sign(verificationPurpose, user.ID.String()+" alice@example.com", time.Now().Add(time.Hour))
+
+		_, err := usecase.VerifyEmail(context.Background(), token)
+
+		assert.ErrorIs(t, err, identity.ErrInvalidVerificationToken)
+	})
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"time"
 
 	"github.com/madalinpopa/gocost-web/internal/domain"
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
 	"github.com/madalinpopa/gocost-web/internal/domain/expense"
+	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
 	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
 	"github.com/madalinpopa/gocost-web/internal/platform/money"
 )
@@ -48,6 +51,10 @@ func (u ExpenseUseCaseImpl) Create(ctx context.Context, req *CreateExpenseReques
 		return nil, errors.New("unauthorized")
 	}
 
+	if err := ensureMonthOpen(ctx, u.uow, uID, closing.MonthOf(req.SpentAt)); err != nil {
+		return nil, err
+	}
+
 	amount, err := money.NewFromFloat(req.Amount, req.Currency)
 	if err != nil {
 		return nil, err
@@ -73,6 +80,15 @@ func (u ExpenseUseCaseImpl) Create(ctx context.Context, req *CreateExpenseReques
 		return nil, err
 	}
 
+	if exp.AccountID, err = resolveAccountID(ctx, u.uow, uID, req.AccountID); err != nil {
+		return nil, err
+	}
+
+	watch, err := watchBudgets(ctx, u.uow, uID, req.Currency, []tracking.Group{group}, []string{closing.MonthOf(exp.SpentAt)})
+	if err != nil {
+		return nil, err
+	}
+
 	txUOW, err := u.uow.Begin(ctx)
 	if err != nil {
 		return nil, err
@@ -83,6 +99,11 @@ func (u ExpenseUseCaseImpl) Create(ctx context.Context, req *CreateExpenseReques
 		return nil, err
 	}
 
+	if err := watch.notify(ctx, txUOW, time.Now()); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
 	if err := txUOW.Commit(); err != nil {
 		_ = txUOW.Rollback()
 		return nil, err
@@ -122,6 +143,17 @@ func (u ExpenseUseCaseImpl) Update(ctx context.Context, req *UpdateExpenseReques
 		return nil, errors.New("unauthorized")
 	}
 
+	// Moving an expense out of or into a closed month changes both months.
+	for _, month := range []string{closing.MonthOf(exp.SpentAt), closing.MonthOf(req.SpentAt)} {
+		if err := ensureMonthOpen(ctx, u.uow, uID, month); err != nil {
+			return nil, err
+		}
+	}
+
+	// Moving an expense can cross thresholds in its new category and month.
+	groups := []tracking.Group{group}
+	months := []string{closing.MonthOf(exp.SpentAt), closing.MonthOf(req.SpentAt)}
+
 	// If category changed, verify new category
 	if req.CategoryID != exp.CategoryID.String() {
 		newCatID, err := identifier.ParseID(req.CategoryID)
@@ -135,6 +167,9 @@ func (u ExpenseUseCaseImpl) Update(ctx context.Context, req *UpdateExpenseReques
 		if newGroup.UserID != uID {
 			return nil, errors.New("unauthorized")
 		}
+		if newGroup.ID != group.ID {
+			groups = append(groups, newGroup)
+		}
 		exp.CategoryID = newCatID
 	}
 
@@ -153,10 +188,21 @@ func (u ExpenseUseCaseImpl) Update(ctx context.Context, req *UpdateExpenseReques
 		return nil, err
 	}
 
+	accountID, err := resolveAccountID(ctx, u.uow, uID, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
 	exp.Amount = amount
 	exp.Description = description
 	exp.SpentAt = req.SpentAt
 	exp.Payment = payment
+	exp.AccountID = accountID
+
+	watch, err := watchBudgets(ctx, u.uow, uID, req.Currency, groups, months)
+	if err != nil {
+		return nil, err
+	}
 
 	txUOW, err := u.uow.Begin(ctx)
 	if err != nil {
@@ -168,6 +214,11 @@ func (u ExpenseUseCaseImpl) Update(ctx context.Context, req *UpdateExpenseReques
 		return nil, err
 	}
 
+	if err := watch.notify(ctx, txUOW, time.Now()); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
 	if err := txUOW.Commit(); err != nil {
 		_ = txUOW.Rollback()
 		return nil, err
@@ -202,6 +253,10 @@ func (u ExpenseUseCaseImpl) Delete(ctx context.Context, userID string, id string
 		return errors.New("unauthorized")
 	}
 
+	if err := ensureMonthOpen(ctx, u.uow, uID, closing.MonthOf(exp.SpentAt)); err != nil {
+		return err
+	}
+
 	txUOW, err := u.uow.Begin(ctx)
 	if err != nil {
 		return err
@@ -312,5 +367,6 @@ func (u ExpenseUseCaseImpl) mapToResponse(e *expense.Expense) *ExpenseResponse {
 		SpentAt:     e.SpentAt,
 		IsPaid:      e.Payment.IsPaid(),
 		PaidAt:      e.Payment.PaidAt(),
+		AccountID:   accountIDString(e.AccountID),
 	}
 }
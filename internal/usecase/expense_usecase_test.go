@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
 	"github.com/madalinpopa/gocost-web/internal/domain/expense"
 	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
 	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
@@ -135,6 +136,21 @@ func TestExpenseUseCase_Create(t *testing.T) {
 
 		assert.Equal(t, expectedAmount.Cents(), savedExpense.Amount.Cents())
 	})
+
+	t.Run("returns error when the month is closed", func(t *testing.T) {
+		groupRepo := &MockGroupRepository{}
+		groupRepo.On("FindGroupByCategoryID", mock.Anything, mock.Anything).Return(*group, nil)
+		closingRepo := &MockClosingRepository{}
+		closingRepo.On("IsClosed", mock.Anything, validUserID, closing.MonthOf(validReq.SpentAt)).Return(true, nil)
+		baseUOW := &MockUnitOfWork{TrackingRepo: groupRepo, ClosingRepo: closingRepo}
+
+		usecase := NewExpenseUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		resp, err := usecase.Create(context.Background(), validReq)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, closing.ErrMonthClosed)
+		baseUOW.AssertNotCalled(t, "Begin", mock.Anything)
+	})
 }
 
 func TestExpenseUseCase_Update(t *testing.T) {
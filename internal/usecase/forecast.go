@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain"
+	"github.com/madalinpopa/gocost-web/internal/domain/loan"
+	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+)
+
+// forecastHistoryMonths is how many months before the current one the usual
+// spending of a category is averaged over.
+const forecastHistoryMonths = 3
+
+// monthForecast projects what each category will have spent by the end of
+// the month in progress.
+type monthForecast struct {
+	today     string
+	elapsed   int64
+	days      int64
+	previous  []*DashboardResponse
+	scheduled map[string]int64
+}
+
+// forecastDashboard adds to the dashboard of the month in progress what each
+// category is projected to spend by its end, the balance that leaves and the
+// categories projected over budget.
+func forecastDashboard(ctx context.Context, uow domain.UnitOfWork, uID identifier.ID, today time.Time, dashboard *DashboardResponse) error {
+	month := tracking.NewMonthFromTime(today)
+
+	previous := make([]*DashboardResponse, 0, forecastHistoryMonths)
+	m := month
+	for range forecastHistoryMonths {
+		m = m.Previous()
+		d, err := buildDashboard(ctx, uow, uID, m.Value())
+		if err != nil {
+			return err
+		}
+		previous = append(previous, d)
+	}
+
+	scheduled, err := scheduledLoanPayments(ctx, uow, uID, month.Value())
+	if err != nil {
+		return err
+	}
+
+	daysInMonth := time.Date(today.Year(), today.Month()+1, 0, 0, 0, 0, 0, today.Location()).Day()
+	f := monthForecast{
+		today:     today.Format(time.DateOnly),
+		elapsed:   int64(today.Day()),
+		days:      int64(daysInMonth),
+		previous:  previous,
+		scheduled: scheduled,
+	}
+
+	forecast := &ForecastResponse{
+		DaysElapsed: today.Day(),
+		DaysInMonth: daysInMonth,
+		AtRisk:      []AtRiskCategoryResponse{},
+	}
+	projectedCents := dashboard.TotalExpensesCents
+	for i := range dashboard.Groups {
+		group := &dashboard.Groups[i]
+		for j := range group.Categories {
+			category := &group.Categories[j]
+			f.project(group.ID, nil, category)
+			projectedCents += category.ProjectedCents - category.SpentCents
+
+			forecast.AtRisk = appendAtRisk(forecast.AtRisk, *category)
+			for _, sub := range category.Subcategories {
+				forecast.AtRisk = appendAtRisk(forecast.AtRisk, sub)
+			}
+		}
+	}
+	forecast.ProjectedExpensesCents = projectedCents
+	forecast.ProjectedBalanceCents = dashboard.TotalIncomeCents - projectedCents - dashboard.SavingsCents
+
+	dashboard.Forecast = forecast
+	return nil
+}
+
+// project sets the projection of the category and its subcategories. What is
+// spent up to today stays; for the days left the category spends at least
+// what is already recorded for them and the loan installments still due, or
+// its estimate when that is more.
+func (f monthForecast) project(groupID string, parent *DashboardCategoryResponse, category *DashboardCategoryResponse) {
+	var toDateCents, upcomingCents int64
+	for _, exp := range category.Expenses {
+		if exp.SpentAt.Format(time.DateOnly) <= f.today {
+			toDateCents += exp.AmountCents
+		} else {
+			upcomingCents += exp.AmountCents
+		}
+	}
+
+	knownCents := upcomingCents + max(f.scheduled[category.ID]-toDateCents-upcomingCents, 0)
+	projectedCents := toDateCents + max(knownCents, f.estimate(toDateCents, f.history(groupID, parent, category)))
+
+	for i := range category.Subcategories {
+		sub := &category.Subcategories[i]
+		f.project(groupID, category, sub)
+		projectedCents += sub.ProjectedCents
+	}
+	category.ProjectedCents = projectedCents
+}
+
+// estimate is what a category is likely to spend in the days left, from its
+// pace so far and its average over the months before. Early in the month the
+// average counts most, towards the end the pace.
+func (f monthForecast) estimate(toDateCents int64, history []int64) int64 {
+	remaining := f.days - f.elapsed
+	if len(history) == 0 {
+		return toDateCents * remaining / f.elapsed
+	}
+
+	var sum int64
+	for _, cents := range history {
+		sum += cents
+	}
+	average := sum / int64(len(history))
+
+	return (toDateCents*remaining*f.days + average*remaining*remaining) / (f.days * f.days)
+}
+
+// history is what the category spent itself, without its subcategories, in
+// each of the previous months it was active in.
+func (f monthForecast) history(groupID string, parent *DashboardCategoryResponse, category *DashboardCategoryResponse) []int64 {
+	var history []int64
+	for _, d := range f.previous {
+		for _, g := range d.Groups {
+			if g.ID != groupID {
+				continue
+			}
+			categories := g.Categories
+			if parent != nil {
+				p, ok := findForkedCategory(categories, parent)
+				if !ok {
+					break
+				}
+				categories = p.Subcategories
+			}
+			if c, ok := findForkedCategory(categories, category); ok {
+				spentCents := c.SpentCents
+				for _, sub := range c.Subcategories {
+					spentCents -= sub.SpentCents
+				}
+				history = append(history, spentCents)
+			}
+		}
+	}
+	return history
+}
+
+// findForkedCategory looks the category up by ID, or by name when it has
+// since been forked.
+func findForkedCategory(categories []DashboardCategoryResponse, category *DashboardCategoryResponse) (DashboardCategoryResponse, bool) {
+	for _, c := range categories {
+		if c.ID == category.ID {
+			return c, true
+		}
+	}
+	for _, c := range categories {
+		if c.Name == category.Name {
+			return c, true
+		}
+	}
+	return DashboardCategoryResponse{}, false
+}
+
+// scheduledLoanPayments sums the loan installments due in the month by the
+// category their payments are recorded in.
+func scheduledLoanPayments(ctx context.Context, uow domain.UnitOfWork, uID identifier.ID, month string) (map[string]int64, error) {
+	loans, err := uow.LoanRepository().FindByUserID(ctx, uID)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled := make(map[string]int64)
+	for i := range loans {
+		l := &loans[i]
+		if l.CategoryID.IsZero() {
+			continue
+		}
+
+		payments, err := loanPayments(ctx, uow, l)
+		if err != nil {
+			return nil, err
+		}
+		if installment, ok := l.Schedule(loan.Extra{OneOff: l.Overpayments(payments)}).Find(month); ok {
+			scheduled[l.CategoryID.String()] += installment.Payment.Cents()
+		}
+	}
+	return scheduled, nil
+}
+
+func appendAtRisk(atRisk []AtRiskCategoryResponse, category DashboardCategoryResponse) []AtRiskCategoryResponse {
+	if category.BudgetCents <= 0 || category.ProjectedCents <= category.BudgetCents {
+		return atRisk
+	}
+	return append(atRisk, AtRiskCategoryResponse{
+		ID:             category.ID,
+		Name:           category.Name,
+		BudgetCents:    category.BudgetCents,
+		ProjectedCents: category.ProjectedCents,
+	})
+}
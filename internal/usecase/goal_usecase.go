@@ -0,0 +1,288 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/madalinpopa/gocost-web/internal/domain"
+	"github.com/madalinpopa/gocost-web/internal/domain/saving"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+)
+
+type GoalUseCaseImpl struct {
+	uow    domain.UnitOfWork
+	logger *slog.Logger
+}
+
+func NewGoalUseCase(uow domain.UnitOfWork, logger *slog.Logger) GoalUseCaseImpl {
+	return GoalUseCaseImpl{
+		uow:    uow,
+		logger: logger,
+	}
+}
+
+func (u GoalUseCaseImpl) Create(ctx context.Context, req *CreateGoalRequest) (*GoalResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	uID, err := identifier.ParseID(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := saving.NewNameVO(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := money.NewFromFloat(req.Target, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := identifier.NewID()
+	if err != nil {
+		return nil, err
+	}
+
+	goal, err := saving.NewGoal(id, uID, name, target, req.StartMonth, req.TargetDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.save(ctx, goal); err != nil {
+		return nil, err
+	}
+
+	resp := mapGoalToResponse(goal, goal.StartMonth)
+	return &resp, nil
+}
+
+func (u GoalUseCaseImpl) Update(ctx context.Context, req *UpdateGoalRequest) (*GoalResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	goal, err := u.find(ctx, req.UserID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := saving.NewNameVO(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := money.NewFromFloat(req.Target, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := goal.Update(name, target, req.TargetDate); err != nil {
+		return nil, err
+	}
+
+	if err := u.save(ctx, goal); err != nil {
+		return nil, err
+	}
+
+	resp := mapGoalToResponse(goal, goal.StartMonth)
+	return &resp, nil
+}
+
+func (u GoalUseCaseImpl) Delete(ctx context.Context, userID string, id string) error {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return err
+	}
+
+	goalID, err := identifier.ParseID(id)
+	if err != nil {
+		return err
+	}
+
+	return u.uow.SavingRepository().Delete(ctx, uID, goalID)
+}
+
+func (u GoalUseCaseImpl) Get(ctx context.Context, userID string, id string, month string) (*GoalResponse, error) {
+	goal, err := u.find(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := mapGoalToResponse(goal, month)
+	return &resp, nil
+}
+
+// List reports every goal as of the end of the month.
+func (u GoalUseCaseImpl) List(ctx context.Context, userID string, month string) ([]GoalResponse, error) {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	goals, err := u.uow.SavingRepository().FindByUserID(ctx, uID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]GoalResponse, 0, len(goals))
+	for i := range goals {
+		responses = append(responses, mapGoalToResponse(&goals[i], month))
+	}
+	return responses, nil
+}
+
+// Contribute records money put aside for the goal in a month. Contributions
+// count against the month like paid expenses, so a closed month refuses them.
+func (u GoalUseCaseImpl) Contribute(ctx context.Context, req *ContributeGoalRequest) (*GoalResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	goal, err := u.find(ctx, req.UserID, req.GoalID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ensureMonthOpen(ctx, u.uow, goal.UserID, req.Month); err != nil {
+		return nil, err
+	}
+
+	amount, err := money.NewFromFloat(req.Amount, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := identifier.NewID()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := goal.Contribute(id, req.Month, amount); err != nil {
+		return nil, err
+	}
+
+	if err := u.save(ctx, goal); err != nil {
+		return nil, err
+	}
+
+	resp := mapGoalToResponse(goal, req.Month)
+	return &resp, nil
+}
+
+func (u GoalUseCaseImpl) RemoveContribution(ctx context.Context, userID string, goalID string, contributionID string) error {
+	goal, err := u.find(ctx, userID, goalID)
+	if err != nil {
+		return err
+	}
+
+	cID, err := identifier.ParseID(contributionID)
+	if err != nil {
+		return err
+	}
+
+	contribution, err := goal.FindContribution(cID)
+	if err != nil {
+		return err
+	}
+
+	if err := ensureMonthOpen(ctx, u.uow, goal.UserID, contribution.Month); err != nil {
+		return err
+	}
+
+	if err := goal.RemoveContribution(cID); err != nil {
+		return err
+	}
+
+	return u.save(ctx, goal)
+}
+
+func (u GoalUseCaseImpl) find(ctx context.Context, userID string, id string) (*saving.Goal, error) {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	goalID, err := identifier.ParseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	goal, err := u.uow.SavingRepository().FindByID(ctx, uID, goalID)
+	if err != nil {
+		return nil, err
+	}
+	return &goal, nil
+}
+
+func (u GoalUseCaseImpl) save(ctx context.Context, goal *saving.Goal) error {
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := txUOW.SavingRepository().Save(ctx, *goal); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+// goalsForMonth reports the goals already started by the month, and what was
+// contributed to them in that month.
+func goalsForMonth(goals []saving.Goal, month string) ([]GoalResponse, int64) {
+	var responses []GoalResponse
+	var contributedCents int64
+	for i := range goals {
+		goal := &goals[i]
+		if goal.StartMonth > month {
+			continue
+		}
+		resp := mapGoalToResponse(goal, month)
+		contributedCents += resp.MonthContributionCents
+		responses = append(responses, resp)
+	}
+	return responses, contributedCents
+}
+
+func mapGoalToResponse(goal *saving.Goal, month string) GoalResponse {
+	progress := goal.ProgressAt(month)
+
+	contributions := make([]GoalContributionResponse, 0, len(goal.Contributions))
+	for _, c := range goal.Contributions {
+		contributions = append(contributions, GoalContributionResponse{
+			ID:          c.ID.String(),
+			Month:       c.Month,
+			AmountCents: c.Amount.Cents(),
+		})
+	}
+
+	return GoalResponse{
+		ID:                     goal.ID.String(),
+		Name:                   goal.Name.Value(),
+		Currency:               goal.Target.Currency(),
+		TargetCents:            goal.Target.Cents(),
+		StartMonth:             goal.StartMonth,
+		TargetDate:             goal.TargetDate,
+		Month:                  month,
+		SavedCents:             progress.Saved.Cents(),
+		RemainingCents:         progress.Remaining.Cents(),
+		RequiredMonthlyCents:   progress.RequiredMonthly.Cents(),
+		MonthContributionCents: goal.ContributedIn(month).Cents(),
+		Percent:                progress.Percent,
+		Status:                 string(progress.Status),
+		Contributions:          contributions,
+	}
+}
+
+var _ GoalUseCase = (*GoalUseCaseImpl)(nil)
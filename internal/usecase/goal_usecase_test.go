@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
+	"github.com/madalinpopa/gocost-web/internal/domain/saving"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestGoal is a 1200.00 goal from January to December 2024.
+func newTestGoal(t *testing.T, userID identifier.ID) *saving.Goal {
+	t.Helper()
+
+	id, _ := identifier.NewID()
+	name, err := saving.NewNameVO("Holiday")
+	require.NoError(t, err)
+	target, err := money.New(120000, "USD")
+	require.NoError(t, err)
+
+	goal, err := saving.NewGoal(id, userID, name, target, "2024-01", time.Date(2024, time.December, 15, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	return goal
+}
+
+func contributeToGoal(t *testing.T, goal *saving.Goal, month string, cents int64) *saving.Contribution {
+	t.Helper()
+
+	id, _ := identifier.NewID()
+	amount, err := money.New(cents, "USD")
+	require.NoError(t, err)
+	contribution, err := goal.Contribute(id, month, amount)
+	require.NoError(t, err)
+	return contribution
+}
+
+// newTestGoalUseCase returns the use case over the goals repository and the
+// transactional repository that records saved goals.
+func newTestGoalUseCase(repo *MockSavingRepository, closingRepo *MockClosingRepository) (GoalUseCaseImpl, *MockSavingRepository, *MockUnitOfWork) {
+	txRepo := &MockSavingRepository{}
+	txUOW := &MockUnitOfWork{SavingRepo: txRepo}
+	txUOW.On("Commit").Return(nil).Maybe()
+	txUOW.On("Rollback").Return(nil).Maybe()
+
+	baseUOW := &MockUnitOfWork{SavingRepo: repo, ClosingRepo: closingRepo}
+	baseUOW.On("Begin", mock.Anything).Return(txUOW, nil).Maybe()
+
+	return NewGoalUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil))), txRepo, txUOW
+}
+
+func TestGoalUseCase_Create(t *testing.T) {
+	t.Run("returns error for nil request", func(t *testing.T) {
+		usecase, _, _ := newTestGoalUseCase(&MockSavingRepository{}, nil)
+		resp, err := usecase.Create(context.Background(), nil)
+		assert.Nil(t, resp)
+		assert.EqualError(t, err, "request cannot be nil")
+	})
+
+	t.Run("saves the goal", func(t *testing.T) {
+		userID, _ := identifier.NewID()
+		usecase, txRepo, txUOW := newTestGoalUseCase(&MockSavingRepository{}, nil)
+		txRepo.On("Save", mock.Anything, mock.MatchedBy(func(g saving.Goal) bool {
+			return g.UserID == userID && g.Name.Value() == "Holiday" && g.Target.Cents() == 120000
+		})).Return(nil)
+
+		resp, err := usecase.Create(context.Background(), &CreateGoalRequest{
+			UserID:     userID.String(),
+			Currency:   "USD",
+			Name:       "Holiday",
+			Target:     1200,
+			StartMonth: "2024-01",
+			TargetDate: time.Date(2024, time.December, 15, 0, 0, 0, 0, time.UTC),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Holiday", resp.Name)
+		assert.Equal(t, int64(10000), resp.RequiredMonthlyCents)
+		txRepo.AssertExpectations(t)
+		txUOW.AssertCalled(t, "Commit")
+	})
+
+	t.Run("returns error when the target date is before the start", func(t *testing.T) {
+		userID, _ := identifier.NewID()
+		usecase, txRepo, _ := newTestGoalUseCase(&MockSavingRepository{}, nil)
+
+		resp, err := usecase.Create(context.Background(), &CreateGoalRequest{
+			UserID:     userID.String(),
+			Currency:   "USD",
+			Name:       "Holiday",
+			Target:     1200,
+			StartMonth: "2024-06",
+			TargetDate: time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC),
+		})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, saving.ErrTargetDateBeforeStart)
+		txRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGoalUseCase_Contribute(t *testing.T) {
+	userID, _ := identifier.NewID()
+
+	t.Run("records the contribution", func(t *testing.T) {
+		goal := newTestGoal(t, userID)
+		contributeToGoal(t, goal, "2024-01", 10000)
+
+		repo := &MockSavingRepository{}
+		repo.On("FindByID", mock.Anything, userID, goal.ID).Return(*goal, nil)
+		usecase, txRepo, _ := newTestGoalUseCase(repo, nil)
+		txRepo.On("Save", mock.Anything, mock.MatchedBy(func(g saving.Goal) bool {
+			return len(g.Contributions) == 2
+		})).Return(nil)
+
+		resp, err := usecase.Contribute(context.Background(), &ContributeGoalRequest{
+			UserID:   userID.String(),
+			Currency: "USD",
+			GoalID:   goal.ID.String(),
+			Month:    "2024-02",
+			Amount:   150,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(25000), resp.SavedCents)
+		assert.Equal(t, int64(15000), resp.MonthContributionCents)
+		assert.Equal(t, string(saving.StatusOnTrack), resp.Status)
+		txRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns error when the month is closed", func(t *testing.T) {
+		goal := newTestGoal(t, userID)
+		repo := &MockSavingRepository{}
+		repo.On("FindByID", mock.Anything, userID, goal.ID).Return(*goal, nil)
+		closingRepo := &MockClosingRepository{}
+		closingRepo.On("IsClosed", mock.Anything, userID, "2024-02").Return(true, nil)
+		usecase, txRepo, _ := newTestGoalUseCase(repo, closingRepo)
+
+		resp, err := usecase.Contribute(context.Background(), &ContributeGoalRequest{
+			UserID:   userID.String(),
+			Currency: "USD",
+			GoalID:   goal.ID.String(),
+			Month:    "2024-02",
+			Amount:   150,
+		})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, closing.ErrMonthClosed)
+		txRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGoalUseCase_RemoveContribution(t *testing.T) {
+	userID, _ := identifier.NewID()
+	goal := newTestGoal(t, userID)
+	contribution := contributeToGoal(t, goal, "2024-01", 10000)
+
+	repo := &MockSavingRepository{}
+	repo.On("FindByID", mock.Anything, userID, goal.ID).Return(*goal, nil)
+	usecase, txRepo, _ := newTestGoalUseCase(repo, nil)
+	txRepo.On("Save", mock.Anything, mock.MatchedBy(func(g saving.Goal) bool {
+		return len(g.Contributions) == 0
+	})).Return(nil)
+
+	err := usecase.RemoveContribution(context.Background(), userID.String(), goal.ID.String(), contribution.ID.String())
+
+	require.NoError(t, err)
+	txRepo.AssertExpectations(t)
+}
+
+func TestGoalUseCase_List(t *testing.T) {
+	userID, _ := identifier.NewID()
+	goal := newTestGoal(t, userID)
+	contributeToGoal(t, goal, "2024-01", 10000)
+	contributeToGoal(t, goal, "2024-03", 5000)
+
+	repo := &MockSavingRepository{}
+	repo.On("FindByUserID", mock.Anything, userID).Return([]saving.Goal{*goal}, nil)
+	usecase, _, _ := newTestGoalUseCase(repo, nil)
+
+	goals, err := usecase.List(context.Background(), userID.String(), "2024-03")
+
+	require.NoError(t, err)
+	require.Len(t, goals, 1)
+	assert.Equal(t, int64(15000), goals[0].SavedCents)
+	assert.Equal(t, int64(5000), goals[0].MonthContributionCents)
+	assert.Equal(t, string(saving.StatusBehind), goals[0].Status)
+}
@@ -134,25 +134,179 @@ func (u GroupUseCaseImpl) Update(ctx context.Context, req *UpdateGroupRequest) (
 	return u.mapToResponse(group), nil
 }
 
-func (u GroupUseCaseImpl) Delete(ctx context.Context, userID string, id string) error {
-	uID, err := identifier.ParseID(userID)
+func (u GroupUseCaseImpl) Delete(ctx context.Context, req *DeleteGroupRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	switch req.Mode {
+	case DeleteModeHard:
+		return u.hardDelete(ctx, req)
+	case DeleteModeReassign:
+		return u.reassignAndDelete(ctx, req)
+	case DeleteModeEnd:
+		return u.end(ctx, req)
+	default:
+		return ErrInvalidDeleteMode
+	}
+}
+
+func (u GroupUseCaseImpl) DeletionImpact(ctx context.Context, userID string, id string, month string) (*DeletionImpactResponse, error) {
+	group, err := u.findOwnedGroup(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := u.uow.ExpenseRepository().CountByCategoriesPerMonth(ctx, group.UserID, categoryIDs(group))
+	if err != nil {
+		return nil, err
+	}
+
+	return newDeletionImpact(counts, month), nil
+}
+
+func (u GroupUseCaseImpl) hardDelete(ctx context.Context, req *DeleteGroupRequest) error {
+	group, err := u.findOwnedGroup(ctx, req.UserID, req.ID)
 	if err != nil {
 		return err
 	}
 
-	groupID, err := identifier.ParseID(id)
+	if err := ensureExpenseMonthsOpen(ctx, u.uow, group.UserID, categoryIDs(group), ""); err != nil {
+		return err
+	}
+
+	txUOW, err := u.uow.Begin(ctx)
 	if err != nil {
 		return err
 	}
 
-	repo := u.uow.TrackingRepository()
-	group, err := repo.FindByID(ctx, groupID)
+	if err := txUOW.TrackingRepository().Delete(ctx, group.ID); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+// reassignAndDelete moves every expense of the group into a category of
+// another group, widening that category's period so they stay visible.
+func (u GroupUseCaseImpl) reassignAndDelete(ctx context.Context, req *DeleteGroupRequest) error {
+	group, err := u.findOwnedGroup(ctx, req.UserID, req.ID)
 	if err != nil {
 		return err
 	}
 
-	if group.UserID != uID {
-		return errors.New("unauthorized")
+	targetID, err := identifier.ParseID(req.TargetCategoryID)
+	if err != nil {
+		return err
+	}
+
+	targetGroup, err := u.uow.TrackingRepository().FindGroupByCategoryID(ctx, targetID)
+	if err != nil {
+		if errors.Is(err, tracking.ErrGroupNotFound) {
+			return tracking.ErrCategoryNotFound
+		}
+		return err
+	}
+	if targetGroup.UserID != group.UserID {
+		return tracking.ErrCategoryNotFound
+	}
+	if targetGroup.ID == group.ID {
+		return tracking.ErrTargetInDeletedGroup
+	}
+
+	if err := ensureExpenseMonthsOpen(ctx, u.uow, group.UserID, categoryIDs(group), ""); err != nil {
+		return err
+	}
+
+	target, err := targetGroup.FindCategory(targetID)
+	if err != nil {
+		return err
+	}
+
+	// A one-off category cannot be widened to the months of the group, so it
+	// is refused before anything changes.
+	if !target.IsRecurrent {
+		for _, c := range group.Categories {
+			if c.IsRecurrent || !c.StartMonth.Equals(target.StartMonth) {
+				return tracking.ErrOneOffReassignTarget
+			}
+		}
+	}
+
+	for _, c := range group.Categories {
+		startMonth, endMonth, err := target.MergedPeriod(c)
+		if err != nil {
+			return err
+		}
+
+		if _, err := targetGroup.UpdateCategory(target.ID, target.Name, target.Description, target.IsRecurrent, startMonth, endMonth, target.Budget); err != nil {
+			return err
+		}
+	}
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := txUOW.TrackingRepository().Save(ctx, targetGroup); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	for _, c := range group.Categories {
+		if err := txUOW.ExpenseRepository().ReassignCategory(ctx, group.UserID, c.ID, targetID); err != nil {
+			_ = txUOW.Rollback()
+			return err
+		}
+	}
+
+	if err := txUOW.TrackingRepository().Delete(ctx, group.ID); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+// end keeps the group but closes each of its categories at the month before
+// req.Month, removing whatever was recorded from that month onwards.
+func (u GroupUseCaseImpl) end(ctx context.Context, req *DeleteGroupRequest) error {
+	group, err := u.findOwnedGroup(ctx, req.UserID, req.ID)
+	if err != nil {
+		return err
+	}
+
+	month, err := tracking.ParseMonth(req.Month)
+	if err != nil {
+		return err
+	}
+
+	if err := ensureMonthOpen(ctx, u.uow, group.UserID, month.Value()); err != nil {
+		return err
+	}
+	if err := ensureExpenseMonthsOpen(ctx, u.uow, group.UserID, categoryIDs(group), month.Value()); err != nil {
+		return err
+	}
+
+	var removed []identifier.ID
+	for _, c := range group.TopLevelCategories() {
+		gone, err := group.EndCategory(c.ID, month)
+		if err != nil {
+			return err
+		}
+		removed = append(removed, gone...)
 	}
 
 	txUOW, err := u.uow.Begin(ctx)
@@ -160,11 +314,25 @@ func (u GroupUseCaseImpl) Delete(ctx context.Context, userID string, id string)
 		return err
 	}
 
-	if err := txUOW.TrackingRepository().Delete(ctx, groupID); err != nil {
+	if err := txUOW.TrackingRepository().Save(ctx, group); err != nil {
 		_ = txUOW.Rollback()
 		return err
 	}
 
+	for _, id := range removed {
+		if err := txUOW.TrackingRepository().DeleteCategory(ctx, id); err != nil {
+			_ = txUOW.Rollback()
+			return err
+		}
+	}
+
+	for _, c := range group.Categories {
+		if err := txUOW.ExpenseRepository().DeleteByCategoryFromMonth(ctx, group.UserID, c.ID, month.Value()); err != nil {
+			_ = txUOW.Rollback()
+			return err
+		}
+	}
+
 	if err := txUOW.Commit(); err != nil {
 		_ = txUOW.Rollback()
 		return err
@@ -217,6 +385,150 @@ func (u GroupUseCaseImpl) List(ctx context.Context, userID string) ([]*GroupResp
 	return responses, nil
 }
 
+func (u GroupUseCaseImpl) Reorder(ctx context.Context, userID string, ids []string) error {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return err
+	}
+
+	repo := u.uow.TrackingRepository()
+	groups, err := repo.FindByUserID(ctx, uID)
+	if err != nil {
+		return err
+	}
+
+	positions := make(map[tracking.ID]int, len(ids))
+	for i, id := range ids {
+		gID, err := identifier.ParseID(id)
+		if err != nil {
+			return err
+		}
+		if _, exists := positions[gID]; exists {
+			return tracking.ErrInvalidGroupOrder
+		}
+		positions[gID] = i
+	}
+
+	matched := 0
+	for _, group := range groups {
+		if _, ok := positions[group.ID]; ok {
+			matched++
+		}
+	}
+	if matched != len(ids) {
+		return tracking.ErrInvalidGroupOrder
+	}
+
+	// Archived groups are missing from most months, so they may be left out
+	// and keep their relative order after the listed groups.
+	next := len(ids)
+	for i := range groups {
+		position, ok := positions[groups[i].ID]
+		if !ok {
+			if !groups[i].Archived {
+				return tracking.ErrInvalidGroupOrder
+			}
+			position = next
+			next++
+		}
+		order, err := tracking.NewOrderVO(position)
+		if err != nil {
+			return err
+		}
+		groups[i].Order = order
+	}
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range groups {
+		if err := txUOW.TrackingRepository().Save(ctx, group); err != nil {
+			_ = txUOW.Rollback()
+			return err
+		}
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func (u GroupUseCaseImpl) Archive(ctx context.Context, userID string, id string) error {
+	return u.setArchived(ctx, userID, id, true)
+}
+
+func (u GroupUseCaseImpl) Restore(ctx context.Context, userID string, id string) error {
+	return u.setArchived(ctx, userID, id, false)
+}
+
+func (u GroupUseCaseImpl) setArchived(ctx context.Context, userID string, id string, archived bool) error {
+	group, err := u.findOwnedGroup(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if archived {
+		group.Archive()
+	} else {
+		group.Restore()
+	}
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := txUOW.TrackingRepository().Save(ctx, group); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func (u GroupUseCaseImpl) findOwnedGroup(ctx context.Context, userID string, id string) (tracking.Group, error) {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return tracking.Group{}, err
+	}
+
+	groupID, err := identifier.ParseID(id)
+	if err != nil {
+		return tracking.Group{}, err
+	}
+
+	group, err := u.uow.TrackingRepository().FindByID(ctx, groupID)
+	if err != nil {
+		return tracking.Group{}, err
+	}
+
+	if group.UserID != uID {
+		return tracking.Group{}, errors.New("unauthorized")
+	}
+
+	return group, nil
+}
+
+// categoryIDs returns the IDs of every category of group, subcategories
+// included.
+func categoryIDs(group tracking.Group) []identifier.ID {
+	ids := make([]identifier.ID, len(group.Categories))
+	for i, c := range group.Categories {
+		ids[i] = c.ID
+	}
+	return ids
+}
+
 func (u GroupUseCaseImpl) mapToResponse(g tracking.Group) *GroupResponse {
 	categories := make([]CategoryResponse, len(g.Categories))
 	for i, c := range g.Categories {
@@ -228,6 +540,8 @@ func (u GroupUseCaseImpl) mapToResponse(g tracking.Group) *GroupResponse {
 			StartMonth:  c.StartMonth.Value(),
 			EndMonth:    c.EndMonth.Value(),
 			Budget:      c.Budget.Amount(),
+			Archived:    c.Archived,
+			ParentID:    parentIDString(c.ParentID),
 		}
 	}
 
@@ -236,6 +550,7 @@ func (u GroupUseCaseImpl) mapToResponse(g tracking.Group) *GroupResponse {
 		Name:        g.Name.Value(),
 		Description: g.Description.Value(),
 		Order:       g.Order.Value(),
+		Archived:    g.Archived,
 		Categories:  categories,
 	}
 }
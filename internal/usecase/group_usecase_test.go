@@ -7,6 +7,8 @@ import (
 	"log/slog"
 	"testing"
 
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
+	"github.com/madalinpopa/gocost-web/internal/domain/expense"
 	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
 	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
 	"github.com/madalinpopa/gocost-web/internal/platform/money"
@@ -200,7 +202,7 @@ func TestGroupUseCase_Delete(t *testing.T) {
 
 	t.Run("returns error for invalid group ID", func(t *testing.T) {
 		usecase := newTestGroupUseCase(nil)
-		err := usecase.Delete(context.Background(), validUserID.String(), "invalid")
+		err := usecase.Delete(context.Background(), &DeleteGroupRequest{ID: "invalid", UserID: validUserID.String(), Mode: DeleteModeHard})
 		assert.ErrorIs(t, err, identifier.ErrInvalidID)
 	})
 
@@ -210,7 +212,7 @@ func TestGroupUseCase_Delete(t *testing.T) {
 		repo.On("FindByID", mock.Anything, mock.Anything).Return(tracking.Group{}, expectedErr)
 
 		usecase := newTestGroupUseCase(repo)
-		err := usecase.Delete(context.Background(), validUserID.String(), existingGroup.ID.String())
+		err := usecase.Delete(context.Background(), &DeleteGroupRequest{ID: existingGroup.ID.String(), UserID: validUserID.String(), Mode: DeleteModeHard})
 		assert.ErrorIs(t, err, expectedErr)
 	})
 
@@ -221,7 +223,7 @@ func TestGroupUseCase_Delete(t *testing.T) {
 		repo.On("FindByID", mock.Anything, mock.Anything).Return(*otherUserGroup, nil)
 
 		usecase := newTestGroupUseCase(repo)
-		err := usecase.Delete(context.Background(), validUserID.String(), otherUserGroup.ID.String())
+		err := usecase.Delete(context.Background(), &DeleteGroupRequest{ID: otherUserGroup.ID.String(), UserID: validUserID.String(), Mode: DeleteModeHard})
 		assert.EqualError(t, err, "unauthorized")
 	})
 
@@ -235,7 +237,7 @@ func TestGroupUseCase_Delete(t *testing.T) {
 		})
 
 		txUOW := &MockUnitOfWork{TrackingRepo: txRepo}
-		baseUOW := &MockUnitOfWork{TrackingRepo: repo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo, ExpenseRepo: newExpenseCountRepository()}
 		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
 		txUOW.On("Commit").Return(nil)
 
@@ -244,12 +246,461 @@ func TestGroupUseCase_Delete(t *testing.T) {
 			slog.New(slog.NewTextHandler(io.Discard, nil)),
 		)
 
-		err := usecase.Delete(context.Background(), validUserID.String(), existingGroup.ID.String())
+		err := usecase.Delete(context.Background(), &DeleteGroupRequest{ID: existingGroup.ID.String(), UserID: validUserID.String(), Mode: DeleteModeHard})
 		require.NoError(t, err)
 		assert.Equal(t, existingGroup.ID, deletedID)
 	})
 }
 
+func TestGroupUseCase_DeleteModes(t *testing.T) {
+	validUserID, _ := identifier.NewID()
+
+	addCategory := func(t *testing.T, group *tracking.Group, n string, isRecurrent bool, start string) identifier.ID {
+		t.Helper()
+		id, _ := identifier.NewID()
+		name, _ := tracking.NewNameVO(n)
+		desc, _ := tracking.NewDescriptionVO("Desc")
+		startMonth, _ := tracking.ParseMonth(start)
+		_, err := group.CreateCategory(id, name, desc, isRecurrent, startMonth, tracking.Month{}, money.Money{})
+		require.NoError(t, err)
+		return id
+	}
+
+	t.Run("returns error for nil request", func(t *testing.T) {
+		usecase := newTestGroupUseCase(nil)
+		err := usecase.Delete(context.Background(), nil)
+		assert.EqualError(t, err, "request cannot be nil")
+	})
+
+	t.Run("returns error for unknown mode", func(t *testing.T) {
+		usecase := newTestGroupUseCase(nil)
+		err := usecase.Delete(context.Background(), &DeleteGroupRequest{Mode: "wipe"})
+		assert.ErrorIs(t, err, ErrInvalidDeleteMode)
+	})
+
+	t.Run("reassign rejects target inside the deleted group", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		catID := addCategory(t, group, "Rent", true, "2023-01")
+		repo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, group.ID).Return(*group, nil)
+		repo.On("FindGroupByCategoryID", mock.Anything, catID).Return(*group, nil)
+
+		usecase := newTestGroupUseCase(repo)
+
+		err := usecase.Delete(context.Background(), &DeleteGroupRequest{
+			ID:               group.ID.String(),
+			UserID:           validUserID.String(),
+			Mode:             DeleteModeReassign,
+			TargetCategoryID: catID.String(),
+		})
+		assert.ErrorIs(t, err, tracking.ErrTargetInDeletedGroup)
+	})
+
+	t.Run("reassign hides another user's category", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		otherUserID, _ := identifier.NewID()
+		foreign := newTestGroup(t, otherUserID)
+		foreignID := addCategory(t, foreign, "Rent", true, "2023-01")
+		repo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, group.ID).Return(*group, nil)
+		repo.On("FindGroupByCategoryID", mock.Anything, foreignID).Return(*foreign, nil)
+
+		usecase := newTestGroupUseCase(repo)
+
+		err := usecase.Delete(context.Background(), &DeleteGroupRequest{
+			ID:               group.ID.String(),
+			UserID:           validUserID.String(),
+			Mode:             DeleteModeReassign,
+			TargetCategoryID: foreignID.String(),
+		})
+		assert.ErrorIs(t, err, tracking.ErrCategoryNotFound)
+	})
+
+	t.Run("reassign rejects a one-off target for recurring categories", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		addCategory(t, group, "Rent", true, "2023-01")
+		addCategory(t, group, "Gift", false, "2023-03")
+		targetGroup := newTestGroup(t, validUserID)
+		targetID := addCategory(t, targetGroup, "Party", false, "2023-03")
+		repo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, group.ID).Return(*group, nil)
+		repo.On("FindGroupByCategoryID", mock.Anything, targetID).Return(*targetGroup, nil)
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo, ExpenseRepo: newExpenseCountRepository()}
+
+		usecase := NewGroupUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		err := usecase.Delete(context.Background(), &DeleteGroupRequest{
+			ID:               group.ID.String(),
+			UserID:           validUserID.String(),
+			Mode:             DeleteModeReassign,
+			TargetCategoryID: targetID.String(),
+		})
+		assert.ErrorIs(t, err, tracking.ErrOneOffReassignTarget)
+		baseUOW.AssertNotCalled(t, "Begin", mock.Anything)
+	})
+
+	t.Run("reassign takes one-off categories of the month of a one-off target", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		giftID := addCategory(t, group, "Gift", false, "2023-03")
+		targetGroup := newTestGroup(t, validUserID)
+		targetID := addCategory(t, targetGroup, "Party", false, "2023-03")
+		repo := &MockGroupRepository{}
+		txRepo := &MockGroupRepository{}
+		txExpenseRepo := &MockExpenseRepository{}
+		repo.On("FindByID", mock.Anything, group.ID).Return(*group, nil)
+		repo.On("FindGroupByCategoryID", mock.Anything, targetID).Return(*targetGroup, nil)
+		txRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		txRepo.On("Delete", mock.Anything, group.ID).Return(nil)
+		txExpenseRepo.On("ReassignCategory", mock.Anything, validUserID, giftID, targetID).Return(nil)
+		txUOW := &MockUnitOfWork{TrackingRepo: txRepo, ExpenseRepo: txExpenseRepo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo, ExpenseRepo: newExpenseCountRepository()}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+		txUOW.On("Commit").Return(nil)
+
+		usecase := NewGroupUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		err := usecase.Delete(context.Background(), &DeleteGroupRequest{
+			ID:               group.ID.String(),
+			UserID:           validUserID.String(),
+			Mode:             DeleteModeReassign,
+			TargetCategoryID: targetID.String(),
+		})
+		require.NoError(t, err)
+		txRepo.AssertExpectations(t)
+		txExpenseRepo.AssertExpectations(t)
+	})
+
+	t.Run("reassign moves expenses then deletes group", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		rentID := addCategory(t, group, "Rent", true, "2022-06")
+		powerID := addCategory(t, group, "Power", true, "2023-01")
+		targetGroup := newTestGroup(t, validUserID)
+		targetID := addCategory(t, targetGroup, "Housing", true, "2023-01")
+
+		var savedGroup tracking.Group
+		repo := &MockGroupRepository{}
+		txRepo := &MockGroupRepository{}
+		txExpenseRepo := &MockExpenseRepository{}
+		repo.On("FindByID", mock.Anything, group.ID).Return(*group, nil)
+		repo.On("FindGroupByCategoryID", mock.Anything, targetID).Return(*targetGroup, nil)
+		txRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			savedGroup = args.Get(1).(tracking.Group)
+		})
+		txExpenseRepo.On("ReassignCategory", mock.Anything, validUserID, rentID, targetID).Return(nil)
+		txExpenseRepo.On("ReassignCategory", mock.Anything, validUserID, powerID, targetID).Return(nil)
+		txRepo.On("Delete", mock.Anything, group.ID).Return(nil)
+		txUOW := &MockUnitOfWork{TrackingRepo: txRepo, ExpenseRepo: txExpenseRepo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo, ExpenseRepo: newExpenseCountRepository()}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+		txUOW.On("Commit").Return(nil)
+
+		usecase := NewGroupUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		err := usecase.Delete(context.Background(), &DeleteGroupRequest{
+			ID:               group.ID.String(),
+			UserID:           validUserID.String(),
+			Mode:             DeleteModeReassign,
+			TargetCategoryID: targetID.String(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, targetGroup.ID, savedGroup.ID)
+		assert.Equal(t, "2022-06", savedGroup.Categories[0].StartMonth.Value())
+		txRepo.AssertExpectations(t)
+		txExpenseRepo.AssertExpectations(t)
+	})
+
+	t.Run("rolls back when reassignment fails", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		rentID := addCategory(t, group, "Rent", true, "2023-01")
+		targetGroup := newTestGroup(t, validUserID)
+		targetID := addCategory(t, targetGroup, "Housing", true, "2023-01")
+		expectedErr := errors.New("db error")
+
+		repo := &MockGroupRepository{}
+		txRepo := &MockGroupRepository{}
+		txExpenseRepo := &MockExpenseRepository{}
+		repo.On("FindByID", mock.Anything, group.ID).Return(*group, nil)
+		repo.On("FindGroupByCategoryID", mock.Anything, targetID).Return(*targetGroup, nil)
+		txRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		txExpenseRepo.On("ReassignCategory", mock.Anything, validUserID, rentID, targetID).Return(expectedErr)
+		txUOW := &MockUnitOfWork{TrackingRepo: txRepo, ExpenseRepo: txExpenseRepo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo, ExpenseRepo: newExpenseCountRepository()}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+		txUOW.On("Rollback").Return(nil)
+
+		usecase := NewGroupUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		err := usecase.Delete(context.Background(), &DeleteGroupRequest{
+			ID:               group.ID.String(),
+			UserID:           validUserID.String(),
+			Mode:             DeleteModeReassign,
+			TargetCategoryID: targetID.String(),
+		})
+		assert.ErrorIs(t, err, expectedErr)
+		txRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+		txUOW.AssertExpectations(t)
+	})
+
+	t.Run("end keeps group and closes its categories", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		rentID := addCategory(t, group, "Rent", true, "2023-01")
+		newID := addCategory(t, group, "Parking", true, "2023-06")
+
+		var savedGroup tracking.Group
+		repo := &MockGroupRepository{}
+		txRepo := &MockGroupRepository{}
+		txExpenseRepo := &MockExpenseRepository{}
+		repo.On("FindByID", mock.Anything, group.ID).Return(*group, nil)
+		txRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			savedGroup = args.Get(1).(tracking.Group)
+		})
+		txRepo.On("DeleteCategory", mock.Anything, newID).Return(nil)
+		txExpenseRepo.On("DeleteByCategoryFromMonth", mock.Anything, validUserID, rentID, "2023-06").Return(nil)
+		txUOW := &MockUnitOfWork{TrackingRepo: txRepo, ExpenseRepo: txExpenseRepo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo, ExpenseRepo: newExpenseCountRepository()}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+		txUOW.On("Commit").Return(nil)
+
+		usecase := NewGroupUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		err := usecase.Delete(context.Background(), &DeleteGroupRequest{
+			ID:     group.ID.String(),
+			UserID: validUserID.String(),
+			Mode:   DeleteModeEnd,
+			Month:  "2023-06",
+		})
+		require.NoError(t, err)
+		require.Len(t, savedGroup.Categories, 1)
+		assert.Equal(t, "2023-05", savedGroup.Categories[0].EndMonth.Value())
+		txRepo.AssertExpectations(t)
+		txRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+		txExpenseRepo.AssertExpectations(t)
+	})
+}
+
+func TestGroupUseCase_DeletionImpact(t *testing.T) {
+	validUserID, _ := identifier.NewID()
+
+	t.Run("returns error when unauthorized", func(t *testing.T) {
+		otherUserID, _ := identifier.NewID()
+		otherUserGroup := newTestGroup(t, otherUserID)
+		repo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, mock.Anything).Return(*otherUserGroup, nil)
+
+		usecase := newTestGroupUseCase(repo)
+
+		resp, err := usecase.DeletionImpact(context.Background(), validUserID.String(), otherUserGroup.ID.String(), "2023-01")
+		assert.Nil(t, resp)
+		assert.EqualError(t, err, "unauthorized")
+	})
+
+	t.Run("counts expenses of every category", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		var ids []identifier.ID
+		for _, n := range []string{"Rent", "Power"} {
+			id, _ := identifier.NewID()
+			name, _ := tracking.NewNameVO(n)
+			desc, _ := tracking.NewDescriptionVO("Desc")
+			startMonth, _ := tracking.ParseMonth("2023-01")
+			_, err := group.CreateCategory(id, name, desc, true, startMonth, tracking.Month{}, money.Money{})
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+
+		repo := &MockGroupRepository{}
+		expenseRepo := &MockExpenseRepository{}
+		repo.On("FindByID", mock.Anything, group.ID).Return(*group, nil)
+		expenseRepo.On("CountByCategoriesPerMonth", mock.Anything, validUserID, ids).Return([]expense.MonthlyCount{
+			{Month: "2023-02", Count: 3},
+		}, nil)
+
+		usecase := NewGroupUseCase(
+			&MockUnitOfWork{TrackingRepo: repo, ExpenseRepo: expenseRepo},
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+		)
+
+		resp, err := usecase.DeletionImpact(context.Background(), validUserID.String(), group.ID.String(), "2023-03")
+		require.NoError(t, err)
+		assert.Equal(t, 3, resp.ExpenseCount)
+		assert.Equal(t, 1, resp.MonthCount)
+		assert.Equal(t, 0, resp.LaterExpenseCount)
+		expenseRepo.AssertExpectations(t)
+	})
+}
+
+func TestGroupUseCase_Reorder(t *testing.T) {
+	validUserID, _ := identifier.NewID()
+
+	t.Run("returns error for invalid user ID", func(t *testing.T) {
+		usecase := newTestGroupUseCase(nil)
+		err := usecase.Reorder(context.Background(), "invalid", nil)
+		assert.ErrorIs(t, err, identifier.ErrInvalidID)
+	})
+
+	t.Run("returns error when ids do not match groups", func(t *testing.T) {
+		grp1 := newTestGroup(t, validUserID)
+		grp2 := newTestGroup(t, validUserID)
+		otherID, _ := identifier.NewID()
+		repo := &MockGroupRepository{}
+		repo.On("FindByUserID", mock.Anything, validUserID).Return([]tracking.Group{*grp1, *grp2}, nil)
+
+		usecase := newTestGroupUseCase(repo)
+
+		err := usecase.Reorder(context.Background(), validUserID.String(), []string{grp1.ID.String()})
+		assert.ErrorIs(t, err, tracking.ErrInvalidGroupOrder)
+
+		err = usecase.Reorder(context.Background(), validUserID.String(), []string{grp1.ID.String(), grp1.ID.String()})
+		assert.ErrorIs(t, err, tracking.ErrInvalidGroupOrder)
+
+		err = usecase.Reorder(context.Background(), validUserID.String(), []string{grp1.ID.String(), otherID.String()})
+		assert.ErrorIs(t, err, tracking.ErrInvalidGroupOrder)
+	})
+
+	t.Run("saves every group with its new order", func(t *testing.T) {
+		grp1 := newTestGroup(t, validUserID)
+		grp2 := newTestGroup(t, validUserID)
+		saved := make(map[identifier.ID]int)
+		repo := &MockGroupRepository{}
+		txRepo := &MockGroupRepository{}
+		repo.On("FindByUserID", mock.Anything, validUserID).Return([]tracking.Group{*grp1, *grp2}, nil)
+		txRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			group := args.Get(1).(tracking.Group)
+			saved[group.ID] = group.Order.Value()
+		})
+
+		txUOW := &MockUnitOfWork{TrackingRepo: txRepo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+		txUOW.On("Commit").Return(nil)
+
+		usecase := NewGroupUseCase(
+			baseUOW,
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+		)
+
+		err := usecase.Reorder(context.Background(), validUserID.String(), []string{grp2.ID.String(), grp1.ID.String()})
+		require.NoError(t, err)
+		assert.Equal(t, map[identifier.ID]int{grp2.ID: 0, grp1.ID: 1}, saved)
+		txUOW.AssertExpectations(t)
+	})
+
+	t.Run("keeps unlisted archived groups last", func(t *testing.T) {
+		grp1 := newTestGroup(t, validUserID)
+		archived := newTestGroup(t, validUserID)
+		archived.Archive()
+		grp2 := newTestGroup(t, validUserID)
+		saved := make(map[identifier.ID]int)
+		repo := &MockGroupRepository{}
+		txRepo := &MockGroupRepository{}
+		repo.On("FindByUserID", mock.Anything, validUserID).Return([]tracking.Group{*grp1, *archived, *grp2}, nil)
+		txRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			group := args.Get(1).(tracking.Group)
+			saved[group.ID] = group.Order.Value()
+		})
+
+		txUOW := &MockUnitOfWork{TrackingRepo: txRepo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+		txUOW.On("Commit").Return(nil)
+
+		usecase := NewGroupUseCase(
+			baseUOW,
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+		)
+
+		err := usecase.Reorder(context.Background(), validUserID.String(), []string{grp2.ID.String(), grp1.ID.String()})
+		require.NoError(t, err)
+		assert.Equal(t, map[identifier.ID]int{grp2.ID: 0, grp1.ID: 1, archived.ID: 2}, saved)
+	})
+
+	t.Run("rolls back when save fails", func(t *testing.T) {
+		grp1 := newTestGroup(t, validUserID)
+		expectedErr := errors.New("db error")
+		repo := &MockGroupRepository{}
+		txRepo := &MockGroupRepository{}
+		repo.On("FindByUserID", mock.Anything, validUserID).Return([]tracking.Group{*grp1}, nil)
+		txRepo.On("Save", mock.Anything, mock.Anything).Return(expectedErr)
+
+		txUOW := &MockUnitOfWork{TrackingRepo: txRepo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+		txUOW.On("Rollback").Return(nil)
+
+		usecase := NewGroupUseCase(
+			baseUOW,
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+		)
+
+		err := usecase.Reorder(context.Background(), validUserID.String(), []string{grp1.ID.String()})
+		assert.ErrorIs(t, err, expectedErr)
+		txUOW.AssertExpectations(t)
+	})
+}
+
+func TestGroupUseCase_Archive(t *testing.T) {
+	validUserID, _ := identifier.NewID()
+
+	t.Run("returns unauthorized for different user", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		otherUserID, _ := identifier.NewID()
+		repo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, group.ID).Return(*group, nil)
+
+		usecase := newTestGroupUseCase(repo)
+
+		err := usecase.Archive(context.Background(), otherUserID.String(), group.ID.String())
+		assert.EqualError(t, err, "unauthorized")
+	})
+
+	t.Run("archives and restores group", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		var saved []bool
+		repo := &MockGroupRepository{}
+		txRepo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, group.ID).Return(*group, nil)
+		txRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			saved = append(saved, args.Get(1).(tracking.Group).Archived)
+		})
+
+		txUOW := &MockUnitOfWork{TrackingRepo: txRepo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+		txUOW.On("Commit").Return(nil)
+
+		usecase := NewGroupUseCase(
+			baseUOW,
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+		)
+
+		require.NoError(t, usecase.Archive(context.Background(), validUserID.String(), group.ID.String()))
+		require.NoError(t, usecase.Restore(context.Background(), validUserID.String(), group.ID.String()))
+		assert.Equal(t, []bool{true, false}, saved)
+	})
+
+	t.Run("rolls back when save fails", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		expectedErr := errors.New("db error")
+		repo := &MockGroupRepository{}
+		txRepo := &MockGroupRepository{}
+		repo.On("FindByID", mock.Anything, group.ID).Return(*group, nil)
+		txRepo.On("Save", mock.Anything, mock.Anything).Return(expectedErr)
+
+		txUOW := &MockUnitOfWork{TrackingRepo: txRepo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+		txUOW.On("Rollback").Return(nil)
+
+		usecase := NewGroupUseCase(
+			baseUOW,
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+		)
+
+		err := usecase.Archive(context.Background(), validUserID.String(), group.ID.String())
+		assert.ErrorIs(t, err, expectedErr)
+		txUOW.AssertExpectations(t)
+	})
+}
+
 func TestGroupUseCase_Get(t *testing.T) {
 	validUserID, _ := identifier.NewID()
 	existingGroup := newTestGroup(t, validUserID)
@@ -344,3 +795,73 @@ func TestGroupUseCase_List_Mapping(t *testing.T) {
 		assert.Equal(t, 123.45, resps[0].Categories[0].Budget)
 	})
 }
+
+func TestGroupUseCase_ClosedMonths(t *testing.T) {
+	validUserID, _ := identifier.NewID()
+
+	addCategory := func(t *testing.T, group *tracking.Group, n string, start string) identifier.ID {
+		t.Helper()
+		id, _ := identifier.NewID()
+		name, _ := tracking.NewNameVO(n)
+		desc, _ := tracking.NewDescriptionVO("Desc")
+		startMonth, _ := tracking.ParseMonth(start)
+		_, err := group.CreateCategory(id, name, desc, true, startMonth, tracking.Month{}, money.Money{})
+		require.NoError(t, err)
+		return id
+	}
+
+	// newUseCase returns a use case over groups where March 2023 is closed
+	// and holds expenses of the deleted group.
+	newUseCase := func(groups ...*tracking.Group) GroupUseCaseImpl {
+		repo := &MockGroupRepository{}
+		for _, g := range groups {
+			repo.On("FindByID", mock.Anything, g.ID).Return(*g, nil)
+			for _, c := range g.Categories {
+				repo.On("FindGroupByCategoryID", mock.Anything, c.ID).Return(*g, nil)
+			}
+		}
+		expenseRepo := newExpenseCountRepository(expense.MonthlyCount{Month: "2023-03", Count: 4})
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo, ExpenseRepo: expenseRepo, ClosingRepo: newClosedMonthsRepository("2023-03")}
+		return NewGroupUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	}
+
+	t.Run("hard delete is rejected", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		addCategory(t, group, "Rent", "2023-01")
+
+		err := newUseCase(group).Delete(context.Background(), &DeleteGroupRequest{
+			ID:     group.ID.String(),
+			UserID: validUserID.String(),
+			Mode:   DeleteModeHard,
+		})
+		assert.ErrorIs(t, err, closing.ErrMonthClosed)
+	})
+
+	t.Run("reassign is rejected", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		addCategory(t, group, "Rent", "2023-01")
+		targetGroup := newTestGroup(t, validUserID)
+		targetID := addCategory(t, targetGroup, "Housing", "2023-01")
+
+		err := newUseCase(group, targetGroup).Delete(context.Background(), &DeleteGroupRequest{
+			ID:               group.ID.String(),
+			UserID:           validUserID.String(),
+			Mode:             DeleteModeReassign,
+			TargetCategoryID: targetID.String(),
+		})
+		assert.ErrorIs(t, err, closing.ErrMonthClosed)
+	})
+
+	t.Run("end is rejected when a later month is closed", func(t *testing.T) {
+		group := newTestGroup(t, validUserID)
+		addCategory(t, group, "Rent", "2023-01")
+
+		err := newUseCase(group).Delete(context.Background(), &DeleteGroupRequest{
+			ID:     group.ID.String(),
+			UserID: validUserID.String(),
+			Mode:   DeleteModeEnd,
+			Month:  "2023-02",
+		})
+		assert.ErrorIs(t, err, closing.ErrMonthClosed)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 
 	"github.com/madalinpopa/gocost-web/internal/domain"
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
 	"github.com/madalinpopa/gocost-web/internal/domain/income"
 	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
 	"github.com/madalinpopa/gocost-web/internal/platform/money"
@@ -33,6 +34,10 @@ func (u IncomeUseCaseImpl) Create(ctx context.Context, req *CreateIncomeRequest)
 		return nil, err
 	}
 
+	if err := ensureMonthOpen(ctx, u.uow, uID, closing.MonthOf(req.ReceivedAt)); err != nil {
+		return nil, err
+	}
+
 	amount, err := money.NewFromFloat(req.Amount, req.Currency)
 	if err != nil {
 		return nil, err
@@ -53,6 +58,10 @@ func (u IncomeUseCaseImpl) Create(ctx context.Context, req *CreateIncomeRequest)
 		return nil, err
 	}
 
+	if inc.AccountID, err = resolveAccountID(ctx, u.uow, uID, req.AccountID); err != nil {
+		return nil, err
+	}
+
 	txUOW, err := u.uow.Begin(ctx)
 	if err != nil {
 		return nil, err
@@ -74,6 +83,7 @@ func (u IncomeUseCaseImpl) Create(ctx context.Context, req *CreateIncomeRequest)
 		Currency:    inc.Amount.Currency(),
 		Source:      inc.Source.Value(),
 		ReceivedAt:  inc.ReceivedAt,
+		AccountID:   accountIDString(inc.AccountID),
 	}, nil
 }
 
@@ -102,6 +112,12 @@ func (u IncomeUseCaseImpl) Update(ctx context.Context, req *UpdateIncomeRequest)
 		return nil, errors.New("unauthorized")
 	}
 
+	for _, month := range []string{closing.MonthOf(inc.ReceivedAt), closing.MonthOf(req.ReceivedAt)} {
+		if err := ensureMonthOpen(ctx, u.uow, uID, month); err != nil {
+			return nil, err
+		}
+	}
+
 	amount, err := money.NewFromFloat(req.Amount, req.Currency)
 	if err != nil {
 		return nil, err
@@ -117,6 +133,10 @@ func (u IncomeUseCaseImpl) Update(ctx context.Context, req *UpdateIncomeRequest)
 		return nil, err
 	}
 
+	if updatedInc.AccountID, err = resolveAccountID(ctx, u.uow, uID, req.AccountID); err != nil {
+		return nil, err
+	}
+
 	txUOW, err := u.uow.Begin(ctx)
 	if err != nil {
 		return nil, err
@@ -138,6 +158,7 @@ func (u IncomeUseCaseImpl) Update(ctx context.Context, req *UpdateIncomeRequest)
 		Currency:    updatedInc.Amount.Currency(),
 		Source:      updatedInc.Source.Value(),
 		ReceivedAt:  updatedInc.ReceivedAt,
+		AccountID:   accountIDString(updatedInc.AccountID),
 	}, nil
 }
 
@@ -162,6 +183,10 @@ func (u IncomeUseCaseImpl) Delete(ctx context.Context, userID string, id string)
 		return errors.New("unauthorized")
 	}
 
+	if err := ensureMonthOpen(ctx, u.uow, uID, closing.MonthOf(inc.ReceivedAt)); err != nil {
+		return err
+	}
+
 	txUOW, err := u.uow.Begin(ctx)
 	if err != nil {
 		return err
@@ -207,6 +232,7 @@ func (u IncomeUseCaseImpl) Get(ctx context.Context, userID string, id string) (*
 		Currency:    inc.Amount.Currency(),
 		Source:      inc.Source.Value(),
 		ReceivedAt:  inc.ReceivedAt,
+		AccountID:   accountIDString(inc.AccountID),
 	}, nil
 }
 
@@ -230,6 +256,7 @@ func (u IncomeUseCaseImpl) List(ctx context.Context, userID string) ([]*IncomeRe
 			Currency:    inc.Amount.Currency(),
 			Source:      inc.Source.Value(),
 			ReceivedAt:  inc.ReceivedAt,
+			AccountID:   accountIDString(inc.AccountID),
 		}
 	}
 
@@ -256,6 +283,7 @@ func (u IncomeUseCaseImpl) ListByMonth(ctx context.Context, userID string, month
 			Currency:    inc.Amount.Currency(),
 			Source:      inc.Source.Value(),
 			ReceivedAt:  inc.ReceivedAt,
+			AccountID:   accountIDString(inc.AccountID),
 		})
 	}
 
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/madalinpopa/gocost-web/internal/domain/account"
 	"github.com/madalinpopa/gocost-web/internal/domain/income"
 	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
 	"github.com/madalinpopa/gocost-web/internal/platform/money"
@@ -153,6 +154,20 @@ func TestIncomeUseCase_Create(t *testing.T) {
 		assert.Equal(t, validReq.ReceivedAt, savedIncome.ReceivedAt)
 		assert.Equal(t, validUserID, savedIncome.UserID)
 	})
+
+	t.Run("returns error for an account of another user", func(t *testing.T) {
+		repo := &MockIncomeRepository{}
+		usecase := newTestIncomeUseCase(repo, nil)
+		accountID, _ := identifier.NewID()
+		req := *validReq
+		req.AccountID = accountID.String()
+
+		resp, err := usecase.Create(context.Background(), &req)
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, account.ErrAccountNotFound)
+		repo.AssertNotCalled(t, "Save")
+	})
 }
 
 func TestIncomeUseCase_Update(t *testing.T) {
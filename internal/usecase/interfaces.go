@@ -5,6 +5,53 @@ import "context"
 type AuthUseCase interface {
 	Register(ctx context.Context, req *RegisterUserRequest) (*UserResponse, error)
 	Login(ctx context.Context, req *LoginRequest) (*LoginResponse, error)
+	ConfirmPassword(ctx context.Context, req *ConfirmPasswordRequest) error
+}
+
+type ProfileUseCase interface {
+	Get(ctx context.Context, userID string) (*UserResponse, error)
+	UpdateProfile(ctx context.Context, req *UpdateProfileRequest) (*UserResponse, error)
+	ChangeCurrency(ctx context.Context, req *ChangeCurrencyRequest) (*UserResponse, error)
+	ChangePassword(ctx context.Context, req *ChangePasswordRequest) error
+}
+
+type PasswordResetUseCase interface {
+	RequestReset(ctx context.Context, req *RequestPasswordResetRequest) error
+	ResetPassword(ctx context.Context, req *ResetPasswordRequest) (string, error)
+}
+
+type EmailVerificationUseCase interface {
+	SendVerification(ctx context.Context, req *SendVerificationRequest) error
+	VerifyEmail(ctx context.Context, token string) (*UserResponse, error)
+}
+
+type TwoFactorUseCase interface {
+	Status(ctx context.Context, userID string) (*TwoFactorStatusResponse, error)
+	BeginSetup(ctx context.Context, userID string) (*TwoFactorSetupResponse, error)
+	ConfirmSetup(ctx context.Context, req *ConfirmTwoFactorRequest) ([]string, error)
+	Disable(ctx context.Context, req *DisableTwoFactorRequest) error
+	Verify(ctx context.Context, req *VerifyTwoFactorRequest) (*UserResponse, error)
+}
+
+type PasskeyUseCase interface {
+	List(ctx context.Context, userID string) ([]PasskeyResponse, error)
+	BeginRegistration(ctx context.Context, req *BeginPasskeyRegistrationRequest) (*PasskeyCreationResponse, error)
+	FinishRegistration(ctx context.Context, req *FinishPasskeyRegistrationRequest) (*PasskeyResponse, error)
+	BeginLogin(ctx context.Context, origin string) (*PasskeyRequestResponse, error)
+	FinishLogin(ctx context.Context, req *FinishPasskeyLoginRequest) (*UserResponse, error)
+	Revoke(ctx context.Context, req *RevokePasskeyRequest) error
+}
+
+type SessionUseCase interface {
+	Start(ctx context.Context, req *StartSessionRequest) (*UserSessionResponse, error)
+	Touch(ctx context.Context, req *TouchSessionRequest) error
+	List(ctx context.Context, userID string, activeIDs []string) ([]UserSessionResponse, error)
+}
+
+type InviteUseCase interface {
+	Create(ctx context.Context, req *CreateInviteRequest) (*InviteResponse, error)
+	List(ctx context.Context) ([]InviteResponse, error)
+	Revoke(ctx context.Context, id string) error
 }
 
 type IncomeUseCase interface {
@@ -20,17 +67,27 @@ type IncomeUseCase interface {
 type GroupUseCase interface {
 	Create(ctx context.Context, req *CreateGroupRequest) (*GroupResponse, error)
 	Update(ctx context.Context, req *UpdateGroupRequest) (*GroupResponse, error)
-	Delete(ctx context.Context, userID string, id string) error
+	Delete(ctx context.Context, req *DeleteGroupRequest) error
+	DeletionImpact(ctx context.Context, userID string, id string, month string) (*DeletionImpactResponse, error)
 	Get(ctx context.Context, userID string, id string) (*GroupResponse, error)
 	List(ctx context.Context, userID string) ([]*GroupResponse, error)
+	Reorder(ctx context.Context, userID string, ids []string) error
+	Archive(ctx context.Context, userID string, id string) error
+	Restore(ctx context.Context, userID string, id string) error
 }
 
 type CategoryUseCase interface {
 	Create(ctx context.Context, req *CreateCategoryRequest) (*CategoryResponse, error)
 	Update(ctx context.Context, req *UpdateCategoryRequest) (*CategoryResponse, error)
-	Delete(ctx context.Context, userID string, groupID string, id string) error
+	Delete(ctx context.Context, req *DeleteCategoryRequest) error
+	DeletionImpact(ctx context.Context, userID string, groupID string, id string, month string) (*DeletionImpactResponse, error)
 	Get(ctx context.Context, userID string, groupID string, id string) (*CategoryResponse, error)
 	List(ctx context.Context, userID string, groupID string) ([]CategoryResponse, error)
+	Reorder(ctx context.Context, userID string, groupID string, ids []string) error
+	Move(ctx context.Context, req *MoveCategoryRequest) (*CategoryResponse, error)
+	Merge(ctx context.Context, req *MergeCategoryRequest) (*CategoryResponse, error)
+	Archive(ctx context.Context, userID string, groupID string, id string) error
+	Restore(ctx context.Context, userID string, groupID string, id string) error
 }
 
 type ExpenseUseCase interface {
@@ -46,3 +103,70 @@ type ExpenseUseCase interface {
 type DashboardUseCase interface {
 	Get(ctx context.Context, req *DashboardRequest) (*DashboardResponse, error)
 }
+
+type PlanUseCase interface {
+	Preview(ctx context.Context, userID string, fromMonth string) (*PlanPreviewResponse, error)
+	Apply(ctx context.Context, req *PlanMonthRequest) (*PlanMonthResponse, error)
+}
+
+type ClosingUseCase interface {
+	Status(ctx context.Context, userID string, month string) (*MonthStatusResponse, error)
+	Close(ctx context.Context, req *CloseMonthRequest) (*MonthStatusResponse, error)
+	Reopen(ctx context.Context, userID string, month string) error
+}
+
+type BudgetUseCase interface {
+	SetZeroBased(ctx context.Context, userID string, enabled bool) error
+	Assignment(ctx context.Context, userID string, month string) (*BudgetAssignmentResponse, error)
+	FillFromLastMonth(ctx context.Context, req *FillBudgetRequest) (*BudgetAssignmentResponse, error)
+	Distribute(ctx context.Context, req *DistributeBudgetRequest) (*BudgetAssignmentResponse, error)
+}
+
+type GoalUseCase interface {
+	Create(ctx context.Context, req *CreateGoalRequest) (*GoalResponse, error)
+	Update(ctx context.Context, req *UpdateGoalRequest) (*GoalResponse, error)
+	Delete(ctx context.Context, userID string, id string) error
+	Get(ctx context.Context, userID string, id string, month string) (*GoalResponse, error)
+	List(ctx context.Context, userID string, month string) ([]GoalResponse, error)
+	Contribute(ctx context.Context, req *ContributeGoalRequest) (*GoalResponse, error)
+	RemoveContribution(ctx context.Context, userID string, goalID string, contributionID string) error
+}
+
+type AccountUseCase interface {
+	Create(ctx context.Context, req *CreateAccountRequest) (*AccountResponse, error)
+	Update(ctx context.Context, req *UpdateAccountRequest) (*AccountResponse, error)
+	Delete(ctx context.Context, userID string, id string) error
+	List(ctx context.Context, userID string) ([]AccountResponse, error)
+	Ledger(ctx context.Context, userID string, id string) (*AccountLedgerResponse, error)
+	Transfer(ctx context.Context, req *TransferRequest) error
+	DeleteTransfer(ctx context.Context, userID string, id string) error
+	CompareStatement(ctx context.Context, req *ReconcileRequest) (*ReconciliationResponse, error)
+	Reconcile(ctx context.Context, req *ReconcileRequest) (*ReconciliationResponse, error)
+}
+
+type LoanUseCase interface {
+	Create(ctx context.Context, req *CreateLoanRequest) (*LoanResponse, error)
+	Update(ctx context.Context, req *UpdateLoanRequest) (*LoanResponse, error)
+	Delete(ctx context.Context, userID string, id string) error
+	List(ctx context.Context, userID string, month string) ([]LoanResponse, error)
+	Schedule(ctx context.Context, userID string, id string, month string) (*LoanScheduleResponse, error)
+	Simulate(ctx context.Context, req *SimulateLoanRequest) (*LoanSimulationResponse, error)
+}
+
+type NetWorthUseCase interface {
+	CreateAsset(ctx context.Context, req *CreateAssetRequest) error
+	UpdateAsset(ctx context.Context, req *UpdateAssetRequest) error
+	DeleteAsset(ctx context.Context, userID string, id string) error
+	RecordValuation(ctx context.Context, req *RecordValuationRequest) error
+	RemoveValuation(ctx context.Context, userID string, assetID string, month string) error
+	Summary(ctx context.Context, req *NetWorthRequest) (*NetWorthResponse, error)
+}
+
+type AlertUseCase interface {
+	Settings(ctx context.Context, userID string, month string) ([]AlertGroupResponse, error)
+	SaveThresholds(ctx context.Context, req *SaveThresholdsRequest) error
+	Notifications(ctx context.Context, userID string, limit int) ([]NotificationResponse, error)
+	UnreadCount(ctx context.Context, userID string) (int, error)
+	MarkRead(ctx context.Context, userID string, id string) error
+	MarkAllRead(ctx context.Context, userID string) error
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain"
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/security"
+)
+
+type InviteUseCaseImpl struct {
+	uow    domain.UnitOfWork
+	logger *slog.Logger
+}
+
+func NewInviteUseCase(uow domain.UnitOfWork, logger *slog.Logger) InviteUseCaseImpl {
+	return InviteUseCaseImpl{
+		uow:    uow,
+		logger: logger,
+	}
+}
+
+// Create generates a new invite code. The code is returned this once; only
+// its hash is stored.
+func (u InviteUseCaseImpl) Create(ctx context.Context, req *CreateInviteRequest) (*InviteResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	id, err := identifier.NewID()
+	if err != nil {
+		return nil, err
+	}
+	code, err := security.NewInviteCode()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	invite, err := identity.NewInvite(id, security.HashToken(code), req.MaxUses, now, now.Add(req.ExpiresIn))
+	if err != nil {
+		return nil, err
+	}
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := txUOW.InviteRepository().Save(ctx, *invite); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
+	resp := mapInviteToResponse(*invite)
+	resp.Code = code
+	return &resp, nil
+}
+
+// List returns all invites, newest first, used up and expired ones included.
+func (u InviteUseCaseImpl) List(ctx context.Context) ([]InviteResponse, error) {
+	invites, err := u.uow.InviteRepository().FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]InviteResponse, 0, len(invites))
+	for _, invite := range invites {
+		responses = append(responses, mapInviteToResponse(invite))
+	}
+	return responses, nil
+}
+
+// Revoke deletes an invite, so that its code no longer registers anyone.
+func (u InviteUseCaseImpl) Revoke(ctx context.Context, id string) error {
+	inviteID, err := identifier.ParseID(id)
+	if err != nil {
+		return identity.ErrInviteNotFound
+	}
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := txUOW.InviteRepository().Delete(ctx, inviteID); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func mapInviteToResponse(invite identity.Invite) InviteResponse {
+	return InviteResponse{
+		ID:        invite.ID.String(),
+		MaxUses:   invite.MaxUses,
+		Uses:      invite.Uses,
+		CreatedAt: invite.CreatedAt,
+		ExpiresAt: invite.ExpiresAt,
+	}
+}
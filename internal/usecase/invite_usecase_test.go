@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestInviteUseCase(repo *MockInviteRepository) InviteUseCaseImpl {
+	txUOW := &MockUnitOfWork{InviteRepo: repo}
+	txUOW.On("Commit").Return(nil)
+	txUOW.On("Rollback").Return(nil)
+
+	baseUOW := &MockUnitOfWork{InviteRepo: repo}
+	baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+
+	return NewInviteUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func newTestInvite(t *testing.T, code string, maxUses int) identity.Invite {
+	t.Helper()
+	id, err := identifier.NewID()
+	require.NoError(t, err)
+	now := time.Now().UTC()
+	invite, err := identity.NewInvite(id, security.HashToken(code), maxUses, now, now.Add(time.Hour))
+	require.NoError(t, err)
+	return *invite
+}
+
+func TestInviteUseCase_Create(t *testing.T) {
+	t.Run("returns error for nil request", func(t *testing.T) {
+		resp, err := newTestInviteUseCase(&MockInviteRepository{}).Create(context.Background(), nil)
+
+		assert.Nil(t, resp)
+		assert.EqualError(t, err, "request cannot be nil")
+	})
+
+	t.Run("rejects an invite without uses", func(t *testing.T) {
+		resp, err := newTestInviteUseCase(&MockInviteRepository{}).Create(context.Background(), &CreateInviteRequest{
+			MaxUses:   0,
+			ExpiresIn: time.Hour,
+		})
+
+		assert.Nil(t, resp)
+		assert.ErrorIs(t, err, identity.ErrInvalidInviteUses)
+	})
+
+	t.Run("stores the hash of the code it returns", func(t *testing.T) {
+		var saved identity.Invite
+		repo := &MockInviteRepository{}
+		repo.On("Save", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(identity.Invite)
+		})
+
+		resp, err := newTestInviteUseCase(repo).Create(context.Background(), &CreateInviteRequest{
+			MaxUses:   3,
+			ExpiresIn: 48 * time.Hour,
+		})
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, resp.Code)
+		assert.Equal(t, security.HashToken(resp.Code), saved.CodeHash)
+		assert.Equal(t, saved.ID.String(), resp.ID)
+		assert.Equal(t, 3, resp.MaxUses)
+		assert.Equal(t, 48*time.Hour, resp.ExpiresAt.Sub(resp.CreatedAt))
+	})
+}
+
+func TestInviteUseCase_List(t *testing.T) {
+	invite := newTestInvite(t, "abcde-fghij-klmno", 2)
+	repo := &MockInviteRepository{}
+	repo.On("FindAll", mock.Anything).Return([]identity.Invite{invite}, nil)
+
+	resp, err := newTestInviteUseCase(repo).List(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, resp, 1)
+	assert.Equal(t, invite.ID.String(), resp[0].ID)
+	assert.Empty(t, resp[0].Code)
+	assert.Equal(t, 2, resp[0].MaxUses)
+}
+
+func TestInviteUseCase_Revoke(t *testing.T) {
+	t.Run("deletes the invite", func(t *testing.T) {
+		invite := newTestInvite(t, "abcde-fghij-klmno", 1)
+		repo := &MockInviteRepository{}
+		repo.On("Delete", mock.Anything, invite.ID).Return(nil)
+
+		err := newTestInviteUseCase(repo).Revoke(context.Background(), invite.ID.String())
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("returns not found for a malformed id", func(t *testing.T) {
+		err := newTestInviteUseCase(&MockInviteRepository{}).Revoke(context.Background(), "not-an-id")
+
+		assert.ErrorIs(t, err, identity.ErrInviteNotFound)
+	})
+
+	t.Run("returns repository errors", func(t *testing.T) {
+		expectedErr := errors.New("delete failed")
+		invite := newTestInvite(t, "abcde-fghij-klmno", 1)
+		repo := &MockInviteRepository{}
+		repo.On("Delete", mock.Anything, invite.ID).Return(expectedErr)
+
+		err := newTestInviteUseCase(repo).Revoke(context.Background(), invite.ID.String())
+
+		assert.ErrorIs(t, err, expectedErr)
+	})
+}
@@ -0,0 +1,353 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/madalinpopa/gocost-web/internal/domain"
+	"github.com/madalinpopa/gocost-web/internal/domain/loan"
+	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+)
+
+type LoanUseCaseImpl struct {
+	uow    domain.UnitOfWork
+	logger *slog.Logger
+}
+
+func NewLoanUseCase(uow domain.UnitOfWork, logger *slog.Logger) LoanUseCaseImpl {
+	return LoanUseCaseImpl{
+		uow:    uow,
+		logger: logger,
+	}
+}
+
+func (u LoanUseCaseImpl) Create(ctx context.Context, req *CreateLoanRequest) (*LoanResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	uID, err := identifier.ParseID(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	name, principal, rate, err := parseLoanFields(req.Name, req.Principal, req.RatePercent, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryID, err := u.resolveCategoryID(ctx, uID, req.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := identifier.NewID()
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := loan.NewLoan(id, uID, name, principal, rate, req.TermMonths, req.StartMonth, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.save(ctx, l); err != nil {
+		return nil, err
+	}
+
+	return u.response(ctx, l, l.StartMonth)
+}
+
+func (u LoanUseCaseImpl) Update(ctx context.Context, req *UpdateLoanRequest) (*LoanResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	l, err := u.find(ctx, req.UserID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	name, principal, rate, err := parseLoanFields(req.Name, req.Principal, req.RatePercent, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryID, err := u.resolveCategoryID(ctx, l.UserID, req.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.Update(name, principal, rate, req.TermMonths, req.StartMonth, categoryID); err != nil {
+		return nil, err
+	}
+
+	if err := u.save(ctx, l); err != nil {
+		return nil, err
+	}
+
+	return u.response(ctx, l, l.StartMonth)
+}
+
+// Delete removes the loan only. The expenses recorded for its payments stay
+// in their category.
+func (u LoanUseCaseImpl) Delete(ctx context.Context, userID string, id string) error {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return err
+	}
+
+	loanID, err := identifier.ParseID(id)
+	if err != nil {
+		return err
+	}
+
+	return u.uow.LoanRepository().Delete(ctx, uID, loanID)
+}
+
+// List reports every loan as of the end of the month.
+func (u LoanUseCaseImpl) List(ctx context.Context, userID string, month string) ([]LoanResponse, error) {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	loans, err := u.uow.LoanRepository().FindByUserID(ctx, uID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]LoanResponse, 0, len(loans))
+	for i := range loans {
+		resp, err := u.response(ctx, &loans[i], month)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, *resp)
+	}
+	return responses, nil
+}
+
+// Schedule returns the amortization schedule of the loan with the payments
+// recorded in its category next to the months they were made in.
+func (u LoanUseCaseImpl) Schedule(ctx context.Context, userID string, id string, month string) (*LoanScheduleResponse, error) {
+	l, err := u.find(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := u.payments(ctx, l)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := l.Schedule(loan.Extra{OneOff: l.Overpayments(payments)})
+	return &LoanScheduleResponse{
+		Loan:         mapLoanToResponse(l, schedule, month),
+		Installments: mapInstallmentsToResponse(schedule, payments),
+	}, nil
+}
+
+// Simulate works out the schedule with extra payments on top of what was
+// already paid, without saving anything.
+func (u LoanUseCaseImpl) Simulate(ctx context.Context, req *SimulateLoanRequest) (*LoanSimulationResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+	if req.ExtraMonthly < 0 || req.LumpSum < 0 {
+		return nil, loan.ErrInvalidExtra
+	}
+
+	l, err := u.find(ctx, req.UserID, req.LoanID)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := u.payments(ctx, l)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := l.Principal.Currency()
+	monthly, err := money.NewFromFloat(req.ExtraMonthly, currency)
+	if err != nil {
+		return nil, err
+	}
+	lumpSum, err := money.NewFromFloat(req.LumpSum, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	overpayments := l.Overpayments(payments)
+	current := l.Schedule(loan.Extra{OneOff: overpayments})
+
+	oneOff := make(map[string]money.Money, len(overpayments)+1)
+	for month, amount := range overpayments {
+		oneOff[month] = amount
+	}
+	if lumpSum.Cents() > 0 {
+		if req.LumpSumMonth == "" {
+			return nil, loan.ErrInvalidMonth
+		}
+		oneOff[req.LumpSumMonth], _ = money.New(lumpSum.Cents()+oneOff[req.LumpSumMonth].Cents(), currency)
+	}
+	simulated := l.Schedule(loan.Extra{Monthly: monthly, From: req.Month, OneOff: oneOff})
+
+	return &LoanSimulationResponse{
+		Loan:               mapLoanToResponse(l, current, req.Month),
+		PayoffMonth:        simulated.PayoffMonth,
+		TotalInterestCents: simulated.TotalInterest.Cents(),
+		InterestSavedCents: current.TotalInterest.Cents() - simulated.TotalInterest.Cents(),
+		MonthsSaved:        len(current.Installments) - len(simulated.Installments),
+		Installments:       mapInstallmentsToResponse(simulated, payments),
+	}, nil
+}
+
+func (u LoanUseCaseImpl) response(ctx context.Context, l *loan.Loan, month string) (*LoanResponse, error) {
+	payments, err := u.payments(ctx, l)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := mapLoanToResponse(l, l.Schedule(loan.Extra{OneOff: l.Overpayments(payments)}), month)
+	return &resp, nil
+}
+
+func (u LoanUseCaseImpl) payments(ctx context.Context, l *loan.Loan) ([]loan.Payment, error) {
+	return loanPayments(ctx, u.uow, l)
+}
+
+// loanPayments returns the payments recorded in the category of the loan,
+// or none when no category is linked.
+func loanPayments(ctx context.Context, uow domain.UnitOfWork, l *loan.Loan) ([]loan.Payment, error) {
+	if l.CategoryID.IsZero() {
+		return nil, nil
+	}
+	return uow.LoanRepository().Payments(ctx, l.UserID, l.CategoryID)
+}
+
+// resolveCategoryID parses the category the loan payments are recorded in.
+// No category is fine; a category of another user is reported as not found.
+func (u LoanUseCaseImpl) resolveCategoryID(ctx context.Context, userID identifier.ID, id string) (identifier.ID, error) {
+	if id == "" {
+		return identifier.ID{}, nil
+	}
+
+	categoryID, err := identifier.ParseID(id)
+	if err != nil {
+		return identifier.ID{}, tracking.ErrCategoryNotFound
+	}
+
+	group, err := u.uow.TrackingRepository().FindGroupByCategoryID(ctx, categoryID)
+	if err != nil {
+		return identifier.ID{}, err
+	}
+	if group.UserID != userID {
+		return identifier.ID{}, tracking.ErrCategoryNotFound
+	}
+	return categoryID, nil
+}
+
+func (u LoanUseCaseImpl) find(ctx context.Context, userID string, id string) (*loan.Loan, error) {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	loanID, err := identifier.ParseID(id)
+	if err != nil {
+		return nil, loan.ErrLoanNotFound
+	}
+
+	l, err := u.uow.LoanRepository().FindByID(ctx, uID, loanID)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (u LoanUseCaseImpl) save(ctx context.Context, l *loan.Loan) error {
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := txUOW.LoanRepository().Save(ctx, *l); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func parseLoanFields(name string, principal float64, ratePercent float64, currency string) (loan.NameVO, money.Money, loan.RateVO, error) {
+	nameVO, err := loan.NewNameVO(name)
+	if err != nil {
+		return loan.NameVO{}, money.Money{}, loan.RateVO{}, err
+	}
+
+	principalMoney, err := money.NewFromFloat(principal, currency)
+	if err != nil {
+		return loan.NameVO{}, money.Money{}, loan.RateVO{}, err
+	}
+
+	rate, err := loan.NewRateFromPercent(ratePercent)
+	if err != nil {
+		return loan.NameVO{}, money.Money{}, loan.RateVO{}, err
+	}
+
+	return nameVO, principalMoney, rate, nil
+}
+
+func mapLoanToResponse(l *loan.Loan, schedule loan.Schedule, month string) LoanResponse {
+	categoryID := ""
+	if !l.CategoryID.IsZero() {
+		categoryID = l.CategoryID.String()
+	}
+
+	return LoanResponse{
+		ID:                 l.ID.String(),
+		Name:               l.Name.Value(),
+		Currency:           l.Principal.Currency(),
+		PrincipalCents:     l.Principal.Cents(),
+		RatePercent:        l.Rate.Percent(),
+		TermMonths:         l.TermMonths,
+		StartMonth:         l.StartMonth,
+		CategoryID:         categoryID,
+		Month:              month,
+		PaymentCents:       schedule.Payment.Cents(),
+		BalanceCents:       schedule.BalanceAfter(l.Principal, month).Cents(),
+		TotalInterestCents: schedule.TotalInterest.Cents(),
+		PayoffMonth:        schedule.PayoffMonth,
+	}
+}
+
+func mapInstallmentsToResponse(schedule loan.Schedule, payments []loan.Payment) []InstallmentResponse {
+	recorded := make(map[string]int64, len(payments))
+	for _, p := range payments {
+		recorded[p.Month] = p.Amount.Cents()
+	}
+
+	installments := make([]InstallmentResponse, 0, len(schedule.Installments))
+	for _, i := range schedule.Installments {
+		installments = append(installments, InstallmentResponse{
+			Number:         i.Number,
+			Month:          i.Month,
+			PaymentCents:   i.Payment.Cents(),
+			PrincipalCents: i.Principal.Cents(),
+			InterestCents:  i.Interest.Cents(),
+			ExtraCents:     i.Extra.Cents(),
+			BalanceCents:   i.Balance.Cents(),
+			RecordedCents:  recorded[i.Month],
+		})
+	}
+	return installments
+}
@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/loan"
+	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestLoan is an interest free loan of 1,200.00 repaid in 12 months from
+// January 2024, so every scheduled payment is 100.00.
+func newTestLoan(t *testing.T, userID identifier.ID, categoryID identifier.ID) loan.Loan {
+	t.Helper()
+
+	id, _ := identifier.NewID()
+	name, err := loan.NewNameVO("Laptop")
+	require.NoError(t, err)
+	principal, err := money.New(120000, "USD")
+	require.NoError(t, err)
+	rate, err := loan.NewRateVO(0)
+	require.NoError(t, err)
+
+	l, err := loan.NewLoan(id, userID, name, principal, rate, 12, "2024-01", categoryID)
+	require.NoError(t, err)
+	return *l
+}
+
+func newTestLoanPayment(month string, cents int64) loan.Payment {
+	amount, _ := money.New(cents, "USD")
+	return loan.Payment{Month: month, Amount: amount}
+}
+
+// newTestLoanUseCase returns the use case over the loans repository and the
+// transactional repository that records changes.
+func newTestLoanUseCase(repo *MockLoanRepository, groups *MockGroupRepository) (LoanUseCaseImpl, *MockLoanRepository) {
+	txRepo := &MockLoanRepository{}
+	txUOW := &MockUnitOfWork{LoanRepo: txRepo}
+	txUOW.On("Commit").Return(nil).Maybe()
+	txUOW.On("Rollback").Return(nil).Maybe()
+
+	baseUOW := &MockUnitOfWork{LoanRepo: repo, TrackingRepo: groups}
+	baseUOW.On("Begin", mock.Anything).Return(txUOW, nil).Maybe()
+
+	return NewLoanUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil))), txRepo
+}
+
+func TestLoanUseCase_Create(t *testing.T) {
+	t.Run("returns error for nil request", func(t *testing.T) {
+		usecase, _ := newTestLoanUseCase(&MockLoanRepository{}, &MockGroupRepository{})
+		resp, err := usecase.Create(context.Background(), nil)
+		assert.Nil(t, resp)
+		assert.EqualError(t, err, "request cannot be nil")
+	})
+
+	t.Run("saves a loan linked to a category", func(t *testing.T) {
+		userID, _ := identifier.NewID()
+		categoryID, _ := identifier.NewID()
+		groups := &MockGroupRepository{}
+		groups.On("FindGroupByCategoryID", mock.Anything, categoryID).Return(tracking.Group{UserID: userID}, nil)
+		repo := &MockLoanRepository{}
+		repo.On("Payments", mock.Anything, userID, categoryID).Return([]loan.Payment{}, nil)
+
+		usecase, txRepo := newTestLoanUseCase(repo, groups)
+		txRepo.On("Save", mock.Anything, mock.MatchedBy(func(l loan.Loan) bool {
+			return l.UserID == userID && l.CategoryID == categoryID && l.Principal.Cents() == 2000000 && l.Rate.BasisPoints() == 500
+		})).Return(nil)
+
+		resp, err := usecase.Create(context.Background(), &CreateLoanRequest{
+			UserID:      userID.String(),
+			Currency:    "USD",
+			Name:        "Car",
+			Principal:   20000,
+			RatePercent: 5,
+			TermMonths:  60,
+			StartMonth:  "2024-01",
+			CategoryID:  categoryID.String(),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(37742), resp.PaymentCents)
+		assert.Equal(t, "2028-12", resp.PayoffMonth)
+		txRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects a category of another user", func(t *testing.T) {
+		userID, _ := identifier.NewID()
+		otherID, _ := identifier.NewID()
+		categoryID, _ := identifier.NewID()
+		groups := &MockGroupRepository{}
+		groups.On("FindGroupByCategoryID", mock.Anything, categoryID).Return(tracking.Group{UserID: otherID}, nil)
+
+		usecase, txRepo := newTestLoanUseCase(&MockLoanRepository{}, groups)
+
+		_, err := usecase.Create(context.Background(), &CreateLoanRequest{
+			UserID:      userID.String(),
+			Currency:    "USD",
+			Name:        "Car",
+			Principal:   20000,
+			RatePercent: 5,
+			TermMonths:  60,
+			StartMonth:  "2024-01",
+			CategoryID:  categoryID.String(),
+		})
+
+		assert.ErrorIs(t, err, tracking.ErrCategoryNotFound)
+		txRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestLoanUseCase_Schedule(t *testing.T) {
+	userID, _ := identifier.NewID()
+	categoryID, _ := identifier.NewID()
+	l := newTestLoan(t, userID, categoryID)
+
+	repo := &MockLoanRepository{}
+	repo.On("FindByID", mock.Anything, userID, l.ID).Return(l, nil)
+	repo.On("Payments", mock.Anything, userID, categoryID).Return([]loan.Payment{
+		newTestLoanPayment("2024-01", 10000),
+		newTestLoanPayment("2024-02", 30000),
+	}, nil)
+	usecase, _ := newTestLoanUseCase(repo, &MockGroupRepository{})
+
+	resp, err := usecase.Schedule(context.Background(), userID.String(), l.ID.String(), "2024-02")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(80000), resp.Loan.BalanceCents)
+	assert.Equal(t, "2024-10", resp.Loan.PayoffMonth)
+	require.Len(t, resp.Installments, 10)
+	assert.Equal(t, int64(30000), resp.Installments[1].RecordedCents)
+	assert.Equal(t, int64(20000), resp.Installments[1].ExtraCents)
+	assert.Equal(t, int64(0), resp.Installments[2].RecordedCents)
+}
+
+func TestLoanUseCase_Simulate(t *testing.T) {
+	userID, _ := identifier.NewID()
+	l := newTestLoan(t, userID, identifier.ID{})
+
+	repo := &MockLoanRepository{}
+	repo.On("FindByID", mock.Anything, userID, l.ID).Return(l, nil)
+	usecase, _ := newTestLoanUseCase(repo, &MockGroupRepository{})
+
+	t.Run("reports what extra payments save", func(t *testing.T) {
+		resp, err := usecase.Simulate(context.Background(), &SimulateLoanRequest{
+			UserID:       userID.String(),
+			LoanID:       l.ID.String(),
+			Month:        "2024-01",
+			ExtraMonthly: 100,
+			LumpSum:      200,
+			LumpSumMonth: "2024-01",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "2024-05", resp.PayoffMonth)
+		assert.Equal(t, 7, resp.MonthsSaved)
+		assert.Equal(t, "2024-12", resp.Loan.PayoffMonth)
+		assert.Len(t, resp.Installments, 5)
+		repo.AssertNotCalled(t, "Payments", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a negative extra", func(t *testing.T) {
+		_, err := usecase.Simulate(context.Background(), &SimulateLoanRequest{
+			UserID:       userID.String(),
+			LoanID:       l.ID.String(),
+			ExtraMonthly: -1,
+		})
+		assert.ErrorIs(t, err, loan.ErrInvalidExtra)
+	})
+
+	t.Run("needs the month of a lump sum", func(t *testing.T) {
+		_, err := usecase.Simulate(context.Background(), &SimulateLoanRequest{
+			UserID:  userID.String(),
+			LoanID:  l.ID.String(),
+			LumpSum: 100,
+		})
+		assert.ErrorIs(t, err, loan.ErrInvalidMonth)
+	})
+}
+
+func TestLoanUseCase_Delete(t *testing.T) {
+	userID, _ := identifier.NewID()
+	id, _ := identifier.NewID()
+	repo := &MockLoanRepository{}
+	repo.On("Delete", mock.Anything, userID, id).Return(loan.ErrLoanNotFound)
+	usecase, _ := newTestLoanUseCase(repo, &MockGroupRepository{})
+
+	err := usecase.Delete(context.Background(), userID.String(), id.String())
+
+	assert.ErrorIs(t, err, loan.ErrLoanNotFound)
+}
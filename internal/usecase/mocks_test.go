@@ -2,12 +2,20 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/madalinpopa/gocost-web/internal/domain"
+	"github.com/madalinpopa/gocost-web/internal/domain/account"
+	"github.com/madalinpopa/gocost-web/internal/domain/alert"
+	"github.com/madalinpopa/gocost-web/internal/domain/closing"
 	"github.com/madalinpopa/gocost-web/internal/domain/expense"
 	"github.com/madalinpopa/gocost-web/internal/domain/identity"
 	"github.com/madalinpopa/gocost-web/internal/domain/income"
+	"github.com/madalinpopa/gocost-web/internal/domain/loan"
+	"github.com/madalinpopa/gocost-web/internal/domain/networth"
+	"github.com/madalinpopa/gocost-web/internal/domain/saving"
 	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
+	"github.com/madalinpopa/gocost-web/internal/infrastructure/mail"
 	"github.com/madalinpopa/gocost-web/internal/platform/money"
 	"github.com/stretchr/testify/mock"
 )
@@ -15,16 +23,47 @@ import (
 // MockUnitOfWork is a test double for the UnitOfWork interface.
 type MockUnitOfWork struct {
 	mock.Mock
-	UserRepo     *MockUserRepository
-	IncomeRepo   *MockIncomeRepository
-	ExpenseRepo  *MockExpenseRepository
-	TrackingRepo *MockGroupRepository
+	UserRepo          *MockUserRepository
+	PasswordResetRepo *MockPasswordResetRepository
+	TwoFactorRepo     *MockTwoFactorRepository
+	PasskeyRepo       *MockPasskeyRepository
+	UserSessionRepo   *MockUserSessionRepository
+	InviteRepo        *MockInviteRepository
+	IncomeRepo        *MockIncomeRepository
+	ExpenseRepo       *MockExpenseRepository
+	TrackingRepo      *MockGroupRepository
+	ClosingRepo       *MockClosingRepository
+	SavingRepo        *MockSavingRepository
+	AccountRepo       *MockAccountRepository
+	LoanRepo          *MockLoanRepository
+	AssetRepo         *MockAssetRepository
+	AlertRepo         *MockAlertRepository
 }
 
 func (m *MockUnitOfWork) UserRepository() identity.UserRepository {
 	return m.UserRepo
 }
 
+func (m *MockUnitOfWork) PasswordResetRepository() identity.PasswordResetRepository {
+	return m.PasswordResetRepo
+}
+
+func (m *MockUnitOfWork) TwoFactorRepository() identity.TwoFactorRepository {
+	return m.TwoFactorRepo
+}
+
+func (m *MockUnitOfWork) PasskeyRepository() identity.PasskeyRepository {
+	return m.PasskeyRepo
+}
+
+func (m *MockUnitOfWork) UserSessionRepository() identity.UserSessionRepository {
+	return m.UserSessionRepo
+}
+
+func (m *MockUnitOfWork) InviteRepository() identity.InviteRepository {
+	return m.InviteRepo
+}
+
 func (m *MockUnitOfWork) IncomeRepository() income.IncomeRepository {
 	return m.IncomeRepo
 }
@@ -37,6 +76,48 @@ func (m *MockUnitOfWork) TrackingRepository() tracking.GroupRepository {
 	return m.TrackingRepo
 }
 
+// ClosingRepository falls back to a repository where every month is open, so
+// tests that do not care about month closing need not set it up.
+func (m *MockUnitOfWork) ClosingRepository() closing.MonthCloseRepository {
+	if m.ClosingRepo == nil {
+		return openMonthsRepository{}
+	}
+	return m.ClosingRepo
+}
+
+// SavingRepository falls back to a repository without goals.
+func (m *MockUnitOfWork) SavingRepository() saving.GoalRepository {
+	if m.SavingRepo == nil {
+		return noGoalsRepository{}
+	}
+	return m.SavingRepo
+}
+
+// AccountRepository falls back to a repository without accounts.
+func (m *MockUnitOfWork) AccountRepository() account.AccountRepository {
+	if m.AccountRepo == nil {
+		return noAccountsRepository{}
+	}
+	return m.AccountRepo
+}
+
+func (m *MockUnitOfWork) LoanRepository() loan.LoanRepository {
+	return m.LoanRepo
+}
+
+func (m *MockUnitOfWork) AssetRepository() networth.AssetRepository {
+	return m.AssetRepo
+}
+
+// AlertRepository falls back to a repository without thresholds, so saving
+// an expense never notifies.
+func (m *MockUnitOfWork) AlertRepository() alert.AlertRepository {
+	if m.AlertRepo == nil {
+		return noAlertsRepository{}
+	}
+	return m.AlertRepo
+}
+
 func (m *MockUnitOfWork) Begin(ctx context.Context) (domain.UnitOfWork, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
@@ -90,6 +171,141 @@ func (m *MockUserRepository) ExistsByUsername(ctx context.Context, username iden
 	return args.Bool(0), args.Error(1)
 }
 
+// MockPasswordResetRepository is a test double for identity.PasswordResetRepository.
+type MockPasswordResetRepository struct {
+	mock.Mock
+}
+
+func (m *MockPasswordResetRepository) Save(ctx context.Context, reset identity.PasswordReset) error {
+	args := m.Called(ctx, reset)
+	return args.Error(0)
+}
+
+func (m *MockPasswordResetRepository) FindByTokenHash(ctx context.Context, tokenHash string) (identity.PasswordReset, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.Get(0).(identity.PasswordReset), args.Error(1)
+}
+
+func (m *MockPasswordResetRepository) DeleteByUserID(ctx context.Context, userID identity.ID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// MockTwoFactorRepository is a test double for identity.TwoFactorRepository.
+type MockTwoFactorRepository struct {
+	mock.Mock
+}
+
+func (m *MockTwoFactorRepository) Save(ctx context.Context, twoFactor identity.TwoFactor) error {
+	args := m.Called(ctx, twoFactor)
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorRepository) FindByUserID(ctx context.Context, userID identity.ID) (identity.TwoFactor, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(identity.TwoFactor), args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) DeleteByUserID(ctx context.Context, userID identity.ID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// MockPasskeyRepository is a test double for identity.PasskeyRepository.
+type MockPasskeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockPasskeyRepository) Save(ctx context.Context, passkey identity.Passkey) error {
+	args := m.Called(ctx, passkey)
+	return args.Error(0)
+}
+
+func (m *MockPasskeyRepository) FindByCredentialID(ctx context.Context, credentialID []byte) (identity.Passkey, error) {
+	args := m.Called(ctx, credentialID)
+	return args.Get(0).(identity.Passkey), args.Error(1)
+}
+
+func (m *MockPasskeyRepository) FindByUserID(ctx context.Context, userID identity.ID) ([]identity.Passkey, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]identity.Passkey), args.Error(1)
+}
+
+func (m *MockPasskeyRepository) Delete(ctx context.Context, userID identity.ID, id identity.ID) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+// MockUserSessionRepository is a test double for
+// identity.UserSessionRepository.
+type MockUserSessionRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserSessionRepository) Save(ctx context.Context, session identity.UserSession) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
+func (m *MockUserSessionRepository) FindByID(ctx context.Context, userID identity.ID, id identity.ID) (identity.UserSession, error) {
+	args := m.Called(ctx, userID, id)
+	return args.Get(0).(identity.UserSession), args.Error(1)
+}
+
+func (m *MockUserSessionRepository) FindByUserID(ctx context.Context, userID identity.ID) ([]identity.UserSession, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]identity.UserSession), args.Error(1)
+}
+
+func (m *MockUserSessionRepository) DeleteSeenBefore(ctx context.Context, userID identity.ID, before time.Time) error {
+	args := m.Called(ctx, userID, before)
+	return args.Error(0)
+}
+
+// MockInviteRepository is a test double for identity.InviteRepository.
+type MockInviteRepository struct {
+	mock.Mock
+}
+
+func (m *MockInviteRepository) Save(ctx context.Context, invite identity.Invite) error {
+	args := m.Called(ctx, invite)
+	return args.Error(0)
+}
+
+func (m *MockInviteRepository) FindByCodeHash(ctx context.Context, codeHash string) (identity.Invite, error) {
+	args := m.Called(ctx, codeHash)
+	return args.Get(0).(identity.Invite), args.Error(1)
+}
+
+func (m *MockInviteRepository) FindAll(ctx context.Context) ([]identity.Invite, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]identity.Invite), args.Error(1)
+}
+
+func (m *MockInviteRepository) Delete(ctx context.Context, id identity.ID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// MockMailer is a test double for mail.Mailer.
+type MockMailer struct {
+	mock.Mock
+}
+
+func (m *MockMailer) Send(ctx context.Context, msg mail.Message) error {
+	args := m.Called(ctx, msg)
+	return args.Error(0)
+}
+
 // MockIncomeRepository is a test double for income.IncomeRepository.
 type MockIncomeRepository struct {
 	mock.Mock
@@ -177,6 +393,14 @@ func (m *MockGroupRepository) DeleteCategory(ctx context.Context, id tracking.ID
 	return args.Error(0)
 }
 
+// newExpenseCountRepository returns an expense repository that reports
+// counts for the expenses of any categories, none by default.
+func newExpenseCountRepository(counts ...expense.MonthlyCount) *MockExpenseRepository {
+	repo := &MockExpenseRepository{}
+	repo.On("CountByCategoriesPerMonth", mock.Anything, mock.Anything, mock.Anything).Return(counts, nil)
+	return repo
+}
+
 // MockExpenseRepository is a test double for expense.ExpenseRepository.
 type MockExpenseRepository struct {
 	mock.Mock
@@ -221,6 +445,24 @@ func (m *MockExpenseRepository) ReassignCategoryFromMonth(ctx context.Context, u
 	return args.Error(0)
 }
 
+func (m *MockExpenseRepository) ReassignCategory(ctx context.Context, userID expense.ID, fromCategoryID expense.ID, toCategoryID expense.ID) error {
+	args := m.Called(ctx, userID, fromCategoryID, toCategoryID)
+	return args.Error(0)
+}
+
+func (m *MockExpenseRepository) CountByCategoriesPerMonth(ctx context.Context, userID expense.ID, categoryIDs []expense.ID) ([]expense.MonthlyCount, error) {
+	args := m.Called(ctx, userID, categoryIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]expense.MonthlyCount), args.Error(1)
+}
+
+func (m *MockExpenseRepository) DeleteByCategoryFromMonth(ctx context.Context, userID expense.ID, categoryID expense.ID, month string) error {
+	args := m.Called(ctx, userID, categoryID, month)
+	return args.Error(0)
+}
+
 func (m *MockExpenseRepository) Delete(ctx context.Context, id expense.ID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -230,3 +472,355 @@ func (m *MockExpenseRepository) Total(ctx context.Context, userID expense.ID, mo
 	args := m.Called(ctx, userID, month)
 	return args.Get(0).(money.Money), args.Error(1)
 }
+
+// MockClosingRepository is a test double for closing.MonthCloseRepository.
+type MockClosingRepository struct {
+	mock.Mock
+}
+
+func (m *MockClosingRepository) Save(ctx context.Context, monthClose closing.MonthClose) error {
+	args := m.Called(ctx, monthClose)
+	return args.Error(0)
+}
+
+func (m *MockClosingRepository) FindByUserIDAndMonth(ctx context.Context, userID closing.ID, month string) (closing.MonthClose, error) {
+	args := m.Called(ctx, userID, month)
+	return args.Get(0).(closing.MonthClose), args.Error(1)
+}
+
+// newClosedMonthsRepository returns a closing repository where only the given
+// months are closed.
+func newClosedMonthsRepository(months ...string) *MockClosingRepository {
+	repo := &MockClosingRepository{}
+	for _, month := range months {
+		repo.On("IsClosed", mock.Anything, mock.Anything, month).Return(true, nil)
+	}
+	repo.On("IsClosed", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	return repo
+}
+
+func (m *MockClosingRepository) IsClosed(ctx context.Context, userID closing.ID, month string) (bool, error) {
+	args := m.Called(ctx, userID, month)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockClosingRepository) Delete(ctx context.Context, userID closing.ID, month string) error {
+	args := m.Called(ctx, userID, month)
+	return args.Error(0)
+}
+
+type openMonthsRepository struct{}
+
+func (openMonthsRepository) Save(context.Context, closing.MonthClose) error {
+	return nil
+}
+
+func (openMonthsRepository) FindByUserIDAndMonth(context.Context, closing.ID, string) (closing.MonthClose, error) {
+	return closing.MonthClose{}, closing.ErrMonthNotClosed
+}
+
+func (openMonthsRepository) IsClosed(context.Context, closing.ID, string) (bool, error) {
+	return false, nil
+}
+
+func (openMonthsRepository) Delete(context.Context, closing.ID, string) error {
+	return closing.ErrMonthNotClosed
+}
+
+// MockSavingRepository is a test double for saving.GoalRepository.
+type MockSavingRepository struct {
+	mock.Mock
+}
+
+func (m *MockSavingRepository) Save(ctx context.Context, goal saving.Goal) error {
+	args := m.Called(ctx, goal)
+	return args.Error(0)
+}
+
+func (m *MockSavingRepository) FindByID(ctx context.Context, userID saving.ID, id saving.ID) (saving.Goal, error) {
+	args := m.Called(ctx, userID, id)
+	return args.Get(0).(saving.Goal), args.Error(1)
+}
+
+func (m *MockSavingRepository) FindByUserID(ctx context.Context, userID saving.ID) ([]saving.Goal, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]saving.Goal), args.Error(1)
+}
+
+func (m *MockSavingRepository) Delete(ctx context.Context, userID saving.ID, id saving.ID) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+type noGoalsRepository struct{}
+
+func (noGoalsRepository) Save(context.Context, saving.Goal) error {
+	return nil
+}
+
+func (noGoalsRepository) FindByID(context.Context, saving.ID, saving.ID) (saving.Goal, error) {
+	return saving.Goal{}, saving.ErrGoalNotFound
+}
+
+func (noGoalsRepository) FindByUserID(context.Context, saving.ID) ([]saving.Goal, error) {
+	return []saving.Goal{}, nil
+}
+
+func (noGoalsRepository) Delete(context.Context, saving.ID, saving.ID) error {
+	return saving.ErrGoalNotFound
+}
+
+// MockAccountRepository is a test double for account.AccountRepository.
+type MockAccountRepository struct {
+	mock.Mock
+}
+
+func (m *MockAccountRepository) Save(ctx context.Context, a account.Account) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+func (m *MockAccountRepository) FindByID(ctx context.Context, userID account.ID, id account.ID) (account.Account, error) {
+	args := m.Called(ctx, userID, id)
+	return args.Get(0).(account.Account), args.Error(1)
+}
+
+func (m *MockAccountRepository) FindByUserID(ctx context.Context, userID account.ID) ([]account.Account, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]account.Account), args.Error(1)
+}
+
+func (m *MockAccountRepository) Delete(ctx context.Context, userID account.ID, id account.ID) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockAccountRepository) SaveTransfer(ctx context.Context, transfer account.Transfer) error {
+	args := m.Called(ctx, transfer)
+	return args.Error(0)
+}
+
+func (m *MockAccountRepository) DeleteTransfer(ctx context.Context, userID account.ID, id account.ID) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockAccountRepository) Entries(ctx context.Context, userID account.ID, accountID account.ID) ([]account.Entry, error) {
+	args := m.Called(ctx, userID, accountID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]account.Entry), args.Error(1)
+}
+
+func (m *MockAccountRepository) SaveReconciliation(ctx context.Context, reconciliation account.Reconciliation) error {
+	args := m.Called(ctx, reconciliation)
+	return args.Error(0)
+}
+
+type noAccountsRepository struct{}
+
+func (noAccountsRepository) Save(context.Context, account.Account) error {
+	return nil
+}
+
+func (noAccountsRepository) FindByID(context.Context, account.ID, account.ID) (account.Account, error) {
+	return account.Account{}, account.ErrAccountNotFound
+}
+
+func (noAccountsRepository) FindByUserID(context.Context, account.ID) ([]account.Account, error) {
+	return []account.Account{}, nil
+}
+
+func (noAccountsRepository) Delete(context.Context, account.ID, account.ID) error {
+	return account.ErrAccountNotFound
+}
+
+func (noAccountsRepository) SaveTransfer(context.Context, account.Transfer) error {
+	return nil
+}
+
+func (noAccountsRepository) DeleteTransfer(context.Context, account.ID, account.ID) error {
+	return account.ErrTransferNotFound
+}
+
+func (noAccountsRepository) Entries(context.Context, account.ID, account.ID) ([]account.Entry, error) {
+	return nil, account.ErrAccountNotFound
+}
+
+func (noAccountsRepository) SaveReconciliation(context.Context, account.Reconciliation) error {
+	return nil
+}
+
+// MockLoanRepository is a test double for loan.LoanRepository.
+type MockLoanRepository struct {
+	mock.Mock
+}
+
+func (m *MockLoanRepository) Save(ctx context.Context, l loan.Loan) error {
+	args := m.Called(ctx, l)
+	return args.Error(0)
+}
+
+func (m *MockLoanRepository) FindByID(ctx context.Context, userID loan.ID, id loan.ID) (loan.Loan, error) {
+	args := m.Called(ctx, userID, id)
+	return args.Get(0).(loan.Loan), args.Error(1)
+}
+
+func (m *MockLoanRepository) FindByUserID(ctx context.Context, userID loan.ID) ([]loan.Loan, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]loan.Loan), args.Error(1)
+}
+
+func (m *MockLoanRepository) Delete(ctx context.Context, userID loan.ID, id loan.ID) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockLoanRepository) Payments(ctx context.Context, userID loan.ID, categoryID loan.ID) ([]loan.Payment, error) {
+	args := m.Called(ctx, userID, categoryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]loan.Payment), args.Error(1)
+}
+
+// MockAssetRepository is a test double for networth.AssetRepository.
+type MockAssetRepository struct {
+	mock.Mock
+}
+
+func (m *MockAssetRepository) Save(ctx context.Context, asset networth.Asset) error {
+	args := m.Called(ctx, asset)
+	return args.Error(0)
+}
+
+func (m *MockAssetRepository) FindByID(ctx context.Context, userID networth.ID, id networth.ID) (networth.Asset, error) {
+	args := m.Called(ctx, userID, id)
+	return args.Get(0).(networth.Asset), args.Error(1)
+}
+
+func (m *MockAssetRepository) FindByUserID(ctx context.Context, userID networth.ID) ([]networth.Asset, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]networth.Asset), args.Error(1)
+}
+
+func (m *MockAssetRepository) Delete(ctx context.Context, userID networth.ID, id networth.ID) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockAssetRepository) SaveValuation(ctx context.Context, valuation networth.Valuation) error {
+	args := m.Called(ctx, valuation)
+	return args.Error(0)
+}
+
+func (m *MockAssetRepository) DeleteValuation(ctx context.Context, userID networth.ID, assetID networth.ID, month string) error {
+	args := m.Called(ctx, userID, assetID, month)
+	return args.Error(0)
+}
+
+func (m *MockAssetRepository) Valuations(ctx context.Context, userID networth.ID) ([]networth.Valuation, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]networth.Valuation), args.Error(1)
+}
+
+// MockAlertRepository is a test double for alert.AlertRepository.
+type MockAlertRepository struct {
+	mock.Mock
+}
+
+func (m *MockAlertRepository) Thresholds(ctx context.Context, userID alert.ID) (map[alert.ID][]alert.Threshold, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[alert.ID][]alert.Threshold), args.Error(1)
+}
+
+func (m *MockAlertRepository) SaveThresholds(ctx context.Context, categoryID alert.ID, thresholds []alert.Threshold) error {
+	args := m.Called(ctx, categoryID, thresholds)
+	return args.Error(0)
+}
+
+func (m *MockAlertRepository) ForkCategory(ctx context.Context, fromCategoryID alert.ID, toCategoryID alert.ID, month string) error {
+	args := m.Called(ctx, fromCategoryID, toCategoryID, month)
+	return args.Error(0)
+}
+
+func (m *MockAlertRepository) SaveNotification(ctx context.Context, notification alert.Notification) (bool, error) {
+	args := m.Called(ctx, notification)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAlertRepository) Notifications(ctx context.Context, userID alert.ID, limit int) ([]alert.Notification, error) {
+	args := m.Called(ctx, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]alert.Notification), args.Error(1)
+}
+
+func (m *MockAlertRepository) CountUnread(ctx context.Context, userID alert.ID) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockAlertRepository) MarkRead(ctx context.Context, userID alert.ID, id alert.ID, at time.Time) error {
+	args := m.Called(ctx, userID, id, at)
+	return args.Error(0)
+}
+
+func (m *MockAlertRepository) MarkAllRead(ctx context.Context, userID alert.ID, at time.Time) error {
+	args := m.Called(ctx, userID, at)
+	return args.Error(0)
+}
+
+type noAlertsRepository struct{}
+
+func (noAlertsRepository) Thresholds(context.Context, alert.ID) (map[alert.ID][]alert.Threshold, error) {
+	return map[alert.ID][]alert.Threshold{}, nil
+}
+
+func (noAlertsRepository) SaveThresholds(context.Context, alert.ID, []alert.Threshold) error {
+	return nil
+}
+
+func (noAlertsRepository) ForkCategory(context.Context, alert.ID, alert.ID, string) error {
+	return nil
+}
+
+func (noAlertsRepository) SaveNotification(context.Context, alert.Notification) (bool, error) {
+	return false, nil
+}
+
+func (noAlertsRepository) Notifications(context.Context, alert.ID, int) ([]alert.Notification, error) {
+	return []alert.Notification{}, nil
+}
+
+func (noAlertsRepository) CountUnread(context.Context, alert.ID) (int, error) {
+	return 0, nil
+}
+
+func (noAlertsRepository) MarkRead(context.Context, alert.ID, alert.ID, time.Time) error {
+	return alert.ErrNotificationNotFound
+}
+
+func (noAlertsRepository) MarkAllRead(context.Context, alert.ID, time.Time) error {
+	return nil
+}
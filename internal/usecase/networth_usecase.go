@@ -0,0 +1,400 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+
+	"github.com/madalinpopa/gocost-web/internal/domain"
+	"github.com/madalinpopa/gocost-web/internal/domain/account"
+	"github.com/madalinpopa/gocost-web/internal/domain/loan"
+	"github.com/madalinpopa/gocost-web/internal/domain/networth"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+)
+
+const (
+	NetWorthSourceAsset   = "asset"
+	NetWorthSourceAccount = "account"
+	NetWorthSourceLoan    = "loan"
+)
+
+type NetWorthUseCaseImpl struct {
+	uow    domain.UnitOfWork
+	logger *slog.Logger
+}
+
+func NewNetWorthUseCase(uow domain.UnitOfWork, logger *slog.Logger) NetWorthUseCaseImpl {
+	return NetWorthUseCaseImpl{
+		uow:    uow,
+		logger: logger,
+	}
+}
+
+func (u NetWorthUseCaseImpl) CreateAsset(ctx context.Context, req *CreateAssetRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	uID, err := identifier.ParseID(req.UserID)
+	if err != nil {
+		return err
+	}
+
+	name, kind, err := parseAssetFields(req.Name, req.Kind)
+	if err != nil {
+		return err
+	}
+
+	id, err := identifier.NewID()
+	if err != nil {
+		return err
+	}
+
+	asset, err := networth.NewAsset(id, uID, name, kind)
+	if err != nil {
+		return err
+	}
+
+	return u.save(ctx, asset)
+}
+
+func (u NetWorthUseCaseImpl) UpdateAsset(ctx context.Context, req *UpdateAssetRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	asset, err := u.findAsset(ctx, req.UserID, req.ID)
+	if err != nil {
+		return err
+	}
+
+	name, kind, err := parseAssetFields(req.Name, req.Kind)
+	if err != nil {
+		return err
+	}
+
+	if err := asset.Update(name, kind); err != nil {
+		return err
+	}
+
+	return u.save(ctx, asset)
+}
+
+// DeleteAsset removes the asset with every value recorded for it.
+func (u NetWorthUseCaseImpl) DeleteAsset(ctx context.Context, userID string, id string) error {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return err
+	}
+
+	assetID, err := identifier.ParseID(id)
+	if err != nil {
+		return networth.ErrAssetNotFound
+	}
+
+	return u.uow.AssetRepository().Delete(ctx, uID, assetID)
+}
+
+// RecordValuation sets the value of the asset at the end of the month,
+// replacing the value already recorded for that month.
+func (u NetWorthUseCaseImpl) RecordValuation(ctx context.Context, req *RecordValuationRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	asset, err := u.findAsset(ctx, req.UserID, req.AssetID)
+	if err != nil {
+		return err
+	}
+
+	value, err := money.NewFromFloat(req.Value, req.Currency)
+	if err != nil {
+		return err
+	}
+
+	valuation, err := networth.NewValuation(asset.ID, req.Month, value)
+	if err != nil {
+		return err
+	}
+
+	return u.saveValuation(ctx, valuation)
+}
+
+func (u NetWorthUseCaseImpl) RemoveValuation(ctx context.Context, userID string, assetID string, month string) error {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return err
+	}
+
+	aID, err := identifier.ParseID(assetID)
+	if err != nil {
+		return networth.ErrValuationNotFound
+	}
+
+	return u.uow.AssetRepository().DeleteValuation(ctx, uID, aID, month)
+}
+
+// Summary works out the net worth at the end of every month of the range.
+// Accounts count with their balance on the last day of the month, on the
+// liability side when it is negative. Loans count with the balance left after
+// that month's payment from the month of their first payment on. Assets
+// entered by hand count with their latest value recorded by then.
+func (u NetWorthUseCaseImpl) Summary(ctx context.Context, req *NetWorthRequest) (*NetWorthResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	uID, err := identifier.ParseID(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	months, err := networth.MonthRange(req.FromMonth, req.ToMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]networth.Snapshot, len(months))
+	for i, month := range months {
+		snapshots[i] = networth.NewSnapshot(month, req.Currency)
+	}
+
+	accountItems, err := u.addAccounts(ctx, uID, snapshots)
+	if err != nil {
+		return nil, err
+	}
+	assetItems, err := u.addAssets(ctx, uID, snapshots)
+	if err != nil {
+		return nil, err
+	}
+	loanItems, err := u.addLoans(ctx, uID, snapshots)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &NetWorthResponse{
+		FromMonth: req.FromMonth,
+		ToMonth:   req.ToMonth,
+		Months:    make([]NetWorthPointResponse, 0, len(snapshots)),
+		Items:     append(append(accountItems, assetItems...), loanItems...),
+	}
+	for _, s := range snapshots {
+		resp.Months = append(resp.Months, NetWorthPointResponse{
+			Month:            s.Month,
+			AssetsCents:      s.Assets.Cents(),
+			LiabilitiesCents: s.Liabilities.Cents(),
+			NetWorthCents:    s.NetWorth().Cents(),
+		})
+	}
+	return resp, nil
+}
+
+func (u NetWorthUseCaseImpl) addAccounts(ctx context.Context, userID identifier.ID, snapshots []networth.Snapshot) ([]NetWorthItemResponse, error) {
+	repo := u.uow.AccountRepository()
+	accounts, err := repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]NetWorthItemResponse, 0, len(accounts))
+	for _, a := range accounts {
+		entries, err := repo.Entries(ctx, userID, a.ID)
+		if err != nil {
+			return nil, err
+		}
+		ledger := account.NewLedger(a, entries)
+
+		var balance money.Money
+		for i := range snapshots {
+			day, err := networth.LastDay(snapshots[i].Month)
+			if err != nil {
+				return nil, err
+			}
+			balance = ledger.BalanceAt(day)
+			if err := snapshots[i].Add(balance, false); err != nil {
+				return nil, err
+			}
+		}
+
+		isNegative, _ := balance.IsNegative()
+		items = append(items, NetWorthItemResponse{
+			ID:         a.ID.String(),
+			Name:       a.Name.Value(),
+			Source:     NetWorthSourceAccount,
+			Kind:       a.Type.Value(),
+			Liability:  isNegative,
+			ValueCents: balance.Cents(),
+			Valued:     true,
+		})
+	}
+	return items, nil
+}
+
+func (u NetWorthUseCaseImpl) addAssets(ctx context.Context, userID identifier.ID, snapshots []networth.Snapshot) ([]NetWorthItemResponse, error) {
+	repo := u.uow.AssetRepository()
+	assets, err := repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	valuations, err := repo.Valuations(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	byAsset := make(map[identifier.ID][]networth.Valuation, len(assets))
+	for _, v := range valuations {
+		byAsset[v.AssetID] = append(byAsset[v.AssetID], v)
+	}
+
+	items := make([]NetWorthItemResponse, 0, len(assets))
+	for _, asset := range assets {
+		assetValuations := byAsset[asset.ID]
+		for i := range snapshots {
+			v, ok := networth.ValueAt(assetValuations, snapshots[i].Month)
+			if !ok {
+				continue
+			}
+			if err := snapshots[i].Add(v.Value, asset.Kind.IsLiability()); err != nil {
+				return nil, err
+			}
+		}
+
+		item := NetWorthItemResponse{
+			ID:         asset.ID.String(),
+			Name:       asset.Name.Value(),
+			Source:     NetWorthSourceAsset,
+			Kind:       asset.Kind.Value(),
+			Liability:  asset.Kind.IsLiability(),
+			Valuations: mapValuationsToResponse(assetValuations),
+		}
+		if v, ok := networth.ValueAt(assetValuations, snapshots[len(snapshots)-1].Month); ok {
+			item.ValueCents = v.Value.Cents()
+			item.Valued = true
+			item.ValuedMonth = v.Month
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (u NetWorthUseCaseImpl) addLoans(ctx context.Context, userID identifier.ID, snapshots []networth.Snapshot) ([]NetWorthItemResponse, error) {
+	loans, err := u.uow.LoanRepository().FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]NetWorthItemResponse, 0, len(loans))
+	for i := range loans {
+		l := &loans[i]
+		payments, err := loanPayments(ctx, u.uow, l)
+		if err != nil {
+			return nil, err
+		}
+		schedule := l.Schedule(loan.Extra{OneOff: l.Overpayments(payments)})
+
+		item := NetWorthItemResponse{
+			ID:        l.ID.String(),
+			Name:      l.Name.Value(),
+			Source:    NetWorthSourceLoan,
+			Kind:      NetWorthSourceLoan,
+			Liability: true,
+		}
+		for n := range snapshots {
+			if snapshots[n].Month < l.StartMonth {
+				continue
+			}
+			balance := schedule.BalanceAfter(l.Principal, snapshots[n].Month)
+			if err := snapshots[n].Add(balance, true); err != nil {
+				return nil, err
+			}
+			item.ValueCents = balance.Cents()
+			item.Valued = true
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (u NetWorthUseCaseImpl) findAsset(ctx context.Context, userID string, id string) (*networth.Asset, error) {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	assetID, err := identifier.ParseID(id)
+	if err != nil {
+		return nil, networth.ErrAssetNotFound
+	}
+
+	asset, err := u.uow.AssetRepository().FindByID(ctx, uID, assetID)
+	if err != nil {
+		return nil, err
+	}
+	return &asset, nil
+}
+
+func (u NetWorthUseCaseImpl) save(ctx context.Context, asset *networth.Asset) error {
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := txUOW.AssetRepository().Save(ctx, *asset); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func (u NetWorthUseCaseImpl) saveValuation(ctx context.Context, valuation *networth.Valuation) error {
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := txUOW.AssetRepository().SaveValuation(ctx, *valuation); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func parseAssetFields(name string, kind string) (networth.NameVO, networth.Kind, error) {
+	nameVO, err := networth.NewNameVO(name)
+	if err != nil {
+		return networth.NameVO{}, "", err
+	}
+
+	kindVO, err := networth.NewKind(kind)
+	if err != nil {
+		return networth.NameVO{}, "", err
+	}
+
+	return nameVO, kindVO, nil
+}
+
+// mapValuationsToResponse lists the valuations, the latest first.
+func mapValuationsToResponse(valuations []networth.Valuation) []AssetValuationResponse {
+	responses := make([]AssetValuationResponse, 0, len(valuations))
+	for _, v := range valuations {
+		responses = append(responses, AssetValuationResponse{Month: v.Month, ValueCents: v.Value.Cents()})
+	}
+	sort.Slice(responses, func(i, j int) bool {
+		return responses[i].Month > responses[j].Month
+	})
+	return responses
+}
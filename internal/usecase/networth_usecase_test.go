@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/account"
+	"github.com/madalinpopa/gocost-web/internal/domain/loan"
+	"github.com/madalinpopa/gocost-web/internal/domain/networth"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAsset(t *testing.T, userID identifier.ID, name string, kind networth.Kind) networth.Asset {
+	t.Helper()
+
+	id, _ := identifier.NewID()
+	nameVO, err := networth.NewNameVO(name)
+	require.NoError(t, err)
+
+	asset, err := networth.NewAsset(id, userID, nameVO, kind)
+	require.NoError(t, err)
+	return *asset
+}
+
+func newTestValuation(assetID identifier.ID, month string, cents int64) networth.Valuation {
+	value, _ := money.New(cents, "USD")
+	return networth.Valuation{AssetID: assetID, Month: month, Value: value}
+}
+
+// newTestNetWorthUseCase returns the use case over the given repositories
+// and the transactional asset repository that records changes.
+func newTestNetWorthUseCase(uow *MockUnitOfWork) (NetWorthUseCaseImpl, *MockAssetRepository) {
+	txRepo := &MockAssetRepository{}
+	txUOW := &MockUnitOfWork{AssetRepo: txRepo}
+	txUOW.On("Commit").Return(nil).Maybe()
+	txUOW.On("Rollback").Return(nil).Maybe()
+
+	uow.On("Begin", mock.Anything).Return(txUOW, nil).Maybe()
+
+	return NewNetWorthUseCase(uow, slog.New(slog.NewTextHandler(io.Discard, nil))), txRepo
+}
+
+func TestNetWorthUseCase_CreateAsset(t *testing.T) {
+	t.Run("saves the asset", func(t *testing.T) {
+		userID, _ := identifier.NewID()
+		usecase, txRepo := newTestNetWorthUseCase(&MockUnitOfWork{AssetRepo: &MockAssetRepository{}})
+		txRepo.On("Save", mock.Anything, mock.MatchedBy(func(a networth.Asset) bool {
+			return a.UserID == userID && a.Name.Value() == "House" && a.Kind == networth.KindProperty
+		})).Return(nil)
+
+		err := usecase.CreateAsset(context.Background(), &CreateAssetRequest{UserID: userID.String(), Name: "House", Kind: "property"})
+
+		require.NoError(t, err)
+		txRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects an unknown kind", func(t *testing.T) {
+		userID, _ := identifier.NewID()
+		usecase, txRepo := newTestNetWorthUseCase(&MockUnitOfWork{AssetRepo: &MockAssetRepository{}})
+
+		err := usecase.CreateAsset(context.Background(), &CreateAssetRequest{UserID: userID.String(), Name: "Boat", Kind: "boat"})
+
+		assert.ErrorIs(t, err, networth.ErrInvalidKind)
+		txRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestNetWorthUseCase_RecordValuation(t *testing.T) {
+	userID, _ := identifier.NewID()
+	asset := newTestAsset(t, userID, "Brokerage", networth.KindInvestment)
+	repo := &MockAssetRepository{}
+	repo.On("FindByID", mock.Anything, userID, asset.ID).Return(asset, nil)
+
+	t.Run("saves the value in minor units", func(t *testing.T) {
+		usecase, txRepo := newTestNetWorthUseCase(&MockUnitOfWork{AssetRepo: repo})
+		txRepo.On("SaveValuation", mock.Anything, mock.MatchedBy(func(v networth.Valuation) bool {
+			return v.AssetID == asset.ID && v.Month == "2024-03" && v.Value.Cents() == 1250050
+		})).Return(nil)
+
+		err := usecase.RecordValuation(context.Background(), &RecordValuationRequest{
+			UserID:   userID.String(),
+			Currency: "USD",
+			AssetID:  asset.ID.String(),
+			Month:    "2024-03",
+			Value:    12500.50,
+		})
+
+		require.NoError(t, err)
+		txRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects a negative value", func(t *testing.T) {
+		usecase, _ := newTestNetWorthUseCase(&MockUnitOfWork{AssetRepo: repo})
+
+		err := usecase.RecordValuation(context.Background(), &RecordValuationRequest{
+			UserID:   userID.String(),
+			Currency: "USD",
+			AssetID:  asset.ID.String(),
+			Month:    "2024-03",
+			Value:    -1,
+		})
+
+		assert.ErrorIs(t, err, networth.ErrInvalidValue)
+	})
+}
+
+func TestNetWorthUseCase_Summary(t *testing.T) {
+	userID, _ := identifier.NewID()
+
+	checking := newTestAccount(t, userID, "Checking")
+	salary, _ := money.New(50000, "USD")
+	accounts := &MockAccountRepository{}
+	accounts.On("FindByUserID", mock.Anything, userID).Return([]account.Account{checking}, nil)
+	accounts.On("Entries", mock.Anything, userID, checking.ID).Return([]account.Entry{
+		{Kind: account.EntryIncome, Date: time.Date(2024, time.February, 10, 0, 0, 0, 0, time.UTC), Amount: salary},
+	}, nil)
+
+	house := newTestAsset(t, userID, "House", networth.KindProperty)
+	debt := newTestAsset(t, userID, "Family loan", networth.KindDebt)
+	assets := &MockAssetRepository{}
+	assets.On("FindByUserID", mock.Anything, userID).Return([]networth.Asset{house, debt}, nil)
+	assets.On("Valuations", mock.Anything, userID).Return([]networth.Valuation{
+		newTestValuation(house.ID, "2024-02", 20000000),
+		newTestValuation(debt.ID, "2023-06", 100000),
+	}, nil)
+
+	laptop := newTestLoan(t, userID, identifier.ID{})
+	loans := &MockLoanRepository{}
+	loans.On("FindByUserID", mock.Anything, userID).Return([]loan.Loan{laptop}, nil)
+
+	usecase, _ := newTestNetWorthUseCase(&MockUnitOfWork{AccountRepo: accounts, AssetRepo: assets, LoanRepo: loans})
+
+	resp, err := usecase.Summary(context.Background(), &NetWorthRequest{
+		UserID:    userID.String(),
+		Currency:  "USD",
+		FromMonth: "2024-01",
+		ToMonth:   "2024-03",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []NetWorthPointResponse{
+		{Month: "2024-01", AssetsCents: 10000, LiabilitiesCents: 210000, NetWorthCents: -200000},
+		{Month: "2024-02", AssetsCents: 20060000, LiabilitiesCents: 200000, NetWorthCents: 19860000},
+		{Month: "2024-03", AssetsCents: 20060000, LiabilitiesCents: 190000, NetWorthCents: 19870000},
+	}, resp.Months)
+
+	require.Len(t, resp.Items, 4)
+	assert.Equal(t, NetWorthSourceAccount, resp.Items[0].Source)
+	assert.Equal(t, int64(60000), resp.Items[0].ValueCents)
+	assert.Equal(t, "2024-02", resp.Items[1].ValuedMonth)
+	assert.True(t, resp.Items[2].Liability)
+	assert.Equal(t, NetWorthSourceLoan, resp.Items[3].Source)
+	assert.Equal(t, int64(90000), resp.Items[3].ValueCents)
+}
+
+func TestNetWorthUseCase_Summary_InvalidRange(t *testing.T) {
+	userID, _ := identifier.NewID()
+	usecase, _ := newTestNetWorthUseCase(&MockUnitOfWork{})
+
+	_, err := usecase.Summary(context.Background(), &NetWorthRequest{
+		UserID:    userID.String(),
+		Currency:  "USD",
+		FromMonth: "2024-03",
+		ToMonth:   "2024-01",
+	})
+
+	assert.ErrorIs(t, err, networth.ErrInvalidRange)
+}
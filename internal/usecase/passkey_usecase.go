@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain"
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/webauthn"
+)
+
+// passkeyRelyingPartyName names the site when the browser asks to create a
+// passkey.
+const passkeyRelyingPartyName = "GoCost"
+
+// ErrInvalidPasskey means a passkey ceremony failed: the response was not
+// for the challenge, not for this site, not signed by a registered passkey,
+// or the passkey is unknown.
+var ErrInvalidPasskey = errors.New("passkey could not be verified")
+
+type PasskeyUseCaseImpl struct {
+	uow    domain.UnitOfWork
+	logger *slog.Logger
+}
+
+func NewPasskeyUseCase(uow domain.UnitOfWork, logger *slog.Logger) PasskeyUseCaseImpl {
+	return PasskeyUseCaseImpl{
+		uow:    uow,
+		logger: logger,
+	}
+}
+
+func (u PasskeyUseCaseImpl) List(ctx context.Context, userID string) ([]PasskeyResponse, error) {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	passkeys, err := u.uow.PasskeyRepository().FindByUserID(ctx, uID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]PasskeyResponse, 0, len(passkeys))
+	for _, passkey := range passkeys {
+		responses = append(responses, mapPasskeyToResponse(passkey))
+	}
+	return responses, nil
+}
+
+// BeginRegistration returns the options to create a passkey with, leaving
+// out the passkeys the user already has so an authenticator is not
+// registered twice.
+func (u PasskeyUseCaseImpl) BeginRegistration(ctx context.Context, req *BeginPasskeyRegistrationRequest) (*PasskeyCreationResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	uID, err := identifier.ParseID(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := webauthn.NewRelyingParty(passkeyRelyingPartyName, req.Origin)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.uow.UserRepository().FindByID(ctx, uID)
+	if err != nil {
+		return nil, err
+	}
+	passkeys, err := u.uow.PasskeyRepository().FindByUserID(ctx, uID)
+	if err != nil {
+		return nil, err
+	}
+
+	exclude := make([][]byte, 0, len(passkeys))
+	for _, passkey := range passkeys {
+		exclude = append(exclude, passkey.CredentialID)
+	}
+
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		return nil, err
+	}
+
+	return &PasskeyCreationResponse{
+		Options:   rp.CreationOptions(challenge, userHandle(uID), user.Email.Value(), exclude),
+		Challenge: challenge,
+	}, nil
+}
+
+// FinishRegistration checks the new passkey answers the challenge and saves
+// it under the name the user gave it.
+func (u PasskeyUseCaseImpl) FinishRegistration(ctx context.Context, req *FinishPasskeyRegistrationRequest) (*PasskeyResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	uID, err := identifier.ParseID(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := webauthn.NewRelyingParty(passkeyRelyingPartyName, req.Origin)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := rp.VerifyRegistration(req.Challenge, req.Credential)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPasskey, err)
+	}
+
+	id, err := identifier.NewID()
+	if err != nil {
+		return nil, err
+	}
+	passkey, err := identity.NewPasskey(id, uID, req.Name, credential.ID, credential.PublicKey, credential.SignCount, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.save(ctx, *passkey); err != nil {
+		return nil, err
+	}
+
+	response := mapPasskeyToResponse(*passkey)
+	return &response, nil
+}
+
+// BeginLogin returns the options to log in with any passkey of the site.
+func (u PasskeyUseCaseImpl) BeginLogin(ctx context.Context, origin string) (*PasskeyRequestResponse, error) {
+	rp, err := webauthn.NewRelyingParty(passkeyRelyingPartyName, origin)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := webauthn.NewChallenge()
+	if err != nil {
+		return nil, err
+	}
+
+	return &PasskeyRequestResponse{
+		Options:   rp.RequestOptions(challenge),
+		Challenge: challenge,
+	}, nil
+}
+
+// FinishLogin checks the challenge was signed by a registered passkey and
+// returns its user. The authenticator verified the user with a PIN or
+// biometric, so no second factor is asked for.
+func (u PasskeyUseCaseImpl) FinishLogin(ctx context.Context, req *FinishPasskeyLoginRequest) (*UserResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	rp, err := webauthn.NewRelyingParty(passkeyRelyingPartyName, req.Origin)
+	if err != nil {
+		return nil, err
+	}
+
+	passkey, err := u.uow.PasskeyRepository().FindByCredentialID(ctx, req.Credential.RawID)
+	if err != nil {
+		if errors.Is(err, identity.ErrPasskeyNotFound) {
+			return nil, ErrInvalidPasskey
+		}
+		return nil, err
+	}
+
+	handle := req.Credential.Response.UserHandle
+	if len(handle) > 0 && !bytes.Equal(handle, userHandle(passkey.UserID)) {
+		return nil, ErrInvalidPasskey
+	}
+
+	signCount, err := rp.VerifyAssertion(req.Challenge, webauthn.Credential{
+		ID:        passkey.CredentialID,
+		PublicKey: passkey.PublicKey,
+		SignCount: passkey.SignCount,
+	}, req.Credential)
+	if err != nil {
+		if errors.Is(err, webauthn.ErrCounterRegressed) {
+			u.logger.Warn("passkey signature counter did not increase", "passkey_id", passkey.ID.String(), "user_id", passkey.UserID.String())
+		}
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPasskey, err)
+	}
+
+	passkey.Use(signCount, time.Now())
+	if err := u.save(ctx, passkey); err != nil {
+		return nil, err
+	}
+
+	user, err := u.uow.UserRepository().FindByID(ctx, passkey.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapUserToResponse(user), nil
+}
+
+// Revoke removes a passkey of the user. It can no longer log in.
+func (u PasskeyUseCaseImpl) Revoke(ctx context.Context, req *RevokePasskeyRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	uID, err := identifier.ParseID(req.UserID)
+	if err != nil {
+		return err
+	}
+	id, err := identifier.ParseID(req.PasskeyID)
+	if err != nil {
+		return identity.ErrPasskeyNotFound
+	}
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := txUOW.PasskeyRepository().Delete(ctx, uID, id); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func (u PasskeyUseCaseImpl) save(ctx context.Context, passkey identity.Passkey) error {
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := txUOW.PasskeyRepository().Save(ctx, passkey); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+// userHandle identifies the user to their authenticator. It comes back when
+// they log in with a passkey.
+func userHandle(userID identifier.ID) []byte {
+	return []byte(userID.String())
+}
+
+func mapPasskeyToResponse(passkey identity.Passkey) PasskeyResponse {
+	return PasskeyResponse{
+		ID:         passkey.ID.String(),
+		Name:       passkey.Name,
+		CreatedAt:  passkey.CreatedAt,
+		LastUsedAt: passkey.LastUsedAt,
+	}
+}
+
+var _ PasskeyUseCase = (*PasskeyUseCaseImpl)(nil)
@@ -0,0 +1,283 @@
+package usecase
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/webauthn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testPasskeyOrigin = "https://gocost.example.com"
+
+func newTestPasskeyUseCase(userRepo *MockUserRepository, passkeyRepo *MockPasskeyRepository) PasskeyUseCaseImpl {
+	txUOW := &MockUnitOfWork{UserRepo: userRepo, PasskeyRepo: passkeyRepo}
+	txUOW.On("Commit").Return(nil)
+	txUOW.On("Rollback").Return(nil)
+
+	baseUOW := &MockUnitOfWork{UserRepo: userRepo, PasskeyRepo: passkeyRepo}
+	baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+
+	return NewPasskeyUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// testAuthenticator stands in for a platform authenticator that verifies the
+// user, signing with an ES256 key.
+type testAuthenticator struct {
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	signCount    uint32
+}
+
+func newTestAuthenticator(t *testing.T) *testAuthenticator {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return &testAuthenticator{key: key, credentialID: []byte("test-credential")}
+}
+
+func (a *testAuthenticator) publicKey(t *testing.T) []byte {
+	t.Helper()
+	point, err := a.key.PublicKey.Bytes()
+	require.NoError(t, err)
+	key, err := cbor.Marshal(map[int]any{1: 2, 3: -7, -1: 1, -2: point[1:33], -3: point[33:]})
+	require.NoError(t, err)
+	return key
+}
+
+func (a *testAuthenticator) authenticatorData(flags byte) []byte {
+	rpIDHash := sha256.Sum256([]byte("gocost.example.com"))
+	data := append(rpIDHash[:], flags)
+	return binary.BigEndian.AppendUint32(data, a.signCount)
+}
+
+func clientDataJSON(t *testing.T, ceremony string, challenge []byte) []byte {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{
+		"type":      ceremony,
+		"challenge": webauthn.Bytes(challenge),
+		"origin":    testPasskeyOrigin,
+	})
+	require.NoError(t, err)
+	return data
+}
+
+func (a *testAuthenticator) register(t *testing.T, challenge []byte) webauthn.RegistrationResponse {
+	t.Helper()
+	authData := a.authenticatorData(0x45)
+	authData = append(authData, make([]byte, 16)...)
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(a.credentialID)))
+	authData = append(authData, a.credentialID...)
+	authData = append(authData, a.publicKey(t)...)
+
+	attestation, err := cbor.Marshal(map[string]any{"fmt": "none", "attStmt": map[string]any{}, "authData": authData})
+	require.NoError(t, err)
+
+	var resp webauthn.RegistrationResponse
+	resp.RawID = a.credentialID
+	resp.Response.ClientDataJSON = clientDataJSON(t, "webauthn.create", challenge)
+	resp.Response.AttestationObject = attestation
+	return resp
+}
+
+func (a *testAuthenticator) assert(t *testing.T, challenge []byte, userHandle []byte) webauthn.AssertionResponse {
+	t.Helper()
+	a.signCount++
+	authData := a.authenticatorData(0x05)
+	clientData := clientDataJSON(t, "webauthn.get", challenge)
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	require.NoError(t, err)
+
+	var resp webauthn.AssertionResponse
+	resp.RawID = a.credentialID
+	resp.Response.ClientDataJSON = clientData
+	resp.Response.AuthenticatorData = authData
+	resp.Response.Signature = signature
+	resp.Response.UserHandle = userHandle
+	return resp
+}
+
+func (a *testAuthenticator) passkey(t *testing.T, userID identifier.ID) identity.Passkey {
+	t.Helper()
+	id, err := identifier.NewID()
+	require.NoError(t, err)
+	passkey, err := identity.NewPasskey(id, userID, "Laptop", a.credentialID, a.publicKey(t), a.signCount, time.Now())
+	require.NoError(t, err)
+	return *passkey
+}
+
+func TestPasskeyUseCase_BeginRegistration(t *testing.T) {
+	user := newTestUser(t, "alice@example.com", "alice", "$2a$12$abcdefghijklmnopqrstuuMtXbjLYe6Xq/Xq8ZuNnF9Jk9r4dvmWe")
+	existing := newTestAuthenticator(t).passkey(t, user.ID)
+
+	userRepo := &MockUserRepository{}
+	userRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+	passkeyRepo := &MockPasskeyRepository{}
+	passkeyRepo.On("FindByUserID", mock.Anything, user.ID).Return([]identity.Passkey{existing}, nil)
+	usecase := newTestPasskeyUseCase(userRepo, passkeyRepo)
+
+	resp, err := usecase.BeginRegistration(context.Background(), &BeginPasskeyRegistrationRequest{
+		UserID: user.ID.String(),
+		Origin: testPasskeyOrigin,
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, resp.Challenge, 32)
+	assert.Equal(t, webauthn.Bytes(resp.Challenge), resp.Options.Challenge)
+	assert.Equal(t, "gocost.example.com", resp.Options.RP.ID)
+	assert.Equal(t, "alice@example.com", resp.Options.User.Name)
+	assert.Equal(t, webauthn.Bytes(user.ID.String()), resp.Options.User.ID)
+	require.Len(t, resp.Options.ExcludeCredentials, 1)
+	assert.Equal(t, webauthn.Bytes(existing.CredentialID), resp.Options.ExcludeCredentials[0].ID)
+}
+
+func TestPasskeyUseCase_FinishRegistration(t *testing.T) {
+	user := newTestUser(t, "alice@example.com", "alice", "$2a$12$abcdefghijklmnopqrstuuMtXbjLYe6Xq/Xq8ZuNnF9Jk9r4dvmWe")
+	challenge := []byte("registration-challenge")
+
+	t.Run("saves the verified passkey", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t)
+		passkeyRepo := &MockPasskeyRepository{}
+		passkeyRepo.On("Save", mock.Anything, mock.MatchedBy(func(p identity.Passkey) bool {
+			return p.UserID == user.ID && p.Name == "Laptop" && string(p.CredentialID) == "test-credential"
+		})).Return(nil)
+		usecase := newTestPasskeyUseCase(&MockUserRepository{}, passkeyRepo)
+
+		resp, err := usecase.FinishRegistration(context.Background(), &FinishPasskeyRegistrationRequest{
+			UserID:     user.ID.String(),
+			Origin:     testPasskeyOrigin,
+			Name:       "Laptop",
+			Challenge:  challenge,
+			Credential: authenticator.register(t, challenge),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Laptop", resp.Name)
+		passkeyRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects a response to another challenge", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t)
+		passkeyRepo := &MockPasskeyRepository{}
+		usecase := newTestPasskeyUseCase(&MockUserRepository{}, passkeyRepo)
+
+		_, err := usecase.FinishRegistration(context.Background(), &FinishPasskeyRegistrationRequest{
+			UserID:     user.ID.String(),
+			Origin:     testPasskeyOrigin,
+			Name:       "Laptop",
+			Challenge:  challenge,
+			Credential: authenticator.register(t, []byte("another challenge")),
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidPasskey)
+		assert.ErrorIs(t, err, webauthn.ErrChallengeMismatch)
+		passkeyRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPasskeyUseCase_FinishLogin(t *testing.T) {
+	user := newTestUser(t, "alice@example.com", "alice", "$2a$12$abcdefghijklmnopqrstuuMtXbjLYe6Xq/Xq8ZuNnF9Jk9r4dvmWe")
+	challenge := []byte("login-challenge")
+
+	t.Run("logs in and stores the signature count", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t)
+		passkey := authenticator.passkey(t, user.ID)
+		userRepo := &MockUserRepository{}
+		userRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+		passkeyRepo := &MockPasskeyRepository{}
+		passkeyRepo.On("FindByCredentialID", mock.Anything, []byte("test-credential")).Return(passkey, nil)
+		passkeyRepo.On("Save", mock.Anything, mock.MatchedBy(func(p identity.Passkey) bool {
+			return p.ID == passkey.ID && p.SignCount == 1 && p.LastUsedAt != nil
+		})).Return(nil)
+		usecase := newTestPasskeyUseCase(userRepo, passkeyRepo)
+
+		resp, err := usecase.FinishLogin(context.Background(), &FinishPasskeyLoginRequest{
+			Origin:     testPasskeyOrigin,
+			Challenge:  challenge,
+			Credential: authenticator.assert(t, challenge, []byte(user.ID.String())),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, user.ID.String(), resp.ID)
+		passkeyRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects an unknown passkey", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t)
+		passkeyRepo := &MockPasskeyRepository{}
+		passkeyRepo.On("FindByCredentialID", mock.Anything, mock.Anything).Return(identity.Passkey{}, identity.ErrPasskeyNotFound)
+		usecase := newTestPasskeyUseCase(&MockUserRepository{}, passkeyRepo)
+
+		_, err := usecase.FinishLogin(context.Background(), &FinishPasskeyLoginRequest{
+			Origin:     testPasskeyOrigin,
+			Challenge:  challenge,
+			Credential: authenticator.assert(t, challenge, nil),
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidPasskey)
+	})
+
+	t.Run("rejects a passkey of another user", func(t *testing.T) {
+		authenticator := newTestAuthenticator(t)
+		passkeyRepo := &MockPasskeyRepository{}
+		passkeyRepo.On("FindByCredentialID", mock.Anything, mock.Anything).Return(authenticator.passkey(t, user.ID), nil)
+		usecase := newTestPasskeyUseCase(&MockUserRepository{}, passkeyRepo)
+
+		_, err := usecase.FinishLogin(context.Background(), &FinishPasskeyLoginRequest{
+			Origin:     testPasskeyOrigin,
+			Challenge:  challenge,
+			Credential: authenticator.assert(t, challenge, []byte("someone else")),
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidPasskey)
+		passkeyRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a signature by another key", func(t *testing.T) {
+		registered := newTestAuthenticator(t)
+		impostor := newTestAuthenticator(t)
+		passkeyRepo := &MockPasskeyRepository{}
+		passkeyRepo.On("FindByCredentialID", mock.Anything, mock.Anything).Return(registered.passkey(t, user.ID), nil)
+		usecase := newTestPasskeyUseCase(&MockUserRepository{}, passkeyRepo)
+
+		_, err := usecase.FinishLogin(context.Background(), &FinishPasskeyLoginRequest{
+			Origin:     testPasskeyOrigin,
+			Challenge:  challenge,
+			Credential: impostor.assert(t, challenge, nil),
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidPasskey)
+		assert.ErrorIs(t, err, webauthn.ErrInvalidSignature)
+	})
+}
+
+func TestPasskeyUseCase_Revoke(t *testing.T) {
+	user := newTestUser(t, "alice@example.com", "alice", "$2a$12$abcdefghijklmnopqrstuuMtXbjLYe6Xq/Xq8ZuNnF9Jk9r4dvmWe")
+	passkeyID, _ := identifier.NewID()
+
+	passkeyRepo := &MockPasskeyRepository{}
+	passkeyRepo.On("Delete", mock.Anything, user.ID, passkeyID).Return(identity.ErrPasskeyNotFound)
+	usecase := newTestPasskeyUseCase(&MockUserRepository{}, passkeyRepo)
+
+	err := usecase.Revoke(context.Background(), &RevokePasskeyRequest{UserID: user.ID.String(), PasskeyID: passkeyID.String()})
+	assert.ErrorIs(t, err, identity.ErrPasskeyNotFound)
+
+	err = usecase.Revoke(context.Background(), &RevokePasskeyRequest{UserID: user.ID.String(), PasskeyID: "not-an-id"})
+	assert.ErrorIs(t, err, identity.ErrPasskeyNotFound)
+}
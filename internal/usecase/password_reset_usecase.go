@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain"
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/infrastructure/mail"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/security"
+)
+
+type PasswordResetUseCaseImpl struct {
+	uow    domain.UnitOfWork
+	logger *slog.Logger
+	hasher security.PasswordHasher
+	mailer mail.Mailer
+}
+
+func NewPasswordResetUseCase(uow domain.UnitOfWork, logger *slog.Logger, h security.PasswordHasher, mailer mail.Mailer) PasswordResetUseCaseImpl {
+	return PasswordResetUseCaseImpl{
+		uow:    uow,
+		logger: logger,
+		hasher: h,
+		mailer: mailer,
+	}
+}
+
+// RequestReset emails the user with the address a link to set a new
+// password, replacing any link sent before. Nothing tells whether a user has
+// the address: the request succeeds either way, even when the email cannot be
+// sent. The mailer is expected to send in the background, so that the time
+// the request takes does not tell either.
+func (u PasswordResetUseCaseImpl) RequestReset(ctx context.Context, req *RequestPasswordResetRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	email, err := identity.NewEmailVO(req.Email)
+	if err != nil {
+		return err
+	}
+
+	user, err := u.uow.UserRepository().FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, identity.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	token, err := security.NewToken()
+	if err != nil {
+		return err
+	}
+
+	link, err := url.Parse(req.ResetURL)
+	if err != nil {
+		return fmt.Errorf("invalid reset url: %w", err)
+	}
+	link.RawQuery = url.Values{"token": {token}}.Encode()
+
+	id, err := identifier.NewID()
+	if err != nil {
+		return err
+	}
+	reset := identity.NewPasswordReset(id, user.ID, security.HashToken(token), time.Now())
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	repo := txUOW.PasswordResetRepository()
+	if err := repo.DeleteByUserID(ctx, user.ID); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+	if err := repo.Save(ctx, *reset); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	err = u.mailer.Send(ctx, mail.Message{
+		To:      user.Email.Value(),
+		Subject: "Reset your GoCost password",
+		Body: fmt.Sprintf(`Hello %s,
+
+Someone asked to reset the password of your GoCost account. Follow this link to choose a new one:
+
+%s
+
+The link works once and expires in %d minutes. If you did not ask for it, ignore this email and your password stays the same.
+`, user.Username.Value(), link.String(), int(identity.PasswordResetTTL.Minutes())),
+	})
+	if err != nil {
+		// Failing here would tell that the address has an account.
+		u.logger.Error("failed to send password reset email", "error", err)
+	}
+	return nil
+}
+
+// ResetPassword sets a new password with the token of a reset link, and
+// returns the ID of the user it belongs to.
+func (u PasswordResetUseCaseImpl) ResetPassword(ctx context.Context, req *ResetPasswordRequest) (string, error) {
+	if req == nil {
+		return "", errors.New("request cannot be nil")
+	}
+
+	if err := u.hasher.ValidatePassword(req.NewPassword); err != nil {
+		return "", err
+	}
+	if len(req.NewPassword) < minPasswordLength {
+		return "", identity.ErrPasswordTooShort
+	}
+
+	hashedPassword, err := u.hasher.HashPassword(req.NewPassword)
+	if err != nil {
+		return "", err
+	}
+
+	password, err := identity.NewPasswordVO(hashedPassword)
+	if err != nil {
+		return "", err
+	}
+
+	// The token is looked up and spent in the transaction, so that two requests
+	// with the same link cannot both set a password.
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	reset, err := txUOW.PasswordResetRepository().FindByTokenHash(ctx, security.HashToken(req.Token))
+	if err != nil {
+		_ = txUOW.Rollback()
+		return "", err
+	}
+	if err := reset.Use(time.Now()); err != nil {
+		_ = txUOW.Rollback()
+		return "", err
+	}
+
+	user, err := txUOW.UserRepository().FindByID(ctx, reset.UserID)
+	if err != nil {
+		_ = txUOW.Rollback()
+		return "", err
+	}
+	user.Password = password
+
+	if err := txUOW.PasswordResetRepository().Save(ctx, reset); err != nil {
+		_ = txUOW.Rollback()
+		return "", err
+	}
+	if err := txUOW.UserRepository().Save(ctx, user); err != nil {
+		_ = txUOW.Rollback()
+		return "", err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return "", err
+	}
+
+	return user.ID.String(), nil
+}
+
+var _ PasswordResetUseCase = (*PasswordResetUseCaseImpl)(nil)
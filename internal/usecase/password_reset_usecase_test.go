@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/infrastructure/mail"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPasswordResetUseCase(userRepo *MockUserRepository, resetRepo *MockPasswordResetRepository, mailer *MockMailer) PasswordResetUseCaseImpl {
+	txUOW := &MockUnitOfWork{UserRepo: userRepo, PasswordResetRepo: resetRepo}
+	txUOW.On("Commit").Return(nil)
+	txUOW.On("Rollback").Return(nil)
+
+	// Reset tokens are only ever read and written in the transaction.
+	baseUOW := &MockUnitOfWork{UserRepo: userRepo, PasswordResetRepo: &MockPasswordResetRepository{}}
+	baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+
+	return NewPasswordResetUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)), security.NewPasswordHasher(), mailer)
+}
+
+func TestPasswordResetUseCase_RequestReset(t *testing.T) {
+	user := newTestUser(t, "alice@example.com", "alice", "$2a$12$abcdefghijklmnopqrstuuMtXbjLYe6Xq/Xq8ZuNnF9Jk9r4dvmWe")
+
+	t.Run("stores the hash of the token and emails the link", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		userRepo.On("FindByEmail", mock.Anything, user.Email).Return(user, nil)
+		resetRepo := &MockPasswordResetRepository{}
+		resetRepo.On("DeleteByUserID", mock.Anything, user.ID).Return(nil)
+		resetRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mailer := &MockMailer{}
+		mailer.On("Send", mock.Anything, mock.Anything).Return(nil)
+		usecase := newTestPasswordResetUseCase(userRepo, resetRepo, mailer)
+
+		err := usecase.RequestReset(context.Background(), &RequestPasswordResetRequest{
+			EmailRequest: EmailRequest{Email: "alice@example.com"},
+			ResetURL:     "https://gocost.example/password/reset",
+		})
+
+		require.NoError(t, err)
+		msg := mailer.Calls[0].Arguments.Get(1).(mail.Message)
+		assert.Equal(t, "alice@example.com", msg.To)
+		link := regexp.MustCompile(`https://gocost\.example/password/reset\?token=\S+`).FindString(msg.Body)
+		require.NotEmpty(t, link)
+		u, err := url.Parse(link)
+		require.NoError(t, err)
+
+		reset := resetRepo.Calls[1].Arguments.Get(1).(identity.PasswordReset)
+		assert.Equal(t, user.ID, reset.UserID)
+		assert.Equal(t, security.HashToken(u.Query().Get("token")), reset.TokenHash)
+		assert.WithinDuration(t, time.Now().Add(identity.PasswordResetTTL), reset.ExpiresAt, time.Minute)
+		resetRepo.AssertExpectations(t)
+	})
+
+	t.Run("succeeds when the email cannot be sent", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		userRepo.On("FindByEmail", mock.Anything, user.Email).Return(user, nil)
+		resetRepo := &MockPasswordResetRepository{}
+		resetRepo.On("DeleteByUserID", mock.Anything, user.ID).Return(nil)
+		resetRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mailer := &MockMailer{}
+		mailer.On("Send", mock.Anything, mock.Anything).Return(assert.AnError)
+		usecase := newTestPasswordResetUseCase(userRepo, resetRepo, mailer)
+
+		err := usecase.RequestReset(context.Background(), &RequestPasswordResetRequest{
+			EmailRequest: EmailRequest{Email: "alice@example.com"},
+			ResetURL:     "https://gocost.example/password/reset",
+		})
+
+		require.NoError(t, err)
+		mailer.AssertExpectations(t)
+	})
+
+	t.Run("succeeds without sending anything for an unknown email", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		userRepo.On("FindByEmail", mock.Anything, mock.Anything).Return(identity.User{}, identity.ErrUserNotFound)
+		resetRepo := &MockPasswordResetRepository{}
+		mailer := &MockMailer{}
+		usecase := newTestPasswordResetUseCase(userRepo, resetRepo, mailer)
+
+		err := usecase.RequestReset(context.Background(), &RequestPasswordResetRequest{
+			EmailRequest: EmailRequest{Email: "nobody@example.com"},
+			ResetURL:     "https://gocost.example/password/reset",
+		})
+
+		require.NoError(t, err)
+		resetRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPasswordResetUseCase_ResetPassword(t *testing.T) {
+	user := newTestUser(t, "alice@example.com", "alice", "$2a$12$abcdefghijklmnopqrstuuMtXbjLYe6Xq/Xq8ZuNnF9Jk9r4dvmWe")
+	resetID, _ := identifier.NewID()
+
+	t.Run("sets the password and spends the token", func(t *testing.T) {
+		reset := identity.NewPasswordReset(resetID, user.ID, security.HashToken("token"), time.Now())
+		resetRepo := &MockPasswordResetRepository{}
+		resetRepo.On("FindByTokenHash", mock.Anything, security.HashToken("token")).Return(*reset, nil)
+		resetRepo.On("Save", mock.Anything, mock.MatchedBy(func(r identity.PasswordReset) bool {
+			return r.ID == resetID && r.UsedAt != nil
+		})).Return(nil)
+		userRepo := &MockUserRepository{}
+		userRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+		userRepo.On("Save", mock.Anything, mock.MatchedBy(func(u identity.User) bool {
+			return security.NewPasswordHasher().CheckPasswordHash("new-password", u.Password.Value())
+		})).Return(nil)
+		usecase := newTestPasswordResetUseCase(userRepo, resetRepo, &MockMailer{})
+
+		userID, err := usecase.ResetPassword(context.Background(), &ResetPasswordRequest{
+			Token:       "token",
+			NewPassword: "new-password",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, user.ID.String(), userID)
+		resetRepo.AssertExpectations(t)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects a token already used", func(t *testing.T) {
+		reset := identity.NewPasswordReset(resetID, user.ID, security.HashToken("token"), time.Now())
+		require.NoError(t, reset.Use(time.Now()))
+		resetRepo := &MockPasswordResetRepository{}
+		resetRepo.On("FindByTokenHash", mock.Anything, security.HashToken("token")).Return(*reset, nil)
+		userRepo := &MockUserRepository{}
+		usecase := newTestPasswordResetUseCase(userRepo, resetRepo, &MockMailer{})
+
+		_, err := usecase.ResetPassword(context.Background(), &ResetPasswordRequest{
+			Token:       "token",
+			NewPassword: "new-password",
+		})
+
+		assert.ErrorIs(t, err, identity.ErrInvalidResetToken)
+		userRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a second use of the same token", func(t *testing.T) {
+		reset := identity.NewPasswordReset(resetID, user.ID, security.HashToken("token"), time.Now())
+		var saved identity.PasswordReset
+		resetRepo := &MockPasswordResetRepository{}
+		resetRepo.On("FindByTokenHash", mock.Anything, security.HashToken("token")).Return(*reset, nil).Once()
+		resetRepo.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(identity.PasswordReset)
+		}).Return(nil).Once()
+		userRepo := &MockUserRepository{}
+		userRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+		userRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Once()
+		usecase := newTestPasswordResetUseCase(userRepo, resetRepo, &MockMailer{})
+		req := &ResetPasswordRequest{Token: "token", NewPassword: "new-password"}
+
+		_, err := usecase.ResetPassword(context.Background(), req)
+		require.NoError(t, err)
+
+		resetRepo.On("FindByTokenHash", mock.Anything, security.HashToken("token")).Return(saved, nil).Once()
+		_, err = usecase.ResetPassword(context.Background(), req)
+
+		assert.ErrorIs(t, err, identity.ErrInvalidResetToken)
+		resetRepo.AssertExpectations(t)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects an expired token", func(t *testing.T) {
+		reset := identity.NewPasswordReset(resetID, user.ID, security.HashToken("token"), time.Now().Add(-2*identity.PasswordResetTTL))
+		resetRepo := &MockPasswordResetRepository{}
+		resetRepo.On("FindByTokenHash", mock.Anything, security.HashToken("token")).Return(*reset, nil)
+		userRepo := &MockUserRepository{}
+		usecase := newTestPasswordResetUseCase(userRepo, resetRepo, &MockMailer{})
+
+		_, err := usecase.ResetPassword(context.Background(), &ResetPasswordRequest{
+			Token:       "token",
+			NewPassword: "new-password",
+		})
+
+		assert.ErrorIs(t, err, identity.ErrInvalidResetToken)
+	})
+
+	t.Run("rejects a short password before looking the token up", func(t *testing.T) {
+		resetRepo := &MockPasswordResetRepository{}
+		usecase := newTestPasswordResetUseCase(&MockUserRepository{}, resetRepo, &MockMailer{})
+
+		_, err := usecase.ResetPassword(context.Background(), &ResetPasswordRequest{
+			Token:       "token",
+			NewPassword: "short",
+		})
+
+		assert.ErrorIs(t, err, identity.ErrPasswordTooShort)
+		resetRepo.AssertNotCalled(t, "FindByTokenHash", mock.Anything, mock.Anything)
+	})
+}
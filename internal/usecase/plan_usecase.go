@@ -0,0 +1,279 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/madalinpopa/gocost-web/internal/domain"
+	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+)
+
+type PlanUseCaseImpl struct {
+	uow    domain.UnitOfWork
+	logger *slog.Logger
+}
+
+func NewPlanUseCase(uow domain.UnitOfWork, logger *slog.Logger) PlanUseCaseImpl {
+	return PlanUseCaseImpl{
+		uow:    uow,
+		logger: logger,
+	}
+}
+
+// Preview lists the one-off categories of fromMonth and the recurring
+// categories that continue into the following month.
+func (u PlanUseCaseImpl) Preview(ctx context.Context, userID string, fromMonth string) (*PlanPreviewResponse, error) {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := tracking.ParseMonth(fromMonth)
+	if err != nil {
+		return nil, err
+	}
+	to := from.Next()
+
+	groups, err := u.uow.TrackingRepository().FindByUserID(ctx, uID)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &PlanPreviewResponse{
+		FromMonth: from.Value(),
+		ToMonth:   to.Value(),
+		Groups:    make([]PlanGroupResponse, 0, len(groups)),
+	}
+
+	for _, group := range groups {
+		if group.Archived {
+			continue
+		}
+
+		var items []PlanItemResponse
+		for _, c := range planOrder(&group) {
+			if c.Archived || !isPlannable(c, from, to) {
+				continue
+			}
+
+			item := PlanItemResponse{
+				CategoryID:  c.ID.String(),
+				Name:        c.Name.Value(),
+				IsRecurrent: c.IsRecurrent,
+				BudgetCents: c.Budget.Cents(),
+			}
+			if parent, err := group.FindCategory(c.ParentID); err == nil {
+				item.ParentName = parent.Name.Value()
+			}
+			if !c.IsRecurrent {
+				item.Conflict = hasNameInMonth(&group, c, to)
+			}
+			items = append(items, item)
+		}
+
+		if len(items) == 0 {
+			continue
+		}
+		preview.Groups = append(preview.Groups, PlanGroupResponse{
+			ID:    group.ID.String(),
+			Name:  group.Name.Value(),
+			Items: items,
+		})
+	}
+
+	return preview, nil
+}
+
+// Apply copies the selected one-off categories into the next month and
+// carries the selected recurring budgets forward, adjusted by AdjustPercent.
+// Categories whose name is already taken in the next month are skipped and
+// reported back rather than failing the whole plan.
+func (u PlanUseCaseImpl) Apply(ctx context.Context, req *PlanMonthRequest) (*PlanMonthResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	uID, err := identifier.ParseID(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := tracking.ParseMonth(req.FromMonth)
+	if err != nil {
+		return nil, err
+	}
+	to := from.Next()
+
+	if err := ensureMonthOpen(ctx, u.uow, uID, to.Value()); err != nil {
+		return nil, err
+	}
+
+	budgets := make(map[string]money.Money, len(req.Items))
+	for _, item := range req.Items {
+		budget, err := money.NewFromFloat(item.Budget, req.Currency)
+		if err != nil {
+			return nil, err
+		}
+		budgets[item.CategoryID] = budget.AdjustByPercent(req.AdjustPercent)
+	}
+
+	groups, err := u.uow.TrackingRepository().FindByUserID(ctx, uID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &PlanMonthResponse{ToMonth: to.Value()}
+	forked := make(map[tracking.ID]tracking.ID)
+	var changed []tracking.Group
+
+	for i := range groups {
+		group := &groups[i]
+		if group.Archived {
+			continue
+		}
+
+		// Categories created or forked in this group, keyed by the ID they
+		// replace in the next month, so that subcategories follow them.
+		mapped := make(map[tracking.ID]tracking.ID)
+		groupChanged := false
+
+		for _, c := range planOrder(group) {
+			if c.Archived || !isPlannable(c, from, to) {
+				continue
+			}
+
+			parentID := c.ParentID
+			if newParentID, ok := mapped[parentID]; ok {
+				parentID = newParentID
+			}
+
+			budget, selected := budgets[c.ID.String()]
+
+			newID, err := identifier.NewID()
+			if err != nil {
+				return nil, err
+			}
+
+			if !c.IsRecurrent {
+				if !selected {
+					continue
+				}
+				if parent, err := group.FindCategory(parentID); err != nil || !parent.IsActiveFor(to) {
+					parentID = tracking.ID{}
+				}
+
+				if parentID.IsZero() {
+					_, err = group.CreateCategory(newID, c.Name, c.Description, false, to, tracking.Month{}, budget)
+				} else {
+					_, err = group.CreateSubcategory(parentID, newID, c.Name, c.Description, false, to, tracking.Month{}, budget)
+				}
+				if errors.Is(err, tracking.ErrCategoryNameExists) {
+					resp.Skipped = append(resp.Skipped, c.Name.Value())
+					continue
+				}
+				if err != nil {
+					return nil, err
+				}
+
+				mapped[c.ID] = newID
+				resp.Created++
+				groupChanged = true
+				continue
+			}
+
+			if !selected {
+				budget = c.Budget
+			}
+			if budget.Cents() == c.Budget.Cents() && parentID == c.ParentID {
+				continue
+			}
+
+			if _, err := group.ForkCategory(c.ID, newID, parentID, to, budget); err != nil {
+				if errors.Is(err, tracking.ErrCategoryNameExists) {
+					resp.Skipped = append(resp.Skipped, c.Name.Value())
+					continue
+				}
+				return nil, err
+			}
+
+			mapped[c.ID] = newID
+			forked[c.ID] = newID
+			if selected {
+				resp.Updated++
+			}
+			groupChanged = true
+		}
+
+		if groupChanged {
+			changed = append(changed, *group)
+		}
+	}
+
+	if len(changed) == 0 {
+		return resp, nil
+	}
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range changed {
+		if err := txUOW.TrackingRepository().Save(ctx, group); err != nil {
+			_ = txUOW.Rollback()
+			return nil, err
+		}
+	}
+
+	for oldID, newID := range forked {
+		if err := txUOW.ExpenseRepository().ReassignCategoryFromMonth(ctx, uID, oldID, newID, to.Value()); err != nil {
+			_ = txUOW.Rollback()
+			return nil, err
+		}
+		if err := txUOW.AlertRepository().ForkCategory(ctx, oldID, newID, to.Value()); err != nil {
+			_ = txUOW.Rollback()
+			return nil, err
+		}
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// planOrder lists the group's categories with every parent ahead of its
+// subcategories.
+func planOrder(group *tracking.Group) []*tracking.Category {
+	ordered := make([]*tracking.Category, 0, len(group.Categories))
+	for _, root := range group.TopLevelCategories() {
+		ordered = append(ordered, root)
+		ordered = append(ordered, group.Descendants(root.ID)...)
+	}
+	return ordered
+}
+
+// isPlannable reports whether the category is a one-off of the from month or
+// a recurring category running through both months.
+func isPlannable(c *tracking.Category, from, to tracking.Month) bool {
+	if !c.IsRecurrent {
+		return c.StartMonth.Equals(from)
+	}
+	return c.IsActiveFor(from) && c.IsActiveFor(to)
+}
+
+func hasNameInMonth(group *tracking.Group, category *tracking.Category, month tracking.Month) bool {
+	for _, c := range group.Categories {
+		if c.ID != category.ID && c.Name.Equals(category.Name) && c.IsActiveFor(month) {
+			return true
+		}
+	}
+	return false
+}
+
+var _ PlanUseCase = (*PlanUseCaseImpl)(nil)
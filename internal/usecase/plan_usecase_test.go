@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/tracking"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func addPlanCategory(t *testing.T, group *tracking.Group, name string, isRecurrent bool, start string, budgetCents int64) *tracking.Category {
+	t.Helper()
+
+	id, err := identifier.NewID()
+	require.NoError(t, err)
+	nameVO, err := tracking.NewNameVO(name)
+	require.NoError(t, err)
+	desc, err := tracking.NewDescriptionVO("")
+	require.NoError(t, err)
+	startMonth, err := tracking.ParseMonth(start)
+	require.NoError(t, err)
+	budget, err := money.New(budgetCents, "USD")
+	require.NoError(t, err)
+
+	category, err := group.CreateCategory(id, nameVO, desc, isRecurrent, startMonth, tracking.Month{}, budget)
+	require.NoError(t, err)
+	return category
+}
+
+func TestPlanUseCase_Preview(t *testing.T) {
+	userID, _ := identifier.NewID()
+	group := newTestGroup(t, userID)
+	rent := addPlanCategory(t, group, "Rent", true, "2024-01", 100000)
+	gifts := addPlanCategory(t, group, "Gifts", false, "2024-03", 5000)
+	addPlanCategory(t, group, "Trip", false, "2024-03", 20000)
+	addPlanCategory(t, group, "Trip", false, "2024-04", 15000)
+	addPlanCategory(t, group, "Old", false, "2024-02", 1000)
+
+	repo := &MockGroupRepository{}
+	repo.On("FindByUserID", mock.Anything, userID).Return([]tracking.Group{*group}, nil)
+
+	usecase := NewPlanUseCase(&MockUnitOfWork{TrackingRepo: repo}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	preview, err := usecase.Preview(context.Background(), userID.String(), "2024-03")
+
+	require.NoError(t, err)
+	assert.Equal(t, "2024-04", preview.ToMonth)
+	require.Len(t, preview.Groups, 1)
+	items := preview.Groups[0].Items
+	require.Len(t, items, 3)
+	assert.Equal(t, rent.ID.String(), items[0].CategoryID)
+	assert.True(t, items[0].IsRecurrent)
+	assert.Equal(t, gifts.ID.String(), items[1].CategoryID)
+	assert.False(t, items[1].Conflict)
+	assert.Equal(t, "Trip", items[2].Name)
+	assert.True(t, items[2].Conflict)
+}
+
+func TestPlanUseCase_Apply(t *testing.T) {
+	userID, _ := identifier.NewID()
+
+	t.Run("returns error for nil request", func(t *testing.T) {
+		usecase := NewPlanUseCase(&MockUnitOfWork{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		resp, err := usecase.Apply(context.Background(), nil)
+		assert.Nil(t, resp)
+		assert.EqualError(t, err, "request cannot be nil")
+	})
+
+	t.Run("copies one-offs, adjusts budgets and skips conflicts", func(t *testing.T) {
+		group := newTestGroup(t, userID)
+		rent := addPlanCategory(t, group, "Rent", true, "2024-01", 100000)
+		gifts := addPlanCategory(t, group, "Gifts", false, "2024-03", 5000)
+		trip := addPlanCategory(t, group, "Trip", false, "2024-03", 20000)
+		addPlanCategory(t, group, "Trip", false, "2024-04", 15000)
+
+		var savedGroup tracking.Group
+		repo := &MockGroupRepository{}
+		repo.On("FindByUserID", mock.Anything, userID).Return([]tracking.Group{*group}, nil)
+		txRepo := &MockGroupRepository{}
+		txRepo.On("Save", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			savedGroup = args.Get(1).(tracking.Group)
+		})
+		txExpenseRepo := &MockExpenseRepository{}
+		txExpenseRepo.On("ReassignCategoryFromMonth", mock.Anything, userID, rent.ID, mock.Anything, "2024-04").Return(nil)
+		txUOW := &MockUnitOfWork{TrackingRepo: txRepo, ExpenseRepo: txExpenseRepo}
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+		txUOW.On("Commit").Return(nil)
+
+		usecase := NewPlanUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		resp, err := usecase.Apply(context.Background(), &PlanMonthRequest{
+			UserID:        userID.String(),
+			Currency:      "USD",
+			FromMonth:     "2024-03",
+			AdjustPercent: 10,
+			Items: []PlanItemRequest{
+				{CategoryID: rent.ID.String(), Budget: 1000},
+				{CategoryID: gifts.ID.String(), Budget: 60},
+				{CategoryID: trip.ID.String(), Budget: 200},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "2024-04", resp.ToMonth)
+		assert.Equal(t, 1, resp.Created)
+		assert.Equal(t, 1, resp.Updated)
+		assert.Equal(t, []string{"Trip"}, resp.Skipped)
+
+		april, _ := tracking.ParseMonth("2024-04")
+		active, err := savedGroup.CategoriesForMonth(april)
+		require.NoError(t, err)
+		budgets := make(map[string]int64)
+		for _, c := range active {
+			budgets[c.Name.Value()] = c.Budget.Cents()
+		}
+		assert.Equal(t, int64(110000), budgets["Rent"])
+		assert.Equal(t, int64(6600), budgets["Gifts"])
+		assert.Equal(t, int64(15000), budgets["Trip"])
+
+		original, err := savedGroup.FindCategory(rent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "2024-03", original.EndMonth.Value())
+		txExpenseRepo.AssertExpectations(t)
+	})
+
+	t.Run("does nothing when nothing changes", func(t *testing.T) {
+		group := newTestGroup(t, userID)
+		rent := addPlanCategory(t, group, "Rent", true, "2024-01", 100000)
+
+		repo := &MockGroupRepository{}
+		repo.On("FindByUserID", mock.Anything, userID).Return([]tracking.Group{*group}, nil)
+		baseUOW := &MockUnitOfWork{TrackingRepo: repo}
+
+		usecase := NewPlanUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		resp, err := usecase.Apply(context.Background(), &PlanMonthRequest{
+			UserID:    userID.String(),
+			Currency:  "USD",
+			FromMonth: "2024-03",
+			Items:     []PlanItemRequest{{CategoryID: rent.ID.String(), Budget: 1000}},
+		})
+
+		require.NoError(t, err)
+		assert.Zero(t, resp.Updated)
+		baseUOW.AssertNotCalled(t, "Begin", mock.Anything)
+	})
+}
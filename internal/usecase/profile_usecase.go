@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/madalinpopa/gocost-web/internal/domain"
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/security"
+)
+
+type ProfileUseCaseImpl struct {
+	uow    domain.UnitOfWork
+	logger *slog.Logger
+	hasher security.PasswordHasher
+}
+
+func NewProfileUseCase(uow domain.UnitOfWork, logger *slog.Logger, h security.PasswordHasher) ProfileUseCaseImpl {
+	return ProfileUseCaseImpl{
+		uow:    uow,
+		logger: logger,
+		hasher: h,
+	}
+}
+
+func (u ProfileUseCaseImpl) Get(ctx context.Context, userID string) (*UserResponse, error) {
+	user, err := u.find(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapUserToResponse(user), nil
+}
+
+// UpdateProfile changes the email and username of the user. Either may stay
+// as it is; a new one must not belong to another user. A new email has to be
+// verified again.
+func (u ProfileUseCaseImpl) UpdateProfile(ctx context.Context, req *UpdateProfileRequest) (*UserResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	email, err := identity.NewEmailVO(req.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	username, err := identity.NewUsernameVO(req.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.find(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := u.uow.UserRepository()
+	if !user.Email.Equals(email) {
+		other, err := repo.FindByEmail(ctx, email)
+		if err == nil && other.ID != user.ID {
+			return nil, identity.ErrEmailTaken
+		}
+		if err != nil && !errors.Is(err, identity.ErrUserNotFound) {
+			return nil, err
+		}
+	}
+	if !user.Username.Equals(username) {
+		other, err := repo.FindByUsername(ctx, username)
+		if err == nil && other.ID != user.ID {
+			return nil, identity.ErrUsernameTaken
+		}
+		if err != nil && !errors.Is(err, identity.ErrUserNotFound) {
+			return nil, err
+		}
+	}
+
+	user.ChangeEmail(email)
+	user.Username = username
+	if err := u.save(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return mapUserToResponse(user), nil
+}
+
+// ChangeCurrency sets the currency amounts are shown in. Amounts are not
+// converted.
+func (u ProfileUseCaseImpl) ChangeCurrency(ctx context.Context, req *ChangeCurrencyRequest) (*UserResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	currency, err := identity.NewCurrencyVO(req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.find(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Currency = currency
+	if err := u.save(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return mapUserToResponse(user), nil
+}
+
+// ChangePassword sets a new password once the current one is confirmed.
+func (u ProfileUseCaseImpl) ChangePassword(ctx context.Context, req *ChangePasswordRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	if err := u.hasher.ValidatePassword(req.NewPassword); err != nil {
+		return err
+	}
+	if len(req.NewPassword) < minPasswordLength {
+		return identity.ErrPasswordTooShort
+	}
+
+	user, err := u.find(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !u.hasher.CheckPasswordHash(req.CurrentPassword, user.Password.Value()) {
+		return ErrInvalidCredentials
+	}
+
+	hashedPassword, err := u.hasher.HashPassword(req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	password, err := identity.NewPasswordVO(hashedPassword)
+	if err != nil {
+		return err
+	}
+
+	user.Password = password
+	return u.save(ctx, user)
+}
+
+func (u ProfileUseCaseImpl) find(ctx context.Context, userID string) (identity.User, error) {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return identity.User{}, err
+	}
+
+	return u.uow.UserRepository().FindByID(ctx, uID)
+}
+
+func (u ProfileUseCaseImpl) save(ctx context.Context, user identity.User) error {
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := txUOW.UserRepository().Save(ctx, user); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func mapUserToResponse(user identity.User) *UserResponse {
+	return &UserResponse{
+		ID:       user.ID.String(),
+		Email:    user.Email.Value(),
+		Username: user.Username.Value(),
+		Currency: user.Currency.Value(),
+
+		EmailVerified: user.EmailVerified,
+	}
+}
+
+var _ ProfileUseCase = (*ProfileUseCaseImpl)(nil)
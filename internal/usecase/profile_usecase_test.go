@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/platform/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProfileUseCase(repo *MockUserRepository) ProfileUseCaseImpl {
+	txUOW := &MockUnitOfWork{UserRepo: repo}
+	txUOW.On("Commit").Return(nil)
+	txUOW.On("Rollback").Return(nil)
+
+	baseUOW := &MockUnitOfWork{UserRepo: repo}
+	baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+
+	return NewProfileUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)), security.NewPasswordHasher())
+}
+
+func TestProfileUseCase_UpdateProfile(t *testing.T) {
+	hash, err := security.NewPasswordHasher().HashPassword("password1")
+	require.NoError(t, err)
+	user := newTestUser(t, "alice@example.com", "alice", hash)
+	other := newTestUser(t, "bob@example.com", "bob", hash)
+
+	t.Run("changes the email and keeps the username", func(t *testing.T) {
+		repo := &MockUserRepository{}
+		repo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+		newEmail, _ := identity.NewEmailVO("alice@example.org")
+		repo.On("FindByEmail", mock.Anything, newEmail).Return(identity.User{}, identity.ErrUserNotFound)
+		repo.On("Save", mock.Anything, mock.MatchedBy(func(u identity.User) bool {
+			return u.Email.Equals(newEmail) && u.Username.Equals(user.Username)
+		})).Return(nil)
+		usecase := newTestProfileUseCase(repo)
+
+		resp, err := usecase.UpdateProfile(context.Background(), &UpdateProfileRequest{
+			UserID:          user.ID.String(),
+			EmailRequest:    EmailRequest{Email: "alice@example.org"},
+			UsernameRequest: UsernameRequest{Username: "alice"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "alice@example.org", resp.Email)
+		repo.AssertNotCalled(t, "FindByUsername", mock.Anything, mock.Anything)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects a username of another user", func(t *testing.T) {
+		repo := &MockUserRepository{}
+		repo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+		repo.On("FindByUsername", mock.Anything, other.Username).Return(other, nil)
+		usecase := newTestProfileUseCase(repo)
+
+		_, err := usecase.UpdateProfile(context.Background(), &UpdateProfileRequest{
+			UserID:          user.ID.String(),
+			EmailRequest:    EmailRequest{Email: "alice@example.com"},
+			UsernameRequest: UsernameRequest{Username: "bob"},
+		})
+
+		assert.ErrorIs(t, err, identity.ErrUsernameTaken)
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestProfileUseCase_ChangeCurrency(t *testing.T) {
+	user := newTestUser(t, "alice@example.com", "alice", "$2a$12$abcdefghijklmnopqrstuv1234567890123456789012345678901")
+
+	repo := &MockUserRepository{}
+	repo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+	repo.On("Save", mock.Anything, mock.MatchedBy(func(u identity.User) bool {
+		return u.Currency.Value() == "EUR"
+	})).Return(nil)
+	usecase := newTestProfileUseCase(repo)
+
+	resp, err := usecase.ChangeCurrency(context.Background(), &ChangeCurrencyRequest{UserID: user.ID.String(), Currency: "EUR"})
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", resp.Currency)
+
+	_, err = usecase.ChangeCurrency(context.Background(), &ChangeCurrencyRequest{UserID: user.ID.String(), Currency: "XYZ"})
+	assert.ErrorIs(t, err, identity.ErrInvalidCurrency)
+}
+
+func TestProfileUseCase_ChangePassword(t *testing.T) {
+	hasher := security.NewPasswordHasher()
+	hash, err := hasher.HashPassword("password1")
+	require.NoError(t, err)
+	user := newTestUser(t, "alice@example.com", "alice", hash)
+
+	t.Run("sets the new password", func(t *testing.T) {
+		repo := &MockUserRepository{}
+		repo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+		repo.On("Save", mock.Anything, mock.MatchedBy(func(u identity.User) bool {
+			return hasher.CheckPasswordHash("new-password", u.Password.Value())
+		})).Return(nil)
+		usecase := newTestProfileUseCase(repo)
+
+		err := usecase.ChangePassword(context.Background(), &ChangePasswordRequest{
+			UserID:          user.ID.String(),
+			CurrentPassword: "password1",
+			NewPassword:     "new-password",
+		})
+
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects a wrong current password", func(t *testing.T) {
+		repo := &MockUserRepository{}
+		repo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+		usecase := newTestProfileUseCase(repo)
+
+		err := usecase.ChangePassword(context.Background(), &ChangePasswordRequest{
+			UserID:          user.ID.String(),
+			CurrentPassword: "wrong-password",
+			NewPassword:     "new-password",
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain"
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/infrastructure/mail"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+)
+
+type SessionUseCaseImpl struct {
+	uow    domain.UnitOfWork
+	logger *slog.Logger
+	mailer mail.Mailer
+}
+
+func NewSessionUseCase(uow domain.UnitOfWork, logger *slog.Logger, mailer mail.Mailer) SessionUseCaseImpl {
+	return SessionUseCaseImpl{
+		uow:    uow,
+		logger: logger,
+		mailer: mailer,
+	}
+}
+
+// Start records a new session of the user. Records not used for
+// identity.UserSessionRetention are forgotten first, so a browser not seen
+// for that long counts as new.
+func (u SessionUseCaseImpl) Start(ctx context.Context, req *StartSessionRequest) (*UserSessionResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	uID, err := identifier.ParseID(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := identifier.NewID()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	session := identity.NewUserSession(id, uID, req.IPAddress, req.UserAgent, now)
+	cutoff := now.Add(-identity.UserSessionRetention)
+
+	previous, err := u.uow.UserSessionRepository().FindByUserID(ctx, uID)
+	if err != nil {
+		return nil, err
+	}
+	previous = slices.DeleteFunc(previous, func(s identity.UserSession) bool {
+		return s.LastSeenAt.Before(cutoff)
+	})
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := txUOW.UserSessionRepository().DeleteSeenBefore(ctx, uID, cutoff); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
+	if err := txUOW.UserSessionRepository().Save(ctx, *session); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
+	// The first session of a user is not from a new device, there being no
+	// other to compare with.
+	newDevice := len(previous) > 0 && !slices.ContainsFunc(previous, func(s identity.UserSession) bool {
+		return s.UserAgent == session.UserAgent
+	})
+	if req.Alert && newDevice {
+		if err := u.sendNewDeviceAlert(ctx, *session, req.SessionsURL); err != nil {
+			u.logger.Error("failed to send new device alert", "error", err)
+		}
+	}
+
+	resp := mapUserSessionToResponse(*session)
+	return &resp, nil
+}
+
+// Touch records a request of the session, from the address it came from.
+func (u SessionUseCaseImpl) Touch(ctx context.Context, req *TouchSessionRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	uID, err := identifier.ParseID(req.UserID)
+	if err != nil {
+		return err
+	}
+	sID, err := identifier.ParseID(req.SessionID)
+	if err != nil {
+		return identity.ErrUserSessionNotFound
+	}
+
+	session, err := u.uow.UserSessionRepository().FindByID(ctx, uID, sID)
+	if err != nil {
+		return err
+	}
+	session.Seen(req.IPAddress, time.Now())
+
+	return u.uow.UserSessionRepository().Save(ctx, session)
+}
+
+// List returns the sessions of the user that are still active, the one used
+// last first. The session store knows which are; activeIDs are their IDs.
+func (u SessionUseCaseImpl) List(ctx context.Context, userID string, activeIDs []string) ([]UserSessionResponse, error) {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := u.uow.UserSessionRepository().FindByUserID(ctx, uID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]UserSessionResponse, 0, len(activeIDs))
+	for _, session := range sessions {
+		if slices.Contains(activeIDs, session.ID.String()) {
+			responses = append(responses, mapUserSessionToResponse(session))
+		}
+	}
+	return responses, nil
+}
+
+// sendNewDeviceAlert tells the user about a login from a browser they never
+// logged in from before, with a link to the sessions it can be signed out
+// from.
+func (u SessionUseCaseImpl) sendNewDeviceAlert(ctx context.Context, session identity.UserSession, sessionsURL string) error {
+	user, err := u.uow.UserRepository().FindByID(ctx, session.UserID)
+	if err != nil {
+		return err
+	}
+
+	return u.mailer.Send(ctx, mail.Message{
+		To:      user.Email.Value(),
+		Subject: "New login to your GoCost account",
+		Body: fmt.Sprintf(`Hello %s,
+
+Your GoCost account was just logged into from a new device:
+
+Device: %s
+IP address: %s
+Time: %s
+
+If this was you, there is nothing to do. If not, sign the session out and change your password:
+
+%s
+`, user.Username.Value(), session.Device(), session.IPAddress, session.CreatedAt.UTC().Format("2006-01-02 15:04 MST"), sessionsURL),
+	})
+}
+
+func mapUserSessionToResponse(session identity.UserSession) UserSessionResponse {
+	return UserSessionResponse{
+		ID:         session.ID.String(),
+		Device:     session.Device(),
+		IPAddress:  session.IPAddress,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+	}
+}
+
+var _ SessionUseCase = (*SessionUseCaseImpl)(nil)
@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/infrastructure/mail"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testFirefoxUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:128.0) Gecko/20100101 Firefox/128.0"
+	testSafariUserAgent  = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Mobile/15E148 Safari/604.1"
+)
+
+func newTestSessionUseCase(userRepo *MockUserRepository, sessionRepo *MockUserSessionRepository, mailer *MockMailer) SessionUseCaseImpl {
+	txUOW := &MockUnitOfWork{UserRepo: userRepo, UserSessionRepo: sessionRepo}
+	txUOW.On("Commit").Return(nil)
+	txUOW.On("Rollback").Return(nil)
+
+	baseUOW := &MockUnitOfWork{UserRepo: userRepo, UserSessionRepo: sessionRepo}
+	baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+
+	return NewSessionUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)), mailer)
+}
+
+func newTestUserSession(t *testing.T, userID identity.ID, userAgent string, lastSeenAt time.Time) identity.UserSession {
+	t.Helper()
+	id, err := identifier.NewID()
+	require.NoError(t, err)
+	return *identity.NewUserSession(id, userID, "203.0.113.7", userAgent, lastSeenAt)
+}
+
+func TestSessionUseCase_Start(t *testing.T) {
+	newRequest := func(userID identity.ID, userAgent string) *StartSessionRequest {
+		return &StartSessionRequest{
+			UserID:      userID.String(),
+			IPAddress:   "198.51.100.2",
+			UserAgent:   userAgent,
+			Alert:       true,
+			SessionsURL: "https://gocost.example/profile#sessions",
+		}
+	}
+
+	t.Run("records the session and forgets old ones", func(t *testing.T) {
+		user := newTestUser(t, "alice@example.com", "alice", "$2a$12$abcdefghijklmnopqrstuuMtXbjLYe6Xq/Xq8ZuNnF9Jk9r4dvmWe")
+		sessionRepo := &MockUserSessionRepository{}
+		sessionRepo.On("FindByUserID", mock.Anything, user.ID).Return(nil, nil)
+		sessionRepo.On("DeleteSeenBefore", mock.Anything, user.ID, mock.MatchedBy(func(before time.Time) bool {
+			return time.Since(before) >= identity.UserSessionRetention
+		})).Return(nil)
+		sessionRepo.On("Save", mock.Anything, mock.MatchedBy(func(s identity.UserSession) bool {
+			return s.UserID == user.ID && s.IPAddress == "198.51.100.2" && s.UserAgent == testFirefoxUserAgent
+		})).Return(nil)
+		mailer := &MockMailer{}
+		usecase := newTestSessionUseCase(&MockUserRepository{}, sessionRepo, mailer)
+
+		resp, err := usecase.Start(context.Background(), newRequest(user.ID, testFirefoxUserAgent))
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, resp.ID)
+		assert.Equal(t, "Firefox on Windows", resp.Device)
+		sessionRepo.AssertExpectations(t)
+		// The first session of a user is not from a new device.
+		mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+
+	t.Run("emails a login from a new device", func(t *testing.T) {
+		user := newTestUser(t, "alice@example.com", "alice", "$2a$12$abcdefghijklmnopqrstuuMtXbjLYe6Xq/Xq8ZuNnF9Jk9r4dvmWe")
+		userRepo := &MockUserRepository{}
+		userRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+		sessionRepo := &MockUserSessionRepository{}
+		sessionRepo.On("FindByUserID", mock.Anything, user.ID).Return([]identity.UserSession{
+			newTestUserSession(t, user.ID, testFirefoxUserAgent, time.Now()),
+		}, nil)
+		sessionRepo.On("DeleteSeenBefore", mock.Anything, user.ID, mock.Anything).Return(nil)
+		sessionRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mailer := &MockMailer{}
+		mailer.On("Send", mock.Anything, mock.Anything).Return(nil)
+		usecase := newTestSessionUseCase(userRepo, sessionRepo, mailer)
+
+		_, err := usecase.Start(context.Background(), newRequest(user.ID, testSafariUserAgent))
+
+		require.NoError(t, err)
+		msg := mailer.Calls[0].Arguments.Get(1).(mail.Message)
+		assert.Equal(t, "alice@example.com", msg.To)
+		assert.Contains(t, msg.Body, "Safari on iOS")
+		assert.Contains(t, msg.Body, "198.51.100.2")
+		assert.Contains(t, msg.Body, "https://gocost.example/profile#sessions")
+	})
+
+	t.Run("does not email a login from a known device", func(t *testing.T) {
+		user := newTestUser(t, "alice@example.com", "alice", "$2a$12$abcdefghijklmnopqrstuuMtXbjLYe6Xq/Xq8ZuNnF9Jk9r4dvmWe")
+		sessionRepo := &MockUserSessionRepository{}
+		sessionRepo.On("FindByUserID", mock.Anything, user.ID).Return([]identity.UserSession{
+			newTestUserSession(t, user.ID, testFirefoxUserAgent, time.Now()),
+		}, nil)
+		sessionRepo.On("DeleteSeenBefore", mock.Anything, user.ID, mock.Anything).Return(nil)
+		sessionRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mailer := &MockMailer{}
+		usecase := newTestSessionUseCase(&MockUserRepository{}, sessionRepo, mailer)
+
+		_, err := usecase.Start(context.Background(), newRequest(user.ID, testFirefoxUserAgent))
+
+		require.NoError(t, err)
+		mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+
+	t.Run("a device not seen for long counts as new", func(t *testing.T) {
+		user := newTestUser(t, "alice@example.com", "alice", "$2a$12$abcdefghijklmnopqrstuuMtXbjLYe6Xq/Xq8ZuNnF9Jk9r4dvmWe")
+		userRepo := &MockUserRepository{}
+		userRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+		sessionRepo := &MockUserSessionRepository{}
+		sessionRepo.On("FindByUserID", mock.Anything, user.ID).Return([]identity.UserSession{
+			newTestUserSession(t, user.ID, testFirefoxUserAgent, time.Now().Add(-identity.UserSessionRetention-time.Hour)),
+			newTestUserSession(t, user.ID, testSafariUserAgent, time.Now()),
+		}, nil)
+		sessionRepo.On("DeleteSeenBefore", mock.Anything, user.ID, mock.Anything).Return(nil)
+		sessionRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mailer := &MockMailer{}
+		mailer.On("Send", mock.Anything, mock.Anything).Return(nil)
+		usecase := newTestSessionUseCase(userRepo, sessionRepo, mailer)
+
+		_, err := usecase.Start(context.Background(), newRequest(user.ID, testFirefoxUserAgent))
+
+		require.NoError(t, err)
+		mailer.AssertNumberOfCalls(t, "Send", 1)
+	})
+
+	t.Run("sends no alerts unless asked to", func(t *testing.T) {
+		user := newTestUser(t, "alice@example.com", "alice", "$2a$12$abcdefghijklmnopqrstuuMtXbjLYe6Xq/Xq8ZuNnF9Jk9r4dvmWe")
+		sessionRepo := &MockUserSessionRepository{}
+		sessionRepo.On("FindByUserID", mock.Anything, user.ID).Return([]identity.UserSession{
+			newTestUserSession(t, user.ID, testFirefoxUserAgent, time.Now()),
+		}, nil)
+		sessionRepo.On("DeleteSeenBefore", mock.Anything, user.ID, mock.Anything).Return(nil)
+		sessionRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mailer := &MockMailer{}
+		usecase := newTestSessionUseCase(&MockUserRepository{}, sessionRepo, mailer)
+		req := newRequest(user.ID, testSafariUserAgent)
+		req.Alert = false
+
+		_, err := usecase.Start(context.Background(), req)
+
+		require.NoError(t, err)
+		mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+	})
+}
+
+func TestSessionUseCase_Touch(t *testing.T) {
+	userID, _ := identifier.NewID()
+	session := newTestUserSession(t, userID, testFirefoxUserAgent, time.Now().Add(-time.Hour))
+	sessionRepo := &MockUserSessionRepository{}
+	sessionRepo.On("FindByID", mock.Anything, userID, session.ID).Return(session, nil)
+	sessionRepo.On("Save", mock.Anything, mock.MatchedBy(func(s identity.UserSession) bool {
+		return s.ID == session.ID && s.IPAddress == "198.51.100.2" && time.Since(s.LastSeenAt) < time.Minute
+	})).Return(nil)
+	usecase := newTestSessionUseCase(&MockUserRepository{}, sessionRepo, &MockMailer{})
+
+	err := usecase.Touch(context.Background(), &TouchSessionRequest{
+		UserID:    userID.String(),
+		SessionID: session.ID.String(),
+		IPAddress: "198.51.100.2",
+	})
+
+	require.NoError(t, err)
+	sessionRepo.AssertExpectations(t)
+}
+
+func TestSessionUseCase_List(t *testing.T) {
+	userID, _ := identifier.NewID()
+	active := newTestUserSession(t, userID, testFirefoxUserAgent, time.Now())
+	ended := newTestUserSession(t, userID, testSafariUserAgent, time.Now().Add(-time.Hour))
+	sessionRepo := &MockUserSessionRepository{}
+	sessionRepo.On("FindByUserID", mock.Anything, userID).Return([]identity.UserSession{active, ended}, nil)
+	usecase := newTestSessionUseCase(&MockUserRepository{}, sessionRepo, &MockMailer{})
+
+	sessions, err := usecase.List(context.Background(), userID.String(), []string{active.ID.String()})
+
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, active.ID.String(), sessions[0].ID)
+	assert.Equal(t, "Firefox on Windows", sessions[0].Device)
+}
@@ -0,0 +1,306 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/madalinpopa/gocost-web/internal/domain"
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/platform/identifier"
+	"github.com/madalinpopa/gocost-web/internal/platform/security"
+)
+
+// twoFactorIssuer names the account in authenticator apps.
+const twoFactorIssuer = "GoCost"
+
+type TwoFactorUseCaseImpl struct {
+	uow    domain.UnitOfWork
+	logger *slog.Logger
+	hasher security.PasswordHasher
+}
+
+func NewTwoFactorUseCase(uow domain.UnitOfWork, logger *slog.Logger, hasher security.PasswordHasher) TwoFactorUseCaseImpl {
+	return TwoFactorUseCaseImpl{
+		uow:    uow,
+		logger: logger,
+		hasher: hasher,
+	}
+}
+
+func (u TwoFactorUseCaseImpl) Status(ctx context.Context, userID string) (*TwoFactorStatusResponse, error) {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactor, err := u.uow.TwoFactorRepository().FindByUserID(ctx, uID)
+	if err != nil {
+		if errors.Is(err, identity.ErrTwoFactorNotFound) {
+			return &TwoFactorStatusResponse{}, nil
+		}
+		return nil, err
+	}
+
+	return &TwoFactorStatusResponse{
+		Enabled:           twoFactor.IsEnabled(),
+		RecoveryCodesLeft: twoFactor.RecoveryCodesLeft(),
+	}, nil
+}
+
+// BeginSetup gives the user a new secret for their authenticator app. Two-factor
+// authentication stays off until ConfirmSetup is given a code made with it.
+func (u TwoFactorUseCaseImpl) BeginSetup(ctx context.Context, userID string) (*TwoFactorSetupResponse, error) {
+	uID, err := identifier.ParseID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.uow.UserRepository().FindByID(ctx, uID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := u.uow.TwoFactorRepository().FindByUserID(ctx, uID)
+	if err == nil && current.IsEnabled() {
+		return nil, identity.ErrTwoFactorAlreadyEnabled
+	}
+	if err != nil && !errors.Is(err, identity.ErrTwoFactorNotFound) {
+		return nil, err
+	}
+
+	secret, err := security.NewTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	totp, err := security.ParseTOTPSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.save(ctx, *identity.NewTwoFactor(uID, secret)); err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorSetupResponse{
+		Secret: secret,
+		URI:    totp.URI(twoFactorIssuer, user.Email.Value()),
+	}, nil
+}
+
+// ConfirmSetup turns two-factor authentication on once the code shows the
+// authenticator app has the secret, and returns the recovery codes. They are
+// not stored and cannot be shown again.
+func (u TwoFactorUseCaseImpl) ConfirmSetup(ctx context.Context, req *ConfirmTwoFactorRequest) ([]string, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	uID, err := identifier.ParseID(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, identity.RecoveryCodeCount)
+	hashes := make([]string, 0, identity.RecoveryCodeCount)
+	for range identity.RecoveryCodeCount {
+		code, err := security.NewRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, security.HashToken(code))
+	}
+
+	// The code is checked and spent in the transaction, so that it cannot
+	// confirm the setup twice.
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactor, err := txUOW.TwoFactorRepository().FindByUserID(ctx, uID)
+	if err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+	if twoFactor.IsEnabled() {
+		_ = txUOW.Rollback()
+		return nil, identity.ErrTwoFactorAlreadyEnabled
+	}
+
+	totp, err := security.ParseTOTPSecret(twoFactor.Secret)
+	if err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+	now := time.Now()
+	step, ok := totp.Validate(normalizeTOTPCode(req.Code), now)
+	if !ok {
+		_ = txUOW.Rollback()
+		return nil, identity.ErrInvalidTwoFactorCode
+	}
+
+	if err := twoFactor.Confirm(step, hashes, now); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+	if err := txUOW.TwoFactorRepository().Save(ctx, twoFactor); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// Disable turns two-factor authentication off once the password is confirmed.
+func (u TwoFactorUseCaseImpl) Disable(ctx context.Context, req *DisableTwoFactorRequest) error {
+	if req == nil {
+		return errors.New("request cannot be nil")
+	}
+
+	uID, err := identifier.ParseID(req.UserID)
+	if err != nil {
+		return err
+	}
+
+	user, err := u.uow.UserRepository().FindByID(ctx, uID)
+	if err != nil {
+		return err
+	}
+	if !u.hasher.CheckPasswordHash(req.Password, user.Password.Value()) {
+		return ErrInvalidCredentials
+	}
+
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := txUOW.TwoFactorRepository().DeleteByUserID(ctx, uID); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+// Verify checks the second step of a login: a code from the authenticator app,
+// or one of the recovery codes. Either works once.
+func (u TwoFactorUseCaseImpl) Verify(ctx context.Context, req *VerifyTwoFactorRequest) (*UserResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	uID, err := identifier.ParseID(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The code is checked and spent in the transaction, so that two logins
+	// cannot both pass with it.
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactor, err := txUOW.TwoFactorRepository().FindByUserID(ctx, uID)
+	if err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+	if err := useTwoFactorCode(&twoFactor, req.Code, time.Now()); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+	if err := txUOW.TwoFactorRepository().Save(ctx, twoFactor); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return nil, err
+	}
+
+	user, err := u.uow.UserRepository().FindByID(ctx, uID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapUserToResponse(user), nil
+}
+
+// useTwoFactorCode spends code, a code from the authenticator app or one of
+// the recovery codes, on twoFactor.
+func useTwoFactorCode(twoFactor *identity.TwoFactor, code string, now time.Time) error {
+	if !twoFactor.IsEnabled() {
+		return identity.ErrTwoFactorNotFound
+	}
+
+	if digits := normalizeTOTPCode(code); isDigits(digits) {
+		totp, err := security.ParseTOTPSecret(twoFactor.Secret)
+		if err != nil {
+			return err
+		}
+		step, ok := totp.Validate(digits, now)
+		if !ok {
+			return identity.ErrInvalidTwoFactorCode
+		}
+		return twoFactor.UseStep(step)
+	}
+
+	codeHash := security.HashToken(security.NormalizeRecoveryCode(code))
+	return twoFactor.UseRecoveryCode(codeHash, now)
+}
+
+func (u TwoFactorUseCaseImpl) save(ctx context.Context, twoFactor identity.TwoFactor) error {
+	txUOW, err := u.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := txUOW.TwoFactorRepository().Save(ctx, twoFactor); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	if err := txUOW.Commit(); err != nil {
+		_ = txUOW.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+// normalizeTOTPCode drops the spaces apps show codes with.
+func normalizeTOTPCode(code string) string {
+	return strings.Join(strings.Fields(code), "")
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+var _ TwoFactorUseCase = (*TwoFactorUseCaseImpl)(nil)
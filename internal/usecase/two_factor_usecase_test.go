@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/madalinpopa/gocost-web/internal/domain/identity"
+	"github.com/madalinpopa/gocost-web/internal/platform/security"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTwoFactorUseCase(userRepo *MockUserRepository, twoFactorRepo *MockTwoFactorRepository) TwoFactorUseCaseImpl {
+	txUOW := &MockUnitOfWork{UserRepo: userRepo, TwoFactorRepo: twoFactorRepo}
+	txUOW.On("Commit").Return(nil)
+	txUOW.On("Rollback").Return(nil)
+
+	baseUOW := &MockUnitOfWork{UserRepo: userRepo, TwoFactorRepo: twoFactorRepo}
+	baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+
+	return NewTwoFactorUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)), security.NewPasswordHasher())
+}
+
+func newTestTwoFactor(t *testing.T, user identity.User, recoveryCodes ...string) (*identity.TwoFactor, security.TOTP) {
+	t.Helper()
+
+	secret, err := security.NewTOTPSecret()
+	require.NoError(t, err)
+	totp, err := security.ParseTOTPSecret(secret)
+	require.NoError(t, err)
+
+	hashes := make([]string, 0, len(recoveryCodes))
+	for _, code := range recoveryCodes {
+		hashes = append(hashes, security.HashToken(code))
+	}
+
+	twoFactor := identity.NewTwoFactor(user.ID, secret)
+	// An hour ago, so that the codes of now have not been used.
+	require.NoError(t, twoFactor.Confirm(totp.Step(time.Now().Add(-time.Hour)), hashes, time.Now()))
+	return twoFactor, totp
+}
+
+func TestTwoFactorUseCase_BeginSetup(t *testing.T) {
+	user := newTestUser(t, "alice@example.com", "alice", "$2a$12$abcdefghijklmnopqrstuuMtXbjLYe6Xq/Xq8ZuNnF9Jk9r4dvmWe")
+
+	t.Run("stores a pending secret and returns its URI", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		userRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+		twoFactorRepo := &MockTwoFactorRepository{}
+		twoFactorRepo.On("FindByUserID", mock.Anything, user.ID).Return(identity.TwoFactor{}, identity.ErrTwoFactorNotFound)
+		twoFactorRepo.On("Save", mock.Anything, mock.MatchedBy(func(tf identity.TwoFactor) bool {
+			return tf.UserID == user.ID && !tf.IsEnabled() && tf.Secret != ""
+		})).Return(nil)
+		usecase := newTestTwoFactorUseCase(userRepo, twoFactorRepo)
+
+		setup, err := usecase.BeginSetup(context.Background(), user.ID.String())
+
+		require.NoError(t, err)
+		saved := twoFactorRepo.Calls[1].Arguments.Get(1).(identity.TwoFactor)
+		assert.Equal(t, saved.Secret, setup.Secret)
+		assert.Contains(t, setup.URI, "otpauth://totp/GoCost:alice@example.com?")
+		assert.Contains(t, setup.URI, "secret="+setup.Secret)
+	})
+
+	t.Run("refuses when already enabled", func(t *testing.T) {
+		twoFactor, _ := newTestTwoFactor(t, user)
+		userRepo := &MockUserRepository{}
+		userRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+		twoFactorRepo := &MockTwoFactorRepository{}
+		twoFactorRepo.On("FindByUserID", mock.Anything, user.ID).Return(*twoFactor, nil)
+		usecase := newTestTwoFactorUseCase(userRepo, twoFactorRepo)
+
+		_, err := usecase.BeginSetup(context.Background(), user.ID.String())
+
+		assert.ErrorIs(t, err, identity.ErrTwoFactorAlreadyEnabled)
+		twoFactorRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTwoFactorUseCase_ConfirmSetup(t *testing.T) {
+	user := newTestUser(t, "alice@example.com", "alice", "$2a$12$abcdefghijklmnopqrstuuMtXbjLYe6Xq/Xq8ZuNnF9Jk9r4dvmWe")
+	secret, err := security.NewTOTPSecret()
+	require.NoError(t, err)
+	totp, err := security.ParseTOTPSecret(secret)
+	require.NoError(t, err)
+
+	t.Run("enables it and returns the recovery codes", func(t *testing.T) {
+		twoFactorRepo := &MockTwoFactorRepository{}
+		twoFactorRepo.On("FindByUserID", mock.Anything, user.ID).Return(*identity.NewTwoFactor(user.ID, secret), nil)
+		twoFactorRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		usecase := newTestTwoFactorUseCase(&MockUserRepository{}, twoFactorRepo)
+
+		codes, err := usecase.ConfirmSetup(context.Background(), &ConfirmTwoFactorRequest{
+			UserID: user.ID.String(),
+			Code:   totp.Code(time.Now()),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, codes, identity.RecoveryCodeCount)
+		saved := twoFactorRepo.Calls[1].Arguments.Get(1).(identity.TwoFactor)
+		assert.True(t, saved.IsEnabled())
+		assert.Equal(t, identity.RecoveryCodeCount, saved.RecoveryCodesLeft())
+		assert.Equal(t, security.HashToken(codes[0]), saved.RecoveryCodes[0].CodeHash)
+	})
+
+	t.Run("rejects a wrong code", func(t *testing.T) {
+		twoFactorRepo := &MockTwoFactorRepository{}
+		twoFactorRepo.On("FindByUserID", mock.Anything, user.ID).Return(*identity.NewTwoFactor(user.ID, secret), nil)
+		usecase := newTestTwoFactorUseCase(&MockUserRepository{}, twoFactorRepo)
+
+		_, err := usecase.ConfirmSetup(context.Background(), &ConfirmTwoFactorRequest{
+			UserID: user.ID.String(),
+			Code:   "000000x",
+		})
+
+		assert.ErrorIs(t, err, identity.ErrInvalidTwoFactorCode)
+		twoFactorRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTwoFactorUseCase_Disable(t *testing.T) {
+	hasher := security.NewPasswordHasher()
+	hash, err := hasher.HashPassword("password1")
+	require.NoError(t, err)
+	user := newTestUser(t, "alice@example.com", "alice", hash)
+
+	t.Run("turns it off with the password", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		userRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+		twoFactorRepo := &MockTwoFactorRepository{}
+		twoFactorRepo.On("DeleteByUserID", mock.Anything, user.ID).Return(nil)
+		usecase := newTestTwoFactorUseCase(userRepo, twoFactorRepo)
+
+		err := usecase.Disable(context.Background(), &DisableTwoFactorRequest{UserID: user.ID.String(), Password: "password1"})
+
+		require.NoError(t, err)
+		twoFactorRepo.AssertExpectations(t)
+	})
+
+	t.Run("keeps it with a wrong password", func(t *testing.T) {
+		userRepo := &MockUserRepository{}
+		userRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+		twoFactorRepo := &MockTwoFactorRepository{}
+		usecase := newTestTwoFactorUseCase(userRepo, twoFactorRepo)
+
+		err := usecase.Disable(context.Background(), &DisableTwoFactorRequest{UserID: user.ID.String(), Password: "wrong-password"})
+
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+		twoFactorRepo.AssertNotCalled(t, "DeleteByUserID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTwoFactorUseCase_Verify(t *testing.T) {
+	user := newTestUser(t, "alice@example.com", "alice", "$2a$12$abcdefghijklmnopqrstuuMtXbjLYe6Xq/Xq8ZuNnF9Jk9r4dvmWe")
+
+	t.Run("accepts a code from the app once", func(t *testing.T) {
+		twoFactor, totp := newTestTwoFactor(t, user)
+		code := totp.Code(time.Now())
+		userRepo := &MockUserRepository{}
+		userRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+		twoFactorRepo := &MockTwoFactorRepository{}
+		twoFactorRepo.On("FindByUserID", mock.Anything, user.ID).Return(*twoFactor, nil).Once()
+		twoFactorRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		usecase := newTestTwoFactorUseCase(userRepo, twoFactorRepo)
+
+		resp, err := usecase.Verify(context.Background(), &VerifyTwoFactorRequest{UserID: user.ID.String(), Code: code[:3] + " " + code[3:]})
+
+		require.NoError(t, err)
+		assert.Equal(t, user.ID.String(), resp.ID)
+
+		saved := twoFactorRepo.Calls[1].Arguments.Get(1).(identity.TwoFactor)
+		twoFactorRepo.On("FindByUserID", mock.Anything, user.ID).Return(saved, nil)
+		_, err = usecase.Verify(context.Background(), &VerifyTwoFactorRequest{UserID: user.ID.String(), Code: code})
+		assert.ErrorIs(t, err, identity.ErrInvalidTwoFactorCode)
+	})
+
+	t.Run("accepts a recovery code once", func(t *testing.T) {
+		twoFactor, _ := newTestTwoFactor(t, user, "abcde-fgh23", "ijklm-nop45")
+		userRepo := &MockUserRepository{}
+		userRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+		twoFactorRepo := &MockTwoFactorRepository{}
+		twoFactorRepo.On("FindByUserID", mock.Anything, user.ID).Return(*twoFactor, nil)
+		twoFactorRepo.On("Save", mock.Anything, mock.MatchedBy(func(tf identity.TwoFactor) bool {
+			return tf.RecoveryCodesLeft() == 1
+		})).Return(nil)
+		usecase := newTestTwoFactorUseCase(userRepo, twoFactorRepo)
+
+		_, err := usecase.Verify(context.Background(), &VerifyTwoFactorRequest{UserID: user.ID.String(), Code: "ABCDE FGH23"})
+
+		require.NoError(t, err)
+		twoFactorRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects a wrong code", func(t *testing.T) {
+		twoFactor, _ := newTestTwoFactor(t, user, "abcde-fgh23")
+		twoFactorRepo := &MockTwoFactorRepository{}
+		twoFactorRepo.On("FindByUserID", mock.Anything, user.ID).Return(*twoFactor, nil)
+		usecase := newTestTwoFactorUseCase(&MockUserRepository{}, twoFactorRepo)
+
+		_, err := usecase.Verify(context.Background(), &VerifyTwoFactorRequest{UserID: user.ID.String(), Code: "zzzzz-zzzzz"})
+
+		assert.ErrorIs(t, err, identity.ErrInvalidTwoFactorCode)
+		twoFactorRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTwoFactorUseCase_CodesAreSpentInTransaction(t *testing.T) {
+	user := newTestUser(t, "alice@example.com", "alice", "$2a$12$abcdefghijklmnopqrstuuMtXbjLYe6Xq/Xq8ZuNnF9Jk9r4dvmWe")
+
+	// Only the transaction can read the two-factor record: the base unit of work
+	// has a repository with no expectations.
+	newUseCase := func(userRepo *MockUserRepository, twoFactorRepo *MockTwoFactorRepository) TwoFactorUseCaseImpl {
+		txUOW := &MockUnitOfWork{UserRepo: userRepo, TwoFactorRepo: twoFactorRepo}
+		txUOW.On("Commit").Return(nil)
+		txUOW.On("Rollback").Return(nil)
+
+		baseUOW := &MockUnitOfWork{UserRepo: userRepo, TwoFactorRepo: &MockTwoFactorRepository{}}
+		baseUOW.On("Begin", mock.Anything).Return(txUOW, nil)
+
+		return NewTwoFactorUseCase(baseUOW, slog.New(slog.NewTextHandler(io.Discard, nil)), security.NewPasswordHasher())
+	}
+
+	t.Run("rejects a recovery code used a second time", func(t *testing.T) {
+		twoFactor, _ := newTestTwoFactor(t, user, "abcde-fgh23", "ijklm-nop45")
+		var saved identity.TwoFactor
+		userRepo := &MockUserRepository{}
+		userRepo.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+		twoFactorRepo := &MockTwoFactorRepository{}
+		twoFactorRepo.On("FindByUserID", mock.Anything, user.ID).Return(*twoFactor, nil).Once()
+		twoFactorRepo.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(identity.TwoFactor)
+		}).Return(nil).Once()
+		usecase := newUseCase(userRepo, twoFactorRepo)
+		req := &VerifyTwoFactorRequest{UserID: user.ID.String(), Code: "abcde-fgh23"}
+
+		_, err := usecase.Verify(context.Background(), req)
+		require.NoError(t, err)
+
+		twoFactorRepo.On("FindByUserID", mock.Anything, user.ID).Return(saved, nil).Once()
+		_, err = usecase.Verify(context.Background(), req)
+
+		assert.ErrorIs(t, err, identity.ErrInvalidTwoFactorCode)
+		twoFactorRepo.AssertExpectations(t)
+	})
+
+	t.Run("confirms the setup once", func(t *testing.T) {
+		secret, err := security.NewTOTPSecret()
+		require.NoError(t, err)
+		totp, err := security.ParseTOTPSecret(secret)
+		require.NoError(t, err)
+		var saved identity.TwoFactor
+		twoFactorRepo := &MockTwoFactorRepository{}
+		twoFactorRepo.On("FindByUserID", mock.Anything, user.ID).Return(*identity.NewTwoFactor(user.ID, secret), nil).Once()
+		twoFactorRepo.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(identity.TwoFactor)
+		}).Return(nil).Once()
+		usecase := newUseCase(&MockUserRepository{}, twoFactorRepo)
+		req := &ConfirmTwoFactorRequest{UserID: user.ID.String(), Code: totp.Code(time.Now())}
+
+		_, err = usecase.ConfirmSetup(context.Background(), req)
+		require.NoError(t, err)
+
+		twoFactorRepo.On("FindByUserID", mock.Anything, user.ID).Return(saved, nil).Once()
+		_, err = usecase.ConfirmSetup(context.Background(), req)
+
+		assert.ErrorIs(t, err, identity.ErrTwoFactorAlreadyEnabled)
+		twoFactorRepo.AssertExpectations(t)
+	})
+}
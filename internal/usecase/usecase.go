@@ -3,37 +3,83 @@ package usecase
 import (
 	"log/slog"
 
+	"github.com/madalinpopa/gocost-web/internal/infrastructure/mail"
 	"github.com/madalinpopa/gocost-web/internal/infrastructure/storage/sqlite"
 	"github.com/madalinpopa/gocost-web/internal/platform/security"
 )
 
 type UseCase struct {
-	AuthUseCase      AuthUseCase
-	IncomeUseCase    IncomeUseCase
-	GroupUseCase     GroupUseCase
-	CategoryUseCase  CategoryUseCase
-	ExpenseUseCase   ExpenseUseCase
-	DashboardUseCase DashboardUseCase
+	AuthUseCase          AuthUseCase
+	ProfileUseCase       ProfileUseCase
+	PasswordResetUseCase PasswordResetUseCase
+	VerificationUseCase  EmailVerificationUseCase
+	TwoFactorUseCase     TwoFactorUseCase
+	PasskeyUseCase       PasskeyUseCase
+	SessionUseCase       SessionUseCase
+	InviteUseCase        InviteUseCase
+	IncomeUseCase        IncomeUseCase
+	GroupUseCase         GroupUseCase
+	CategoryUseCase      CategoryUseCase
+	ExpenseUseCase       ExpenseUseCase
+	DashboardUseCase     DashboardUseCase
+	PlanUseCase          PlanUseCase
+	ClosingUseCase       ClosingUseCase
+	BudgetUseCase        BudgetUseCase
+	GoalUseCase          GoalUseCase
+	AccountUseCase       AccountUseCase
+	LoanUseCase          LoanUseCase
+	NetWorthUseCase      NetWorthUseCase
+	AlertUseCase         AlertUseCase
 }
 
-func New(uow *sqlite.SqliteUnitOfWork, logger *slog.Logger) *UseCase {
+func New(uow *sqlite.SqliteUnitOfWork, logger *slog.Logger, mailer mail.Mailer, signer security.Signer, registration RegistrationMode) *UseCase {
 	// Infra services
 	passwordHasher := security.NewPasswordHasher()
 
 	// Use cases
-	authUseCase := NewAuthUseCase(uow, logger, passwordHasher)
+	authUseCase := NewAuthUseCase(uow, logger, passwordHasher, registration)
+	profileUseCase := NewProfileUseCase(uow, logger, passwordHasher)
+	passwordResetUseCase := NewPasswordResetUseCase(uow, logger, passwordHasher, mailer)
+	verificationUseCase := NewEmailVerificationUseCase(uow, logger, mailer, signer)
+	twoFactorUseCase := NewTwoFactorUseCase(uow, logger, passwordHasher)
+	passkeyUseCase := NewPasskeyUseCase(uow, logger)
+	sessionUseCase := NewSessionUseCase(uow, logger, mailer)
+	inviteUseCase := NewInviteUseCase(uow, logger)
 	incomeUseCase := NewIncomeUseCase(uow, logger)
 	groupUseCase := NewGroupUseCase(uow, logger)
 	categoryUseCase := NewCategoryUseCase(uow, logger)
 	expenseUseCase := NewExpenseUseCase(uow, logger)
 	dashboardUseCase := NewDashboardUseCase(uow, logger)
+	planUseCase := NewPlanUseCase(uow, logger)
+	closingUseCase := NewClosingUseCase(uow, logger)
+	budgetUseCase := NewBudgetUseCase(uow, logger)
+	goalUseCase := NewGoalUseCase(uow, logger)
+	accountUseCase := NewAccountUseCase(uow, logger)
+	loanUseCase := NewLoanUseCase(uow, logger)
+	netWorthUseCase := NewNetWorthUseCase(uow, logger)
+	alertUseCase := NewAlertUseCase(uow, logger)
 
 	return &UseCase{
-		AuthUseCase:      authUseCase,
-		IncomeUseCase:    incomeUseCase,
-		GroupUseCase:     groupUseCase,
-		CategoryUseCase:  categoryUseCase,
-		ExpenseUseCase:   expenseUseCase,
-		DashboardUseCase: dashboardUseCase,
+		AuthUseCase:          authUseCase,
+		ProfileUseCase:       profileUseCase,
+		PasswordResetUseCase: passwordResetUseCase,
+		VerificationUseCase:  verificationUseCase,
+		TwoFactorUseCase:     twoFactorUseCase,
+		PasskeyUseCase:       passkeyUseCase,
+		SessionUseCase:       sessionUseCase,
+		InviteUseCase:        inviteUseCase,
+		IncomeUseCase:        incomeUseCase,
+		GroupUseCase:         groupUseCase,
+		CategoryUseCase:      categoryUseCase,
+		ExpenseUseCase:       expenseUseCase,
+		DashboardUseCase:     dashboardUseCase,
+		PlanUseCase:          planUseCase,
+		ClosingUseCase:       closingUseCase,
+		BudgetUseCase:        budgetUseCase,
+		GoalUseCase:          goalUseCase,
+		AccountUseCase:       accountUseCase,
+		LoanUseCase:          loanUseCase,
+		NetWorthUseCase:      netWorthUseCase,
+		AlertUseCase:         alertUseCase,
 	}
 }
@@ -0,0 +1,8 @@
+-- +goose Up
+ALTER TABLE categories ADD COLUMN display_order INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_categories_display_order ON categories(display_order);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_categories_display_order;
+ALTER TABLE categories DROP COLUMN display_order;
@@ -0,0 +1,12 @@
+-- +goose Up
+ALTER TABLE groups ADD COLUMN archived INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE categories ADD COLUMN archived INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_groups_archived ON groups(archived);
+CREATE INDEX idx_categories_archived ON categories(archived);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_categories_archived;
+DROP INDEX IF EXISTS idx_groups_archived;
+ALTER TABLE categories DROP COLUMN archived;
+ALTER TABLE groups DROP COLUMN archived;
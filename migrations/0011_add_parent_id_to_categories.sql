@@ -0,0 +1,8 @@
+-- +goose Up
+ALTER TABLE categories ADD COLUMN parent_id TEXT;
+
+CREATE INDEX idx_categories_parent_id ON categories(parent_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_categories_parent_id;
+ALTER TABLE categories DROP COLUMN parent_id;
@@ -0,0 +1,23 @@
+-- +goose Up
+CREATE TABLE month_closes
+(
+    id             TEXT PRIMARY KEY,
+    user_id        TEXT     NOT NULL,
+    month          TEXT     NOT NULL,
+    total_income   INTEGER  NOT NULL,
+    total_expenses INTEGER  NOT NULL,
+    total_budgeted INTEGER  NOT NULL,
+    paid_expenses  INTEGER  NOT NULL,
+    unpaid_count   INTEGER  NOT NULL DEFAULT 0,
+    snapshot       TEXT     NOT NULL,
+    closed_at      DATETIME NOT NULL,
+    created_at     DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    UNIQUE (user_id, month)
+);
+
+CREATE INDEX idx_month_closes_user_id ON month_closes(user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_month_closes_user_id;
+DROP TABLE IF EXISTS month_closes;
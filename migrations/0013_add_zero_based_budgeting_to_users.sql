@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE users ADD COLUMN zero_based_budgeting INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN zero_based_budgeting;
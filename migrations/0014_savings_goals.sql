@@ -0,0 +1,32 @@
+-- +goose Up
+CREATE TABLE savings_goals
+(
+    id          TEXT PRIMARY KEY,
+    user_id     TEXT         NOT NULL,
+    name        VARCHAR(100) NOT NULL,
+    target      INTEGER      NOT NULL,
+    start_month TEXT         NOT NULL,
+    target_date DATETIME     NOT NULL,
+    created_at  DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_savings_goals_user_id ON savings_goals(user_id);
+
+CREATE TABLE goal_contributions
+(
+    id         TEXT PRIMARY KEY,
+    goal_id    TEXT     NOT NULL,
+    month      TEXT     NOT NULL,
+    amount     INTEGER  NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (goal_id) REFERENCES savings_goals(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_goal_contributions_goal_id ON goal_contributions(goal_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_goal_contributions_goal_id;
+DROP TABLE IF EXISTS goal_contributions;
+DROP INDEX IF EXISTS idx_savings_goals_user_id;
+DROP TABLE IF EXISTS savings_goals;
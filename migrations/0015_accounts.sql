@@ -0,0 +1,68 @@
+-- +goose Up
+CREATE TABLE accounts
+(
+    id              TEXT PRIMARY KEY,
+    user_id         TEXT         NOT NULL,
+    name            VARCHAR(100) NOT NULL,
+    type            TEXT         NOT NULL,
+    opening_balance INTEGER      NOT NULL DEFAULT 0,
+    created_at      DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_accounts_user_id ON accounts(user_id);
+
+CREATE TABLE account_transfers
+(
+    id              TEXT PRIMARY KEY,
+    user_id         TEXT     NOT NULL,
+    from_account_id TEXT     NOT NULL,
+    to_account_id   TEXT     NOT NULL,
+    amount          INTEGER  NOT NULL,
+    transfer_date   DATETIME NOT NULL,
+    created_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (from_account_id) REFERENCES accounts(id) ON DELETE CASCADE,
+    FOREIGN KEY (to_account_id) REFERENCES accounts(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_account_transfers_from_account_id ON account_transfers(from_account_id);
+CREATE INDEX idx_account_transfers_to_account_id ON account_transfers(to_account_id);
+
+CREATE TABLE account_reconciliations
+(
+    id                TEXT PRIMARY KEY,
+    account_id        TEXT     NOT NULL,
+    statement_date    DATETIME NOT NULL,
+    statement_balance INTEGER  NOT NULL,
+    created_at        DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE
+);
+
+CREATE TABLE account_reconciled_entries
+(
+    account_id TEXT NOT NULL,
+    kind       TEXT NOT NULL,
+    entry_id   TEXT NOT NULL,
+    PRIMARY KEY (account_id, kind, entry_id),
+    FOREIGN KEY (account_id) REFERENCES accounts(id) ON DELETE CASCADE
+);
+
+ALTER TABLE incomes ADD COLUMN account_id TEXT REFERENCES accounts(id) ON DELETE SET NULL;
+ALTER TABLE expenses ADD COLUMN account_id TEXT REFERENCES accounts(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_incomes_account_id ON incomes(account_id);
+CREATE INDEX idx_expenses_account_id ON expenses(account_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_expenses_account_id;
+DROP INDEX IF EXISTS idx_incomes_account_id;
+ALTER TABLE expenses DROP COLUMN account_id;
+ALTER TABLE incomes DROP COLUMN account_id;
+DROP TABLE IF EXISTS account_reconciled_entries;
+DROP TABLE IF EXISTS account_reconciliations;
+DROP INDEX IF EXISTS idx_account_transfers_to_account_id;
+DROP INDEX IF EXISTS idx_account_transfers_from_account_id;
+DROP TABLE IF EXISTS account_transfers;
+DROP INDEX IF EXISTS idx_accounts_user_id;
+DROP TABLE IF EXISTS accounts;
@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE loans
+(
+    id          TEXT PRIMARY KEY,
+    user_id     TEXT         NOT NULL,
+    name        VARCHAR(100) NOT NULL,
+    principal   INTEGER      NOT NULL,
+    rate        INTEGER      NOT NULL,
+    term_months INTEGER      NOT NULL,
+    start_month TEXT         NOT NULL,
+    category_id TEXT,
+    created_at  DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_loans_user_id ON loans(user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_loans_user_id;
+DROP TABLE IF EXISTS loans;
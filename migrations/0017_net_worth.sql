@@ -0,0 +1,27 @@
+-- +goose Up
+CREATE TABLE assets
+(
+    id         TEXT PRIMARY KEY,
+    user_id    TEXT         NOT NULL,
+    name       VARCHAR(100) NOT NULL,
+    kind       TEXT         NOT NULL,
+    created_at DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_assets_user_id ON assets(user_id);
+
+CREATE TABLE asset_valuations
+(
+    asset_id   TEXT     NOT NULL,
+    month      TEXT     NOT NULL,
+    value      INTEGER  NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (asset_id, month),
+    FOREIGN KEY (asset_id) REFERENCES assets(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS asset_valuations;
+DROP INDEX IF EXISTS idx_assets_user_id;
+DROP TABLE IF EXISTS assets;
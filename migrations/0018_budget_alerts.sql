@@ -0,0 +1,31 @@
+-- +goose Up
+CREATE TABLE category_alert_thresholds
+(
+    category_id TEXT    NOT NULL,
+    percent     INTEGER NOT NULL,
+    PRIMARY KEY (category_id, percent),
+    FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE CASCADE
+);
+
+CREATE TABLE notifications
+(
+    id          TEXT PRIMARY KEY,
+    user_id     TEXT     NOT NULL,
+    category_id TEXT     NOT NULL,
+    month       TEXT     NOT NULL,
+    threshold   INTEGER  NOT NULL,
+    spent       INTEGER  NOT NULL,
+    budget      INTEGER  NOT NULL,
+    created_at  DATETIME NOT NULL,
+    read_at     DATETIME,
+    UNIQUE (category_id, month, threshold),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_notifications_user_id ON notifications(user_id, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_notifications_user_id;
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS category_alert_thresholds;
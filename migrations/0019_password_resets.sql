@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE password_resets
+(
+    id         TEXT PRIMARY KEY,
+    user_id    TEXT     NOT NULL,
+    token_hash TEXT     NOT NULL UNIQUE,
+    expires_at DATETIME NOT NULL,
+    used_at    DATETIME,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_password_resets_user_id ON password_resets(user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_password_resets_user_id;
+DROP TABLE IF EXISTS password_resets;
@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE two_factor
+(
+    user_id        TEXT PRIMARY KEY,
+    secret         TEXT    NOT NULL,
+    confirmed_at   DATETIME,
+    last_used_step INTEGER NOT NULL DEFAULT 0,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE recovery_codes
+(
+    user_id   TEXT NOT NULL,
+    code_hash TEXT NOT NULL,
+    used_at   DATETIME,
+    PRIMARY KEY (user_id, code_hash),
+    FOREIGN KEY (user_id) REFERENCES two_factor(user_id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS recovery_codes;
+DROP TABLE IF EXISTS two_factor;
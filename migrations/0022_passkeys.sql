@@ -0,0 +1,19 @@
+-- +goose Up
+CREATE TABLE passkeys
+(
+    id            TEXT PRIMARY KEY,
+    user_id       TEXT     NOT NULL,
+    name          TEXT     NOT NULL,
+    credential_id BLOB     NOT NULL UNIQUE,
+    public_key    BLOB     NOT NULL,
+    sign_count    INTEGER  NOT NULL DEFAULT 0,
+    created_at    DATETIME NOT NULL,
+    last_used_at  DATETIME,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_passkeys_user_id ON passkeys(user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_passkeys_user_id;
+DROP TABLE IF EXISTS passkeys;
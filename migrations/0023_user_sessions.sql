@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE user_sessions
+(
+    id           TEXT PRIMARY KEY,
+    user_id      TEXT     NOT NULL,
+    ip_address   TEXT     NOT NULL,
+    user_agent   TEXT     NOT NULL,
+    created_at   DATETIME NOT NULL,
+    last_seen_at DATETIME NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_user_sessions_user_id ON user_sessions(user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_user_sessions_user_id;
+DROP TABLE IF EXISTS user_sessions;
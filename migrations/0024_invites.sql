@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE invites
+(
+    id         TEXT PRIMARY KEY,
+    code_hash  TEXT     NOT NULL UNIQUE,
+    max_uses   INTEGER  NOT NULL,
+    uses       INTEGER  NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS invites;
@@ -84,4 +84,77 @@ document.addEventListener('alpine:init', () => {
             }
         }
     }));
+
+    // Drag-and-drop ordering for the direct children marked with data-sort-id.
+    // Dragging starts from a data-sort-handle; the new order is posted to url.
+    Alpine.data('sortable', (url) => ({
+        dragged: null,
+        initialOrder: [],
+
+        init() {
+            this.$el.addEventListener('mousedown', (event) => {
+                const handle = event.target.closest('[data-sort-handle]');
+                const item = handle && handle.closest('[data-sort-id]');
+                if (item && item.parentElement === this.$el) {
+                    item.setAttribute('draggable', 'true');
+                }
+            });
+
+            this.$el.addEventListener('dragstart', (event) => {
+                const item = event.target.closest('[data-sort-id]');
+                if (!item || item.parentElement !== this.$el) return;
+
+                event.stopPropagation();
+                event.dataTransfer.effectAllowed = 'move';
+                this.dragged = item;
+                this.initialOrder = this.ids();
+                item.classList.add('opacity-50');
+            });
+
+            this.$el.addEventListener('dragover', (event) => {
+                if (!this.dragged) return;
+
+                event.preventDefault();
+                event.stopPropagation();
+
+                const target = event.target.closest('[data-sort-id]');
+                if (!target || target === this.dragged || target.parentElement !== this.$el) return;
+
+                const items = this.items();
+                if (items.indexOf(this.dragged) < items.indexOf(target)) {
+                    target.after(this.dragged);
+                } else {
+                    target.before(this.dragged);
+                }
+            });
+
+            this.$el.addEventListener('drop', (event) => {
+                if (!this.dragged) return;
+                event.preventDefault();
+                event.stopPropagation();
+            });
+
+            this.$el.addEventListener('dragend', (event) => {
+                if (!this.dragged) return;
+
+                event.stopPropagation();
+                this.dragged.classList.remove('opacity-50');
+                this.dragged.removeAttribute('draggable');
+                this.dragged = null;
+
+                const ids = this.ids();
+                if (ids.join(',') !== this.initialOrder.join(',')) {
+                    htmx.ajax('POST', url, { values: { ids: ids }, swap: 'none' });
+                }
+            });
+        },
+
+        items() {
+            return Array.from(this.$el.children).filter((el) => el.dataset.sortId);
+        },
+
+        ids() {
+            return this.items().map((el) => el.dataset.sortId);
+        }
+    }));
 });
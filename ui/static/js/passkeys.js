@@ -0,0 +1,103 @@
+// Passkey ceremonies. A form with data-passkey="register" or "login" fetches
+// its options from data-passkey-options, asks the browser for a passkey, puts
+// the result in its "credential" field and fires "passkey:ready", which htmx
+// posts the form on.
+(() => {
+    const toBytes = (value) => {
+        const base64 = value.replace(/-/g, '+').replace(/_/g, '/');
+        const binary = atob(base64.padEnd(base64.length + (4 - base64.length % 4) % 4, '='));
+        return Uint8Array.from(binary, (c) => c.charCodeAt(0));
+    };
+
+    const toBase64URL = (buffer) => {
+        if (!buffer) {
+            return null;
+        }
+        const binary = String.fromCharCode(...new Uint8Array(buffer));
+        return btoa(binary).replace(/\+/g, '-').replace(/\//g, '_').replace(/=+$/, '');
+    };
+
+    const withCredentialIDs = (credentials) =>
+        (credentials || []).map((c) => ({...c, id: toBytes(c.id)}));
+
+    const register = async (options) => {
+        const credential = await navigator.credentials.create({
+            publicKey: {
+                ...options,
+                challenge: toBytes(options.challenge),
+                user: {...options.user, id: toBytes(options.user.id)},
+                excludeCredentials: withCredentialIDs(options.excludeCredentials),
+            },
+        });
+        return {
+            id: credential.id,
+            rawId: toBase64URL(credential.rawId),
+            type: credential.type,
+            response: {
+                clientDataJSON: toBase64URL(credential.response.clientDataJSON),
+                attestationObject: toBase64URL(credential.response.attestationObject),
+            },
+        };
+    };
+
+    const login = async (options) => {
+        const credential = await navigator.credentials.get({
+            publicKey: {
+                ...options,
+                challenge: toBytes(options.challenge),
+                allowCredentials: withCredentialIDs(options.allowCredentials),
+            },
+        });
+        return {
+            id: credential.id,
+            rawId: toBase64URL(credential.rawId),
+            type: credential.type,
+            response: {
+                clientDataJSON: toBase64URL(credential.response.clientDataJSON),
+                authenticatorData: toBase64URL(credential.response.authenticatorData),
+                signature: toBase64URL(credential.response.signature),
+                userHandle: toBase64URL(credential.response.userHandle),
+            },
+        };
+    };
+
+    const ceremonies = {register, login};
+
+    document.addEventListener('submit', async (event) => {
+        const form = event.target.closest('form[data-passkey]');
+        if (!form) {
+            return;
+        }
+        event.preventDefault();
+
+        const error = form.querySelector('[data-passkey-error]');
+        error.textContent = '';
+
+        if (!window.PublicKeyCredential) {
+            error.textContent = 'This browser does not support passkeys.';
+            return;
+        }
+
+        try {
+            // The current page is where to come back to when the password
+            // is asked for again first.
+            const response = await fetch(form.dataset.passkeyOptions, {
+                headers: {'Accept': 'application/json', 'HX-Current-URL': window.location.href},
+            });
+            if (response.redirected) {
+                window.location.href = response.url;
+                return;
+            }
+            if (!response.ok) {
+                throw new Error('options request failed');
+            }
+            const credential = await ceremonies[form.dataset.passkey](await response.json());
+            form.querySelector('[name="credential"]').value = JSON.stringify(credential);
+            htmx.trigger(form, 'passkey:ready');
+        } catch (e) {
+            error.textContent = e.name === 'NotAllowedError'
+                ? 'The passkey request was cancelled or timed out.'
+                : 'The passkey could not be used. Please try again.';
+        }
+    });
+})();